@@ -15,6 +15,7 @@ type Access interface {
 	IsAdmin() bool
 	IsSystem() bool
 	TeamNames() []string
+	TeamRoles() map[string][]string
 	CSRFToken() string
 	UserName() string
 }
@@ -140,9 +141,13 @@ func (a *access) UserName() string {
 var requiredRoles = map[string]string{
 	atc.SaveConfig:                    "member",
 	atc.GetConfig:                     "viewer",
+	atc.ListConfigVersions:            "viewer",
+	atc.GetConfigVersion:              "viewer",
+	atc.DiffConfigVersions:            "viewer",
 	atc.GetCC:                         "viewer",
 	atc.GetBuild:                      "viewer",
 	atc.GetBuildPlan:                  "viewer",
+	atc.GetBuildTimeline:              "viewer",
 	atc.CreateBuild:                   "member",
 	atc.ListBuilds:                    "viewer",
 	atc.BuildEvents:                   "viewer",
@@ -221,4 +226,23 @@ var requiredRoles = map[string]string{
 	atc.CreateArtifact:                "member",
 	atc.GetArtifact:                   "member",
 	atc.ListBuildArtifacts:            "viewer",
+	atc.UnquarantineWorker:            "member",
+	atc.CreateAPIToken:                "viewer",
+	atc.ListAPITokens:                 "viewer",
+	atc.RevokeAPIToken:                "viewer",
+	atc.ListTeamWorkerKeys:            "owner",
+	atc.CreateTeamWorkerKey:           "owner",
+	atc.DeleteTeamWorkerKey:           "owner",
+	atc.ListLocalUsers:                "owner",
+	atc.CreateLocalUser:               "owner",
+	atc.SetLocalUserPassword:          "owner",
+	atc.DisableLocalUser:              "owner",
+	atc.EnableLocalUser:               "owner",
+	atc.ListAuditEvents:               "owner",
+	atc.ListTeamAuditEvents:           "owner",
+	atc.StatusEvents:                  "viewer",
+	atc.ListNamedLocks:                "viewer",
+	atc.ReleaseNamedLock:              "owner",
+	atc.ListHijackSessions:            "owner",
+	atc.GetHijackSessionRecording:     "owner",
 }
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/concourse/concourse/atc/db"
 	jwt "github.com/dgrijalva/jwt-go"
 )
 
@@ -16,12 +17,14 @@ type AccessFactory interface {
 }
 
 type accessFactory struct {
-	publicKey *rsa.PublicKey
+	publicKey       *rsa.PublicKey
+	apiTokenFactory db.APITokenFactory
 }
 
-func NewAccessFactory(key *rsa.PublicKey) AccessFactory {
+func NewAccessFactory(key *rsa.PublicKey, apiTokenFactory db.APITokenFactory) AccessFactory {
 	return &accessFactory{
-		publicKey: key,
+		publicKey:       key,
+		apiTokenFactory: apiTokenFactory,
 	}
 }
 
@@ -36,6 +39,10 @@ func (a *accessFactory) Create(r *http.Request, action string) Access {
 		return &access{&jwt.Token{}, action}
 	}
 
+	if strings.HasPrefix(header[7:], db.APITokenPrefix) {
+		return &access{a.apiToken(header[7:]), action}
+	}
+
 	token, err := jwt.Parse(header[7:], a.validate)
 	if err != nil {
 		return &access{&jwt.Token{}, action}
@@ -52,3 +59,25 @@ func (a *accessFactory) validate(token *jwt.Token) (interface{}, error) {
 
 	return a.publicKey, nil
 }
+
+// apiToken resolves a long-lived API token into a token carrying the same
+// claims a JWT would, limited to the roles the API token was granted.
+func (a *accessFactory) apiToken(value string) *jwt.Token {
+	apiToken, found, err := a.apiTokenFactory.FindByToken(value)
+	if err != nil || !found {
+		return &jwt.Token{}
+	}
+
+	return &jwt.Token{
+		Valid: true,
+		Claims: jwt.MapClaims{
+			"sub":       apiToken.Owner,
+			"user_name": apiToken.Owner,
+			"api_token": apiToken.Name,
+			"teams": map[string][]string{
+				apiToken.TeamName: apiToken.Roles,
+			},
+			"exp": apiToken.ExpiresAt.Unix(),
+		},
+	}
+}
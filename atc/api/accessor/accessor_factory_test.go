@@ -4,9 +4,13 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"fmt"
+	"errors"
 	"net/http"
+	"time"
 
 	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
 	jwt "github.com/dgrijalva/jwt-go"
 
 	. "github.com/onsi/ginkgo"
@@ -18,6 +22,7 @@ var _ = Describe("AccessorFactory", func() {
 	var access accessor.Access
 	var key *rsa.PrivateKey
 	var req *http.Request
+	var fakeAPITokenFactory *dbfakes.FakeAPITokenFactory
 
 	Describe("Create", func() {
 		BeforeEach(func() {
@@ -29,7 +34,8 @@ var _ = Describe("AccessorFactory", func() {
 
 			publicKey := &key.PublicKey
 			//publicKey = rsa.GenerateKey(random, bits)
-			accessorFactory = accessor.NewAccessFactory(publicKey)
+			fakeAPITokenFactory = new(dbfakes.FakeAPITokenFactory)
+			accessorFactory = accessor.NewAccessFactory(publicKey, fakeAPITokenFactory)
 
 			req, err = http.NewRequest("GET", "localhost:8080", nil)
 			Expect(err).NotTo(HaveOccurred())
@@ -84,5 +90,56 @@ var _ = Describe("AccessorFactory", func() {
 				Expect(access).ToNot(BeNil())
 			})
 		})
+
+		Context("when request has an api token set", func() {
+			BeforeEach(func() {
+				req.Header.Add("Authorization", "Bearer "+db.APITokenPrefix+"some-token")
+			})
+
+			Context("when the token is found", func() {
+				BeforeEach(func() {
+					fakeAPITokenFactory.FindByTokenReturns(db.APIToken{
+						Name:      "some-token",
+						TeamName:  "some-team",
+						Owner:     "some-service-account",
+						Roles:     []string{"viewer"},
+						ExpiresAt: time.Now().Add(time.Hour),
+					}, true, nil)
+				})
+
+				It("looks up the token", func() {
+					Expect(fakeAPITokenFactory.FindByTokenCallCount()).To(Equal(1))
+					Expect(fakeAPITokenFactory.FindByTokenArgsForCall(0)).To(Equal(db.APITokenPrefix + "some-token"))
+				})
+
+				It("authenticates as the token owner with the token's roles", func() {
+					Expect(access.IsAuthenticated()).To(BeTrue())
+					Expect(access.UserName()).To(Equal("some-service-account"))
+					Expect(access.TeamRoles()).To(Equal(map[string][]string{"some-team": {"viewer"}}))
+					Expect(access.IsAdmin()).To(BeFalse())
+				})
+			})
+
+			Context("when the token is not found", func() {
+				BeforeEach(func() {
+					fakeAPITokenFactory.FindByTokenReturns(db.APIToken{}, false, nil)
+				})
+
+				It("is not authenticated", func() {
+					Expect(access.HasToken()).To(BeTrue())
+					Expect(access.IsAuthenticated()).To(BeFalse())
+				})
+			})
+
+			Context("when looking up the token fails", func() {
+				BeforeEach(func() {
+					fakeAPITokenFactory.FindByTokenReturns(db.APIToken{}, false, errors.New("nope"))
+				})
+
+				It("is not authenticated", func() {
+					Expect(access.IsAuthenticated()).To(BeFalse())
+				})
+			})
+		})
 	})
 })
@@ -8,6 +8,7 @@ import (
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db/dbfakes"
 	jwt "github.com/dgrijalva/jwt-go"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
@@ -35,7 +36,7 @@ var _ = Describe("Accessor", func() {
 		Expect(err).NotTo(HaveOccurred())
 
 		publicKey := &key.PublicKey
-		accessorFactory = accessor.NewAccessFactory(publicKey)
+		accessorFactory = accessor.NewAccessFactory(publicKey, new(dbfakes.FakeAPITokenFactory))
 
 	})
 
@@ -462,6 +463,21 @@ var _ = Describe("Accessor", func() {
 		Entry("pipeline-operator :: "+atc.GetConfig, atc.GetConfig, "pipeline-operator", true),
 		Entry("viewer :: "+atc.GetConfig, atc.GetConfig, "viewer", true),
 
+		Entry("owner :: "+atc.ListConfigVersions, atc.ListConfigVersions, "owner", true),
+		Entry("member :: "+atc.ListConfigVersions, atc.ListConfigVersions, "member", true),
+		Entry("pipeline-operator :: "+atc.ListConfigVersions, atc.ListConfigVersions, "pipeline-operator", true),
+		Entry("viewer :: "+atc.ListConfigVersions, atc.ListConfigVersions, "viewer", true),
+
+		Entry("owner :: "+atc.GetConfigVersion, atc.GetConfigVersion, "owner", true),
+		Entry("member :: "+atc.GetConfigVersion, atc.GetConfigVersion, "member", true),
+		Entry("pipeline-operator :: "+atc.GetConfigVersion, atc.GetConfigVersion, "pipeline-operator", true),
+		Entry("viewer :: "+atc.GetConfigVersion, atc.GetConfigVersion, "viewer", true),
+
+		Entry("owner :: "+atc.DiffConfigVersions, atc.DiffConfigVersions, "owner", true),
+		Entry("member :: "+atc.DiffConfigVersions, atc.DiffConfigVersions, "member", true),
+		Entry("pipeline-operator :: "+atc.DiffConfigVersions, atc.DiffConfigVersions, "pipeline-operator", true),
+		Entry("viewer :: "+atc.DiffConfigVersions, atc.DiffConfigVersions, "viewer", true),
+
 		Entry("owner :: "+atc.GetCC, atc.GetCC, "owner", true),
 		Entry("member :: "+atc.GetCC, atc.GetCC, "member", true),
 		Entry("pipeline-operator :: "+atc.GetCC, atc.GetCC, "pipeline-operator", true),
@@ -477,6 +493,11 @@ var _ = Describe("Accessor", func() {
 		Entry("pipeline-operator :: "+atc.GetBuildPlan, atc.GetBuildPlan, "pipeline-operator", true),
 		Entry("viewer :: "+atc.GetBuildPlan, atc.GetBuildPlan, "viewer", true),
 
+		Entry("owner :: "+atc.GetBuildTimeline, atc.GetBuildTimeline, "owner", true),
+		Entry("member :: "+atc.GetBuildTimeline, atc.GetBuildTimeline, "member", true),
+		Entry("pipeline-operator :: "+atc.GetBuildTimeline, atc.GetBuildTimeline, "pipeline-operator", true),
+		Entry("viewer :: "+atc.GetBuildTimeline, atc.GetBuildTimeline, "viewer", true),
+
 		Entry("owner :: "+atc.CreateBuild, atc.CreateBuild, "owner", true),
 		Entry("member :: "+atc.CreateBuild, atc.CreateBuild, "member", true),
 		Entry("pipeline-operator :: "+atc.CreateBuild, atc.CreateBuild, "pipeline-operator", false),
@@ -850,5 +871,100 @@ var _ = Describe("Accessor", func() {
 		Entry("member :: "+atc.ListBuildArtifacts, atc.ListBuildArtifacts, "member", true),
 		Entry("pipeline-operator :: "+atc.ListBuildArtifacts, atc.ListBuildArtifacts, "pipeline-operator", true),
 		Entry("viewer :: "+atc.ListBuildArtifacts, atc.ListBuildArtifacts, "viewer", true),
+
+		Entry("owner :: "+atc.UnquarantineWorker, atc.UnquarantineWorker, "owner", true),
+		Entry("member :: "+atc.UnquarantineWorker, atc.UnquarantineWorker, "member", true),
+		Entry("pipeline-operator :: "+atc.UnquarantineWorker, atc.UnquarantineWorker, "pipeline-operator", false),
+		Entry("viewer :: "+atc.UnquarantineWorker, atc.UnquarantineWorker, "viewer", false),
+
+		Entry("owner :: "+atc.CreateAPIToken, atc.CreateAPIToken, "owner", true),
+		Entry("member :: "+atc.CreateAPIToken, atc.CreateAPIToken, "member", true),
+		Entry("pipeline-operator :: "+atc.CreateAPIToken, atc.CreateAPIToken, "pipeline-operator", true),
+		Entry("viewer :: "+atc.CreateAPIToken, atc.CreateAPIToken, "viewer", true),
+
+		Entry("owner :: "+atc.ListAPITokens, atc.ListAPITokens, "owner", true),
+		Entry("member :: "+atc.ListAPITokens, atc.ListAPITokens, "member", true),
+		Entry("pipeline-operator :: "+atc.ListAPITokens, atc.ListAPITokens, "pipeline-operator", true),
+		Entry("viewer :: "+atc.ListAPITokens, atc.ListAPITokens, "viewer", true),
+
+		Entry("owner :: "+atc.RevokeAPIToken, atc.RevokeAPIToken, "owner", true),
+		Entry("member :: "+atc.RevokeAPIToken, atc.RevokeAPIToken, "member", true),
+		Entry("pipeline-operator :: "+atc.RevokeAPIToken, atc.RevokeAPIToken, "pipeline-operator", true),
+		Entry("viewer :: "+atc.RevokeAPIToken, atc.RevokeAPIToken, "viewer", true),
+
+		Entry("owner :: "+atc.ListLocalUsers, atc.ListLocalUsers, "owner", true),
+		Entry("member :: "+atc.ListLocalUsers, atc.ListLocalUsers, "member", false),
+		Entry("pipeline-operator :: "+atc.ListLocalUsers, atc.ListLocalUsers, "pipeline-operator", false),
+		Entry("viewer :: "+atc.ListLocalUsers, atc.ListLocalUsers, "viewer", false),
+
+		Entry("owner :: "+atc.CreateLocalUser, atc.CreateLocalUser, "owner", true),
+		Entry("member :: "+atc.CreateLocalUser, atc.CreateLocalUser, "member", false),
+		Entry("pipeline-operator :: "+atc.CreateLocalUser, atc.CreateLocalUser, "pipeline-operator", false),
+		Entry("viewer :: "+atc.CreateLocalUser, atc.CreateLocalUser, "viewer", false),
+
+		Entry("owner :: "+atc.SetLocalUserPassword, atc.SetLocalUserPassword, "owner", true),
+		Entry("member :: "+atc.SetLocalUserPassword, atc.SetLocalUserPassword, "member", false),
+		Entry("pipeline-operator :: "+atc.SetLocalUserPassword, atc.SetLocalUserPassword, "pipeline-operator", false),
+		Entry("viewer :: "+atc.SetLocalUserPassword, atc.SetLocalUserPassword, "viewer", false),
+
+		Entry("owner :: "+atc.DisableLocalUser, atc.DisableLocalUser, "owner", true),
+		Entry("member :: "+atc.DisableLocalUser, atc.DisableLocalUser, "member", false),
+		Entry("pipeline-operator :: "+atc.DisableLocalUser, atc.DisableLocalUser, "pipeline-operator", false),
+		Entry("viewer :: "+atc.DisableLocalUser, atc.DisableLocalUser, "viewer", false),
+
+		Entry("owner :: "+atc.EnableLocalUser, atc.EnableLocalUser, "owner", true),
+		Entry("member :: "+atc.EnableLocalUser, atc.EnableLocalUser, "member", false),
+		Entry("pipeline-operator :: "+atc.EnableLocalUser, atc.EnableLocalUser, "pipeline-operator", false),
+		Entry("viewer :: "+atc.EnableLocalUser, atc.EnableLocalUser, "viewer", false),
+
+		Entry("owner :: "+atc.ListAuditEvents, atc.ListAuditEvents, "owner", true),
+		Entry("member :: "+atc.ListAuditEvents, atc.ListAuditEvents, "member", false),
+		Entry("pipeline-operator :: "+atc.ListAuditEvents, atc.ListAuditEvents, "pipeline-operator", false),
+		Entry("viewer :: "+atc.ListAuditEvents, atc.ListAuditEvents, "viewer", false),
+
+		Entry("owner :: "+atc.ListTeamAuditEvents, atc.ListTeamAuditEvents, "owner", true),
+		Entry("member :: "+atc.ListTeamAuditEvents, atc.ListTeamAuditEvents, "member", false),
+		Entry("pipeline-operator :: "+atc.ListTeamAuditEvents, atc.ListTeamAuditEvents, "pipeline-operator", false),
+		Entry("viewer :: "+atc.ListTeamAuditEvents, atc.ListTeamAuditEvents, "viewer", false),
+
+		Entry("owner :: "+atc.ListTeamWorkerKeys, atc.ListTeamWorkerKeys, "owner", true),
+		Entry("member :: "+atc.ListTeamWorkerKeys, atc.ListTeamWorkerKeys, "member", false),
+		Entry("pipeline-operator :: "+atc.ListTeamWorkerKeys, atc.ListTeamWorkerKeys, "pipeline-operator", false),
+		Entry("viewer :: "+atc.ListTeamWorkerKeys, atc.ListTeamWorkerKeys, "viewer", false),
+
+		Entry("owner :: "+atc.CreateTeamWorkerKey, atc.CreateTeamWorkerKey, "owner", true),
+		Entry("member :: "+atc.CreateTeamWorkerKey, atc.CreateTeamWorkerKey, "member", false),
+		Entry("pipeline-operator :: "+atc.CreateTeamWorkerKey, atc.CreateTeamWorkerKey, "pipeline-operator", false),
+		Entry("viewer :: "+atc.CreateTeamWorkerKey, atc.CreateTeamWorkerKey, "viewer", false),
+
+		Entry("owner :: "+atc.DeleteTeamWorkerKey, atc.DeleteTeamWorkerKey, "owner", true),
+		Entry("member :: "+atc.DeleteTeamWorkerKey, atc.DeleteTeamWorkerKey, "member", false),
+		Entry("pipeline-operator :: "+atc.DeleteTeamWorkerKey, atc.DeleteTeamWorkerKey, "pipeline-operator", false),
+		Entry("viewer :: "+atc.DeleteTeamWorkerKey, atc.DeleteTeamWorkerKey, "viewer", false),
+
+		Entry("owner :: "+atc.StatusEvents, atc.StatusEvents, "owner", true),
+		Entry("member :: "+atc.StatusEvents, atc.StatusEvents, "member", true),
+		Entry("pipeline-operator :: "+atc.StatusEvents, atc.StatusEvents, "pipeline-operator", true),
+		Entry("viewer :: "+atc.StatusEvents, atc.StatusEvents, "viewer", true),
+
+		Entry("owner :: "+atc.ListNamedLocks, atc.ListNamedLocks, "owner", true),
+		Entry("member :: "+atc.ListNamedLocks, atc.ListNamedLocks, "member", true),
+		Entry("pipeline-operator :: "+atc.ListNamedLocks, atc.ListNamedLocks, "pipeline-operator", true),
+		Entry("viewer :: "+atc.ListNamedLocks, atc.ListNamedLocks, "viewer", true),
+
+		Entry("owner :: "+atc.ReleaseNamedLock, atc.ReleaseNamedLock, "owner", true),
+		Entry("member :: "+atc.ReleaseNamedLock, atc.ReleaseNamedLock, "member", false),
+		Entry("pipeline-operator :: "+atc.ReleaseNamedLock, atc.ReleaseNamedLock, "pipeline-operator", false),
+		Entry("viewer :: "+atc.ReleaseNamedLock, atc.ReleaseNamedLock, "viewer", false),
+
+		Entry("owner :: "+atc.ListHijackSessions, atc.ListHijackSessions, "owner", true),
+		Entry("member :: "+atc.ListHijackSessions, atc.ListHijackSessions, "member", false),
+		Entry("pipeline-operator :: "+atc.ListHijackSessions, atc.ListHijackSessions, "pipeline-operator", false),
+		Entry("viewer :: "+atc.ListHijackSessions, atc.ListHijackSessions, "viewer", false),
+
+		Entry("owner :: "+atc.GetHijackSessionRecording, atc.GetHijackSessionRecording, "owner", true),
+		Entry("member :: "+atc.GetHijackSessionRecording, atc.GetHijackSessionRecording, "member", false),
+		Entry("pipeline-operator :: "+atc.GetHijackSessionRecording, atc.GetHijackSessionRecording, "pipeline-operator", false),
+		Entry("viewer :: "+atc.GetHijackSessionRecording, atc.GetHijackSessionRecording, "viewer", false),
 	)
 })
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/concourse/concourse/atc/auditor"
+	"github.com/felixge/httpsnoop"
 )
 
 func NewHandler(
@@ -34,7 +35,10 @@ func (h accessorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx := context.WithValue(r.Context(), "accessor", acc)
 
 	h.auditor.Audit(h.action, acc.UserName(), r)
-	h.handler.ServeHTTP(w, r.WithContext(ctx))
+
+	metrics := httpsnoop.CaptureMetrics(h.handler, w, r.WithContext(ctx))
+
+	h.auditor.Record(h.action, acc.UserName(), r, metrics.Code)
 }
 
 func GetAccessor(r *http.Request) Access {
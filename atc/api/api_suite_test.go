@@ -10,6 +10,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api"
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
@@ -22,6 +23,8 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/gc/gcfakes"
+	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/atc/policy/policyfakes"
 	"github.com/concourse/concourse/atc/worker/workerfakes"
 	"github.com/concourse/concourse/atc/wrappa"
 	. "github.com/onsi/ginkgo"
@@ -42,6 +45,14 @@ var (
 	dbJobFactory            *dbfakes.FakeJobFactory
 	dbResourceFactory       *dbfakes.FakeResourceFactory
 	dbResourceConfigFactory *dbfakes.FakeResourceConfigFactory
+	dbAPITokenFactory       *dbfakes.FakeAPITokenFactory
+	dbLocalUserFactory      *dbfakes.FakeLocalUserFactory
+	dbAuditEventFactory     *dbfakes.FakeAuditEventFactory
+	dbWorkerKeyFactory      *dbfakes.FakeWorkerKeyFactory
+	dbStatusEventFactory    *dbfakes.FakeStatusEventFactory
+	dbNamedLockFactory      *dbfakes.FakeNamedLockFactory
+	dbHijackSessionFactory  *dbfakes.FakeHijackSessionFactory
+	fakePolicyChecker       *policyfakes.FakeChecker
 	fakePipeline            *dbfakes.FakePipeline
 	fakeAccess              *accessorfakes.FakeAccess
 	fakeAccessor            *accessorfakes.FakeAccessFactory
@@ -53,6 +64,7 @@ var (
 	fakeScannerFactory      *resourceserverfakes.FakeScannerFactory
 	fakeSecretManager       *credsfakes.FakeSecrets
 	credsManagers           creds.Managers
+	sourceDefaults          atc.SourceDefaults
 	interceptTimeoutFactory *containerserverfakes.FakeInterceptTimeoutFactory
 	interceptTimeout        *containerserverfakes.FakeInterceptTimeout
 	expire                  time.Duration
@@ -92,6 +104,15 @@ var _ = BeforeEach(func() {
 	dbJobFactory = new(dbfakes.FakeJobFactory)
 	dbResourceFactory = new(dbfakes.FakeResourceFactory)
 	dbResourceConfigFactory = new(dbfakes.FakeResourceConfigFactory)
+	dbAPITokenFactory = new(dbfakes.FakeAPITokenFactory)
+	dbLocalUserFactory = new(dbfakes.FakeLocalUserFactory)
+	dbAuditEventFactory = new(dbfakes.FakeAuditEventFactory)
+	dbWorkerKeyFactory = new(dbfakes.FakeWorkerKeyFactory)
+	dbStatusEventFactory = new(dbfakes.FakeStatusEventFactory)
+	dbNamedLockFactory = new(dbfakes.FakeNamedLockFactory)
+	dbHijackSessionFactory = new(dbfakes.FakeHijackSessionFactory)
+	fakePolicyChecker = new(policyfakes.FakeChecker)
+	fakePolicyChecker.CheckReturns(policy.Result{Decision: policy.DecisionAllow}, nil)
 	dbBuildFactory = new(dbfakes.FakeBuildFactory)
 
 	interceptTimeoutFactory = new(containerserverfakes.FakeInterceptTimeoutFactory)
@@ -123,6 +144,12 @@ var _ = BeforeEach(func() {
 
 	fakeSecretManager = new(credsfakes.FakeSecrets)
 	credsManagers = make(creds.Managers)
+
+	sourceDefaults = atc.SourceDefaults{
+		RegistryRewriteRules: []atc.RegistryRewriteRule{
+			{From: "docker.io", To: "mirror.example.com"},
+		},
+	}
 	var err error
 
 	cliDownloadsDir, err = ioutil.TempDir("", "cli-downloads")
@@ -170,6 +197,13 @@ var _ = BeforeEach(func() {
 		fakeDestroyer,
 		dbBuildFactory,
 		dbResourceConfigFactory,
+		dbAPITokenFactory,
+		dbLocalUserFactory,
+		dbAuditEventFactory,
+		dbWorkerKeyFactory,
+		dbStatusEventFactory,
+		dbNamedLockFactory,
+		dbHijackSessionFactory,
 
 		constructedEventHandler.Construct,
 
@@ -185,8 +219,12 @@ var _ = BeforeEach(func() {
 		"1.2.3",
 		"4.5.6",
 		fakeSecretManager,
+		sourceDefaults,
 		credsManagers,
 		interceptTimeoutFactory,
+		true,
+		fakePolicyChecker,
+		nil,
 	)
 
 	Expect(err).NotTo(HaveOccurred())
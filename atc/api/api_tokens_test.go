@@ -0,0 +1,361 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("API Tokens API", func() {
+	var response *http.Response
+
+	BeforeEach(func() {
+		dbTeam.NameReturns("some-team")
+		fakeAccess.IsAuthenticatedReturns(true)
+		fakeAccess.IsAuthorizedReturns(true)
+		fakeAccess.UserNameReturns("some-user")
+	})
+
+	Describe("POST /api/v1/teams/:team_name/tokens", func() {
+		var tokenRequest atc.APITokenRequest
+
+		BeforeEach(func() {
+			tokenRequest = atc.APITokenRequest{
+				Name:      "some-token",
+				Roles:     []string{"member"},
+				ExpiresAt: time.Now().Add(time.Hour).Unix(),
+			}
+
+			fakeAccess.TeamRolesReturns(map[string][]string{"some-team": {"member"}})
+		})
+
+		JustBeforeEach(func() {
+			payload, err := json.Marshal(tokenRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/tokens", bytes.NewBuffer(payload))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when creating the token succeeds", func() {
+			BeforeEach(func() {
+				dbAPITokenFactory.CreateTokenReturns(db.APIToken{
+					ID:        1,
+					Name:      "some-token",
+					TeamName:  "some-team",
+					Owner:     "some-user",
+					Roles:     []string{"member"},
+					CreatedAt: time.Unix(100, 0),
+					ExpiresAt: time.Unix(200, 0),
+				}, "concourse_some-secret", nil)
+			})
+
+			It("returns 201 with the plaintext token", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusCreated))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`{
+					"id": 1,
+					"name": "some-token",
+					"team_name": "some-team",
+					"owner": "some-user",
+					"roles": ["member"],
+					"created_at": 100,
+					"expires_at": 200,
+					"token": "concourse_some-secret"
+				}`))
+			})
+
+			It("creates the token for the requesting user in the team", func() {
+				Expect(dbAPITokenFactory.CreateTokenCallCount()).To(Equal(1))
+
+				teamID, spec := dbAPITokenFactory.CreateTokenArgsForCall(0)
+				Expect(teamID).To(Equal(734))
+				Expect(spec.Name).To(Equal("some-token"))
+				Expect(spec.Owner).To(Equal("some-user"))
+				Expect(spec.ServiceAccount).To(BeFalse())
+				Expect(spec.Roles).To(Equal([]string{"member"}))
+				Expect(spec.ExpiresAt.Unix()).To(Equal(tokenRequest.ExpiresAt))
+			})
+		})
+
+		Context("when requesting a role above the requester's own", func() {
+			BeforeEach(func() {
+				tokenRequest.Roles = []string{"owner"}
+			})
+
+			It("returns 403 without creating the token", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbAPITokenFactory.CreateTokenCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when creating a service account token", func() {
+			BeforeEach(func() {
+				tokenRequest.ServiceAccount = "some-service-account"
+			})
+
+			Context("when the requester is not a team owner", func() {
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(dbAPITokenFactory.CreateTokenCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the requester is a team owner", func() {
+				BeforeEach(func() {
+					fakeAccess.TeamRolesReturns(map[string][]string{"some-team": {"owner"}})
+				})
+
+				It("creates the token owned by the service account", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+					_, spec := dbAPITokenFactory.CreateTokenArgsForCall(0)
+					Expect(spec.Owner).To(Equal("some-service-account"))
+					Expect(spec.ServiceAccount).To(BeTrue())
+				})
+			})
+
+			Context("when the requester is an admin", func() {
+				BeforeEach(func() {
+					fakeAccess.TeamRolesReturns(map[string][]string{})
+					fakeAccess.IsAdminReturns(true)
+				})
+
+				It("creates the token", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusCreated))
+				})
+			})
+		})
+
+		Context("when the role is unknown", func() {
+			BeforeEach(func() {
+				tokenRequest.Roles = []string{"superuser"}
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(dbAPITokenFactory.CreateTokenCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the expiry is in the past", func() {
+			BeforeEach(func() {
+				tokenRequest.ExpiresAt = time.Now().Add(-time.Hour).Unix()
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("when a token with the same name exists", func() {
+			BeforeEach(func() {
+				dbAPITokenFactory.CreateTokenReturns(db.APIToken{}, "", db.ErrAPITokenAlreadyExists)
+			})
+
+			It("returns 409", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusConflict))
+			})
+		})
+
+		Context("when creating the token fails", func() {
+			BeforeEach(func() {
+				dbAPITokenFactory.CreateTokenReturns(db.APIToken{}, "", errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/tokens", func() {
+		BeforeEach(func() {
+			dbAPITokenFactory.TeamTokensReturns([]db.APIToken{
+				{
+					ID:        1,
+					Name:      "mine",
+					TeamName:  "some-team",
+					Owner:     "some-user",
+					Roles:     []string{"viewer"},
+					CreatedAt: time.Unix(100, 0),
+					ExpiresAt: time.Unix(200, 0),
+				},
+				{
+					ID:             2,
+					Name:           "ci",
+					TeamName:       "some-team",
+					Owner:          "some-service-account",
+					ServiceAccount: true,
+					Roles:          []string{"member"},
+					CreatedAt:      time.Unix(100, 0),
+					ExpiresAt:      time.Unix(200, 0),
+				},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/tokens")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the requester is a team owner", func() {
+			BeforeEach(func() {
+				fakeAccess.TeamRolesReturns(map[string][]string{"some-team": {"owner"}})
+			})
+
+			It("returns every token in the team", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				var tokens []atc.APIToken
+				err := json.NewDecoder(response.Body).Decode(&tokens)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(tokens).To(HaveLen(2))
+				Expect(tokens[0].Token).To(BeEmpty())
+			})
+		})
+
+		Context("when the requester is not a team owner", func() {
+			BeforeEach(func() {
+				fakeAccess.TeamRolesReturns(map[string][]string{"some-team": {"member"}})
+			})
+
+			It("returns only the requester's own tokens", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[{
+					"id": 1,
+					"name": "mine",
+					"team_name": "some-team",
+					"owner": "some-user",
+					"roles": ["viewer"],
+					"created_at": 100,
+					"expires_at": 200
+				}]`))
+			})
+		})
+
+		Context("when listing tokens fails", func() {
+			BeforeEach(func() {
+				dbAPITokenFactory.TeamTokensReturns(nil, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/tokens/:token_name", func() {
+		var tokenName string
+
+		BeforeEach(func() {
+			tokenName = "mine"
+
+			dbAPITokenFactory.TeamTokensReturns([]db.APIToken{
+				{Name: "mine", TeamName: "some-team", Owner: "some-user"},
+				{Name: "ci", TeamName: "some-team", Owner: "some-service-account", ServiceAccount: true},
+			}, nil)
+			dbAPITokenFactory.RevokeTokenReturns(true, nil)
+
+			fakeAccess.TeamRolesReturns(map[string][]string{"some-team": {"member"}})
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/some-team/tokens/"+tokenName, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("revokes the requester's own token", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+			Expect(dbAPITokenFactory.RevokeTokenCallCount()).To(Equal(1))
+			teamID, name := dbAPITokenFactory.RevokeTokenArgsForCall(0)
+			Expect(teamID).To(Equal(734))
+			Expect(name).To(Equal("mine"))
+		})
+
+		Context("when the token belongs to someone else", func() {
+			BeforeEach(func() {
+				tokenName = "ci"
+			})
+
+			It("returns 404 without revoking", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				Expect(dbAPITokenFactory.RevokeTokenCallCount()).To(BeZero())
+			})
+
+			Context("when the requester is a team owner", func() {
+				BeforeEach(func() {
+					fakeAccess.TeamRolesReturns(map[string][]string{"some-team": {"owner"}})
+				})
+
+				It("revokes the token", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				})
+			})
+		})
+
+		Context("when the token does not exist", func() {
+			BeforeEach(func() {
+				tokenName = "bogus"
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when revoking fails", func() {
+			BeforeEach(func() {
+				dbAPITokenFactory.RevokeTokenReturns(false, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+})
@@ -0,0 +1,115 @@
+package apitokenserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) CreateAPIToken(team db.Team) http.Handler {
+	hLog := s.logger.Session("create-api-token")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req atc.APITokenRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			hLog.Error("malformed-request", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = validate(req)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "%s", err.Error())
+			return
+		}
+
+		acc := accessor.GetAccessor(r)
+		rank := highestRank(acc, team.Name())
+
+		if req.ServiceAccount != "" && rank < roleRanks["owner"] {
+			hLog.Info("service-account-requires-owner", lager.Data{"user": acc.UserName()})
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		for _, role := range req.Roles {
+			if roleRanks[role] > rank {
+				hLog.Info("role-exceeds-requester", lager.Data{"user": acc.UserName(), "role": role})
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+		}
+
+		spec := db.APITokenSpec{
+			Name:      req.Name,
+			Owner:     acc.UserName(),
+			Roles:     req.Roles,
+			ExpiresAt: time.Unix(req.ExpiresAt, 0),
+		}
+
+		if req.ServiceAccount != "" {
+			spec.Owner = req.ServiceAccount
+			spec.ServiceAccount = true
+		}
+
+		token, plaintext, err := s.apiTokenFactory.CreateToken(team.ID(), spec)
+		if err == db.ErrAPITokenAlreadyExists {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		if err != nil {
+			hLog.Error("failed-to-create-api-token", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		hLog.Info("created", lager.Data{
+			"team":  team.Name(),
+			"name":  token.Name,
+			"owner": token.Owner,
+		})
+
+		presented := present.APIToken(token)
+		presented.Token = plaintext
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		err = json.NewEncoder(w).Encode(presented)
+		if err != nil {
+			hLog.Error("failed-to-encode-api-token", err)
+		}
+	})
+}
+
+func validate(req atc.APITokenRequest) error {
+	if req.Name == "" {
+		return fmt.Errorf("token name must be specified")
+	}
+
+	if len(req.Roles) == 0 {
+		return fmt.Errorf("at least one role must be specified")
+	}
+
+	for _, role := range req.Roles {
+		if _, ok := roleRanks[role]; !ok {
+			return fmt.Errorf("unknown role '%s'", role)
+		}
+	}
+
+	if req.ExpiresAt <= time.Now().Unix() {
+		return fmt.Errorf("expiry must be in the future")
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package apitokenserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListAPITokens(team db.Team) http.Handler {
+	hLog := s.logger.Session("list-api-tokens")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokens, err := s.apiTokenFactory.TeamTokens(team.ID())
+		if err != nil {
+			hLog.Error("failed-to-get-api-tokens", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		acc := accessor.GetAccessor(r)
+
+		presentedTokens := []atc.APIToken{}
+		for _, token := range tokens {
+			if canManage(acc, token) {
+				presentedTokens = append(presentedTokens, present.APIToken(token))
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(presentedTokens)
+		if err != nil {
+			hLog.Error("failed-to-encode-api-tokens", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
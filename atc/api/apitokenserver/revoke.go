@@ -0,0 +1,63 @@
+package apitokenserver
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) RevokeAPIToken(team db.Team) http.Handler {
+	hLog := s.logger.Session("revoke-api-token")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenName := r.FormValue(":token_name")
+
+		tokens, err := s.apiTokenFactory.TeamTokens(team.ID())
+		if err != nil {
+			hLog.Error("failed-to-get-api-tokens", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		acc := accessor.GetAccessor(r)
+
+		var token db.APIToken
+		var found bool
+		for _, t := range tokens {
+			if t.Name == tokenName {
+				token = t
+				found = true
+				break
+			}
+		}
+
+		// tokens the requester may not manage are reported as missing so
+		// their names don't leak
+		if !found || !canManage(acc, token) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		found, err = s.apiTokenFactory.RevokeToken(team.ID(), tokenName)
+		if err != nil {
+			hLog.Error("failed-to-revoke-api-token", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		hLog.Info("revoked", lager.Data{
+			"team":  team.Name(),
+			"name":  tokenName,
+			"owner": token.Owner,
+		})
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,57 @@
+package apitokenserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger          lager.Logger
+	apiTokenFactory db.APITokenFactory
+}
+
+func NewServer(
+	logger lager.Logger,
+	apiTokenFactory db.APITokenFactory,
+) *Server {
+	return &Server{
+		logger:          logger,
+		apiTokenFactory: apiTokenFactory,
+	}
+}
+
+// roleRanks orders team roles from least to most privileged.
+var roleRanks = map[string]int{
+	"viewer":            1,
+	"pipeline-operator": 2,
+	"member":            3,
+	"owner":             4,
+}
+
+// highestRank returns the rank of the most privileged role the requester
+// holds in the team. Admins are treated as owners of every team.
+func highestRank(acc accessor.Access, teamName string) int {
+	if acc.IsAdmin() {
+		return roleRanks["owner"]
+	}
+
+	highest := 0
+	for _, role := range acc.TeamRoles()[teamName] {
+		if roleRanks[role] > highest {
+			highest = roleRanks[role]
+		}
+	}
+
+	return highest
+}
+
+// canManage reports whether the requester may see or revoke the given token:
+// team owners manage every token, everyone else only their own.
+func canManage(acc accessor.Access, token db.APIToken) bool {
+	if highestRank(acc, token.TeamName) == roleRanks["owner"] {
+		return true
+	}
+
+	return !token.ServiceAccount && token.Owner == acc.UserName()
+}
@@ -0,0 +1,171 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Audit Events API", func() {
+	var response *http.Response
+
+	BeforeEach(func() {
+		fakeAccess.IsAuthenticatedReturns(true)
+	})
+
+	Describe("GET /api/v1/audit-events", func() {
+		var query string
+
+		BeforeEach(func() {
+			query = ""
+			fakeAccess.IsAdminReturns(true)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/audit-events" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbAuditEventFactory.AuditEventsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when listing events succeeds", func() {
+			BeforeEach(func() {
+				dbAuditEventFactory.AuditEventsReturns([]db.AuditEvent{
+					{
+						ID:        3,
+						Actor:     "some-user",
+						TeamName:  "some-team",
+						Action:    "DeletePipeline",
+						Target:    "pipeline_name=some-pipeline",
+						RequestID: "some-request-id",
+						SourceIP:  "1.2.3.4",
+						Status:    204,
+						CreatedAt: time.Unix(100, 0),
+					},
+				}, db.Pagination{
+					Next: &db.Page{Since: 3, Limit: 1},
+				}, nil)
+			})
+
+			It("returns the events", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[{
+					"id": 3,
+					"actor": "some-user",
+					"team_name": "some-team",
+					"action": "DeletePipeline",
+					"target": "pipeline_name=some-pipeline",
+					"request_id": "some-request-id",
+					"source_ip": "1.2.3.4",
+					"status": 204,
+					"created_at": 100
+				}]`))
+			})
+
+			It("lists events across all teams with the default page", func() {
+				filter, page := dbAuditEventFactory.AuditEventsArgsForCall(0)
+				Expect(filter).To(Equal(db.AuditEventFilter{}))
+				Expect(page).To(Equal(db.Page{Limit: 100}))
+			})
+
+			It("returns a link to the next page", func() {
+				Expect(response.Header.Get("Link")).To(Equal(`<https://example.com/api/v1/audit-events?limit=1&since=3>; rel="next"`))
+			})
+
+			Context("when filters are given", func() {
+				BeforeEach(func() {
+					query = "?team=some-team&user=some-user&action=DeletePipeline&from=100&to=200&limit=1"
+				})
+
+				It("passes them along", func() {
+					filter, page := dbAuditEventFactory.AuditEventsArgsForCall(0)
+					Expect(filter).To(Equal(db.AuditEventFilter{
+						TeamName: "some-team",
+						Actor:    "some-user",
+						Action:   "DeletePipeline",
+						From:     time.Unix(100, 0),
+						To:       time.Unix(200, 0),
+					}))
+					Expect(page).To(Equal(db.Page{Limit: 1}))
+				})
+
+				It("keeps the filters in the pagination links", func() {
+					Expect(response.Header.Get("Link")).To(Equal(`<https://example.com/api/v1/audit-events?action=DeletePipeline&from=100&limit=1&since=3&team=some-team&to=200&user=some-user>; rel="next"`))
+				})
+			})
+		})
+
+		Context("when a timestamp is invalid", func() {
+			BeforeEach(func() {
+				query = "?from=yesterday"
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("when listing events fails", func() {
+			BeforeEach(func() {
+				dbAuditEventFactory.AuditEventsReturns(nil, db.Pagination{}, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/audit-events", func() {
+		BeforeEach(func() {
+			dbTeam.NameReturns("some-team")
+			fakeAccess.IsAuthorizedReturns(true)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/audit-events?team=other-team&user=some-user")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		It("only lists the team's events", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+			filter, _ := dbAuditEventFactory.AuditEventsArgsForCall(0)
+			Expect(filter).To(Equal(db.AuditEventFilter{
+				TeamName: "some-team",
+				Actor:    "some-user",
+			}))
+		})
+	})
+})
@@ -0,0 +1,122 @@
+package auditserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListAuditEvents(w http.ResponseWriter, r *http.Request) {
+	filter := db.AuditEventFilter{
+		TeamName: r.FormValue("team"),
+	}
+
+	s.listAuditEvents(s.logger.Session("list-audit-events"), "/api/v1/audit-events", filter, w, r)
+}
+
+func (s *Server) ListTeamAuditEvents(team db.Team) http.Handler {
+	logger := s.logger.Session("list-team-audit-events")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filter := db.AuditEventFilter{
+			TeamName: team.Name(),
+		}
+
+		path := fmt.Sprintf("/api/v1/teams/%s/audit-events", url.PathEscape(team.Name()))
+
+		s.listAuditEvents(logger, path, filter, w, r)
+	})
+}
+
+func (s *Server) listAuditEvents(logger lager.Logger, path string, filter db.AuditEventFilter, w http.ResponseWriter, r *http.Request) {
+	filter.Actor = r.FormValue("user")
+	filter.Action = r.FormValue("action")
+
+	if from := r.FormValue("from"); from != "" {
+		unix, err := strconv.ParseInt(from, 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "invalid 'from' timestamp: %s", from)
+			return
+		}
+
+		filter.From = time.Unix(unix, 0)
+	}
+
+	if to := r.FormValue("to"); to != "" {
+		unix, err := strconv.ParseInt(to, 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "invalid 'to' timestamp: %s", to)
+			return
+		}
+
+		filter.To = time.Unix(unix, 0)
+	}
+
+	until, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryUntil))
+	since, _ := strconv.Atoi(r.FormValue(atc.PaginationQuerySince))
+
+	limit, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+	if limit == 0 {
+		limit = atc.PaginationAPIDefaultLimit
+	}
+
+	events, pagination, err := s.auditEventFactory.AuditEvents(filter, db.Page{Until: until, Since: since, Limit: limit})
+	if err != nil {
+		logger.Error("failed-to-get-audit-events", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if pagination.Next != nil {
+		s.addLink(w, path, r.Form, atc.PaginationQuerySince, pagination.Next.Since, pagination.Next.Limit, atc.LinkRelNext)
+	}
+
+	if pagination.Previous != nil {
+		s.addLink(w, path, r.Form, atc.PaginationQueryUntil, pagination.Previous.Until, pagination.Previous.Limit, atc.LinkRelPrevious)
+	}
+
+	presentedEvents := []atc.AuditEvent{}
+	for _, event := range events {
+		presentedEvents = append(presentedEvents, present.AuditEvent(event))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(presentedEvents)
+	if err != nil {
+		logger.Error("failed-to-encode-audit-events", err)
+	}
+}
+
+// addLink keeps the request's filters in the pagination links so that
+// following them walks through the same result set.
+func (s *Server) addLink(w http.ResponseWriter, path string, form url.Values, key string, id int, limit int, rel string) {
+	query := url.Values{}
+	for _, filter := range []string{"team", "user", "action", "from", "to"} {
+		if value := form.Get(filter); value != "" {
+			query.Set(filter, value)
+		}
+	}
+
+	query.Set(key, strconv.Itoa(id))
+	query.Set(atc.PaginationQueryLimit, strconv.Itoa(limit))
+
+	w.Header().Add("Link", fmt.Sprintf(
+		`<%s%s?%s>; rel="%s"`,
+		s.externalURL,
+		path,
+		query.Encode(),
+		rel,
+	))
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -1409,4 +1410,214 @@ var _ = Describe("Builds API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/builds/:build_id/timeline", func() {
+		var response *http.Response
+
+		envelope := func(ev atc.Event) event.Envelope {
+			payload, err := json.Marshal(ev)
+			Expect(err).NotTo(HaveOccurred())
+
+			data := json.RawMessage(payload)
+
+			return event.Envelope{
+				Data:    &data,
+				Event:   ev.EventType(),
+				Version: ev.Version(),
+			}
+		}
+
+		BeforeEach(func() {
+			build.IDReturns(42)
+			build.JobNameReturns("job1")
+			build.TeamNameReturns("some-team")
+			build.StartTimeReturns(time.Unix(100, 0))
+			build.EndTimeReturns(time.Unix(200, 0))
+			dbBuildFactory.BuildReturns(build, true, nil)
+
+			fakeAccess.IsAuthenticatedReturns(true)
+			fakeAccess.IsAuthorizedReturns(true)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/timeline")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(false)
+				build.PipelineReturns(fakePipeline, true, nil)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when the build has not started", func() {
+			It("returns a timeline with no nodes", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`{
+					"build_id": 42,
+					"start_time": 100,
+					"end_time": 200,
+					"nodes": []
+				}`))
+			})
+		})
+
+		Context("when the build has a plan", func() {
+			BeforeEach(func() {
+				plan := atc.Plan{
+					ID: "do",
+					Do: &atc.DoPlan{
+						{
+							ID: "aggregate",
+							Aggregate: &atc.AggregatePlan{
+								{ID: "fast-get", Get: &atc.GetPlan{Name: "fast", Type: "git"}},
+								{ID: "slow-get", Get: &atc.GetPlan{Name: "slow", Type: "git"}},
+							},
+						},
+						{ID: "task", Task: &atc.TaskPlan{Name: "unit"}},
+					},
+				}
+
+				build.PublicPlanReturns(plan.Public())
+
+				build.StepEventsReturns([]event.Envelope{
+					envelope(event.InitializeGet{Origin: event.Origin{ID: "fast-get"}, Time: 101}),
+					envelope(event.SelectedWorker{Origin: event.Origin{ID: "fast-get"}, Time: 101, WorkerName: "worker-a", Cache: atc.CacheHit}),
+					envelope(event.InitializeGet{Origin: event.Origin{ID: "slow-get"}, Time: 102}),
+					envelope(event.SelectedWorker{Origin: event.Origin{ID: "slow-get"}, Time: 102, WorkerName: "worker-b", Cache: atc.CacheMiss}),
+					envelope(event.StartGet{Origin: event.Origin{ID: "fast-get"}, Time: 103}),
+					envelope(event.FinishGet{Origin: event.Origin{ID: "fast-get"}, Time: 104, ExitStatus: 0}),
+					envelope(event.StartGet{Origin: event.Origin{ID: "slow-get"}, Time: 105}),
+					envelope(event.FinishGet{Origin: event.Origin{ID: "slow-get"}, Time: 150, ExitStatus: 0}),
+					envelope(event.InitializeTask{Origin: event.Origin{ID: "task"}, Time: 151}),
+					envelope(event.SelectedWorker{Origin: event.Origin{ID: "task"}, Time: 152, WorkerName: "worker-a"}),
+					envelope(event.StartTask{Origin: event.Origin{ID: "task"}, Time: 153}),
+					envelope(event.FinishTask{Origin: event.Origin{ID: "task"}, Time: 190, ExitStatus: 1}),
+				}, nil)
+			})
+
+			It("returns each node with its times, worker and the critical path", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`{
+					"build_id": 42,
+					"start_time": 100,
+					"end_time": 200,
+					"nodes": [
+						{
+							"id": "do",
+							"depth": 0,
+							"type": "do",
+							"queued_time": 100,
+							"initialize_time": 101,
+							"start_time": 103,
+							"finish_time": 190,
+							"critical": true
+						},
+						{
+							"id": "aggregate",
+							"parent_id": "do",
+							"depth": 1,
+							"type": "aggregate",
+							"queued_time": 100,
+							"initialize_time": 101,
+							"start_time": 103,
+							"finish_time": 150,
+							"critical": true
+						},
+						{
+							"id": "fast-get",
+							"parent_id": "aggregate",
+							"depth": 2,
+							"type": "get",
+							"name": "fast",
+							"queued_time": 100,
+							"initialize_time": 101,
+							"start_time": 103,
+							"finish_time": 104,
+							"worker": "worker-a",
+							"cache": "hit",
+							"exit_status": 0
+						},
+						{
+							"id": "slow-get",
+							"parent_id": "aggregate",
+							"depth": 2,
+							"type": "get",
+							"name": "slow",
+							"queued_time": 100,
+							"initialize_time": 102,
+							"start_time": 105,
+							"finish_time": 150,
+							"worker": "worker-b",
+							"cache": "miss",
+							"exit_status": 0,
+							"critical": true
+						},
+						{
+							"id": "task",
+							"parent_id": "do",
+							"depth": 1,
+							"type": "task",
+							"name": "unit",
+							"queued_time": 150,
+							"initialize_time": 151,
+							"start_time": 153,
+							"finish_time": 190,
+							"worker": "worker-a",
+							"exit_status": 1,
+							"critical": true
+						}
+					]
+				}`))
+			})
+
+			Context("when a step is still running", func() {
+				BeforeEach(func() {
+					build.StepEventsReturns([]event.Envelope{
+						envelope(event.InitializeGet{Origin: event.Origin{ID: "fast-get"}, Time: 101}),
+						envelope(event.FinishGet{Origin: event.Origin{ID: "fast-get"}, Time: 104}),
+						envelope(event.InitializeGet{Origin: event.Origin{ID: "slow-get"}, Time: 102}),
+					}, nil)
+				})
+
+				It("does not finish its parents and marks it as critical", func() {
+					var timeline atc.BuildTimeline
+					err := json.NewDecoder(response.Body).Decode(&timeline)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(timeline.Nodes).To(HaveLen(5))
+					Expect(timeline.Nodes[0].FinishTime).To(BeZero())
+					Expect(timeline.Nodes[1].FinishTime).To(BeZero())
+					Expect(timeline.Nodes[2].Critical).To(BeFalse())
+					Expect(timeline.Nodes[3].Critical).To(BeTrue())
+					Expect(timeline.Nodes[4].Critical).To(BeFalse())
+				})
+			})
+
+			Context("when getting the events fails", func() {
+				BeforeEach(func() {
+					build.StepEventsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
 })
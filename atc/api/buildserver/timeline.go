@@ -0,0 +1,312 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+)
+
+func (s *Server) GetBuildTimeline(build db.Build) http.Handler {
+	logger := s.logger.Session("get-build-timeline", lager.Data{"build-id": build.ID()})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timeline := atc.BuildTimeline{
+			BuildID: build.ID(),
+			Nodes:   []atc.TimelineNode{},
+		}
+
+		if !build.StartTime().IsZero() {
+			timeline.StartTime = build.StartTime().Unix()
+		}
+
+		if !build.EndTime().IsZero() {
+			timeline.EndTime = build.EndTime().Unix()
+		}
+
+		if build.PublicPlan() != nil {
+			var plan atc.Plan
+			err := json.Unmarshal(*build.PublicPlan(), &plan)
+			if err != nil {
+				logger.Error("failed-to-unmarshal-public-plan", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			envelopes, err := build.StepEvents()
+			if err != nil {
+				logger.Error("failed-to-get-step-events", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			events := []stepEvent{}
+			for _, envelope := range envelopes {
+				var ev stepEvent
+				err := json.Unmarshal(*envelope.Data, &ev)
+				if err != nil {
+					logger.Error("failed-to-unmarshal-event", err)
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+
+				ev.Type = envelope.Event
+				events = append(events, ev)
+			}
+
+			timeline.Nodes = buildTimelineNodes(plan, events, timeline.StartTime)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(timeline)
+		if err != nil {
+			logger.Error("failed-to-encode-build-timeline", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+// stepEvent holds the fields shared by the events which describe a step's
+// progress. The payloads are decoded directly, rather than through
+// event.ParseEvent, so that every version of an event yields its time.
+type stepEvent struct {
+	Type atc.EventType `json:"-"`
+
+	Origin     event.Origin    `json:"origin"`
+	Time       int64           `json:"time"`
+	ExitStatus *int            `json:"exit_status"`
+	WorkerName string          `json:"selected_worker"`
+	Cache      atc.CacheStatus `json:"cache"`
+}
+
+type timelineNode struct {
+	atc.TimelineNode
+
+	parallel bool
+	children []*timelineNode
+}
+
+func (node *timelineNode) ran() bool {
+	return node.InitializeTime != 0 || node.StartTime != 0 || node.FinishTime != 0
+}
+
+func buildTimelineNodes(plan atc.Plan, events []stepEvent, buildStart int64) []atc.TimelineNode {
+	leaves := map[atc.PlanID]*atc.TimelineNode{}
+
+	leaf := func(origin event.Origin) *atc.TimelineNode {
+		id := atc.PlanID(origin.ID)
+
+		node, found := leaves[id]
+		if !found {
+			node = &atc.TimelineNode{}
+			leaves[id] = node
+		}
+
+		return node
+	}
+
+	for _, ev := range events {
+		switch ev.Type {
+		case event.EventTypeInitializeGet, event.EventTypeInitializePut, event.EventTypeInitializeTask:
+			leaf(ev.Origin).InitializeTime = ev.Time
+		case event.EventTypeStartGet, event.EventTypeStartPut, event.EventTypeStartTask:
+			leaf(ev.Origin).StartTime = ev.Time
+		case event.EventTypeFinishGet, event.EventTypeFinishPut, event.EventTypeFinishTask:
+			node := leaf(ev.Origin)
+			node.FinishTime = ev.Time
+			node.ExitStatus = ev.ExitStatus
+		case event.EventTypeSelectedWorker:
+			node := leaf(ev.Origin)
+			node.Worker = ev.WorkerName
+			node.Cache = ev.Cache
+		case event.EventTypeError:
+			node := leaf(ev.Origin)
+			node.Errored = true
+			if node.FinishTime == 0 {
+				node.FinishTime = ev.Time
+			}
+		}
+	}
+
+	root := timelineTree(plan, "", 0, buildStart, leaves)
+	markCritical(root)
+
+	nodes := []atc.TimelineNode{}
+	flattenTimeline(root, &nodes)
+
+	return nodes
+}
+
+func timelineTree(plan atc.Plan, parentID atc.PlanID, depth int, queued int64, leaves map[atc.PlanID]*atc.TimelineNode) *timelineNode {
+	node := &timelineNode{
+		TimelineNode: atc.TimelineNode{
+			ID:         plan.ID,
+			ParentID:   parentID,
+			Depth:      depth,
+			QueuedTime: queued,
+		},
+	}
+
+	var children []atc.Plan
+
+	switch {
+	case plan.Get != nil:
+		node.Type = "get"
+		node.Name = plan.Get.Name
+	case plan.Put != nil:
+		node.Type = "put"
+		node.Name = plan.Put.Name
+	case plan.Task != nil:
+		node.Type = "task"
+		node.Name = plan.Task.Name
+	case plan.DependentGet != nil:
+		node.Type = "get"
+		node.Name = plan.DependentGet.Name
+	case plan.ArtifactInput != nil:
+		node.Type = "artifact_input"
+		node.Name = plan.ArtifactInput.Name
+	case plan.ArtifactOutput != nil:
+		node.Type = "artifact_output"
+		node.Name = plan.ArtifactOutput.Name
+	case plan.Aggregate != nil:
+		node.Type = "aggregate"
+		node.parallel = true
+		children = *plan.Aggregate
+	case plan.InParallel != nil:
+		node.Type = "in_parallel"
+		node.parallel = true
+		children = plan.InParallel.Steps
+	case plan.Do != nil:
+		node.Type = "do"
+		children = *plan.Do
+	case plan.Retry != nil:
+		node.Type = "retry"
+		children = *plan.Retry
+	case plan.OnSuccess != nil:
+		node.Type = "on_success"
+		children = []atc.Plan{plan.OnSuccess.Step, plan.OnSuccess.Next}
+	case plan.OnFailure != nil:
+		node.Type = "on_failure"
+		children = []atc.Plan{plan.OnFailure.Step, plan.OnFailure.Next}
+	case plan.OnAbort != nil:
+		node.Type = "on_abort"
+		children = []atc.Plan{plan.OnAbort.Step, plan.OnAbort.Next}
+	case plan.OnError != nil:
+		node.Type = "on_error"
+		children = []atc.Plan{plan.OnError.Step, plan.OnError.Next}
+	case plan.Ensure != nil:
+		node.Type = "ensure"
+		children = []atc.Plan{plan.Ensure.Step, plan.Ensure.Next}
+	case plan.Try != nil:
+		node.Type = "try"
+		children = []atc.Plan{plan.Try.Step}
+	case plan.Timeout != nil:
+		node.Type = "timeout"
+		children = []atc.Plan{plan.Timeout.Step}
+	case plan.Lock != nil:
+		node.Type = "lock"
+		children = []atc.Plan{plan.Lock.Step}
+	}
+
+	if children == nil {
+		if stats, found := leaves[plan.ID]; found {
+			node.InitializeTime = stats.InitializeTime
+			node.StartTime = stats.StartTime
+			node.FinishTime = stats.FinishTime
+			node.Worker = stats.Worker
+			node.Cache = stats.Cache
+			node.ExitStatus = stats.ExitStatus
+			node.Errored = stats.Errored
+		}
+
+		return node
+	}
+
+	childQueued := queued
+	finished := true
+	for _, childPlan := range children {
+		child := timelineTree(childPlan, plan.ID, depth+1, childQueued, leaves)
+		node.children = append(node.children, child)
+
+		if !child.ran() {
+			continue
+		}
+
+		node.InitializeTime = earliest(node.InitializeTime, child.InitializeTime)
+		node.StartTime = earliest(node.StartTime, child.StartTime)
+
+		if child.FinishTime == 0 {
+			finished = false
+		} else if child.FinishTime > node.FinishTime {
+			node.FinishTime = child.FinishTime
+		}
+
+		if child.Errored {
+			node.Errored = true
+		}
+
+		if !node.parallel && child.FinishTime != 0 {
+			childQueued = child.FinishTime
+		}
+	}
+
+	if !finished {
+		node.FinishTime = 0
+	}
+
+	return node
+}
+
+// markCritical marks the chain of nodes which determined when the node
+// finished: every step of a sequence, but only the last to finish of a set of
+// parallel steps.
+func markCritical(node *timelineNode) {
+	if !node.ran() {
+		return
+	}
+
+	node.Critical = true
+
+	if !node.parallel {
+		for _, child := range node.children {
+			markCritical(child)
+		}
+
+		return
+	}
+
+	var last *timelineNode
+	for _, child := range node.children {
+		if !child.ran() {
+			continue
+		}
+
+		// a step which is still running will finish after all the others
+		if last == nil || child.FinishTime == 0 || (last.FinishTime != 0 && child.FinishTime > last.FinishTime) {
+			last = child
+		}
+	}
+
+	if last != nil {
+		markCritical(last)
+	}
+}
+
+func flattenTimeline(node *timelineNode, nodes *[]atc.TimelineNode) {
+	*nodes = append(*nodes, node.TimelineNode)
+
+	for _, child := range node.children {
+		flattenTimeline(child, nodes)
+	}
+}
+
+func earliest(a, b int64) int64 {
+	if a == 0 || (b != 0 && b < a) {
+		return b
+	}
+
+	return a
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/concourse/concourse/atc/creds/noop"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/policy"
 	"github.com/onsi/gomega/gbytes"
 	"github.com/tedsuo/rata"
 	"gopkg.in/yaml.v2"
@@ -484,13 +485,24 @@ var _ = Describe("Config API", func() {
 						It("saves it", func() {
 							Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
 
-							name, savedConfig, id, pipelineState := dbTeam.SavePipelineArgsForCall(0)
+							name, savedConfig, id, pipelineState, _ := dbTeam.SavePipelineArgsForCall(0)
 							Expect(name).To(Equal("a-pipeline"))
 							Expect(savedConfig).To(Equal(pipelineConfig))
 							Expect(id).To(Equal(db.ConfigVersion(42)))
 							Expect(pipelineState).To(Equal(db.PipelineNoChange))
 						})
 
+						Context("when the user is known", func() {
+							BeforeEach(func() {
+								fakeaccess.UserNameReturns("some-user")
+							})
+
+							It("records who saved it", func() {
+								_, _, _, _, savedBy := dbTeam.SavePipelineArgsForCall(0)
+								Expect(savedBy).To(Equal("some-user"))
+							})
+						})
+
 						Context("and saving it fails", func() {
 							BeforeEach(func() {
 								dbTeam.SavePipelineReturns(nil, false, errors.New("oh no!"))
@@ -505,6 +517,74 @@ var _ = Describe("Config API", func() {
 							})
 						})
 
+						Context("when checking the policy", func() {
+							BeforeEach(func() {
+								fakeaccess.UserNameReturns("some-user")
+							})
+
+							It("sends the pipeline config to the policy checker", func() {
+								Expect(fakePolicyChecker.CheckCallCount()).To(Equal(1))
+								Expect(fakePolicyChecker.CheckArgsForCall(0)).To(Equal(policy.Input{
+									Action:   policy.ActionSaveConfig,
+									User:     "some-user",
+									Team:     "a-team",
+									Pipeline: "a-pipeline",
+									Data:     pipelineConfig,
+								}))
+							})
+
+							Context("when the policy denies the config", func() {
+								BeforeEach(func() {
+									fakePolicyChecker.CheckReturns(policy.Result{
+										Decision: policy.DecisionDeny,
+										Reasons:  []string{"privileged tasks are not allowed"},
+									}, nil)
+								})
+
+								It("returns 400 with the reasons without saving", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+									Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`
+									{
+										"errors": [
+											"policy check denied SaveConfig: privileged tasks are not allowed"
+										]
+									}`))
+									Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+								})
+							})
+
+							Context("when the policy warns about the config", func() {
+								BeforeEach(func() {
+									fakePolicyChecker.CheckReturns(policy.Result{
+										Decision: policy.DecisionWarn,
+										Reasons:  []string{"jobs should not be public"},
+									}, nil)
+								})
+
+								It("saves the config and returns the warnings", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+									Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+									Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`
+									{
+										"warnings": [
+											{"type": "policy", "message": "jobs should not be public"}
+										]
+									}`))
+								})
+							})
+
+							Context("when the policy check fails", func() {
+								BeforeEach(func() {
+									fakePolicyChecker.CheckReturns(policy.Result{}, errors.New("agent down"))
+								})
+
+								It("returns 500 without saving", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+									Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+								})
+							})
+						})
+
 						Context("when it's the first time the pipeline has been created", func() {
 							BeforeEach(func() {
 								returnedPipeline := new(dbfakes.FakePipeline)
@@ -516,6 +596,59 @@ var _ = Describe("Config API", func() {
 							})
 						})
 
+						Context("when a job passes through a job in another pipeline", func() {
+							BeforeEach(func() {
+								pipelineConfig.Jobs[0].Plan[0].Passed = []string{"upstream/some-job"}
+								payload, err := json.Marshal(pipelineConfig)
+								Expect(err).NotTo(HaveOccurred())
+								request.Body = gbytes.BufferWithBytes(payload)
+							})
+
+							Context("when the job exists", func() {
+								BeforeEach(func() {
+									upstreamPipeline := new(dbfakes.FakePipeline)
+									upstreamPipeline.JobReturns(new(dbfakes.FakeJob), true, nil)
+									dbTeam.PipelineReturns(upstreamPipeline, true, nil)
+								})
+
+								It("looks the job up in the team", func() {
+									Expect(dbTeam.PipelineArgsForCall(0)).To(Equal("upstream"))
+								})
+
+								It("saves the config", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+									Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+								})
+							})
+
+							Context("when the pipeline does not exist", func() {
+								BeforeEach(func() {
+									dbTeam.PipelineReturns(nil, false, nil)
+								})
+
+								It("returns 400 without saving", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+									Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`
+									{
+										"errors": [
+											"jobs.some-job.passed references an unknown job in another pipeline ('upstream/some-job')"
+										]
+									}`))
+									Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+								})
+							})
+
+							Context("when looking up the pipeline fails", func() {
+								BeforeEach(func() {
+									dbTeam.PipelineReturns(nil, false, errors.New("nope"))
+								})
+
+								It("returns 500", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+								})
+							})
+						})
+
 						Context("when the config is invalid", func() {
 							BeforeEach(func() {
 								pipelineConfig.Groups[0].Resources = []string{"missing-resource"}
@@ -568,7 +701,7 @@ var _ = Describe("Config API", func() {
 						It("saves it", func() {
 							Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
 
-							name, savedConfig, id, pipelineState := dbTeam.SavePipelineArgsForCall(0)
+							name, savedConfig, id, pipelineState, _ := dbTeam.SavePipelineArgsForCall(0)
 							Expect(name).To(Equal("a-pipeline"))
 							Expect(savedConfig).To(Equal(pipelineConfig))
 							Expect(id).To(Equal(db.ConfigVersion(42)))
@@ -578,7 +711,7 @@ var _ = Describe("Config API", func() {
 						It("does not give the DB a map of empty interfaces to empty interfaces", func() {
 							Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
 
-							_, savedConfig, _, _ := dbTeam.SavePipelineArgsForCall(0)
+							_, savedConfig, _, _, _ := dbTeam.SavePipelineArgsForCall(0)
 							Expect(savedConfig).To(Equal(pipelineConfig))
 
 							_, err := json.Marshal(pipelineConfig)
@@ -622,7 +755,7 @@ jobs:
 							It("saves it", func() {
 								Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
 
-								name, savedConfig, id, pipelineState := dbTeam.SavePipelineArgsForCall(0)
+								name, savedConfig, id, pipelineState, _ := dbTeam.SavePipelineArgsForCall(0)
 								Expect(name).To(Equal("a-pipeline"))
 								Expect(savedConfig).To(Equal(atc.Config{
 									Resources: []atc.ResourceConfig{
@@ -893,7 +1026,7 @@ jobs:
 									It("passes validation and saves it un-interpolated", func() {
 										Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
 
-										name, savedConfig, id, pipelineState := dbTeam.SavePipelineArgsForCall(0)
+										name, savedConfig, id, pipelineState, _ := dbTeam.SavePipelineArgsForCall(0)
 										Expect(name).To(Equal("a-pipeline"))
 										Expect(savedConfig).To(Equal(payloadAsConfig))
 
@@ -1042,7 +1175,7 @@ jobs:
 							It("saves it", func() {
 								Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
 
-								name, savedConfig, id, pipelineState := dbTeam.SavePipelineArgsForCall(0)
+								name, savedConfig, id, pipelineState, _ := dbTeam.SavePipelineArgsForCall(0)
 								Expect(name).To(Equal("a-pipeline"))
 								Expect(savedConfig).To(Equal(pipelineConfig))
 								Expect(id).To(Equal(db.ConfigVersion(42)))
@@ -1336,7 +1469,7 @@ jobs:
 					It("saves it", func() {
 						Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
 
-						name, savedConfig, id, _ := dbTeam.SavePipelineArgsForCall(0)
+						name, savedConfig, id, _, _ := dbTeam.SavePipelineArgsForCall(0)
 						Expect(name).To(Equal("a-pipeline"))
 						Expect(savedConfig).To(Equal(atc.Config{
 							Jobs: atc.JobConfigs{
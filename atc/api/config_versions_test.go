@@ -0,0 +1,293 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Config Versions API", func() {
+	var (
+		requestGenerator *rata.RequestGenerator
+		fakeaccess       *accessorfakes.FakeAccess
+
+		fakeTeam     *dbfakes.FakeTeam
+		fakePipeline *dbfakes.FakePipeline
+
+		response *http.Response
+	)
+
+	BeforeEach(func() {
+		requestGenerator = rata.NewRequestGenerator(server.URL, atc.Routes)
+
+		fakeaccess = new(accessorfakes.FakeAccess)
+		fakeaccess.IsAuthenticatedReturns(true)
+		fakeaccess.IsAuthorizedReturns(true)
+
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeam.NameReturns("a-team")
+		dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+		fakePipeline = new(dbfakes.FakePipeline)
+		fakePipeline.NameReturns("a-pipeline")
+		fakePipeline.ConfigVersionReturns(3)
+		fakeTeam.PipelineReturns(fakePipeline, true, nil)
+	})
+
+	JustBeforeEach(func() {
+		fakeAccessor.CreateReturns(fakeaccess)
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/config/versions", func() {
+		JustBeforeEach(func() {
+			req, err := requestGenerator.CreateRequest(atc.ListConfigVersions, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "a-pipeline",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		BeforeEach(func() {
+			fakePipeline.ConfigHistoryReturns([]db.PipelineConfigVersion{
+				{Version: 3, SavedBy: "some-user", SavedAt: time.Unix(200, 0)},
+				{Version: 1, SavedAt: time.Unix(100, 0)},
+			}, nil)
+		})
+
+		It("returns 200 with the history", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(body).To(MatchJSON(`[
+				{"version": 3, "saved_by": "some-user", "saved_at": 200},
+				{"version": 1, "saved_at": 100}
+			]`))
+		})
+
+		It("looks up the pipeline in the team", func() {
+			Expect(dbTeamFactory.FindTeamArgsForCall(0)).To(Equal("a-team"))
+			Expect(fakeTeam.PipelineArgsForCall(0)).To(Equal("a-pipeline"))
+		})
+
+		Context("when getting the history fails", func() {
+			BeforeEach(func() {
+				fakePipeline.ConfigHistoryReturns(nil, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		Context("when the pipeline is not found", func() {
+			BeforeEach(func() {
+				fakeTeam.PipelineReturns(nil, false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when the team is not found", func() {
+			BeforeEach(func() {
+				dbTeamFactory.FindTeamReturns(nil, false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/config/versions/:config_version", func() {
+		var configVersion string
+
+		BeforeEach(func() {
+			configVersion = "1"
+		})
+
+		JustBeforeEach(func() {
+			req, err := requestGenerator.CreateRequest(atc.GetConfigVersion, rata.Params{
+				"team_name":      "a-team",
+				"pipeline_name":  "a-pipeline",
+				"config_version": configVersion,
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the version is found", func() {
+			BeforeEach(func() {
+				fakePipeline.HistoricalConfigReturns(db.PipelineConfigVersion{
+					Version: 1,
+					SavedBy: "some-user",
+					SavedAt: time.Unix(100, 0),
+					Config: atc.Config{
+						Jobs: atc.JobConfigs{{Name: "some-job"}},
+					},
+				}, true, nil)
+			})
+
+			It("returns the config saved at that version", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				var version atc.ConfigVersion
+				Expect(json.NewDecoder(response.Body).Decode(&version)).To(Succeed())
+				Expect(version).To(Equal(atc.ConfigVersion{
+					Version: 1,
+					SavedBy: "some-user",
+					SavedAt: 100,
+					Config: &atc.Config{
+						Jobs: atc.JobConfigs{{Name: "some-job"}},
+					},
+				}))
+			})
+
+			It("looks up the requested version", func() {
+				Expect(fakePipeline.HistoricalConfigArgsForCall(0)).To(Equal(db.ConfigVersion(1)))
+			})
+		})
+
+		Context("when the version is not found", func() {
+			BeforeEach(func() {
+				fakePipeline.HistoricalConfigReturns(db.PipelineConfigVersion{}, false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when looking up the version fails", func() {
+			BeforeEach(func() {
+				fakePipeline.HistoricalConfigReturns(db.PipelineConfigVersion{}, false, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		Context("when the version is not a number", func() {
+			BeforeEach(func() {
+				configVersion = "latest"
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/config/diff", func() {
+		var query string
+
+		BeforeEach(func() {
+			query = "from=1&to=2"
+
+			fakePipeline.HistoricalConfigStub = func(version db.ConfigVersion) (db.PipelineConfigVersion, bool, error) {
+				jobName := "job-one"
+				if version != 1 {
+					jobName = "job-two"
+				}
+
+				return db.PipelineConfigVersion{
+					Version: version,
+					Config: atc.Config{
+						Jobs: atc.JobConfigs{{Name: jobName}},
+					},
+				}, true, nil
+			}
+		})
+
+		JustBeforeEach(func() {
+			req, err := requestGenerator.CreateRequest(atc.DiffConfigVersions, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "a-pipeline",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			req.URL.RawQuery = query
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("diffs the two versions", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+			Expect(fakePipeline.HistoricalConfigCallCount()).To(Equal(2))
+			Expect(fakePipeline.HistoricalConfigArgsForCall(0)).To(Equal(db.ConfigVersion(1)))
+			Expect(fakePipeline.HistoricalConfigArgsForCall(1)).To(Equal(db.ConfigVersion(2)))
+
+			var diff atc.ConfigDiff
+			Expect(json.NewDecoder(response.Body).Decode(&diff)).To(Succeed())
+			Expect(diff.From).To(Equal(1))
+			Expect(diff.To).To(Equal(2))
+			Expect(diff.Lines).To(ContainElement(atc.ConfigDiffLine{Delta: atc.ConfigDiffRemoved, Text: "- name: job-one"}))
+			Expect(diff.Lines).To(ContainElement(atc.ConfigDiffLine{Delta: atc.ConfigDiffAdded, Text: "- name: job-two"}))
+			Expect(diff.Lines).To(ContainElement(atc.ConfigDiffLine{Delta: atc.ConfigDiffCommon, Text: "jobs:"}))
+		})
+
+		Context("when 'to' is omitted", func() {
+			BeforeEach(func() {
+				query = "from=1"
+			})
+
+			It("diffs against the current version", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(fakePipeline.HistoricalConfigArgsForCall(1)).To(Equal(db.ConfigVersion(3)))
+			})
+		})
+
+		Context("when 'from' is omitted", func() {
+			BeforeEach(func() {
+				query = "to=2"
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("when a version is not found", func() {
+			BeforeEach(func() {
+				fakePipeline.HistoricalConfigStub = nil
+				fakePipeline.HistoricalConfigReturns(db.PipelineConfigVersion{}, false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+})
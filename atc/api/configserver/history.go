@@ -0,0 +1,197 @@
+package configserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/aryann/difflib"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/tedsuo/rata"
+	"gopkg.in/yaml.v2"
+)
+
+func (s *Server) ListConfigVersions(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-config-versions")
+
+	pipeline, found := s.findPipeline(logger, w, r)
+	if !found {
+		return
+	}
+
+	history, err := pipeline.ConfigHistory()
+	if err != nil {
+		logger.Error("failed-to-get-config-history", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	versions := make([]atc.ConfigVersion, len(history))
+	for i, version := range history {
+		versions[i] = present.ConfigVersion(version)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(versions)
+	if err != nil {
+		logger.Error("failed-to-encode-config-versions", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) GetConfigVersion(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-config-version")
+
+	configVersion, err := strconv.Atoi(rata.Param(r, "config_version"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	pipeline, found := s.findPipeline(logger, w, r)
+	if !found {
+		return
+	}
+
+	version, found, err := pipeline.HistoricalConfig(db.ConfigVersion(configVersion))
+	if err != nil {
+		logger.Error("failed-to-get-config-version", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		logger.Debug("config-version-not-found", lager.Data{"version": configVersion})
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	presented := present.ConfigVersion(version)
+	presented.Config = &version.Config
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(presented)
+	if err != nil {
+		logger.Error("failed-to-encode-config-version", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// DiffConfigVersions diffs the config saved at the 'from' version against
+// the one saved at the 'to' version, which defaults to the current config.
+func (s *Server) DiffConfigVersions(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("diff-config-versions")
+
+	from, err := strconv.Atoi(r.FormValue(atc.DiffConfigFrom))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	pipeline, found := s.findPipeline(logger, w, r)
+	if !found {
+		return
+	}
+
+	to := int(pipeline.ConfigVersion())
+	if r.FormValue(atc.DiffConfigTo) != "" {
+		to, err = strconv.Atoi(r.FormValue(atc.DiffConfigTo))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	var payloads []string
+	for _, configVersion := range []int{from, to} {
+		version, found, err := pipeline.HistoricalConfig(db.ConfigVersion(configVersion))
+		if err != nil {
+			logger.Error("failed-to-get-config-version", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Debug("config-version-not-found", lager.Data{"version": configVersion})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		payload, err := yaml.Marshal(version.Config)
+		if err != nil {
+			logger.Error("failed-to-marshal-config", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		payloads = append(payloads, string(payload))
+	}
+
+	diff := atc.ConfigDiff{
+		From:  from,
+		To:    to,
+		Lines: []atc.ConfigDiffLine{},
+	}
+
+	for _, record := range difflib.Diff(strings.Split(payloads[0], "\n"), strings.Split(payloads[1], "\n")) {
+		line := atc.ConfigDiffLine{Text: record.Payload}
+
+		switch record.Delta {
+		case difflib.RightOnly:
+			line.Delta = atc.ConfigDiffAdded
+		case difflib.LeftOnly:
+			line.Delta = atc.ConfigDiffRemoved
+		default:
+			line.Delta = atc.ConfigDiffCommon
+		}
+
+		diff.Lines = append(diff.Lines, line)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(diff)
+	if err != nil {
+		logger.Error("failed-to-encode-config-diff", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) findPipeline(logger lager.Logger, w http.ResponseWriter, r *http.Request) (db.Pipeline, bool) {
+	pipelineName := rata.Param(r, "pipeline_name")
+	teamName := rata.Param(r, "team_name")
+
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		logger.Error("failed-to-find-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return nil, false
+	}
+
+	if !found {
+		logger.Debug("team-not-found", lager.Data{"team": teamName})
+		w.WriteHeader(http.StatusNotFound)
+		return nil, false
+	}
+
+	pipeline, found, err := team.Pipeline(pipelineName)
+	if err != nil {
+		logger.Error("failed-to-find-pipeline", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return nil, false
+	}
+
+	if !found {
+		logger.Debug("pipeline-not-found", lager.Data{"pipeline": pipelineName})
+		w.WriteHeader(http.StatusNotFound)
+		return nil, false
+	}
+
+	return pipeline, true
+}
@@ -18,8 +18,10 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/policy"
 	"github.com/hashicorp/go-multierror"
 	"github.com/mitchellh/mapstructure"
 	"github.com/tedsuo/rata"
@@ -142,7 +144,48 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, created, err := team.SavePipeline(pipelineName, config, version, pausedState)
+	errorMessages, err = config.ValidatePassedJobs(pipelineName, passedJobExists(team))
+	if err != nil {
+		session.Error("failed-to-validate-passed-jobs", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if len(errorMessages) > 0 {
+		s.handleBadRequest(w, errorMessages, session)
+		return
+	}
+
+	result, err := s.policyChecker.Check(policy.Input{
+		Action:   policy.ActionSaveConfig,
+		User:     accessor.GetAccessor(r).UserName(),
+		Team:     teamName,
+		Pipeline: pipelineName,
+		Data:     config,
+	})
+	if err != nil {
+		session.Error("failed-to-check-policy", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "failed to check policy: %s", err)
+		return
+	}
+
+	if result.Denied() {
+		session.Info("denied-by-policy", lager.Data{"reasons": result.Reasons})
+		s.handleBadRequest(w, []string{policy.DeniedError{Action: policy.ActionSaveConfig, Reasons: result.Reasons}.Error()}, session)
+		return
+	}
+
+	if result.Warned() {
+		for _, reason := range result.Reasons {
+			warnings = append(warnings, atc.ConfigWarning{
+				Type:    "policy",
+				Message: reason,
+			})
+		}
+	}
+
+	_, created, err := team.SavePipeline(pipelineName, config, version, pausedState, accessor.GetAccessor(r).UserName())
 	if err != nil {
 		session.Error("failed-to-save-config", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -163,6 +206,18 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 	s.writeSaveConfigResponse(w, atc.SaveConfigResponse{Warnings: warnings}, session)
 }
 
+func passedJobExists(team db.Team) func(atc.PassedJobReference) (bool, error) {
+	return func(ref atc.PassedJobReference) (bool, error) {
+		pipeline, found, err := team.Pipeline(ref.PipelineName)
+		if err != nil || !found {
+			return false, err
+		}
+
+		_, found, err = pipeline.Job(ref.JobName)
+		return found, err
+	}
+}
+
 // Simply validate that the credentials exist; don't do anything with the actual secrets
 func validateCredParams(credMgrVars creds.Variables, config atc.Config, session lager.Logger) error {
 	var errs error
@@ -362,10 +362,11 @@ var _ = Describe("Containers API", func() {
 					_, err := client.Do(req)
 					Expect(err).NotTo(HaveOccurred())
 
-					_, pipelineName, resourceName, secretManager := dbTeam.FindCheckContainersArgsForCall(0)
+					_, pipelineName, resourceName, secretManager, actualSourceDefaults := dbTeam.FindCheckContainersArgsForCall(0)
 					Expect(pipelineName).To(Equal("some-pipeline"))
 					Expect(resourceName).To(Equal("some-resource"))
 					Expect(secretManager).To(Equal(fakeSecretManager))
+					Expect(actualSourceDefaults).To(Equal(sourceDefaults))
 				})
 			})
 		})
@@ -770,6 +771,84 @@ var _ = Describe("Containers API", func() {
 								Expect(fakeContainer.MarkAsHijackedCallCount()).To(Equal(1))
 							})
 
+							Context("when the session is recorded", func() {
+								BeforeEach(func() {
+									fakeaccess.UserNameReturns("some-user")
+									fakeContainer.HandleReturns("some-handle")
+									fakeContainer.WorkerNameReturns("some-worker")
+
+									dbHijackSessionFactory.CreateHijackSessionReturns(db.HijackSession{ID: 42}, nil)
+								})
+
+								It("creates the session before running the process", func() {
+									Eventually(fakeContainer.RunCallCount).Should(Equal(1))
+
+									Expect(dbHijackSessionFactory.CreateHijackSessionCallCount()).To(Equal(1))
+									Expect(dbHijackSessionFactory.CreateHijackSessionArgsForCall(0)).To(Equal(db.HijackSession{
+										TeamID:          734,
+										ContainerHandle: "some-handle",
+										WorkerName:      "some-worker",
+										Username:        "some-user",
+										Path:            "ls",
+										ProcessUser:     "snoopy",
+									}))
+								})
+
+								It("saves the input and output and finishes the session when the process exits", func() {
+									Eventually(fakeContainer.RunCallCount).Should(Equal(1))
+
+									_, processIO := fakeContainer.RunArgsForCall(0)
+
+									err := conn.WriteJSON(atc.HijackInput{
+										Stdin: []byte("some stdin\n"),
+									})
+									Expect(err).NotTo(HaveOccurred())
+
+									Expect(bufio.NewReader(processIO.Stdin).ReadBytes('\n')).To(Equal([]byte("some stdin\n")))
+
+									_, err = fmt.Fprintf(processIO.Stdout, "some stdout\n")
+									Expect(err).NotTo(HaveOccurred())
+
+									var hijackOutput atc.HijackOutput
+									err = conn.ReadJSON(&hijackOutput)
+									Expect(err).NotTo(HaveOccurred())
+
+									Eventually(processExit).Should(BeSent(123))
+
+									Eventually(dbHijackSessionFactory.FinishHijackSessionCallCount).Should(Equal(1))
+
+									sessionID, exitStatus := dbHijackSessionFactory.FinishHijackSessionArgsForCall(0)
+									Expect(sessionID).To(Equal(42))
+									Expect(exitStatus).ToNot(BeNil())
+									Expect(*exitStatus).To(Equal(123))
+
+									Expect(dbHijackSessionFactory.SaveHijackSessionEventsCallCount()).To(Equal(1))
+
+									sessionID, events := dbHijackSessionFactory.SaveHijackSessionEventsArgsForCall(0)
+									Expect(sessionID).To(Equal(42))
+									Expect(events).To(HaveLen(2))
+									Expect(events[0].Type).To(Equal(db.HijackSessionEventInput))
+									Expect(events[0].Data).To(Equal([]byte("some stdin\n")))
+									Expect(events[1].Type).To(Equal(db.HijackSessionEventOutput))
+									Expect(events[1].Data).To(Equal([]byte("some stdout\n")))
+								})
+
+								Context("when creating the session fails", func() {
+									BeforeEach(func() {
+										dbHijackSessionFactory.CreateHijackSessionReturns(db.HijackSession{}, errors.New("nope"))
+									})
+
+									It("does not run the process", func() {
+										var hijackOutput atc.HijackOutput
+										err := conn.ReadJSON(&hijackOutput)
+										Expect(err).NotTo(HaveOccurred())
+										Expect(hijackOutput.Error).To(Equal("failed to start recording hijack session"))
+
+										Expect(fakeContainer.RunCallCount()).To(BeZero())
+									})
+								})
+							})
+
 							Context("when stdin is sent over the API", func() {
 								JustBeforeEach(func() {
 									err := conn.WriteJSON(atc.HijackInput{
@@ -151,6 +151,8 @@ func (s *Server) HijackContainer(team db.Team) http.Handler {
 		hijackRequest := hijackRequest{
 			Container: container,
 			Process:   processSpec,
+			TeamID:    team.ID(),
+			Username:  accessor.GetAccessor(r).UserName(),
 		}
 
 		s.hijack(hLog, conn, hijackRequest)
@@ -160,6 +162,8 @@ func (s *Server) HijackContainer(team db.Team) http.Handler {
 type hijackRequest struct {
 	Container worker.Container
 	Process   atc.HijackProcessSpec
+	TeamID    int
+	Username  string
 }
 
 func closeWithErr(log lager.Logger, conn *websocket.Conn, code int, reason string) {
@@ -213,6 +217,20 @@ func (s *Server) hijack(hLog lager.Logger, conn *websocket.Conn, request hijackR
 		}
 	}
 
+	recorder, err := s.startRecording(hLog, request)
+	if err != nil {
+		_ = conn.WriteJSON(atc.HijackOutput{
+			Error: "failed to start recording hijack session",
+		})
+		hLog.Error("failed-to-start-recording", err)
+		return
+	}
+
+	var exitStatus *int
+	defer func() {
+		recorder.Finish(exitStatus)
+	}()
+
 	process, err := request.Container.Run(garden.ProcessSpec{
 		Path: request.Process.Path,
 		Args: request.Process.Args,
@@ -278,6 +296,8 @@ func (s *Server) hijack(hLog lager.Logger, conn *websocket.Conn, request hijackR
 			if input.Closed {
 				_ = stdinW.Close()
 			} else if input.TTYSpec != nil {
+				recorder.RecordResize(input.TTYSpec.WindowSize.Columns, input.TTYSpec.WindowSize.Rows)
+
 				err := process.SetTTY(garden.TTYSpec{
 					WindowSize: &garden.WindowSize{
 						Columns: input.TTYSpec.WindowSize.Columns,
@@ -290,6 +310,8 @@ func (s *Server) hijack(hLog lager.Logger, conn *websocket.Conn, request hijackR
 					})
 				}
 			} else {
+				recorder.Record(db.HijackSessionEventInput, input.Stdin)
+
 				_, _ = stdinW.Write(input.Stdin)
 			}
 
@@ -297,12 +319,17 @@ func (s *Server) hijack(hLog lager.Logger, conn *websocket.Conn, request hijackR
 			errs <- idle.Error()
 
 		case output := <-outputs:
+			recorder.Record(db.HijackSessionEventOutput, output.Stdout)
+			recorder.Record(db.HijackSessionEventOutput, output.Stderr)
+
 			err := conn.WriteJSON(output)
 			if err != nil {
 				return
 			}
 
 		case status := <-exited:
+			exitStatus = &status
+
 			_ = conn.WriteJSON(atc.HijackOutput{
 				ExitStatus: &status,
 			})
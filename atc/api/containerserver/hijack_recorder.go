@@ -0,0 +1,156 @@
+package containerserver
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+const (
+	hijackRecordingBatchSize     = 100
+	hijackRecordingFlushInterval = 5 * time.Second
+)
+
+// hijackRecorder buffers the events of a hijack session and saves them in
+// batches from a separate goroutine, so that the hijack loop never waits on
+// the database. A nil recorder records nothing, so that the hijack loop does
+// not have to care whether recording is enabled.
+type hijackRecorder struct {
+	logger  lager.Logger
+	factory db.HijackSessionFactory
+
+	sessionID int
+	started   time.Time
+
+	eventsL sync.Mutex
+	events  []db.HijackSessionEvent
+
+	full chan struct{}
+	stop chan struct{}
+	done chan struct{}
+}
+
+func (s *Server) startRecording(logger lager.Logger, request hijackRequest) (*hijackRecorder, error) {
+	if !s.recordHijackSessions {
+		return nil, nil
+	}
+
+	session := db.HijackSession{
+		TeamID:          request.TeamID,
+		ContainerHandle: request.Container.Handle(),
+		WorkerName:      request.Container.WorkerName(),
+		Username:        request.Username,
+		Path:            request.Process.Path,
+		Args:            request.Process.Args,
+		ProcessUser:     request.Process.User,
+	}
+
+	if request.Process.TTY != nil {
+		session.Width = request.Process.TTY.WindowSize.Columns
+		session.Height = request.Process.TTY.WindowSize.Rows
+	}
+
+	session, err := s.hijackSessionFactory.CreateHijackSession(session)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("recording", lager.Data{"session": session.ID})
+
+	recorder := &hijackRecorder{
+		logger:    logger,
+		factory:   s.hijackSessionFactory,
+		sessionID: session.ID,
+		started:   time.Now(),
+
+		full: make(chan struct{}, 1),
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+
+	go recorder.flushPeriodically()
+
+	return recorder, nil
+}
+
+func (r *hijackRecorder) Record(eventType string, data []byte) {
+	if r == nil || len(data) == 0 {
+		return
+	}
+
+	r.eventsL.Lock()
+	defer r.eventsL.Unlock()
+
+	r.events = append(r.events, db.HijackSessionEvent{
+		Elapsed: time.Since(r.started),
+		Type:    eventType,
+		Data:    data,
+	})
+
+	if len(r.events) >= hijackRecordingBatchSize {
+		select {
+		case r.full <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (r *hijackRecorder) RecordResize(columns, rows int) {
+	r.Record(db.HijackSessionEventResize, []byte(fmt.Sprintf("%dx%d", columns, rows)))
+}
+
+// Finish saves the remaining events and marks the session as ended. The exit
+// status is nil if the process did not exit by itself.
+func (r *hijackRecorder) Finish(exitStatus *int) {
+	if r == nil {
+		return
+	}
+
+	close(r.stop)
+	<-r.done
+
+	err := r.factory.FinishHijackSession(r.sessionID, exitStatus)
+	if err != nil {
+		r.logger.Error("failed-to-finish-hijack-session", err)
+	}
+}
+
+// flushPeriodically saves the buffered events every flush interval, or as
+// soon as a batch is full, until the recorder is finished.
+func (r *hijackRecorder) flushPeriodically() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(hijackRecordingFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.flush()
+		case <-r.full:
+			r.flush()
+		case <-r.stop:
+			r.flush()
+			return
+		}
+	}
+}
+
+func (r *hijackRecorder) flush() {
+	r.eventsL.Lock()
+	events := r.events
+	r.events = nil
+	r.eventsL.Unlock()
+
+	if len(events) == 0 {
+		return
+	}
+
+	err := r.factory.SaveHijackSessionEvents(r.sessionID, events)
+	if err != nil {
+		r.logger.Error("failed-to-save-hijack-session-events", err)
+	}
+}
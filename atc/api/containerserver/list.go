@@ -21,7 +21,7 @@ func (s *Server) ListContainers(team db.Team) http.Handler {
 			"params": params,
 		})
 
-		containerLocator, err := createContainerLocatorFromRequest(team, r, s.secretManager)
+		containerLocator, err := createContainerLocatorFromRequest(team, r, s.secretManager, s.sourceDefaults)
 		if err != nil {
 			hLog.Error("failed-to-parse-request", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
@@ -59,7 +59,7 @@ type containerLocator interface {
 	Locate(logger lager.Logger) ([]db.Container, map[int]time.Time, error)
 }
 
-func createContainerLocatorFromRequest(team db.Team, r *http.Request, secretManager creds.Secrets) (containerLocator, error) {
+func createContainerLocatorFromRequest(team db.Team, r *http.Request, secretManager creds.Secrets, sourceDefaults atc.SourceDefaults) (containerLocator, error) {
 	query := r.URL.Query()
 	delete(query, ":team_name")
 
@@ -71,10 +71,11 @@ func createContainerLocatorFromRequest(team db.Team, r *http.Request, secretMana
 
 	if query.Get("type") == "check" {
 		return &checkContainerLocator{
-			team:          team,
-			pipelineName:  query.Get("pipeline_name"),
-			resourceName:  query.Get("resource_name"),
-			secretManager: secretManager,
+			team:           team,
+			pipelineName:   query.Get("pipeline_name"),
+			resourceName:   query.Get("resource_name"),
+			secretManager:  secretManager,
+			sourceDefaults: sourceDefaults,
 		}, nil
 	}
 
@@ -132,14 +133,15 @@ func (l *allContainersLocator) Locate(logger lager.Logger) ([]db.Container, map[
 }
 
 type checkContainerLocator struct {
-	team          db.Team
-	pipelineName  string
-	resourceName  string
-	secretManager creds.Secrets
+	team           db.Team
+	pipelineName   string
+	resourceName   string
+	secretManager  creds.Secrets
+	sourceDefaults atc.SourceDefaults
 }
 
 func (l *checkContainerLocator) Locate(logger lager.Logger) ([]db.Container, map[int]time.Time, error) {
-	return l.team.FindCheckContainers(logger, l.pipelineName, l.resourceName, l.secretManager)
+	return l.team.FindCheckContainers(logger, l.pipelineName, l.resourceName, l.secretManager, l.sourceDefaults)
 }
 
 type stepContainerLocator struct {
@@ -2,6 +2,7 @@ package containerserver
 
 import (
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/gc"
@@ -13,25 +14,34 @@ type Server struct {
 
 	workerClient            worker.Client
 	secretManager           creds.Secrets
+	sourceDefaults          atc.SourceDefaults
 	interceptTimeoutFactory InterceptTimeoutFactory
 	containerRepository     db.ContainerRepository
 	destroyer               gc.Destroyer
+	hijackSessionFactory    db.HijackSessionFactory
+	recordHijackSessions    bool
 }
 
 func NewServer(
 	logger lager.Logger,
 	workerClient worker.Client,
 	secretManager creds.Secrets,
+	sourceDefaults atc.SourceDefaults,
 	interceptTimeoutFactory InterceptTimeoutFactory,
 	containerRepository db.ContainerRepository,
 	destroyer gc.Destroyer,
+	hijackSessionFactory db.HijackSessionFactory,
+	recordHijackSessions bool,
 ) *Server {
 	return &Server{
 		logger:                  logger,
 		workerClient:            workerClient,
 		secretManager:           secretManager,
+		sourceDefaults:          sourceDefaults,
 		interceptTimeoutFactory: interceptTimeoutFactory,
 		containerRepository:     containerRepository,
 		destroyer:               destroyer,
+		hijackSessionFactory:    hijackSessionFactory,
+		recordHijackSessions:    recordHijackSessions,
 	}
 }
@@ -6,25 +6,34 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/apitokenserver"
 	"github.com/concourse/concourse/atc/api/artifactserver"
+	"github.com/concourse/concourse/atc/api/auditserver"
 	"github.com/concourse/concourse/atc/api/buildserver"
 	"github.com/concourse/concourse/atc/api/ccserver"
 	"github.com/concourse/concourse/atc/api/cliserver"
 	"github.com/concourse/concourse/atc/api/configserver"
 	"github.com/concourse/concourse/atc/api/containerserver"
+	"github.com/concourse/concourse/atc/api/hijacksessionserver"
 	"github.com/concourse/concourse/atc/api/infoserver"
 	"github.com/concourse/concourse/atc/api/jobserver"
+	"github.com/concourse/concourse/atc/api/localuserserver"
+	"github.com/concourse/concourse/atc/api/lockserver"
 	"github.com/concourse/concourse/atc/api/loglevelserver"
 	"github.com/concourse/concourse/atc/api/pipelineserver"
 	"github.com/concourse/concourse/atc/api/resourceserver"
 	"github.com/concourse/concourse/atc/api/resourceserver/versionserver"
+	"github.com/concourse/concourse/atc/api/statusserver"
 	"github.com/concourse/concourse/atc/api/teamserver"
 	"github.com/concourse/concourse/atc/api/volumeserver"
+	"github.com/concourse/concourse/atc/api/workerkeyserver"
 	"github.com/concourse/concourse/atc/api/workerserver"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/gc"
+	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/mainredirect"
+	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/wrappa"
 	"github.com/tedsuo/rata"
@@ -47,6 +56,13 @@ func NewHandler(
 	destroyer gc.Destroyer,
 	dbBuildFactory db.BuildFactory,
 	dbResourceConfigFactory db.ResourceConfigFactory,
+	dbAPITokenFactory db.APITokenFactory,
+	dbLocalUserFactory db.LocalUserFactory,
+	dbAuditEventFactory db.AuditEventFactory,
+	dbWorkerKeyFactory db.WorkerKeyFactory,
+	dbStatusEventFactory db.StatusEventFactory,
+	dbNamedLockFactory db.NamedLockFactory,
+	dbHijackSessionFactory db.HijackSessionFactory,
 
 	eventHandlerFactory buildserver.EventHandlerFactory,
 
@@ -62,8 +78,12 @@ func NewHandler(
 	version string,
 	workerVersion string,
 	secretManager creds.Secrets,
+	sourceDefaults atc.SourceDefaults,
 	credsManagers creds.Managers,
 	interceptTimeoutFactory containerserver.InterceptTimeoutFactory,
+	recordHijackSessions bool,
+	policyChecker policy.Checker,
+	metricsRegistry *metric.Registry,
 ) (http.Handler, error) {
 
 	absCLIDownloadsDir, err := filepath.Abs(cliDownloadsDir)
@@ -81,20 +101,30 @@ func NewHandler(
 
 	versionServer := versionserver.NewServer(logger, externalURL)
 	pipelineServer := pipelineserver.NewServer(logger, dbTeamFactory, dbPipelineFactory, externalURL)
-	configServer := configserver.NewServer(logger, dbTeamFactory, secretManager)
+	configServer := configserver.NewServer(logger, dbTeamFactory, secretManager, policyChecker)
 	ccServer := ccserver.NewServer(logger, dbTeamFactory, externalURL)
-	workerServer := workerserver.NewServer(logger, dbTeamFactory, dbWorkerFactory)
+	workerServer := workerserver.NewServer(logger, dbTeamFactory, dbWorkerFactory, metricsRegistry)
 	logLevelServer := loglevelserver.NewServer(logger, sink)
 	cliServer := cliserver.NewServer(logger, absCLIDownloadsDir)
-	containerServer := containerserver.NewServer(logger, workerClient, secretManager, interceptTimeoutFactory, containerRepository, destroyer)
-	volumesServer := volumeserver.NewServer(logger, volumeRepository, destroyer)
-	teamServer := teamserver.NewServer(logger, dbTeamFactory, externalURL)
+	containerServer := containerserver.NewServer(logger, workerClient, secretManager, sourceDefaults, interceptTimeoutFactory, containerRepository, destroyer, dbHijackSessionFactory, recordHijackSessions)
+	volumesServer := volumeserver.NewServer(logger, volumeRepository, destroyer, metricsRegistry)
+	teamServer := teamserver.NewServer(logger, dbTeamFactory, externalURL, policyChecker)
 	infoServer := infoserver.NewServer(logger, version, workerVersion, credsManagers)
 	artifactServer := artifactserver.NewServer(logger, workerClient)
+	apiTokenServer := apitokenserver.NewServer(logger, dbAPITokenFactory)
+	localUserServer := localuserserver.NewServer(logger, dbLocalUserFactory)
+	auditServer := auditserver.NewServer(logger, externalURL, dbAuditEventFactory)
+	workerKeyServer := workerkeyserver.NewServer(logger, dbWorkerKeyFactory)
+	statusServer := statusserver.NewServer(logger, dbStatusEventFactory)
+	lockServer := lockserver.NewServer(logger, dbNamedLockFactory)
+	hijackSessionServer := hijacksessionserver.NewServer(logger, dbHijackSessionFactory)
 
 	handlers := map[string]http.Handler{
-		atc.GetConfig:  http.HandlerFunc(configServer.GetConfig),
-		atc.SaveConfig: http.HandlerFunc(configServer.SaveConfig),
+		atc.GetConfig:          http.HandlerFunc(configServer.GetConfig),
+		atc.SaveConfig:         http.HandlerFunc(configServer.SaveConfig),
+		atc.ListConfigVersions: http.HandlerFunc(configServer.ListConfigVersions),
+		atc.GetConfigVersion:   http.HandlerFunc(configServer.GetConfigVersion),
+		atc.DiffConfigVersions: http.HandlerFunc(configServer.DiffConfigVersions),
 
 		atc.GetCC: http.HandlerFunc(ccServer.GetCC),
 
@@ -104,6 +134,7 @@ func NewHandler(
 		atc.BuildResources:      buildHandlerFactory.HandlerFor(buildServer.BuildResources),
 		atc.AbortBuild:          buildHandlerFactory.HandlerFor(buildServer.AbortBuild),
 		atc.GetBuildPlan:        buildHandlerFactory.HandlerFor(buildServer.GetBuildPlan),
+		atc.GetBuildTimeline:    buildHandlerFactory.HandlerFor(buildServer.GetBuildTimeline),
 		atc.GetBuildPreparation: buildHandlerFactory.HandlerFor(buildServer.GetBuildPreparation),
 		atc.BuildEvents:         buildHandlerFactory.HandlerFor(buildServer.BuildEvents),
 		atc.ListBuildArtifacts:  buildHandlerFactory.HandlerFor(buildServer.GetBuildArtifacts),
@@ -159,13 +190,14 @@ func NewHandler(
 		atc.ListBuildsWithVersionAsOutput: pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsOutput),
 		atc.GetResourceCausality:          pipelineHandlerFactory.HandlerFor(versionServer.GetCausality),
 
-		atc.ListWorkers:     http.HandlerFunc(workerServer.ListWorkers),
-		atc.RegisterWorker:  http.HandlerFunc(workerServer.RegisterWorker),
-		atc.LandWorker:      http.HandlerFunc(workerServer.LandWorker),
-		atc.RetireWorker:    http.HandlerFunc(workerServer.RetireWorker),
-		atc.PruneWorker:     http.HandlerFunc(workerServer.PruneWorker),
-		atc.HeartbeatWorker: http.HandlerFunc(workerServer.HeartbeatWorker),
-		atc.DeleteWorker:    http.HandlerFunc(workerServer.DeleteWorker),
+		atc.ListWorkers:        http.HandlerFunc(workerServer.ListWorkers),
+		atc.RegisterWorker:     http.HandlerFunc(workerServer.RegisterWorker),
+		atc.LandWorker:         http.HandlerFunc(workerServer.LandWorker),
+		atc.RetireWorker:       http.HandlerFunc(workerServer.RetireWorker),
+		atc.UnquarantineWorker: http.HandlerFunc(workerServer.UnquarantineWorker),
+		atc.PruneWorker:        http.HandlerFunc(workerServer.PruneWorker),
+		atc.HeartbeatWorker:    http.HandlerFunc(workerServer.HeartbeatWorker),
+		atc.DeleteWorker:       http.HandlerFunc(workerServer.DeleteWorker),
 
 		atc.SetLogLevel: http.HandlerFunc(logLevelServer.SetMinLevel),
 		atc.GetLogLevel: http.HandlerFunc(logLevelServer.GetMinLevel),
@@ -174,6 +206,14 @@ func NewHandler(
 		atc.GetInfo:      http.HandlerFunc(infoServer.Info),
 		atc.GetInfoCreds: http.HandlerFunc(infoServer.Creds),
 
+		atc.ListLocalUsers:       http.HandlerFunc(localUserServer.ListLocalUsers),
+		atc.CreateLocalUser:      http.HandlerFunc(localUserServer.CreateLocalUser),
+		atc.SetLocalUserPassword: http.HandlerFunc(localUserServer.SetLocalUserPassword),
+		atc.DisableLocalUser:     http.HandlerFunc(localUserServer.DisableLocalUser),
+		atc.EnableLocalUser:      http.HandlerFunc(localUserServer.EnableLocalUser),
+		atc.ListAuditEvents:      http.HandlerFunc(auditServer.ListAuditEvents),
+		atc.ListTeamAuditEvents:  teamHandlerFactory.HandlerFor(auditServer.ListTeamAuditEvents),
+
 		atc.ListContainers:           teamHandlerFactory.HandlerFor(containerServer.ListContainers),
 		atc.GetContainer:             teamHandlerFactory.HandlerFor(containerServer.GetContainer),
 		atc.HijackContainer:          teamHandlerFactory.HandlerFor(containerServer.HijackContainer),
@@ -193,6 +233,25 @@ func NewHandler(
 
 		atc.CreateArtifact: teamHandlerFactory.HandlerFor(artifactServer.CreateArtifact),
 		atc.GetArtifact:    teamHandlerFactory.HandlerFor(artifactServer.GetArtifact),
+
+		atc.CreateAPIToken: teamHandlerFactory.HandlerFor(apiTokenServer.CreateAPIToken),
+		atc.ListAPITokens:  teamHandlerFactory.HandlerFor(apiTokenServer.ListAPITokens),
+		atc.RevokeAPIToken: teamHandlerFactory.HandlerFor(apiTokenServer.RevokeAPIToken),
+
+		atc.ListWorkerKeys:      http.HandlerFunc(workerKeyServer.ListWorkerKeys),
+		atc.CreateWorkerKey:     http.HandlerFunc(workerKeyServer.CreateWorkerKey),
+		atc.DeleteWorkerKey:     http.HandlerFunc(workerKeyServer.DeleteWorkerKey),
+		atc.ListTeamWorkerKeys:  teamHandlerFactory.HandlerFor(workerKeyServer.ListTeamWorkerKeys),
+		atc.CreateTeamWorkerKey: teamHandlerFactory.HandlerFor(workerKeyServer.CreateTeamWorkerKey),
+		atc.DeleteTeamWorkerKey: teamHandlerFactory.HandlerFor(workerKeyServer.DeleteTeamWorkerKey),
+
+		atc.StatusEvents: http.HandlerFunc(statusServer.StatusEvents),
+
+		atc.ListNamedLocks:   teamHandlerFactory.HandlerFor(lockServer.ListNamedLocks),
+		atc.ReleaseNamedLock: teamHandlerFactory.HandlerFor(lockServer.ReleaseNamedLock),
+
+		atc.ListHijackSessions:        teamHandlerFactory.HandlerFor(hijackSessionServer.ListHijackSessions),
+		atc.GetHijackSessionRecording: teamHandlerFactory.HandlerFor(hijackSessionServer.GetHijackSessionRecording),
 	}
 
 	return rata.NewRouter(atc.Routes, wrapper.Wrap(handlers))
@@ -0,0 +1,239 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Hijack Sessions API", func() {
+	var response *http.Response
+
+	BeforeEach(func() {
+		dbTeam.NameReturns("some-team")
+		fakeAccess.IsAuthenticatedReturns(true)
+		fakeAccess.IsAuthorizedReturns(true)
+	})
+
+	Describe("GET /api/v1/teams/:team_name/hijack-sessions", func() {
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/hijack-sessions")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when getting the sessions succeeds", func() {
+			BeforeEach(func() {
+				exitStatus := 1
+
+				dbHijackSessionFactory.HijackSessionsReturns([]db.HijackSession{
+					{
+						ID:              2,
+						TeamName:        "some-team",
+						ContainerHandle: "some-handle",
+						WorkerName:      "some-worker",
+						Username:        "some-user",
+						Path:            "bash",
+						ProcessUser:     "root",
+						StartedAt:       time.Unix(200, 0),
+					},
+					{
+						ID:              1,
+						TeamName:        "some-team",
+						ContainerHandle: "other-handle",
+						WorkerName:      "some-worker",
+						Username:        "other-user",
+						Path:            "sh",
+						Args:            []string{"-c", "env"},
+						StartedAt:       time.Unix(100, 0),
+						EndedAt:         time.Unix(110, 0),
+						ExitStatus:      &exitStatus,
+					},
+				}, nil)
+			})
+
+			It("returns the team's sessions", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				Expect(dbHijackSessionFactory.HijackSessionsArgsForCall(0)).To(Equal(734))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"id": 2,
+						"team_name": "some-team",
+						"container_handle": "some-handle",
+						"worker_name": "some-worker",
+						"username": "some-user",
+						"path": "bash",
+						"process_user": "root",
+						"started_at": 200
+					},
+					{
+						"id": 1,
+						"team_name": "some-team",
+						"container_handle": "other-handle",
+						"worker_name": "some-worker",
+						"username": "other-user",
+						"path": "sh",
+						"args": ["-c", "env"],
+						"started_at": 100,
+						"ended_at": 110,
+						"exit_status": 1
+					}
+				]`))
+			})
+		})
+
+		Context("when getting the sessions fails", func() {
+			BeforeEach(func() {
+				dbHijackSessionFactory.HijackSessionsReturns(nil, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/hijack-sessions/:session_id/recording", func() {
+		var sessionID string
+
+		BeforeEach(func() {
+			sessionID = "42"
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/hijack-sessions/" + sessionID + "/recording")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403 without looking up the session", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbHijackSessionFactory.HijackSessionCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the session id is not a number", func() {
+			BeforeEach(func() {
+				sessionID = "nope"
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("when the session exists", func() {
+			BeforeEach(func() {
+				dbHijackSessionFactory.HijackSessionReturns(db.HijackSession{
+					ID:              42,
+					ContainerHandle: "some-handle",
+					Username:        "some-user",
+					Path:            "bash",
+					Args:            []string{"-l"},
+					Width:           100,
+					Height:          30,
+					StartedAt:       time.Unix(100, 0),
+					EndedAt:         time.Unix(160, 0),
+				}, true, nil)
+
+				dbHijackSessionFactory.HijackSessionEventsReturns([]db.HijackSessionEvent{
+					{Elapsed: 500 * time.Millisecond, Type: db.HijackSessionEventInput, Data: []byte("ls\n")},
+					{Elapsed: time.Second, Type: db.HijackSessionEventOutput, Data: []byte("some-file\n")},
+				}, nil)
+			})
+
+			It("returns the session as an asciicast recording", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/x-asciicast"))
+
+				teamID, id := dbHijackSessionFactory.HijackSessionArgsForCall(0)
+				Expect(teamID).To(Equal(734))
+				Expect(id).To(Equal(42))
+
+				Expect(dbHijackSessionFactory.HijackSessionEventsArgsForCall(0)).To(Equal(42))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(string(body)).To(Equal(
+					`{"version":2,"width":100,"height":30,"timestamp":100,"duration":60,"title":"some-user@some-handle: bash -l"}` + "\n" +
+						`[0.5,"i","ls\n"]` + "\n" +
+						`[1,"o","some-file\n"]` + "\n",
+				))
+			})
+
+			Context("when the session has no terminal size", func() {
+				BeforeEach(func() {
+					dbHijackSessionFactory.HijackSessionReturns(db.HijackSession{
+						ID:        42,
+						Path:      "env",
+						StartedAt: time.Unix(100, 0),
+					}, true, nil)
+				})
+
+				It("uses a default size", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(string(body)).To(HavePrefix(`{"version":2,"width":80,"height":24,"timestamp":100,`))
+				})
+			})
+
+			Context("when getting the events fails", func() {
+				BeforeEach(func() {
+					dbHijackSessionFactory.HijackSessionEventsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when the session does not exist", func() {
+			BeforeEach(func() {
+				dbHijackSessionFactory.HijackSessionReturns(db.HijackSession{}, false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when getting the session fails", func() {
+			BeforeEach(func() {
+				dbHijackSessionFactory.HijackSessionReturns(db.HijackSession{}, false, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+})
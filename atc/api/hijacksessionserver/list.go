@@ -0,0 +1,36 @@
+package hijacksessionserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListHijackSessions(team db.Team) http.Handler {
+	hLog := s.logger.Session("list-hijack-sessions")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sessions, err := s.hijackSessionFactory.HijackSessions(team.ID())
+		if err != nil {
+			hLog.Error("failed-to-get-hijack-sessions", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presentedSessions := []atc.HijackSession{}
+		for _, session := range sessions {
+			presentedSessions = append(presentedSessions, present.HijackSession(session))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(presentedSessions)
+		if err != nil {
+			hLog.Error("failed-to-encode-hijack-sessions", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
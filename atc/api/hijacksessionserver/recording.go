@@ -0,0 +1,95 @@
+package hijacksessionserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+// asciicastHeader is the first line of an asciicast v2 recording. The rest of
+// the lines are events of the form [time, type, data].
+type asciicastHeader struct {
+	Version   int    `json:"version"`
+	Width     int    `json:"width"`
+	Height    int    `json:"height"`
+	Timestamp int64  `json:"timestamp"`
+	Duration  int64  `json:"duration,omitempty"`
+	Title     string `json:"title"`
+}
+
+// GetHijackSessionRecording responds with the session as an asciicast v2
+// recording, which can be replayed with fly or asciinema.
+func (s *Server) GetHijackSessionRecording(team db.Team) http.Handler {
+	hLog := s.logger.Session("get-hijack-session-recording")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sessionID, err := strconv.Atoi(r.FormValue(":session_id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		session, found, err := s.hijackSessionFactory.HijackSession(team.ID(), sessionID)
+		if err != nil {
+			hLog.Error("failed-to-get-hijack-session", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		events, err := s.hijackSessionFactory.HijackSessionEvents(session.ID)
+		if err != nil {
+			hLog.Error("failed-to-get-hijack-session-events", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		header := asciicastHeader{
+			Version:   2,
+			Width:     session.Width,
+			Height:    session.Height,
+			Timestamp: session.StartedAt.Unix(),
+			Title: session.Username + "@" + session.ContainerHandle + ": " +
+				strings.Join(append([]string{session.Path}, session.Args...), " "),
+		}
+
+		// sessions without a tty have no size, but players need one
+		if header.Width == 0 || header.Height == 0 {
+			header.Width = 80
+			header.Height = 24
+		}
+
+		if !session.EndedAt.IsZero() {
+			header.Duration = int64(session.EndedAt.Sub(session.StartedAt).Seconds())
+		}
+
+		w.Header().Set("Content-Type", "application/x-asciicast")
+
+		encoder := json.NewEncoder(w)
+
+		err = encoder.Encode(header)
+		if err != nil {
+			hLog.Error("failed-to-encode-recording", err)
+			return
+		}
+
+		for _, event := range events {
+			err = encoder.Encode([]interface{}{
+				event.Elapsed.Seconds(),
+				event.Type,
+				string(event.Data),
+			})
+			if err != nil {
+				hLog.Error("failed-to-encode-recording", err)
+				return
+			}
+		}
+	})
+}
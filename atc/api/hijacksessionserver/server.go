@@ -0,0 +1,21 @@
+package hijacksessionserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger               lager.Logger
+	hijackSessionFactory db.HijackSessionFactory
+}
+
+func NewServer(
+	logger lager.Logger,
+	hijackSessionFactory db.HijackSessionFactory,
+) *Server {
+	return &Server{
+		logger:               logger,
+		hijackSessionFactory: hijackSessionFactory,
+	}
+}
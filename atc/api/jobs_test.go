@@ -467,6 +467,24 @@ var _ = Describe("Jobs API", func() {
 
 					})
 
+					Context("when the job has a schedule", func() {
+						BeforeEach(func() {
+							fakeJob.ConfigReturns(atc.JobConfig{
+								Name:     "some-job",
+								Schedule: &atc.ScheduleConfig{Cron: "0 * * * *"},
+							})
+							fakeJob.ScheduleFiredAtReturns(time.Date(2019, 5, 10, 12, 0, 0, 0, time.UTC))
+						})
+
+						It("returns the next time the schedule fires", func() {
+							var job atc.Job
+							err := json.NewDecoder(response.Body).Decode(&job)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(job.NextScheduledBuild).To(Equal(time.Date(2019, 5, 10, 13, 0, 0, 0, time.UTC).Unix()))
+						})
+					})
+
 					Context("when there are no running or finished builds", func() {
 						BeforeEach(func() {
 							fakeJob.FinishedAndNextBuildReturns(nil, nil, nil)
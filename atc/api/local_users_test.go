@@ -0,0 +1,243 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var _ = Describe("Local Users API", func() {
+	var response *http.Response
+
+	BeforeEach(func() {
+		fakeAccess.IsAuthenticatedReturns(true)
+		fakeAccess.IsAdminReturns(true)
+	})
+
+	Describe("GET /api/v1/users/local", func() {
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/users/local")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when listing users succeeds", func() {
+			BeforeEach(func() {
+				dbLocalUserFactory.LocalUsersReturns([]db.LocalUser{
+					{
+						Username:     "some-user",
+						PasswordHash: []byte("some-hash"),
+						Disabled:     true,
+						CreatedAt:    time.Unix(100, 0),
+						UpdatedAt:    time.Unix(200, 0),
+					},
+				}, nil)
+			})
+
+			It("returns the users without their password hashes", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[{
+					"username": "some-user",
+					"disabled": true,
+					"created_at": 100,
+					"updated_at": 200
+				}]`))
+			})
+		})
+
+		Context("when listing users fails", func() {
+			BeforeEach(func() {
+				dbLocalUserFactory.LocalUsersReturns(nil, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
+	Describe("POST /api/v1/users/local", func() {
+		var userRequest atc.LocalUserRequest
+
+		BeforeEach(func() {
+			userRequest = atc.LocalUserRequest{
+				Username: "some-user",
+				Password: "some-password",
+			}
+		})
+
+		JustBeforeEach(func() {
+			payload, err := json.Marshal(userRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Post(server.URL+"/api/v1/users/local", "application/json", bytes.NewBuffer(payload))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbLocalUserFactory.CreateLocalUserCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when creating the user succeeds", func() {
+			BeforeEach(func() {
+				dbLocalUserFactory.CreateLocalUserReturns(db.LocalUser{
+					Username:  "some-user",
+					CreatedAt: time.Unix(100, 0),
+					UpdatedAt: time.Unix(100, 0),
+				}, nil)
+			})
+
+			It("returns 201", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusCreated))
+			})
+
+			It("stores a bcrypt hash of the password", func() {
+				Expect(dbLocalUserFactory.CreateLocalUserCallCount()).To(Equal(1))
+
+				username, hash := dbLocalUserFactory.CreateLocalUserArgsForCall(0)
+				Expect(username).To(Equal("some-user"))
+				Expect(bcrypt.CompareHashAndPassword(hash, []byte("some-password"))).To(Succeed())
+			})
+		})
+
+		Context("when the password is missing", func() {
+			BeforeEach(func() {
+				userRequest.Password = ""
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(dbLocalUserFactory.CreateLocalUserCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the user already exists", func() {
+			BeforeEach(func() {
+				dbLocalUserFactory.CreateLocalUserReturns(db.LocalUser{}, db.ErrLocalUserAlreadyExists)
+			})
+
+			It("returns 409", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusConflict))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/users/local/:username/password", func() {
+		JustBeforeEach(func() {
+			payload, err := json.Marshal(atc.LocalUserRequest{Password: "new-password"})
+			Expect(err).NotTo(HaveOccurred())
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/users/local/some-user/password", bytes.NewBuffer(payload))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the user exists", func() {
+			BeforeEach(func() {
+				dbLocalUserFactory.SetLocalUserPasswordReturns(true, nil)
+			})
+
+			It("updates the password hash", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				username, hash := dbLocalUserFactory.SetLocalUserPasswordArgsForCall(0)
+				Expect(username).To(Equal("some-user"))
+				Expect(bcrypt.CompareHashAndPassword(hash, []byte("new-password"))).To(Succeed())
+			})
+		})
+
+		Context("when the user does not exist", func() {
+			BeforeEach(func() {
+				dbLocalUserFactory.SetLocalUserPasswordReturns(false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/users/local/:username/disable", func() {
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/users/local/some-user/disable", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the user exists", func() {
+			BeforeEach(func() {
+				dbLocalUserFactory.SetLocalUserDisabledReturns(true, nil)
+			})
+
+			It("disables the user", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				username, disabled := dbLocalUserFactory.SetLocalUserDisabledArgsForCall(0)
+				Expect(username).To(Equal("some-user"))
+				Expect(disabled).To(BeTrue())
+			})
+		})
+
+		Context("when the user does not exist", func() {
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/users/local/:username/enable", func() {
+		BeforeEach(func() {
+			dbLocalUserFactory.SetLocalUserDisabledReturns(true, nil)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/users/local/some-user/enable", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("enables the user", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+			_, disabled := dbLocalUserFactory.SetLocalUserDisabledArgsForCall(0)
+			Expect(disabled).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,60 @@
+package localuserserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func (s *Server) CreateLocalUser(w http.ResponseWriter, r *http.Request) {
+	hLog := s.logger.Session("create-local-user")
+
+	var req atc.LocalUserRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		hLog.Error("malformed-request", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if req.Username == "" || req.Password == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "username and password must be specified")
+		return
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		hLog.Error("failed-to-hash-password", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	user, err := s.localUserFactory.CreateLocalUser(req.Username, hash)
+	if err == db.ErrLocalUserAlreadyExists {
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+
+	if err != nil {
+		hLog.Error("failed-to-create-local-user", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	hLog.Info("created", lager.Data{"username": user.Username})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	err = json.NewEncoder(w).Encode(present.LocalUser(user))
+	if err != nil {
+		hLog.Error("failed-to-encode-local-user", err)
+	}
+}
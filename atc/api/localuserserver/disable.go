@@ -0,0 +1,37 @@
+package localuserserver
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+)
+
+func (s *Server) DisableLocalUser(w http.ResponseWriter, r *http.Request) {
+	s.setDisabled(w, r, true)
+}
+
+func (s *Server) EnableLocalUser(w http.ResponseWriter, r *http.Request) {
+	s.setDisabled(w, r, false)
+}
+
+func (s *Server) setDisabled(w http.ResponseWriter, r *http.Request, disabled bool) {
+	hLog := s.logger.Session("set-local-user-disabled")
+
+	username := r.FormValue(":username")
+
+	found, err := s.localUserFactory.SetLocalUserDisabled(username, disabled)
+	if err != nil {
+		hLog.Error("failed-to-set-local-user-disabled", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	hLog.Info("updated", lager.Data{"username": username, "disabled": disabled})
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,33 @@
+package localuserserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+)
+
+func (s *Server) ListLocalUsers(w http.ResponseWriter, r *http.Request) {
+	hLog := s.logger.Session("list-local-users")
+
+	users, err := s.localUserFactory.LocalUsers()
+	if err != nil {
+		hLog.Error("failed-to-get-local-users", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	presentedUsers := []atc.LocalUser{}
+	for _, user := range users {
+		presentedUsers = append(presentedUsers, present.LocalUser(user))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(presentedUsers)
+	if err != nil {
+		hLog.Error("failed-to-encode-local-users", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,21 @@
+package localuserserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger           lager.Logger
+	localUserFactory db.LocalUserFactory
+}
+
+func NewServer(
+	logger lager.Logger,
+	localUserFactory db.LocalUserFactory,
+) *Server {
+	return &Server{
+		logger:           logger,
+		localUserFactory: localUserFactory,
+	}
+}
@@ -0,0 +1,54 @@
+package localuserserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func (s *Server) SetLocalUserPassword(w http.ResponseWriter, r *http.Request) {
+	hLog := s.logger.Session("set-local-user-password")
+
+	username := r.FormValue(":username")
+
+	var req atc.LocalUserRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		hLog.Error("malformed-request", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if req.Password == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "password must be specified")
+		return
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		hLog.Error("failed-to-hash-password", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	found, err := s.localUserFactory.SetLocalUserPassword(username, hash)
+	if err != nil {
+		hLog.Error("failed-to-set-local-user-password", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	hLog.Info("reset", lager.Data{"username": username})
+
+	w.WriteHeader(http.StatusOK)
+}
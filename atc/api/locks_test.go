@@ -0,0 +1,179 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Named Locks API", func() {
+	var response *http.Response
+
+	BeforeEach(func() {
+		dbTeam.NameReturns("some-team")
+		fakeAccess.IsAuthenticatedReturns(true)
+		fakeAccess.IsAuthorizedReturns(true)
+	})
+
+	Describe("GET /api/v1/teams/:team_name/locks", func() {
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/locks")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when getting the locks succeeds", func() {
+			BeforeEach(func() {
+				dbNamedLockFactory.NamedLocksReturns([]db.NamedLock{
+					{
+						Name:     "some-lock",
+						Capacity: 1,
+						Holders: []db.NamedLockClaim{
+							{
+								ID:           1,
+								BuildID:      42,
+								BuildName:    "7",
+								JobName:      "some-job",
+								PipelineName: "some-pipeline",
+								ClaimedAt:    time.Unix(100, 0),
+								AcquiredAt:   time.Unix(110, 0),
+							},
+						},
+						Waiters: []db.NamedLockClaim{
+							{
+								ID:        2,
+								BuildID:   43,
+								BuildName: "43",
+								ClaimedAt: time.Unix(200, 0),
+							},
+						},
+					},
+					{
+						Name:     "other-lock",
+						Capacity: 2,
+					},
+				}, nil)
+			})
+
+			It("returns the team's locks", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				Expect(dbNamedLockFactory.NamedLocksArgsForCall(0)).To(Equal(734))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"name": "some-lock",
+						"capacity": 1,
+						"holders": [
+							{
+								"build_id": 42,
+								"build_name": "7",
+								"job_name": "some-job",
+								"pipeline_name": "some-pipeline",
+								"claimed_at": 100,
+								"acquired_at": 110
+							}
+						],
+						"waiters": [
+							{
+								"build_id": 43,
+								"build_name": "43",
+								"claimed_at": 200
+							}
+						]
+					},
+					{
+						"name": "other-lock",
+						"capacity": 2,
+						"holders": [],
+						"waiters": []
+					}
+				]`))
+			})
+		})
+
+		Context("when getting the locks fails", func() {
+			BeforeEach(func() {
+				dbNamedLockFactory.NamedLocksReturns(nil, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/locks/:lock_name/release", func() {
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/some-team/locks/some-lock/release", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403 without releasing the lock", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbNamedLockFactory.ForceReleaseNamedLockCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the lock exists", func() {
+			BeforeEach(func() {
+				dbNamedLockFactory.ForceReleaseNamedLockReturns(true, nil)
+			})
+
+			It("releases the lock", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				teamID, name := dbNamedLockFactory.ForceReleaseNamedLockArgsForCall(0)
+				Expect(teamID).To(Equal(734))
+				Expect(name).To(Equal("some-lock"))
+			})
+		})
+
+		Context("when the lock does not exist", func() {
+			BeforeEach(func() {
+				dbNamedLockFactory.ForceReleaseNamedLockReturns(false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when releasing the lock fails", func() {
+			BeforeEach(func() {
+				dbNamedLockFactory.ForceReleaseNamedLockReturns(false, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+})
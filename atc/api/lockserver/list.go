@@ -0,0 +1,36 @@
+package lockserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListNamedLocks(team db.Team) http.Handler {
+	hLog := s.logger.Session("list-named-locks")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locks, err := s.namedLockFactory.NamedLocks(team.ID())
+		if err != nil {
+			hLog.Error("failed-to-get-named-locks", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presentedLocks := []atc.NamedLock{}
+		for _, lock := range locks {
+			presentedLocks = append(presentedLocks, present.NamedLock(lock))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(presentedLocks)
+		if err != nil {
+			hLog.Error("failed-to-encode-named-locks", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
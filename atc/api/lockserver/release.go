@@ -0,0 +1,35 @@
+package lockserver
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ReleaseNamedLock(team db.Team) http.Handler {
+	hLog := s.logger.Session("release-named-lock")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lockName := r.FormValue(":lock_name")
+
+		found, err := s.namedLockFactory.ForceReleaseNamedLock(team.ID(), lockName)
+		if err != nil {
+			hLog.Error("failed-to-release-named-lock", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		hLog.Info("released", lager.Data{
+			"team": team.Name(),
+			"lock": lockName,
+		})
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -0,0 +1,21 @@
+package lockserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger           lager.Logger
+	namedLockFactory db.NamedLockFactory
+}
+
+func NewServer(
+	logger lager.Logger,
+	namedLockFactory db.NamedLockFactory,
+) *Server {
+	return &Server{
+		logger:           logger,
+		namedLockFactory: namedLockFactory,
+	}
+}
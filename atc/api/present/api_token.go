@@ -0,0 +1,19 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func APIToken(token db.APIToken) atc.APIToken {
+	return atc.APIToken{
+		ID:             token.ID,
+		Name:           token.Name,
+		TeamName:       token.TeamName,
+		Owner:          token.Owner,
+		ServiceAccount: token.ServiceAccount,
+		Roles:          token.Roles,
+		CreatedAt:      token.CreatedAt.Unix(),
+		ExpiresAt:      token.ExpiresAt.Unix(),
+	}
+}
@@ -0,0 +1,20 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func AuditEvent(event db.AuditEvent) atc.AuditEvent {
+	return atc.AuditEvent{
+		ID:        event.ID,
+		Actor:     event.Actor,
+		TeamName:  event.TeamName,
+		Action:    event.Action,
+		Target:    event.Target,
+		RequestID: event.RequestID,
+		SourceIP:  event.SourceIP,
+		Status:    event.Status,
+		CreatedAt: event.CreatedAt.Unix(),
+	}
+}
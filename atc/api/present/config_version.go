@@ -0,0 +1,14 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func ConfigVersion(version db.PipelineConfigVersion) atc.ConfigVersion {
+	return atc.ConfigVersion{
+		Version: int(version.Version),
+		SavedBy: version.SavedBy,
+		SavedAt: version.SavedAt.Unix(),
+	}
+}
@@ -0,0 +1,27 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func HijackSession(session db.HijackSession) atc.HijackSession {
+	presented := atc.HijackSession{
+		ID:              session.ID,
+		TeamName:        session.TeamName,
+		ContainerHandle: session.ContainerHandle,
+		WorkerName:      session.WorkerName,
+		Username:        session.Username,
+		Path:            session.Path,
+		Args:            session.Args,
+		ProcessUser:     session.ProcessUser,
+		StartedAt:       session.StartedAt.Unix(),
+		ExitStatus:      session.ExitStatus,
+	}
+
+	if !session.EndedAt.IsZero() {
+		presented.EndedAt = session.EndedAt.Unix()
+	}
+
+	return presented
+}
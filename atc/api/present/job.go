@@ -1,6 +1,8 @@
 package present
 
 import (
+	"time"
+
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 )
@@ -60,6 +62,7 @@ func Job(
 		NextBuild:            presentedNextBuild,
 		TransitionBuild:      presentedTransitionBuild,
 		HasNewInputs:         job.HasNewInputs(),
+		NextScheduledBuild:   nextScheduledBuild(job),
 
 		Inputs:  sanitizedInputs,
 		Outputs: sanitizedOutputs,
@@ -67,3 +70,27 @@ func Job(
 		Groups: job.Tags(),
 	}
 }
+
+func nextScheduledBuild(job db.Job) int64 {
+	config := job.Config().Schedule
+	if config == nil {
+		return 0
+	}
+
+	schedule, err := atc.ParseJobSchedule(*config)
+	if err != nil {
+		return 0
+	}
+
+	from := job.ScheduleFiredAt()
+	if from.IsZero() {
+		from = time.Now()
+	}
+
+	slot := schedule.Next(from)
+	if slot.IsZero() {
+		return 0
+	}
+
+	return schedule.FireTime(job.ID(), slot).Unix()
+}
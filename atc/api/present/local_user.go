@@ -0,0 +1,15 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func LocalUser(user db.LocalUser) atc.LocalUser {
+	return atc.LocalUser{
+		Username:  user.Username,
+		Disabled:  user.Disabled,
+		CreatedAt: user.CreatedAt.Unix(),
+		UpdatedAt: user.UpdatedAt.Unix(),
+	}
+}
@@ -0,0 +1,41 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func NamedLock(lock db.NamedLock) atc.NamedLock {
+	presented := atc.NamedLock{
+		Name:     lock.Name,
+		Capacity: lock.Capacity,
+		Holders:  []atc.NamedLockClaim{},
+		Waiters:  []atc.NamedLockClaim{},
+	}
+
+	for _, claim := range lock.Holders {
+		presented.Holders = append(presented.Holders, NamedLockClaim(claim))
+	}
+
+	for _, claim := range lock.Waiters {
+		presented.Waiters = append(presented.Waiters, NamedLockClaim(claim))
+	}
+
+	return presented
+}
+
+func NamedLockClaim(claim db.NamedLockClaim) atc.NamedLockClaim {
+	presented := atc.NamedLockClaim{
+		BuildID:      claim.BuildID,
+		BuildName:    claim.BuildName,
+		JobName:      claim.JobName,
+		PipelineName: claim.PipelineName,
+		ClaimedAt:    claim.ClaimedAt.Unix(),
+	}
+
+	if !claim.AcquiredAt.IsZero() {
+		presented.AcquiredAt = claim.AcquiredAt.Unix()
+	}
+
+	return presented
+}
@@ -0,0 +1,41 @@
+package present
+
+import (
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+func StatusEvent(event db.StatusEvent) atc.StatusEvent {
+	statusEvent := atc.StatusEvent{
+		ID:           event.ID,
+		Type:         event.Type,
+		TeamName:     event.TeamName,
+		PipelineName: event.PipelineName,
+		JobName:      event.JobName,
+		Time:         event.CreatedAt.Unix(),
+	}
+
+	if event.BuildID != 0 {
+		apiURL, err := atc.Routes.CreatePathForRoute(atc.GetBuild, rata.Params{
+			"build_id": strconv.Itoa(event.BuildID),
+		})
+		if err != nil {
+			panic("failed to generate url: " + err.Error())
+		}
+
+		statusEvent.Build = &atc.Build{
+			ID:           event.BuildID,
+			Name:         event.BuildName,
+			JobName:      event.JobName,
+			PipelineName: event.PipelineName,
+			TeamName:     event.TeamName,
+			Status:       string(event.BuildStatus),
+			APIURL:       apiURL,
+		}
+	}
+
+	return statusEvent
+}
@@ -6,9 +6,16 @@ import (
 )
 
 func Team(team db.Team) atc.Team {
-	return atc.Team{
+	presentedTeam := atc.Team{
 		ID:   team.ID(),
 		Name: team.Name(),
 		Auth: team.Auth(),
 	}
+
+	quota := team.Quota()
+	if quota != (atc.TeamQuota{}) {
+		presentedTeam.Quota = &quota
+	}
+
+	return presentedTeam
 }
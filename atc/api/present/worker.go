@@ -18,6 +18,10 @@ func Worker(workerInfo db.Worker) atc.Worker {
 	if workerInfo.Version() != nil {
 		version = *workerInfo.Version()
 	}
+	var quarantinedUntil int64
+	if !workerInfo.QuarantinedUntil().IsZero() {
+		quarantinedUntil = workerInfo.QuarantinedUntil().Unix()
+	}
 
 	return atc.Worker{
 		GardenAddr:       gardenAddr,
@@ -30,11 +34,14 @@ func Worker(workerInfo db.Worker) atc.Worker {
 		ResourceTypes:    workerInfo.ResourceTypes(),
 		Platform:         workerInfo.Platform(),
 		Tags:             workerInfo.Tags(),
+		Capabilities:     workerInfo.Capabilities(),
 		Name:             workerInfo.Name(),
 		Team:             workerInfo.TeamName(),
 		State:            string(workerInfo.State()),
 		StartTime:        workerInfo.StartTime(),
 		Version:          version,
 		Ephemeral:        workerInfo.Ephemeral(),
+		QuarantinedUntil: quarantinedUntil,
+		KeyFingerprint:   workerInfo.KeyFingerprint(),
 	}
 }
@@ -19,5 +19,6 @@ func WorkerArtifact(artifact db.WorkerArtifact) atc.WorkerArtifact {
 		Name:      artifact.Name(),
 		BuildID:   artifact.BuildID(),
 		CreatedAt: artifact.CreatedAt().Unix(),
+		Retained:  artifact.Retained(),
 	}
 }
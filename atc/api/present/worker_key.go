@@ -0,0 +1,16 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func WorkerKey(key db.WorkerKey) atc.WorkerKey {
+	return atc.WorkerKey{
+		Name:        key.Name,
+		TeamName:    key.TeamName,
+		PublicKey:   key.PublicKey,
+		Fingerprint: key.Fingerprint,
+		CreatedAt:   key.CreatedAt.Unix(),
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 
 	"code.cloudfoundry.org/lager"
@@ -47,6 +48,18 @@ func (s *Server) ListResourceVersions(pipeline db.Pipeline) http.Handler {
 			limit = atc.PaginationAPIDefaultLimit
 		}
 
+		var filter *atc.VersionFilter
+		urlFilter := r.FormValue(atc.PaginationQueryFilter)
+		if urlFilter != "" {
+			filter, err = atc.ParseVersionFilter(urlFilter)
+			if err != nil {
+				logger.Info("invalid-version-filter", lager.Data{"filter": urlFilter, "error": err.Error()})
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, "invalid version filter: %s", err)
+				return
+			}
+		}
+
 		resource, found, err := pipeline.Resource(resourceName)
 		if err != nil {
 			logger.Error("failed-to-get-resource", err, lager.Data{"resource-name": resourceName})
@@ -60,13 +73,13 @@ func (s *Server) ListResourceVersions(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		versions, pagination, found, err := resource.Versions(db.Page{
+		versions, pagination, found, err := filteredVersions(resource, db.Page{
 			Until: until,
 			Since: since,
 			From:  from,
 			To:    to,
 			Limit: limit,
-		})
+		}, filter)
 		if err != nil {
 			logger.Error("failed-to-get-resource-config-versions", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -79,11 +92,11 @@ func (s *Server) ListResourceVersions(pipeline db.Pipeline) http.Handler {
 		}
 
 		if pagination.Next != nil {
-			s.addNextLink(w, teamName, pipeline.Name(), resourceName, *pagination.Next)
+			s.addNextLink(w, teamName, pipeline.Name(), resourceName, *pagination.Next, urlFilter)
 		}
 
 		if pagination.Previous != nil {
-			s.addPreviousLink(w, teamName, pipeline.Name(), resourceName, *pagination.Previous)
+			s.addPreviousLink(w, teamName, pipeline.Name(), resourceName, *pagination.Previous, urlFilter)
 		}
 
 		w.Header().Set("Content-Type", "application/json")
@@ -103,9 +116,89 @@ func (s *Server) ListResourceVersions(pipeline db.Pipeline) http.Handler {
 	})
 }
 
-func (s *Server) addNextLink(w http.ResponseWriter, teamName, pipelineName, resourceName string, page db.Page) {
+// filteredVersions returns a page of the versions matching the filter. The
+// filter can't be evaluated by the database, so pages of versions are fetched
+// in the direction of the requested page until enough of them match or there
+// are no more versions.
+func filteredVersions(resource db.Resource, page db.Page, filter *atc.VersionFilter) ([]atc.ResourceVersion, db.Pagination, bool, error) {
+	if filter == nil {
+		return resource.Versions(page)
+	}
+
+	newer := page.Until != 0 || page.To != 0
+
+	matching := []atc.ResourceVersion{}
+
+	var pagination db.Pagination
+
+	for fetched := 0; ; fetched++ {
+		versions, versionsPagination, found, err := resource.Versions(page)
+		if err != nil || !found {
+			return nil, db.Pagination{}, found, err
+		}
+
+		matches := []atc.ResourceVersion{}
+		for _, version := range versions {
+			if filter.Matches(version.Version) {
+				matches = append(matches, version)
+			}
+		}
+
+		// pages are ordered newest first, so the versions of pages fetched
+		// towards newer versions go in front; the link away from the fetching
+		// direction comes from the first page, the other from the last
+		if newer {
+			matching = append(matches, matching...)
+
+			if fetched == 0 {
+				pagination.Next = versionsPagination.Next
+			}
+
+			pagination.Previous = versionsPagination.Previous
+		} else {
+			matching = append(matching, matches...)
+
+			if fetched == 0 {
+				pagination.Previous = versionsPagination.Previous
+			}
+
+			pagination.Next = versionsPagination.Next
+		}
+
+		if len(matching) >= page.Limit {
+			break
+		}
+
+		var more *db.Page
+		if newer {
+			more = versionsPagination.Previous
+		} else {
+			more = versionsPagination.Next
+		}
+
+		if more == nil {
+			break
+		}
+
+		page = *more
+	}
+
+	if len(matching) > page.Limit {
+		if newer {
+			matching = matching[len(matching)-page.Limit:]
+			pagination.Previous = &db.Page{Until: matching[0].ID, Limit: page.Limit}
+		} else {
+			matching = matching[:page.Limit]
+			pagination.Next = &db.Page{Since: matching[len(matching)-1].ID, Limit: page.Limit}
+		}
+	}
+
+	return matching, pagination, true, nil
+}
+
+func (s *Server) addNextLink(w http.ResponseWriter, teamName, pipelineName, resourceName string, page db.Page, filter string) {
 	w.Header().Add("Link", fmt.Sprintf(
-		`<%s/api/v1/teams/%s/pipelines/%s/resources/%s/versions?%s=%d&%s=%d>; rel="%s"`,
+		`<%s/api/v1/teams/%s/pipelines/%s/resources/%s/versions?%s=%d&%s=%d%s>; rel="%s"`,
 		s.externalURL,
 		teamName,
 		pipelineName,
@@ -114,13 +207,14 @@ func (s *Server) addNextLink(w http.ResponseWriter, teamName, pipelineName, reso
 		page.Since,
 		atc.PaginationQueryLimit,
 		page.Limit,
+		filterQuery(filter),
 		atc.LinkRelNext,
 	))
 }
 
-func (s *Server) addPreviousLink(w http.ResponseWriter, teamName, pipelineName, resourceName string, page db.Page) {
+func (s *Server) addPreviousLink(w http.ResponseWriter, teamName, pipelineName, resourceName string, page db.Page, filter string) {
 	w.Header().Add("Link", fmt.Sprintf(
-		`<%s/api/v1/teams/%s/pipelines/%s/resources/%s/versions?%s=%d&%s=%d>; rel="%s"`,
+		`<%s/api/v1/teams/%s/pipelines/%s/resources/%s/versions?%s=%d&%s=%d%s>; rel="%s"`,
 		s.externalURL,
 		teamName,
 		pipelineName,
@@ -129,6 +223,15 @@ func (s *Server) addPreviousLink(w http.ResponseWriter, teamName, pipelineName,
 		page.Until,
 		atc.PaginationQueryLimit,
 		page.Limit,
+		filterQuery(filter),
 		atc.LinkRelPrevious,
 	))
 }
+
+func filterQuery(filter string) string {
+	if filter == "" {
+		return ""
+	}
+
+	return "&" + atc.PaginationQueryFilter + "=" + url.QueryEscape(filter)
+}
@@ -0,0 +1,134 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Status Events API", func() {
+	var (
+		request  *http.Request
+		response *http.Response
+
+		fakeSource *dbfakes.FakeStatusEventSource
+	)
+
+	BeforeEach(func() {
+		var err error
+		request, err = http.NewRequest("GET", server.URL+"/api/v1/events?team=some-team&pipeline=some-pipeline&job=some-job", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeAccess.TeamNamesReturns([]string{"some-team", "other-team"})
+
+		fakeSource = new(dbfakes.FakeStatusEventSource)
+		fakeSource.NextReturnsOnCall(0, db.StatusEvent{
+			ID:           3,
+			Type:         atc.StatusEventBuildFinished,
+			TeamName:     "some-team",
+			PipelineName: "some-pipeline",
+			JobName:      "some-job",
+			BuildID:      42,
+			BuildName:    "7",
+			BuildStatus:  db.BuildStatusSucceeded,
+			CreatedAt:    time.Unix(100, 0),
+		}, nil)
+		fakeSource.NextReturnsOnCall(1, db.StatusEvent{
+			ID:           4,
+			Type:         atc.StatusEventJobPaused,
+			TeamName:     "some-team",
+			PipelineName: "some-pipeline",
+			JobName:      "some-job",
+			CreatedAt:    time.Unix(200, 0),
+		}, nil)
+		fakeSource.NextReturnsOnCall(2, db.StatusEvent{}, db.ErrStatusEventStreamClosed)
+
+		dbStatusEventFactory.StatusEventsReturns(fakeSource, nil)
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		response, err = client.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("filters the events by the query and the caller's teams", func() {
+		Expect(dbStatusEventFactory.StatusEventsCallCount()).To(Equal(1))
+
+		filter, after := dbStatusEventFactory.StatusEventsArgsForCall(0)
+		Expect(filter).To(Equal(db.StatusEventFilter{
+			VisibleTeams: []string{"some-team", "other-team"},
+			TeamName:     "some-team",
+			PipelineName: "some-pipeline",
+			JobName:      "some-job",
+		}))
+		Expect(after).To(BeZero())
+	})
+
+	It("streams the events", func() {
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(response.Header.Get("Content-Type")).To(Equal("text/event-stream; charset=utf-8"))
+
+		body, err := ioutil.ReadAll(response.Body)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(string(body)).To(Equal(
+			"id: 3\n" +
+				"event: event\n" +
+				`data: {"id":3,"type":"build-finished","team_name":"some-team","pipeline_name":"some-pipeline","job_name":"some-job","build":{"id":42,"team_name":"some-team","name":"7","status":"succeeded","job_name":"some-job","api_url":"/api/v1/builds/42","pipeline_name":"some-pipeline"},"time":100}` + "\n\n" +
+				"id: 4\n" +
+				"event: event\n" +
+				`data: {"id":4,"type":"job-paused","team_name":"some-team","pipeline_name":"some-pipeline","job_name":"some-job","time":200}` + "\n\n",
+		))
+	})
+
+	Context("when the caller is an admin", func() {
+		BeforeEach(func() {
+			fakeAccess.IsAdminReturns(true)
+		})
+
+		It("streams events of every team", func() {
+			filter, _ := dbStatusEventFactory.StatusEventsArgsForCall(0)
+			Expect(filter.AllTeams).To(BeTrue())
+		})
+	})
+
+	Context("when resuming from an event", func() {
+		BeforeEach(func() {
+			request.Header.Set("Last-Event-ID", "2")
+		})
+
+		It("streams the events after it", func() {
+			_, after := dbStatusEventFactory.StatusEventsArgsForCall(0)
+			Expect(after).To(Equal(int64(2)))
+		})
+	})
+
+	Context("when the last event ID is invalid", func() {
+		BeforeEach(func() {
+			request.Header.Set("Last-Event-ID", "nope")
+		})
+
+		It("returns 400", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			Expect(dbStatusEventFactory.StatusEventsCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when getting the events fails", func() {
+		BeforeEach(func() {
+			dbStatusEventFactory.StatusEventsReturns(nil, errors.New("nope"))
+		})
+
+		It("returns 500", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
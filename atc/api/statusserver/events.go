@@ -0,0 +1,91 @@
+package statusserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/vito/go-sse/sse"
+)
+
+// StatusEvents streams build and job status changes visible to the caller as
+// server-sent events until the client disconnects. Clients may resume the
+// stream by passing the ID of the last event they saw as Last-Event-ID.
+func (s *Server) StatusEvents(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("status-events")
+
+	acc := accessor.GetAccessor(r)
+
+	var after int64
+	if lastEventID := r.Header.Get("Last-Event-ID"); lastEventID != "" {
+		var err error
+		after, err = strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil {
+			logger.Info("failed-to-parse-last-event-id", lager.Data{"last-event-id": lastEventID})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	filter := db.StatusEventFilter{
+		VisibleTeams: acc.TeamNames(),
+		AllTeams:     acc.IsAdmin(),
+		TeamName:     r.FormValue(atc.StatusEventsTeam),
+		PipelineName: r.FormValue(atc.StatusEventsPipeline),
+		JobName:      r.FormValue(atc.StatusEventsJob),
+	}
+
+	events, err := s.statusEventFactory.StatusEvents(filter, after)
+	if err != nil {
+		logger.Error("failed-to-get-status-events", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	go func() {
+		<-r.Context().Done()
+		db.Close(events)
+	}()
+
+	w.Header().Add("Content-Type", "text/event-stream; charset=utf-8")
+	w.Header().Add("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.Header().Add("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	flusher := w.(http.Flusher)
+	flusher.Flush()
+
+	for {
+		ev, err := events.Next()
+		if err != nil {
+			if err != db.ErrStatusEventStreamClosed {
+				logger.Error("failed-to-get-next-status-event", err)
+			}
+
+			return
+		}
+
+		payload, err := json.Marshal(present.StatusEvent(ev))
+		if err != nil {
+			logger.Error("failed-to-marshal-status-event", err)
+			return
+		}
+
+		err = sse.Event{
+			ID:   strconv.FormatInt(ev.ID, 10),
+			Name: "event",
+			Data: payload,
+		}.Write(w)
+		if err != nil {
+			logger.Info("failed-to-write-event", lager.Data{"error": err.Error()})
+			return
+		}
+
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,21 @@
+package statusserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger             lager.Logger
+	statusEventFactory db.StatusEventFactory
+}
+
+func NewServer(
+	logger lager.Logger,
+	statusEventFactory db.StatusEventFactory,
+) *Server {
+	return &Server{
+		logger:             logger,
+		statusEventFactory: statusEventFactory,
+	}
+}
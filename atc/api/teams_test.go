@@ -13,6 +13,7 @@ import (
 	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/policy"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -233,6 +234,10 @@ var _ = Describe("Teams API", func() {
 								"local:username"
 							]
 						}
+					},
+					"usage": {
+						"running_builds": 0,
+						"containers": 0
 					}
 				}`))
 			})
@@ -269,9 +274,61 @@ var _ = Describe("Teams API", func() {
 								"local:username"
 							]
 						}
+					},
+					"usage": {
+						"running_builds": 0,
+						"containers": 0
 					}
 				}`))
 			})
+
+			Context("when the team has a quota", func() {
+				BeforeEach(func() {
+					fakeTeam.QuotaReturns(atc.TeamQuota{MaxRunningBuilds: 5, MaxContainers: 50})
+					fakeTeam.QuotaUsageReturns(db.TeamQuotaUsage{
+						TeamName: "a-team",
+						Quota:    atc.TeamQuota{MaxRunningBuilds: 5, MaxContainers: 50},
+						Usage:    atc.TeamUsage{RunningBuilds: 2, Containers: 17},
+					}, nil)
+				})
+
+				It("returns the quota along with the usage", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`
+					{
+						"id": 1,
+						"name": "a-team",
+						"auth": {
+							"owner": {
+								"groups": [],
+								"users": [
+									"local:username"
+								]
+							}
+						},
+						"quota": {
+							"max_running_builds": 5,
+							"max_containers": 50
+						},
+						"usage": {
+							"running_builds": 2,
+							"containers": 17
+						}
+					}`))
+				})
+			})
+
+			Context("when getting the usage fails", func() {
+				BeforeEach(func() {
+					fakeTeam.QuotaUsageReturns(db.TeamQuotaUsage{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
 		})
 
 		Context("when authenticated as another team", func() {
@@ -339,6 +396,10 @@ var _ = Describe("Teams API", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
 					})
 				})
+
+				It("leaves the quota alone", func() {
+					Expect(fakeTeam.UpdateQuotaCallCount()).To(BeZero())
+				})
 			})
 		}
 
@@ -351,6 +412,105 @@ var _ = Describe("Teams API", func() {
 
 			authorizedTeamTests()
 
+			Context("when checking the policy", func() {
+				BeforeEach(func() {
+					fakeaccess.UserNameReturns("some-user")
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				It("sends the team config to the policy checker", func() {
+					Expect(fakePolicyChecker.CheckCallCount()).To(Equal(1))
+					Expect(fakePolicyChecker.CheckArgsForCall(0)).To(Equal(policy.Input{
+						Action: policy.ActionSetTeam,
+						User:   "some-user",
+						Team:   "some-team",
+						Data:   atc.Team{Name: "some-team"},
+					}))
+				})
+
+				Context("when the policy denies the team config", func() {
+					BeforeEach(func() {
+						fakePolicyChecker.CheckReturns(policy.Result{
+							Decision: policy.DecisionDeny,
+							Reasons:  []string{"teams must not allow all users"},
+						}, nil)
+					})
+
+					It("returns 400 with the reasons", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(string(body)).To(Equal("policy check denied SetTeam: teams must not allow all users"))
+					})
+
+					It("does not update the team", func() {
+						Expect(fakeTeam.UpdateProviderAuthCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the policy warns about the team config", func() {
+					BeforeEach(func() {
+						fakeTeam.NameReturns("some-team")
+						fakePolicyChecker.CheckReturns(policy.Result{
+							Decision: policy.DecisionWarn,
+							Reasons:  []string{"teams should have an owner group"},
+						}, nil)
+					})
+
+					It("updates the team and returns the warnings", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakeTeam.UpdateProviderAuthCallCount()).To(Equal(1))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(body).To(MatchJSON(`{
+							"id": 5,
+							"name": "some-team",
+							"warnings": [
+								{"type": "policy", "message": "teams should have an owner group"}
+							]
+						}`))
+					})
+				})
+
+				Context("when the policy check fails", func() {
+					BeforeEach(func() {
+						fakePolicyChecker.CheckReturns(policy.Result{}, errors.New("agent down"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						Expect(fakeTeam.UpdateProviderAuthCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when a quota is given", func() {
+				BeforeEach(func() {
+					atcTeam = atc.Team{
+						Quota: &atc.TeamQuota{MaxRunningBuilds: 5},
+					}
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				It("updates the quota", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(fakeTeam.UpdateQuotaCallCount()).To(Equal(1))
+					Expect(fakeTeam.UpdateQuotaArgsForCall(0)).To(Equal(atc.TeamQuota{MaxRunningBuilds: 5}))
+				})
+
+				Context("when updating the quota fails", func() {
+					BeforeEach(func() {
+						fakeTeam.UpdateQuotaReturns(errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
 			Context("when the team is not found", func() {
 				BeforeEach(func() {
 					dbTeamFactory.FindTeamReturns(nil, false, nil)
@@ -387,6 +547,21 @@ var _ = Describe("Teams API", func() {
 
 			authorizedTeamTests()
 
+			Context("when a quota is given", func() {
+				BeforeEach(func() {
+					atcTeam = atc.Team{
+						Quota: &atc.TeamQuota{MaxRunningBuilds: 5},
+					}
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				It("returns 403 without updating the team", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(fakeTeam.UpdateProviderAuthCallCount()).To(BeZero())
+					Expect(fakeTeam.UpdateQuotaCallCount()).To(BeZero())
+				})
+			})
+
 			Context("when the team is not found", func() {
 				BeforeEach(func() {
 					dbTeamFactory.FindTeamReturns(nil, false, nil)
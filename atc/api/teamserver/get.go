@@ -32,7 +32,15 @@ func (s *Server) GetTeam(w http.ResponseWriter, r *http.Request) {
 	var presentedTeam atc.Team
 
 	if acc.IsAdmin() || acc.IsAuthorized(team.Name()) {
+		quotaUsage, err := team.QuotaUsage()
+		if err != nil {
+			hLog.Error("failed-to-get-team-usage", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
 		presentedTeam = present.Team(team)
+		presentedTeam.Usage = &quotaUsage.Usage
 	} else {
 		hLog.Error("unauthorized", errors.New("not authorized to "+team.Name()))
 		w.WriteHeader(http.StatusUnauthorized)
@@ -3,22 +3,26 @@ package teamserver
 import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/policy"
 )
 
 type Server struct {
-	logger      lager.Logger
-	teamFactory db.TeamFactory
-	externalURL string
+	logger        lager.Logger
+	teamFactory   db.TeamFactory
+	externalURL   string
+	policyChecker policy.Checker
 }
 
 func NewServer(
 	logger lager.Logger,
 	teamFactory db.TeamFactory,
 	externalURL string,
+	policyChecker policy.Checker,
 ) *Server {
 	return &Server{
-		logger:      logger,
-		teamFactory: teamFactory,
-		externalURL: externalURL,
+		logger:        logger,
+		teamFactory:   teamFactory,
+		externalURL:   externalURL,
+		policyChecker: policyChecker,
 	}
 }
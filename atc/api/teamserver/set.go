@@ -2,6 +2,7 @@ package teamserver
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
@@ -9,6 +10,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/policy"
 )
 
 func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
@@ -34,6 +36,45 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if atcTeam.Quota != nil && !acc.IsAdmin() {
+		hLog.Debug("not-allowed-to-set-quota")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, "only admins can set team quotas")
+		return
+	}
+
+	result, err := s.policyChecker.Check(policy.Input{
+		Action: policy.ActionSetTeam,
+		User:   acc.UserName(),
+		Team:   teamName,
+		Data:   atcTeam,
+	})
+	if err != nil {
+		hLog.Error("failed-to-check-policy", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "failed to check policy: %s", err)
+		return
+	}
+
+	if result.Denied() {
+		hLog.Info("denied-by-policy", lager.Data{"reasons": result.Reasons})
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, policy.DeniedError{Action: policy.ActionSetTeam, Reasons: result.Reasons}.Error())
+		return
+	}
+
+	var warnings []atc.ConfigWarning
+	if result.Warned() {
+		hLog.Info("warned-by-policy", lager.Data{"reasons": result.Reasons})
+
+		for _, reason := range result.Reasons {
+			warnings = append(warnings, atc.ConfigWarning{
+				Type:    "policy",
+				Message: reason,
+			})
+		}
+	}
+
 	team, found, err := s.teamFactory.FindTeam(teamName)
 	if err != nil {
 		hLog.Error("failed-to-lookup-team", err, lager.Data{"teamName": teamName})
@@ -50,6 +91,16 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if atcTeam.Quota != nil {
+			hLog.Debug("updating-quota")
+			err = team.UpdateQuota(*atcTeam.Quota)
+			if err != nil {
+				hLog.Error("failed-to-update-team-quota", err, lager.Data{"teamName": teamName})
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 	} else if acc.IsAdmin() {
@@ -68,7 +119,10 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = json.NewEncoder(w).Encode(present.Team(team))
+	err = json.NewEncoder(w).Encode(atc.SetTeamResponse{
+		Team:     present.Team(team),
+		Warnings: warnings,
+	})
 	if err != nil {
 		hLog.Error("failed-to-encode-team", err)
 		w.WriteHeader(http.StatusInternalServerError)
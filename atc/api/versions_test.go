@@ -1,10 +1,12 @@
 package api_test
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/concourse/concourse/atc"
@@ -351,6 +353,112 @@ var _ = Describe("Versions API", func() {
 							}))
 						})
 					})
+
+					Context("when a version filter is given", func() {
+						BeforeEach(func() {
+							queryParams = "?since=5&limit=2&filter=" + url.QueryEscape("semver(tag) >=1.4 <2")
+
+							returnedVersions[0].Version = atc.Version{"tag": "1.4.2"}
+							returnedVersions[1].Version = atc.Version{"tag": "2.0.0"}
+
+							fakePipeline.NameReturns("some-pipeline")
+							fakeResource.VersionsReturnsOnCall(0, returnedVersions, db.Pagination{
+								Previous: &db.Page{Until: 4, Limit: 2},
+								Next:     &db.Page{Since: 2, Limit: 2},
+							}, true, nil)
+							fakeResource.VersionsReturnsOnCall(1, []atc.ResourceVersion{
+								{ID: 1, Version: atc.Version{"tag": "1.4.1"}},
+							}, db.Pagination{
+								Previous: &db.Page{Until: 1, Limit: 2},
+							}, true, nil)
+						})
+
+						It("fetches older versions until the page is full", func() {
+							Expect(fakeResource.VersionsCallCount()).To(Equal(2))
+							Expect(fakeResource.VersionsArgsForCall(0)).To(Equal(db.Page{Since: 5, Limit: 2}))
+							Expect(fakeResource.VersionsArgsForCall(1)).To(Equal(db.Page{Since: 2, Limit: 2}))
+
+							var versions []atc.ResourceVersion
+							err := json.NewDecoder(response.Body).Decode(&versions)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(versions).To(HaveLen(2))
+							Expect(versions[0].ID).To(Equal(4))
+							Expect(versions[1].ID).To(Equal(1))
+						})
+
+						It("keeps the filter in the Link headers", func() {
+							Expect(response.Header["Link"]).To(ConsistOf([]string{
+								fmt.Sprintf(`<%s/api/v1/teams/a-team/pipelines/some-pipeline/resources/some-resource/versions?until=4&limit=2&filter=%s>; rel="previous"`, externalURL, url.QueryEscape("semver(tag) >=1.4 <2")),
+							}))
+						})
+
+						Context("when more versions match than fit on the page", func() {
+							BeforeEach(func() {
+								fakeResource.VersionsReturnsOnCall(1, []atc.ResourceVersion{
+									{ID: 1, Version: atc.Version{"tag": "1.4.1"}},
+									{ID: 0, Version: atc.Version{"tag": "1.4.0"}},
+								}, db.Pagination{
+									Previous: &db.Page{Until: 1, Limit: 2},
+								}, true, nil)
+							})
+
+							It("links the next page to the last version returned", func() {
+								Expect(response.Header["Link"]).To(ContainElement(
+									fmt.Sprintf(`<%s/api/v1/teams/a-team/pipelines/some-pipeline/resources/some-resource/versions?since=1&limit=2&filter=%s>; rel="next"`, externalURL, url.QueryEscape("semver(tag) >=1.4 <2")),
+								))
+							})
+						})
+
+						Context("when fetching newer versions", func() {
+							BeforeEach(func() {
+								queryParams = "?until=1&limit=2&filter=" + url.QueryEscape("semver(tag) >=1.4 <2")
+
+								fakeResource.VersionsReturnsOnCall(0, []atc.ResourceVersion{
+									{ID: 3, Version: atc.Version{"tag": "2.1.0"}},
+									{ID: 2, Version: atc.Version{"tag": "1.4.1"}},
+								}, db.Pagination{
+									Previous: &db.Page{Until: 3, Limit: 2},
+									Next:     &db.Page{Since: 2, Limit: 2},
+								}, true, nil)
+								fakeResource.VersionsReturnsOnCall(1, []atc.ResourceVersion{
+									{ID: 5, Version: atc.Version{"tag": "1.4.3"}},
+									{ID: 4, Version: atc.Version{"tag": "1.4.2"}},
+								}, db.Pagination{
+									Previous: &db.Page{Until: 5, Limit: 2},
+									Next:     &db.Page{Since: 4, Limit: 2},
+								}, true, nil)
+							})
+
+							It("returns the oldest matching versions, newest first", func() {
+								Expect(fakeResource.VersionsArgsForCall(1)).To(Equal(db.Page{Until: 3, Limit: 2}))
+
+								var versions []atc.ResourceVersion
+								err := json.NewDecoder(response.Body).Decode(&versions)
+								Expect(err).NotTo(HaveOccurred())
+
+								Expect(versions).To(HaveLen(2))
+								Expect(versions[0].ID).To(Equal(4))
+								Expect(versions[1].ID).To(Equal(2))
+
+								Expect(response.Header["Link"]).To(ConsistOf([]string{
+									fmt.Sprintf(`<%s/api/v1/teams/a-team/pipelines/some-pipeline/resources/some-resource/versions?until=4&limit=2&filter=%s>; rel="previous"`, externalURL, url.QueryEscape("semver(tag) >=1.4 <2")),
+									fmt.Sprintf(`<%s/api/v1/teams/a-team/pipelines/some-pipeline/resources/some-resource/versions?since=2&limit=2&filter=%s>; rel="next"`, externalURL, url.QueryEscape("semver(tag) >=1.4 <2")),
+								}))
+							})
+						})
+
+						Context("when the filter is malformed", func() {
+							BeforeEach(func() {
+								queryParams = "?filter=" + url.QueryEscape("semver(tag) >=banana")
+							})
+
+							It("returns 400 Bad Request", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+								Expect(fakeResource.VersionsCallCount()).To(BeZero())
+							})
+						})
+					})
 				})
 
 				Context("when the versions can't be found", func() {
@@ -30,7 +30,7 @@ func (s *Server) ListDestroyingVolumes(w http.ResponseWriter, r *http.Request) {
 
 	metric.VolumesToBeGarbageCollected{
 		Volumes: len(volumeHandles),
-	}.Emit(logger)
+	}.Emit(logger, s.registry)
 
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(volumeHandles)
@@ -4,22 +4,26 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/gc"
+	"github.com/concourse/concourse/atc/metric"
 )
 
 type Server struct {
 	logger     lager.Logger
 	repository db.VolumeRepository
 	destroyer  gc.Destroyer
+	registry   *metric.Registry
 }
 
 func NewServer(
 	logger lager.Logger,
 	volumeRepository db.VolumeRepository,
 	destroyer gc.Destroyer,
+	registry *metric.Registry,
 ) *Server {
 	return &Server{
 		logger:     logger,
 		repository: volumeRepository,
 		destroyer:  destroyer,
+		registry:   registry,
 	}
 }
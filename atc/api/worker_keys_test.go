@@ -0,0 +1,361 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Worker Keys API", func() {
+	const (
+		publicKey   = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIKrHk8+RL1oQ7MFTq3BeY4R7GkZ8hP909rC9m0E8Iw3G"
+		fingerprint = "SHA256:1rQvQ+7c+GRkLDE7ysmEkkmkiWe+P2xFqt5uTGhPQIo"
+	)
+
+	var response *http.Response
+
+	BeforeEach(func() {
+		dbTeam.NameReturns("some-team")
+		fakeAccess.IsAuthenticatedReturns(true)
+		fakeAccess.IsAuthorizedReturns(true)
+	})
+
+	Describe("GET /api/v1/worker_keys", func() {
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/worker_keys")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when neither admin nor system", func() {
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when requested by the system", func() {
+			BeforeEach(func() {
+				fakeAccess.IsSystemReturns(true)
+
+				dbWorkerKeyFactory.AllKeysReturns([]db.WorkerKey{
+					{
+						ID:          1,
+						Name:        "global-key",
+						PublicKey:   publicKey,
+						Fingerprint: fingerprint,
+						CreatedAt:   time.Unix(100, 0),
+					},
+					{
+						ID:          2,
+						Name:        "team-key",
+						TeamID:      734,
+						TeamName:    "some-team",
+						PublicKey:   publicKey,
+						Fingerprint: fingerprint,
+						CreatedAt:   time.Unix(200, 0),
+					},
+				}, nil)
+			})
+
+			It("returns every key", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"name": "global-key",
+						"public_key": "` + publicKey + `",
+						"fingerprint": "` + fingerprint + `",
+						"created_at": 100
+					},
+					{
+						"name": "team-key",
+						"team_name": "some-team",
+						"public_key": "` + publicKey + `",
+						"fingerprint": "` + fingerprint + `",
+						"created_at": 200
+					}
+				]`))
+			})
+
+			Context("when getting the keys fails", func() {
+				BeforeEach(func() {
+					dbWorkerKeyFactory.AllKeysReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("POST /api/v1/worker_keys", func() {
+		var keyRequest atc.WorkerKeyRequest
+
+		BeforeEach(func() {
+			keyRequest = atc.WorkerKeyRequest{
+				Name:      "some-key",
+				PublicKey: publicKey + " some-comment\n",
+			}
+
+			fakeAccess.IsAdminReturns(true)
+		})
+
+		JustBeforeEach(func() {
+			payload, err := json.Marshal(keyRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Post(server.URL+"/api/v1/worker_keys", "application/json", bytes.NewBuffer(payload))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when creating the key succeeds", func() {
+			BeforeEach(func() {
+				dbWorkerKeyFactory.CreateGlobalKeyReturns(db.WorkerKey{
+					ID:          1,
+					Name:        "some-key",
+					PublicKey:   publicKey,
+					Fingerprint: fingerprint,
+					CreatedAt:   time.Unix(100, 0),
+				}, nil)
+			})
+
+			It("returns 201 with the key", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`{
+					"name": "some-key",
+					"public_key": "` + publicKey + `",
+					"fingerprint": "` + fingerprint + `",
+					"created_at": 100
+				}`))
+			})
+
+			It("stores the normalized key with its fingerprint", func() {
+				Expect(dbWorkerKeyFactory.CreateGlobalKeyCallCount()).To(Equal(1))
+				Expect(dbWorkerKeyFactory.CreateGlobalKeyArgsForCall(0)).To(Equal(db.WorkerKeySpec{
+					Name:        "some-key",
+					PublicKey:   publicKey,
+					Fingerprint: fingerprint,
+				}))
+			})
+		})
+
+		Context("when the key already exists", func() {
+			BeforeEach(func() {
+				dbWorkerKeyFactory.CreateGlobalKeyReturns(db.WorkerKey{}, db.ErrWorkerKeyAlreadyExists)
+			})
+
+			It("returns 409", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusConflict))
+			})
+		})
+
+		Context("when the public key is invalid", func() {
+			BeforeEach(func() {
+				keyRequest.PublicKey = "not-a-key"
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(dbWorkerKeyFactory.CreateGlobalKeyCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the name is missing", func() {
+			BeforeEach(func() {
+				keyRequest.Name = ""
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/worker_keys/:key_name", func() {
+		BeforeEach(func() {
+			fakeAccess.IsAdminReturns(true)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/worker_keys/some-key", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the key exists", func() {
+			BeforeEach(func() {
+				dbWorkerKeyFactory.DeleteGlobalKeyReturns(true, nil)
+			})
+
+			It("returns 204", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				Expect(dbWorkerKeyFactory.DeleteGlobalKeyArgsForCall(0)).To(Equal("some-key"))
+			})
+		})
+
+		Context("when the key does not exist", func() {
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/worker_keys", func() {
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/worker_keys")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				dbWorkerKeyFactory.TeamKeysReturns([]db.WorkerKey{
+					{
+						ID:          2,
+						Name:        "team-key",
+						TeamID:      734,
+						TeamName:    "some-team",
+						PublicKey:   publicKey,
+						Fingerprint: fingerprint,
+						CreatedAt:   time.Unix(200, 0),
+					},
+				}, nil)
+			})
+
+			It("returns the team's keys", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(dbWorkerKeyFactory.TeamKeysArgsForCall(0)).To(Equal(734))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"name": "team-key",
+						"team_name": "some-team",
+						"public_key": "` + publicKey + `",
+						"fingerprint": "` + fingerprint + `",
+						"created_at": 200
+					}
+				]`))
+			})
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/worker_keys", func() {
+		JustBeforeEach(func() {
+			payload, err := json.Marshal(atc.WorkerKeyRequest{
+				Name:      "team-key",
+				PublicKey: publicKey,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Post(server.URL+"/api/v1/teams/some-team/worker_keys", "application/json", bytes.NewBuffer(payload))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		BeforeEach(func() {
+			dbWorkerKeyFactory.CreateTeamKeyReturns(db.WorkerKey{
+				ID:          2,
+				Name:        "team-key",
+				TeamID:      734,
+				TeamName:    "some-team",
+				PublicKey:   publicKey,
+				Fingerprint: fingerprint,
+			}, nil)
+		})
+
+		It("creates the key for the team", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusCreated))
+			Expect(dbWorkerKeyFactory.CreateTeamKeyCallCount()).To(Equal(1))
+
+			teamID, spec := dbWorkerKeyFactory.CreateTeamKeyArgsForCall(0)
+			Expect(teamID).To(Equal(734))
+			Expect(spec.Name).To(Equal("team-key"))
+			Expect(spec.Fingerprint).To(Equal(fingerprint))
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/worker_keys/:key_name", func() {
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/some-team/worker_keys/team-key", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the key exists", func() {
+			BeforeEach(func() {
+				dbWorkerKeyFactory.DeleteTeamKeyReturns(true, nil)
+			})
+
+			It("deletes the team's key", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+				teamID, name := dbWorkerKeyFactory.DeleteTeamKeyArgsForCall(0)
+				Expect(teamID).To(Equal(734))
+				Expect(name).To(Equal("team-key"))
+			})
+		})
+
+		Context("when deleting fails", func() {
+			BeforeEach(func() {
+				dbWorkerKeyFactory.DeleteTeamKeyReturns(false, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+})
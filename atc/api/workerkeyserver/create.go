@@ -0,0 +1,77 @@
+package workerkeyserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) CreateWorkerKey(w http.ResponseWriter, r *http.Request) {
+	hLog := s.logger.Session("create-worker-key")
+
+	s.create(hLog, w, r, s.workerKeyFactory.CreateGlobalKey)
+}
+
+func (s *Server) CreateTeamWorkerKey(team db.Team) http.Handler {
+	hLog := s.logger.Session("create-team-worker-key", lager.Data{
+		"team": team.Name(),
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.create(hLog, w, r, func(spec db.WorkerKeySpec) (db.WorkerKey, error) {
+			return s.workerKeyFactory.CreateTeamKey(team.ID(), spec)
+		})
+	})
+}
+
+func (s *Server) create(
+	hLog lager.Logger,
+	w http.ResponseWriter,
+	r *http.Request,
+	createKey func(db.WorkerKeySpec) (db.WorkerKey, error),
+) {
+	var req atc.WorkerKeyRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		hLog.Error("malformed-request", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	spec, err := parseKey(req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "%s", err.Error())
+		return
+	}
+
+	key, err := createKey(spec)
+	if err == db.ErrWorkerKeyAlreadyExists {
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+
+	if err != nil {
+		hLog.Error("failed-to-create-worker-key", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	hLog.Info("created", lager.Data{
+		"name":        key.Name,
+		"fingerprint": key.Fingerprint,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	err = json.NewEncoder(w).Encode(present.WorkerKey(key))
+	if err != nil {
+		hLog.Error("failed-to-encode-worker-key", err)
+	}
+}
@@ -0,0 +1,51 @@
+package workerkeyserver
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) DeleteWorkerKey(w http.ResponseWriter, r *http.Request) {
+	hLog := s.logger.Session("delete-worker-key")
+
+	s.delete(hLog, w, r, s.workerKeyFactory.DeleteGlobalKey)
+}
+
+func (s *Server) DeleteTeamWorkerKey(team db.Team) http.Handler {
+	hLog := s.logger.Session("delete-team-worker-key", lager.Data{
+		"team": team.Name(),
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.delete(hLog, w, r, func(name string) (bool, error) {
+			return s.workerKeyFactory.DeleteTeamKey(team.ID(), name)
+		})
+	})
+}
+
+func (s *Server) delete(
+	hLog lager.Logger,
+	w http.ResponseWriter,
+	r *http.Request,
+	deleteKey func(string) (bool, error),
+) {
+	keyName := r.FormValue(":key_name")
+
+	found, err := deleteKey(keyName)
+	if err != nil {
+		hLog.Error("failed-to-delete-worker-key", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	hLog.Info("deleted", lager.Data{"name": keyName})
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,66 @@
+package workerkeyserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// ListWorkerKeys lists the keys of every team along with the global keys. It
+// is used by the TSA to authorize workers, so the system token may call it as
+// well as admins.
+func (s *Server) ListWorkerKeys(w http.ResponseWriter, r *http.Request) {
+	hLog := s.logger.Session("list-worker-keys")
+
+	acc := accessor.GetAccessor(r)
+	if !acc.IsAdmin() && !acc.IsSystem() {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	keys, err := s.workerKeyFactory.AllKeys()
+	if err != nil {
+		hLog.Error("failed-to-get-worker-keys", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	s.respond(hLog, w, keys)
+}
+
+func (s *Server) ListTeamWorkerKeys(team db.Team) http.Handler {
+	hLog := s.logger.Session("list-team-worker-keys", lager.Data{
+		"team": team.Name(),
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys, err := s.workerKeyFactory.TeamKeys(team.ID())
+		if err != nil {
+			hLog.Error("failed-to-get-worker-keys", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		s.respond(hLog, w, keys)
+	})
+}
+
+func (s *Server) respond(hLog lager.Logger, w http.ResponseWriter, keys []db.WorkerKey) {
+	presentedKeys := []atc.WorkerKey{}
+	for _, key := range keys {
+		presentedKeys = append(presentedKeys, present.WorkerKey(key))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err := json.NewEncoder(w).Encode(presentedKeys)
+	if err != nil {
+		hLog.Error("failed-to-encode-worker-keys", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
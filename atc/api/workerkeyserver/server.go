@@ -0,0 +1,45 @@
+package workerkeyserver
+
+import (
+	"errors"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"golang.org/x/crypto/ssh"
+)
+
+type Server struct {
+	logger           lager.Logger
+	workerKeyFactory db.WorkerKeyFactory
+}
+
+func NewServer(
+	logger lager.Logger,
+	workerKeyFactory db.WorkerKeyFactory,
+) *Server {
+	return &Server{
+		logger:           logger,
+		workerKeyFactory: workerKeyFactory,
+	}
+}
+
+// parseKey validates the requested key and returns it normalized to a single
+// authorized_keys line along with its fingerprint.
+func parseKey(req atc.WorkerKeyRequest) (db.WorkerKeySpec, error) {
+	if req.Name == "" {
+		return db.WorkerKeySpec{}, errors.New("key name must be specified")
+	}
+
+	publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(req.PublicKey))
+	if err != nil {
+		return db.WorkerKeySpec{}, errors.New("public key must be in authorized_keys format")
+	}
+
+	return db.WorkerKeySpec{
+		Name:        req.Name,
+		PublicKey:   strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey))),
+		Fingerprint: ssh.FingerprintSHA256(publicKey),
+	}, nil
+}
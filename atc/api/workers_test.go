@@ -661,6 +661,110 @@ var _ = Describe("Workers API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/workers/:worker_name/unquarantine", func() {
+		var (
+			response   *http.Response
+			workerName string
+			fakeWorker *dbfakes.FakeWorker
+		)
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/workers/"+workerName+"/unquarantine", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		BeforeEach(func() {
+			fakeWorker = new(dbfakes.FakeWorker)
+			workerName = "some-worker"
+			fakeWorker.NameReturns(workerName)
+			fakeWorker.TeamNameReturns("some-team")
+			fakeaccess.IsAuthenticatedReturns(true)
+
+			dbWorkerFactory.GetWorkerReturns(fakeWorker, true, nil)
+			fakeWorker.ReleaseQuarantineReturns(nil)
+		})
+
+		Context("when autheticated as system", func() {
+			BeforeEach(func() {
+				fakeaccess.IsSystemReturns(true)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("sees if the worker exists and releases its quarantine", func() {
+				Expect(dbWorkerFactory.GetWorkerCallCount()).To(Equal(1))
+				Expect(dbWorkerFactory.GetWorkerArgsForCall(0)).To(Equal(workerName))
+
+				Expect(fakeWorker.ReleaseQuarantineCallCount()).To(Equal(1))
+			})
+
+			Context("when the worker is not quarantined", func() {
+				BeforeEach(func() {
+					fakeWorker.ReleaseQuarantineReturns(db.ErrWorkerNotQuarantined)
+				})
+
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+				})
+			})
+
+			Context("when the worker disappears", func() {
+				BeforeEach(func() {
+					fakeWorker.ReleaseQuarantineReturns(db.ErrWorkerNotPresent)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when releasing the quarantine fails", func() {
+				BeforeEach(func() {
+					fakeWorker.ReleaseQuarantineReturns(errors.New("some-error"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the worker does not exist", func() {
+				BeforeEach(func() {
+					dbWorkerFactory.GetWorkerReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when authorized as some other team", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/workers/:worker_name/prune", func() {
 		var (
 			response   *http.Response
@@ -45,13 +45,13 @@ func (s *Server) HeartbeatWorker(w http.ResponseWriter, r *http.Request) {
 		WorkerName: registration.Name,
 		Containers: registration.ActiveContainers,
 		Platform:   registration.Platform,
-	}.Emit(s.logger)
+	}.Emit(s.logger, s.registry)
 
 	metric.WorkerVolumes{
 		WorkerName: registration.Name,
 		Volumes:    registration.ActiveVolumes,
 		Platform:   registration.Platform,
-	}.Emit(s.logger)
+	}.Emit(s.logger, s.registry)
 
 	savedWorker, err := s.dbWorkerFactory.HeartbeatWorker(registration, ttl)
 	if err == db.ErrWorkerNotPresent {
@@ -67,13 +67,13 @@ func (s *Server) RegisterWorker(w http.ResponseWriter, r *http.Request) {
 		WorkerName: registration.Name,
 		Containers: registration.ActiveContainers,
 		Platform:   registration.Platform,
-	}.Emit(s.logger)
+	}.Emit(s.logger, s.registry)
 
 	metric.WorkerVolumes{
 		WorkerName: registration.Name,
 		Volumes:    registration.ActiveVolumes,
 		Platform:   registration.Platform,
-	}.Emit(s.logger)
+	}.Emit(s.logger, s.registry)
 
 	if registration.Team != "" {
 		team, found, err := s.teamFactory.FindTeam(registration.Team)
@@ -3,6 +3,7 @@ package workerserver
 import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/metric"
 )
 
 type Server struct {
@@ -10,17 +11,19 @@ type Server struct {
 
 	teamFactory     db.TeamFactory
 	dbWorkerFactory db.WorkerFactory
+	registry        *metric.Registry
 }
 
 func NewServer(
 	logger lager.Logger,
 	teamFactory db.TeamFactory,
 	dbWorkerFactory db.WorkerFactory,
-
+	registry *metric.Registry,
 ) *Server {
 	return &Server{
 		logger:          logger,
 		teamFactory:     teamFactory,
 		dbWorkerFactory: dbWorkerFactory,
+		registry:        registry,
 	}
 }
@@ -0,0 +1,46 @@
+package workerserver
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) UnquarantineWorker(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("unquarantining-worker")
+	workerName := r.FormValue(":worker_name")
+
+	worker, found, err := s.dbWorkerFactory.GetWorker(workerName)
+	if err != nil {
+		logger.Error("failed-finding-worker-to-unquarantine", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		logger.Error("failed-to-find-worker", err)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	err = worker.ReleaseQuarantine()
+	if err == db.ErrWorkerNotPresent {
+		logger.Error("failed-to-find-worker", err)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if err == db.ErrWorkerNotQuarantined {
+		logger.Info("worker-not-quarantined")
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+
+	if err != nil {
+		logger.Error("failed-to-unquarantine-worker", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
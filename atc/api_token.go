@@ -0,0 +1,26 @@
+package atc
+
+type APIToken struct {
+	ID             int      `json:"id"`
+	Name           string   `json:"name"`
+	TeamName       string   `json:"team_name"`
+	Owner          string   `json:"owner"`
+	ServiceAccount bool     `json:"service_account,omitempty"`
+	Roles          []string `json:"roles"`
+	CreatedAt      int64    `json:"created_at"`
+	ExpiresAt      int64    `json:"expires_at"`
+
+	// Token is only populated in the response to creating the token.
+	Token string `json:"token,omitempty"`
+}
+
+type APITokenRequest struct {
+	Name string `json:"name"`
+
+	// ServiceAccount, when set, creates the token on behalf of the named
+	// team-level service account instead of the requesting user.
+	ServiceAccount string `json:"service_account,omitempty"`
+
+	Roles     []string `json:"roles"`
+	ExpiresAt int64    `json:"expires_at"`
+}
@@ -6,6 +6,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
 	_ "net/http/pprof"
@@ -37,6 +38,7 @@ import (
 	"github.com/concourse/concourse/atc/lockrunner"
 	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/pipelines"
+	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/radar"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/scheduler"
@@ -54,10 +56,12 @@ import (
 	"github.com/cppforlife/go-semi-semantic/version"
 	"github.com/hashicorp/go-multierror"
 	"github.com/jessevdk/go-flags"
+	"github.com/mitchellh/mapstructure"
 	"github.com/tedsuo/ifrit"
 	"github.com/tedsuo/ifrit/grouper"
 	"github.com/tedsuo/ifrit/http_server"
 	"github.com/tedsuo/ifrit/sigmon"
+	"gopkg.in/yaml.v2"
 
 	// dynamically registered metric emitters
 	_ "github.com/concourse/concourse/atc/metric/emitter"
@@ -92,6 +96,13 @@ type RunCommand struct {
 
 	Postgres flag.PostgresConfig `group:"PostgreSQL Configuration" namespace:"postgres"`
 
+	PostgresReplica struct {
+		Enabled  bool `long:"enable" description:"Serve dashboard listings of pipelines, jobs, resources and builds from a read replica. Writes, locks, scheduling and build event streams stay on the primary."`
+		MaxConns int  `long:"max-conns" default:"32" description:"Maximum number of open connections to the read replica."`
+
+		flag.PostgresConfig
+	} `group:"PostgreSQL Read Replica Configuration" namespace:"postgres-replica"`
+
 	CredentialManagement creds.CredentialManagementConfig `group:"Credential Management"`
 	CredentialManagers   creds.Managers
 
@@ -103,8 +114,13 @@ type RunCommand struct {
 
 	InterceptIdleTimeout time.Duration `long:"intercept-idle-timeout" default:"0m" description:"Length of time for a intercepted session to be idle before terminating."`
 
+	EnableHijackRecording bool `long:"enable-hijack-recording" description:"Record the input and output of intercepted sessions so that team owners can list and replay them."`
+
 	EnableGlobalResources bool `long:"enable-global-resources" description:"Enable equivalent resources across pipelines and teams to share a single version history."`
 
+	ResourceSourceDefaults flag.File                 `long:"resource-source-defaults" description:"YAML file mapping resource types to source defaults, merged into the source of every resource, resource type and image resource of that type."`
+	RegistryRewriteRules   []atc.RegistryRewriteRule `long:"registry-rewrite"         description:"Pull images of a registry from another registry, e.g. a mirror. Repositories without a registry are in docker.io. Can be specified multiple times." value-name:"FROM=TO"`
+
 	GlobalResourceCheckTimeout   time.Duration `long:"global-resource-check-timeout" default:"1h" description:"Time limit on checking for new versions of resources."`
 	ResourceCheckingInterval     time.Duration `long:"resource-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources."`
 	ResourceTypeCheckingInterval time.Duration `long:"resource-type-checking-interval" default:"1m" description:"Interval on which to check for new versions of resource types."`
@@ -114,6 +130,18 @@ type RunCommand struct {
 
 	CLIArtifactsDir flag.Dir `long:"cli-artifacts-dir" description:"Directory containing downloadable CLI binaries."`
 
+	WorkerQuarantine struct {
+		FailureThreshold int           `long:"failure-threshold" default:"0"   description:"Number of container or volume creation failures on a worker within the window before it may be quarantined. 0 disables quarantining."`
+		FailureRate      float64       `long:"failure-rate"      default:"0.5" description:"Ratio of failed to attempted creations within the window above which a worker is quarantined."`
+		Window           time.Duration `long:"window"            default:"10m" description:"Period over which creation failures are counted."`
+		Duration         time.Duration `long:"duration"          default:"30m" description:"How long a quarantined worker is kept out of container placement before being released."`
+	} `group:"Worker Quarantine" namespace:"worker-quarantine"`
+
+	PolicyCheck struct {
+		URL     flag.URL      `long:"url"     description:"URL of an HTTP policy agent (e.g. OPA) consulted before saving pipelines, setting teams and running tasks and puts."`
+		Timeout time.Duration `long:"timeout" default:"5s" description:"Time limit on requests to the policy agent."`
+	} `group:"Policy Checking" namespace:"policy-check"`
+
 	Developer struct {
 		Noop bool `short:"n" long:"noop"              description:"Don't actually do any automatic scheduling or checking."`
 	} `group:"Developer Options"`
@@ -128,6 +156,7 @@ type RunCommand struct {
 		HostName            string            `long:"metrics-host-name" description:"Host string to attach to emitted metrics."`
 		Attributes          map[string]string `long:"metrics-attribute" description:"A key-value attribute to attach to emitted metrics. Can be specified multiple times." value-name:"NAME:VALUE"`
 		CaptureErrorMetrics bool              `long:"capture-error-metrics" description:"Enable capturing of error log metrics"`
+		BufferSize          int               `long:"metrics-buffer-size" default:"1000" description:"Number of events queued for each metric emitter before further events are dropped."`
 	} `group:"Metrics & Diagnostics"`
 
 	Server struct {
@@ -142,6 +171,17 @@ type RunCommand struct {
 
 		OneOffBuildGracePeriod time.Duration `long:"one-off-grace-period" default:"5m" description:"Period after which one-off build containers will be garbage-collected."`
 		MissingGracePeriod     time.Duration `long:"missing-grace-period" default:"5m" description:"Period after which to reap containers and volumes that were created but went missing from the worker."`
+
+		AuditEventRetention time.Duration `long:"audit-event-retention" default:"2160h" description:"Period after which persisted audit events are removed. 0 keeps them forever."`
+
+		StatusEventRetention time.Duration `long:"status-event-retention" default:"1h" description:"Period for which build and job status events are kept for clients resuming the status event stream."`
+
+		HijackSessionRetention time.Duration `long:"hijack-session-retention" default:"720h" description:"Period after which recorded intercepted sessions are removed. 0 keeps them forever."`
+
+		RetainedOutputRetention time.Duration `long:"retained-output-retention" default:"168h" description:"Period after which task outputs kept with retain_outputs are removed. 0 keeps them forever."`
+
+		VersionRetentionLatest int `long:"version-retention-latest" description:"Default number of most recent versions to keep for resources that do not configure version_retention. 0 keeps all versions."`
+		VersionRetentionDays   int `long:"version-retention-days" description:"Default number of days to keep versions for resources that do not configure version_retention. 0 keeps all versions."`
 	} `group:"Garbage Collection" namespace:"gc"`
 
 	BuildTrackerInterval time.Duration `long:"build-tracker-interval" default:"10s" description:"Interval on which to run build tracking."`
@@ -154,8 +194,17 @@ type RunCommand struct {
 	DefaultDaysToRetainBuildLogs uint64 `long:"default-days-to-retain-build-logs" description:"Default days to retain build logs. 0 means unlimited"`
 	MaxDaysToRetainBuildLogs     uint64 `long:"max-days-to-retain-build-logs" description:"Maximum days to retain build logs, 0 means not specified. Will override values configured in jobs"`
 
+	DefaultBuildsToRetain uint64 `long:"default-builds-to-retain" description:"Default builds to retain, 0 means all. Older builds are deleted along with their logs"`
+	MaxBuildsToRetain     uint64 `long:"max-builds-to-retain" description:"Maximum builds to retain, 0 means not specified. Will override values configured in jobs"`
+
+	DefaultDaysToRetainBuilds uint64 `long:"default-days-to-retain-builds" description:"Default days to retain builds. 0 means unlimited"`
+	MaxDaysToRetainBuilds     uint64 `long:"max-days-to-retain-builds" description:"Maximum days to retain builds, 0 means not specified. Will override values configured in jobs"`
+
 	DefaultCpuLimit    *int    `long:"default-task-cpu-limit" description:"Default max number of cpu shares per task, 0 means unlimited"`
 	DefaultMemoryLimit *string `long:"default-task-memory-limit" description:"Default maximum memory per task, 0 means unlimited"`
+	DefaultDiskLimit   *string `long:"default-task-disk-limit" description:"Default maximum disk written per task, 0 means unlimited"`
+	DefaultPidsLimit   *int    `long:"default-task-pids-limit" description:"Default maximum number of processes per task, 0 means unlimited"`
+	DefaultNetwork     *string `long:"default-task-network" choice:"default" choice:"none" description:"Default network of task containers. Tasks can override it with network in their container_limits"`
 
 	Auditor struct {
 		EnableBuildAuditLog     bool `long:"enable-build-auditing" description:"Enable auditing for all api requests connected to builds."`
@@ -167,6 +216,8 @@ type RunCommand struct {
 		EnableTeamAuditLog      bool `long:"enable-team-auditing" description:"Enable auditing for all api requests connected to teams."`
 		EnableWorkerAuditLog    bool `long:"enable-worker-auditing" description:"Enable auditing for all api requests connected to workers."`
 		EnableVolumeAuditLog    bool `long:"enable-volume-auditing" description:"Enable auditing for all api requests connected to volumes."`
+
+		TrustedProxies []string `long:"audit-trusted-proxy" value-name:"CIDR" description:"Network of a proxy trusted to set X-Forwarded-For. The source address of audit events is only taken from X-Forwarded-For for requests from these networks. Can be specified multiple times."`
 	}
 
 	Syslog struct {
@@ -371,28 +422,29 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 		retryingDriverName,
 	)
 
-	// Register the sink that collects error metrics
-	if cmd.Metrics.CaptureErrorMetrics {
-		errorSinkCollector := metric.NewErrorSinkCollector(logger)
-		logger.RegisterSink(&errorSinkCollector)
-	}
-
 	http.HandleFunc("/debug/connections", func(w http.ResponseWriter, r *http.Request) {
 		for _, stack := range db.GlobalConnectionTracker.Current() {
 			fmt.Fprintln(w, stack)
 		}
 	})
 
-	if err := cmd.configureMetrics(logger); err != nil {
+	metricsRegistry, err := cmd.configureMetrics(logger)
+	if err != nil {
 		return nil, err
 	}
 
+	// Register the sink that collects error metrics
+	if cmd.Metrics.CaptureErrorMetrics {
+		errorSinkCollector := metric.NewErrorSinkCollector(logger, metricsRegistry)
+		logger.RegisterSink(&errorSinkCollector)
+	}
+
 	lockConn, err := cmd.constructLockConn(retryingDriverName)
 	if err != nil {
 		return nil, err
 	}
 
-	lockFactory := lock.NewLockFactory(lockConn, metric.LogLockAcquired, metric.LogLockReleased)
+	lockFactory := lock.NewLockFactory(lockConn, metricsRegistry.LogLockAcquired, metricsRegistry.LogLockReleased)
 
 	apiConn, err := cmd.constructDBConn(retryingDriverName, logger, 32, "api", lockFactory)
 	if err != nil {
@@ -404,17 +456,36 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 		return nil, err
 	}
 
+	closers := []Closer{lockConn, apiConn, backendConn}
+
+	readConn := apiConn
+	if cmd.PostgresReplica.Enabled {
+		readConn, err = cmd.constructReadReplicaConn(retryingDriverName, logger, apiConn)
+		if err != nil {
+			return nil, err
+		}
+
+		closers = append(closers, readConn)
+	}
+
 	storage, err := storage.NewPostgresStorage(logger, cmd.Postgres)
 	if err != nil {
 		return nil, err
 	}
 
+	closers = append(closers, storage)
+
 	secretManager, err := cmd.secretManager(logger)
 	if err != nil {
 		return nil, err
 	}
 
-	members, err := cmd.constructMembers(logger, reconfigurableSink, apiConn, backendConn, storage, lockFactory, secretManager)
+	sourceDefaults, err := cmd.sourceDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := cmd.constructMembers(logger, reconfigurableSink, apiConn, readConn, backendConn, storage, lockFactory, secretManager, sourceDefaults, metricsRegistry)
 	if err != nil {
 		return nil, err
 	}
@@ -423,6 +494,7 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 		Name: "periodic-metrics",
 		Runner: metric.PeriodicallyEmit(
 			logger.Session("periodic-metrics"),
+			metricsRegistry,
 			10*time.Second,
 		),
 	})
@@ -441,7 +513,7 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 	}
 
 	onExit := func() {
-		for _, closer := range []Closer{lockConn, apiConn, backendConn, storage} {
+		for _, closer := range closers {
 			closer.Close()
 		}
 	}
@@ -453,10 +525,13 @@ func (cmd *RunCommand) constructMembers(
 	logger lager.Logger,
 	reconfigurableSink *lager.ReconfigurableSink,
 	apiConn db.Conn,
+	readConn db.Conn,
 	backendConn db.Conn,
 	storage storage.Storage,
 	lockFactory lock.LockFactory,
 	secretManager creds.Secrets,
+	sourceDefaults atc.SourceDefaults,
+	metricsRegistry *metric.Registry,
 ) ([]grouper.Member, error) {
 	if cmd.TelemetryOptIn {
 		url := fmt.Sprintf("http://telemetry.concourse-ci.org/?version=%s", concourse.Version)
@@ -468,12 +543,16 @@ func (cmd *RunCommand) constructMembers(
 		}()
 	}
 
-	apiMembers, err := cmd.constructAPIMembers(logger, reconfigurableSink, apiConn, storage, lockFactory, secretManager)
+	// shared so that failures seen by either are counted together and both
+	// respect the same quarantines
+	quarantiner := cmd.workerQuarantiner(metricsRegistry)
+
+	apiMembers, err := cmd.constructAPIMembers(logger, reconfigurableSink, apiConn, readConn, storage, lockFactory, secretManager, sourceDefaults, quarantiner, metricsRegistry)
 	if err != nil {
 		return nil, err
 	}
 
-	backendMembers, err := cmd.constructBackendMembers(logger, backendConn, lockFactory, secretManager)
+	backendMembers, err := cmd.constructBackendMembers(logger, backendConn, lockFactory, secretManager, sourceDefaults, quarantiner, metricsRegistry)
 	if err != nil {
 		return nil, err
 	}
@@ -485,9 +564,13 @@ func (cmd *RunCommand) constructAPIMembers(
 	logger lager.Logger,
 	reconfigurableSink *lager.ReconfigurableSink,
 	dbConn db.Conn,
+	readConn db.Conn,
 	storage storage.Storage,
 	lockFactory lock.LockFactory,
 	secretManager creds.Secrets,
+	sourceDefaults atc.SourceDefaults,
+	quarantiner worker.Quarantiner,
+	metricsRegistry *metric.Registry,
 ) ([]grouper.Member, error) {
 	teamFactory := db.NewTeamFactory(dbConn, lockFactory)
 
@@ -505,13 +588,16 @@ func (cmd *RunCommand) constructAPIMembers(
 		return nil, err
 	}
 
+	dbLocalUserFactory := db.NewLocalUserFactory(dbConn)
+
 	authHandler, err := skymarshal.NewServer(&skymarshal.Config{
-		Logger:      logger,
-		TeamFactory: teamFactory,
-		Flags:       cmd.Auth.AuthFlags,
-		ExternalURL: cmd.ExternalURL.String(),
-		HTTPClient:  httpClient,
-		Storage:     storage,
+		Logger:           logger,
+		TeamFactory:      teamFactory,
+		LocalUserFactory: dbLocalUserFactory,
+		Flags:            cmd.Auth.AuthFlags,
+		ExternalURL:      cmd.ExternalURL.String(),
+		HTTPClient:       httpClient,
+		Storage:          storage,
 	})
 	if err != nil {
 		return nil, err
@@ -551,6 +637,8 @@ func (cmd *RunCommand) constructAPIMembers(
 		dbWorkerFactory,
 		workerVersion,
 		cmd.BaggageclaimResponseHeaderTimeout,
+		quarantiner,
+		metricsRegistry,
 	)
 
 	pool := worker.NewPool(workerProvider)
@@ -566,17 +654,30 @@ func (cmd *RunCommand) constructAPIMembers(
 		cmd.ResourceCheckingInterval,
 		cmd.ExternalURL.String(),
 		secretManager,
+		sourceDefaults,
 		checkContainerStrategy,
+		metricsRegistry,
 	)
 
 	credsManagers := cmd.CredentialManagers
-	dbPipelineFactory := db.NewPipelineFactory(dbConn, lockFactory)
-	dbJobFactory := db.NewJobFactory(dbConn, lockFactory)
-	dbResourceFactory := db.NewResourceFactory(dbConn, lockFactory)
 	dbContainerRepository := db.NewContainerRepository(dbConn)
-	gcContainerDestroyer := gc.NewDestroyer(logger, dbContainerRepository, dbVolumeRepository)
+	gcContainerDestroyer := gc.NewDestroyer(logger, dbContainerRepository, dbVolumeRepository, metricsRegistry)
 	dbBuildFactory := db.NewBuildFactory(dbConn, lockFactory, cmd.GC.OneOffBuildGracePeriod)
-	accessFactory := accessor.NewAccessFactory(authHandler.PublicKey())
+
+	// these only back the dashboard listings, which tolerate the lag of a read
+	// replica when one is configured
+	dbPipelineFactory := db.NewPipelineFactory(readConn, lockFactory)
+	dbJobFactory := db.NewJobFactory(readConn, lockFactory)
+	dbResourceFactory := db.NewResourceFactory(readConn, lockFactory)
+	dbReadBuildFactory := db.NewBuildFactory(readConn, lockFactory, cmd.GC.OneOffBuildGracePeriod)
+	dbAPITokenFactory := db.NewAPITokenFactory(dbConn)
+	dbAuditEventFactory := db.NewAuditEventFactory(dbConn)
+	dbWorkerKeyFactory := db.NewWorkerKeyFactory(dbConn)
+	dbStatusEventFactory := db.NewStatusEventFactory(dbConn)
+	dbNamedLockFactory := db.NewNamedLockFactory(dbConn)
+	dbHijackSessionFactory := db.NewHijackSessionFactory(dbConn)
+	accessFactory := accessor.NewAccessFactory(authHandler.PublicKey(), dbAPITokenFactory)
+	policyChecker := cmd.policyChecker()
 
 	apiHandler, err := cmd.constructAPIHandler(
 		logger,
@@ -590,12 +691,23 @@ func (cmd *RunCommand) constructAPIMembers(
 		dbContainerRepository,
 		gcContainerDestroyer,
 		dbBuildFactory,
+		dbReadBuildFactory,
 		dbResourceConfigFactory,
+		dbAPITokenFactory,
+		dbLocalUserFactory,
+		dbAuditEventFactory,
+		dbWorkerKeyFactory,
+		dbStatusEventFactory,
+		dbNamedLockFactory,
+		dbHijackSessionFactory,
 		workerClient,
 		radarScannerFactory,
 		secretManager,
+		sourceDefaults,
 		credsManagers,
 		accessFactory,
+		policyChecker,
+		metricsRegistry,
 	)
 
 	if err != nil {
@@ -603,7 +715,7 @@ func (cmd *RunCommand) constructAPIMembers(
 	}
 
 	indexhandler.ClusterName = cmd.Server.ClusterName
-	webHandler, err := webHandler(logger)
+	webHandler, err := webHandler(logger, metricsRegistry)
 	if err != nil {
 		return nil, err
 	}
@@ -681,6 +793,9 @@ func (cmd *RunCommand) constructBackendMembers(
 	dbConn db.Conn,
 	lockFactory lock.LockFactory,
 	secretManager creds.Secrets,
+	sourceDefaults atc.SourceDefaults,
+	quarantiner worker.Quarantiner,
+	metricsRegistry *metric.Registry,
 ) ([]grouper.Member, error) {
 
 	if cmd.Syslog.Address != "" && cmd.Syslog.Transport == "" {
@@ -728,6 +843,8 @@ func (cmd *RunCommand) constructBackendMembers(
 		dbWorkerFactory,
 		workerVersion,
 		cmd.BaggageclaimResponseHeaderTimeout,
+		quarantiner,
+		metricsRegistry,
 	)
 
 	pool := worker.NewPool(workerProvider)
@@ -749,17 +866,25 @@ func (cmd *RunCommand) constructBackendMembers(
 		dbResourceConfigFactory,
 		secretManager,
 		defaultLimits,
+		sourceDefaults,
 		buildContainerStrategy,
 		resourceFactory,
+		cmd.policyChecker(),
+		db.NewTaskResultFactory(dbConn),
+		db.NewNamedLockFactory(dbConn),
+		metricsRegistry,
 	)
 
 	radarSchedulerFactory := pipelines.NewRadarSchedulerFactory(
 		pool,
 		resourceFactory,
 		dbResourceConfigFactory,
+		teamFactory,
 		cmd.ResourceTypeCheckingInterval,
 		cmd.ResourceCheckingInterval,
+		sourceDefaults,
 		checkContainerStrategy,
+		metricsRegistry,
 	)
 
 	dbWorkerLifecycle := db.NewWorkerLifecycle(dbConn)
@@ -767,6 +892,9 @@ func (cmd *RunCommand) constructBackendMembers(
 	dbContainerRepository := db.NewContainerRepository(dbConn)
 	dbArtifactLifecycle := db.NewArtifactLifecycle(dbConn)
 	resourceConfigCheckSessionLifecycle := db.NewResourceConfigCheckSessionLifecycle(dbConn)
+	dbAuditEventFactory := db.NewAuditEventFactory(dbConn)
+	dbStatusEventFactory := db.NewStatusEventFactory(dbConn)
+	dbHijackSessionFactory := db.NewHijackSessionFactory(dbConn)
 	dbBuildFactory := db.NewBuildFactory(dbConn, lockFactory, cmd.GC.OneOffBuildGracePeriod)
 	bus := dbConn.Bus()
 	dbPipelineFactory := db.NewPipelineFactory(dbConn, lockFactory)
@@ -778,6 +906,7 @@ func (cmd *RunCommand) constructBackendMembers(
 				radarSchedulerFactory,
 				secretManager,
 				bus,
+				metricsRegistry,
 			),
 			Interval: 10 * time.Second,
 			Clock:    clock.NewClock(),
@@ -797,14 +926,15 @@ func (cmd *RunCommand) constructBackendMembers(
 			logger.Session("collector"),
 			gc.NewCollector(
 				gc.NewBuildCollector(dbBuildFactory),
-				gc.NewWorkerCollector(dbWorkerLifecycle),
+				gc.NewWorkerCollector(dbWorkerLifecycle, metricsRegistry),
 				gc.NewResourceCacheUseCollector(dbResourceCacheLifecycle),
 				gc.NewResourceConfigCollector(dbResourceConfigFactory),
 				gc.NewResourceCacheCollector(dbResourceCacheLifecycle),
-				gc.NewArtifactCollector(dbArtifactLifecycle),
+				gc.NewArtifactCollector(dbArtifactLifecycle, cmd.GC.RetainedOutputRetention),
 				gc.NewVolumeCollector(
 					dbVolumeRepository,
 					cmd.GC.MissingGracePeriod,
+					metricsRegistry,
 				),
 				gc.NewContainerCollector(
 					dbContainerRepository,
@@ -814,6 +944,7 @@ func (cmd *RunCommand) constructBackendMembers(
 						time.Minute,
 					),
 					cmd.GC.MissingGracePeriod,
+					metricsRegistry,
 				),
 				gc.NewResourceConfigCheckSessionCollector(
 					resourceConfigCheckSessionLifecycle,
@@ -843,6 +974,71 @@ func (cmd *RunCommand) constructBackendMembers(
 			clock.NewClock(),
 			30*time.Second,
 		)},
+		{Name: "build-record-collector", Runner: lockrunner.NewRunner(
+			logger.Session("build-record-collector"),
+			gc.NewBuildRecordCollector(
+				dbPipelineFactory,
+				500,
+				gc.NewBuildRetentionCalculator(
+					cmd.DefaultBuildsToRetain,
+					cmd.MaxBuildsToRetain,
+					cmd.DefaultDaysToRetainBuilds,
+					cmd.MaxDaysToRetainBuilds,
+				),
+				syslogDrainConfigured,
+			),
+			"build-record-collector",
+			lockFactory,
+			clock.NewClock(),
+			cmd.GC.Interval,
+		)},
+		{Name: "audit-event-collector", Runner: lockrunner.NewRunner(
+			logger.Session("audit-event-collector"),
+			gc.NewAuditEventCollector(
+				dbAuditEventFactory,
+				cmd.GC.AuditEventRetention,
+			),
+			"audit-event-collector",
+			lockFactory,
+			clock.NewClock(),
+			cmd.GC.Interval,
+		)},
+		{Name: "status-event-collector", Runner: lockrunner.NewRunner(
+			logger.Session("status-event-collector"),
+			gc.NewStatusEventCollector(
+				dbStatusEventFactory,
+				cmd.GC.StatusEventRetention,
+			),
+			"status-event-collector",
+			lockFactory,
+			clock.NewClock(),
+			cmd.GC.Interval,
+		)},
+		{Name: "hijack-session-collector", Runner: lockrunner.NewRunner(
+			logger.Session("hijack-session-collector"),
+			gc.NewHijackSessionCollector(
+				dbHijackSessionFactory,
+				cmd.GC.HijackSessionRetention,
+			),
+			"hijack-session-collector",
+			lockFactory,
+			clock.NewClock(),
+			cmd.GC.Interval,
+		)},
+		{Name: "resource-config-version-collector", Runner: lockrunner.NewRunner(
+			logger.Session("resource-config-version-collector"),
+			gc.NewResourceConfigVersionCollector(
+				db.NewResourceConfigVersionPruner(dbConn),
+				atc.VersionRetention{
+					Latest: cmd.GC.VersionRetentionLatest,
+					Days:   cmd.GC.VersionRetentionDays,
+				},
+			),
+			"resource-config-version-collector",
+			lockFactory,
+			clock.NewClock(),
+			cmd.GC.Interval,
+		)},
 	}
 
 	//Syslog Drainer Configuration
@@ -930,12 +1126,12 @@ func (cmd *RunCommand) oldKey() *encryption.Key {
 	return oldKey
 }
 
-func webHandler(logger lager.Logger) (http.Handler, error) {
+func webHandler(logger lager.Logger, metricsRegistry *metric.Registry) (http.Handler, error) {
 	webHandler, err := web.NewHandler(logger)
 	if err != nil {
 		return nil, err
 	}
-	return metric.WrapHandler(logger, "web", webHandler), nil
+	return metric.WrapHandler(logger, metricsRegistry, "web", webHandler), nil
 }
 
 func (cmd *RunCommand) skyHttpClient() (*http.Client, error) {
@@ -1011,11 +1207,22 @@ func (cmd *RunCommand) tlsConfig() (*tls.Config, error) {
 
 func (cmd *RunCommand) parseDefaultLimits() (atc.ContainerLimits, error) {
 	return atc.ContainerLimitsParser(map[string]interface{}{
-		"cpu":    cmd.DefaultCpuLimit,
-		"memory": cmd.DefaultMemoryLimit,
+		"cpu":     cmd.DefaultCpuLimit,
+		"memory":  cmd.DefaultMemoryLimit,
+		"disk":    cmd.DefaultDiskLimit,
+		"pids":    cmd.DefaultPidsLimit,
+		"network": cmd.DefaultNetwork,
 	})
 }
 
+func (cmd *RunCommand) policyChecker() policy.Checker {
+	if cmd.PolicyCheck.URL.URL == nil {
+		return policy.NoopChecker{}
+	}
+
+	return policy.NewAgentChecker(cmd.PolicyCheck.URL.String(), cmd.PolicyCheck.Timeout)
+}
+
 func (cmd *RunCommand) defaultBindIP() net.IP {
 	URL := cmd.BindIP.String()
 	if URL == "0.0.0.0" {
@@ -1057,6 +1264,52 @@ func run(runner ifrit.Runner, onReady func(), onExit func()) ifrit.Runner {
 	})
 }
 
+func (cmd *RunCommand) sourceDefaults() (atc.SourceDefaults, error) {
+	resourceDefaults, err := cmd.loadResourceSourceDefaults()
+	if err != nil {
+		return atc.SourceDefaults{}, err
+	}
+
+	return atc.SourceDefaults{
+		Resources:            resourceDefaults,
+		RegistryRewriteRules: cmd.RegistryRewriteRules,
+	}, nil
+}
+
+func (cmd *RunCommand) loadResourceSourceDefaults() (map[string]atc.Source, error) {
+	defaults := map[string]atc.Source{}
+
+	if cmd.ResourceSourceDefaults == "" {
+		return defaults, nil
+	}
+
+	content, err := ioutil.ReadFile(cmd.ResourceSourceDefaults.Path())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read resource source defaults: %s", err)
+	}
+
+	var rawDefaults map[string]interface{}
+	err = yaml.Unmarshal(content, &rawDefaults)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse resource source defaults: %s", err)
+	}
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:     &defaults,
+		DecodeHook: atc.SanitizeDecodeHook,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = decoder.Decode(rawDefaults)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resource source defaults: %s", err)
+	}
+
+	return defaults, nil
+}
+
 func (cmd *RunCommand) validate() error {
 	var errs *multierror.Error
 
@@ -1100,13 +1353,13 @@ func (cmd *RunCommand) debugBindAddr() string {
 	return fmt.Sprintf("%s:%d", cmd.DebugBindIP, cmd.DebugBindPort)
 }
 
-func (cmd *RunCommand) configureMetrics(logger lager.Logger) error {
+func (cmd *RunCommand) configureMetrics(logger lager.Logger) (*metric.Registry, error) {
 	host := cmd.Metrics.HostName
 	if host == "" {
 		host, _ = os.Hostname()
 	}
 
-	return metric.Initialize(logger.Session("metrics"), host, cmd.Metrics.Attributes)
+	return metric.Initialize(logger.Session("metrics"), host, cmd.Metrics.Attributes, cmd.Metrics.BufferSize)
 }
 
 func (cmd *RunCommand) constructDBConn(
@@ -1136,6 +1389,28 @@ func (cmd *RunCommand) constructDBConn(
 	return dbConn, nil
 }
 
+func (cmd *RunCommand) constructReadReplicaConn(
+	driverName string,
+	logger lager.Logger,
+	primary db.Conn,
+) (db.Conn, error) {
+	dbConn, err := db.OpenReadReplica(logger.Session("db-replica"), driverName, cmd.PostgresReplica.ConnectionString(), primary, "replica")
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to read replica: %s", err)
+	}
+
+	dbConn = metric.CountQueries(dbConn)
+	metric.Databases = append(metric.Databases, dbConn)
+
+	if cmd.LogDBQueries {
+		dbConn = db.Log(logger.Session("log-replica-conn"), dbConn)
+	}
+
+	dbConn.SetMaxOpenConns(cmd.PostgresReplica.MaxConns)
+
+	return dbConn, nil
+}
+
 type Closer interface {
 	Close() error
 }
@@ -1153,6 +1428,15 @@ func (cmd *RunCommand) constructLockConn(driverName string) (*sql.DB, error) {
 	return dbConn, nil
 }
 
+func (cmd *RunCommand) workerQuarantiner(metricsRegistry *metric.Registry) worker.Quarantiner {
+	return worker.NewQuarantiner(clock.NewClock(), worker.QuarantineConfig{
+		FailureThreshold: cmd.WorkerQuarantine.FailureThreshold,
+		FailureRate:      cmd.WorkerQuarantine.FailureRate,
+		Window:           cmd.WorkerQuarantine.Window,
+		Duration:         cmd.WorkerQuarantine.Duration,
+	}, metricsRegistry)
+}
+
 func (cmd *RunCommand) chooseBuildContainerStrategy() worker.ContainerPlacementStrategy {
 	var strategy worker.ContainerPlacementStrategy
 	switch cmd.ContainerPlacementStrategy {
@@ -1198,8 +1482,13 @@ func (cmd *RunCommand) constructEngine(
 	resourceConfigFactory db.ResourceConfigFactory,
 	secretManager creds.Secrets,
 	defaultLimits atc.ContainerLimits,
+	sourceDefaults atc.SourceDefaults,
 	strategy worker.ContainerPlacementStrategy,
 	resourceFactory resource.ResourceFactory,
+	policyChecker policy.Checker,
+	taskResultFactory db.TaskResultFactory,
+	namedLockFactory db.NamedLockFactory,
+	metricsRegistry *metric.Registry,
 ) engine.Engine {
 
 	stepFactory := builder.NewStepFactory(
@@ -1210,8 +1499,12 @@ func (cmd *RunCommand) constructEngine(
 		resourceConfigFactory,
 		secretManager,
 		defaultLimits,
+		sourceDefaults,
 		strategy,
 		resourceFactory,
+		policyChecker,
+		taskResultFactory,
+		namedLockFactory,
 	)
 
 	stepBuilder := builder.NewStepBuilder(
@@ -1220,7 +1513,7 @@ func (cmd *RunCommand) constructEngine(
 		cmd.ExternalURL.String(),
 	)
 
-	return engine.NewEngine(stepBuilder)
+	return engine.NewEngine(stepBuilder, metricsRegistry)
 }
 
 func (cmd *RunCommand) constructHTTPHandler(
@@ -1266,12 +1559,23 @@ func (cmd *RunCommand) constructAPIHandler(
 	dbContainerRepository db.ContainerRepository,
 	gcContainerDestroyer gc.Destroyer,
 	dbBuildFactory db.BuildFactory,
+	dbReadBuildFactory db.BuildFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
+	dbAPITokenFactory db.APITokenFactory,
+	dbLocalUserFactory db.LocalUserFactory,
+	dbAuditEventFactory db.AuditEventFactory,
+	dbWorkerKeyFactory db.WorkerKeyFactory,
+	dbStatusEventFactory db.StatusEventFactory,
+	dbNamedLockFactory db.NamedLockFactory,
+	dbHijackSessionFactory db.HijackSessionFactory,
 	workerClient worker.Client,
 	radarScannerFactory radar.ScannerFactory,
 	secretManager creds.Secrets,
+	sourceDefaults atc.SourceDefaults,
 	credsManagers creds.Managers,
 	accessFactory accessor.AccessFactory,
+	policyChecker policy.Checker,
+	metricsRegistry *metric.Registry,
 ) (http.Handler, error) {
 
 	checkPipelineAccessHandlerFactory := auth.NewCheckPipelineAccessHandlerFactory(teamFactory)
@@ -1279,6 +1583,16 @@ func (cmd *RunCommand) constructAPIHandler(
 	checkBuildWriteAccessHandlerFactory := auth.NewCheckBuildWriteAccessHandlerFactory(dbBuildFactory)
 	checkWorkerTeamAccessHandlerFactory := auth.NewCheckWorkerTeamAccessHandlerFactory(dbWorkerFactory)
 
+	trustedProxies := []*net.IPNet{}
+	for _, cidr := range cmd.Auditor.TrustedProxies {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid audit trusted proxy '%s': %s", cidr, err)
+		}
+
+		trustedProxies = append(trustedProxies, network)
+	}
+
 	aud := auditor.NewAuditor(
 		cmd.Auditor.EnableBuildAuditLog,
 		cmd.Auditor.EnableContainerAuditLog,
@@ -1289,10 +1603,12 @@ func (cmd *RunCommand) constructAPIHandler(
 		cmd.Auditor.EnableTeamAuditLog,
 		cmd.Auditor.EnableWorkerAuditLog,
 		cmd.Auditor.EnableVolumeAuditLog,
+		trustedProxies,
+		dbAuditEventFactory,
 		logger,
 	)
 	apiWrapper := wrappa.MultiWrappa{
-		wrappa.NewAPIMetricsWrappa(logger),
+		wrappa.NewAPIMetricsWrappa(logger, metricsRegistry),
 		wrappa.NewAPIAuthWrappa(
 			checkPipelineAccessHandlerFactory,
 			checkBuildReadAccessHandlerFactory,
@@ -1316,8 +1632,15 @@ func (cmd *RunCommand) constructAPIHandler(
 		dbVolumeRepository,
 		dbContainerRepository,
 		gcContainerDestroyer,
-		dbBuildFactory,
+		dbReadBuildFactory,
 		resourceConfigFactory,
+		dbAPITokenFactory,
+		dbLocalUserFactory,
+		dbAuditEventFactory,
+		dbWorkerKeyFactory,
+		dbStatusEventFactory,
+		dbNamedLockFactory,
+		dbHijackSessionFactory,
 
 		buildserver.NewEventHandler,
 
@@ -1332,8 +1655,12 @@ func (cmd *RunCommand) constructAPIHandler(
 		concourse.Version,
 		concourse.WorkerVersion,
 		secretManager,
+		sourceDefaults,
 		credsManagers,
 		containerserver.NewInterceptTimeoutFactory(cmd.InterceptIdleTimeout),
+		cmd.EnableHijackRecording,
+		policyChecker,
+		metricsRegistry,
 	)
 }
 
@@ -1364,6 +1691,7 @@ func (cmd *RunCommand) constructPipelineSyncer(
 	radarSchedulerFactory pipelines.RadarSchedulerFactory,
 	secretManager creds.Secrets,
 	bus db.NotificationsBus,
+	metricsRegistry *metric.Registry,
 ) *pipelines.Syncer {
 	return pipelines.NewSyncer(
 		logger,
@@ -1395,6 +1723,7 @@ func (cmd *RunCommand) constructPipelineSyncer(
 						Scheduler: radarSchedulerFactory.BuildScheduler(pipeline),
 						Noop:      cmd.Developer.Noop,
 						Interval:  10 * time.Second,
+						Registry:  metricsRegistry,
 					},
 				},
 			})
@@ -0,0 +1,13 @@
+package atc
+
+type AuditEvent struct {
+	ID        int    `json:"id"`
+	Actor     string `json:"actor"`
+	TeamName  string `json:"team_name,omitempty"`
+	Action    string `json:"action"`
+	Target    string `json:"target,omitempty"`
+	RequestID string `json:"request_id"`
+	SourceIP  string `json:"source_ip"`
+	Status    int    `json:"status"`
+	CreatedAt int64  `json:"created_at"`
+}
@@ -1,10 +1,15 @@
 package auditor
 
 import (
+	"net"
 	"net/http"
+	"sort"
+	"strings"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	uuid "github.com/nu7hatch/gouuid"
 )
 
 //go:generate counterfeiter . Auditor
@@ -19,6 +24,8 @@ func NewAuditor(
 	EnableTeamAuditLog bool,
 	EnableWorkerAuditLog bool,
 	EnableVolumeAuditLog bool,
+	trustedProxies []*net.IPNet,
+	auditEventFactory db.AuditEventFactory,
 	logger lager.Logger,
 ) *auditor {
 	return &auditor{
@@ -31,12 +38,15 @@ func NewAuditor(
 		EnableTeamAuditLog:      EnableTeamAuditLog,
 		EnableWorkerAuditLog:    EnableWorkerAuditLog,
 		EnableVolumeAuditLog:    EnableVolumeAuditLog,
+		trustedProxies:          trustedProxies,
+		auditEventFactory:       auditEventFactory,
 		logger:                  logger,
 	}
 }
 
 type Auditor interface {
 	Audit(action string, userName string, r *http.Request)
+	Record(action string, userName string, r *http.Request, status int)
 }
 
 type auditor struct {
@@ -49,6 +59,8 @@ type auditor struct {
 	EnableTeamAuditLog      bool
 	EnableWorkerAuditLog    bool
 	EnableVolumeAuditLog    bool
+	trustedProxies          []*net.IPNet
+	auditEventFactory       db.AuditEventFactory
 	logger                  lager.Logger
 }
 
@@ -84,12 +96,125 @@ func (a *auditor) Audit(action string, userName string, r *http.Request) {
 	}
 }
 
+// Record persists an audit event for every state-changing request, regardless
+// of the logging flags, so that it can later be queried through the API.
+func (a *auditor) Record(action string, userName string, r *http.Request, status int) {
+	if r.Method == http.MethodGet || r.Method == http.MethodHead || unrecordedActions[action] {
+		return
+	}
+
+	err := a.auditEventFactory.CreateAuditEvent(db.AuditEvent{
+		Actor:     userName,
+		TeamName:  r.URL.Query().Get(":team_name"),
+		Action:    action,
+		Target:    target(r),
+		RequestID: requestID(r),
+		SourceIP:  a.sourceIP(r),
+		Status:    status,
+	})
+	if err != nil {
+		a.logger.Error("failed-to-record-audit-event", err, lager.Data{"action": action, "user": userName})
+	}
+}
+
+// target describes what the request acted upon using its route parameters,
+// e.g. "job_name=some-job pipeline_name=some-pipeline".
+func target(r *http.Request) string {
+	params := []string{}
+	for key, values := range r.URL.Query() {
+		if !strings.HasPrefix(key, ":") || key == ":team_name" || len(values) == 0 {
+			continue
+		}
+
+		params = append(params, strings.TrimPrefix(key, ":")+"="+values[0])
+	}
+
+	sort.Strings(params)
+
+	return strings.Join(params, " ")
+}
+
+func requestID(r *http.Request) string {
+	if id := r.Header.Get("X-Request-Id"); id != "" {
+		return id
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		return ""
+	}
+
+	return id.String()
+}
+
+// sourceIP is the address the request came from. X-Forwarded-For is only
+// consulted when the request was made by a trusted proxy, in which case the
+// right-most hop that is not itself a trusted proxy is used; any hops to the
+// left of it could have been set by the client.
+func (a *auditor) sourceIP(r *http.Request) string {
+	remote, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		remote = r.RemoteAddr
+	}
+
+	if !a.trustedProxy(remote) {
+		return remote
+	}
+
+	hops := []string{}
+	for _, header := range r.Header["X-Forwarded-For"] {
+		for _, hop := range strings.Split(header, ",") {
+			hops = append(hops, strings.TrimSpace(hop))
+		}
+	}
+
+	for i := len(hops) - 1; i >= 0; i-- {
+		if !a.trustedProxy(hops[i]) {
+			return hops[i]
+		}
+	}
+
+	if len(hops) > 0 {
+		return hops[0]
+	}
+
+	return remote
+}
+
+func (a *auditor) trustedProxy(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+
+	for _, proxy := range a.trustedProxies {
+		if proxy.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// unrecordedActions are state-changing requests issued periodically by
+// workers; recording them would drown out the actions of users.
+var unrecordedActions = map[string]bool{
+	atc.RegisterWorker:         true,
+	atc.HeartbeatWorker:        true,
+	atc.ReportWorkerContainers: true,
+	atc.ReportWorkerVolumes:    true,
+}
+
 var loggingLevels = map[string]string{
 	atc.SaveConfig:                    "EnableSystemAuditLog",
 	atc.GetConfig:                     "EnableSystemAuditLog",
+	atc.ListConfigVersions:            "EnableSystemAuditLog",
+	atc.GetConfigVersion:              "EnableSystemAuditLog",
+	atc.DiffConfigVersions:            "EnableSystemAuditLog",
 	atc.GetCC:                         "EnableSystemAuditLog",
 	atc.GetBuild:                      "EnableBuildAuditLog",
 	atc.GetBuildPlan:                  "EnableBuildAuditLog",
+	atc.GetBuildTimeline:              "EnableBuildAuditLog",
 	atc.CreateBuild:                   "EnableBuildAuditLog",
 	atc.ListBuilds:                    "EnableBuildAuditLog",
 	atc.BuildEvents:                   "EnableBuildAuditLog",
@@ -167,4 +292,26 @@ var loggingLevels = map[string]string{
 	atc.CreateArtifact:                "EnableBuildAuditLog",
 	atc.GetArtifact:                   "EnableBuildAuditLog",
 	atc.ListBuildArtifacts:            "EnableBuildAuditLog",
+	atc.UnquarantineWorker:            "EnableWorkerAuditLog",
+	atc.CreateAPIToken:                "EnableTeamAuditLog",
+	atc.ListAPITokens:                 "EnableTeamAuditLog",
+	atc.RevokeAPIToken:                "EnableTeamAuditLog",
+	atc.ListWorkerKeys:                "EnableWorkerAuditLog",
+	atc.CreateWorkerKey:               "EnableWorkerAuditLog",
+	atc.DeleteWorkerKey:               "EnableWorkerAuditLog",
+	atc.ListTeamWorkerKeys:            "EnableTeamAuditLog",
+	atc.CreateTeamWorkerKey:           "EnableTeamAuditLog",
+	atc.DeleteTeamWorkerKey:           "EnableTeamAuditLog",
+	atc.ListLocalUsers:                "EnableSystemAuditLog",
+	atc.CreateLocalUser:               "EnableSystemAuditLog",
+	atc.SetLocalUserPassword:          "EnableSystemAuditLog",
+	atc.DisableLocalUser:              "EnableSystemAuditLog",
+	atc.EnableLocalUser:               "EnableSystemAuditLog",
+	atc.ListAuditEvents:               "EnableSystemAuditLog",
+	atc.ListTeamAuditEvents:           "EnableTeamAuditLog",
+	atc.StatusEvents:                  "EnableBuildAuditLog",
+	atc.ListNamedLocks:                "EnableTeamAuditLog",
+	atc.ReleaseNamedLock:              "EnableTeamAuditLog",
+	atc.ListHijackSessions:            "EnableContainerAuditLog",
+	atc.GetHijackSessionRecording:     "EnableContainerAuditLog",
 }
@@ -1,11 +1,15 @@
 package auditor_test
 
 import (
+	"errors"
+	"net"
 	"net/http"
 
 	"code.cloudfoundry.org/lager/lagertest"
 
 	"github.com/concourse/concourse/atc/auditor"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -50,6 +54,8 @@ var _ = Describe("Audit", func() {
 			EnableTeamAuditLog,
 			EnableWorkerAuditLog,
 			EnableVolumeAuditLog,
+			nil,
+			new(dbfakes.FakeAuditEventFactory),
 			logger,
 		)
 	})
@@ -570,3 +576,128 @@ var _ = Describe("Audit", func() {
 		})
 	})
 })
+
+var _ = Describe("Record", func() {
+	var (
+		aud                   auditor.Auditor
+		fakeAuditEventFactory *dbfakes.FakeAuditEventFactory
+		logger                *lagertest.TestLogger
+		req                   *http.Request
+		action                string
+	)
+
+	BeforeEach(func() {
+		fakeAuditEventFactory = new(dbfakes.FakeAuditEventFactory)
+		logger = lagertest.NewTestLogger("auditor")
+
+		_, trustedProxy, err := net.ParseCIDR("10.0.0.0/8")
+		Expect(err).NotTo(HaveOccurred())
+
+		aud = auditor.NewAuditor(false, false, false, false, false, false, false, false, false, []*net.IPNet{trustedProxy}, fakeAuditEventFactory, logger)
+
+		req, err = http.NewRequest("DELETE", "http://localhost:8080/api/v1/teams/some-team/pipelines/some-pipeline?:team_name=some-team&:pipeline_name=some-pipeline", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		req.RemoteAddr = "1.2.3.4:5678"
+		req.Header.Set("X-Request-Id", "some-request-id")
+
+		action = "DeletePipeline"
+	})
+
+	JustBeforeEach(func() {
+		aud.Record(action, "some-user", req, http.StatusNoContent)
+	})
+
+	It("persists the event regardless of the logging flags", func() {
+		Expect(fakeAuditEventFactory.CreateAuditEventCallCount()).To(Equal(1))
+
+		event := fakeAuditEventFactory.CreateAuditEventArgsForCall(0)
+		Expect(event).To(Equal(db.AuditEvent{
+			Actor:     "some-user",
+			TeamName:  "some-team",
+			Action:    "DeletePipeline",
+			Target:    "pipeline_name=some-pipeline",
+			RequestID: "some-request-id",
+			SourceIP:  "1.2.3.4",
+			Status:    http.StatusNoContent,
+		}))
+	})
+
+	Context("when the request claims to have been forwarded", func() {
+		BeforeEach(func() {
+			req.Header.Set("X-Forwarded-For", "5.6.7.8")
+		})
+
+		Context("by an untrusted peer", func() {
+			It("records the peer's address", func() {
+				event := fakeAuditEventFactory.CreateAuditEventArgsForCall(0)
+				Expect(event.SourceIP).To(Equal("1.2.3.4"))
+			})
+		})
+
+		Context("by a trusted proxy", func() {
+			BeforeEach(func() {
+				req.RemoteAddr = "10.0.0.2:5678"
+				req.Header.Set("X-Forwarded-For", "6.6.6.6, 5.6.7.8, 10.0.0.1")
+			})
+
+			It("records the right-most untrusted hop", func() {
+				event := fakeAuditEventFactory.CreateAuditEventArgsForCall(0)
+				Expect(event.SourceIP).To(Equal("5.6.7.8"))
+			})
+
+			Context("when every hop is trusted", func() {
+				BeforeEach(func() {
+					req.Header.Set("X-Forwarded-For", "10.0.0.3, 10.0.0.1")
+				})
+
+				It("records the left-most hop", func() {
+					event := fakeAuditEventFactory.CreateAuditEventArgsForCall(0)
+					Expect(event.SourceIP).To(Equal("10.0.0.3"))
+				})
+			})
+		})
+	})
+
+	Context("when the request has no ID", func() {
+		BeforeEach(func() {
+			req.Header.Del("X-Request-Id")
+		})
+
+		It("generates one", func() {
+			event := fakeAuditEventFactory.CreateAuditEventArgsForCall(0)
+			Expect(event.RequestID).NotTo(BeEmpty())
+		})
+	})
+
+	Context("when the request does not change anything", func() {
+		BeforeEach(func() {
+			req.Method = "GET"
+		})
+
+		It("does not persist an event", func() {
+			Expect(fakeAuditEventFactory.CreateAuditEventCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the request is a worker heartbeat", func() {
+		BeforeEach(func() {
+			req.Method = "PUT"
+			action = "HeartbeatWorker"
+		})
+
+		It("does not persist an event", func() {
+			Expect(fakeAuditEventFactory.CreateAuditEventCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when persisting the event fails", func() {
+		BeforeEach(func() {
+			fakeAuditEventFactory.CreateAuditEventReturns(errors.New("nope"))
+		})
+
+		It("logs the failure", func() {
+			Expect(logger.LogMessages()).To(ContainElement("auditor.failed-to-record-audit-event"))
+		})
+	})
+})
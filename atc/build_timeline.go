@@ -0,0 +1,47 @@
+package atc
+
+// CacheStatus is whether a step found what it needed already cached on its
+// worker.
+type CacheStatus string
+
+const (
+	CacheHit  CacheStatus = "hit"
+	CacheMiss CacheStatus = "miss"
+)
+
+type BuildTimeline struct {
+	BuildID   int   `json:"build_id"`
+	StartTime int64 `json:"start_time,omitempty"`
+	EndTime   int64 `json:"end_time,omitempty"`
+
+	// Nodes lists each node of the build's plan, with parents listed before
+	// their children.
+	Nodes []TimelineNode `json:"nodes"`
+}
+
+type TimelineNode struct {
+	ID       PlanID `json:"id"`
+	ParentID PlanID `json:"parent_id,omitempty"`
+	Depth    int    `json:"depth"`
+
+	// Type is the kind of plan, e.g. "get", "task" or "in_parallel".
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+
+	// QueuedTime is when the steps before the node had finished, and the
+	// node could begin.
+	QueuedTime     int64 `json:"queued_time,omitempty"`
+	InitializeTime int64 `json:"initialize_time,omitempty"`
+	StartTime      int64 `json:"start_time,omitempty"`
+	FinishTime     int64 `json:"finish_time,omitempty"`
+
+	Worker string      `json:"worker,omitempty"`
+	Cache  CacheStatus `json:"cache,omitempty"`
+
+	ExitStatus *int `json:"exit_status,omitempty"`
+	Errored    bool `json:"errored,omitempty"`
+
+	// Critical marks the nodes which bounded the build's duration; speeding
+	// up any other node would not have finished the build any sooner.
+	Critical bool `json:"critical,omitempty"`
+}
@@ -52,6 +52,16 @@ type ResourceConfig struct {
 	Tags         Tags    `yaml:"tags,omitempty" json:"tags" mapstructure:"tags"`
 	Version      Version `yaml:"version,omitempty" json:"version" mapstructure:"version"`
 	Icon         string  `yaml:"icon,omitempty" json:"icon,omitempty" mapstructure:"icon"`
+
+	VersionRetention *VersionRetention `yaml:"version_retention,omitempty" json:"version_retention,omitempty" mapstructure:"version_retention"`
+}
+
+// VersionRetention limits how many of a resource's versions are kept. A
+// version is kept if it is one of the Latest versions or was saved within
+// the last Days days; zero disables either limit.
+type VersionRetention struct {
+	Latest int `yaml:"latest,omitempty" json:"latest,omitempty" mapstructure:"latest"`
+	Days   int `yaml:"days,omitempty" json:"days,omitempty" mapstructure:"days"`
 }
 
 type ResourceType struct {
@@ -108,6 +118,32 @@ type VersionConfig struct {
 	Every  bool
 	Latest bool
 	Pinned Version
+
+	// Filter is the expression following "where" in e.g. "latest where
+	// semver(tag) >=1.4 <2". It is validated by ParseVersionFilter.
+	Filter string
+}
+
+func versionConfigFromString(s string) VersionConfig {
+	strategy, filter := s, ""
+	if i := strings.Index(s, VersionFilterSeparator); i != -1 {
+		strategy = s[:i]
+		filter = strings.TrimSpace(s[i+len(VersionFilterSeparator):])
+	}
+
+	return VersionConfig{
+		Every:  strategy == VersionEvery,
+		Latest: strategy == VersionLatest,
+		Filter: filter,
+	}
+}
+
+func (c VersionConfig) withFilter(strategy string) string {
+	if c.Filter == "" {
+		return strategy
+	}
+
+	return strategy + VersionFilterSeparator + c.Filter
 }
 
 func (c *VersionConfig) UnmarshalJSON(version []byte) error {
@@ -120,8 +156,7 @@ func (c *VersionConfig) UnmarshalJSON(version []byte) error {
 
 	switch actual := data.(type) {
 	case string:
-		c.Every = actual == "every"
-		c.Latest = actual == "latest"
+		*c = versionConfigFromString(actual)
 	case map[string]interface{}:
 		version := Version{}
 
@@ -149,8 +184,7 @@ func (c *VersionConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 
 	switch actual := data.(type) {
 	case string:
-		c.Every = actual == "every"
-		c.Latest = actual == "latest"
+		*c = versionConfigFromString(actual)
 	case map[interface{}]interface{}:
 		version := Version{}
 
@@ -172,11 +206,11 @@ func (c *VersionConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 
 func (c *VersionConfig) MarshalYAML() (interface{}, error) {
 	if c.Latest {
-		return VersionLatest, nil
+		return c.withFilter(VersionLatest), nil
 	}
 
 	if c.Every {
-		return VersionEvery, nil
+		return c.withFilter(VersionEvery), nil
 	}
 
 	if c.Pinned != nil {
@@ -188,11 +222,11 @@ func (c *VersionConfig) MarshalYAML() (interface{}, error) {
 
 func (c *VersionConfig) MarshalJSON() ([]byte, error) {
 	if c.Latest {
-		return json.Marshal(VersionLatest)
+		return json.Marshal(c.withFilter(VersionLatest))
 	}
 
 	if c.Every {
-		return json.Marshal(VersionEvery)
+		return json.Marshal(c.withFilter(VersionEvery))
 	}
 
 	if c.Pinned != nil {
@@ -377,6 +411,11 @@ type PlanConfig struct {
 	TaskVars Params `yaml:"vars,omitempty" json:"vars,omitempty" mapstructure:"vars"`
 	// inlined task config
 	TaskConfig *TaskConfig `yaml:"config,omitempty" json:"config,omitempty" mapstructure:"config"`
+	// keep the task's outputs after the build so that they can be downloaded
+	RetainOutputs bool `yaml:"retain_outputs,omitempty" json:"retain_outputs,omitempty" mapstructure:"retain_outputs"`
+	// skip the task and reuse the outputs of an earlier successful run with
+	// the same config, image and inputs
+	CacheResult bool `yaml:"cache_result,omitempty" json:"cache_result,omitempty" mapstructure:"cache_result"`
 
 	// used by Get and Put for specifying params to the resource
 	// used by Task for passing params to external task config
@@ -416,6 +455,12 @@ type PlanConfig struct {
 	// used on any step to interrupt the step after a given duration
 	Timeout string `yaml:"timeout,omitempty" json:"timeout,omitempty" mapstructure:"timeout"`
 
+	// used on any step to hold a named lock of the team while the step runs
+	Lock string `yaml:"lock,omitempty" json:"lock,omitempty" mapstructure:"lock"`
+
+	// used with lock to allow more than one build to hold it at a time
+	LockCapacity int `yaml:"lock_capacity,omitempty" json:"lock_capacity,omitempty" mapstructure:"lock_capacity"`
+
 	// not present in yaml
 	DependentGet string `yaml:"-" json:"-"`
 
@@ -45,5 +45,94 @@ var _ = Describe("Config", func() {
 				Expect(versionConfig).To(Equal(expected))
 			})
 		})
+
+		Context("when unmarshaling a filtered version from YAML", func() {
+			It("splits the strategy from the filter", func() {
+				var versionConfig VersionConfig
+				bs := []byte(`latest where semver(tag) >=1.4 <2`)
+				err := yaml.Unmarshal(bs, &versionConfig)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(versionConfig).To(Equal(VersionConfig{
+					Latest: true,
+					Filter: "semver(tag) >=1.4 <2",
+				}))
+			})
+		})
+
+		Context("when unmarshaling a filtered version from JSON", func() {
+			It("splits the strategy from the filter", func() {
+				var versionConfig VersionConfig
+				bs := []byte(`"every where ref =~ ^release/"`)
+				err := json.Unmarshal(bs, &versionConfig)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(versionConfig).To(Equal(VersionConfig{
+					Every:  true,
+					Filter: "ref =~ ^release/",
+				}))
+			})
+		})
+
+		Context("when marshaling a filtered version", func() {
+			It("round-trips through JSON", func() {
+				versionConfig := &VersionConfig{Every: true, Filter: "ref =~ ^release/"}
+
+				bs, err := json.Marshal(versionConfig)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bs).To(MatchJSON(`"every where ref =~ ^release/"`))
+
+				var unmarshaled VersionConfig
+				err = json.Unmarshal(bs, &unmarshaled)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(&unmarshaled).To(Equal(versionConfig))
+			})
+		})
+	})
+
+	Describe("ParseVersionFilter", func() {
+		It("matches semver constraints", func() {
+			filter, err := ParseVersionFilter("semver(tag) >=1.4 <2")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(filter.Matches(Version{"tag": "1.4.0"})).To(BeTrue())
+			Expect(filter.Matches(Version{"tag": "v1.9.3"})).To(BeTrue())
+			Expect(filter.Matches(Version{"tag": "1.3.9"})).To(BeFalse())
+			Expect(filter.Matches(Version{"tag": "2.0.0"})).To(BeFalse())
+			Expect(filter.Matches(Version{"tag": "not-a-version"})).To(BeFalse())
+			Expect(filter.Matches(Version{"ref": "1.5.0"})).To(BeFalse())
+		})
+
+		It("accepts constraints separated by commas and spaces", func() {
+			filter, err := ParseVersionFilter("semver(tag) >= 1.4, < 2")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(filter.Matches(Version{"tag": "1.5.0"})).To(BeTrue())
+			Expect(filter.Matches(Version{"tag": "2.1.0"})).To(BeFalse())
+		})
+
+		It("matches regular expressions", func() {
+			filter, err := ParseVersionFilter("ref =~ ^release/ and ref !~ -rc$")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(filter.Matches(Version{"ref": "release/1.0"})).To(BeTrue())
+			Expect(filter.Matches(Version{"ref": "release/1.0-rc"})).To(BeFalse())
+			Expect(filter.Matches(Version{"ref": "master"})).To(BeFalse())
+		})
+
+		It("rejects malformed expressions", func() {
+			for _, expression := range []string{
+				"",
+				"semver(tag)",
+				"semver(tag) >=banana",
+				"ref =~ (",
+				"=~ ^release/",
+				"ref == master",
+				"ref =~ ^release/ and  and ref =~ /1$",
+			} {
+				_, err := ParseVersionFilter(expression)
+				Expect(err).To(HaveOccurred(), expression)
+			}
+		})
 	})
 })
@@ -0,0 +1,29 @@
+package atc
+
+// ConfigVersion is an entry in a pipeline's config history. Config is only
+// present when fetching a single version.
+type ConfigVersion struct {
+	Version int     `json:"version"`
+	SavedBy string  `json:"saved_by,omitempty"`
+	SavedAt int64   `json:"saved_at"`
+	Config  *Config `json:"config,omitempty"`
+}
+
+const (
+	ConfigDiffAdded   = "+"
+	ConfigDiffRemoved = "-"
+	ConfigDiffCommon  = " "
+)
+
+// ConfigDiff is a line-by-line diff of the YAML of two versions of a
+// pipeline's config.
+type ConfigDiff struct {
+	From  int              `json:"from"`
+	To    int              `json:"to"`
+	Lines []ConfigDiffLine `json:"lines"`
+}
+
+type ConfigDiffLine struct {
+	Delta string `json:"delta"`
+	Text  string `json:"text"`
+}
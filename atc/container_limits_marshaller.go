@@ -17,8 +17,7 @@ func (c *ContainerLimits) UnmarshalJSON(limit []byte) error {
 		return err
 	}
 
-	c.CPU = climits.CPU
-	c.Memory = climits.Memory
+	*c = climits
 
 	return nil
 }
@@ -36,7 +35,6 @@ func (c *ContainerLimits) UnmarshalYAML(unmarshal func(interface{}) error) error
 		return err
 	}
 
-	c.CPU = climits.CPU
-	c.Memory = climits.Memory
+	*c = climits
 	return nil
 }
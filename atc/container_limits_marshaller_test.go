@@ -29,6 +29,32 @@ var _ = Describe("ContainerLimits", func() {
 		})
 	})
 
+	Context("when unmarshaling disk, pids and network limits from YAML", func() {
+		It("produces the correct ContainerLimits object without error", func() {
+			var containerLimits ContainerLimits
+			bs := []byte(`{ disk: 1GB, pids: 256, network: none }`)
+			err := yaml.Unmarshal(bs, &containerLimits)
+			Expect(err).NotTo(HaveOccurred())
+
+			disk := uint64(1073741824)
+			pids := uint64(256)
+			expected := ContainerLimits{
+				Disk:    &disk,
+				Pids:    &pids,
+				Network: ContainerNetworkNone,
+			}
+
+			Expect(containerLimits).To(Equal(expected))
+		})
+
+		It("rejects unknown network modes", func() {
+			var containerLimits ContainerLimits
+			bs := []byte(`{ network: bridge }`)
+			err := yaml.Unmarshal(bs, &containerLimits)
+			Expect(err).To(MatchError("network must be 'none' or 'default'"))
+		})
+	})
+
 	Context("when unmarshaling a container_limits from JSON", func() {
 		It("produces the correct ContainerLimits without error", func() {
 			var containerLimits ContainerLimits
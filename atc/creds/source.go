@@ -8,6 +8,10 @@ import (
 type Source struct {
 	variablesResolver Variables
 	rawSource         atc.Source
+
+	resourceType    string
+	defaults        atc.SourceDefaults
+	rewriteRegistry bool
 }
 
 func NewSource(variables Variables, source atc.Source) Source {
@@ -17,6 +21,25 @@ func NewSource(variables Variables, source atc.Source) Source {
 	}
 }
 
+// NewResourceSource is like NewSource, but applies the operator's defaults and
+// registry rewrite rules for the resource type once the source is evaluated.
+func NewResourceSource(variables Variables, resourceType string, source atc.Source, defaults atc.SourceDefaults) Source {
+	return Source{
+		variablesResolver: variables,
+		rawSource:         source,
+		resourceType:      resourceType,
+		defaults:          defaults,
+		rewriteRegistry:   true,
+	}
+}
+
+// WithoutRegistryRewrites returns the source with the operator's defaults but
+// without its repository rewritten, for pushing to the configured registry.
+func (s Source) WithoutRegistryRewrites() Source {
+	s.rewriteRegistry = false
+	return s
+}
+
 func (s Source) Evaluate() (atc.Source, error) {
 	var untypedInput interface{}
 
@@ -44,5 +67,40 @@ func (s Source) Evaluate() (atc.Source, error) {
 		return nil, err
 	}
 
+	source, _ = s.applyDefaults(source)
+
 	return source, nil
 }
+
+// AppliedDefaults describes the operator's defaults and registry rewrite rules
+// that change the source once it is evaluated.
+func (s Source) AppliedDefaults() ([]string, error) {
+	if s.resourceType == "" {
+		return nil, nil
+	}
+
+	source, err := NewSource(s.variablesResolver, s.rawSource).Evaluate()
+	if err != nil {
+		return nil, err
+	}
+
+	_, applied := s.applyDefaults(source)
+
+	return applied, nil
+}
+
+func (s Source) applyDefaults(source atc.Source) (atc.Source, []string) {
+	if s.resourceType == "" {
+		return source, nil
+	}
+
+	source, applied := s.defaults.Apply(s.resourceType, source)
+
+	if s.rewriteRegistry {
+		var rewritten []string
+		source, rewritten = s.defaults.RewriteRegistry(s.resourceType, source)
+		applied = append(applied, rewritten...)
+	}
+
+	return source, applied
+}
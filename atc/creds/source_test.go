@@ -35,4 +35,58 @@ var _ = Describe("Evaluate", func() {
 			}))
 		})
 	})
+
+	Context("when the source is for a resource type", func() {
+		BeforeEach(func() {
+			variables := template.StaticVariables{
+				"some-repository": "busybox",
+			}
+			source = creds.NewResourceSource(variables, "registry-image", atc.Source{
+				"repository": "((some-repository))",
+			}, atc.SourceDefaults{
+				Resources: map[string]atc.Source{
+					"registry-image": {"username": "mirror-user"},
+				},
+				RegistryRewriteRules: []atc.RegistryRewriteRule{
+					{From: "docker.io", To: "mirror.example.com"},
+				},
+			})
+		})
+
+		It("applies the rewrite rules once the variables are evaluated", func() {
+			result, err := source.Evaluate()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result).To(Equal(atc.Source{
+				"repository": "mirror.example.com/library/busybox",
+				"username":   "mirror-user",
+			}))
+		})
+
+		It("describes the defaults and rules that apply", func() {
+			applied, err := source.AppliedDefaults()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(applied).To(Equal([]string{
+				"source defaults for registry-image",
+				"registry rewrite docker.io => mirror.example.com",
+			}))
+		})
+
+		Context("without registry rewrites", func() {
+			BeforeEach(func() {
+				source = source.WithoutRegistryRewrites()
+			})
+
+			It("only applies the defaults", func() {
+				result, err := source.Evaluate()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(result).To(Equal(atc.Source{
+					"repository": "busybox",
+					"username":   "mirror-user",
+				}))
+			})
+		})
+	})
 })
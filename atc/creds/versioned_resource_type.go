@@ -22,6 +22,22 @@ func NewVersionedResourceTypes(variables Variables, rawTypes atc.VersionedResour
 	return types
 }
 
+// WithSourceDefaults returns the types with the operator's defaults and
+// registry rewrite rules applied to their sources, which are only ever used to
+// fetch the images of the types.
+func (types VersionedResourceTypes) WithSourceDefaults(defaults atc.SourceDefaults) VersionedResourceTypes {
+	var newTypes VersionedResourceTypes
+	for _, t := range types {
+		t.Source.resourceType = t.Type
+		t.Source.defaults = defaults
+		t.Source.rewriteRegistry = true
+
+		newTypes = append(newTypes, t)
+	}
+
+	return newTypes
+}
+
 func (types VersionedResourceTypes) Lookup(name string) (VersionedResourceType, bool) {
 	for _, t := range types {
 		if t.Name == name {
@@ -43,3 +59,25 @@ func (types VersionedResourceTypes) Without(name string) VersionedResourceTypes
 
 	return newTypes
 }
+
+// AppliedDefaults describes the operator's defaults and registry rewrite rules
+// that change the source of the named resource type and of the types it is
+// based on in turn.
+func (types VersionedResourceTypes) AppliedDefaults(name string) ([]string, error) {
+	resourceType, found := types.Lookup(name)
+	if !found {
+		return nil, nil
+	}
+
+	applied, err := resourceType.Source.AppliedDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	parentApplied, err := types.Without(name).AppliedDefaults(resourceType.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(applied, parentApplied...), nil
+}
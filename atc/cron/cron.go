@@ -0,0 +1,244 @@
+// Package cron parses standard five-field cron expressions and computes the
+// times at which they fire.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	minute field
+	hour   field
+	dom    field
+	month  field
+	dow    field
+
+	// cron fires when either day field matches if both are restricted
+	domRestricted bool
+	dowRestricted bool
+}
+
+type field uint64
+
+func (f field) has(n int) bool {
+	return f&(1<<uint(n)) != 0
+}
+
+type bounds struct {
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minutes = bounds{0, 59, nil}
+	hours   = bounds{0, 23, nil}
+	doms    = bounds{1, 31, nil}
+	months  = bounds{1, 12, map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	dows = bounds{0, 7, map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse parses a five-field cron expression (minute, hour, day of month,
+// month, day of week) or one of the @yearly, @monthly, @weekly, @daily and
+// @hourly macros.
+func Parse(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if expanded, ok := macros[strings.ToLower(spec)]; ok {
+		spec = expanded
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return Schedule{}, fmt.Errorf("expected 5 fields in cron expression '%s', found %d", spec, len(fields))
+	}
+
+	var (
+		schedule Schedule
+		err      error
+	)
+
+	schedule.minute, err = parseField(fields[0], minutes)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("invalid minute field: %s", err)
+	}
+
+	schedule.hour, err = parseField(fields[1], hours)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("invalid hour field: %s", err)
+	}
+
+	schedule.dom, err = parseField(fields[2], doms)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("invalid day of month field: %s", err)
+	}
+
+	schedule.month, err = parseField(fields[3], months)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("invalid month field: %s", err)
+	}
+
+	schedule.dow, err = parseField(fields[4], dows)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("invalid day of week field: %s", err)
+	}
+
+	// 7 is an alias for sunday
+	if schedule.dow.has(7) {
+		schedule.dow |= 1
+	}
+
+	schedule.domRestricted = fields[2] != "*" && fields[2] != "?"
+	schedule.dowRestricted = fields[4] != "*" && fields[4] != "?"
+
+	return schedule, nil
+}
+
+func parseField(expr string, b bounds) (field, error) {
+	var f field
+
+	for _, part := range strings.Split(expr, ",") {
+		step := 1
+
+		rangeExpr := part
+		if i := strings.Index(part, "/"); i != -1 {
+			var err error
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step in '%s'", part)
+			}
+
+			rangeExpr = part[:i]
+		}
+
+		var low, high int
+		switch {
+		case rangeExpr == "*" || rangeExpr == "?":
+			low, high = b.min, b.max
+		case strings.Contains(rangeExpr, "-"):
+			ends := strings.SplitN(rangeExpr, "-", 2)
+
+			var err error
+			low, err = parseValue(ends[0], b)
+			if err != nil {
+				return 0, err
+			}
+
+			high, err = parseValue(ends[1], b)
+			if err != nil {
+				return 0, err
+			}
+
+			if high < low {
+				return 0, fmt.Errorf("invalid range '%s'", rangeExpr)
+			}
+		default:
+			var err error
+			low, err = parseValue(rangeExpr, b)
+			if err != nil {
+				return 0, err
+			}
+
+			high = low
+			if strings.Contains(part, "/") {
+				high = b.max
+			}
+		}
+
+		for n := low; n <= high; n += step {
+			f |= 1 << uint(n)
+		}
+	}
+
+	return f, nil
+}
+
+func parseValue(s string, b bounds) (int, error) {
+	if n, ok := b.names[strings.ToLower(s)]; ok {
+		return n, nil
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value '%s'", s)
+	}
+
+	if n < b.min || n > b.max {
+		return 0, fmt.Errorf("value %d out of range [%d-%d]", n, b.min, b.max)
+	}
+
+	return n, nil
+}
+
+// Next returns the first time after t at which the schedule fires, in t's
+// location. It returns the zero time if the schedule can never fire, e.g.
+// "0 0 30 2 *".
+func (s Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	yearLimit := t.Year() + 5
+
+wrap:
+	if t.Year() > yearLimit {
+		return time.Time{}
+	}
+
+	for !s.month.has(int(t.Month())) {
+		t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		if t.Month() == time.January {
+			goto wrap
+		}
+	}
+
+	for !s.dayMatches(t) {
+		t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		if t.Day() == 1 {
+			goto wrap
+		}
+	}
+
+	for !s.hour.has(t.Hour()) {
+		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		if t.Hour() == 0 {
+			goto wrap
+		}
+	}
+
+	for !s.minute.has(t.Minute()) {
+		t = t.Add(time.Minute)
+		if t.Minute() == 0 {
+			goto wrap
+		}
+	}
+
+	return t
+}
+
+func (s Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom.has(t.Day())
+	dowMatch := s.dow.has(int(t.Weekday()))
+
+	if s.domRestricted && s.dowRestricted {
+		return domMatch || dowMatch
+	}
+
+	return domMatch && dowMatch
+}
@@ -0,0 +1,72 @@
+package cron_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc/cron"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Schedule", func() {
+	parseTime := func(s string) time.Time {
+		t, err := time.Parse("2006-01-02 15:04", s)
+		Expect(err).NotTo(HaveOccurred())
+		return t
+	}
+
+	DescribeTable("Next",
+		func(spec string, from string, expected string) {
+			schedule, err := cron.Parse(spec)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(schedule.Next(parseTime(from))).To(Equal(parseTime(expected)))
+		},
+		Entry("every minute", "* * * * *", "2019-05-10 10:15", "2019-05-10 10:16"),
+		Entry("a fixed time later in the day", "30 14 * * *", "2019-05-10 10:15", "2019-05-10 14:30"),
+		Entry("a fixed time earlier in the day", "30 2 * * *", "2019-05-10 10:15", "2019-05-11 02:30"),
+		Entry("a step", "*/20 * * * *", "2019-05-10 10:15", "2019-05-10 10:20"),
+		Entry("a step from an offset", "5/20 * * * *", "2019-05-10 10:26", "2019-05-10 10:45"),
+		Entry("a range with a step", "0 9-17/4 * * *", "2019-05-10 10:15", "2019-05-10 13:00"),
+		Entry("a list", "0 8,20 * * *", "2019-05-10 10:15", "2019-05-10 20:00"),
+		Entry("weekdays by name", "0 2 * * mon-fri", "2019-05-10 10:15", "2019-05-13 02:00"),
+		Entry("sunday as 7", "0 0 * * 7", "2019-05-10 10:15", "2019-05-12 00:00"),
+		Entry("a month by name", "0 0 1 jan *", "2019-05-10 10:15", "2020-01-01 00:00"),
+		Entry("either day field when both are restricted", "0 0 13 * fri", "2019-05-10 10:15", "2019-05-13 00:00"),
+		Entry("a leap day", "0 0 29 2 *", "2019-05-10 10:15", "2020-02-29 00:00"),
+		Entry("a macro", "@weekly", "2019-05-10 10:15", "2019-05-12 00:00"),
+		Entry("a time exactly on a slot", "15 10 * * *", "2019-05-10 10:15", "2019-05-11 10:15"),
+	)
+
+	It("returns the zero time for schedules that never fire", func() {
+		schedule, err := cron.Parse("0 0 30 2 *")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(schedule.Next(parseTime("2019-05-10 10:15")).IsZero()).To(BeTrue())
+	})
+
+	It("keeps the location of the given time", func() {
+		location, err := time.LoadLocation("America/New_York")
+		Expect(err).NotTo(HaveOccurred())
+
+		schedule, err := cron.Parse("0 2 * * *")
+		Expect(err).NotTo(HaveOccurred())
+
+		next := schedule.Next(time.Date(2019, 5, 10, 10, 15, 0, 0, location))
+		Expect(next).To(Equal(time.Date(2019, 5, 11, 2, 0, 0, 0, location)))
+	})
+
+	DescribeTable("invalid expressions",
+		func(spec string) {
+			_, err := cron.Parse(spec)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("too few fields", "* * * *"),
+		Entry("too many fields", "* * * * * *"),
+		Entry("an out of range value", "60 * * * *"),
+		Entry("an unknown name", "0 0 * * someday"),
+		Entry("a backwards range", "0 5-1 * * *"),
+		Entry("a zero step", "*/0 * * * *"),
+	)
+})
@@ -1,6 +1,7 @@
 package algorithm_test
 
 import (
+	"github.com/concourse/concourse/atc"
 	. "github.com/onsi/ginkgo/extensions/table"
 )
 
@@ -819,4 +820,80 @@ var _ = DescribeTable("Input resolving",
 			},
 		},
 	}),
+
+	Entry("uses the latest version matching a filter", Example{
+		DB: DB{
+			Resources: []DBRow{
+				{Resource: "resource-x", Version: "rxv1", CheckOrder: 1, Fields: atc.Version{"tag": "1.3.0"}},
+				{Resource: "resource-x", Version: "rxv2", CheckOrder: 2, Fields: atc.Version{"tag": "1.4.2"}},
+				{Resource: "resource-x", Version: "rxv3", CheckOrder: 3, Fields: atc.Version{"tag": "2.0.0"}},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:     "resource-x",
+				Resource: "resource-x",
+				Version:  Version{Latest: true, Filter: "semver(tag) >=1.4 <2"},
+			},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv2",
+			},
+		},
+	}),
+
+	Entry("fails to resolve when no version matches a filter", Example{
+		DB: DB{
+			Resources: []DBRow{
+				{Resource: "resource-x", Version: "rxv1", CheckOrder: 1, Fields: atc.Version{"ref": "master"}},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:     "resource-x",
+				Resource: "resource-x",
+				Version:  Version{Latest: true, Filter: "ref =~ ^release/"},
+			},
+		},
+
+		Result: Result{
+			OK:     false,
+			Values: map[string]string{},
+		},
+	}),
+
+	Entry("applies filters to versions which passed constraints", Example{
+		DB: DB{
+			Resources: []DBRow{
+				{Resource: "resource-x", Version: "rxv1", CheckOrder: 1, Fields: atc.Version{"ref": "release/1"}},
+				{Resource: "resource-x", Version: "rxv2", CheckOrder: 2, Fields: atc.Version{"ref": "master"}},
+			},
+
+			BuildOutputs: []DBRow{
+				{Job: "simple-a", BuildID: 1, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Job: "simple-a", BuildID: 2, Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:     "resource-x",
+				Resource: "resource-x",
+				Passed:   []string{"simple-a"},
+				Version:  Version{Filter: "ref =~ ^release/"},
+			},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv1",
+			},
+		},
+	}),
 )
@@ -1,5 +1,7 @@
 package algorithm
 
+import "github.com/concourse/concourse/atc"
+
 type VersionsDB struct {
 	ResourceVersions []ResourceVersion
 	BuildOutputs     []BuildOutput
@@ -12,6 +14,10 @@ type ResourceVersion struct {
 	VersionID  int
 	ResourceID int
 	CheckOrder int
+
+	// Version is only loaded for the versions of each resource, not for build
+	// inputs and outputs; it is used for evaluating version filters.
+	Version atc.Version `json:",omitempty"`
 }
 
 type BuildOutput struct {
@@ -70,6 +76,37 @@ func (db VersionsDB) LatestVersionOfResource(resourceID int) (VersionCandidate,
 	return candidate, found
 }
 
+func (db VersionsDB) LatestMatchingVersionOfResource(resourceID int, filter *atc.VersionFilter) (VersionCandidate, bool) {
+	var candidate VersionCandidate
+	var found bool
+
+	for _, v := range db.ResourceVersions {
+		if v.ResourceID == resourceID && v.CheckOrder > candidate.CheckOrder && filter.Matches(v.Version) {
+			candidate = VersionCandidate{
+				VersionID:  v.VersionID,
+				CheckOrder: v.CheckOrder,
+			}
+
+			found = true
+		}
+	}
+
+	return candidate, found
+}
+
+// MatchingVersionsOfResource returns the IDs of the versions of the resource
+// which match the filter.
+func (db VersionsDB) MatchingVersionsOfResource(resourceID int, filter *atc.VersionFilter) map[int]bool {
+	matching := map[int]bool{}
+	for _, v := range db.ResourceVersions {
+		if v.ResourceID == resourceID && filter.Matches(v.Version) {
+			matching[v.VersionID] = true
+		}
+	}
+
+	return matching
+}
+
 func (db VersionsDB) FindVersionOfResource(resourceID int, versionID int) (VersionCandidate, bool) {
 	var candidate VersionCandidate
 	var found bool
@@ -1,5 +1,7 @@
 package algorithm
 
+import "github.com/concourse/concourse/atc"
+
 type InputConfigs []InputConfig
 
 type InputConfig struct {
@@ -8,6 +10,7 @@ type InputConfig struct {
 	Passed          JobSet
 	UseEveryVersion bool
 	PinnedVersionID int
+	VersionFilter   *atc.VersionFilter
 	ResourceID      int
 	JobID           int
 }
@@ -22,12 +25,20 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 		if len(inputConfig.Passed) == 0 {
 			if inputConfig.UseEveryVersion {
 				versionCandidates = db.AllVersionsOfResource(inputConfig.ResourceID)
+
+				if inputConfig.VersionFilter != nil {
+					versionCandidates = versionCandidates.FilterByVersion(
+						db.MatchingVersionsOfResource(inputConfig.ResourceID, inputConfig.VersionFilter),
+					)
+				}
 			} else {
 				var versionCandidate VersionCandidate
 				var found bool
 
 				if inputConfig.PinnedVersionID != 0 {
 					versionCandidate, found = db.FindVersionOfResource(inputConfig.ResourceID, inputConfig.PinnedVersionID)
+				} else if inputConfig.VersionFilter != nil {
+					versionCandidate, found = db.LatestMatchingVersionOfResource(inputConfig.ResourceID, inputConfig.VersionFilter)
 				} else {
 					versionCandidate, found = db.LatestVersionOfResource(inputConfig.ResourceID)
 				}
@@ -48,6 +59,12 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 				inputConfig.Passed,
 			)
 
+			if inputConfig.VersionFilter != nil {
+				versionCandidates = versionCandidates.FilterByVersion(
+					db.MatchingVersionsOfResource(inputConfig.ResourceID, inputConfig.VersionFilter),
+				)
+			}
+
 			if versionCandidates.IsEmpty() {
 				return nil, false
 			}
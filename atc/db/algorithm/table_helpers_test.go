@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db/algorithm"
 	. "github.com/onsi/gomega"
 )
@@ -23,6 +24,7 @@ type DBRow struct {
 	Version    string
 	CheckOrder int
 	VersionID  int
+	Fields     atc.Version
 }
 
 type Example struct {
@@ -45,6 +47,7 @@ type Version struct {
 	Every  bool
 	Latest bool
 	Pinned string
+	Filter string
 }
 
 type Result struct {
@@ -111,6 +114,7 @@ func (example Example) Run() {
 				VersionID:  versionIDs.ID(row.Version),
 				ResourceID: resourceIDs.ID(row.Resource),
 				CheckOrder: row.CheckOrder,
+				Version:    row.Fields,
 			}
 			db.ResourceVersions = append(db.ResourceVersions, version)
 		}
@@ -152,12 +156,20 @@ func (example Example) Run() {
 			versionID = versionIDs.ID(input.Version.Pinned)
 		}
 
+		var filter *atc.VersionFilter
+		if input.Version.Filter != "" {
+			var err error
+			filter, err = atc.ParseVersionFilter(input.Version.Filter)
+			Expect(err).ToNot(HaveOccurred())
+		}
+
 		inputConfigs[i] = algorithm.InputConfig{
 			Name:            input.Name,
 			Passed:          passed,
 			ResourceID:      resourceIDs.ID(input.Resource),
 			UseEveryVersion: input.Version.Every,
 			PinnedVersionID: versionID,
+			VersionFilter:   filter,
 			JobID:           jobIDs.ID(CurrentJobName),
 		}
 	}
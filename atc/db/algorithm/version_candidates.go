@@ -86,6 +86,20 @@ func (candidates VersionCandidates) IntersectByVersion(other VersionCandidates)
 	return intersected
 }
 
+func (candidates VersionCandidates) FilterByVersion(versionIDs map[int]bool) VersionCandidates {
+	filtered := VersionCandidates{
+		constraints: candidates.constraints,
+	}
+
+	for _, version := range candidates.versions {
+		if versionIDs[version.id] {
+			filtered.Merge(version)
+		}
+	}
+
+	return filtered
+}
+
 func (candidates VersionCandidates) BuildIDs(jobID int) BuildSet {
 	builds, found := candidates.buildIDs[jobID]
 	if !found {
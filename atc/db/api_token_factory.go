@@ -0,0 +1,213 @@
+package db
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+)
+
+// APITokenPrefix marks a bearer token as a long-lived API token rather than
+// a JWT issued by skymarshal.
+const APITokenPrefix = "concourse_"
+
+var ErrAPITokenAlreadyExists = errors.New("api token already exists")
+
+type APIToken struct {
+	ID             int
+	Name           string
+	TeamID         int
+	TeamName       string
+	Owner          string
+	ServiceAccount bool
+	Roles          []string
+	CreatedAt      time.Time
+	ExpiresAt      time.Time
+}
+
+type APITokenSpec struct {
+	Name           string
+	Owner          string
+	ServiceAccount bool
+	Roles          []string
+	ExpiresAt      time.Time
+}
+
+//go:generate counterfeiter . APITokenFactory
+
+type APITokenFactory interface {
+	CreateToken(teamID int, spec APITokenSpec) (APIToken, string, error)
+	FindByToken(token string) (APIToken, bool, error)
+	TeamTokens(teamID int) ([]APIToken, error)
+	RevokeToken(teamID int, name string) (bool, error)
+}
+
+type apiTokenFactory struct {
+	conn Conn
+}
+
+func NewAPITokenFactory(conn Conn) APITokenFactory {
+	return &apiTokenFactory{
+		conn: conn,
+	}
+}
+
+var apiTokensQuery = psql.Select(`
+		a.id,
+		a.name,
+		a.team_id,
+		t.name,
+		a.owner,
+		a.service_account,
+		a.roles,
+		a.created_at,
+		a.expires_at
+	`).
+	From("api_tokens a").
+	Join("teams t ON t.id = a.team_id")
+
+// CreateToken generates a new token and stores only its hash. The plaintext
+// token is returned to the caller and cannot be recovered afterwards.
+func (f *apiTokenFactory) CreateToken(teamID int, spec APITokenSpec) (APIToken, string, error) {
+	secret := make([]byte, 32)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return APIToken{}, "", err
+	}
+
+	token := APITokenPrefix + hex.EncodeToString(secret)
+
+	var id int
+	err = psql.Insert("api_tokens").
+		Columns("name", "team_id", "owner", "service_account", "roles", "token_hash", "expires_at").
+		Values(spec.Name, teamID, spec.Owner, spec.ServiceAccount, pq.Array(spec.Roles), hashAPIToken(token), spec.ExpiresAt).
+		Suffix("RETURNING id").
+		RunWith(f.conn).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode {
+			return APIToken{}, "", ErrAPITokenAlreadyExists
+		}
+
+		return APIToken{}, "", err
+	}
+
+	apiToken, found, err := f.find(sq.Eq{"a.id": id})
+	if err != nil {
+		return APIToken{}, "", err
+	}
+
+	if !found {
+		return APIToken{}, "", errors.New("api token disappeared after creation")
+	}
+
+	return apiToken, token, nil
+}
+
+// FindByToken looks up an unexpired token by its plaintext value.
+func (f *apiTokenFactory) FindByToken(token string) (APIToken, bool, error) {
+	if !strings.HasPrefix(token, APITokenPrefix) {
+		return APIToken{}, false, nil
+	}
+
+	return f.find(sq.And{
+		sq.Eq{"a.token_hash": hashAPIToken(token)},
+		sq.Expr("a.expires_at > NOW()"),
+	})
+}
+
+func (f *apiTokenFactory) TeamTokens(teamID int) ([]APIToken, error) {
+	rows, err := apiTokensQuery.
+		Where(sq.Eq{"a.team_id": teamID}).
+		OrderBy("a.name").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	tokens := []APIToken{}
+	for rows.Next() {
+		token, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+func (f *apiTokenFactory) RevokeToken(teamID int, name string) (bool, error) {
+	result, err := psql.Delete("api_tokens").
+		Where(sq.Eq{
+			"team_id": teamID,
+			"name":    name,
+		}).
+		RunWith(f.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (f *apiTokenFactory) find(where sq.Sqlizer) (APIToken, bool, error) {
+	row := apiTokensQuery.
+		Where(where).
+		RunWith(f.conn).
+		QueryRow()
+
+	token, err := scanAPIToken(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return APIToken{}, false, nil
+		}
+
+		return APIToken{}, false, err
+	}
+
+	return token, true, nil
+}
+
+func scanAPIToken(row scannable) (APIToken, error) {
+	var token APIToken
+
+	err := row.Scan(
+		&token.ID,
+		&token.Name,
+		&token.TeamID,
+		&token.TeamName,
+		&token.Owner,
+		&token.ServiceAccount,
+		pq.Array(&token.Roles),
+		&token.CreatedAt,
+		&token.ExpiresAt,
+	)
+	if err != nil {
+		return APIToken{}, err
+	}
+
+	return token, nil
+}
+
+func hashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
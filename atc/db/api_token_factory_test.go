@@ -0,0 +1,150 @@
+package db_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("APITokenFactory", func() {
+	var (
+		apiTokenFactory db.APITokenFactory
+		spec            db.APITokenSpec
+	)
+
+	BeforeEach(func() {
+		apiTokenFactory = db.NewAPITokenFactory(dbConn)
+
+		spec = db.APITokenSpec{
+			Name:      "some-token",
+			Owner:     "some-user",
+			Roles:     []string{"member"},
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+	})
+
+	Describe("CreateToken", func() {
+		var (
+			apiToken  db.APIToken
+			plaintext string
+			err       error
+		)
+
+		JustBeforeEach(func() {
+			apiToken, plaintext, err = apiTokenFactory.CreateToken(defaultTeam.ID(), spec)
+		})
+
+		It("returns the token and its plaintext value", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(apiToken.Name).To(Equal("some-token"))
+			Expect(apiToken.TeamName).To(Equal(defaultTeam.Name()))
+			Expect(apiToken.Owner).To(Equal("some-user"))
+			Expect(apiToken.Roles).To(Equal([]string{"member"}))
+			Expect(strings.HasPrefix(plaintext, db.APITokenPrefix)).To(BeTrue())
+		})
+
+		It("does not store the plaintext value", func() {
+			var count int
+			err := dbConn.QueryRow(`SELECT COUNT(*) FROM api_tokens WHERE token_hash = $1`, plaintext).Scan(&count)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(BeZero())
+		})
+
+		Context("when a token with the same name exists in the team", func() {
+			BeforeEach(func() {
+				_, _, err := apiTokenFactory.CreateToken(defaultTeam.ID(), spec)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns ErrAPITokenAlreadyExists", func() {
+				Expect(err).To(Equal(db.ErrAPITokenAlreadyExists))
+			})
+		})
+	})
+
+	Describe("FindByToken", func() {
+		var plaintext string
+
+		BeforeEach(func() {
+			var err error
+			_, plaintext, err = apiTokenFactory.CreateToken(defaultTeam.ID(), spec)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("finds the token", func() {
+			apiToken, found, err := apiTokenFactory.FindByToken(plaintext)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(apiToken.Name).To(Equal("some-token"))
+		})
+
+		It("does not find unknown tokens", func() {
+			_, found, err := apiTokenFactory.FindByToken(db.APITokenPrefix + "bogus")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		Context("when the token has expired", func() {
+			BeforeEach(func() {
+				_, err := dbConn.Exec(`UPDATE api_tokens SET expires_at = NOW() - '1 minute'::interval`)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("does not find the token", func() {
+				_, found, err := apiTokenFactory.FindByToken(plaintext)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("TeamTokens", func() {
+		BeforeEach(func() {
+			_, _, err := apiTokenFactory.CreateToken(defaultTeam.ID(), spec)
+			Expect(err).ToNot(HaveOccurred())
+
+			otherTeam, err := teamFactory.CreateTeam(atc.Team{Name: "other-team"})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, _, err = apiTokenFactory.CreateToken(otherTeam.ID(), spec)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns only the team's tokens", func() {
+			tokens, err := apiTokenFactory.TeamTokens(defaultTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tokens).To(HaveLen(1))
+			Expect(tokens[0].TeamName).To(Equal(defaultTeam.Name()))
+		})
+	})
+
+	Describe("RevokeToken", func() {
+		var plaintext string
+
+		BeforeEach(func() {
+			var err error
+			_, plaintext, err = apiTokenFactory.CreateToken(defaultTeam.ID(), spec)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("deletes the token", func() {
+			found, err := apiTokenFactory.RevokeToken(defaultTeam.ID(), "some-token")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			_, found, err = apiTokenFactory.FindByToken(plaintext)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("returns false for unknown tokens", func() {
+			found, err := apiTokenFactory.RevokeToken(defaultTeam.ID(), "bogus")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,215 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+type AuditEvent struct {
+	ID        int
+	Actor     string
+	TeamName  string
+	Action    string
+	Target    string
+	RequestID string
+	SourceIP  string
+	Status    int
+	CreatedAt time.Time
+}
+
+// AuditEventFilter narrows down the events returned by AuditEvents. Zero
+// values are ignored.
+type AuditEventFilter struct {
+	TeamName string
+	Actor    string
+	Action   string
+
+	From time.Time // inclusive
+	To   time.Time // exclusive
+}
+
+//go:generate counterfeiter . AuditEventFactory
+
+type AuditEventFactory interface {
+	CreateAuditEvent(event AuditEvent) error
+	AuditEvents(filter AuditEventFilter, page Page) ([]AuditEvent, Pagination, error)
+	DeleteAuditEventsBefore(cutoff time.Time) error
+}
+
+type auditEventFactory struct {
+	conn Conn
+}
+
+func NewAuditEventFactory(conn Conn) AuditEventFactory {
+	return &auditEventFactory{
+		conn: conn,
+	}
+}
+
+func (f *auditEventFactory) CreateAuditEvent(event AuditEvent) error {
+	var teamName sql.NullString
+	if event.TeamName != "" {
+		teamName = sql.NullString{String: event.TeamName, Valid: true}
+	}
+
+	_, err := psql.Insert("audit_events").
+		Columns("actor", "team_name", "action", "target", "request_id", "source_ip", "status").
+		Values(event.Actor, teamName, event.Action, event.Target, event.RequestID, event.SourceIP, event.Status).
+		RunWith(f.conn).
+		Exec()
+
+	return err
+}
+
+// AuditEvents returns the events matching the filter, newest first, paginated
+// by event ID in the same way as builds.
+func (f *auditEventFactory) AuditEvents(filter AuditEventFilter, page Page) ([]AuditEvent, Pagination, error) {
+	where := filter.conditions()
+
+	query := psql.Select("id, actor, team_name, action, target, request_id, source_ip, status, created_at").
+		From("audit_events").
+		Where(where).
+		Limit(uint64(page.Limit))
+
+	var reverse bool
+	if page.Since == 0 && page.Until == 0 {
+		query = query.OrderBy("id DESC")
+	} else if page.Until != 0 && page.Since == 0 {
+		query = query.Where(sq.Gt{"id": page.Until}).OrderBy("id ASC")
+		reverse = true
+	} else if page.Since != 0 && page.Until == 0 {
+		query = query.Where(sq.Lt{"id": page.Since}).OrderBy("id DESC")
+	} else {
+		if page.Until > page.Since {
+			return nil, Pagination{}, fmt.Errorf("Invalid range boundaries")
+		}
+
+		query = query.Where(sq.And{
+			sq.Gt{"id": page.Until},
+			sq.Lt{"id": page.Since},
+		}).OrderBy("id ASC")
+		reverse = true
+	}
+
+	rows, err := query.RunWith(f.conn).Query()
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	defer Close(rows)
+
+	events := []AuditEvent{}
+	for rows.Next() {
+		event, err := scanAuditEvent(rows)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+
+		events = append(events, event)
+	}
+
+	if reverse {
+		for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+			events[i], events[j] = events[j], events[i]
+		}
+	}
+
+	if len(events) == 0 {
+		return events, Pagination{}, nil
+	}
+
+	var minID, maxID int
+	err = psql.Select("COALESCE(MAX(id), 0), COALESCE(MIN(id), 0)").
+		From("audit_events").
+		Where(where).
+		RunWith(f.conn).
+		QueryRow().
+		Scan(&maxID, &minID)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	first := events[0]
+	last := events[len(events)-1]
+
+	var pagination Pagination
+	if first.ID < maxID {
+		pagination.Previous = &Page{
+			Until: first.ID,
+			Limit: page.Limit,
+		}
+	}
+
+	if last.ID > minID {
+		pagination.Next = &Page{
+			Since: last.ID,
+			Limit: page.Limit,
+		}
+	}
+
+	return events, pagination, nil
+}
+
+func (f *auditEventFactory) DeleteAuditEventsBefore(cutoff time.Time) error {
+	_, err := psql.Delete("audit_events").
+		Where(sq.Lt{"created_at": cutoff}).
+		RunWith(f.conn).
+		Exec()
+
+	return err
+}
+
+func (filter AuditEventFilter) conditions() sq.And {
+	conditions := sq.And{}
+
+	if filter.TeamName != "" {
+		conditions = append(conditions, sq.Eq{"team_name": filter.TeamName})
+	}
+
+	if filter.Actor != "" {
+		conditions = append(conditions, sq.Eq{"actor": filter.Actor})
+	}
+
+	if filter.Action != "" {
+		conditions = append(conditions, sq.Eq{"action": filter.Action})
+	}
+
+	if !filter.From.IsZero() {
+		conditions = append(conditions, sq.GtOrEq{"created_at": filter.From})
+	}
+
+	if !filter.To.IsZero() {
+		conditions = append(conditions, sq.Lt{"created_at": filter.To})
+	}
+
+	return conditions
+}
+
+func scanAuditEvent(row scannable) (AuditEvent, error) {
+	var (
+		event    AuditEvent
+		teamName sql.NullString
+	)
+
+	err := row.Scan(
+		&event.ID,
+		&event.Actor,
+		&teamName,
+		&event.Action,
+		&event.Target,
+		&event.RequestID,
+		&event.SourceIP,
+		&event.Status,
+		&event.CreatedAt,
+	)
+	if err != nil {
+		return AuditEvent{}, err
+	}
+
+	event.TeamName = teamName.String
+
+	return event, nil
+}
@@ -0,0 +1,110 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditEventFactory", func() {
+	var auditEventFactory db.AuditEventFactory
+
+	BeforeEach(func() {
+		auditEventFactory = db.NewAuditEventFactory(dbConn)
+	})
+
+	createEvent := func(actor string, teamName string, action string) {
+		err := auditEventFactory.CreateAuditEvent(db.AuditEvent{
+			Actor:     actor,
+			TeamName:  teamName,
+			Action:    action,
+			Target:    "pipeline_name=some-pipeline",
+			RequestID: "some-request-id",
+			SourceIP:  "1.2.3.4",
+			Status:    200,
+		})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	Describe("AuditEvents", func() {
+		BeforeEach(func() {
+			createEvent("some-user", "some-team", "DeletePipeline")
+			createEvent("other-user", "other-team", "PausePipeline")
+			createEvent("some-user", "", "SetLogLevel")
+		})
+
+		It("returns every event, newest first", func() {
+			events, _, err := auditEventFactory.AuditEvents(db.AuditEventFilter{}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(3))
+			Expect(events[0].Action).To(Equal("SetLogLevel"))
+			Expect(events[0].TeamName).To(BeEmpty())
+			Expect(events[2].Action).To(Equal("DeletePipeline"))
+			Expect(events[2].Target).To(Equal("pipeline_name=some-pipeline"))
+			Expect(events[2].SourceIP).To(Equal("1.2.3.4"))
+			Expect(events[2].Status).To(Equal(200))
+		})
+
+		It("filters by team", func() {
+			events, _, err := auditEventFactory.AuditEvents(db.AuditEventFilter{TeamName: "some-team"}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].Action).To(Equal("DeletePipeline"))
+		})
+
+		It("filters by actor and action", func() {
+			events, _, err := auditEventFactory.AuditEvents(db.AuditEventFilter{
+				Actor:  "some-user",
+				Action: "SetLogLevel",
+			}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+		})
+
+		It("filters by time", func() {
+			events, _, err := auditEventFactory.AuditEvents(db.AuditEventFilter{
+				From: time.Now().Add(time.Hour),
+			}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(BeEmpty())
+		})
+
+		It("paginates", func() {
+			events, pagination, err := auditEventFactory.AuditEvents(db.AuditEventFilter{}, db.Page{Limit: 2})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(2))
+			Expect(pagination.Previous).To(BeNil())
+			Expect(pagination.Next).To(Equal(&db.Page{Since: events[1].ID, Limit: 2}))
+
+			events, pagination, err = auditEventFactory.AuditEvents(db.AuditEventFilter{}, *pagination.Next)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].Action).To(Equal("DeletePipeline"))
+			Expect(pagination.Previous).To(Equal(&db.Page{Until: events[0].ID, Limit: 2}))
+			Expect(pagination.Next).To(BeNil())
+		})
+	})
+
+	Describe("DeleteAuditEventsBefore", func() {
+		BeforeEach(func() {
+			createEvent("some-user", "some-team", "DeletePipeline")
+
+			_, err := dbConn.Exec(`UPDATE audit_events SET created_at = NOW() - '2 days'::interval`)
+			Expect(err).ToNot(HaveOccurred())
+
+			createEvent("some-user", "some-team", "PausePipeline")
+		})
+
+		It("removes only the older events", func() {
+			err := auditEventFactory.DeleteAuditEventsBefore(time.Now().Add(-24 * time.Hour))
+			Expect(err).ToNot(HaveOccurred())
+
+			events, _, err := auditEventFactory.AuditEvents(db.AuditEventFilter{}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].Action).To(Equal("PausePipeline"))
+		})
+	})
+})
@@ -90,6 +90,7 @@ type Build interface {
 	SetInterceptible(bool) error
 
 	Events(uint) (EventSource, error)
+	StepEvents() ([]event.Envelope, error)
 	SaveEvent(event atc.Event) error
 
 	Artifacts() ([]WorkerArtifact, error)
@@ -290,6 +291,11 @@ func (b *build) Start(plan atc.Plan) (bool, error) {
 		return false, err
 	}
 
+	err = saveBuildStatusEvent(tx, b.id, atc.StatusEventBuildStarted)
+	if err != nil {
+		return false, err
+	}
+
 	if b.jobID != 0 {
 		err = updateNextBuildForJob(tx, b.jobID)
 		if err != nil {
@@ -343,6 +349,16 @@ func (b *build) Finish(status BuildStatus) error {
 		return err
 	}
 
+	err = saveBuildStatusEvent(tx, b.id, atc.StatusEventBuildFinished)
+	if err != nil {
+		return err
+	}
+
+	err = releaseNamedLocksOfBuild(tx, b.id)
+	if err != nil {
+		return err
+	}
+
 	_, err = tx.Exec(fmt.Sprintf(`
 		DROP SEQUENCE %s
 	`, buildEventSeq(b.id)))
@@ -736,6 +752,47 @@ func (b *build) Events(from uint) (EventSource, error) {
 	), nil
 }
 
+// StepEvents returns every event saved for the build so far, excluding log
+// output. Unlike Events it does not wait for the build to complete.
+func (b *build) StepEvents() ([]event.Envelope, error) {
+	table := fmt.Sprintf("team_build_events_%d", b.teamID)
+	if b.pipelineID != 0 {
+		table = fmt.Sprintf("pipeline_build_events_%d", b.pipelineID)
+	}
+
+	rows, err := psql.Select("type", "version", "payload").
+		From(table).
+		Where(sq.Eq{"build_id": b.id}).
+		Where(sq.NotEq{"type": string(event.EventTypeLog)}).
+		OrderBy("event_id ASC").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	envelopes := []event.Envelope{}
+	for rows.Next() {
+		var t, v, p string
+		err := rows.Scan(&t, &v, &p)
+		if err != nil {
+			return nil, err
+		}
+
+		data := json.RawMessage(p)
+
+		envelopes = append(envelopes, event.Envelope{
+			Data:    &data,
+			Event:   atc.EventType(t),
+			Version: atc.EventVersion(v),
+		})
+	}
+
+	return envelopes, rows.Err()
+}
+
 func (b *build) SaveEvent(event atc.Event) error {
 	tx, err := b.conn.Begin()
 	if err != nil {
@@ -763,13 +820,13 @@ func (b *build) Artifact(artifactID int) (WorkerArtifact, error) {
 		conn: b.conn,
 	}
 
-	err := psql.Select("id", "name", "created_at").
+	err := psql.Select("id", "name", "created_at", "retained").
 		From("worker_artifacts").
 		Where(sq.Eq{
 			"id": artifactID,
 		}).
 		RunWith(b.conn).
-		Scan(&artifact.id, &artifact.name, &artifact.createdAt)
+		Scan(&artifact.id, &artifact.name, &artifact.createdAt, &artifact.retained)
 
 	return &artifact, err
 }
@@ -777,7 +834,7 @@ func (b *build) Artifact(artifactID int) (WorkerArtifact, error) {
 func (b *build) Artifacts() ([]WorkerArtifact, error) {
 	artifacts := []WorkerArtifact{}
 
-	rows, err := psql.Select("id", "name", "created_at").
+	rows, err := psql.Select("id", "name", "created_at", "retained").
 		From("worker_artifacts").
 		Where(sq.Eq{
 			"build_id": b.id,
@@ -796,7 +853,7 @@ func (b *build) Artifacts() ([]WorkerArtifact, error) {
 			buildID: b.id,
 		}
 
-		err = rows.Scan(&wa.id, &wa.name, &wa.createdAt)
+		err = rows.Scan(&wa.id, &wa.name, &wa.createdAt, &wa.retained)
 		if err != nil {
 			return nil, err
 		}
@@ -1183,6 +1240,18 @@ func createBuild(tx Tx, build *build, vals map[string]interface{}) error {
 		return err
 	}
 
+	err = saveBuildStatusEvent(tx, buildID, atc.StatusEventBuildCreated)
+	if err != nil {
+		return err
+	}
+
+	if build.status == BuildStatusStarted {
+		err = saveBuildStatusEvent(tx, buildID, atc.StatusEventBuildStarted)
+		if err != nil {
+			return err
+		}
+	}
+
 	return createBuildEventSeq(tx, buildID)
 }
 
@@ -90,7 +90,8 @@ var _ = BeforeEach(func() {
 
 	dbConn = postgresRunner.OpenConn()
 
-	lockFactory = lock.NewLockFactory(postgresRunner.OpenSingleton(), metric.LogLockAcquired, metric.LogLockReleased)
+	var metricsRegistry *metric.Registry
+	lockFactory = lock.NewLockFactory(postgresRunner.OpenSingleton(), metricsRegistry.LogLockAcquired, metricsRegistry.LogLockReleased)
 
 	buildFactory = db.NewBuildFactory(dbConn, lockFactory, 5*time.Minute)
 	volumeRepository = db.NewVolumeRepository(dbConn)
@@ -162,7 +163,7 @@ var _ = BeforeEach(func() {
 				},
 			},
 		},
-	}, db.ConfigVersion(0), db.PipelineUnpaused)
+	}, db.ConfigVersion(0), db.PipelineUnpaused, "")
 	Expect(err).NotTo(HaveOccurred())
 
 	var found bool
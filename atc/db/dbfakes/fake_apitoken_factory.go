@@ -0,0 +1,363 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeAPITokenFactory struct {
+	CreateTokenStub        func(int, db.APITokenSpec) (db.APIToken, string, error)
+	createTokenMutex       sync.RWMutex
+	createTokenArgsForCall []struct {
+		arg1 int
+		arg2 db.APITokenSpec
+	}
+	createTokenReturns struct {
+		result1 db.APIToken
+		result2 string
+		result3 error
+	}
+	createTokenReturnsOnCall map[int]struct {
+		result1 db.APIToken
+		result2 string
+		result3 error
+	}
+	FindByTokenStub        func(string) (db.APIToken, bool, error)
+	findByTokenMutex       sync.RWMutex
+	findByTokenArgsForCall []struct {
+		arg1 string
+	}
+	findByTokenReturns struct {
+		result1 db.APIToken
+		result2 bool
+		result3 error
+	}
+	findByTokenReturnsOnCall map[int]struct {
+		result1 db.APIToken
+		result2 bool
+		result3 error
+	}
+	RevokeTokenStub        func(int, string) (bool, error)
+	revokeTokenMutex       sync.RWMutex
+	revokeTokenArgsForCall []struct {
+		arg1 int
+		arg2 string
+	}
+	revokeTokenReturns struct {
+		result1 bool
+		result2 error
+	}
+	revokeTokenReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	TeamTokensStub        func(int) ([]db.APIToken, error)
+	teamTokensMutex       sync.RWMutex
+	teamTokensArgsForCall []struct {
+		arg1 int
+	}
+	teamTokensReturns struct {
+		result1 []db.APIToken
+		result2 error
+	}
+	teamTokensReturnsOnCall map[int]struct {
+		result1 []db.APIToken
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAPITokenFactory) CreateToken(arg1 int, arg2 db.APITokenSpec) (db.APIToken, string, error) {
+	fake.createTokenMutex.Lock()
+	ret, specificReturn := fake.createTokenReturnsOnCall[len(fake.createTokenArgsForCall)]
+	fake.createTokenArgsForCall = append(fake.createTokenArgsForCall, struct {
+		arg1 int
+		arg2 db.APITokenSpec
+	}{arg1, arg2})
+	fake.recordInvocation("CreateToken", []interface{}{arg1, arg2})
+	fake.createTokenMutex.Unlock()
+	if fake.CreateTokenStub != nil {
+		return fake.CreateTokenStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.createTokenReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeAPITokenFactory) CreateTokenCallCount() int {
+	fake.createTokenMutex.RLock()
+	defer fake.createTokenMutex.RUnlock()
+	return len(fake.createTokenArgsForCall)
+}
+
+func (fake *FakeAPITokenFactory) CreateTokenCalls(stub func(int, db.APITokenSpec) (db.APIToken, string, error)) {
+	fake.createTokenMutex.Lock()
+	defer fake.createTokenMutex.Unlock()
+	fake.CreateTokenStub = stub
+}
+
+func (fake *FakeAPITokenFactory) CreateTokenArgsForCall(i int) (int, db.APITokenSpec) {
+	fake.createTokenMutex.RLock()
+	defer fake.createTokenMutex.RUnlock()
+	argsForCall := fake.createTokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPITokenFactory) CreateTokenReturns(result1 db.APIToken, result2 string, result3 error) {
+	fake.createTokenMutex.Lock()
+	defer fake.createTokenMutex.Unlock()
+	fake.CreateTokenStub = nil
+	fake.createTokenReturns = struct {
+		result1 db.APIToken
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeAPITokenFactory) CreateTokenReturnsOnCall(i int, result1 db.APIToken, result2 string, result3 error) {
+	fake.createTokenMutex.Lock()
+	defer fake.createTokenMutex.Unlock()
+	fake.CreateTokenStub = nil
+	if fake.createTokenReturnsOnCall == nil {
+		fake.createTokenReturnsOnCall = make(map[int]struct {
+			result1 db.APIToken
+			result2 string
+			result3 error
+		})
+	}
+	fake.createTokenReturnsOnCall[i] = struct {
+		result1 db.APIToken
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeAPITokenFactory) FindByToken(arg1 string) (db.APIToken, bool, error) {
+	fake.findByTokenMutex.Lock()
+	ret, specificReturn := fake.findByTokenReturnsOnCall[len(fake.findByTokenArgsForCall)]
+	fake.findByTokenArgsForCall = append(fake.findByTokenArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("FindByToken", []interface{}{arg1})
+	fake.findByTokenMutex.Unlock()
+	if fake.FindByTokenStub != nil {
+		return fake.FindByTokenStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.findByTokenReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeAPITokenFactory) FindByTokenCallCount() int {
+	fake.findByTokenMutex.RLock()
+	defer fake.findByTokenMutex.RUnlock()
+	return len(fake.findByTokenArgsForCall)
+}
+
+func (fake *FakeAPITokenFactory) FindByTokenCalls(stub func(string) (db.APIToken, bool, error)) {
+	fake.findByTokenMutex.Lock()
+	defer fake.findByTokenMutex.Unlock()
+	fake.FindByTokenStub = stub
+}
+
+func (fake *FakeAPITokenFactory) FindByTokenArgsForCall(i int) string {
+	fake.findByTokenMutex.RLock()
+	defer fake.findByTokenMutex.RUnlock()
+	argsForCall := fake.findByTokenArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPITokenFactory) FindByTokenReturns(result1 db.APIToken, result2 bool, result3 error) {
+	fake.findByTokenMutex.Lock()
+	defer fake.findByTokenMutex.Unlock()
+	fake.FindByTokenStub = nil
+	fake.findByTokenReturns = struct {
+		result1 db.APIToken
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeAPITokenFactory) FindByTokenReturnsOnCall(i int, result1 db.APIToken, result2 bool, result3 error) {
+	fake.findByTokenMutex.Lock()
+	defer fake.findByTokenMutex.Unlock()
+	fake.FindByTokenStub = nil
+	if fake.findByTokenReturnsOnCall == nil {
+		fake.findByTokenReturnsOnCall = make(map[int]struct {
+			result1 db.APIToken
+			result2 bool
+			result3 error
+		})
+	}
+	fake.findByTokenReturnsOnCall[i] = struct {
+		result1 db.APIToken
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeAPITokenFactory) RevokeToken(arg1 int, arg2 string) (bool, error) {
+	fake.revokeTokenMutex.Lock()
+	ret, specificReturn := fake.revokeTokenReturnsOnCall[len(fake.revokeTokenArgsForCall)]
+	fake.revokeTokenArgsForCall = append(fake.revokeTokenArgsForCall, struct {
+		arg1 int
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("RevokeToken", []interface{}{arg1, arg2})
+	fake.revokeTokenMutex.Unlock()
+	if fake.RevokeTokenStub != nil {
+		return fake.RevokeTokenStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.revokeTokenReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPITokenFactory) RevokeTokenCallCount() int {
+	fake.revokeTokenMutex.RLock()
+	defer fake.revokeTokenMutex.RUnlock()
+	return len(fake.revokeTokenArgsForCall)
+}
+
+func (fake *FakeAPITokenFactory) RevokeTokenCalls(stub func(int, string) (bool, error)) {
+	fake.revokeTokenMutex.Lock()
+	defer fake.revokeTokenMutex.Unlock()
+	fake.RevokeTokenStub = stub
+}
+
+func (fake *FakeAPITokenFactory) RevokeTokenArgsForCall(i int) (int, string) {
+	fake.revokeTokenMutex.RLock()
+	defer fake.revokeTokenMutex.RUnlock()
+	argsForCall := fake.revokeTokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPITokenFactory) RevokeTokenReturns(result1 bool, result2 error) {
+	fake.revokeTokenMutex.Lock()
+	defer fake.revokeTokenMutex.Unlock()
+	fake.RevokeTokenStub = nil
+	fake.revokeTokenReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPITokenFactory) RevokeTokenReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.revokeTokenMutex.Lock()
+	defer fake.revokeTokenMutex.Unlock()
+	fake.RevokeTokenStub = nil
+	if fake.revokeTokenReturnsOnCall == nil {
+		fake.revokeTokenReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.revokeTokenReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPITokenFactory) TeamTokens(arg1 int) ([]db.APIToken, error) {
+	fake.teamTokensMutex.Lock()
+	ret, specificReturn := fake.teamTokensReturnsOnCall[len(fake.teamTokensArgsForCall)]
+	fake.teamTokensArgsForCall = append(fake.teamTokensArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("TeamTokens", []interface{}{arg1})
+	fake.teamTokensMutex.Unlock()
+	if fake.TeamTokensStub != nil {
+		return fake.TeamTokensStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.teamTokensReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPITokenFactory) TeamTokensCallCount() int {
+	fake.teamTokensMutex.RLock()
+	defer fake.teamTokensMutex.RUnlock()
+	return len(fake.teamTokensArgsForCall)
+}
+
+func (fake *FakeAPITokenFactory) TeamTokensCalls(stub func(int) ([]db.APIToken, error)) {
+	fake.teamTokensMutex.Lock()
+	defer fake.teamTokensMutex.Unlock()
+	fake.TeamTokensStub = stub
+}
+
+func (fake *FakeAPITokenFactory) TeamTokensArgsForCall(i int) int {
+	fake.teamTokensMutex.RLock()
+	defer fake.teamTokensMutex.RUnlock()
+	argsForCall := fake.teamTokensArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPITokenFactory) TeamTokensReturns(result1 []db.APIToken, result2 error) {
+	fake.teamTokensMutex.Lock()
+	defer fake.teamTokensMutex.Unlock()
+	fake.TeamTokensStub = nil
+	fake.teamTokensReturns = struct {
+		result1 []db.APIToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPITokenFactory) TeamTokensReturnsOnCall(i int, result1 []db.APIToken, result2 error) {
+	fake.teamTokensMutex.Lock()
+	defer fake.teamTokensMutex.Unlock()
+	fake.TeamTokensStub = nil
+	if fake.teamTokensReturnsOnCall == nil {
+		fake.teamTokensReturnsOnCall = make(map[int]struct {
+			result1 []db.APIToken
+			result2 error
+		})
+	}
+	fake.teamTokensReturnsOnCall[i] = struct {
+		result1 []db.APIToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPITokenFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createTokenMutex.RLock()
+	defer fake.createTokenMutex.RUnlock()
+	fake.findByTokenMutex.RLock()
+	defer fake.findByTokenMutex.RUnlock()
+	fake.revokeTokenMutex.RLock()
+	defer fake.revokeTokenMutex.RUnlock()
+	fake.teamTokensMutex.RLock()
+	defer fake.teamTokensMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeAPITokenFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.APITokenFactory = new(FakeAPITokenFactory)
@@ -11,6 +11,7 @@ import (
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/lock"
+	"github.com/concourse/concourse/atc/event"
 )
 
 type FakeBuild struct {
@@ -466,6 +467,18 @@ type FakeBuild struct {
 	statusReturnsOnCall map[int]struct {
 		result1 db.BuildStatus
 	}
+	StepEventsStub        func() ([]event.Envelope, error)
+	stepEventsMutex       sync.RWMutex
+	stepEventsArgsForCall []struct {
+	}
+	stepEventsReturns struct {
+		result1 []event.Envelope
+		result2 error
+	}
+	stepEventsReturnsOnCall map[int]struct {
+		result1 []event.Envelope
+		result2 error
+	}
 	TeamIDStub        func() int
 	teamIDMutex       sync.RWMutex
 	teamIDArgsForCall []struct {
@@ -2720,6 +2733,61 @@ func (fake *FakeBuild) StatusReturnsOnCall(i int, result1 db.BuildStatus) {
 	}{result1}
 }
 
+func (fake *FakeBuild) StepEvents() ([]event.Envelope, error) {
+	fake.stepEventsMutex.Lock()
+	ret, specificReturn := fake.stepEventsReturnsOnCall[len(fake.stepEventsArgsForCall)]
+	fake.stepEventsArgsForCall = append(fake.stepEventsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("StepEvents", []interface{}{})
+	fake.stepEventsMutex.Unlock()
+	if fake.StepEventsStub != nil {
+		return fake.StepEventsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.stepEventsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) StepEventsCallCount() int {
+	fake.stepEventsMutex.RLock()
+	defer fake.stepEventsMutex.RUnlock()
+	return len(fake.stepEventsArgsForCall)
+}
+
+func (fake *FakeBuild) StepEventsCalls(stub func() ([]event.Envelope, error)) {
+	fake.stepEventsMutex.Lock()
+	defer fake.stepEventsMutex.Unlock()
+	fake.StepEventsStub = stub
+}
+
+func (fake *FakeBuild) StepEventsReturns(result1 []event.Envelope, result2 error) {
+	fake.stepEventsMutex.Lock()
+	defer fake.stepEventsMutex.Unlock()
+	fake.StepEventsStub = nil
+	fake.stepEventsReturns = struct {
+		result1 []event.Envelope
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) StepEventsReturnsOnCall(i int, result1 []event.Envelope, result2 error) {
+	fake.stepEventsMutex.Lock()
+	defer fake.stepEventsMutex.Unlock()
+	fake.StepEventsStub = nil
+	if fake.stepEventsReturnsOnCall == nil {
+		fake.stepEventsReturnsOnCall = make(map[int]struct {
+			result1 []event.Envelope
+			result2 error
+		})
+	}
+	fake.stepEventsReturnsOnCall[i] = struct {
+		result1 []event.Envelope
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) TeamID() int {
 	fake.teamIDMutex.Lock()
 	ret, specificReturn := fake.teamIDReturnsOnCall[len(fake.teamIDArgsForCall)]
@@ -2972,6 +3040,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.startTimeMutex.RUnlock()
 	fake.statusMutex.RLock()
 	defer fake.statusMutex.RUnlock()
+	fake.stepEventsMutex.RLock()
+	defer fake.stepEventsMutex.RUnlock()
 	fake.teamIDMutex.RLock()
 	defer fake.teamIDMutex.RUnlock()
 	fake.teamNameMutex.RLock()
@@ -91,6 +91,20 @@ type FakeCreatedVolume struct {
 	initializeResourceCacheReturnsOnCall map[int]struct {
 		result1 error
 	}
+	InitializeRetainedArtifactStub        func(string, int) (db.WorkerArtifact, error)
+	initializeRetainedArtifactMutex       sync.RWMutex
+	initializeRetainedArtifactArgsForCall []struct {
+		arg1 string
+		arg2 int
+	}
+	initializeRetainedArtifactReturns struct {
+		result1 db.WorkerArtifact
+		result2 error
+	}
+	initializeRetainedArtifactReturnsOnCall map[int]struct {
+		result1 db.WorkerArtifact
+		result2 error
+	}
 	InitializeTaskCacheStub        func(int, string, string) error
 	initializeTaskCacheMutex       sync.RWMutex
 	initializeTaskCacheArgsForCall []struct {
@@ -598,6 +612,70 @@ func (fake *FakeCreatedVolume) InitializeResourceCacheReturnsOnCall(i int, resul
 	}{result1}
 }
 
+func (fake *FakeCreatedVolume) InitializeRetainedArtifact(arg1 string, arg2 int) (db.WorkerArtifact, error) {
+	fake.initializeRetainedArtifactMutex.Lock()
+	ret, specificReturn := fake.initializeRetainedArtifactReturnsOnCall[len(fake.initializeRetainedArtifactArgsForCall)]
+	fake.initializeRetainedArtifactArgsForCall = append(fake.initializeRetainedArtifactArgsForCall, struct {
+		arg1 string
+		arg2 int
+	}{arg1, arg2})
+	fake.recordInvocation("InitializeRetainedArtifact", []interface{}{arg1, arg2})
+	fake.initializeRetainedArtifactMutex.Unlock()
+	if fake.InitializeRetainedArtifactStub != nil {
+		return fake.InitializeRetainedArtifactStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.initializeRetainedArtifactReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCreatedVolume) InitializeRetainedArtifactCallCount() int {
+	fake.initializeRetainedArtifactMutex.RLock()
+	defer fake.initializeRetainedArtifactMutex.RUnlock()
+	return len(fake.initializeRetainedArtifactArgsForCall)
+}
+
+func (fake *FakeCreatedVolume) InitializeRetainedArtifactCalls(stub func(string, int) (db.WorkerArtifact, error)) {
+	fake.initializeRetainedArtifactMutex.Lock()
+	defer fake.initializeRetainedArtifactMutex.Unlock()
+	fake.InitializeRetainedArtifactStub = stub
+}
+
+func (fake *FakeCreatedVolume) InitializeRetainedArtifactArgsForCall(i int) (string, int) {
+	fake.initializeRetainedArtifactMutex.RLock()
+	defer fake.initializeRetainedArtifactMutex.RUnlock()
+	argsForCall := fake.initializeRetainedArtifactArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCreatedVolume) InitializeRetainedArtifactReturns(result1 db.WorkerArtifact, result2 error) {
+	fake.initializeRetainedArtifactMutex.Lock()
+	defer fake.initializeRetainedArtifactMutex.Unlock()
+	fake.InitializeRetainedArtifactStub = nil
+	fake.initializeRetainedArtifactReturns = struct {
+		result1 db.WorkerArtifact
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCreatedVolume) InitializeRetainedArtifactReturnsOnCall(i int, result1 db.WorkerArtifact, result2 error) {
+	fake.initializeRetainedArtifactMutex.Lock()
+	defer fake.initializeRetainedArtifactMutex.Unlock()
+	fake.InitializeRetainedArtifactStub = nil
+	if fake.initializeRetainedArtifactReturnsOnCall == nil {
+		fake.initializeRetainedArtifactReturnsOnCall = make(map[int]struct {
+			result1 db.WorkerArtifact
+			result2 error
+		})
+	}
+	fake.initializeRetainedArtifactReturnsOnCall[i] = struct {
+		result1 db.WorkerArtifact
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeCreatedVolume) InitializeTaskCache(arg1 int, arg2 string, arg3 string) error {
 	fake.initializeTaskCacheMutex.Lock()
 	ret, specificReturn := fake.initializeTaskCacheReturnsOnCall[len(fake.initializeTaskCacheArgsForCall)]
@@ -1105,6 +1183,8 @@ func (fake *FakeCreatedVolume) Invocations() map[string][][]interface{} {
 	defer fake.initializeArtifactMutex.RUnlock()
 	fake.initializeResourceCacheMutex.RLock()
 	defer fake.initializeResourceCacheMutex.RUnlock()
+	fake.initializeRetainedArtifactMutex.RLock()
+	defer fake.initializeRetainedArtifactMutex.RUnlock()
 	fake.initializeTaskCacheMutex.RLock()
 	defer fake.initializeTaskCacheMutex.RUnlock()
 	fake.parentHandleMutex.RLock()
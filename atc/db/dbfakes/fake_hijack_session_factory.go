@@ -0,0 +1,585 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeHijackSessionFactory struct {
+	CreateHijackSessionStub        func(db.HijackSession) (db.HijackSession, error)
+	createHijackSessionMutex       sync.RWMutex
+	createHijackSessionArgsForCall []struct {
+		arg1 db.HijackSession
+	}
+	createHijackSessionReturns struct {
+		result1 db.HijackSession
+		result2 error
+	}
+	createHijackSessionReturnsOnCall map[int]struct {
+		result1 db.HijackSession
+		result2 error
+	}
+	DeleteHijackSessionsBeforeStub        func(time.Time) error
+	deleteHijackSessionsBeforeMutex       sync.RWMutex
+	deleteHijackSessionsBeforeArgsForCall []struct {
+		arg1 time.Time
+	}
+	deleteHijackSessionsBeforeReturns struct {
+		result1 error
+	}
+	deleteHijackSessionsBeforeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	FinishHijackSessionStub        func(int, *int) error
+	finishHijackSessionMutex       sync.RWMutex
+	finishHijackSessionArgsForCall []struct {
+		arg1 int
+		arg2 *int
+	}
+	finishHijackSessionReturns struct {
+		result1 error
+	}
+	finishHijackSessionReturnsOnCall map[int]struct {
+		result1 error
+	}
+	HijackSessionStub        func(int, int) (db.HijackSession, bool, error)
+	hijackSessionMutex       sync.RWMutex
+	hijackSessionArgsForCall []struct {
+		arg1 int
+		arg2 int
+	}
+	hijackSessionReturns struct {
+		result1 db.HijackSession
+		result2 bool
+		result3 error
+	}
+	hijackSessionReturnsOnCall map[int]struct {
+		result1 db.HijackSession
+		result2 bool
+		result3 error
+	}
+	HijackSessionEventsStub        func(int) ([]db.HijackSessionEvent, error)
+	hijackSessionEventsMutex       sync.RWMutex
+	hijackSessionEventsArgsForCall []struct {
+		arg1 int
+	}
+	hijackSessionEventsReturns struct {
+		result1 []db.HijackSessionEvent
+		result2 error
+	}
+	hijackSessionEventsReturnsOnCall map[int]struct {
+		result1 []db.HijackSessionEvent
+		result2 error
+	}
+	HijackSessionsStub        func(int) ([]db.HijackSession, error)
+	hijackSessionsMutex       sync.RWMutex
+	hijackSessionsArgsForCall []struct {
+		arg1 int
+	}
+	hijackSessionsReturns struct {
+		result1 []db.HijackSession
+		result2 error
+	}
+	hijackSessionsReturnsOnCall map[int]struct {
+		result1 []db.HijackSession
+		result2 error
+	}
+	SaveHijackSessionEventsStub        func(int, []db.HijackSessionEvent) error
+	saveHijackSessionEventsMutex       sync.RWMutex
+	saveHijackSessionEventsArgsForCall []struct {
+		arg1 int
+		arg2 []db.HijackSessionEvent
+	}
+	saveHijackSessionEventsReturns struct {
+		result1 error
+	}
+	saveHijackSessionEventsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeHijackSessionFactory) CreateHijackSession(arg1 db.HijackSession) (db.HijackSession, error) {
+	fake.createHijackSessionMutex.Lock()
+	ret, specificReturn := fake.createHijackSessionReturnsOnCall[len(fake.createHijackSessionArgsForCall)]
+	fake.createHijackSessionArgsForCall = append(fake.createHijackSessionArgsForCall, struct {
+		arg1 db.HijackSession
+	}{arg1})
+	fake.recordInvocation("CreateHijackSession", []interface{}{arg1})
+	fake.createHijackSessionMutex.Unlock()
+	if fake.CreateHijackSessionStub != nil {
+		return fake.CreateHijackSessionStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createHijackSessionReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeHijackSessionFactory) CreateHijackSessionCallCount() int {
+	fake.createHijackSessionMutex.RLock()
+	defer fake.createHijackSessionMutex.RUnlock()
+	return len(fake.createHijackSessionArgsForCall)
+}
+
+func (fake *FakeHijackSessionFactory) CreateHijackSessionCalls(stub func(db.HijackSession) (db.HijackSession, error)) {
+	fake.createHijackSessionMutex.Lock()
+	defer fake.createHijackSessionMutex.Unlock()
+	fake.CreateHijackSessionStub = stub
+}
+
+func (fake *FakeHijackSessionFactory) CreateHijackSessionArgsForCall(i int) db.HijackSession {
+	fake.createHijackSessionMutex.RLock()
+	defer fake.createHijackSessionMutex.RUnlock()
+	argsForCall := fake.createHijackSessionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeHijackSessionFactory) CreateHijackSessionReturns(result1 db.HijackSession, result2 error) {
+	fake.createHijackSessionMutex.Lock()
+	defer fake.createHijackSessionMutex.Unlock()
+	fake.CreateHijackSessionStub = nil
+	fake.createHijackSessionReturns = struct {
+		result1 db.HijackSession
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeHijackSessionFactory) CreateHijackSessionReturnsOnCall(i int, result1 db.HijackSession, result2 error) {
+	fake.createHijackSessionMutex.Lock()
+	defer fake.createHijackSessionMutex.Unlock()
+	fake.CreateHijackSessionStub = nil
+	if fake.createHijackSessionReturnsOnCall == nil {
+		fake.createHijackSessionReturnsOnCall = make(map[int]struct {
+			result1 db.HijackSession
+			result2 error
+		})
+	}
+	fake.createHijackSessionReturnsOnCall[i] = struct {
+		result1 db.HijackSession
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeHijackSessionFactory) DeleteHijackSessionsBefore(arg1 time.Time) error {
+	fake.deleteHijackSessionsBeforeMutex.Lock()
+	ret, specificReturn := fake.deleteHijackSessionsBeforeReturnsOnCall[len(fake.deleteHijackSessionsBeforeArgsForCall)]
+	fake.deleteHijackSessionsBeforeArgsForCall = append(fake.deleteHijackSessionsBeforeArgsForCall, struct {
+		arg1 time.Time
+	}{arg1})
+	fake.recordInvocation("DeleteHijackSessionsBefore", []interface{}{arg1})
+	fake.deleteHijackSessionsBeforeMutex.Unlock()
+	if fake.DeleteHijackSessionsBeforeStub != nil {
+		return fake.DeleteHijackSessionsBeforeStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteHijackSessionsBeforeReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeHijackSessionFactory) DeleteHijackSessionsBeforeCallCount() int {
+	fake.deleteHijackSessionsBeforeMutex.RLock()
+	defer fake.deleteHijackSessionsBeforeMutex.RUnlock()
+	return len(fake.deleteHijackSessionsBeforeArgsForCall)
+}
+
+func (fake *FakeHijackSessionFactory) DeleteHijackSessionsBeforeCalls(stub func(time.Time) error) {
+	fake.deleteHijackSessionsBeforeMutex.Lock()
+	defer fake.deleteHijackSessionsBeforeMutex.Unlock()
+	fake.DeleteHijackSessionsBeforeStub = stub
+}
+
+func (fake *FakeHijackSessionFactory) DeleteHijackSessionsBeforeArgsForCall(i int) time.Time {
+	fake.deleteHijackSessionsBeforeMutex.RLock()
+	defer fake.deleteHijackSessionsBeforeMutex.RUnlock()
+	argsForCall := fake.deleteHijackSessionsBeforeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeHijackSessionFactory) DeleteHijackSessionsBeforeReturns(result1 error) {
+	fake.deleteHijackSessionsBeforeMutex.Lock()
+	defer fake.deleteHijackSessionsBeforeMutex.Unlock()
+	fake.DeleteHijackSessionsBeforeStub = nil
+	fake.deleteHijackSessionsBeforeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHijackSessionFactory) DeleteHijackSessionsBeforeReturnsOnCall(i int, result1 error) {
+	fake.deleteHijackSessionsBeforeMutex.Lock()
+	defer fake.deleteHijackSessionsBeforeMutex.Unlock()
+	fake.DeleteHijackSessionsBeforeStub = nil
+	if fake.deleteHijackSessionsBeforeReturnsOnCall == nil {
+		fake.deleteHijackSessionsBeforeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteHijackSessionsBeforeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHijackSessionFactory) FinishHijackSession(arg1 int, arg2 *int) error {
+	fake.finishHijackSessionMutex.Lock()
+	ret, specificReturn := fake.finishHijackSessionReturnsOnCall[len(fake.finishHijackSessionArgsForCall)]
+	fake.finishHijackSessionArgsForCall = append(fake.finishHijackSessionArgsForCall, struct {
+		arg1 int
+		arg2 *int
+	}{arg1, arg2})
+	fake.recordInvocation("FinishHijackSession", []interface{}{arg1, arg2})
+	fake.finishHijackSessionMutex.Unlock()
+	if fake.FinishHijackSessionStub != nil {
+		return fake.FinishHijackSessionStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.finishHijackSessionReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeHijackSessionFactory) FinishHijackSessionCallCount() int {
+	fake.finishHijackSessionMutex.RLock()
+	defer fake.finishHijackSessionMutex.RUnlock()
+	return len(fake.finishHijackSessionArgsForCall)
+}
+
+func (fake *FakeHijackSessionFactory) FinishHijackSessionCalls(stub func(int, *int) error) {
+	fake.finishHijackSessionMutex.Lock()
+	defer fake.finishHijackSessionMutex.Unlock()
+	fake.FinishHijackSessionStub = stub
+}
+
+func (fake *FakeHijackSessionFactory) FinishHijackSessionArgsForCall(i int) (int, *int) {
+	fake.finishHijackSessionMutex.RLock()
+	defer fake.finishHijackSessionMutex.RUnlock()
+	argsForCall := fake.finishHijackSessionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeHijackSessionFactory) FinishHijackSessionReturns(result1 error) {
+	fake.finishHijackSessionMutex.Lock()
+	defer fake.finishHijackSessionMutex.Unlock()
+	fake.FinishHijackSessionStub = nil
+	fake.finishHijackSessionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHijackSessionFactory) FinishHijackSessionReturnsOnCall(i int, result1 error) {
+	fake.finishHijackSessionMutex.Lock()
+	defer fake.finishHijackSessionMutex.Unlock()
+	fake.FinishHijackSessionStub = nil
+	if fake.finishHijackSessionReturnsOnCall == nil {
+		fake.finishHijackSessionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.finishHijackSessionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHijackSessionFactory) HijackSession(arg1 int, arg2 int) (db.HijackSession, bool, error) {
+	fake.hijackSessionMutex.Lock()
+	ret, specificReturn := fake.hijackSessionReturnsOnCall[len(fake.hijackSessionArgsForCall)]
+	fake.hijackSessionArgsForCall = append(fake.hijackSessionArgsForCall, struct {
+		arg1 int
+		arg2 int
+	}{arg1, arg2})
+	fake.recordInvocation("HijackSession", []interface{}{arg1, arg2})
+	fake.hijackSessionMutex.Unlock()
+	if fake.HijackSessionStub != nil {
+		return fake.HijackSessionStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.hijackSessionReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeHijackSessionFactory) HijackSessionCallCount() int {
+	fake.hijackSessionMutex.RLock()
+	defer fake.hijackSessionMutex.RUnlock()
+	return len(fake.hijackSessionArgsForCall)
+}
+
+func (fake *FakeHijackSessionFactory) HijackSessionCalls(stub func(int, int) (db.HijackSession, bool, error)) {
+	fake.hijackSessionMutex.Lock()
+	defer fake.hijackSessionMutex.Unlock()
+	fake.HijackSessionStub = stub
+}
+
+func (fake *FakeHijackSessionFactory) HijackSessionArgsForCall(i int) (int, int) {
+	fake.hijackSessionMutex.RLock()
+	defer fake.hijackSessionMutex.RUnlock()
+	argsForCall := fake.hijackSessionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeHijackSessionFactory) HijackSessionReturns(result1 db.HijackSession, result2 bool, result3 error) {
+	fake.hijackSessionMutex.Lock()
+	defer fake.hijackSessionMutex.Unlock()
+	fake.HijackSessionStub = nil
+	fake.hijackSessionReturns = struct {
+		result1 db.HijackSession
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeHijackSessionFactory) HijackSessionReturnsOnCall(i int, result1 db.HijackSession, result2 bool, result3 error) {
+	fake.hijackSessionMutex.Lock()
+	defer fake.hijackSessionMutex.Unlock()
+	fake.HijackSessionStub = nil
+	if fake.hijackSessionReturnsOnCall == nil {
+		fake.hijackSessionReturnsOnCall = make(map[int]struct {
+			result1 db.HijackSession
+			result2 bool
+			result3 error
+		})
+	}
+	fake.hijackSessionReturnsOnCall[i] = struct {
+		result1 db.HijackSession
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeHijackSessionFactory) HijackSessionEvents(arg1 int) ([]db.HijackSessionEvent, error) {
+	fake.hijackSessionEventsMutex.Lock()
+	ret, specificReturn := fake.hijackSessionEventsReturnsOnCall[len(fake.hijackSessionEventsArgsForCall)]
+	fake.hijackSessionEventsArgsForCall = append(fake.hijackSessionEventsArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("HijackSessionEvents", []interface{}{arg1})
+	fake.hijackSessionEventsMutex.Unlock()
+	if fake.HijackSessionEventsStub != nil {
+		return fake.HijackSessionEventsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.hijackSessionEventsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeHijackSessionFactory) HijackSessionEventsCallCount() int {
+	fake.hijackSessionEventsMutex.RLock()
+	defer fake.hijackSessionEventsMutex.RUnlock()
+	return len(fake.hijackSessionEventsArgsForCall)
+}
+
+func (fake *FakeHijackSessionFactory) HijackSessionEventsCalls(stub func(int) ([]db.HijackSessionEvent, error)) {
+	fake.hijackSessionEventsMutex.Lock()
+	defer fake.hijackSessionEventsMutex.Unlock()
+	fake.HijackSessionEventsStub = stub
+}
+
+func (fake *FakeHijackSessionFactory) HijackSessionEventsArgsForCall(i int) int {
+	fake.hijackSessionEventsMutex.RLock()
+	defer fake.hijackSessionEventsMutex.RUnlock()
+	argsForCall := fake.hijackSessionEventsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeHijackSessionFactory) HijackSessionEventsReturns(result1 []db.HijackSessionEvent, result2 error) {
+	fake.hijackSessionEventsMutex.Lock()
+	defer fake.hijackSessionEventsMutex.Unlock()
+	fake.HijackSessionEventsStub = nil
+	fake.hijackSessionEventsReturns = struct {
+		result1 []db.HijackSessionEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeHijackSessionFactory) HijackSessionEventsReturnsOnCall(i int, result1 []db.HijackSessionEvent, result2 error) {
+	fake.hijackSessionEventsMutex.Lock()
+	defer fake.hijackSessionEventsMutex.Unlock()
+	fake.HijackSessionEventsStub = nil
+	if fake.hijackSessionEventsReturnsOnCall == nil {
+		fake.hijackSessionEventsReturnsOnCall = make(map[int]struct {
+			result1 []db.HijackSessionEvent
+			result2 error
+		})
+	}
+	fake.hijackSessionEventsReturnsOnCall[i] = struct {
+		result1 []db.HijackSessionEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeHijackSessionFactory) HijackSessions(arg1 int) ([]db.HijackSession, error) {
+	fake.hijackSessionsMutex.Lock()
+	ret, specificReturn := fake.hijackSessionsReturnsOnCall[len(fake.hijackSessionsArgsForCall)]
+	fake.hijackSessionsArgsForCall = append(fake.hijackSessionsArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("HijackSessions", []interface{}{arg1})
+	fake.hijackSessionsMutex.Unlock()
+	if fake.HijackSessionsStub != nil {
+		return fake.HijackSessionsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.hijackSessionsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeHijackSessionFactory) HijackSessionsCallCount() int {
+	fake.hijackSessionsMutex.RLock()
+	defer fake.hijackSessionsMutex.RUnlock()
+	return len(fake.hijackSessionsArgsForCall)
+}
+
+func (fake *FakeHijackSessionFactory) HijackSessionsCalls(stub func(int) ([]db.HijackSession, error)) {
+	fake.hijackSessionsMutex.Lock()
+	defer fake.hijackSessionsMutex.Unlock()
+	fake.HijackSessionsStub = stub
+}
+
+func (fake *FakeHijackSessionFactory) HijackSessionsArgsForCall(i int) int {
+	fake.hijackSessionsMutex.RLock()
+	defer fake.hijackSessionsMutex.RUnlock()
+	argsForCall := fake.hijackSessionsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeHijackSessionFactory) HijackSessionsReturns(result1 []db.HijackSession, result2 error) {
+	fake.hijackSessionsMutex.Lock()
+	defer fake.hijackSessionsMutex.Unlock()
+	fake.HijackSessionsStub = nil
+	fake.hijackSessionsReturns = struct {
+		result1 []db.HijackSession
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeHijackSessionFactory) HijackSessionsReturnsOnCall(i int, result1 []db.HijackSession, result2 error) {
+	fake.hijackSessionsMutex.Lock()
+	defer fake.hijackSessionsMutex.Unlock()
+	fake.HijackSessionsStub = nil
+	if fake.hijackSessionsReturnsOnCall == nil {
+		fake.hijackSessionsReturnsOnCall = make(map[int]struct {
+			result1 []db.HijackSession
+			result2 error
+		})
+	}
+	fake.hijackSessionsReturnsOnCall[i] = struct {
+		result1 []db.HijackSession
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeHijackSessionFactory) SaveHijackSessionEvents(arg1 int, arg2 []db.HijackSessionEvent) error {
+	var arg2Copy []db.HijackSessionEvent
+	if arg2 != nil {
+		arg2Copy = make([]db.HijackSessionEvent, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.saveHijackSessionEventsMutex.Lock()
+	ret, specificReturn := fake.saveHijackSessionEventsReturnsOnCall[len(fake.saveHijackSessionEventsArgsForCall)]
+	fake.saveHijackSessionEventsArgsForCall = append(fake.saveHijackSessionEventsArgsForCall, struct {
+		arg1 int
+		arg2 []db.HijackSessionEvent
+	}{arg1, arg2Copy})
+	fake.recordInvocation("SaveHijackSessionEvents", []interface{}{arg1, arg2Copy})
+	fake.saveHijackSessionEventsMutex.Unlock()
+	if fake.SaveHijackSessionEventsStub != nil {
+		return fake.SaveHijackSessionEventsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.saveHijackSessionEventsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeHijackSessionFactory) SaveHijackSessionEventsCallCount() int {
+	fake.saveHijackSessionEventsMutex.RLock()
+	defer fake.saveHijackSessionEventsMutex.RUnlock()
+	return len(fake.saveHijackSessionEventsArgsForCall)
+}
+
+func (fake *FakeHijackSessionFactory) SaveHijackSessionEventsCalls(stub func(int, []db.HijackSessionEvent) error) {
+	fake.saveHijackSessionEventsMutex.Lock()
+	defer fake.saveHijackSessionEventsMutex.Unlock()
+	fake.SaveHijackSessionEventsStub = stub
+}
+
+func (fake *FakeHijackSessionFactory) SaveHijackSessionEventsArgsForCall(i int) (int, []db.HijackSessionEvent) {
+	fake.saveHijackSessionEventsMutex.RLock()
+	defer fake.saveHijackSessionEventsMutex.RUnlock()
+	argsForCall := fake.saveHijackSessionEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeHijackSessionFactory) SaveHijackSessionEventsReturns(result1 error) {
+	fake.saveHijackSessionEventsMutex.Lock()
+	defer fake.saveHijackSessionEventsMutex.Unlock()
+	fake.SaveHijackSessionEventsStub = nil
+	fake.saveHijackSessionEventsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHijackSessionFactory) SaveHijackSessionEventsReturnsOnCall(i int, result1 error) {
+	fake.saveHijackSessionEventsMutex.Lock()
+	defer fake.saveHijackSessionEventsMutex.Unlock()
+	fake.SaveHijackSessionEventsStub = nil
+	if fake.saveHijackSessionEventsReturnsOnCall == nil {
+		fake.saveHijackSessionEventsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveHijackSessionEventsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHijackSessionFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createHijackSessionMutex.RLock()
+	defer fake.createHijackSessionMutex.RUnlock()
+	fake.deleteHijackSessionsBeforeMutex.RLock()
+	defer fake.deleteHijackSessionsBeforeMutex.RUnlock()
+	fake.finishHijackSessionMutex.RLock()
+	defer fake.finishHijackSessionMutex.RUnlock()
+	fake.hijackSessionMutex.RLock()
+	defer fake.hijackSessionMutex.RUnlock()
+	fake.hijackSessionEventsMutex.RLock()
+	defer fake.hijackSessionEventsMutex.RUnlock()
+	fake.hijackSessionsMutex.RLock()
+	defer fake.hijackSessionsMutex.RUnlock()
+	fake.saveHijackSessionEventsMutex.RLock()
+	defer fake.saveHijackSessionEventsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeHijackSessionFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.HijackSessionFactory = new(FakeHijackSessionFactory)
@@ -3,6 +3,7 @@ package dbfakes
 
 import (
 	"sync"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
@@ -91,6 +92,35 @@ type FakeJob struct {
 		result1 db.Build
 		result2 error
 	}
+	CreateScheduledBuildStub        func(time.Time) (db.Build, error)
+	createScheduledBuildMutex       sync.RWMutex
+	createScheduledBuildArgsForCall []struct {
+		arg1 time.Time
+	}
+	createScheduledBuildReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	createScheduledBuildReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
+	DeleteExpiredBuildsStub        func(atc.BuildRetention, bool, bool, int) (int, error)
+	deleteExpiredBuildsMutex       sync.RWMutex
+	deleteExpiredBuildsArgsForCall []struct {
+		arg1 atc.BuildRetention
+		arg2 bool
+		arg3 bool
+		arg4 int
+	}
+	deleteExpiredBuildsReturns struct {
+		result1 int
+		result2 error
+	}
+	deleteExpiredBuildsReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
 	DeleteNextInputMappingStub        func() error
 	deleteNextInputMappingMutex       sync.RWMutex
 	deleteNextInputMappingArgsForCall []struct {
@@ -315,6 +345,16 @@ type FakeJob struct {
 	saveNextInputMappingReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ScheduleFiredAtStub        func() time.Time
+	scheduleFiredAtMutex       sync.RWMutex
+	scheduleFiredAtArgsForCall []struct {
+	}
+	scheduleFiredAtReturns struct {
+		result1 time.Time
+	}
+	scheduleFiredAtReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
 	SetHasNewInputsStub        func(bool) error
 	setHasNewInputsMutex       sync.RWMutex
 	setHasNewInputsArgsForCall []struct {
@@ -337,6 +377,17 @@ type FakeJob struct {
 	setMaxInFlightReachedReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SetScheduleFiredAtStub        func(time.Time) error
+	setScheduleFiredAtMutex       sync.RWMutex
+	setScheduleFiredAtArgsForCall []struct {
+		arg1 time.Time
+	}
+	setScheduleFiredAtReturns struct {
+		result1 error
+	}
+	setScheduleFiredAtReturnsOnCall map[int]struct {
+		result1 error
+	}
 	TagsStub        func() []string
 	tagsMutex       sync.RWMutex
 	tagsArgsForCall []struct {
@@ -761,6 +812,135 @@ func (fake *FakeJob) CreateBuildReturnsOnCall(i int, result1 db.Build, result2 e
 	}{result1, result2}
 }
 
+func (fake *FakeJob) CreateScheduledBuild(arg1 time.Time) (db.Build, error) {
+	fake.createScheduledBuildMutex.Lock()
+	ret, specificReturn := fake.createScheduledBuildReturnsOnCall[len(fake.createScheduledBuildArgsForCall)]
+	fake.createScheduledBuildArgsForCall = append(fake.createScheduledBuildArgsForCall, struct {
+		arg1 time.Time
+	}{arg1})
+	fake.recordInvocation("CreateScheduledBuild", []interface{}{arg1})
+	fake.createScheduledBuildMutex.Unlock()
+	if fake.CreateScheduledBuildStub != nil {
+		return fake.CreateScheduledBuildStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createScheduledBuildReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) CreateScheduledBuildCallCount() int {
+	fake.createScheduledBuildMutex.RLock()
+	defer fake.createScheduledBuildMutex.RUnlock()
+	return len(fake.createScheduledBuildArgsForCall)
+}
+
+func (fake *FakeJob) CreateScheduledBuildCalls(stub func(time.Time) (db.Build, error)) {
+	fake.createScheduledBuildMutex.Lock()
+	defer fake.createScheduledBuildMutex.Unlock()
+	fake.CreateScheduledBuildStub = stub
+}
+
+func (fake *FakeJob) CreateScheduledBuildArgsForCall(i int) time.Time {
+	fake.createScheduledBuildMutex.RLock()
+	defer fake.createScheduledBuildMutex.RUnlock()
+	argsForCall := fake.createScheduledBuildArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeJob) CreateScheduledBuildReturns(result1 db.Build, result2 error) {
+	fake.createScheduledBuildMutex.Lock()
+	defer fake.createScheduledBuildMutex.Unlock()
+	fake.CreateScheduledBuildStub = nil
+	fake.createScheduledBuildReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) CreateScheduledBuildReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.createScheduledBuildMutex.Lock()
+	defer fake.createScheduledBuildMutex.Unlock()
+	fake.CreateScheduledBuildStub = nil
+	if fake.createScheduledBuildReturnsOnCall == nil {
+		fake.createScheduledBuildReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.createScheduledBuildReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) DeleteExpiredBuilds(arg1 atc.BuildRetention, arg2 bool, arg3 bool, arg4 int) (int, error) {
+	fake.deleteExpiredBuildsMutex.Lock()
+	ret, specificReturn := fake.deleteExpiredBuildsReturnsOnCall[len(fake.deleteExpiredBuildsArgsForCall)]
+	fake.deleteExpiredBuildsArgsForCall = append(fake.deleteExpiredBuildsArgsForCall, struct {
+		arg1 atc.BuildRetention
+		arg2 bool
+		arg3 bool
+		arg4 int
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("DeleteExpiredBuilds", []interface{}{arg1, arg2, arg3, arg4})
+	fake.deleteExpiredBuildsMutex.Unlock()
+	if fake.DeleteExpiredBuildsStub != nil {
+		return fake.DeleteExpiredBuildsStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.deleteExpiredBuildsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) DeleteExpiredBuildsCallCount() int {
+	fake.deleteExpiredBuildsMutex.RLock()
+	defer fake.deleteExpiredBuildsMutex.RUnlock()
+	return len(fake.deleteExpiredBuildsArgsForCall)
+}
+
+func (fake *FakeJob) DeleteExpiredBuildsCalls(stub func(atc.BuildRetention, bool, bool, int) (int, error)) {
+	fake.deleteExpiredBuildsMutex.Lock()
+	defer fake.deleteExpiredBuildsMutex.Unlock()
+	fake.DeleteExpiredBuildsStub = stub
+}
+
+func (fake *FakeJob) DeleteExpiredBuildsArgsForCall(i int) (atc.BuildRetention, bool, bool, int) {
+	fake.deleteExpiredBuildsMutex.RLock()
+	defer fake.deleteExpiredBuildsMutex.RUnlock()
+	argsForCall := fake.deleteExpiredBuildsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeJob) DeleteExpiredBuildsReturns(result1 int, result2 error) {
+	fake.deleteExpiredBuildsMutex.Lock()
+	defer fake.deleteExpiredBuildsMutex.Unlock()
+	fake.DeleteExpiredBuildsStub = nil
+	fake.deleteExpiredBuildsReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) DeleteExpiredBuildsReturnsOnCall(i int, result1 int, result2 error) {
+	fake.deleteExpiredBuildsMutex.Lock()
+	defer fake.deleteExpiredBuildsMutex.Unlock()
+	fake.DeleteExpiredBuildsStub = nil
+	if fake.deleteExpiredBuildsReturnsOnCall == nil {
+		fake.deleteExpiredBuildsReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.deleteExpiredBuildsReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) DeleteNextInputMapping() error {
 	fake.deleteNextInputMappingMutex.Lock()
 	ret, specificReturn := fake.deleteNextInputMappingReturnsOnCall[len(fake.deleteNextInputMappingArgsForCall)]
@@ -1873,6 +2053,58 @@ func (fake *FakeJob) SaveNextInputMappingReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeJob) ScheduleFiredAt() time.Time {
+	fake.scheduleFiredAtMutex.Lock()
+	ret, specificReturn := fake.scheduleFiredAtReturnsOnCall[len(fake.scheduleFiredAtArgsForCall)]
+	fake.scheduleFiredAtArgsForCall = append(fake.scheduleFiredAtArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ScheduleFiredAt", []interface{}{})
+	fake.scheduleFiredAtMutex.Unlock()
+	if fake.ScheduleFiredAtStub != nil {
+		return fake.ScheduleFiredAtStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.scheduleFiredAtReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeJob) ScheduleFiredAtCallCount() int {
+	fake.scheduleFiredAtMutex.RLock()
+	defer fake.scheduleFiredAtMutex.RUnlock()
+	return len(fake.scheduleFiredAtArgsForCall)
+}
+
+func (fake *FakeJob) ScheduleFiredAtCalls(stub func() time.Time) {
+	fake.scheduleFiredAtMutex.Lock()
+	defer fake.scheduleFiredAtMutex.Unlock()
+	fake.ScheduleFiredAtStub = stub
+}
+
+func (fake *FakeJob) ScheduleFiredAtReturns(result1 time.Time) {
+	fake.scheduleFiredAtMutex.Lock()
+	defer fake.scheduleFiredAtMutex.Unlock()
+	fake.ScheduleFiredAtStub = nil
+	fake.scheduleFiredAtReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeJob) ScheduleFiredAtReturnsOnCall(i int, result1 time.Time) {
+	fake.scheduleFiredAtMutex.Lock()
+	defer fake.scheduleFiredAtMutex.Unlock()
+	fake.ScheduleFiredAtStub = nil
+	if fake.scheduleFiredAtReturnsOnCall == nil {
+		fake.scheduleFiredAtReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.scheduleFiredAtReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
 func (fake *FakeJob) SetHasNewInputs(arg1 bool) error {
 	fake.setHasNewInputsMutex.Lock()
 	ret, specificReturn := fake.setHasNewInputsReturnsOnCall[len(fake.setHasNewInputsArgsForCall)]
@@ -1993,6 +2225,66 @@ func (fake *FakeJob) SetMaxInFlightReachedReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeJob) SetScheduleFiredAt(arg1 time.Time) error {
+	fake.setScheduleFiredAtMutex.Lock()
+	ret, specificReturn := fake.setScheduleFiredAtReturnsOnCall[len(fake.setScheduleFiredAtArgsForCall)]
+	fake.setScheduleFiredAtArgsForCall = append(fake.setScheduleFiredAtArgsForCall, struct {
+		arg1 time.Time
+	}{arg1})
+	fake.recordInvocation("SetScheduleFiredAt", []interface{}{arg1})
+	fake.setScheduleFiredAtMutex.Unlock()
+	if fake.SetScheduleFiredAtStub != nil {
+		return fake.SetScheduleFiredAtStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.setScheduleFiredAtReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeJob) SetScheduleFiredAtCallCount() int {
+	fake.setScheduleFiredAtMutex.RLock()
+	defer fake.setScheduleFiredAtMutex.RUnlock()
+	return len(fake.setScheduleFiredAtArgsForCall)
+}
+
+func (fake *FakeJob) SetScheduleFiredAtCalls(stub func(time.Time) error) {
+	fake.setScheduleFiredAtMutex.Lock()
+	defer fake.setScheduleFiredAtMutex.Unlock()
+	fake.SetScheduleFiredAtStub = stub
+}
+
+func (fake *FakeJob) SetScheduleFiredAtArgsForCall(i int) time.Time {
+	fake.setScheduleFiredAtMutex.RLock()
+	defer fake.setScheduleFiredAtMutex.RUnlock()
+	argsForCall := fake.setScheduleFiredAtArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeJob) SetScheduleFiredAtReturns(result1 error) {
+	fake.setScheduleFiredAtMutex.Lock()
+	defer fake.setScheduleFiredAtMutex.Unlock()
+	fake.SetScheduleFiredAtStub = nil
+	fake.setScheduleFiredAtReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeJob) SetScheduleFiredAtReturnsOnCall(i int, result1 error) {
+	fake.setScheduleFiredAtMutex.Lock()
+	defer fake.setScheduleFiredAtMutex.Unlock()
+	fake.SetScheduleFiredAtStub = nil
+	if fake.setScheduleFiredAtReturnsOnCall == nil {
+		fake.setScheduleFiredAtReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setScheduleFiredAtReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeJob) Tags() []string {
 	fake.tagsMutex.Lock()
 	ret, specificReturn := fake.tagsReturnsOnCall[len(fake.tagsArgsForCall)]
@@ -2276,6 +2568,10 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.configMutex.RUnlock()
 	fake.createBuildMutex.RLock()
 	defer fake.createBuildMutex.RUnlock()
+	fake.createScheduledBuildMutex.RLock()
+	defer fake.createScheduledBuildMutex.RUnlock()
+	fake.deleteExpiredBuildsMutex.RLock()
+	defer fake.deleteExpiredBuildsMutex.RUnlock()
 	fake.deleteNextInputMappingMutex.RLock()
 	defer fake.deleteNextInputMappingMutex.RUnlock()
 	fake.ensurePendingBuildExistsMutex.RLock()
@@ -2316,10 +2612,14 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.saveIndependentInputMappingMutex.RUnlock()
 	fake.saveNextInputMappingMutex.RLock()
 	defer fake.saveNextInputMappingMutex.RUnlock()
+	fake.scheduleFiredAtMutex.RLock()
+	defer fake.scheduleFiredAtMutex.RUnlock()
 	fake.setHasNewInputsMutex.RLock()
 	defer fake.setHasNewInputsMutex.RUnlock()
 	fake.setMaxInFlightReachedMutex.RLock()
 	defer fake.setMaxInFlightReachedMutex.RUnlock()
+	fake.setScheduleFiredAtMutex.RLock()
+	defer fake.setScheduleFiredAtMutex.RUnlock()
 	fake.tagsMutex.RLock()
 	defer fake.tagsMutex.RUnlock()
 	fake.teamIDMutex.RLock()
@@ -0,0 +1,502 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeNamedLockFactory struct {
+	AcquireNamedLockStub        func(int) (bool, error)
+	acquireNamedLockMutex       sync.RWMutex
+	acquireNamedLockArgsForCall []struct {
+		arg1 int
+	}
+	acquireNamedLockReturns struct {
+		result1 bool
+		result2 error
+	}
+	acquireNamedLockReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	ClaimNamedLockStub        func(int, string, int, int, atc.PlanID) (int, error)
+	claimNamedLockMutex       sync.RWMutex
+	claimNamedLockArgsForCall []struct {
+		arg1 int
+		arg2 string
+		arg3 int
+		arg4 int
+		arg5 atc.PlanID
+	}
+	claimNamedLockReturns struct {
+		result1 int
+		result2 error
+	}
+	claimNamedLockReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	ForceReleaseNamedLockStub        func(int, string) (bool, error)
+	forceReleaseNamedLockMutex       sync.RWMutex
+	forceReleaseNamedLockArgsForCall []struct {
+		arg1 int
+		arg2 string
+	}
+	forceReleaseNamedLockReturns struct {
+		result1 bool
+		result2 error
+	}
+	forceReleaseNamedLockReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	NamedLockNotifierStub        func() (db.Notifier, error)
+	namedLockNotifierMutex       sync.RWMutex
+	namedLockNotifierArgsForCall []struct {
+	}
+	namedLockNotifierReturns struct {
+		result1 db.Notifier
+		result2 error
+	}
+	namedLockNotifierReturnsOnCall map[int]struct {
+		result1 db.Notifier
+		result2 error
+	}
+	NamedLocksStub        func(int) ([]db.NamedLock, error)
+	namedLocksMutex       sync.RWMutex
+	namedLocksArgsForCall []struct {
+		arg1 int
+	}
+	namedLocksReturns struct {
+		result1 []db.NamedLock
+		result2 error
+	}
+	namedLocksReturnsOnCall map[int]struct {
+		result1 []db.NamedLock
+		result2 error
+	}
+	ReleaseNamedLockStub        func(int) error
+	releaseNamedLockMutex       sync.RWMutex
+	releaseNamedLockArgsForCall []struct {
+		arg1 int
+	}
+	releaseNamedLockReturns struct {
+		result1 error
+	}
+	releaseNamedLockReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeNamedLockFactory) AcquireNamedLock(arg1 int) (bool, error) {
+	fake.acquireNamedLockMutex.Lock()
+	ret, specificReturn := fake.acquireNamedLockReturnsOnCall[len(fake.acquireNamedLockArgsForCall)]
+	fake.acquireNamedLockArgsForCall = append(fake.acquireNamedLockArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("AcquireNamedLock", []interface{}{arg1})
+	fake.acquireNamedLockMutex.Unlock()
+	if fake.AcquireNamedLockStub != nil {
+		return fake.AcquireNamedLockStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.acquireNamedLockReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeNamedLockFactory) AcquireNamedLockCallCount() int {
+	fake.acquireNamedLockMutex.RLock()
+	defer fake.acquireNamedLockMutex.RUnlock()
+	return len(fake.acquireNamedLockArgsForCall)
+}
+
+func (fake *FakeNamedLockFactory) AcquireNamedLockCalls(stub func(int) (bool, error)) {
+	fake.acquireNamedLockMutex.Lock()
+	defer fake.acquireNamedLockMutex.Unlock()
+	fake.AcquireNamedLockStub = stub
+}
+
+func (fake *FakeNamedLockFactory) AcquireNamedLockArgsForCall(i int) int {
+	fake.acquireNamedLockMutex.RLock()
+	defer fake.acquireNamedLockMutex.RUnlock()
+	argsForCall := fake.acquireNamedLockArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeNamedLockFactory) AcquireNamedLockReturns(result1 bool, result2 error) {
+	fake.acquireNamedLockMutex.Lock()
+	defer fake.acquireNamedLockMutex.Unlock()
+	fake.AcquireNamedLockStub = nil
+	fake.acquireNamedLockReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNamedLockFactory) AcquireNamedLockReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.acquireNamedLockMutex.Lock()
+	defer fake.acquireNamedLockMutex.Unlock()
+	fake.AcquireNamedLockStub = nil
+	if fake.acquireNamedLockReturnsOnCall == nil {
+		fake.acquireNamedLockReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.acquireNamedLockReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNamedLockFactory) ClaimNamedLock(arg1 int, arg2 string, arg3 int, arg4 int, arg5 atc.PlanID) (int, error) {
+	fake.claimNamedLockMutex.Lock()
+	ret, specificReturn := fake.claimNamedLockReturnsOnCall[len(fake.claimNamedLockArgsForCall)]
+	fake.claimNamedLockArgsForCall = append(fake.claimNamedLockArgsForCall, struct {
+		arg1 int
+		arg2 string
+		arg3 int
+		arg4 int
+		arg5 atc.PlanID
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("ClaimNamedLock", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.claimNamedLockMutex.Unlock()
+	if fake.ClaimNamedLockStub != nil {
+		return fake.ClaimNamedLockStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.claimNamedLockReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeNamedLockFactory) ClaimNamedLockCallCount() int {
+	fake.claimNamedLockMutex.RLock()
+	defer fake.claimNamedLockMutex.RUnlock()
+	return len(fake.claimNamedLockArgsForCall)
+}
+
+func (fake *FakeNamedLockFactory) ClaimNamedLockCalls(stub func(int, string, int, int, atc.PlanID) (int, error)) {
+	fake.claimNamedLockMutex.Lock()
+	defer fake.claimNamedLockMutex.Unlock()
+	fake.ClaimNamedLockStub = stub
+}
+
+func (fake *FakeNamedLockFactory) ClaimNamedLockArgsForCall(i int) (int, string, int, int, atc.PlanID) {
+	fake.claimNamedLockMutex.RLock()
+	defer fake.claimNamedLockMutex.RUnlock()
+	argsForCall := fake.claimNamedLockArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeNamedLockFactory) ClaimNamedLockReturns(result1 int, result2 error) {
+	fake.claimNamedLockMutex.Lock()
+	defer fake.claimNamedLockMutex.Unlock()
+	fake.ClaimNamedLockStub = nil
+	fake.claimNamedLockReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNamedLockFactory) ClaimNamedLockReturnsOnCall(i int, result1 int, result2 error) {
+	fake.claimNamedLockMutex.Lock()
+	defer fake.claimNamedLockMutex.Unlock()
+	fake.ClaimNamedLockStub = nil
+	if fake.claimNamedLockReturnsOnCall == nil {
+		fake.claimNamedLockReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.claimNamedLockReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNamedLockFactory) ForceReleaseNamedLock(arg1 int, arg2 string) (bool, error) {
+	fake.forceReleaseNamedLockMutex.Lock()
+	ret, specificReturn := fake.forceReleaseNamedLockReturnsOnCall[len(fake.forceReleaseNamedLockArgsForCall)]
+	fake.forceReleaseNamedLockArgsForCall = append(fake.forceReleaseNamedLockArgsForCall, struct {
+		arg1 int
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("ForceReleaseNamedLock", []interface{}{arg1, arg2})
+	fake.forceReleaseNamedLockMutex.Unlock()
+	if fake.ForceReleaseNamedLockStub != nil {
+		return fake.ForceReleaseNamedLockStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.forceReleaseNamedLockReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeNamedLockFactory) ForceReleaseNamedLockCallCount() int {
+	fake.forceReleaseNamedLockMutex.RLock()
+	defer fake.forceReleaseNamedLockMutex.RUnlock()
+	return len(fake.forceReleaseNamedLockArgsForCall)
+}
+
+func (fake *FakeNamedLockFactory) ForceReleaseNamedLockCalls(stub func(int, string) (bool, error)) {
+	fake.forceReleaseNamedLockMutex.Lock()
+	defer fake.forceReleaseNamedLockMutex.Unlock()
+	fake.ForceReleaseNamedLockStub = stub
+}
+
+func (fake *FakeNamedLockFactory) ForceReleaseNamedLockArgsForCall(i int) (int, string) {
+	fake.forceReleaseNamedLockMutex.RLock()
+	defer fake.forceReleaseNamedLockMutex.RUnlock()
+	argsForCall := fake.forceReleaseNamedLockArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeNamedLockFactory) ForceReleaseNamedLockReturns(result1 bool, result2 error) {
+	fake.forceReleaseNamedLockMutex.Lock()
+	defer fake.forceReleaseNamedLockMutex.Unlock()
+	fake.ForceReleaseNamedLockStub = nil
+	fake.forceReleaseNamedLockReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNamedLockFactory) ForceReleaseNamedLockReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.forceReleaseNamedLockMutex.Lock()
+	defer fake.forceReleaseNamedLockMutex.Unlock()
+	fake.ForceReleaseNamedLockStub = nil
+	if fake.forceReleaseNamedLockReturnsOnCall == nil {
+		fake.forceReleaseNamedLockReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.forceReleaseNamedLockReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNamedLockFactory) NamedLockNotifier() (db.Notifier, error) {
+	fake.namedLockNotifierMutex.Lock()
+	ret, specificReturn := fake.namedLockNotifierReturnsOnCall[len(fake.namedLockNotifierArgsForCall)]
+	fake.namedLockNotifierArgsForCall = append(fake.namedLockNotifierArgsForCall, struct {
+	}{})
+	fake.recordInvocation("NamedLockNotifier", []interface{}{})
+	fake.namedLockNotifierMutex.Unlock()
+	if fake.NamedLockNotifierStub != nil {
+		return fake.NamedLockNotifierStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.namedLockNotifierReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeNamedLockFactory) NamedLockNotifierCallCount() int {
+	fake.namedLockNotifierMutex.RLock()
+	defer fake.namedLockNotifierMutex.RUnlock()
+	return len(fake.namedLockNotifierArgsForCall)
+}
+
+func (fake *FakeNamedLockFactory) NamedLockNotifierCalls(stub func() (db.Notifier, error)) {
+	fake.namedLockNotifierMutex.Lock()
+	defer fake.namedLockNotifierMutex.Unlock()
+	fake.NamedLockNotifierStub = stub
+}
+
+func (fake *FakeNamedLockFactory) NamedLockNotifierReturns(result1 db.Notifier, result2 error) {
+	fake.namedLockNotifierMutex.Lock()
+	defer fake.namedLockNotifierMutex.Unlock()
+	fake.NamedLockNotifierStub = nil
+	fake.namedLockNotifierReturns = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNamedLockFactory) NamedLockNotifierReturnsOnCall(i int, result1 db.Notifier, result2 error) {
+	fake.namedLockNotifierMutex.Lock()
+	defer fake.namedLockNotifierMutex.Unlock()
+	fake.NamedLockNotifierStub = nil
+	if fake.namedLockNotifierReturnsOnCall == nil {
+		fake.namedLockNotifierReturnsOnCall = make(map[int]struct {
+			result1 db.Notifier
+			result2 error
+		})
+	}
+	fake.namedLockNotifierReturnsOnCall[i] = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNamedLockFactory) NamedLocks(arg1 int) ([]db.NamedLock, error) {
+	fake.namedLocksMutex.Lock()
+	ret, specificReturn := fake.namedLocksReturnsOnCall[len(fake.namedLocksArgsForCall)]
+	fake.namedLocksArgsForCall = append(fake.namedLocksArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("NamedLocks", []interface{}{arg1})
+	fake.namedLocksMutex.Unlock()
+	if fake.NamedLocksStub != nil {
+		return fake.NamedLocksStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.namedLocksReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeNamedLockFactory) NamedLocksCallCount() int {
+	fake.namedLocksMutex.RLock()
+	defer fake.namedLocksMutex.RUnlock()
+	return len(fake.namedLocksArgsForCall)
+}
+
+func (fake *FakeNamedLockFactory) NamedLocksCalls(stub func(int) ([]db.NamedLock, error)) {
+	fake.namedLocksMutex.Lock()
+	defer fake.namedLocksMutex.Unlock()
+	fake.NamedLocksStub = stub
+}
+
+func (fake *FakeNamedLockFactory) NamedLocksArgsForCall(i int) int {
+	fake.namedLocksMutex.RLock()
+	defer fake.namedLocksMutex.RUnlock()
+	argsForCall := fake.namedLocksArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeNamedLockFactory) NamedLocksReturns(result1 []db.NamedLock, result2 error) {
+	fake.namedLocksMutex.Lock()
+	defer fake.namedLocksMutex.Unlock()
+	fake.NamedLocksStub = nil
+	fake.namedLocksReturns = struct {
+		result1 []db.NamedLock
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNamedLockFactory) NamedLocksReturnsOnCall(i int, result1 []db.NamedLock, result2 error) {
+	fake.namedLocksMutex.Lock()
+	defer fake.namedLocksMutex.Unlock()
+	fake.NamedLocksStub = nil
+	if fake.namedLocksReturnsOnCall == nil {
+		fake.namedLocksReturnsOnCall = make(map[int]struct {
+			result1 []db.NamedLock
+			result2 error
+		})
+	}
+	fake.namedLocksReturnsOnCall[i] = struct {
+		result1 []db.NamedLock
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNamedLockFactory) ReleaseNamedLock(arg1 int) error {
+	fake.releaseNamedLockMutex.Lock()
+	ret, specificReturn := fake.releaseNamedLockReturnsOnCall[len(fake.releaseNamedLockArgsForCall)]
+	fake.releaseNamedLockArgsForCall = append(fake.releaseNamedLockArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("ReleaseNamedLock", []interface{}{arg1})
+	fake.releaseNamedLockMutex.Unlock()
+	if fake.ReleaseNamedLockStub != nil {
+		return fake.ReleaseNamedLockStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.releaseNamedLockReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeNamedLockFactory) ReleaseNamedLockCallCount() int {
+	fake.releaseNamedLockMutex.RLock()
+	defer fake.releaseNamedLockMutex.RUnlock()
+	return len(fake.releaseNamedLockArgsForCall)
+}
+
+func (fake *FakeNamedLockFactory) ReleaseNamedLockCalls(stub func(int) error) {
+	fake.releaseNamedLockMutex.Lock()
+	defer fake.releaseNamedLockMutex.Unlock()
+	fake.ReleaseNamedLockStub = stub
+}
+
+func (fake *FakeNamedLockFactory) ReleaseNamedLockArgsForCall(i int) int {
+	fake.releaseNamedLockMutex.RLock()
+	defer fake.releaseNamedLockMutex.RUnlock()
+	argsForCall := fake.releaseNamedLockArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeNamedLockFactory) ReleaseNamedLockReturns(result1 error) {
+	fake.releaseNamedLockMutex.Lock()
+	defer fake.releaseNamedLockMutex.Unlock()
+	fake.ReleaseNamedLockStub = nil
+	fake.releaseNamedLockReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNamedLockFactory) ReleaseNamedLockReturnsOnCall(i int, result1 error) {
+	fake.releaseNamedLockMutex.Lock()
+	defer fake.releaseNamedLockMutex.Unlock()
+	fake.ReleaseNamedLockStub = nil
+	if fake.releaseNamedLockReturnsOnCall == nil {
+		fake.releaseNamedLockReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.releaseNamedLockReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNamedLockFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.acquireNamedLockMutex.RLock()
+	defer fake.acquireNamedLockMutex.RUnlock()
+	fake.claimNamedLockMutex.RLock()
+	defer fake.claimNamedLockMutex.RUnlock()
+	fake.forceReleaseNamedLockMutex.RLock()
+	defer fake.forceReleaseNamedLockMutex.RUnlock()
+	fake.namedLockNotifierMutex.RLock()
+	defer fake.namedLockNotifierMutex.RUnlock()
+	fake.namedLocksMutex.RLock()
+	defer fake.namedLocksMutex.RUnlock()
+	fake.releaseNamedLockMutex.RLock()
+	defer fake.releaseNamedLockMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeNamedLockFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.NamedLockFactory = new(FakeNamedLockFactory)
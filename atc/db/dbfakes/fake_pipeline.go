@@ -84,6 +84,18 @@ type FakePipeline struct {
 		result1 bool
 		result2 error
 	}
+	ConfigHistoryStub        func() ([]db.PipelineConfigVersion, error)
+	configHistoryMutex       sync.RWMutex
+	configHistoryArgsForCall []struct {
+	}
+	configHistoryReturns struct {
+		result1 []db.PipelineConfigVersion
+		result2 error
+	}
+	configHistoryReturnsOnCall map[int]struct {
+		result1 []db.PipelineConfigVersion
+		result2 error
+	}
 	ConfigVersionStub        func() db.ConfigVersion
 	configVersionMutex       sync.RWMutex
 	configVersionArgsForCall []struct {
@@ -222,6 +234,21 @@ type FakePipeline struct {
 	hideReturnsOnCall map[int]struct {
 		result1 error
 	}
+	HistoricalConfigStub        func(db.ConfigVersion) (db.PipelineConfigVersion, bool, error)
+	historicalConfigMutex       sync.RWMutex
+	historicalConfigArgsForCall []struct {
+		arg1 db.ConfigVersion
+	}
+	historicalConfigReturns struct {
+		result1 db.PipelineConfigVersion
+		result2 bool
+		result3 error
+	}
+	historicalConfigReturnsOnCall map[int]struct {
+		result1 db.PipelineConfigVersion
+		result2 bool
+		result3 error
+	}
 	IDStub        func() int
 	iDMutex       sync.RWMutex
 	iDArgsForCall []struct {
@@ -784,6 +811,61 @@ func (fake *FakePipeline) CheckPausedReturnsOnCall(i int, result1 bool, result2
 	}{result1, result2}
 }
 
+func (fake *FakePipeline) ConfigHistory() ([]db.PipelineConfigVersion, error) {
+	fake.configHistoryMutex.Lock()
+	ret, specificReturn := fake.configHistoryReturnsOnCall[len(fake.configHistoryArgsForCall)]
+	fake.configHistoryArgsForCall = append(fake.configHistoryArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ConfigHistory", []interface{}{})
+	fake.configHistoryMutex.Unlock()
+	if fake.ConfigHistoryStub != nil {
+		return fake.ConfigHistoryStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.configHistoryReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) ConfigHistoryCallCount() int {
+	fake.configHistoryMutex.RLock()
+	defer fake.configHistoryMutex.RUnlock()
+	return len(fake.configHistoryArgsForCall)
+}
+
+func (fake *FakePipeline) ConfigHistoryCalls(stub func() ([]db.PipelineConfigVersion, error)) {
+	fake.configHistoryMutex.Lock()
+	defer fake.configHistoryMutex.Unlock()
+	fake.ConfigHistoryStub = stub
+}
+
+func (fake *FakePipeline) ConfigHistoryReturns(result1 []db.PipelineConfigVersion, result2 error) {
+	fake.configHistoryMutex.Lock()
+	defer fake.configHistoryMutex.Unlock()
+	fake.ConfigHistoryStub = nil
+	fake.configHistoryReturns = struct {
+		result1 []db.PipelineConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) ConfigHistoryReturnsOnCall(i int, result1 []db.PipelineConfigVersion, result2 error) {
+	fake.configHistoryMutex.Lock()
+	defer fake.configHistoryMutex.Unlock()
+	fake.ConfigHistoryStub = nil
+	if fake.configHistoryReturnsOnCall == nil {
+		fake.configHistoryReturnsOnCall = make(map[int]struct {
+			result1 []db.PipelineConfigVersion
+			result2 error
+		})
+	}
+	fake.configHistoryReturnsOnCall[i] = struct {
+		result1 []db.PipelineConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipeline) ConfigVersion() db.ConfigVersion {
 	fake.configVersionMutex.Lock()
 	ret, specificReturn := fake.configVersionReturnsOnCall[len(fake.configVersionArgsForCall)]
@@ -1465,6 +1547,72 @@ func (fake *FakePipeline) HideReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipeline) HistoricalConfig(arg1 db.ConfigVersion) (db.PipelineConfigVersion, bool, error) {
+	fake.historicalConfigMutex.Lock()
+	ret, specificReturn := fake.historicalConfigReturnsOnCall[len(fake.historicalConfigArgsForCall)]
+	fake.historicalConfigArgsForCall = append(fake.historicalConfigArgsForCall, struct {
+		arg1 db.ConfigVersion
+	}{arg1})
+	fake.recordInvocation("HistoricalConfig", []interface{}{arg1})
+	fake.historicalConfigMutex.Unlock()
+	if fake.HistoricalConfigStub != nil {
+		return fake.HistoricalConfigStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.historicalConfigReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakePipeline) HistoricalConfigCallCount() int {
+	fake.historicalConfigMutex.RLock()
+	defer fake.historicalConfigMutex.RUnlock()
+	return len(fake.historicalConfigArgsForCall)
+}
+
+func (fake *FakePipeline) HistoricalConfigCalls(stub func(db.ConfigVersion) (db.PipelineConfigVersion, bool, error)) {
+	fake.historicalConfigMutex.Lock()
+	defer fake.historicalConfigMutex.Unlock()
+	fake.HistoricalConfigStub = stub
+}
+
+func (fake *FakePipeline) HistoricalConfigArgsForCall(i int) db.ConfigVersion {
+	fake.historicalConfigMutex.RLock()
+	defer fake.historicalConfigMutex.RUnlock()
+	argsForCall := fake.historicalConfigArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipeline) HistoricalConfigReturns(result1 db.PipelineConfigVersion, result2 bool, result3 error) {
+	fake.historicalConfigMutex.Lock()
+	defer fake.historicalConfigMutex.Unlock()
+	fake.HistoricalConfigStub = nil
+	fake.historicalConfigReturns = struct {
+		result1 db.PipelineConfigVersion
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) HistoricalConfigReturnsOnCall(i int, result1 db.PipelineConfigVersion, result2 bool, result3 error) {
+	fake.historicalConfigMutex.Lock()
+	defer fake.historicalConfigMutex.Unlock()
+	fake.HistoricalConfigStub = nil
+	if fake.historicalConfigReturnsOnCall == nil {
+		fake.historicalConfigReturnsOnCall = make(map[int]struct {
+			result1 db.PipelineConfigVersion
+			result2 bool
+			result3 error
+		})
+	}
+	fake.historicalConfigReturnsOnCall[i] = struct {
+		result1 db.PipelineConfigVersion
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakePipeline) ID() int {
 	fake.iDMutex.Lock()
 	ret, specificReturn := fake.iDReturnsOnCall[len(fake.iDArgsForCall)]
@@ -2625,6 +2773,8 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.causalityMutex.RUnlock()
 	fake.checkPausedMutex.RLock()
 	defer fake.checkPausedMutex.RUnlock()
+	fake.configHistoryMutex.RLock()
+	defer fake.configHistoryMutex.RUnlock()
 	fake.configVersionMutex.RLock()
 	defer fake.configVersionMutex.RUnlock()
 	fake.createOneOffBuildMutex.RLock()
@@ -2649,6 +2799,8 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.groupsMutex.RUnlock()
 	fake.hideMutex.RLock()
 	defer fake.hideMutex.RUnlock()
+	fake.historicalConfigMutex.RLock()
+	defer fake.historicalConfigMutex.RUnlock()
 	fake.iDMutex.RLock()
 	defer fake.iDMutex.RUnlock()
 	fake.jobMutex.RLock()
@@ -0,0 +1,116 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeResourceConfigVersionPruner struct {
+	PruneVersionsStub        func(atc.VersionRetention) (int, error)
+	pruneVersionsMutex       sync.RWMutex
+	pruneVersionsArgsForCall []struct {
+		arg1 atc.VersionRetention
+	}
+	pruneVersionsReturns struct {
+		result1 int
+		result2 error
+	}
+	pruneVersionsReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeResourceConfigVersionPruner) PruneVersions(arg1 atc.VersionRetention) (int, error) {
+	fake.pruneVersionsMutex.Lock()
+	ret, specificReturn := fake.pruneVersionsReturnsOnCall[len(fake.pruneVersionsArgsForCall)]
+	fake.pruneVersionsArgsForCall = append(fake.pruneVersionsArgsForCall, struct {
+		arg1 atc.VersionRetention
+	}{arg1})
+	fake.recordInvocation("PruneVersions", []interface{}{arg1})
+	fake.pruneVersionsMutex.Unlock()
+	if fake.PruneVersionsStub != nil {
+		return fake.PruneVersionsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.pruneVersionsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResourceConfigVersionPruner) PruneVersionsCallCount() int {
+	fake.pruneVersionsMutex.RLock()
+	defer fake.pruneVersionsMutex.RUnlock()
+	return len(fake.pruneVersionsArgsForCall)
+}
+
+func (fake *FakeResourceConfigVersionPruner) PruneVersionsCalls(stub func(atc.VersionRetention) (int, error)) {
+	fake.pruneVersionsMutex.Lock()
+	defer fake.pruneVersionsMutex.Unlock()
+	fake.PruneVersionsStub = stub
+}
+
+func (fake *FakeResourceConfigVersionPruner) PruneVersionsArgsForCall(i int) atc.VersionRetention {
+	fake.pruneVersionsMutex.RLock()
+	defer fake.pruneVersionsMutex.RUnlock()
+	argsForCall := fake.pruneVersionsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResourceConfigVersionPruner) PruneVersionsReturns(result1 int, result2 error) {
+	fake.pruneVersionsMutex.Lock()
+	defer fake.pruneVersionsMutex.Unlock()
+	fake.PruneVersionsStub = nil
+	fake.pruneVersionsReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceConfigVersionPruner) PruneVersionsReturnsOnCall(i int, result1 int, result2 error) {
+	fake.pruneVersionsMutex.Lock()
+	defer fake.pruneVersionsMutex.Unlock()
+	fake.PruneVersionsStub = nil
+	if fake.pruneVersionsReturnsOnCall == nil {
+		fake.pruneVersionsReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.pruneVersionsReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceConfigVersionPruner) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.pruneVersionsMutex.RLock()
+	defer fake.pruneVersionsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeResourceConfigVersionPruner) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.ResourceConfigVersionPruner = new(FakeResourceConfigVersionPruner)
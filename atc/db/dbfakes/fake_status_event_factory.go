@@ -0,0 +1,191 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeStatusEventFactory struct {
+	DeleteStatusEventsBeforeStub        func(time.Time) error
+	deleteStatusEventsBeforeMutex       sync.RWMutex
+	deleteStatusEventsBeforeArgsForCall []struct {
+		arg1 time.Time
+	}
+	deleteStatusEventsBeforeReturns struct {
+		result1 error
+	}
+	deleteStatusEventsBeforeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	StatusEventsStub        func(db.StatusEventFilter, int64) (db.StatusEventSource, error)
+	statusEventsMutex       sync.RWMutex
+	statusEventsArgsForCall []struct {
+		arg1 db.StatusEventFilter
+		arg2 int64
+	}
+	statusEventsReturns struct {
+		result1 db.StatusEventSource
+		result2 error
+	}
+	statusEventsReturnsOnCall map[int]struct {
+		result1 db.StatusEventSource
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeStatusEventFactory) DeleteStatusEventsBefore(arg1 time.Time) error {
+	fake.deleteStatusEventsBeforeMutex.Lock()
+	ret, specificReturn := fake.deleteStatusEventsBeforeReturnsOnCall[len(fake.deleteStatusEventsBeforeArgsForCall)]
+	fake.deleteStatusEventsBeforeArgsForCall = append(fake.deleteStatusEventsBeforeArgsForCall, struct {
+		arg1 time.Time
+	}{arg1})
+	fake.recordInvocation("DeleteStatusEventsBefore", []interface{}{arg1})
+	fake.deleteStatusEventsBeforeMutex.Unlock()
+	if fake.DeleteStatusEventsBeforeStub != nil {
+		return fake.DeleteStatusEventsBeforeStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteStatusEventsBeforeReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeStatusEventFactory) DeleteStatusEventsBeforeCallCount() int {
+	fake.deleteStatusEventsBeforeMutex.RLock()
+	defer fake.deleteStatusEventsBeforeMutex.RUnlock()
+	return len(fake.deleteStatusEventsBeforeArgsForCall)
+}
+
+func (fake *FakeStatusEventFactory) DeleteStatusEventsBeforeCalls(stub func(time.Time) error) {
+	fake.deleteStatusEventsBeforeMutex.Lock()
+	defer fake.deleteStatusEventsBeforeMutex.Unlock()
+	fake.DeleteStatusEventsBeforeStub = stub
+}
+
+func (fake *FakeStatusEventFactory) DeleteStatusEventsBeforeArgsForCall(i int) time.Time {
+	fake.deleteStatusEventsBeforeMutex.RLock()
+	defer fake.deleteStatusEventsBeforeMutex.RUnlock()
+	argsForCall := fake.deleteStatusEventsBeforeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStatusEventFactory) DeleteStatusEventsBeforeReturns(result1 error) {
+	fake.deleteStatusEventsBeforeMutex.Lock()
+	defer fake.deleteStatusEventsBeforeMutex.Unlock()
+	fake.DeleteStatusEventsBeforeStub = nil
+	fake.deleteStatusEventsBeforeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStatusEventFactory) DeleteStatusEventsBeforeReturnsOnCall(i int, result1 error) {
+	fake.deleteStatusEventsBeforeMutex.Lock()
+	defer fake.deleteStatusEventsBeforeMutex.Unlock()
+	fake.DeleteStatusEventsBeforeStub = nil
+	if fake.deleteStatusEventsBeforeReturnsOnCall == nil {
+		fake.deleteStatusEventsBeforeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteStatusEventsBeforeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStatusEventFactory) StatusEvents(arg1 db.StatusEventFilter, arg2 int64) (db.StatusEventSource, error) {
+	fake.statusEventsMutex.Lock()
+	ret, specificReturn := fake.statusEventsReturnsOnCall[len(fake.statusEventsArgsForCall)]
+	fake.statusEventsArgsForCall = append(fake.statusEventsArgsForCall, struct {
+		arg1 db.StatusEventFilter
+		arg2 int64
+	}{arg1, arg2})
+	fake.recordInvocation("StatusEvents", []interface{}{arg1, arg2})
+	fake.statusEventsMutex.Unlock()
+	if fake.StatusEventsStub != nil {
+		return fake.StatusEventsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.statusEventsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStatusEventFactory) StatusEventsCallCount() int {
+	fake.statusEventsMutex.RLock()
+	defer fake.statusEventsMutex.RUnlock()
+	return len(fake.statusEventsArgsForCall)
+}
+
+func (fake *FakeStatusEventFactory) StatusEventsCalls(stub func(db.StatusEventFilter, int64) (db.StatusEventSource, error)) {
+	fake.statusEventsMutex.Lock()
+	defer fake.statusEventsMutex.Unlock()
+	fake.StatusEventsStub = stub
+}
+
+func (fake *FakeStatusEventFactory) StatusEventsArgsForCall(i int) (db.StatusEventFilter, int64) {
+	fake.statusEventsMutex.RLock()
+	defer fake.statusEventsMutex.RUnlock()
+	argsForCall := fake.statusEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStatusEventFactory) StatusEventsReturns(result1 db.StatusEventSource, result2 error) {
+	fake.statusEventsMutex.Lock()
+	defer fake.statusEventsMutex.Unlock()
+	fake.StatusEventsStub = nil
+	fake.statusEventsReturns = struct {
+		result1 db.StatusEventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStatusEventFactory) StatusEventsReturnsOnCall(i int, result1 db.StatusEventSource, result2 error) {
+	fake.statusEventsMutex.Lock()
+	defer fake.statusEventsMutex.Unlock()
+	fake.StatusEventsStub = nil
+	if fake.statusEventsReturnsOnCall == nil {
+		fake.statusEventsReturnsOnCall = make(map[int]struct {
+			result1 db.StatusEventSource
+			result2 error
+		})
+	}
+	fake.statusEventsReturnsOnCall[i] = struct {
+		result1 db.StatusEventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStatusEventFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.deleteStatusEventsBeforeMutex.RLock()
+	defer fake.deleteStatusEventsBeforeMutex.RUnlock()
+	fake.statusEventsMutex.RLock()
+	defer fake.statusEventsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeStatusEventFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.StatusEventFactory = new(FakeStatusEventFactory)
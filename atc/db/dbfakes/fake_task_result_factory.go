@@ -0,0 +1,274 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeTaskResultFactory struct {
+	FindTaskResultStub        func(int, string) (db.TaskResult, bool, error)
+	findTaskResultMutex       sync.RWMutex
+	findTaskResultArgsForCall []struct {
+		arg1 int
+		arg2 string
+	}
+	findTaskResultReturns struct {
+		result1 db.TaskResult
+		result2 bool
+		result3 error
+	}
+	findTaskResultReturnsOnCall map[int]struct {
+		result1 db.TaskResult
+		result2 bool
+		result3 error
+	}
+	SaveTaskResultStub        func(int, string, int, map[string]int) error
+	saveTaskResultMutex       sync.RWMutex
+	saveTaskResultArgsForCall []struct {
+		arg1 int
+		arg2 string
+		arg3 int
+		arg4 map[string]int
+	}
+	saveTaskResultReturns struct {
+		result1 error
+	}
+	saveTaskResultReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UseTaskResultStub        func(int) error
+	useTaskResultMutex       sync.RWMutex
+	useTaskResultArgsForCall []struct {
+		arg1 int
+	}
+	useTaskResultReturns struct {
+		result1 error
+	}
+	useTaskResultReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeTaskResultFactory) FindTaskResult(arg1 int, arg2 string) (db.TaskResult, bool, error) {
+	fake.findTaskResultMutex.Lock()
+	ret, specificReturn := fake.findTaskResultReturnsOnCall[len(fake.findTaskResultArgsForCall)]
+	fake.findTaskResultArgsForCall = append(fake.findTaskResultArgsForCall, struct {
+		arg1 int
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("FindTaskResult", []interface{}{arg1, arg2})
+	fake.findTaskResultMutex.Unlock()
+	if fake.FindTaskResultStub != nil {
+		return fake.FindTaskResultStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.findTaskResultReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTaskResultFactory) FindTaskResultCallCount() int {
+	fake.findTaskResultMutex.RLock()
+	defer fake.findTaskResultMutex.RUnlock()
+	return len(fake.findTaskResultArgsForCall)
+}
+
+func (fake *FakeTaskResultFactory) FindTaskResultCalls(stub func(int, string) (db.TaskResult, bool, error)) {
+	fake.findTaskResultMutex.Lock()
+	defer fake.findTaskResultMutex.Unlock()
+	fake.FindTaskResultStub = stub
+}
+
+func (fake *FakeTaskResultFactory) FindTaskResultArgsForCall(i int) (int, string) {
+	fake.findTaskResultMutex.RLock()
+	defer fake.findTaskResultMutex.RUnlock()
+	argsForCall := fake.findTaskResultArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTaskResultFactory) FindTaskResultReturns(result1 db.TaskResult, result2 bool, result3 error) {
+	fake.findTaskResultMutex.Lock()
+	defer fake.findTaskResultMutex.Unlock()
+	fake.FindTaskResultStub = nil
+	fake.findTaskResultReturns = struct {
+		result1 db.TaskResult
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTaskResultFactory) FindTaskResultReturnsOnCall(i int, result1 db.TaskResult, result2 bool, result3 error) {
+	fake.findTaskResultMutex.Lock()
+	defer fake.findTaskResultMutex.Unlock()
+	fake.FindTaskResultStub = nil
+	if fake.findTaskResultReturnsOnCall == nil {
+		fake.findTaskResultReturnsOnCall = make(map[int]struct {
+			result1 db.TaskResult
+			result2 bool
+			result3 error
+		})
+	}
+	fake.findTaskResultReturnsOnCall[i] = struct {
+		result1 db.TaskResult
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTaskResultFactory) SaveTaskResult(arg1 int, arg2 string, arg3 int, arg4 map[string]int) error {
+	fake.saveTaskResultMutex.Lock()
+	ret, specificReturn := fake.saveTaskResultReturnsOnCall[len(fake.saveTaskResultArgsForCall)]
+	fake.saveTaskResultArgsForCall = append(fake.saveTaskResultArgsForCall, struct {
+		arg1 int
+		arg2 string
+		arg3 int
+		arg4 map[string]int
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("SaveTaskResult", []interface{}{arg1, arg2, arg3, arg4})
+	fake.saveTaskResultMutex.Unlock()
+	if fake.SaveTaskResultStub != nil {
+		return fake.SaveTaskResultStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.saveTaskResultReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTaskResultFactory) SaveTaskResultCallCount() int {
+	fake.saveTaskResultMutex.RLock()
+	defer fake.saveTaskResultMutex.RUnlock()
+	return len(fake.saveTaskResultArgsForCall)
+}
+
+func (fake *FakeTaskResultFactory) SaveTaskResultCalls(stub func(int, string, int, map[string]int) error) {
+	fake.saveTaskResultMutex.Lock()
+	defer fake.saveTaskResultMutex.Unlock()
+	fake.SaveTaskResultStub = stub
+}
+
+func (fake *FakeTaskResultFactory) SaveTaskResultArgsForCall(i int) (int, string, int, map[string]int) {
+	fake.saveTaskResultMutex.RLock()
+	defer fake.saveTaskResultMutex.RUnlock()
+	argsForCall := fake.saveTaskResultArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeTaskResultFactory) SaveTaskResultReturns(result1 error) {
+	fake.saveTaskResultMutex.Lock()
+	defer fake.saveTaskResultMutex.Unlock()
+	fake.SaveTaskResultStub = nil
+	fake.saveTaskResultReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskResultFactory) SaveTaskResultReturnsOnCall(i int, result1 error) {
+	fake.saveTaskResultMutex.Lock()
+	defer fake.saveTaskResultMutex.Unlock()
+	fake.SaveTaskResultStub = nil
+	if fake.saveTaskResultReturnsOnCall == nil {
+		fake.saveTaskResultReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveTaskResultReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskResultFactory) UseTaskResult(arg1 int) error {
+	fake.useTaskResultMutex.Lock()
+	ret, specificReturn := fake.useTaskResultReturnsOnCall[len(fake.useTaskResultArgsForCall)]
+	fake.useTaskResultArgsForCall = append(fake.useTaskResultArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("UseTaskResult", []interface{}{arg1})
+	fake.useTaskResultMutex.Unlock()
+	if fake.UseTaskResultStub != nil {
+		return fake.UseTaskResultStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.useTaskResultReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTaskResultFactory) UseTaskResultCallCount() int {
+	fake.useTaskResultMutex.RLock()
+	defer fake.useTaskResultMutex.RUnlock()
+	return len(fake.useTaskResultArgsForCall)
+}
+
+func (fake *FakeTaskResultFactory) UseTaskResultCalls(stub func(int) error) {
+	fake.useTaskResultMutex.Lock()
+	defer fake.useTaskResultMutex.Unlock()
+	fake.UseTaskResultStub = stub
+}
+
+func (fake *FakeTaskResultFactory) UseTaskResultArgsForCall(i int) int {
+	fake.useTaskResultMutex.RLock()
+	defer fake.useTaskResultMutex.RUnlock()
+	argsForCall := fake.useTaskResultArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTaskResultFactory) UseTaskResultReturns(result1 error) {
+	fake.useTaskResultMutex.Lock()
+	defer fake.useTaskResultMutex.Unlock()
+	fake.UseTaskResultStub = nil
+	fake.useTaskResultReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskResultFactory) UseTaskResultReturnsOnCall(i int, result1 error) {
+	fake.useTaskResultMutex.Lock()
+	defer fake.useTaskResultMutex.Unlock()
+	fake.UseTaskResultStub = nil
+	if fake.useTaskResultReturnsOnCall == nil {
+		fake.useTaskResultReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.useTaskResultReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskResultFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.findTaskResultMutex.RLock()
+	defer fake.findTaskResultMutex.RUnlock()
+	fake.saveTaskResultMutex.RLock()
+	defer fake.saveTaskResultMutex.RUnlock()
+	fake.useTaskResultMutex.RLock()
+	defer fake.useTaskResultMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeTaskResultFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.TaskResultFactory = new(FakeTaskResultFactory)
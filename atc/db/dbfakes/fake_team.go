@@ -9,9 +9,25 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/lock"
 )
 
 type FakeTeam struct {
+	AcquireBuildQuotaLockStub        func(lager.Logger) (lock.Lock, bool, error)
+	acquireBuildQuotaLockMutex       sync.RWMutex
+	acquireBuildQuotaLockArgsForCall []struct {
+		arg1 lager.Logger
+	}
+	acquireBuildQuotaLockReturns struct {
+		result1 lock.Lock
+		result2 bool
+		result3 error
+	}
+	acquireBuildQuotaLockReturnsOnCall map[int]struct {
+		result1 lock.Lock
+		result2 bool
+		result3 error
+	}
 	AdminStub        func() bool
 	adminMutex       sync.RWMutex
 	adminArgsForCall []struct {
@@ -110,13 +126,14 @@ type FakeTeam struct {
 	deleteReturnsOnCall map[int]struct {
 		result1 error
 	}
-	FindCheckContainersStub        func(lager.Logger, string, string, creds.Secrets) ([]db.Container, map[int]time.Time, error)
+	FindCheckContainersStub        func(lager.Logger, string, string, creds.Secrets, atc.SourceDefaults) ([]db.Container, map[int]time.Time, error)
 	findCheckContainersMutex       sync.RWMutex
 	findCheckContainersArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 string
 		arg3 string
 		arg4 creds.Secrets
+		arg5 atc.SourceDefaults
 	}
 	findCheckContainersReturns struct {
 		result1 []db.Container
@@ -328,6 +345,28 @@ type FakeTeam struct {
 		result1 []db.Pipeline
 		result2 error
 	}
+	QuotaStub        func() atc.TeamQuota
+	quotaMutex       sync.RWMutex
+	quotaArgsForCall []struct {
+	}
+	quotaReturns struct {
+		result1 atc.TeamQuota
+	}
+	quotaReturnsOnCall map[int]struct {
+		result1 atc.TeamQuota
+	}
+	QuotaUsageStub        func() (db.TeamQuotaUsage, error)
+	quotaUsageMutex       sync.RWMutex
+	quotaUsageArgsForCall []struct {
+	}
+	quotaUsageReturns struct {
+		result1 db.TeamQuotaUsage
+		result2 error
+	}
+	quotaUsageReturnsOnCall map[int]struct {
+		result1 db.TeamQuotaUsage
+		result2 error
+	}
 	RenameStub        func(string) error
 	renameMutex       sync.RWMutex
 	renameArgsForCall []struct {
@@ -339,13 +378,14 @@ type FakeTeam struct {
 	renameReturnsOnCall map[int]struct {
 		result1 error
 	}
-	SavePipelineStub        func(string, atc.Config, db.ConfigVersion, db.PipelinePausedState) (db.Pipeline, bool, error)
+	SavePipelineStub        func(string, atc.Config, db.ConfigVersion, db.PipelinePausedState, string) (db.Pipeline, bool, error)
 	savePipelineMutex       sync.RWMutex
 	savePipelineArgsForCall []struct {
 		arg1 string
 		arg2 atc.Config
 		arg3 db.ConfigVersion
 		arg4 db.PipelinePausedState
+		arg5 string
 	}
 	savePipelineReturns struct {
 		result1 db.Pipeline
@@ -382,6 +422,17 @@ type FakeTeam struct {
 	updateProviderAuthReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpdateQuotaStub        func(atc.TeamQuota) error
+	updateQuotaMutex       sync.RWMutex
+	updateQuotaArgsForCall []struct {
+		arg1 atc.TeamQuota
+	}
+	updateQuotaReturns struct {
+		result1 error
+	}
+	updateQuotaReturnsOnCall map[int]struct {
+		result1 error
+	}
 	VisiblePipelinesStub        func() ([]db.Pipeline, error)
 	visiblePipelinesMutex       sync.RWMutex
 	visiblePipelinesArgsForCall []struct {
@@ -410,6 +461,72 @@ type FakeTeam struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeTeam) AcquireBuildQuotaLock(arg1 lager.Logger) (lock.Lock, bool, error) {
+	fake.acquireBuildQuotaLockMutex.Lock()
+	ret, specificReturn := fake.acquireBuildQuotaLockReturnsOnCall[len(fake.acquireBuildQuotaLockArgsForCall)]
+	fake.acquireBuildQuotaLockArgsForCall = append(fake.acquireBuildQuotaLockArgsForCall, struct {
+		arg1 lager.Logger
+	}{arg1})
+	fake.recordInvocation("AcquireBuildQuotaLock", []interface{}{arg1})
+	fake.acquireBuildQuotaLockMutex.Unlock()
+	if fake.AcquireBuildQuotaLockStub != nil {
+		return fake.AcquireBuildQuotaLockStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.acquireBuildQuotaLockReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) AcquireBuildQuotaLockCallCount() int {
+	fake.acquireBuildQuotaLockMutex.RLock()
+	defer fake.acquireBuildQuotaLockMutex.RUnlock()
+	return len(fake.acquireBuildQuotaLockArgsForCall)
+}
+
+func (fake *FakeTeam) AcquireBuildQuotaLockCalls(stub func(lager.Logger) (lock.Lock, bool, error)) {
+	fake.acquireBuildQuotaLockMutex.Lock()
+	defer fake.acquireBuildQuotaLockMutex.Unlock()
+	fake.AcquireBuildQuotaLockStub = stub
+}
+
+func (fake *FakeTeam) AcquireBuildQuotaLockArgsForCall(i int) lager.Logger {
+	fake.acquireBuildQuotaLockMutex.RLock()
+	defer fake.acquireBuildQuotaLockMutex.RUnlock()
+	argsForCall := fake.acquireBuildQuotaLockArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) AcquireBuildQuotaLockReturns(result1 lock.Lock, result2 bool, result3 error) {
+	fake.acquireBuildQuotaLockMutex.Lock()
+	defer fake.acquireBuildQuotaLockMutex.Unlock()
+	fake.AcquireBuildQuotaLockStub = nil
+	fake.acquireBuildQuotaLockReturns = struct {
+		result1 lock.Lock
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) AcquireBuildQuotaLockReturnsOnCall(i int, result1 lock.Lock, result2 bool, result3 error) {
+	fake.acquireBuildQuotaLockMutex.Lock()
+	defer fake.acquireBuildQuotaLockMutex.Unlock()
+	fake.AcquireBuildQuotaLockStub = nil
+	if fake.acquireBuildQuotaLockReturnsOnCall == nil {
+		fake.acquireBuildQuotaLockReturnsOnCall = make(map[int]struct {
+			result1 lock.Lock
+			result2 bool
+			result3 error
+		})
+	}
+	fake.acquireBuildQuotaLockReturnsOnCall[i] = struct {
+		result1 lock.Lock
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) Admin() bool {
 	fake.adminMutex.Lock()
 	ret, specificReturn := fake.adminReturnsOnCall[len(fake.adminArgsForCall)]
@@ -879,7 +996,7 @@ func (fake *FakeTeam) DeleteReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeTeam) FindCheckContainers(arg1 lager.Logger, arg2 string, arg3 string, arg4 creds.Secrets) ([]db.Container, map[int]time.Time, error) {
+func (fake *FakeTeam) FindCheckContainers(arg1 lager.Logger, arg2 string, arg3 string, arg4 creds.Secrets, arg5 atc.SourceDefaults) ([]db.Container, map[int]time.Time, error) {
 	fake.findCheckContainersMutex.Lock()
 	ret, specificReturn := fake.findCheckContainersReturnsOnCall[len(fake.findCheckContainersArgsForCall)]
 	fake.findCheckContainersArgsForCall = append(fake.findCheckContainersArgsForCall, struct {
@@ -887,11 +1004,12 @@ func (fake *FakeTeam) FindCheckContainers(arg1 lager.Logger, arg2 string, arg3 s
 		arg2 string
 		arg3 string
 		arg4 creds.Secrets
-	}{arg1, arg2, arg3, arg4})
-	fake.recordInvocation("FindCheckContainers", []interface{}{arg1, arg2, arg3, arg4})
+		arg5 atc.SourceDefaults
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("FindCheckContainers", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.findCheckContainersMutex.Unlock()
 	if fake.FindCheckContainersStub != nil {
-		return fake.FindCheckContainersStub(arg1, arg2, arg3, arg4)
+		return fake.FindCheckContainersStub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3
@@ -906,17 +1024,17 @@ func (fake *FakeTeam) FindCheckContainersCallCount() int {
 	return len(fake.findCheckContainersArgsForCall)
 }
 
-func (fake *FakeTeam) FindCheckContainersCalls(stub func(lager.Logger, string, string, creds.Secrets) ([]db.Container, map[int]time.Time, error)) {
+func (fake *FakeTeam) FindCheckContainersCalls(stub func(lager.Logger, string, string, creds.Secrets, atc.SourceDefaults) ([]db.Container, map[int]time.Time, error)) {
 	fake.findCheckContainersMutex.Lock()
 	defer fake.findCheckContainersMutex.Unlock()
 	fake.FindCheckContainersStub = stub
 }
 
-func (fake *FakeTeam) FindCheckContainersArgsForCall(i int) (lager.Logger, string, string, creds.Secrets) {
+func (fake *FakeTeam) FindCheckContainersArgsForCall(i int) (lager.Logger, string, string, creds.Secrets, atc.SourceDefaults) {
 	fake.findCheckContainersMutex.RLock()
 	defer fake.findCheckContainersMutex.RUnlock()
 	argsForCall := fake.findCheckContainersArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
 func (fake *FakeTeam) FindCheckContainersReturns(result1 []db.Container, result2 map[int]time.Time, result3 error) {
@@ -1879,6 +1997,113 @@ func (fake *FakeTeam) PublicPipelinesReturnsOnCall(i int, result1 []db.Pipeline,
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) Quota() atc.TeamQuota {
+	fake.quotaMutex.Lock()
+	ret, specificReturn := fake.quotaReturnsOnCall[len(fake.quotaArgsForCall)]
+	fake.quotaArgsForCall = append(fake.quotaArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Quota", []interface{}{})
+	fake.quotaMutex.Unlock()
+	if fake.QuotaStub != nil {
+		return fake.QuotaStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.quotaReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) QuotaCallCount() int {
+	fake.quotaMutex.RLock()
+	defer fake.quotaMutex.RUnlock()
+	return len(fake.quotaArgsForCall)
+}
+
+func (fake *FakeTeam) QuotaCalls(stub func() atc.TeamQuota) {
+	fake.quotaMutex.Lock()
+	defer fake.quotaMutex.Unlock()
+	fake.QuotaStub = stub
+}
+
+func (fake *FakeTeam) QuotaReturns(result1 atc.TeamQuota) {
+	fake.quotaMutex.Lock()
+	defer fake.quotaMutex.Unlock()
+	fake.QuotaStub = nil
+	fake.quotaReturns = struct {
+		result1 atc.TeamQuota
+	}{result1}
+}
+
+func (fake *FakeTeam) QuotaReturnsOnCall(i int, result1 atc.TeamQuota) {
+	fake.quotaMutex.Lock()
+	defer fake.quotaMutex.Unlock()
+	fake.QuotaStub = nil
+	if fake.quotaReturnsOnCall == nil {
+		fake.quotaReturnsOnCall = make(map[int]struct {
+			result1 atc.TeamQuota
+		})
+	}
+	fake.quotaReturnsOnCall[i] = struct {
+		result1 atc.TeamQuota
+	}{result1}
+}
+
+func (fake *FakeTeam) QuotaUsage() (db.TeamQuotaUsage, error) {
+	fake.quotaUsageMutex.Lock()
+	ret, specificReturn := fake.quotaUsageReturnsOnCall[len(fake.quotaUsageArgsForCall)]
+	fake.quotaUsageArgsForCall = append(fake.quotaUsageArgsForCall, struct {
+	}{})
+	fake.recordInvocation("QuotaUsage", []interface{}{})
+	fake.quotaUsageMutex.Unlock()
+	if fake.QuotaUsageStub != nil {
+		return fake.QuotaUsageStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.quotaUsageReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) QuotaUsageCallCount() int {
+	fake.quotaUsageMutex.RLock()
+	defer fake.quotaUsageMutex.RUnlock()
+	return len(fake.quotaUsageArgsForCall)
+}
+
+func (fake *FakeTeam) QuotaUsageCalls(stub func() (db.TeamQuotaUsage, error)) {
+	fake.quotaUsageMutex.Lock()
+	defer fake.quotaUsageMutex.Unlock()
+	fake.QuotaUsageStub = stub
+}
+
+func (fake *FakeTeam) QuotaUsageReturns(result1 db.TeamQuotaUsage, result2 error) {
+	fake.quotaUsageMutex.Lock()
+	defer fake.quotaUsageMutex.Unlock()
+	fake.QuotaUsageStub = nil
+	fake.quotaUsageReturns = struct {
+		result1 db.TeamQuotaUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) QuotaUsageReturnsOnCall(i int, result1 db.TeamQuotaUsage, result2 error) {
+	fake.quotaUsageMutex.Lock()
+	defer fake.quotaUsageMutex.Unlock()
+	fake.QuotaUsageStub = nil
+	if fake.quotaUsageReturnsOnCall == nil {
+		fake.quotaUsageReturnsOnCall = make(map[int]struct {
+			result1 db.TeamQuotaUsage
+			result2 error
+		})
+	}
+	fake.quotaUsageReturnsOnCall[i] = struct {
+		result1 db.TeamQuotaUsage
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) Rename(arg1 string) error {
 	fake.renameMutex.Lock()
 	ret, specificReturn := fake.renameReturnsOnCall[len(fake.renameArgsForCall)]
@@ -1939,7 +2164,7 @@ func (fake *FakeTeam) RenameReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeTeam) SavePipeline(arg1 string, arg2 atc.Config, arg3 db.ConfigVersion, arg4 db.PipelinePausedState) (db.Pipeline, bool, error) {
+func (fake *FakeTeam) SavePipeline(arg1 string, arg2 atc.Config, arg3 db.ConfigVersion, arg4 db.PipelinePausedState, arg5 string) (db.Pipeline, bool, error) {
 	fake.savePipelineMutex.Lock()
 	ret, specificReturn := fake.savePipelineReturnsOnCall[len(fake.savePipelineArgsForCall)]
 	fake.savePipelineArgsForCall = append(fake.savePipelineArgsForCall, struct {
@@ -1947,11 +2172,12 @@ func (fake *FakeTeam) SavePipeline(arg1 string, arg2 atc.Config, arg3 db.ConfigV
 		arg2 atc.Config
 		arg3 db.ConfigVersion
 		arg4 db.PipelinePausedState
-	}{arg1, arg2, arg3, arg4})
-	fake.recordInvocation("SavePipeline", []interface{}{arg1, arg2, arg3, arg4})
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("SavePipeline", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.savePipelineMutex.Unlock()
 	if fake.SavePipelineStub != nil {
-		return fake.SavePipelineStub(arg1, arg2, arg3, arg4)
+		return fake.SavePipelineStub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3
@@ -1966,17 +2192,17 @@ func (fake *FakeTeam) SavePipelineCallCount() int {
 	return len(fake.savePipelineArgsForCall)
 }
 
-func (fake *FakeTeam) SavePipelineCalls(stub func(string, atc.Config, db.ConfigVersion, db.PipelinePausedState) (db.Pipeline, bool, error)) {
+func (fake *FakeTeam) SavePipelineCalls(stub func(string, atc.Config, db.ConfigVersion, db.PipelinePausedState, string) (db.Pipeline, bool, error)) {
 	fake.savePipelineMutex.Lock()
 	defer fake.savePipelineMutex.Unlock()
 	fake.SavePipelineStub = stub
 }
 
-func (fake *FakeTeam) SavePipelineArgsForCall(i int) (string, atc.Config, db.ConfigVersion, db.PipelinePausedState) {
+func (fake *FakeTeam) SavePipelineArgsForCall(i int) (string, atc.Config, db.ConfigVersion, db.PipelinePausedState, string) {
 	fake.savePipelineMutex.RLock()
 	defer fake.savePipelineMutex.RUnlock()
 	argsForCall := fake.savePipelineArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
 func (fake *FakeTeam) SavePipelineReturns(result1 db.Pipeline, result2 bool, result3 error) {
@@ -2132,6 +2358,66 @@ func (fake *FakeTeam) UpdateProviderAuthReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeTeam) UpdateQuota(arg1 atc.TeamQuota) error {
+	fake.updateQuotaMutex.Lock()
+	ret, specificReturn := fake.updateQuotaReturnsOnCall[len(fake.updateQuotaArgsForCall)]
+	fake.updateQuotaArgsForCall = append(fake.updateQuotaArgsForCall, struct {
+		arg1 atc.TeamQuota
+	}{arg1})
+	fake.recordInvocation("UpdateQuota", []interface{}{arg1})
+	fake.updateQuotaMutex.Unlock()
+	if fake.UpdateQuotaStub != nil {
+		return fake.UpdateQuotaStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateQuotaReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdateQuotaCallCount() int {
+	fake.updateQuotaMutex.RLock()
+	defer fake.updateQuotaMutex.RUnlock()
+	return len(fake.updateQuotaArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateQuotaCalls(stub func(atc.TeamQuota) error) {
+	fake.updateQuotaMutex.Lock()
+	defer fake.updateQuotaMutex.Unlock()
+	fake.UpdateQuotaStub = stub
+}
+
+func (fake *FakeTeam) UpdateQuotaArgsForCall(i int) atc.TeamQuota {
+	fake.updateQuotaMutex.RLock()
+	defer fake.updateQuotaMutex.RUnlock()
+	argsForCall := fake.updateQuotaArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdateQuotaReturns(result1 error) {
+	fake.updateQuotaMutex.Lock()
+	defer fake.updateQuotaMutex.Unlock()
+	fake.UpdateQuotaStub = nil
+	fake.updateQuotaReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateQuotaReturnsOnCall(i int, result1 error) {
+	fake.updateQuotaMutex.Lock()
+	defer fake.updateQuotaMutex.Unlock()
+	fake.UpdateQuotaStub = nil
+	if fake.updateQuotaReturnsOnCall == nil {
+		fake.updateQuotaReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateQuotaReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTeam) VisiblePipelines() ([]db.Pipeline, error) {
 	fake.visiblePipelinesMutex.Lock()
 	ret, specificReturn := fake.visiblePipelinesReturnsOnCall[len(fake.visiblePipelinesArgsForCall)]
@@ -2245,6 +2531,8 @@ func (fake *FakeTeam) WorkersReturnsOnCall(i int, result1 []db.Worker, result2 e
 func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.acquireBuildQuotaLockMutex.RLock()
+	defer fake.acquireBuildQuotaLockMutex.RUnlock()
 	fake.adminMutex.RLock()
 	defer fake.adminMutex.RUnlock()
 	fake.authMutex.RLock()
@@ -2293,6 +2581,10 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.privateAndPublicBuildsMutex.RUnlock()
 	fake.publicPipelinesMutex.RLock()
 	defer fake.publicPipelinesMutex.RUnlock()
+	fake.quotaMutex.RLock()
+	defer fake.quotaMutex.RUnlock()
+	fake.quotaUsageMutex.RLock()
+	defer fake.quotaUsageMutex.RUnlock()
 	fake.renameMutex.RLock()
 	defer fake.renameMutex.RUnlock()
 	fake.savePipelineMutex.RLock()
@@ -2301,6 +2593,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.saveWorkerMutex.RUnlock()
 	fake.updateProviderAuthMutex.RLock()
 	defer fake.updateProviderAuthMutex.RUnlock()
+	fake.updateQuotaMutex.RLock()
+	defer fake.updateQuotaMutex.RUnlock()
 	fake.visiblePipelinesMutex.RLock()
 	defer fake.visiblePipelinesMutex.RUnlock()
 	fake.workersMutex.RLock()
@@ -40,6 +40,16 @@ type FakeWorker struct {
 	baggageclaimURLReturnsOnCall map[int]struct {
 		result1 *string
 	}
+	CapabilitiesStub        func() []string
+	capabilitiesMutex       sync.RWMutex
+	capabilitiesArgsForCall []struct {
+	}
+	capabilitiesReturns struct {
+		result1 []string
+	}
+	capabilitiesReturnsOnCall map[int]struct {
+		result1 []string
+	}
 	CertsPathStub        func() *string
 	certsPathMutex       sync.RWMutex
 	certsPathArgsForCall []struct {
@@ -139,6 +149,16 @@ type FakeWorker struct {
 	hTTPSProxyURLReturnsOnCall map[int]struct {
 		result1 string
 	}
+	KeyFingerprintStub        func() string
+	keyFingerprintMutex       sync.RWMutex
+	keyFingerprintArgsForCall []struct {
+	}
+	keyFingerprintReturns struct {
+		result1 string
+	}
+	keyFingerprintReturnsOnCall map[int]struct {
+		result1 string
+	}
 	LandStub        func() error
 	landMutex       sync.RWMutex
 	landArgsForCall []struct {
@@ -189,6 +209,39 @@ type FakeWorker struct {
 	pruneReturnsOnCall map[int]struct {
 		result1 error
 	}
+	QuarantineStub        func(time.Duration) (bool, error)
+	quarantineMutex       sync.RWMutex
+	quarantineArgsForCall []struct {
+		arg1 time.Duration
+	}
+	quarantineReturns struct {
+		result1 bool
+		result2 error
+	}
+	quarantineReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	QuarantinedUntilStub        func() time.Time
+	quarantinedUntilMutex       sync.RWMutex
+	quarantinedUntilArgsForCall []struct {
+	}
+	quarantinedUntilReturns struct {
+		result1 time.Time
+	}
+	quarantinedUntilReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
+	ReleaseQuarantineStub        func() error
+	releaseQuarantineMutex       sync.RWMutex
+	releaseQuarantineArgsForCall []struct {
+	}
+	releaseQuarantineReturns struct {
+		result1 error
+	}
+	releaseQuarantineReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ReloadStub        func() (bool, error)
 	reloadMutex       sync.RWMutex
 	reloadArgsForCall []struct {
@@ -455,6 +508,58 @@ func (fake *FakeWorker) BaggageclaimURLReturnsOnCall(i int, result1 *string) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Capabilities() []string {
+	fake.capabilitiesMutex.Lock()
+	ret, specificReturn := fake.capabilitiesReturnsOnCall[len(fake.capabilitiesArgsForCall)]
+	fake.capabilitiesArgsForCall = append(fake.capabilitiesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Capabilities", []interface{}{})
+	fake.capabilitiesMutex.Unlock()
+	if fake.CapabilitiesStub != nil {
+		return fake.CapabilitiesStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.capabilitiesReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) CapabilitiesCallCount() int {
+	fake.capabilitiesMutex.RLock()
+	defer fake.capabilitiesMutex.RUnlock()
+	return len(fake.capabilitiesArgsForCall)
+}
+
+func (fake *FakeWorker) CapabilitiesCalls(stub func() []string) {
+	fake.capabilitiesMutex.Lock()
+	defer fake.capabilitiesMutex.Unlock()
+	fake.CapabilitiesStub = stub
+}
+
+func (fake *FakeWorker) CapabilitiesReturns(result1 []string) {
+	fake.capabilitiesMutex.Lock()
+	defer fake.capabilitiesMutex.Unlock()
+	fake.CapabilitiesStub = nil
+	fake.capabilitiesReturns = struct {
+		result1 []string
+	}{result1}
+}
+
+func (fake *FakeWorker) CapabilitiesReturnsOnCall(i int, result1 []string) {
+	fake.capabilitiesMutex.Lock()
+	defer fake.capabilitiesMutex.Unlock()
+	fake.CapabilitiesStub = nil
+	if fake.capabilitiesReturnsOnCall == nil {
+		fake.capabilitiesReturnsOnCall = make(map[int]struct {
+			result1 []string
+		})
+	}
+	fake.capabilitiesReturnsOnCall[i] = struct {
+		result1 []string
+	}{result1}
+}
+
 func (fake *FakeWorker) CertsPath() *string {
 	fake.certsPathMutex.Lock()
 	ret, specificReturn := fake.certsPathReturnsOnCall[len(fake.certsPathArgsForCall)]
@@ -949,6 +1054,58 @@ func (fake *FakeWorker) HTTPSProxyURLReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeWorker) KeyFingerprint() string {
+	fake.keyFingerprintMutex.Lock()
+	ret, specificReturn := fake.keyFingerprintReturnsOnCall[len(fake.keyFingerprintArgsForCall)]
+	fake.keyFingerprintArgsForCall = append(fake.keyFingerprintArgsForCall, struct {
+	}{})
+	fake.recordInvocation("KeyFingerprint", []interface{}{})
+	fake.keyFingerprintMutex.Unlock()
+	if fake.KeyFingerprintStub != nil {
+		return fake.KeyFingerprintStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.keyFingerprintReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) KeyFingerprintCallCount() int {
+	fake.keyFingerprintMutex.RLock()
+	defer fake.keyFingerprintMutex.RUnlock()
+	return len(fake.keyFingerprintArgsForCall)
+}
+
+func (fake *FakeWorker) KeyFingerprintCalls(stub func() string) {
+	fake.keyFingerprintMutex.Lock()
+	defer fake.keyFingerprintMutex.Unlock()
+	fake.KeyFingerprintStub = stub
+}
+
+func (fake *FakeWorker) KeyFingerprintReturns(result1 string) {
+	fake.keyFingerprintMutex.Lock()
+	defer fake.keyFingerprintMutex.Unlock()
+	fake.KeyFingerprintStub = nil
+	fake.keyFingerprintReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeWorker) KeyFingerprintReturnsOnCall(i int, result1 string) {
+	fake.keyFingerprintMutex.Lock()
+	defer fake.keyFingerprintMutex.Unlock()
+	fake.KeyFingerprintStub = nil
+	if fake.keyFingerprintReturnsOnCall == nil {
+		fake.keyFingerprintReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.keyFingerprintReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeWorker) Land() error {
 	fake.landMutex.Lock()
 	ret, specificReturn := fake.landReturnsOnCall[len(fake.landArgsForCall)]
@@ -1209,6 +1366,173 @@ func (fake *FakeWorker) PruneReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Quarantine(arg1 time.Duration) (bool, error) {
+	fake.quarantineMutex.Lock()
+	ret, specificReturn := fake.quarantineReturnsOnCall[len(fake.quarantineArgsForCall)]
+	fake.quarantineArgsForCall = append(fake.quarantineArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	fake.recordInvocation("Quarantine", []interface{}{arg1})
+	fake.quarantineMutex.Unlock()
+	if fake.QuarantineStub != nil {
+		return fake.QuarantineStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.quarantineReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorker) QuarantineCallCount() int {
+	fake.quarantineMutex.RLock()
+	defer fake.quarantineMutex.RUnlock()
+	return len(fake.quarantineArgsForCall)
+}
+
+func (fake *FakeWorker) QuarantineCalls(stub func(time.Duration) (bool, error)) {
+	fake.quarantineMutex.Lock()
+	defer fake.quarantineMutex.Unlock()
+	fake.QuarantineStub = stub
+}
+
+func (fake *FakeWorker) QuarantineArgsForCall(i int) time.Duration {
+	fake.quarantineMutex.RLock()
+	defer fake.quarantineMutex.RUnlock()
+	argsForCall := fake.quarantineArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorker) QuarantineReturns(result1 bool, result2 error) {
+	fake.quarantineMutex.Lock()
+	defer fake.quarantineMutex.Unlock()
+	fake.QuarantineStub = nil
+	fake.quarantineReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) QuarantineReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.quarantineMutex.Lock()
+	defer fake.quarantineMutex.Unlock()
+	fake.QuarantineStub = nil
+	if fake.quarantineReturnsOnCall == nil {
+		fake.quarantineReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.quarantineReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) QuarantinedUntil() time.Time {
+	fake.quarantinedUntilMutex.Lock()
+	ret, specificReturn := fake.quarantinedUntilReturnsOnCall[len(fake.quarantinedUntilArgsForCall)]
+	fake.quarantinedUntilArgsForCall = append(fake.quarantinedUntilArgsForCall, struct {
+	}{})
+	fake.recordInvocation("QuarantinedUntil", []interface{}{})
+	fake.quarantinedUntilMutex.Unlock()
+	if fake.QuarantinedUntilStub != nil {
+		return fake.QuarantinedUntilStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.quarantinedUntilReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) QuarantinedUntilCallCount() int {
+	fake.quarantinedUntilMutex.RLock()
+	defer fake.quarantinedUntilMutex.RUnlock()
+	return len(fake.quarantinedUntilArgsForCall)
+}
+
+func (fake *FakeWorker) QuarantinedUntilCalls(stub func() time.Time) {
+	fake.quarantinedUntilMutex.Lock()
+	defer fake.quarantinedUntilMutex.Unlock()
+	fake.QuarantinedUntilStub = stub
+}
+
+func (fake *FakeWorker) QuarantinedUntilReturns(result1 time.Time) {
+	fake.quarantinedUntilMutex.Lock()
+	defer fake.quarantinedUntilMutex.Unlock()
+	fake.QuarantinedUntilStub = nil
+	fake.quarantinedUntilReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeWorker) QuarantinedUntilReturnsOnCall(i int, result1 time.Time) {
+	fake.quarantinedUntilMutex.Lock()
+	defer fake.quarantinedUntilMutex.Unlock()
+	fake.QuarantinedUntilStub = nil
+	if fake.quarantinedUntilReturnsOnCall == nil {
+		fake.quarantinedUntilReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.quarantinedUntilReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeWorker) ReleaseQuarantine() error {
+	fake.releaseQuarantineMutex.Lock()
+	ret, specificReturn := fake.releaseQuarantineReturnsOnCall[len(fake.releaseQuarantineArgsForCall)]
+	fake.releaseQuarantineArgsForCall = append(fake.releaseQuarantineArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ReleaseQuarantine", []interface{}{})
+	fake.releaseQuarantineMutex.Unlock()
+	if fake.ReleaseQuarantineStub != nil {
+		return fake.ReleaseQuarantineStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.releaseQuarantineReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) ReleaseQuarantineCallCount() int {
+	fake.releaseQuarantineMutex.RLock()
+	defer fake.releaseQuarantineMutex.RUnlock()
+	return len(fake.releaseQuarantineArgsForCall)
+}
+
+func (fake *FakeWorker) ReleaseQuarantineCalls(stub func() error) {
+	fake.releaseQuarantineMutex.Lock()
+	defer fake.releaseQuarantineMutex.Unlock()
+	fake.ReleaseQuarantineStub = stub
+}
+
+func (fake *FakeWorker) ReleaseQuarantineReturns(result1 error) {
+	fake.releaseQuarantineMutex.Lock()
+	defer fake.releaseQuarantineMutex.Unlock()
+	fake.ReleaseQuarantineStub = nil
+	fake.releaseQuarantineReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorker) ReleaseQuarantineReturnsOnCall(i int, result1 error) {
+	fake.releaseQuarantineMutex.Lock()
+	defer fake.releaseQuarantineMutex.Unlock()
+	fake.ReleaseQuarantineStub = nil
+	if fake.releaseQuarantineReturnsOnCall == nil {
+		fake.releaseQuarantineReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.releaseQuarantineReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeWorker) Reload() (bool, error) {
 	fake.reloadMutex.Lock()
 	ret, specificReturn := fake.reloadReturnsOnCall[len(fake.reloadArgsForCall)]
@@ -1747,6 +2071,8 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.activeVolumesMutex.RUnlock()
 	fake.baggageclaimURLMutex.RLock()
 	defer fake.baggageclaimURLMutex.RUnlock()
+	fake.capabilitiesMutex.RLock()
+	defer fake.capabilitiesMutex.RUnlock()
 	fake.certsPathMutex.RLock()
 	defer fake.certsPathMutex.RUnlock()
 	fake.createContainerMutex.RLock()
@@ -1765,6 +2091,8 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.hTTPProxyURLMutex.RUnlock()
 	fake.hTTPSProxyURLMutex.RLock()
 	defer fake.hTTPSProxyURLMutex.RUnlock()
+	fake.keyFingerprintMutex.RLock()
+	defer fake.keyFingerprintMutex.RUnlock()
 	fake.landMutex.RLock()
 	defer fake.landMutex.RUnlock()
 	fake.nameMutex.RLock()
@@ -1775,6 +2103,12 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.platformMutex.RUnlock()
 	fake.pruneMutex.RLock()
 	defer fake.pruneMutex.RUnlock()
+	fake.quarantineMutex.RLock()
+	defer fake.quarantineMutex.RUnlock()
+	fake.quarantinedUntilMutex.RLock()
+	defer fake.quarantinedUntilMutex.RUnlock()
+	fake.releaseQuarantineMutex.RLock()
+	defer fake.releaseQuarantineMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
 	fake.resourceCertsMutex.RLock()
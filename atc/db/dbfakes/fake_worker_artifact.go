@@ -49,6 +49,16 @@ type FakeWorkerArtifact struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	RetainedStub        func() bool
+	retainedMutex       sync.RWMutex
+	retainedArgsForCall []struct {
+	}
+	retainedReturns struct {
+		result1 bool
+	}
+	retainedReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	VolumeStub        func(int) (db.CreatedVolume, bool, error)
 	volumeMutex       sync.RWMutex
 	volumeArgsForCall []struct {
@@ -276,6 +286,58 @@ func (fake *FakeWorkerArtifact) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeWorkerArtifact) Retained() bool {
+	fake.retainedMutex.Lock()
+	ret, specificReturn := fake.retainedReturnsOnCall[len(fake.retainedArgsForCall)]
+	fake.retainedArgsForCall = append(fake.retainedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Retained", []interface{}{})
+	fake.retainedMutex.Unlock()
+	if fake.RetainedStub != nil {
+		return fake.RetainedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.retainedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorkerArtifact) RetainedCallCount() int {
+	fake.retainedMutex.RLock()
+	defer fake.retainedMutex.RUnlock()
+	return len(fake.retainedArgsForCall)
+}
+
+func (fake *FakeWorkerArtifact) RetainedCalls(stub func() bool) {
+	fake.retainedMutex.Lock()
+	defer fake.retainedMutex.Unlock()
+	fake.RetainedStub = stub
+}
+
+func (fake *FakeWorkerArtifact) RetainedReturns(result1 bool) {
+	fake.retainedMutex.Lock()
+	defer fake.retainedMutex.Unlock()
+	fake.RetainedStub = nil
+	fake.retainedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorkerArtifact) RetainedReturnsOnCall(i int, result1 bool) {
+	fake.retainedMutex.Lock()
+	defer fake.retainedMutex.Unlock()
+	fake.RetainedStub = nil
+	if fake.retainedReturnsOnCall == nil {
+		fake.retainedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.retainedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeWorkerArtifact) Volume(arg1 int) (db.CreatedVolume, bool, error) {
 	fake.volumeMutex.Lock()
 	ret, specificReturn := fake.volumeReturnsOnCall[len(fake.volumeArgsForCall)]
@@ -353,6 +415,8 @@ func (fake *FakeWorkerArtifact) Invocations() map[string][][]interface{} {
 	defer fake.iDMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.retainedMutex.RLock()
+	defer fake.retainedMutex.RUnlock()
 	fake.volumeMutex.RLock()
 	defer fake.volumeMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -3,14 +3,16 @@ package dbfakes
 
 import (
 	"sync"
+	"time"
 
 	"github.com/concourse/concourse/atc/db"
 )
 
 type FakeWorkerArtifactLifecycle struct {
-	RemoveExpiredArtifactsStub        func() error
+	RemoveExpiredArtifactsStub        func(time.Duration) error
 	removeExpiredArtifactsMutex       sync.RWMutex
 	removeExpiredArtifactsArgsForCall []struct {
+		arg1 time.Duration
 	}
 	removeExpiredArtifactsReturns struct {
 		result1 error
@@ -22,15 +24,16 @@ type FakeWorkerArtifactLifecycle struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeWorkerArtifactLifecycle) RemoveExpiredArtifacts() error {
+func (fake *FakeWorkerArtifactLifecycle) RemoveExpiredArtifacts(arg1 time.Duration) error {
 	fake.removeExpiredArtifactsMutex.Lock()
 	ret, specificReturn := fake.removeExpiredArtifactsReturnsOnCall[len(fake.removeExpiredArtifactsArgsForCall)]
 	fake.removeExpiredArtifactsArgsForCall = append(fake.removeExpiredArtifactsArgsForCall, struct {
-	}{})
-	fake.recordInvocation("RemoveExpiredArtifacts", []interface{}{})
+		arg1 time.Duration
+	}{arg1})
+	fake.recordInvocation("RemoveExpiredArtifacts", []interface{}{arg1})
 	fake.removeExpiredArtifactsMutex.Unlock()
 	if fake.RemoveExpiredArtifactsStub != nil {
-		return fake.RemoveExpiredArtifactsStub()
+		return fake.RemoveExpiredArtifactsStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -45,12 +48,19 @@ func (fake *FakeWorkerArtifactLifecycle) RemoveExpiredArtifactsCallCount() int {
 	return len(fake.removeExpiredArtifactsArgsForCall)
 }
 
-func (fake *FakeWorkerArtifactLifecycle) RemoveExpiredArtifactsCalls(stub func() error) {
+func (fake *FakeWorkerArtifactLifecycle) RemoveExpiredArtifactsCalls(stub func(time.Duration) error) {
 	fake.removeExpiredArtifactsMutex.Lock()
 	defer fake.removeExpiredArtifactsMutex.Unlock()
 	fake.RemoveExpiredArtifactsStub = stub
 }
 
+func (fake *FakeWorkerArtifactLifecycle) RemoveExpiredArtifactsArgsForCall(i int) time.Duration {
+	fake.removeExpiredArtifactsMutex.RLock()
+	defer fake.removeExpiredArtifactsMutex.RUnlock()
+	argsForCall := fake.removeExpiredArtifactsArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeWorkerArtifactLifecycle) RemoveExpiredArtifactsReturns(result1 error) {
 	fake.removeExpiredArtifactsMutex.Lock()
 	defer fake.removeExpiredArtifactsMutex.Unlock()
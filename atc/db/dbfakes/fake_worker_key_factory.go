@@ -0,0 +1,569 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeWorkerKeyFactory struct {
+	AllKeysStub        func() ([]db.WorkerKey, error)
+	allKeysMutex       sync.RWMutex
+	allKeysArgsForCall []struct {
+	}
+	allKeysReturns struct {
+		result1 []db.WorkerKey
+		result2 error
+	}
+	allKeysReturnsOnCall map[int]struct {
+		result1 []db.WorkerKey
+		result2 error
+	}
+	CreateGlobalKeyStub        func(db.WorkerKeySpec) (db.WorkerKey, error)
+	createGlobalKeyMutex       sync.RWMutex
+	createGlobalKeyArgsForCall []struct {
+		arg1 db.WorkerKeySpec
+	}
+	createGlobalKeyReturns struct {
+		result1 db.WorkerKey
+		result2 error
+	}
+	createGlobalKeyReturnsOnCall map[int]struct {
+		result1 db.WorkerKey
+		result2 error
+	}
+	CreateTeamKeyStub        func(int, db.WorkerKeySpec) (db.WorkerKey, error)
+	createTeamKeyMutex       sync.RWMutex
+	createTeamKeyArgsForCall []struct {
+		arg1 int
+		arg2 db.WorkerKeySpec
+	}
+	createTeamKeyReturns struct {
+		result1 db.WorkerKey
+		result2 error
+	}
+	createTeamKeyReturnsOnCall map[int]struct {
+		result1 db.WorkerKey
+		result2 error
+	}
+	DeleteGlobalKeyStub        func(string) (bool, error)
+	deleteGlobalKeyMutex       sync.RWMutex
+	deleteGlobalKeyArgsForCall []struct {
+		arg1 string
+	}
+	deleteGlobalKeyReturns struct {
+		result1 bool
+		result2 error
+	}
+	deleteGlobalKeyReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	DeleteTeamKeyStub        func(int, string) (bool, error)
+	deleteTeamKeyMutex       sync.RWMutex
+	deleteTeamKeyArgsForCall []struct {
+		arg1 int
+		arg2 string
+	}
+	deleteTeamKeyReturns struct {
+		result1 bool
+		result2 error
+	}
+	deleteTeamKeyReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	GlobalKeysStub        func() ([]db.WorkerKey, error)
+	globalKeysMutex       sync.RWMutex
+	globalKeysArgsForCall []struct {
+	}
+	globalKeysReturns struct {
+		result1 []db.WorkerKey
+		result2 error
+	}
+	globalKeysReturnsOnCall map[int]struct {
+		result1 []db.WorkerKey
+		result2 error
+	}
+	TeamKeysStub        func(int) ([]db.WorkerKey, error)
+	teamKeysMutex       sync.RWMutex
+	teamKeysArgsForCall []struct {
+		arg1 int
+	}
+	teamKeysReturns struct {
+		result1 []db.WorkerKey
+		result2 error
+	}
+	teamKeysReturnsOnCall map[int]struct {
+		result1 []db.WorkerKey
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeWorkerKeyFactory) AllKeys() ([]db.WorkerKey, error) {
+	fake.allKeysMutex.Lock()
+	ret, specificReturn := fake.allKeysReturnsOnCall[len(fake.allKeysArgsForCall)]
+	fake.allKeysArgsForCall = append(fake.allKeysArgsForCall, struct {
+	}{})
+	fake.recordInvocation("AllKeys", []interface{}{})
+	fake.allKeysMutex.Unlock()
+	if fake.AllKeysStub != nil {
+		return fake.AllKeysStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.allKeysReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerKeyFactory) AllKeysCallCount() int {
+	fake.allKeysMutex.RLock()
+	defer fake.allKeysMutex.RUnlock()
+	return len(fake.allKeysArgsForCall)
+}
+
+func (fake *FakeWorkerKeyFactory) AllKeysCalls(stub func() ([]db.WorkerKey, error)) {
+	fake.allKeysMutex.Lock()
+	defer fake.allKeysMutex.Unlock()
+	fake.AllKeysStub = stub
+}
+
+func (fake *FakeWorkerKeyFactory) AllKeysReturns(result1 []db.WorkerKey, result2 error) {
+	fake.allKeysMutex.Lock()
+	defer fake.allKeysMutex.Unlock()
+	fake.AllKeysStub = nil
+	fake.allKeysReturns = struct {
+		result1 []db.WorkerKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerKeyFactory) AllKeysReturnsOnCall(i int, result1 []db.WorkerKey, result2 error) {
+	fake.allKeysMutex.Lock()
+	defer fake.allKeysMutex.Unlock()
+	fake.AllKeysStub = nil
+	if fake.allKeysReturnsOnCall == nil {
+		fake.allKeysReturnsOnCall = make(map[int]struct {
+			result1 []db.WorkerKey
+			result2 error
+		})
+	}
+	fake.allKeysReturnsOnCall[i] = struct {
+		result1 []db.WorkerKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerKeyFactory) CreateGlobalKey(arg1 db.WorkerKeySpec) (db.WorkerKey, error) {
+	fake.createGlobalKeyMutex.Lock()
+	ret, specificReturn := fake.createGlobalKeyReturnsOnCall[len(fake.createGlobalKeyArgsForCall)]
+	fake.createGlobalKeyArgsForCall = append(fake.createGlobalKeyArgsForCall, struct {
+		arg1 db.WorkerKeySpec
+	}{arg1})
+	fake.recordInvocation("CreateGlobalKey", []interface{}{arg1})
+	fake.createGlobalKeyMutex.Unlock()
+	if fake.CreateGlobalKeyStub != nil {
+		return fake.CreateGlobalKeyStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createGlobalKeyReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerKeyFactory) CreateGlobalKeyCallCount() int {
+	fake.createGlobalKeyMutex.RLock()
+	defer fake.createGlobalKeyMutex.RUnlock()
+	return len(fake.createGlobalKeyArgsForCall)
+}
+
+func (fake *FakeWorkerKeyFactory) CreateGlobalKeyCalls(stub func(db.WorkerKeySpec) (db.WorkerKey, error)) {
+	fake.createGlobalKeyMutex.Lock()
+	defer fake.createGlobalKeyMutex.Unlock()
+	fake.CreateGlobalKeyStub = stub
+}
+
+func (fake *FakeWorkerKeyFactory) CreateGlobalKeyArgsForCall(i int) db.WorkerKeySpec {
+	fake.createGlobalKeyMutex.RLock()
+	defer fake.createGlobalKeyMutex.RUnlock()
+	argsForCall := fake.createGlobalKeyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerKeyFactory) CreateGlobalKeyReturns(result1 db.WorkerKey, result2 error) {
+	fake.createGlobalKeyMutex.Lock()
+	defer fake.createGlobalKeyMutex.Unlock()
+	fake.CreateGlobalKeyStub = nil
+	fake.createGlobalKeyReturns = struct {
+		result1 db.WorkerKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerKeyFactory) CreateGlobalKeyReturnsOnCall(i int, result1 db.WorkerKey, result2 error) {
+	fake.createGlobalKeyMutex.Lock()
+	defer fake.createGlobalKeyMutex.Unlock()
+	fake.CreateGlobalKeyStub = nil
+	if fake.createGlobalKeyReturnsOnCall == nil {
+		fake.createGlobalKeyReturnsOnCall = make(map[int]struct {
+			result1 db.WorkerKey
+			result2 error
+		})
+	}
+	fake.createGlobalKeyReturnsOnCall[i] = struct {
+		result1 db.WorkerKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerKeyFactory) CreateTeamKey(arg1 int, arg2 db.WorkerKeySpec) (db.WorkerKey, error) {
+	fake.createTeamKeyMutex.Lock()
+	ret, specificReturn := fake.createTeamKeyReturnsOnCall[len(fake.createTeamKeyArgsForCall)]
+	fake.createTeamKeyArgsForCall = append(fake.createTeamKeyArgsForCall, struct {
+		arg1 int
+		arg2 db.WorkerKeySpec
+	}{arg1, arg2})
+	fake.recordInvocation("CreateTeamKey", []interface{}{arg1, arg2})
+	fake.createTeamKeyMutex.Unlock()
+	if fake.CreateTeamKeyStub != nil {
+		return fake.CreateTeamKeyStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createTeamKeyReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerKeyFactory) CreateTeamKeyCallCount() int {
+	fake.createTeamKeyMutex.RLock()
+	defer fake.createTeamKeyMutex.RUnlock()
+	return len(fake.createTeamKeyArgsForCall)
+}
+
+func (fake *FakeWorkerKeyFactory) CreateTeamKeyCalls(stub func(int, db.WorkerKeySpec) (db.WorkerKey, error)) {
+	fake.createTeamKeyMutex.Lock()
+	defer fake.createTeamKeyMutex.Unlock()
+	fake.CreateTeamKeyStub = stub
+}
+
+func (fake *FakeWorkerKeyFactory) CreateTeamKeyArgsForCall(i int) (int, db.WorkerKeySpec) {
+	fake.createTeamKeyMutex.RLock()
+	defer fake.createTeamKeyMutex.RUnlock()
+	argsForCall := fake.createTeamKeyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerKeyFactory) CreateTeamKeyReturns(result1 db.WorkerKey, result2 error) {
+	fake.createTeamKeyMutex.Lock()
+	defer fake.createTeamKeyMutex.Unlock()
+	fake.CreateTeamKeyStub = nil
+	fake.createTeamKeyReturns = struct {
+		result1 db.WorkerKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerKeyFactory) CreateTeamKeyReturnsOnCall(i int, result1 db.WorkerKey, result2 error) {
+	fake.createTeamKeyMutex.Lock()
+	defer fake.createTeamKeyMutex.Unlock()
+	fake.CreateTeamKeyStub = nil
+	if fake.createTeamKeyReturnsOnCall == nil {
+		fake.createTeamKeyReturnsOnCall = make(map[int]struct {
+			result1 db.WorkerKey
+			result2 error
+		})
+	}
+	fake.createTeamKeyReturnsOnCall[i] = struct {
+		result1 db.WorkerKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerKeyFactory) DeleteGlobalKey(arg1 string) (bool, error) {
+	fake.deleteGlobalKeyMutex.Lock()
+	ret, specificReturn := fake.deleteGlobalKeyReturnsOnCall[len(fake.deleteGlobalKeyArgsForCall)]
+	fake.deleteGlobalKeyArgsForCall = append(fake.deleteGlobalKeyArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("DeleteGlobalKey", []interface{}{arg1})
+	fake.deleteGlobalKeyMutex.Unlock()
+	if fake.DeleteGlobalKeyStub != nil {
+		return fake.DeleteGlobalKeyStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.deleteGlobalKeyReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerKeyFactory) DeleteGlobalKeyCallCount() int {
+	fake.deleteGlobalKeyMutex.RLock()
+	defer fake.deleteGlobalKeyMutex.RUnlock()
+	return len(fake.deleteGlobalKeyArgsForCall)
+}
+
+func (fake *FakeWorkerKeyFactory) DeleteGlobalKeyCalls(stub func(string) (bool, error)) {
+	fake.deleteGlobalKeyMutex.Lock()
+	defer fake.deleteGlobalKeyMutex.Unlock()
+	fake.DeleteGlobalKeyStub = stub
+}
+
+func (fake *FakeWorkerKeyFactory) DeleteGlobalKeyArgsForCall(i int) string {
+	fake.deleteGlobalKeyMutex.RLock()
+	defer fake.deleteGlobalKeyMutex.RUnlock()
+	argsForCall := fake.deleteGlobalKeyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerKeyFactory) DeleteGlobalKeyReturns(result1 bool, result2 error) {
+	fake.deleteGlobalKeyMutex.Lock()
+	defer fake.deleteGlobalKeyMutex.Unlock()
+	fake.DeleteGlobalKeyStub = nil
+	fake.deleteGlobalKeyReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerKeyFactory) DeleteGlobalKeyReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.deleteGlobalKeyMutex.Lock()
+	defer fake.deleteGlobalKeyMutex.Unlock()
+	fake.DeleteGlobalKeyStub = nil
+	if fake.deleteGlobalKeyReturnsOnCall == nil {
+		fake.deleteGlobalKeyReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.deleteGlobalKeyReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerKeyFactory) DeleteTeamKey(arg1 int, arg2 string) (bool, error) {
+	fake.deleteTeamKeyMutex.Lock()
+	ret, specificReturn := fake.deleteTeamKeyReturnsOnCall[len(fake.deleteTeamKeyArgsForCall)]
+	fake.deleteTeamKeyArgsForCall = append(fake.deleteTeamKeyArgsForCall, struct {
+		arg1 int
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("DeleteTeamKey", []interface{}{arg1, arg2})
+	fake.deleteTeamKeyMutex.Unlock()
+	if fake.DeleteTeamKeyStub != nil {
+		return fake.DeleteTeamKeyStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.deleteTeamKeyReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerKeyFactory) DeleteTeamKeyCallCount() int {
+	fake.deleteTeamKeyMutex.RLock()
+	defer fake.deleteTeamKeyMutex.RUnlock()
+	return len(fake.deleteTeamKeyArgsForCall)
+}
+
+func (fake *FakeWorkerKeyFactory) DeleteTeamKeyCalls(stub func(int, string) (bool, error)) {
+	fake.deleteTeamKeyMutex.Lock()
+	defer fake.deleteTeamKeyMutex.Unlock()
+	fake.DeleteTeamKeyStub = stub
+}
+
+func (fake *FakeWorkerKeyFactory) DeleteTeamKeyArgsForCall(i int) (int, string) {
+	fake.deleteTeamKeyMutex.RLock()
+	defer fake.deleteTeamKeyMutex.RUnlock()
+	argsForCall := fake.deleteTeamKeyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerKeyFactory) DeleteTeamKeyReturns(result1 bool, result2 error) {
+	fake.deleteTeamKeyMutex.Lock()
+	defer fake.deleteTeamKeyMutex.Unlock()
+	fake.DeleteTeamKeyStub = nil
+	fake.deleteTeamKeyReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerKeyFactory) DeleteTeamKeyReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.deleteTeamKeyMutex.Lock()
+	defer fake.deleteTeamKeyMutex.Unlock()
+	fake.DeleteTeamKeyStub = nil
+	if fake.deleteTeamKeyReturnsOnCall == nil {
+		fake.deleteTeamKeyReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.deleteTeamKeyReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerKeyFactory) GlobalKeys() ([]db.WorkerKey, error) {
+	fake.globalKeysMutex.Lock()
+	ret, specificReturn := fake.globalKeysReturnsOnCall[len(fake.globalKeysArgsForCall)]
+	fake.globalKeysArgsForCall = append(fake.globalKeysArgsForCall, struct {
+	}{})
+	fake.recordInvocation("GlobalKeys", []interface{}{})
+	fake.globalKeysMutex.Unlock()
+	if fake.GlobalKeysStub != nil {
+		return fake.GlobalKeysStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.globalKeysReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerKeyFactory) GlobalKeysCallCount() int {
+	fake.globalKeysMutex.RLock()
+	defer fake.globalKeysMutex.RUnlock()
+	return len(fake.globalKeysArgsForCall)
+}
+
+func (fake *FakeWorkerKeyFactory) GlobalKeysCalls(stub func() ([]db.WorkerKey, error)) {
+	fake.globalKeysMutex.Lock()
+	defer fake.globalKeysMutex.Unlock()
+	fake.GlobalKeysStub = stub
+}
+
+func (fake *FakeWorkerKeyFactory) GlobalKeysReturns(result1 []db.WorkerKey, result2 error) {
+	fake.globalKeysMutex.Lock()
+	defer fake.globalKeysMutex.Unlock()
+	fake.GlobalKeysStub = nil
+	fake.globalKeysReturns = struct {
+		result1 []db.WorkerKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerKeyFactory) GlobalKeysReturnsOnCall(i int, result1 []db.WorkerKey, result2 error) {
+	fake.globalKeysMutex.Lock()
+	defer fake.globalKeysMutex.Unlock()
+	fake.GlobalKeysStub = nil
+	if fake.globalKeysReturnsOnCall == nil {
+		fake.globalKeysReturnsOnCall = make(map[int]struct {
+			result1 []db.WorkerKey
+			result2 error
+		})
+	}
+	fake.globalKeysReturnsOnCall[i] = struct {
+		result1 []db.WorkerKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerKeyFactory) TeamKeys(arg1 int) ([]db.WorkerKey, error) {
+	fake.teamKeysMutex.Lock()
+	ret, specificReturn := fake.teamKeysReturnsOnCall[len(fake.teamKeysArgsForCall)]
+	fake.teamKeysArgsForCall = append(fake.teamKeysArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("TeamKeys", []interface{}{arg1})
+	fake.teamKeysMutex.Unlock()
+	if fake.TeamKeysStub != nil {
+		return fake.TeamKeysStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.teamKeysReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerKeyFactory) TeamKeysCallCount() int {
+	fake.teamKeysMutex.RLock()
+	defer fake.teamKeysMutex.RUnlock()
+	return len(fake.teamKeysArgsForCall)
+}
+
+func (fake *FakeWorkerKeyFactory) TeamKeysCalls(stub func(int) ([]db.WorkerKey, error)) {
+	fake.teamKeysMutex.Lock()
+	defer fake.teamKeysMutex.Unlock()
+	fake.TeamKeysStub = stub
+}
+
+func (fake *FakeWorkerKeyFactory) TeamKeysArgsForCall(i int) int {
+	fake.teamKeysMutex.RLock()
+	defer fake.teamKeysMutex.RUnlock()
+	argsForCall := fake.teamKeysArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerKeyFactory) TeamKeysReturns(result1 []db.WorkerKey, result2 error) {
+	fake.teamKeysMutex.Lock()
+	defer fake.teamKeysMutex.Unlock()
+	fake.TeamKeysStub = nil
+	fake.teamKeysReturns = struct {
+		result1 []db.WorkerKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerKeyFactory) TeamKeysReturnsOnCall(i int, result1 []db.WorkerKey, result2 error) {
+	fake.teamKeysMutex.Lock()
+	defer fake.teamKeysMutex.Unlock()
+	fake.TeamKeysStub = nil
+	if fake.teamKeysReturnsOnCall == nil {
+		fake.teamKeysReturnsOnCall = make(map[int]struct {
+			result1 []db.WorkerKey
+			result2 error
+		})
+	}
+	fake.teamKeysReturnsOnCall[i] = struct {
+		result1 []db.WorkerKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerKeyFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.allKeysMutex.RLock()
+	defer fake.allKeysMutex.RUnlock()
+	fake.createGlobalKeyMutex.RLock()
+	defer fake.createGlobalKeyMutex.RUnlock()
+	fake.createTeamKeyMutex.RLock()
+	defer fake.createTeamKeyMutex.RUnlock()
+	fake.deleteGlobalKeyMutex.RLock()
+	defer fake.deleteGlobalKeyMutex.RUnlock()
+	fake.deleteTeamKeyMutex.RLock()
+	defer fake.deleteTeamKeyMutex.RUnlock()
+	fake.globalKeysMutex.RLock()
+	defer fake.globalKeysMutex.RUnlock()
+	fake.teamKeysMutex.RLock()
+	defer fake.teamKeysMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeWorkerKeyFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.WorkerKeyFactory = new(FakeWorkerKeyFactory)
@@ -56,6 +56,18 @@ type FakeWorkerLifecycle struct {
 		result1 []string
 		result2 error
 	}
+	ReleaseExpiredQuarantinedWorkersStub        func() ([]string, error)
+	releaseExpiredQuarantinedWorkersMutex       sync.RWMutex
+	releaseExpiredQuarantinedWorkersArgsForCall []struct {
+	}
+	releaseExpiredQuarantinedWorkersReturns struct {
+		result1 []string
+		result2 error
+	}
+	releaseExpiredQuarantinedWorkersReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
 	StallUnresponsiveWorkersStub        func() ([]string, error)
 	stallUnresponsiveWorkersMutex       sync.RWMutex
 	stallUnresponsiveWorkersArgsForCall []struct {
@@ -292,6 +304,61 @@ func (fake *FakeWorkerLifecycle) LandFinishedLandingWorkersReturnsOnCall(i int,
 	}{result1, result2}
 }
 
+func (fake *FakeWorkerLifecycle) ReleaseExpiredQuarantinedWorkers() ([]string, error) {
+	fake.releaseExpiredQuarantinedWorkersMutex.Lock()
+	ret, specificReturn := fake.releaseExpiredQuarantinedWorkersReturnsOnCall[len(fake.releaseExpiredQuarantinedWorkersArgsForCall)]
+	fake.releaseExpiredQuarantinedWorkersArgsForCall = append(fake.releaseExpiredQuarantinedWorkersArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ReleaseExpiredQuarantinedWorkers", []interface{}{})
+	fake.releaseExpiredQuarantinedWorkersMutex.Unlock()
+	if fake.ReleaseExpiredQuarantinedWorkersStub != nil {
+		return fake.ReleaseExpiredQuarantinedWorkersStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.releaseExpiredQuarantinedWorkersReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) ReleaseExpiredQuarantinedWorkersCallCount() int {
+	fake.releaseExpiredQuarantinedWorkersMutex.RLock()
+	defer fake.releaseExpiredQuarantinedWorkersMutex.RUnlock()
+	return len(fake.releaseExpiredQuarantinedWorkersArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) ReleaseExpiredQuarantinedWorkersCalls(stub func() ([]string, error)) {
+	fake.releaseExpiredQuarantinedWorkersMutex.Lock()
+	defer fake.releaseExpiredQuarantinedWorkersMutex.Unlock()
+	fake.ReleaseExpiredQuarantinedWorkersStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) ReleaseExpiredQuarantinedWorkersReturns(result1 []string, result2 error) {
+	fake.releaseExpiredQuarantinedWorkersMutex.Lock()
+	defer fake.releaseExpiredQuarantinedWorkersMutex.Unlock()
+	fake.ReleaseExpiredQuarantinedWorkersStub = nil
+	fake.releaseExpiredQuarantinedWorkersReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) ReleaseExpiredQuarantinedWorkersReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.releaseExpiredQuarantinedWorkersMutex.Lock()
+	defer fake.releaseExpiredQuarantinedWorkersMutex.Unlock()
+	fake.ReleaseExpiredQuarantinedWorkersStub = nil
+	if fake.releaseExpiredQuarantinedWorkersReturnsOnCall == nil {
+		fake.releaseExpiredQuarantinedWorkersReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.releaseExpiredQuarantinedWorkersReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkers() ([]string, error) {
 	fake.stallUnresponsiveWorkersMutex.Lock()
 	ret, specificReturn := fake.stallUnresponsiveWorkersReturnsOnCall[len(fake.stallUnresponsiveWorkersArgsForCall)]
@@ -358,6 +425,8 @@ func (fake *FakeWorkerLifecycle) Invocations() map[string][][]interface{} {
 	defer fake.getWorkerStateByNameMutex.RUnlock()
 	fake.landFinishedLandingWorkersMutex.RLock()
 	defer fake.landFinishedLandingWorkersMutex.RUnlock()
+	fake.releaseExpiredQuarantinedWorkersMutex.RLock()
+	defer fake.releaseExpiredQuarantinedWorkersMutex.RUnlock()
 	fake.stallUnresponsiveWorkersMutex.RLock()
 	defer fake.stallUnresponsiveWorkersMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -0,0 +1,271 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+)
+
+// HijackSession is a recorded hijack of a container by a user.
+type HijackSession struct {
+	ID       int
+	TeamID   int
+	TeamName string
+
+	ContainerHandle string
+	WorkerName      string
+	Username        string
+
+	Path        string
+	Args        []string
+	ProcessUser string
+
+	Width  int
+	Height int
+
+	StartedAt  time.Time
+	EndedAt    time.Time
+	ExitStatus *int
+}
+
+const (
+	HijackSessionEventInput  = "i"
+	HijackSessionEventOutput = "o"
+	HijackSessionEventResize = "r"
+)
+
+// HijackSessionEvent is input sent to or output received from the hijacked
+// process, or a change of the size of its terminal, at some time after the
+// session started.
+type HijackSessionEvent struct {
+	Elapsed time.Duration
+	Type    string
+	Data    []byte
+}
+
+//go:generate counterfeiter . HijackSessionFactory
+
+type HijackSessionFactory interface {
+	CreateHijackSession(session HijackSession) (HijackSession, error)
+	SaveHijackSessionEvents(sessionID int, events []HijackSessionEvent) error
+	FinishHijackSession(sessionID int, exitStatus *int) error
+
+	HijackSessions(teamID int) ([]HijackSession, error)
+	HijackSession(teamID int, sessionID int) (HijackSession, bool, error)
+	HijackSessionEvents(sessionID int) ([]HijackSessionEvent, error)
+
+	DeleteHijackSessionsBefore(cutoff time.Time) error
+}
+
+var hijackSessionsQuery = psql.Select("s.id, s.team_id, t.name, s.container_handle, s.worker_name, s.username, s.path, s.args, s.process_user, s.width, s.height, s.started_at, s.ended_at, s.exit_status").
+	From("hijack_sessions s").
+	Join("teams t ON t.id = s.team_id")
+
+type hijackSessionFactory struct {
+	conn Conn
+}
+
+func NewHijackSessionFactory(conn Conn) HijackSessionFactory {
+	return &hijackSessionFactory{
+		conn: conn,
+	}
+}
+
+func (f *hijackSessionFactory) CreateHijackSession(session HijackSession) (HijackSession, error) {
+	args := session.Args
+	if args == nil {
+		args = []string{}
+	}
+
+	err := psql.Insert("hijack_sessions").
+		Columns("team_id", "container_handle", "worker_name", "username", "path", "args", "process_user", "width", "height").
+		Values(session.TeamID, session.ContainerHandle, session.WorkerName, session.Username, session.Path, pq.Array(args), session.ProcessUser, session.Width, session.Height).
+		Suffix("RETURNING id, started_at").
+		RunWith(f.conn).
+		QueryRow().
+		Scan(&session.ID, &session.StartedAt)
+	if err != nil {
+		return HijackSession{}, err
+	}
+
+	return session, nil
+}
+
+func (f *hijackSessionFactory) SaveHijackSessionEvents(sessionID int, events []HijackSessionEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	query := psql.Insert("hijack_session_events").
+		Columns("hijack_session_id", "elapsed", "type", "data", "nonce")
+
+	es := f.conn.EncryptionStrategy()
+	for _, event := range events {
+		encryptedData, nonce, err := es.Encrypt(event.Data)
+		if err != nil {
+			return err
+		}
+
+		query = query.Values(sessionID, event.Elapsed.Seconds(), event.Type, []byte(encryptedData), nonce)
+	}
+
+	_, err := query.RunWith(f.conn).Exec()
+	return err
+}
+
+func (f *hijackSessionFactory) FinishHijackSession(sessionID int, exitStatus *int) error {
+	_, err := psql.Update("hijack_sessions").
+		Set("ended_at", sq.Expr("NOW()")).
+		Set("exit_status", exitStatus).
+		Where(sq.Eq{"id": sessionID}).
+		RunWith(f.conn).
+		Exec()
+
+	return err
+}
+
+// HijackSessions returns the sessions of the team, newest first.
+func (f *hijackSessionFactory) HijackSessions(teamID int) ([]HijackSession, error) {
+	rows, err := hijackSessionsQuery.
+		Where(sq.Eq{"s.team_id": teamID}).
+		OrderBy("s.id DESC").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	sessions := []HijackSession{}
+	for rows.Next() {
+		session, err := scanHijackSession(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+func (f *hijackSessionFactory) HijackSession(teamID int, sessionID int) (HijackSession, bool, error) {
+	row := hijackSessionsQuery.
+		Where(sq.Eq{
+			"s.team_id": teamID,
+			"s.id":      sessionID,
+		}).
+		RunWith(f.conn).
+		QueryRow()
+
+	session, err := scanHijackSession(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return HijackSession{}, false, nil
+		}
+
+		return HijackSession{}, false, err
+	}
+
+	return session, true, nil
+}
+
+// HijackSessionEvents returns the events of the session in the order they
+// were recorded.
+func (f *hijackSessionFactory) HijackSessionEvents(sessionID int) ([]HijackSessionEvent, error) {
+	rows, err := psql.Select("elapsed, type, data, nonce").
+		From("hijack_session_events").
+		Where(sq.Eq{"hijack_session_id": sessionID}).
+		OrderBy("id ASC").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	es := f.conn.EncryptionStrategy()
+
+	events := []HijackSessionEvent{}
+	for rows.Next() {
+		var (
+			event   HijackSessionEvent
+			elapsed float64
+			data    []byte
+			nonce   sql.NullString
+		)
+
+		err = rows.Scan(&elapsed, &event.Type, &data, &nonce)
+		if err != nil {
+			return nil, err
+		}
+
+		event.Data = data
+		if nonce.Valid {
+			event.Data, err = es.Decrypt(string(data), &nonce.String)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		event.Elapsed = time.Duration(elapsed * float64(time.Second))
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// DeleteHijackSessionsBefore removes the sessions started before the cutoff
+// along with their events.
+func (f *hijackSessionFactory) DeleteHijackSessionsBefore(cutoff time.Time) error {
+	_, err := psql.Delete("hijack_sessions").
+		Where(sq.Lt{"started_at": cutoff}).
+		RunWith(f.conn).
+		Exec()
+
+	return err
+}
+
+func scanHijackSession(row scannable) (HijackSession, error) {
+	var (
+		session    HijackSession
+		endedAt    pq.NullTime
+		exitStatus sql.NullInt64
+	)
+
+	err := row.Scan(
+		&session.ID,
+		&session.TeamID,
+		&session.TeamName,
+		&session.ContainerHandle,
+		&session.WorkerName,
+		&session.Username,
+		&session.Path,
+		pq.Array(&session.Args),
+		&session.ProcessUser,
+		&session.Width,
+		&session.Height,
+		&session.StartedAt,
+		&endedAt,
+		&exitStatus,
+	)
+	if err != nil {
+		return HijackSession{}, err
+	}
+
+	if endedAt.Valid {
+		session.EndedAt = endedAt.Time
+	}
+
+	if exitStatus.Valid {
+		status := int(exitStatus.Int64)
+		session.ExitStatus = &status
+	}
+
+	return session, nil
+}
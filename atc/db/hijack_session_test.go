@@ -0,0 +1,143 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HijackSessionFactory", func() {
+	var (
+		hijackSessionFactory db.HijackSessionFactory
+		session              db.HijackSession
+	)
+
+	BeforeEach(func() {
+		hijackSessionFactory = db.NewHijackSessionFactory(dbConn)
+
+		var err error
+		session, err = hijackSessionFactory.CreateHijackSession(db.HijackSession{
+			TeamID:          defaultTeam.ID(),
+			ContainerHandle: "some-handle",
+			WorkerName:      "some-worker",
+			Username:        "some-user",
+			Path:            "bash",
+			Args:            []string{"-l"},
+			ProcessUser:     "root",
+			Width:           80,
+			Height:          24,
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("creates the session", func() {
+		Expect(session.ID).ToNot(BeZero())
+		Expect(session.StartedAt).To(BeTemporally("~", time.Now(), time.Minute))
+
+		found, exists, err := hijackSessionFactory.HijackSession(defaultTeam.ID(), session.ID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exists).To(BeTrue())
+		Expect(found.TeamName).To(Equal(defaultTeam.Name()))
+		Expect(found.ContainerHandle).To(Equal("some-handle"))
+		Expect(found.WorkerName).To(Equal("some-worker"))
+		Expect(found.Username).To(Equal("some-user"))
+		Expect(found.Path).To(Equal("bash"))
+		Expect(found.Args).To(Equal([]string{"-l"}))
+		Expect(found.ProcessUser).To(Equal("root"))
+		Expect(found.Width).To(Equal(80))
+		Expect(found.Height).To(Equal(24))
+		Expect(found.EndedAt.IsZero()).To(BeTrue())
+		Expect(found.ExitStatus).To(BeNil())
+	})
+
+	It("does not find the session in other teams", func() {
+		otherTeam, err := teamFactory.CreateTeam(atc.Team{Name: "some-other-team"})
+		Expect(err).ToNot(HaveOccurred())
+
+		_, exists, err := hijackSessionFactory.HijackSession(otherTeam.ID(), session.ID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exists).To(BeFalse())
+
+		sessions, err := hijackSessionFactory.HijackSessions(otherTeam.ID())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sessions).To(BeEmpty())
+	})
+
+	It("lists the sessions of the team, newest first", func() {
+		newer, err := hijackSessionFactory.CreateHijackSession(db.HijackSession{
+			TeamID:          defaultTeam.ID(),
+			ContainerHandle: "other-handle",
+			WorkerName:      "some-worker",
+			Username:        "some-user",
+			Path:            "sh",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		sessions, err := hijackSessionFactory.HijackSessions(defaultTeam.ID())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sessions).To(HaveLen(2))
+		Expect(sessions[0].ID).To(Equal(newer.ID))
+		Expect(sessions[0].Args).To(BeEmpty())
+		Expect(sessions[1].ID).To(Equal(session.ID))
+	})
+
+	It("saves events in order", func() {
+		err := hijackSessionFactory.SaveHijackSessionEvents(session.ID, []db.HijackSessionEvent{
+			{Elapsed: 500 * time.Millisecond, Type: db.HijackSessionEventInput, Data: []byte("ls\n")},
+			{Elapsed: time.Second, Type: db.HijackSessionEventOutput, Data: []byte("some-file\n")},
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		err = hijackSessionFactory.SaveHijackSessionEvents(session.ID, []db.HijackSessionEvent{
+			{Elapsed: 2 * time.Second, Type: db.HijackSessionEventResize, Data: []byte("100x30")},
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		events, err := hijackSessionFactory.HijackSessionEvents(session.ID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(events).To(Equal([]db.HijackSessionEvent{
+			{Elapsed: 500 * time.Millisecond, Type: db.HijackSessionEventInput, Data: []byte("ls\n")},
+			{Elapsed: time.Second, Type: db.HijackSessionEventOutput, Data: []byte("some-file\n")},
+			{Elapsed: 2 * time.Second, Type: db.HijackSessionEventResize, Data: []byte("100x30")},
+		}))
+	})
+
+	It("finishes the session", func() {
+		exitStatus := 3
+		err := hijackSessionFactory.FinishHijackSession(session.ID, &exitStatus)
+		Expect(err).ToNot(HaveOccurred())
+
+		found, _, err := hijackSessionFactory.HijackSession(defaultTeam.ID(), session.ID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found.EndedAt).To(BeTemporally("~", time.Now(), time.Minute))
+		Expect(found.ExitStatus).To(Equal(&exitStatus))
+	})
+
+	It("deletes sessions started before the cutoff along with their events", func() {
+		err := hijackSessionFactory.SaveHijackSessionEvents(session.ID, []db.HijackSessionEvent{
+			{Type: db.HijackSessionEventOutput, Data: []byte("hello")},
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		err = hijackSessionFactory.DeleteHijackSessionsBefore(time.Now().Add(-time.Hour))
+		Expect(err).ToNot(HaveOccurred())
+
+		_, exists, err := hijackSessionFactory.HijackSession(defaultTeam.ID(), session.ID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exists).To(BeTrue())
+
+		err = hijackSessionFactory.DeleteHijackSessionsBefore(time.Now().Add(time.Hour))
+		Expect(err).ToNot(HaveOccurred())
+
+		_, exists, err = hijackSessionFactory.HijackSession(defaultTeam.ID(), session.ID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exists).To(BeFalse())
+
+		events, err := hijackSessionFactory.HijackSessionEvents(session.ID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(events).To(BeEmpty())
+	})
+})
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc"
@@ -38,6 +39,7 @@ type Job interface {
 	Build(name string) (Build, bool, error)
 	FinishedAndNextBuild() (Build, Build, error)
 	UpdateFirstLoggedBuildID(newFirstLoggedBuildID int) error
+	DeleteExpiredBuilds(retention atc.BuildRetention, keepPassedVersions bool, onlyDrained bool, limit int) (int, error)
 	EnsurePendingBuildExists() error
 	GetPendingBuilds() ([]Build, error)
 
@@ -55,9 +57,13 @@ type Job interface {
 
 	SetHasNewInputs(bool) error
 	HasNewInputs() bool
+
+	ScheduleFiredAt() time.Time
+	SetScheduleFiredAt(time.Time) error
+	CreateScheduledBuild(slot time.Time) (Build, error)
 }
 
-var jobsQuery = psql.Select("j.id", "j.name", "j.config", "j.paused", "j.first_logged_build_id", "j.pipeline_id", "p.name", "p.team_id", "t.name", "j.nonce", "j.tags", "j.has_new_inputs").
+var jobsQuery = psql.Select("j.id", "j.name", "j.config", "j.paused", "j.first_logged_build_id", "j.pipeline_id", "p.name", "p.team_id", "t.name", "j.nonce", "j.tags", "j.has_new_inputs", "j.schedule_fired_at").
 	From("jobs j, pipelines p").
 	LeftJoin("teams t ON p.team_id = t.id").
 	Where(sq.Expr("j.pipeline_id = p.id"))
@@ -84,6 +90,7 @@ type job struct {
 	config             atc.JobConfig
 	tags               []string
 	hasNewInputs       bool
+	scheduleFiredAt    time.Time
 
 	conn        Conn
 	lockFactory lock.LockFactory
@@ -136,6 +143,8 @@ func (j *job) Tags() []string          { return j.tags }
 func (j *job) Public() bool            { return j.Config().Public }
 func (j *job) HasNewInputs() bool      { return j.hasNewInputs }
 
+func (j *job) ScheduleFiredAt() time.Time { return j.scheduleFiredAt }
+
 func (j *job) Reload() (bool, error) {
 	row := jobsQuery.Where(sq.Eq{"j.id": j.id}).
 		RunWith(j.conn).
@@ -214,6 +223,135 @@ func (j *job) UpdateFirstLoggedBuildID(newFirstLoggedBuildID int) error {
 	return nil
 }
 
+// DeleteExpiredBuilds deletes up to limit completed builds of the job, along
+// with their events, that are outside both the latest retention.Builds builds
+// and the last retention.Days days. It returns the number of builds deleted.
+//
+// The latest succeeded and latest completed builds are always kept. With
+// keepPassedVersions, so are the succeeded builds whose versions, taken
+// together, were not all used or produced by a later succeeded build, as
+// passed constraints of other jobs rely on the versions having gone through
+// the same build. With onlyDrained, builds whose events have not been drained
+// yet are kept.
+func (j *job) DeleteExpiredBuilds(retention atc.BuildRetention, keepPassedVersions bool, onlyDrained bool, limit int) (int, error) {
+	if retention.Builds == 0 && retention.Days == 0 {
+		return 0, nil
+	}
+
+	tx, err := j.conn.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	defer Rollback(tx)
+
+	rows, err := tx.Query(`
+		WITH versions AS (
+			SELECT i.build_id, i.resource_id, i.version_md5
+			FROM build_resource_config_version_inputs i
+			JOIN builds vb ON vb.id = i.build_id
+			WHERE vb.job_id = $1
+			AND vb.status = 'succeeded'
+			UNION
+			SELECT o.build_id, o.resource_id, o.version_md5
+			FROM build_resource_config_version_outputs o
+			JOIN builds vb ON vb.id = o.build_id
+			WHERE vb.job_id = $1
+			AND vb.status = 'succeeded'
+		)
+		SELECT b.id
+		FROM (
+			SELECT id, status, end_time, drained, completed, rank() OVER (ORDER BY id DESC) AS rank
+			FROM builds
+			WHERE job_id = $1
+		) b
+		JOIN jobs j ON j.id = $1
+		WHERE b.completed
+		AND ($2 = 0 OR b.rank > $2)
+		AND ($3 = 0 OR b.end_time < NOW() - ($3 * interval '1 day'))
+		AND (NOT $4 OR b.drained)
+		AND b.id IS DISTINCT FROM j.latest_completed_build_id
+		AND b.id IS DISTINCT FROM j.transition_build_id
+		AND b.id IS DISTINCT FROM (
+			SELECT MAX(id) FROM builds WHERE job_id = $1 AND status = 'succeeded'
+		)
+		AND NOT (
+			$5 AND b.status = 'succeeded' AND EXISTS (
+				SELECT 1 FROM versions v WHERE v.build_id = b.id
+			) AND NOT EXISTS (
+				SELECT 1
+				FROM builds nb
+				WHERE nb.job_id = $1
+				AND nb.status = 'succeeded'
+				AND nb.id > b.id
+				AND NOT EXISTS (
+					SELECT 1
+					FROM versions v
+					WHERE v.build_id = b.id
+					AND NOT EXISTS (
+						SELECT 1
+						FROM versions nv
+						WHERE nv.build_id = nb.id
+						AND nv.resource_id = v.resource_id
+						AND nv.version_md5 = v.version_md5
+					)
+				)
+			)
+		)
+		ORDER BY b.id ASC
+		LIMIT $6
+	`, j.id, retention.Builds, retention.Days, onlyDrained, keepPassedVersions, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	var buildIDs []int
+	for rows.Next() {
+		var buildID int
+		err = rows.Scan(&buildID)
+		if err != nil {
+			Close(rows)
+			return 0, err
+		}
+
+		buildIDs = append(buildIDs, buildID)
+	}
+
+	Close(rows)
+
+	if len(buildIDs) == 0 {
+		return 0, nil
+	}
+
+	_, err = psql.Delete("build_events").
+		Where(sq.Eq{"build_id": buildIDs}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := psql.Delete("builds").
+		Where(sq.Eq{"id": buildIDs}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(deleted), nil
+}
+
 func (j *job) BuildsWithTime(page Page) ([]Build, Pagination, error) {
 	newBuildsQuery := buildsQuery.Where(sq.Eq{"j.id": j.id})
 	newMinMaxIdQuery := minMaxIdQuery.
@@ -463,6 +601,11 @@ func (j *job) EnsurePendingBuildExists() error {
 			return err
 		}
 
+		err = saveBuildStatusEvent(tx, buildID, atc.StatusEventBuildCreated)
+		if err != nil {
+			return err
+		}
+
 		err = createBuildEventSeq(tx, buildID)
 		if err != nil {
 			return err
@@ -555,6 +698,74 @@ func (j *job) CreateBuild() (Build, error) {
 	return build, nil
 }
 
+// SetScheduleFiredAt records a schedule slot as handled without creating a
+// build for it, e.g. when a schedule is first seen or a missed run is skipped.
+func (j *job) SetScheduleFiredAt(slot time.Time) error {
+	_, err := psql.Update("jobs").
+		Set("schedule_fired_at", slot).
+		Where(sq.Eq{"id": j.id}).
+		RunWith(j.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	j.scheduleFiredAt = slot
+
+	return nil
+}
+
+// CreateScheduledBuild creates a pending build for the given schedule slot
+// and records the slot as fired in the same transaction.
+func (j *job) CreateScheduledBuild(slot time.Time) (Build, error) {
+	tx, err := j.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	buildName, err := j.getNewBuildName(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	build := &build{conn: j.conn, lockFactory: j.lockFactory}
+	err = createBuild(tx, build, map[string]interface{}{
+		"name":        buildName,
+		"job_id":      j.id,
+		"pipeline_id": j.pipelineID,
+		"team_id":     j.teamID,
+		"status":      BuildStatusPending,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = updateNextBuildForJob(tx, j.id)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = psql.Update("jobs").
+		Set("schedule_fired_at", slot).
+		Where(sq.Eq{"id": j.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	j.scheduleFiredAt = slot
+
+	return build, nil
+}
+
 func (j *job) ClearTaskCache(stepName string, cachePath string) (int64, error) {
 	tx, err := j.conn.Begin()
 	if err != nil {
@@ -622,10 +833,17 @@ func (j *job) updateSerialGroups(serialGroups []string) error {
 }
 
 func (j *job) updatePausedJob(pause bool) error {
+	tx, err := j.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
 	result, err := psql.Update("jobs").
 		Set("paused", pause).
 		Where(sq.Eq{"id": j.id}).
-		RunWith(j.conn).
+		RunWith(tx).
 		Exec()
 	if err != nil {
 		return err
@@ -640,7 +858,17 @@ func (j *job) updatePausedJob(pause bool) error {
 		return nonOneRowAffectedError{rowsAffected}
 	}
 
-	return nil
+	eventType := atc.StatusEventJobUnpaused
+	if pause {
+		eventType = atc.StatusEventJobPaused
+	}
+
+	err = saveJobStatusEvent(tx, j.id, eventType)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (j *job) getBuildInputs(table string) ([]BuildInput, error) {
@@ -825,15 +1053,18 @@ func (j *job) finishedBuild() (Build, error) {
 
 func scanJob(j *job, row scannable) error {
 	var (
-		configBlob []byte
-		nonce      sql.NullString
+		configBlob      []byte
+		nonce           sql.NullString
+		scheduleFiredAt pq.NullTime
 	)
 
-	err := row.Scan(&j.id, &j.name, &configBlob, &j.paused, &j.firstLoggedBuildID, &j.pipelineID, &j.pipelineName, &j.teamID, &j.teamName, &nonce, pq.Array(&j.tags), &j.hasNewInputs)
+	err := row.Scan(&j.id, &j.name, &configBlob, &j.paused, &j.firstLoggedBuildID, &j.pipelineID, &j.pipelineName, &j.teamID, &j.teamName, &nonce, pq.Array(&j.tags), &j.hasNewInputs, &scheduleFiredAt)
 	if err != nil {
 		return err
 	}
 
+	j.scheduleFiredAt = scheduleFiredAt.Time
+
 	es := j.conn.EncryptionStrategy()
 
 	var noncense *string
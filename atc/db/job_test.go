@@ -7,6 +7,7 @@ import (
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/algorithm"
+	"github.com/concourse/concourse/atc/event"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -87,7 +88,7 @@ var _ = Describe("Job", func() {
 					Type: "some-type",
 				},
 			},
-		}, db.ConfigVersion(0), db.PipelineUnpaused)
+		}, db.ConfigVersion(0), db.PipelineUnpaused, "")
 		Expect(err).ToNot(HaveOccurred())
 		Expect(created).To(BeTrue())
 
@@ -162,7 +163,7 @@ var _ = Describe("Job", func() {
 				Jobs: atc.JobConfigs{
 					{Name: "some-job"},
 				},
-			}, db.ConfigVersion(0), db.PipelineUnpaused)
+			}, db.ConfigVersion(0), db.PipelineUnpaused, "")
 			Expect(err).ToNot(HaveOccurred())
 			Expect(created).To(BeTrue())
 
@@ -275,6 +276,197 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("DeleteExpiredBuilds", func() {
+		var (
+			builds []db.Build
+
+			retention          atc.BuildRetention
+			keepPassedVersions bool
+			onlyDrained        bool
+			limit              int
+
+			deleted   int
+			deleteErr error
+		)
+
+		finishBuildWithVersions := func(status db.BuildStatus, versions map[string]string) db.Build {
+			build, err := job.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			for resourceName, version := range versions {
+				err = build.SaveOutput(logger, "some-type", atc.Source{"some": resourceName}, creds.VersionedResourceTypes{}, atc.Version{"ver": version}, nil, resourceName, resourceName)
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			err = build.SaveEvent(event.Log{Payload: "some-log"})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.Finish(status)
+			Expect(err).ToNot(HaveOccurred())
+
+			return build
+		}
+
+		finishBuild := func(status db.BuildStatus, version string) db.Build {
+			if version == "" {
+				return finishBuildWithVersions(status, nil)
+			}
+
+			return finishBuildWithVersions(status, map[string]string{"some-resource": version})
+		}
+
+		remainingBuildIDs := func() []int {
+			ids := []int{}
+			for _, build := range builds {
+				found, err := build.Reload()
+				Expect(err).ToNot(HaveOccurred())
+
+				if found {
+					ids = append(ids, build.ID())
+				}
+			}
+
+			return ids
+		}
+
+		BeforeEach(func() {
+			builds = nil
+			retention = atc.BuildRetention{Builds: 2}
+			keepPassedVersions = false
+			onlyDrained = false
+			limit = 100
+		})
+
+		JustBeforeEach(func() {
+			deleted, deleteErr = job.DeleteExpiredBuilds(retention, keepPassedVersions, onlyDrained, limit)
+		})
+
+		Context("with completed and running builds", func() {
+			BeforeEach(func() {
+				builds = append(builds,
+					finishBuild(db.BuildStatusSucceeded, ""),
+					finishBuild(db.BuildStatusFailed, ""),
+					finishBuild(db.BuildStatusErrored, ""),
+					finishBuild(db.BuildStatusFailed, ""),
+					finishBuild(db.BuildStatusAborted, ""),
+				)
+
+				running, err := job.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				builds = append(builds, running)
+			})
+
+			It("deletes the builds beyond the retention except the latest succeeded one", func() {
+				Expect(deleteErr).ToNot(HaveOccurred())
+				Expect(deleted).To(Equal(3))
+				Expect(remainingBuildIDs()).To(Equal([]int{builds[0].ID(), builds[4].ID(), builds[5].ID()}))
+			})
+
+			It("deletes the events of the deleted builds", func() {
+				var count int
+				err := dbConn.QueryRow("SELECT COUNT(*) FROM build_events WHERE build_id = $1", builds[1].ID()).Scan(&count)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(count).To(BeZero())
+			})
+
+			Context("with a limit", func() {
+				BeforeEach(func() {
+					limit = 2
+				})
+
+				It("deletes the oldest builds first", func() {
+					Expect(deleted).To(Equal(2))
+					Expect(remainingBuildIDs()).To(Equal([]int{builds[0].ID(), builds[3].ID(), builds[4].ID(), builds[5].ID()}))
+				})
+			})
+
+			Context("when the builds are within the days to retain", func() {
+				BeforeEach(func() {
+					retention.Days = 1
+				})
+
+				It("keeps them", func() {
+					Expect(deleted).To(BeZero())
+					Expect(remainingBuildIDs()).To(HaveLen(6))
+				})
+			})
+
+			Context("when only drained builds may be deleted", func() {
+				BeforeEach(func() {
+					onlyDrained = true
+
+					Expect(builds[2].SetDrained(true)).To(Succeed())
+				})
+
+				It("keeps the builds that have not been drained", func() {
+					Expect(deleted).To(Equal(1))
+					Expect(remainingBuildIDs()).ToNot(ContainElement(builds[2].ID()))
+				})
+			})
+
+			Context("when there is no retention", func() {
+				BeforeEach(func() {
+					retention = atc.BuildRetention{}
+				})
+
+				It("keeps every build", func() {
+					Expect(deleted).To(BeZero())
+					Expect(remainingBuildIDs()).To(HaveLen(6))
+				})
+			})
+		})
+
+		Context("when succeeded builds produced versions", func() {
+			BeforeEach(func() {
+				retention = atc.BuildRetention{Builds: 1}
+
+				builds = append(builds,
+					finishBuild(db.BuildStatusSucceeded, "1"),
+					finishBuild(db.BuildStatusSucceeded, "2"),
+					finishBuild(db.BuildStatusSucceeded, "1"),
+					finishBuild(db.BuildStatusFailed, ""),
+				)
+			})
+
+			It("deletes them", func() {
+				Expect(deleted).To(Equal(2))
+				Expect(remainingBuildIDs()).To(Equal([]int{builds[2].ID(), builds[3].ID()}))
+			})
+
+			Context("when keeping versions for passed constraints", func() {
+				BeforeEach(func() {
+					keepPassedVersions = true
+				})
+
+				It("keeps the latest build of each version", func() {
+					Expect(deleted).To(Equal(1))
+					Expect(remainingBuildIDs()).To(Equal([]int{builds[1].ID(), builds[2].ID(), builds[3].ID()}))
+				})
+			})
+		})
+
+		Context("when succeeded builds produced combinations of versions", func() {
+			BeforeEach(func() {
+				retention = atc.BuildRetention{Builds: 1}
+				keepPassedVersions = true
+
+				builds = append(builds,
+					finishBuildWithVersions(db.BuildStatusSucceeded, map[string]string{"some-resource": "1", "some-other-resource": "a"}),
+					finishBuildWithVersions(db.BuildStatusSucceeded, map[string]string{"some-resource": "1", "some-other-resource": "b"}),
+					finishBuildWithVersions(db.BuildStatusSucceeded, map[string]string{"some-resource": "2", "some-other-resource": "a"}),
+					finishBuild(db.BuildStatusFailed, ""),
+					finishBuildWithVersions(db.BuildStatusSucceeded, map[string]string{"some-resource": "1", "some-other-resource": "b"}),
+				)
+			})
+
+			It("keeps the builds whose combination was not produced again", func() {
+				Expect(deleted).To(Equal(2))
+				Expect(remainingBuildIDs()).To(Equal([]int{builds[0].ID(), builds[2].ID(), builds[4].ID()}))
+			})
+		})
+	})
+
 	Describe("Builds", func() {
 		var (
 			builds       [10]db.Build
@@ -388,7 +580,7 @@ var _ = Describe("Job", func() {
 					},
 				},
 			}
-			pipeline, _, err = team.SavePipeline("some-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+			pipeline, _, err = team.SavePipeline("some-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused, "")
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err = pipeline.Job("some-job")
@@ -789,7 +981,7 @@ var _ = Describe("Job", func() {
 				},
 			}
 
-			pipeline2, _, err = team.SavePipeline("some-pipeline-2", config, 1, db.PipelineUnpaused)
+			pipeline2, _, err = team.SavePipeline("some-pipeline-2", config, 1, db.PipelineUnpaused, "")
 			Expect(err).ToNot(HaveOccurred())
 
 			resource2, found, err = pipeline2.Resource("some-resource")
@@ -969,7 +1161,7 @@ var _ = Describe("Job", func() {
 				},
 			}
 
-			pipeline2, _, err = team.SavePipeline("some-pipeline-2", config, 1, db.PipelineUnpaused)
+			pipeline2, _, err = team.SavePipeline("some-pipeline-2", config, 1, db.PipelineUnpaused, "")
 			Expect(err).ToNot(HaveOccurred())
 
 			resource2, found, err = pipeline2.Resource("some-resource")
@@ -1121,7 +1313,7 @@ var _ = Describe("Job", func() {
 				},
 			}
 			var err error
-			otherPipeline, _, err = team.SavePipeline("some-other-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
+			otherPipeline, _, err = team.SavePipeline("some-other-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused, "")
 			Expect(err).ToNot(HaveOccurred())
 
 			build1DB, err = job.CreateBuild()
@@ -1277,6 +1469,57 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("CreateScheduledBuild", func() {
+		var slot time.Time
+
+		BeforeEach(func() {
+			slot = time.Date(2019, 5, 10, 2, 0, 0, 0, time.UTC)
+		})
+
+		It("creates a pending build that was not manually triggered", func() {
+			build, err := job.CreateScheduledBuild(slot)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build.Status()).To(Equal(db.BuildStatusPending))
+			Expect(build.IsManuallyTriggered()).To(BeFalse())
+
+			pendingBuilds, err := job.GetPendingBuilds()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pendingBuilds).To(HaveLen(1))
+		})
+
+		It("records the slot as fired", func() {
+			_, err := job.CreateScheduledBuild(slot)
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := job.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(job.ScheduleFiredAt()).To(BeTemporally("==", slot))
+		})
+	})
+
+	Describe("SetScheduleFiredAt", func() {
+		It("starts out unset", func() {
+			Expect(job.ScheduleFiredAt().IsZero()).To(BeTrue())
+		})
+
+		It("records the slot without creating a build", func() {
+			slot := time.Date(2019, 5, 10, 2, 0, 0, 0, time.UTC)
+
+			err := job.SetScheduleFiredAt(slot)
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := job.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(job.ScheduleFiredAt()).To(BeTemporally("==", slot))
+
+			pendingBuilds, err := job.GetPendingBuilds()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pendingBuilds).To(BeEmpty())
+		})
+	})
+
 	Describe("EnsurePendingBuildExists", func() {
 		Context("when only a started build exists", func() {
 			BeforeEach(func() {
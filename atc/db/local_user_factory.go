@@ -0,0 +1,165 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+)
+
+var ErrLocalUserAlreadyExists = errors.New("local user already exists")
+
+type LocalUser struct {
+	Username     string
+	PasswordHash []byte
+	Disabled     bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+//go:generate counterfeiter . LocalUserFactory
+
+// LocalUserFactory manages the username/password users that authenticate
+// through the local connector. Passwords are only ever stored as bcrypt
+// hashes; hashing is left to the caller.
+type LocalUserFactory interface {
+	CreateLocalUser(username string, passwordHash []byte) (LocalUser, error)
+	SeedLocalUser(username string, passwordHash []byte) error
+	FindLocalUser(username string) (LocalUser, bool, error)
+	LocalUsers() ([]LocalUser, error)
+	SetLocalUserPassword(username string, passwordHash []byte) (bool, error)
+	SetLocalUserDisabled(username string, disabled bool) (bool, error)
+}
+
+type localUserFactory struct {
+	conn Conn
+}
+
+func NewLocalUserFactory(conn Conn) LocalUserFactory {
+	return &localUserFactory{
+		conn: conn,
+	}
+}
+
+var localUsersQuery = psql.Select("username, password_hash, disabled, created_at, updated_at").
+	From("local_users")
+
+func (f *localUserFactory) CreateLocalUser(username string, passwordHash []byte) (LocalUser, error) {
+	row := psql.Insert("local_users").
+		Columns("username", "password_hash").
+		Values(username, string(passwordHash)).
+		Suffix("RETURNING username, password_hash, disabled, created_at, updated_at").
+		RunWith(f.conn).
+		QueryRow()
+
+	user, err := scanLocalUser(row)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode {
+			return LocalUser{}, ErrLocalUserAlreadyExists
+		}
+
+		return LocalUser{}, err
+	}
+
+	return user, nil
+}
+
+// SeedLocalUser creates the user unless one with the same name already
+// exists, leaving any password set at runtime untouched.
+func (f *localUserFactory) SeedLocalUser(username string, passwordHash []byte) error {
+	_, err := psql.Insert("local_users").
+		Columns("username", "password_hash").
+		Values(username, string(passwordHash)).
+		Suffix("ON CONFLICT (LOWER(username)) DO NOTHING").
+		RunWith(f.conn).
+		Exec()
+
+	return err
+}
+
+func (f *localUserFactory) FindLocalUser(username string) (LocalUser, bool, error) {
+	row := localUsersQuery.
+		Where(sq.Expr("LOWER(username) = LOWER(?)", username)).
+		RunWith(f.conn).
+		QueryRow()
+
+	user, err := scanLocalUser(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return LocalUser{}, false, nil
+		}
+
+		return LocalUser{}, false, err
+	}
+
+	return user, true, nil
+}
+
+func (f *localUserFactory) LocalUsers() ([]LocalUser, error) {
+	rows, err := localUsersQuery.
+		OrderBy("username").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	users := []LocalUser{}
+	for rows.Next() {
+		user, err := scanLocalUser(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+func (f *localUserFactory) SetLocalUserPassword(username string, passwordHash []byte) (bool, error) {
+	return f.update(username, "password_hash", string(passwordHash))
+}
+
+func (f *localUserFactory) SetLocalUserDisabled(username string, disabled bool) (bool, error) {
+	return f.update(username, "disabled", disabled)
+}
+
+func (f *localUserFactory) update(username string, column string, value interface{}) (bool, error) {
+	result, err := psql.Update("local_users").
+		Set(column, value).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Expr("LOWER(username) = LOWER(?)", username)).
+		RunWith(f.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func scanLocalUser(row scannable) (LocalUser, error) {
+	var (
+		user LocalUser
+		hash string
+	)
+
+	err := row.Scan(&user.Username, &hash, &user.Disabled, &user.CreatedAt, &user.UpdatedAt)
+	if err != nil {
+		return LocalUser{}, err
+	}
+
+	user.PasswordHash = []byte(hash)
+
+	return user, nil
+}
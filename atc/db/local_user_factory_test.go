@@ -0,0 +1,150 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LocalUserFactory", func() {
+	var localUserFactory db.LocalUserFactory
+
+	BeforeEach(func() {
+		localUserFactory = db.NewLocalUserFactory(dbConn)
+	})
+
+	Describe("CreateLocalUser", func() {
+		It("creates an enabled user", func() {
+			user, err := localUserFactory.CreateLocalUser("some-user", []byte("some-hash"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(user.Username).To(Equal("some-user"))
+			Expect(user.PasswordHash).To(Equal([]byte("some-hash")))
+			Expect(user.Disabled).To(BeFalse())
+		})
+
+		Context("when a user with the same name exists in a different case", func() {
+			BeforeEach(func() {
+				_, err := localUserFactory.CreateLocalUser("Some-User", []byte("some-hash"))
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns ErrLocalUserAlreadyExists", func() {
+				_, err := localUserFactory.CreateLocalUser("some-user", []byte("other-hash"))
+				Expect(err).To(Equal(db.ErrLocalUserAlreadyExists))
+			})
+		})
+	})
+
+	Describe("SeedLocalUser", func() {
+		It("creates missing users", func() {
+			err := localUserFactory.SeedLocalUser("some-user", []byte("some-hash"))
+			Expect(err).ToNot(HaveOccurred())
+
+			user, found, err := localUserFactory.FindLocalUser("some-user")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(user.PasswordHash).To(Equal([]byte("some-hash")))
+		})
+
+		Context("when the user already exists", func() {
+			BeforeEach(func() {
+				_, err := localUserFactory.CreateLocalUser("some-user", []byte("runtime-hash"))
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("keeps the existing password", func() {
+				err := localUserFactory.SeedLocalUser("some-user", []byte("flag-hash"))
+				Expect(err).ToNot(HaveOccurred())
+
+				user, _, err := localUserFactory.FindLocalUser("some-user")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(user.PasswordHash).To(Equal([]byte("runtime-hash")))
+			})
+		})
+	})
+
+	Describe("FindLocalUser", func() {
+		BeforeEach(func() {
+			_, err := localUserFactory.CreateLocalUser("Some-User", []byte("some-hash"))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("matches usernames case-insensitively", func() {
+			user, found, err := localUserFactory.FindLocalUser("some-user")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(user.Username).To(Equal("Some-User"))
+		})
+
+		It("returns false for unknown users", func() {
+			_, found, err := localUserFactory.FindLocalUser("bogus")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Describe("LocalUsers", func() {
+		BeforeEach(func() {
+			_, err := localUserFactory.CreateLocalUser("user-b", []byte("some-hash"))
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = localUserFactory.CreateLocalUser("user-a", []byte("some-hash"))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns all users ordered by name", func() {
+			users, err := localUserFactory.LocalUsers()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(users).To(HaveLen(2))
+			Expect(users[0].Username).To(Equal("user-a"))
+			Expect(users[1].Username).To(Equal("user-b"))
+		})
+	})
+
+	Describe("SetLocalUserPassword", func() {
+		BeforeEach(func() {
+			_, err := localUserFactory.CreateLocalUser("some-user", []byte("some-hash"))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("updates the password", func() {
+			found, err := localUserFactory.SetLocalUserPassword("some-user", []byte("new-hash"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			user, _, err := localUserFactory.FindLocalUser("some-user")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(user.PasswordHash).To(Equal([]byte("new-hash")))
+		})
+
+		It("returns false for unknown users", func() {
+			found, err := localUserFactory.SetLocalUserPassword("bogus", []byte("new-hash"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Describe("SetLocalUserDisabled", func() {
+		BeforeEach(func() {
+			_, err := localUserFactory.CreateLocalUser("some-user", []byte("some-hash"))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("disables and re-enables the user", func() {
+			found, err := localUserFactory.SetLocalUserDisabled("some-user", true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			user, _, err := localUserFactory.FindLocalUser("some-user")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(user.Disabled).To(BeTrue())
+
+			_, err = localUserFactory.SetLocalUserDisabled("some-user", false)
+			Expect(err).ToNot(HaveOccurred())
+
+			user, _, err = localUserFactory.FindLocalUser("some-user")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(user.Disabled).To(BeFalse())
+		})
+	})
+})
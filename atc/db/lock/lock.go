@@ -20,6 +20,7 @@ const (
 	LockTypeVolumeCreating
 	LockTypeContainerCreating
 	LockTypeDatabaseMigration
+	LockTypeTeamBuildQuota
 )
 
 var ErrLostLock = errors.New("lock was lost while held, possibly due to connection breakage")
@@ -48,6 +49,10 @@ func NewContainerCreatingLockID(containerID int) LockID {
 	return LockID{LockTypeContainerCreating, containerID}
 }
 
+func NewTeamBuildQuotaLockID(teamID int) LockID {
+	return LockID{LockTypeTeamBuildQuota, teamID}
+}
+
 func NewDatabaseMigrationLockID() LockID {
 	return LockID{LockTypeDatabaseMigration}
 }
@@ -0,0 +1,7 @@
+BEGIN;
+
+  UPDATE workers SET state = 'running' WHERE state = 'quarantined';
+
+  ALTER TABLE workers DROP COLUMN quarantined_until;
+
+COMMIT;
@@ -0,0 +1,4 @@
+-- NO_TRANSACTION
+ALTER TYPE worker_state ADD VALUE IF NOT EXISTS 'quarantined';
+
+ALTER TABLE workers ADD COLUMN quarantined_until timestamp with time zone;
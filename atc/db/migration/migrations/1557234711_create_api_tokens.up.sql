@@ -0,0 +1,16 @@
+BEGIN;
+  CREATE TABLE api_tokens (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    team_id INTEGER NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    owner TEXT NOT NULL,
+    service_account BOOLEAN DEFAULT false NOT NULL,
+    roles TEXT[] NOT NULL,
+    token_hash TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    UNIQUE (team_id, name)
+  );
+
+  CREATE UNIQUE INDEX api_tokens_token_hash_idx ON api_tokens (token_hash);
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  DROP TABLE local_users;
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+  CREATE TABLE local_users (
+    id SERIAL PRIMARY KEY,
+    username TEXT NOT NULL,
+    password_hash TEXT NOT NULL,
+    disabled BOOLEAN DEFAULT false NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+  );
+
+  CREATE UNIQUE INDEX local_users_username_idx ON local_users (LOWER(username));
+COMMIT;
@@ -0,0 +1,16 @@
+BEGIN;
+  CREATE TABLE audit_events (
+    id BIGSERIAL PRIMARY KEY,
+    actor TEXT NOT NULL,
+    team_name TEXT,
+    action TEXT NOT NULL,
+    target TEXT DEFAULT '' NOT NULL,
+    request_id TEXT NOT NULL,
+    source_ip TEXT NOT NULL,
+    status INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+  );
+
+  CREATE INDEX audit_events_created_at_idx ON audit_events (created_at);
+  CREATE INDEX audit_events_team_name_idx ON audit_events (team_name);
+COMMIT;
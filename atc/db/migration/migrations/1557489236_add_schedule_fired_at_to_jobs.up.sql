@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE jobs ADD COLUMN schedule_fired_at TIMESTAMP WITH TIME ZONE;
+COMMIT;
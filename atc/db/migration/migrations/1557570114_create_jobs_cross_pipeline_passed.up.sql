@@ -0,0 +1,8 @@
+BEGIN;
+  CREATE TABLE jobs_cross_pipeline_passed (
+    job_id INTEGER NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+    passed_pipeline_name TEXT NOT NULL,
+    passed_job_name TEXT NOT NULL,
+    UNIQUE (job_id, passed_pipeline_name, passed_job_name)
+  );
+COMMIT;
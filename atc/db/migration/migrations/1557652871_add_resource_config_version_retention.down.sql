@@ -0,0 +1,8 @@
+BEGIN;
+  ALTER TABLE resources
+    DROP COLUMN version_retention_latest,
+    DROP COLUMN version_retention_days,
+    DROP COLUMN config_pinned_versions;
+
+  ALTER TABLE resource_config_versions DROP COLUMN created_at;
+COMMIT;
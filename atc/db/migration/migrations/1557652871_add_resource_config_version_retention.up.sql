@@ -0,0 +1,8 @@
+BEGIN;
+  ALTER TABLE resource_config_versions ADD COLUMN created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL;
+
+  ALTER TABLE resources
+    ADD COLUMN version_retention_latest INTEGER,
+    ADD COLUMN version_retention_days INTEGER,
+    ADD COLUMN config_pinned_versions JSONB DEFAULT '[]' NOT NULL;
+COMMIT;
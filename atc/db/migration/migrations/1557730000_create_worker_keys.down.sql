@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE workers DROP COLUMN key_fingerprint;
+
+  DROP TABLE worker_keys;
+COMMIT;
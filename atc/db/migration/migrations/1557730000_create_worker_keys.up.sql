@@ -0,0 +1,16 @@
+BEGIN;
+  CREATE TABLE worker_keys (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    team_id INTEGER REFERENCES teams(id) ON DELETE CASCADE,
+    public_key TEXT NOT NULL,
+    fingerprint TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+  );
+
+  CREATE UNIQUE INDEX worker_keys_team_id_name_idx ON worker_keys (COALESCE(team_id, 0), name);
+
+  CREATE UNIQUE INDEX worker_keys_fingerprint_idx ON worker_keys (fingerprint);
+
+  ALTER TABLE workers ADD COLUMN key_fingerprint TEXT;
+COMMIT;
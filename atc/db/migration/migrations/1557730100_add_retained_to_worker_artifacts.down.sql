@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE worker_artifacts
+    DROP COLUMN retained;
+COMMIT;
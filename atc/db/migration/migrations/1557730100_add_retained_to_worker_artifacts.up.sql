@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE worker_artifacts
+    ADD COLUMN retained boolean NOT NULL DEFAULT false;
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+  CREATE TABLE pipeline_configs (
+    id SERIAL PRIMARY KEY,
+    pipeline_id INTEGER NOT NULL REFERENCES pipelines(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    config TEXT NOT NULL,
+    nonce TEXT,
+    saved_by TEXT,
+    saved_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+  );
+
+  CREATE UNIQUE INDEX pipeline_configs_pipeline_id_version_idx ON pipeline_configs (pipeline_id, version);
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+  DROP TABLE task_result_outputs;
+  DROP TABLE task_results;
+COMMIT;
@@ -0,0 +1,22 @@
+BEGIN;
+  CREATE TABLE task_results (
+    id SERIAL PRIMARY KEY,
+    team_id INTEGER NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    key TEXT NOT NULL,
+    build_id INTEGER NOT NULL REFERENCES builds(id) ON DELETE CASCADE,
+    outputs INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    last_used_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+  );
+
+  CREATE INDEX task_results_team_id_key_idx ON task_results (team_id, key);
+
+  CREATE TABLE task_result_outputs (
+    task_result_id INTEGER NOT NULL REFERENCES task_results(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    worker_artifact_id INTEGER NOT NULL REFERENCES worker_artifacts(id) ON DELETE CASCADE
+  );
+
+  CREATE INDEX task_result_outputs_task_result_id_idx ON task_result_outputs (task_result_id);
+  CREATE INDEX task_result_outputs_worker_artifact_id_idx ON task_result_outputs (worker_artifact_id);
+COMMIT;
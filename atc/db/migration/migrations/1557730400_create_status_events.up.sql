@@ -0,0 +1,18 @@
+BEGIN;
+  CREATE TABLE status_events (
+    id BIGSERIAL PRIMARY KEY,
+    type TEXT NOT NULL,
+    team_id INTEGER NOT NULL,
+    team_name TEXT NOT NULL,
+    pipeline_id INTEGER,
+    pipeline_name TEXT,
+    job_id INTEGER,
+    job_name TEXT,
+    build_id INTEGER,
+    build_name TEXT,
+    build_status TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+  );
+
+  CREATE INDEX status_events_created_at_idx ON status_events (created_at);
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+  DROP TABLE named_lock_claims;
+  DROP TABLE named_locks;
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+  CREATE TABLE named_locks (
+    id SERIAL PRIMARY KEY,
+    team_id INTEGER NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    capacity INTEGER DEFAULT 1 NOT NULL,
+    UNIQUE (team_id, name)
+  );
+
+  CREATE TABLE named_lock_claims (
+    id BIGSERIAL PRIMARY KEY,
+    named_lock_id INTEGER NOT NULL REFERENCES named_locks(id) ON DELETE CASCADE,
+    build_id INTEGER NOT NULL REFERENCES builds(id) ON DELETE CASCADE,
+    claimed_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    acquired_at TIMESTAMP WITH TIME ZONE
+  );
+
+  CREATE INDEX named_lock_claims_named_lock_id_idx ON named_lock_claims (named_lock_id);
+  CREATE INDEX named_lock_claims_build_id_idx ON named_lock_claims (build_id);
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+  DROP TABLE hijack_session_events;
+  DROP TABLE hijack_sessions;
+COMMIT;
@@ -0,0 +1,30 @@
+BEGIN;
+  CREATE TABLE hijack_sessions (
+    id SERIAL PRIMARY KEY,
+    team_id INTEGER NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    container_handle TEXT NOT NULL,
+    worker_name TEXT NOT NULL,
+    username TEXT NOT NULL,
+    path TEXT NOT NULL,
+    args TEXT[] DEFAULT '{}' NOT NULL,
+    process_user TEXT NOT NULL,
+    width INTEGER DEFAULT 0 NOT NULL,
+    height INTEGER DEFAULT 0 NOT NULL,
+    started_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    ended_at TIMESTAMP WITH TIME ZONE,
+    exit_status INTEGER
+  );
+
+  CREATE INDEX hijack_sessions_team_id_idx ON hijack_sessions (team_id);
+  CREATE INDEX hijack_sessions_started_at_idx ON hijack_sessions (started_at);
+
+  CREATE TABLE hijack_session_events (
+    id BIGSERIAL PRIMARY KEY,
+    hijack_session_id INTEGER NOT NULL REFERENCES hijack_sessions(id) ON DELETE CASCADE,
+    elapsed DOUBLE PRECISION NOT NULL,
+    type TEXT NOT NULL,
+    data BYTEA NOT NULL
+  );
+
+  CREATE INDEX hijack_session_events_hijack_session_id_idx ON hijack_session_events (hijack_session_id);
+COMMIT;
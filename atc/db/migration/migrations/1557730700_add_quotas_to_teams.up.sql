@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE teams
+    ADD COLUMN max_running_builds integer NOT NULL DEFAULT 0,
+    ADD COLUMN max_containers integer NOT NULL DEFAULT 0;
+COMMIT;
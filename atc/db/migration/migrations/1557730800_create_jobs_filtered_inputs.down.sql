@@ -0,0 +1,3 @@
+BEGIN;
+  DROP TABLE jobs_filtered_inputs;
+COMMIT;
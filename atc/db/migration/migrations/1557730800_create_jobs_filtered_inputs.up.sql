@@ -0,0 +1,7 @@
+BEGIN;
+  CREATE TABLE jobs_filtered_inputs (
+    job_id INTEGER NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+    resource_name TEXT NOT NULL,
+    UNIQUE (job_id, resource_name)
+  );
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE named_lock_claims DROP CONSTRAINT named_lock_claims_build_id_plan_id_key;
+
+  ALTER TABLE named_lock_claims DROP COLUMN plan_id;
+COMMIT;
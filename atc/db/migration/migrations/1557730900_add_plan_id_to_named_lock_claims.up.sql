@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE named_lock_claims ADD COLUMN plan_id TEXT;
+
+  ALTER TABLE named_lock_claims ADD CONSTRAINT named_lock_claims_build_id_plan_id_key UNIQUE (build_id, plan_id);
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE hijack_session_events ADD COLUMN nonce TEXT;
+COMMIT;
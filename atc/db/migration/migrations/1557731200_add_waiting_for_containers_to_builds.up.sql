@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE builds ADD COLUMN waiting_for_containers_until timestamp with time zone;
+COMMIT;
@@ -0,0 +1,343 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/lib/pq"
+)
+
+const namedLocksChannel = "named_locks"
+
+// NamedLock is a lock shared by the builds of a team. Up to Capacity builds
+// hold it at a time while the rest wait in the order they claimed it.
+type NamedLock struct {
+	Name     string
+	Capacity int
+	Holders  []NamedLockClaim
+	Waiters  []NamedLockClaim
+}
+
+// NamedLockCapacityError is returned when a lock is claimed with a different
+// capacity than the one its current claims were made with.
+type NamedLockCapacityError struct {
+	Name      string
+	Capacity  int
+	Requested int
+}
+
+func (err NamedLockCapacityError) Error() string {
+	return fmt.Sprintf("lock '%s' is already claimed with capacity %d, not %d", err.Name, err.Capacity, err.Requested)
+}
+
+type NamedLockClaim struct {
+	ID           int
+	BuildID      int
+	BuildName    string
+	JobName      string
+	PipelineName string
+	ClaimedAt    time.Time
+	AcquiredAt   time.Time
+}
+
+//go:generate counterfeiter . NamedLockFactory
+
+type NamedLockFactory interface {
+	ClaimNamedLock(teamID int, name string, capacity int, buildID int, planID atc.PlanID) (int, error)
+	AcquireNamedLock(claimID int) (bool, error)
+	ReleaseNamedLock(claimID int) error
+	NamedLockNotifier() (Notifier, error)
+
+	NamedLocks(teamID int) ([]NamedLock, error)
+	ForceReleaseNamedLock(teamID int, name string) (bool, error)
+}
+
+type namedLockFactory struct {
+	conn Conn
+}
+
+func NewNamedLockFactory(conn Conn) NamedLockFactory {
+	return &namedLockFactory{
+		conn: conn,
+	}
+}
+
+// ClaimNamedLock queues the step of the build for the lock, creating the lock
+// if it does not exist yet. A step which has already claimed the lock, e.g.
+// before the build was resumed by another ATC, keeps its place in the queue.
+//
+// The capacity, or 1 if it is not set, only replaces the capacity of the lock
+// while nobody claims it; otherwise it has to match.
+func (f *namedLockFactory) ClaimNamedLock(teamID int, name string, capacity int, buildID int, planID atc.PlanID) (int, error) {
+	if capacity <= 0 {
+		capacity = 1
+	}
+
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	defer Rollback(tx)
+
+	// the upsert locks the row until commit, so claims of the same lock are
+	// numbered in the order they become visible
+	var lockID, lockCapacity int
+	err = psql.Insert("named_locks").
+		Columns("team_id", "name", "capacity").
+		Values(teamID, name, capacity).
+		Suffix(`ON CONFLICT (team_id, name) DO UPDATE SET capacity = CASE
+			WHEN EXISTS (SELECT 1 FROM named_lock_claims c WHERE c.named_lock_id = named_locks.id) THEN named_locks.capacity
+			ELSE EXCLUDED.capacity
+		END
+		RETURNING id, capacity`).
+		RunWith(tx).
+		QueryRow().
+		Scan(&lockID, &lockCapacity)
+	if err != nil {
+		return 0, err
+	}
+
+	if lockCapacity != capacity {
+		return 0, NamedLockCapacityError{
+			Name:      name,
+			Capacity:  lockCapacity,
+			Requested: capacity,
+		}
+	}
+
+	var claimID int
+	err = psql.Insert("named_lock_claims").
+		Columns("named_lock_id", "build_id", "plan_id").
+		Values(lockID, buildID, string(planID)).
+		Suffix("ON CONFLICT (build_id, plan_id) DO UPDATE SET plan_id = EXCLUDED.plan_id RETURNING id").
+		RunWith(tx).
+		QueryRow().
+		Scan(&claimID)
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	return claimID, nil
+}
+
+// AcquireNamedLock acquires the lock for the claim once fewer claims than the
+// capacity of the lock are ahead of it.
+func (f *namedLockFactory) AcquireNamedLock(claimID int) (bool, error) {
+	result, err := f.conn.Exec(`
+		UPDATE named_lock_claims c
+		SET acquired_at = COALESCE(c.acquired_at, NOW())
+		FROM named_locks l
+		WHERE c.id = $1
+		AND l.id = c.named_lock_id
+		AND (
+			SELECT COUNT(*)
+			FROM named_lock_claims o
+			WHERE o.named_lock_id = c.named_lock_id
+			AND o.id < c.id
+		) < l.capacity
+	`, claimID)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+func (f *namedLockFactory) ReleaseNamedLock(claimID int) error {
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	_, err = psql.Delete("named_lock_claims").
+		Where(sq.Eq{"id": claimID}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	err = notifyNamedLocks(tx)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// NamedLockNotifier notifies whenever a claim is released, and once right
+// away.
+func (f *namedLockFactory) NamedLockNotifier() (Notifier, error) {
+	return newConditionNotifier(f.conn.Bus(), namedLocksChannel, func() (bool, error) {
+		return true, nil
+	})
+}
+
+func (f *namedLockFactory) NamedLocks(teamID int) ([]NamedLock, error) {
+	rows, err := psql.Select("l.id, l.name, l.capacity").
+		From("named_locks l").
+		Where(sq.Eq{"l.team_id": teamID}).
+		OrderBy("l.name ASC").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	locks := []NamedLock{}
+	lockIndexes := map[int]int{}
+	for rows.Next() {
+		var id int
+		var lock NamedLock
+
+		err = rows.Scan(&id, &lock.Name, &lock.Capacity)
+		if err != nil {
+			return nil, err
+		}
+
+		lockIndexes[id] = len(locks)
+		locks = append(locks, lock)
+	}
+
+	claimRows, err := psql.Select("c.named_lock_id, c.id, c.build_id, b.name, j.name, p.name, c.claimed_at, c.acquired_at").
+		From("named_lock_claims c").
+		Join("named_locks l ON l.id = c.named_lock_id").
+		Join("builds b ON b.id = c.build_id").
+		LeftJoin("jobs j ON j.id = b.job_id").
+		LeftJoin("pipelines p ON p.id = b.pipeline_id").
+		Where(sq.Eq{"l.team_id": teamID}).
+		OrderBy("c.id ASC").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(claimRows)
+
+	for claimRows.Next() {
+		var lockID int
+		var claim NamedLockClaim
+		var jobName, pipelineName sql.NullString
+		var acquiredAt pq.NullTime
+
+		err = claimRows.Scan(&lockID, &claim.ID, &claim.BuildID, &claim.BuildName, &jobName, &pipelineName, &claim.ClaimedAt, &acquiredAt)
+		if err != nil {
+			return nil, err
+		}
+
+		index, found := lockIndexes[lockID]
+		if !found {
+			continue
+		}
+
+		claim.JobName = jobName.String
+		claim.PipelineName = pipelineName.String
+
+		if acquiredAt.Valid {
+			claim.AcquiredAt = acquiredAt.Time
+			locks[index].Holders = append(locks[index].Holders, claim)
+		} else {
+			locks[index].Waiters = append(locks[index].Waiters, claim)
+		}
+	}
+
+	return locks, nil
+}
+
+// ForceReleaseNamedLock releases the lock from the builds currently holding
+// it, letting the next waiters acquire it. It returns false if the team has
+// no such lock.
+func (f *namedLockFactory) ForceReleaseNamedLock(teamID int, name string) (bool, error) {
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	var lockID int
+	err = psql.Select("id").
+		From("named_locks").
+		Where(sq.Eq{
+			"team_id": teamID,
+			"name":    name,
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&lockID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	_, err = psql.Delete("named_lock_claims").
+		Where(sq.Eq{"named_lock_id": lockID}).
+		Where(sq.NotEq{"acquired_at": nil}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	err = notifyNamedLocks(tx)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// releaseNamedLocksOfBuild releases every lock claimed by the build, so that
+// locks are never left held by builds which have finished or been aborted.
+func releaseNamedLocksOfBuild(tx Tx, buildID int) error {
+	result, err := psql.Delete("named_lock_claims").
+		Where(sq.Eq{"build_id": buildID}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return nil
+	}
+
+	return notifyNamedLocks(tx)
+}
+
+func notifyNamedLocks(tx Tx) error {
+	_, err := tx.Exec("NOTIFY " + namedLocksChannel)
+	return err
+}
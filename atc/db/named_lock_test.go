@@ -0,0 +1,180 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NamedLockFactory", func() {
+	var (
+		namedLockFactory db.NamedLockFactory
+		firstBuild       db.Build
+		secondBuild      db.Build
+		thirdBuild       db.Build
+	)
+
+	BeforeEach(func() {
+		namedLockFactory = db.NewNamedLockFactory(dbConn)
+
+		var err error
+		firstBuild, err = defaultJob.CreateBuild()
+		Expect(err).ToNot(HaveOccurred())
+
+		secondBuild, err = defaultJob.CreateBuild()
+		Expect(err).ToNot(HaveOccurred())
+
+		thirdBuild, err = defaultTeam.CreateOneOffBuild()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	claim := func(build db.Build, capacity int) int {
+		claimID, err := namedLockFactory.ClaimNamedLock(defaultTeam.ID(), "some-lock", capacity, build.ID(), atc.PlanID("some-plan"))
+		Expect(err).ToNot(HaveOccurred())
+		return claimID
+	}
+
+	acquire := func(claimID int) bool {
+		acquired, err := namedLockFactory.AcquireNamedLock(claimID)
+		Expect(err).ToNot(HaveOccurred())
+		return acquired
+	}
+
+	It("lets the claims acquire the lock in order", func() {
+		firstClaim := claim(firstBuild, 0)
+		secondClaim := claim(secondBuild, 0)
+
+		Expect(acquire(secondClaim)).To(BeFalse())
+		Expect(acquire(firstClaim)).To(BeTrue())
+		Expect(acquire(secondClaim)).To(BeFalse())
+
+		Expect(namedLockFactory.ReleaseNamedLock(firstClaim)).To(Succeed())
+
+		Expect(acquire(secondClaim)).To(BeTrue())
+	})
+
+	Context("when the step claims the lock again", func() {
+		It("keeps its claim", func() {
+			firstClaim := claim(firstBuild, 0)
+			secondClaim := claim(secondBuild, 0)
+
+			Expect(acquire(firstClaim)).To(BeTrue())
+
+			Expect(claim(firstBuild, 0)).To(Equal(firstClaim))
+			Expect(acquire(firstClaim)).To(BeTrue())
+
+			Expect(claim(secondBuild, 0)).To(Equal(secondClaim))
+			Expect(acquire(secondClaim)).To(BeFalse())
+		})
+	})
+
+	Context("when another step of the build claims the lock", func() {
+		It("queues it behind the first one", func() {
+			firstClaim := claim(firstBuild, 0)
+
+			otherClaim, err := namedLockFactory.ClaimNamedLock(defaultTeam.ID(), "some-lock", 0, firstBuild.ID(), atc.PlanID("some-other-plan"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(otherClaim).ToNot(Equal(firstClaim))
+
+			Expect(acquire(firstClaim)).To(BeTrue())
+			Expect(acquire(otherClaim)).To(BeFalse())
+		})
+	})
+
+	Context("when the lock has a capacity", func() {
+		It("lets that many claims hold it at once", func() {
+			firstClaim := claim(firstBuild, 2)
+			secondClaim := claim(secondBuild, 2)
+			thirdClaim := claim(thirdBuild, 2)
+
+			Expect(acquire(firstClaim)).To(BeTrue())
+			Expect(acquire(secondClaim)).To(BeTrue())
+			Expect(acquire(thirdClaim)).To(BeFalse())
+		})
+
+		Context("when it is claimed with another capacity", func() {
+			It("returns an error while the lock is claimed", func() {
+				firstClaim := claim(firstBuild, 2)
+
+				_, err := namedLockFactory.ClaimNamedLock(defaultTeam.ID(), "some-lock", 3, secondBuild.ID(), atc.PlanID("some-plan"))
+				Expect(err).To(Equal(db.NamedLockCapacityError{
+					Name:      "some-lock",
+					Capacity:  2,
+					Requested: 3,
+				}))
+
+				Expect(namedLockFactory.ReleaseNamedLock(firstClaim)).To(Succeed())
+
+				claim(secondBuild, 3)
+
+				locks, err := namedLockFactory.NamedLocks(defaultTeam.ID())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(locks).To(HaveLen(1))
+				Expect(locks[0].Capacity).To(Equal(3))
+			})
+		})
+	})
+
+	Context("when the build holding the lock finishes", func() {
+		It("releases the lock", func() {
+			firstClaim := claim(firstBuild, 0)
+			secondClaim := claim(secondBuild, 0)
+
+			Expect(acquire(firstClaim)).To(BeTrue())
+
+			Expect(firstBuild.Finish(db.BuildStatusAborted)).To(Succeed())
+
+			Expect(acquire(secondClaim)).To(BeTrue())
+		})
+	})
+
+	Describe("NamedLocks", func() {
+		It("returns the holders and waiters of the team's locks", func() {
+			firstClaim := claim(firstBuild, 0)
+			secondClaim := claim(secondBuild, 0)
+
+			Expect(acquire(firstClaim)).To(BeTrue())
+
+			locks, err := namedLockFactory.NamedLocks(defaultTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(locks).To(HaveLen(1))
+			Expect(locks[0].Name).To(Equal("some-lock"))
+			Expect(locks[0].Capacity).To(Equal(1))
+
+			Expect(locks[0].Holders).To(HaveLen(1))
+			Expect(locks[0].Holders[0].ID).To(Equal(firstClaim))
+			Expect(locks[0].Holders[0].BuildName).To(Equal(firstBuild.Name()))
+			Expect(locks[0].Holders[0].JobName).To(Equal(defaultJob.Name()))
+			Expect(locks[0].Holders[0].PipelineName).To(Equal(defaultPipeline.Name()))
+			Expect(locks[0].Holders[0].AcquiredAt).ToNot(BeZero())
+
+			Expect(locks[0].Waiters).To(HaveLen(1))
+			Expect(locks[0].Waiters[0].ID).To(Equal(secondClaim))
+			Expect(locks[0].Waiters[0].AcquiredAt).To(BeZero())
+		})
+	})
+
+	Describe("ForceReleaseNamedLock", func() {
+		It("releases the lock from its holders", func() {
+			firstClaim := claim(firstBuild, 0)
+			secondClaim := claim(secondBuild, 0)
+
+			Expect(acquire(firstClaim)).To(BeTrue())
+
+			found, err := namedLockFactory.ForceReleaseNamedLock(defaultTeam.ID(), "some-lock")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(acquire(secondClaim)).To(BeTrue())
+		})
+
+		Context("when the lock does not exist", func() {
+			It("returns false", func() {
+				found, err := namedLockFactory.ForceReleaseNamedLock(defaultTeam.ID(), "bogus-lock")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
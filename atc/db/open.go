@@ -102,6 +102,42 @@ func Open(logger lager.Logger, sqlDriver string, sqlDataSource string, newKey *e
 	}
 }
 
+// OpenReadReplica opens a connection pool to a read replica of the database
+// opened by Open. The replica is never migrated or re-encrypted, and it
+// shares the primary's notifications bus and encryption strategy, so it must
+// only be used for queries that tolerate slightly stale data.
+func OpenReadReplica(logger lager.Logger, sqlDriver string, sqlDataSource string, primary Conn, connectionName string) (Conn, error) {
+	for {
+		sqlDb, err := sql.Open(sqlDriver, sqlDataSource)
+		if err == nil {
+			err = sqlDb.Ping()
+			if err != nil {
+				_ = sqlDb.Close()
+			}
+		}
+
+		if err != nil {
+			if shouldRetry(err) {
+				logger.Error("failed-to-open-read-replica-retrying", err)
+				time.Sleep(5 * time.Second)
+				continue
+			}
+
+			return nil, err
+		}
+
+		return &readReplica{
+			db: &db{
+				DB: sqlDb,
+
+				bus:        primary.Bus(),
+				encryption: primary.EncryptionStrategy(),
+				name:       connectionName,
+			},
+		}, nil
+	}
+}
+
 func shouldRetry(err error) bool {
 	if strings.Contains(err.Error(), "dial ") {
 		return true
@@ -115,11 +151,14 @@ func shouldRetry(err error) bool {
 }
 
 var encryptedColumns = map[string]string{
-	"teams":          "legacy_auth",
-	"resources":      "config",
-	"jobs":           "config",
-	"resource_types": "config",
-	"builds":         "private_plan",
+	"teams":            "legacy_auth",
+	"resources":        "config",
+	"jobs":             "config",
+	"resource_types":   "config",
+	"builds":           "private_plan",
+	"pipeline_configs": "config",
+
+	"hijack_session_events": "data",
 }
 
 func encryptPlaintext(logger lager.Logger, sqlDB *sql.DB, key *encryption.Key) error {
@@ -362,6 +401,16 @@ func (db *db) Close() error {
 	return errs
 }
 
+// readReplica leaves the shared notifications bus to be closed along with
+// the primary connection.
+type readReplica struct {
+	*db
+}
+
+func (replica *readReplica) Close() error {
+	return replica.DB.Close()
+}
+
 // Close ignores errors, and should used with defer.
 // makes errcheck happy that those errs are captured
 func Close(c io.Closer) {
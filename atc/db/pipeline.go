@@ -27,8 +27,9 @@ func (e ErrResourceNotFound) Error() string {
 //go:generate counterfeiter . Pipeline
 
 type Cause struct {
-	ResourceVersionID int `json:"resource_version_id"`
-	BuildID           int `json:"build_id"`
+	ResourceVersionID int    `json:"resource_version_id"`
+	BuildID           int    `json:"build_id"`
+	PipelineName      string `json:"pipeline_name"`
 }
 
 type Pipeline interface {
@@ -44,6 +45,9 @@ type Pipeline interface {
 	CheckPaused() (bool, error)
 	Reload() (bool, error)
 
+	ConfigHistory() ([]PipelineConfigVersion, error)
+	HistoricalConfig(ConfigVersion) (PipelineConfigVersion, bool, error)
+
 	Causality(versionedResourceID int) ([]Cause, error)
 	ResourceVersion(resourceConfigVersionID int) (atc.ResourceVersion, bool, error)
 
@@ -95,8 +99,9 @@ type pipeline struct {
 	paused        bool
 	public        bool
 
-	cacheIndex int
-	versionsDB *algorithm.VersionsDB
+	cacheIndex         int
+	passedCacheIndexes string
+	versionsDB         *algorithm.VersionsDB
 
 	conn        Conn
 	lockFactory lock.LockFactory
@@ -158,49 +163,52 @@ func (p *pipeline) ConfigVersion() ConfigVersion { return p.configVersion }
 func (p *pipeline) Public() bool                 { return p.public }
 func (p *pipeline) Paused() bool                 { return p.paused }
 
-// IMPORTANT: This method is broken with the new resource config versions changes
+// Causality returns the builds downstream of the given resource config
+// version, following outputs into the inputs of later builds. Versions are
+// followed into other pipelines of the same team through resources with the
+// same resource config, so cross-pipeline passed constraints are included.
 func (p *pipeline) Causality(versionedResourceID int) ([]Cause, error) {
 	rows, err := p.conn.Query(`
-		WITH RECURSIVE causality(versioned_resource_id, build_id) AS (
-				SELECT bi.versioned_resource_id, bi.build_id
-				FROM build_inputs bi
-				WHERE bi.versioned_resource_id = $1
+		WITH RECURSIVE causality(resource_id, version_md5, build_id) AS (
+				SELECT i.resource_id, i.version_md5, i.build_id
+				FROM build_resource_config_version_inputs i
+				INNER JOIN resources r ON r.id = i.resource_id
+				INNER JOIN resource_config_versions v ON v.version_md5 = i.version_md5 AND v.resource_config_scope_id = r.resource_config_scope_id
+				WHERE v.id = $1
+				AND r.pipeline_id = $2
 			UNION
-				SELECT bi.versioned_resource_id, bi.build_id
-				FROM causality t
-				INNER JOIN build_outputs bo ON bo.build_id = t.build_id
-				INNER JOIN build_inputs bi ON bi.versioned_resource_id = bo.versioned_resource_id
-				INNER JOIN builds b ON b.id = bi.build_id
-				AND NOT EXISTS (
-					SELECT 1
-					FROM build_outputs obo
-					INNER JOIN builds ob ON ob.id = obo.build_id
-					WHERE obo.build_id < bi.build_id
-					AND ob.job_id = b.job_id
-					AND obo.versioned_resource_id = bi.versioned_resource_id
-				)
+				SELECT i.resource_id, i.version_md5, i.build_id
+				FROM causality c
+				INNER JOIN build_resource_config_version_outputs o ON o.build_id = c.build_id
+				INNER JOIN resources ores ON ores.id = o.resource_id
+				INNER JOIN resources ires ON ires.resource_config_id = ores.resource_config_id
+				INNER JOIN pipelines ip ON ip.id = ires.pipeline_id
+				INNER JOIN build_resource_config_version_inputs i ON i.resource_id = ires.id AND i.version_md5 = o.version_md5
+				WHERE ip.team_id = $3
 		)
-		SELECT c.versioned_resource_id, c.build_id
+		SELECT v.id, c.build_id, cp.name
 		FROM causality c
 		INNER JOIN builds b ON b.id = c.build_id
-		ORDER BY b.start_time ASC, c.versioned_resource_id ASC
-	`, versionedResourceID)
+		INNER JOIN resources r ON r.id = c.resource_id
+		INNER JOIN pipelines cp ON cp.id = r.pipeline_id
+		INNER JOIN resource_config_versions v ON v.version_md5 = c.version_md5 AND v.resource_config_scope_id = r.resource_config_scope_id
+		ORDER BY b.start_time ASC, v.id ASC
+	`, versionedResourceID, p.id, p.teamID)
 	if err != nil {
 		return nil, err
 	}
 
+	defer Close(rows)
+
 	var causality []Cause
 	for rows.Next() {
-		var vrID, buildID int
-		err := rows.Scan(&vrID, &buildID)
+		var cause Cause
+		err := rows.Scan(&cause.ResourceVersionID, &cause.BuildID, &cause.PipelineName)
 		if err != nil {
 			return nil, err
 		}
 
-		causality = append(causality, Cause{
-			ResourceVersionID: vrID,
-			BuildID:           buildID,
-		})
+		causality = append(causality, cause)
 	}
 
 	return causality, nil
@@ -701,18 +709,35 @@ func (p *pipeline) Destroy() error {
 }
 
 func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
-	var cacheIndex int
-	err := psql.Select("cache_index").
-		From("pipelines").
-		Where(sq.Eq{"id": p.id}).
+	var (
+		cacheIndex         int
+		passedCacheIndexes sql.NullString
+	)
+
+	// the versions DB also depends on builds of jobs in other pipelines
+	// referenced by passed constraints, so their cache indexes are included
+	err := psql.Select("p.cache_index").
+		Column(`(
+			SELECT string_agg(u.id || ':' || u.cache_index, ',' ORDER BY u.id)
+			FROM pipelines u
+			WHERE u.team_id = p.team_id
+			AND u.name IN (
+				SELECT x.passed_pipeline_name
+				FROM jobs_cross_pipeline_passed x
+				JOIN jobs j ON j.id = x.job_id
+				WHERE j.pipeline_id = p.id
+			)
+		)`).
+		From("pipelines p").
+		Where(sq.Eq{"p.id": p.id}).
 		RunWith(p.conn).
 		QueryRow().
-		Scan(&cacheIndex)
+		Scan(&cacheIndex, &passedCacheIndexes)
 	if err != nil {
 		return nil, err
 	}
 
-	if p.versionsDB != nil && p.cacheIndex == cacheIndex {
+	if p.versionsDB != nil && p.cacheIndex == cacheIndex && p.passedCacheIndexes == passedCacheIndexes.String {
 		return p.versionsDB, nil
 	}
 
@@ -802,7 +827,16 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		}
 	}
 
+	// versions are only needed for evaluating version filters, so they are
+	// only loaded for the resources which inputs of active jobs filter
 	rows, err = psql.Select("v.id, v.check_order, r.id").
+		Column(`CASE WHEN r.name IN (
+			SELECT f.resource_name
+			FROM jobs_filtered_inputs f
+			JOIN jobs j ON j.id = f.job_id
+			WHERE j.pipeline_id = r.pipeline_id
+			AND j.active
+		) THEN v.version END`).
 		From("resource_config_versions v").
 		Join("resources r ON r.resource_config_scope_id = v.resource_config_scope_id").
 		LeftJoin("resource_disabled_versions d ON d.resource_id = r.id AND d.version_md5 = v.version_md5").
@@ -824,11 +858,19 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 
 	for rows.Next() {
 		var output algorithm.ResourceVersion
-		err = rows.Scan(&output.VersionID, &output.CheckOrder, &output.ResourceID)
+		var versionBytes sql.NullString
+		err = rows.Scan(&output.VersionID, &output.CheckOrder, &output.ResourceID, &versionBytes)
 		if err != nil {
 			return nil, err
 		}
 
+		if versionBytes.Valid {
+			err = json.Unmarshal([]byte(versionBytes.String), &output.Version)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		db.ResourceVersions = append(db.ResourceVersions, output)
 	}
 
@@ -876,12 +918,134 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		db.ResourceIDs[name] = id
 	}
 
+	err = p.loadCrossPipelinePassed(db)
+	if err != nil {
+		return nil, err
+	}
+
 	p.versionsDB = db
 	p.cacheIndex = cacheIndex
+	p.passedCacheIndexes = passedCacheIndexes.String
 
 	return db, nil
 }
 
+// loadCrossPipelinePassed adds the jobs in other pipelines referenced by
+// passed constraints to the versions DB, keyed by 'pipeline/job', along with
+// their successful outputs.
+//
+// Versions are matched to this pipeline's resources by resource config, so
+// the resource must have the same type and source in both pipelines.
+func (p *pipeline) loadCrossPipelinePassed(db *algorithm.VersionsDB) error {
+	rows, err := psql.Select("DISTINCT x.passed_pipeline_name, x.passed_job_name, uj.id").
+		From("jobs_cross_pipeline_passed x").
+		Join("jobs j ON j.id = x.job_id").
+		Join("pipelines u ON u.name = x.passed_pipeline_name").
+		Join("jobs uj ON uj.pipeline_id = u.id AND uj.name = x.passed_job_name").
+		Where(sq.Eq{
+			"j.pipeline_id": p.id,
+			"u.team_id":     p.teamID,
+			"uj.active":     true,
+		}).
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return err
+	}
+
+	defer Close(rows)
+
+	jobIDs := []int{}
+	for rows.Next() {
+		var ref atc.PassedJobReference
+		var id int
+		err = rows.Scan(&ref.PipelineName, &ref.JobName, &id)
+		if err != nil {
+			return err
+		}
+
+		db.JobIDs[ref.String()] = id
+		jobIDs = append(jobIDs, id)
+	}
+
+	if len(jobIDs) == 0 {
+		return nil
+	}
+
+	rows, err = psql.Select("v.id, v.check_order, r.id, o.build_id, b.job_id").
+		From("build_resource_config_version_outputs o").
+		Join("builds b ON b.id = o.build_id").
+		Join("resources ur ON ur.id = o.resource_id").
+		Join("resources r ON r.resource_config_id = ur.resource_config_id").
+		Join("resource_config_versions v ON v.version_md5 = o.version_md5 AND v.resource_config_scope_id = r.resource_config_scope_id").
+		Where(sq.Expr("(r.id, v.version_md5) NOT IN (SELECT resource_id, version_md5 from resource_disabled_versions)")).
+		Where(sq.NotEq{
+			"v.check_order": 0,
+		}).
+		Where(sq.Eq{
+			"b.status":      BuildStatusSucceeded,
+			"b.job_id":      jobIDs,
+			"r.pipeline_id": p.id,
+		}).
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		var output algorithm.BuildOutput
+		err = rows.Scan(&output.VersionID, &output.CheckOrder, &output.ResourceID, &output.BuildID, &output.JobID)
+		if err != nil {
+			return err
+		}
+
+		output.ResourceVersion.CheckOrder = output.CheckOrder
+
+		db.BuildOutputs = append(db.BuildOutputs, output)
+	}
+
+	// implicit outputs
+	rows, err = psql.Select("v.id, v.check_order, r.id, i.build_id, b.job_id").
+		From("build_resource_config_version_inputs i").
+		Join("builds b ON b.id = i.build_id").
+		Join("resources ur ON ur.id = i.resource_id").
+		Join("resources r ON r.resource_config_id = ur.resource_config_id").
+		Join("resource_config_versions v ON v.version_md5 = i.version_md5 AND v.resource_config_scope_id = r.resource_config_scope_id").
+		Where(sq.Expr("(r.id, v.version_md5) NOT IN (SELECT resource_id, version_md5 from resource_disabled_versions)")).
+		Where(sq.NotEq{
+			"v.check_order": 0,
+		}).
+		Where(sq.Eq{
+			"b.status":      BuildStatusSucceeded,
+			"b.job_id":      jobIDs,
+			"r.pipeline_id": p.id,
+		}).
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		var output algorithm.BuildOutput
+		err = rows.Scan(&output.VersionID, &output.CheckOrder, &output.ResourceID, &output.BuildID, &output.JobID)
+		if err != nil {
+			return err
+		}
+
+		output.ResourceVersion.CheckOrder = output.CheckOrder
+
+		db.BuildOutputs = append(db.BuildOutputs, output)
+	}
+
+	return nil
+}
+
 func (p *pipeline) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
 	if len(buildIDs) == 0 {
 		return nil
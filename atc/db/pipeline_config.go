@@ -0,0 +1,95 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// PipelineConfigVersion is a config that was saved for a pipeline. Config is
+// only loaded when fetching a single version.
+type PipelineConfigVersion struct {
+	Version ConfigVersion
+	Config  atc.Config
+	SavedBy string
+	SavedAt time.Time
+}
+
+func (p *pipeline) ConfigHistory() ([]PipelineConfigVersion, error) {
+	rows, err := psql.Select("version, saved_by, saved_at").
+		From("pipeline_configs").
+		Where(sq.Eq{"pipeline_id": p.id}).
+		OrderBy("version DESC").
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	versions := []PipelineConfigVersion{}
+	for rows.Next() {
+		var version PipelineConfigVersion
+		var savedBy sql.NullString
+
+		err = rows.Scan(&version.Version, &savedBy, &version.SavedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		version.SavedBy = savedBy.String
+
+		versions = append(versions, version)
+	}
+
+	return versions, nil
+}
+
+func (p *pipeline) HistoricalConfig(configVersion ConfigVersion) (PipelineConfigVersion, bool, error) {
+	var (
+		version    PipelineConfigVersion
+		configBlob []byte
+		nonce      sql.NullString
+		savedBy    sql.NullString
+	)
+
+	err := psql.Select("version, config, nonce, saved_by, saved_at").
+		From("pipeline_configs").
+		Where(sq.Eq{
+			"pipeline_id": p.id,
+			"version":     configVersion,
+		}).
+		RunWith(p.conn).
+		QueryRow().
+		Scan(&version.Version, &configBlob, &nonce, &savedBy, &version.SavedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return PipelineConfigVersion{}, false, nil
+		}
+
+		return PipelineConfigVersion{}, false, err
+	}
+
+	var noncense *string
+	if nonce.Valid {
+		noncense = &nonce.String
+	}
+
+	decryptedConfig, err := p.conn.EncryptionStrategy().Decrypt(string(configBlob), noncense)
+	if err != nil {
+		return PipelineConfigVersion{}, false, err
+	}
+
+	err = json.Unmarshal(decryptedConfig, &version.Config)
+	if err != nil {
+		return PipelineConfigVersion{}, false, err
+	}
+
+	version.SavedBy = savedBy.String
+
+	return version, true, nil
+}
@@ -118,7 +118,7 @@ var _ = Describe("Pipeline", func() {
 			},
 		}
 		var created bool
-		pipeline, created, err = team.SavePipeline("fake-pipeline", pipelineConfig, db.ConfigVersion(0), db.PipelineUnpaused)
+		pipeline, created, err = team.SavePipeline("fake-pipeline", pipelineConfig, db.ConfigVersion(0), db.PipelineUnpaused, "")
 		Expect(err).ToNot(HaveOccurred())
 		Expect(created).To(BeTrue())
 
@@ -233,6 +233,65 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 
+	Describe("ConfigHistory", func() {
+		var newConfig atc.Config
+
+		BeforeEach(func() {
+			newConfig = pipelineConfig
+			newConfig.Groups = nil
+
+			var err error
+			pipeline, _, err = team.SavePipeline("fake-pipeline", newConfig, pipeline.ConfigVersion(), db.PipelineNoChange, "some-user")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns every saved version, newest first", func() {
+			history, err := pipeline.ConfigHistory()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(history).To(HaveLen(2))
+
+			Expect(history[0].Version).To(Equal(pipeline.ConfigVersion()))
+			Expect(history[0].SavedBy).To(Equal("some-user"))
+			Expect(history[0].SavedAt).To(BeTemporally("~", time.Now(), time.Minute))
+
+			Expect(history[1].Version).To(BeNumerically("<", pipeline.ConfigVersion()))
+			Expect(history[1].SavedBy).To(BeEmpty())
+		})
+
+		It("can fetch the config saved at an earlier version", func() {
+			history, err := pipeline.ConfigHistory()
+			Expect(err).ToNot(HaveOccurred())
+
+			version, found, err := pipeline.HistoricalConfig(history[1].Version)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(version.Config).To(Equal(pipelineConfig))
+
+			version, found, err = pipeline.HistoricalConfig(history[0].Version)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(version.Config).To(Equal(newConfig))
+			Expect(version.SavedBy).To(Equal("some-user"))
+		})
+
+		It("does not find versions that were never saved", func() {
+			_, found, err := pipeline.HistoricalConfig(pipeline.ConfigVersion() + 100)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		Context("when the pipeline is destroyed", func() {
+			It("removes its history", func() {
+				Expect(pipeline.Destroy()).To(Succeed())
+
+				var count int
+				err := dbConn.QueryRow("SELECT COUNT(*) FROM pipeline_configs WHERE pipeline_id = $1", pipeline.ID()).Scan(&count)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(count).To(BeZero())
+			})
+		})
+	})
+
 	Describe("Resource Config Versions", func() {
 		resourceName := "some-resource"
 		otherResourceName := "some-other-resource"
@@ -399,10 +458,10 @@ var _ = Describe("Pipeline", func() {
 			}
 
 			var err error
-			dbPipeline, _, err = team.SavePipeline("pipeline-name", pipelineConfig, 0, db.PipelineUnpaused)
+			dbPipeline, _, err = team.SavePipeline("pipeline-name", pipelineConfig, 0, db.PipelineUnpaused, "")
 			Expect(err).ToNot(HaveOccurred())
 
-			otherDBPipeline, _, err = team.SavePipeline("other-pipeline-name", otherPipelineConfig, 0, db.PipelineUnpaused)
+			otherDBPipeline, _, err = team.SavePipeline("other-pipeline-name", otherPipelineConfig, 0, db.PipelineUnpaused, "")
 			Expect(err).ToNot(HaveOccurred())
 
 			resource, _, err = dbPipeline.Resource(resourceName)
@@ -436,6 +495,56 @@ var _ = Describe("Pipeline", func() {
 			Expect(resource.Source()).To(Equal(atc.Source{"source-config": "some-value"}))
 		})
 
+		It("only loads the versions themselves for resources which inputs filter", func() {
+			filteredPipeline, _, err := team.SavePipeline("filtered-pipeline", atc.Config{
+				Resources: atc.ResourceConfigs{
+					{Name: "filtered-resource", Type: "some-type", Source: atc.Source{"some": "filtered"}},
+					{Name: "unfiltered-resource", Type: "some-type", Source: atc.Source{"some": "unfiltered"}},
+				},
+				Jobs: atc.JobConfigs{
+					{
+						Name: "some-job",
+						Plan: atc.PlanSequence{
+							{Get: "filtered-resource", Version: &atc.VersionConfig{Latest: true, Filter: "semver(tag) >=1"}},
+							{Get: "unfiltered-resource"},
+						},
+					},
+				},
+			}, 0, db.PipelineUnpaused, "")
+			Expect(err).ToNot(HaveOccurred())
+
+			expected := []algorithm.ResourceVersion{}
+			for name, version := range map[string]atc.Version{
+				"filtered-resource":   atc.Version{"tag": "1.0.0"},
+				"unfiltered-resource": nil,
+			} {
+				resource, found, err := filteredPipeline.Resource(name)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				scope, err := resource.SetResourceConfig(logger, resource.Source(), creds.VersionedResourceTypes{})
+				Expect(err).ToNot(HaveOccurred())
+
+				err = scope.SaveVersions([]atc.Version{atc.Version{"tag": "1.0.0"}})
+				Expect(err).ToNot(HaveOccurred())
+
+				savedVersion, found, err := scope.LatestVersion()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				expected = append(expected, algorithm.ResourceVersion{
+					VersionID:  savedVersion.ID(),
+					ResourceID: resource.ID(),
+					CheckOrder: savedVersion.CheckOrder(),
+					Version:    version,
+				})
+			}
+
+			versions, err := filteredPipeline.LoadVersionsDB()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versions.ResourceVersions).To(ConsistOf(expected))
+		})
+
 		It("can load up resource config version information relevant to scheduling", func() {
 			job, found, err := dbPipeline.Job("some-job")
 			Expect(found).To(BeTrue())
@@ -755,6 +864,75 @@ var _ = Describe("Pipeline", func() {
 			}))
 		})
 
+		It("includes outputs of jobs in other pipelines referenced by passed constraints", func() {
+			downstreamPipeline, _, err := team.SavePipeline("downstream", atc.Config{
+				Resources: atc.ResourceConfigs{
+					{
+						Name:   "renamed-resource",
+						Type:   "some-type",
+						Source: atc.Source{"source-config": "some-value"},
+					},
+				},
+				Jobs: atc.JobConfigs{
+					{
+						Name: "promote",
+						Plan: atc.PlanSequence{
+							{
+								Get:      "some-input",
+								Resource: "renamed-resource",
+								Passed:   []string{"pipeline-name/a-job"},
+							},
+						},
+					},
+				},
+			}, 0, db.PipelineUnpaused, "")
+			Expect(err).ToNot(HaveOccurred())
+
+			downstreamResource, _, err := downstreamPipeline.Resource("renamed-resource")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = downstreamResource.SetResourceConfig(logger, atc.Source{"source-config": "some-value"}, creds.VersionedResourceTypes{})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = resourceConfigScope.SaveVersions([]atc.Version{{"version": "1"}})
+			Expect(err).ToNot(HaveOccurred())
+
+			savedVR, found, err := resourceConfigScope.LatestVersion()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			aJob, found, err := dbPipeline.Job("a-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			versions, err := downstreamPipeline.LoadVersionsDB()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versions.JobIDs).To(HaveKeyWithValue("pipeline-name/a-job", aJob.ID()))
+			Expect(versions.BuildOutputs).To(BeEmpty())
+
+			By("including outputs of the upstream job once a build succeeds")
+			build, err := aJob.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.SaveOutput(logger, "some-type", atc.Source{"source-config": "some-value"}, creds.VersionedResourceTypes{}, atc.Version{"version": "1"}, nil, "some-output-name", "some-resource")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+
+			versions, err = downstreamPipeline.LoadVersionsDB()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versions.BuildOutputs).To(ConsistOf(algorithm.BuildOutput{
+				ResourceVersion: algorithm.ResourceVersion{
+					VersionID:  savedVR.ID(),
+					ResourceID: downstreamResource.ID(),
+					CheckOrder: savedVR.CheckOrder(),
+				},
+				JobID:   aJob.ID(),
+				BuildID: build.ID(),
+			}))
+		})
+
 		It("can load up the latest versioned resource, enabled or not", func() {
 			By("initially having no latest versioned resource")
 			_, found, err := resourceConfigScope.LatestVersion()
@@ -970,7 +1148,7 @@ var _ = Describe("Pipeline", func() {
 				},
 			}
 			var err error
-			pipelineDB, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
+			pipelineDB, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused, "")
 			Expect(err).ToNot(HaveOccurred())
 
 			var found bool
@@ -1211,7 +1389,7 @@ var _ = Describe("Pipeline", func() {
 				},
 			}
 			var err error
-			otherPipeline, _, err = team.SavePipeline("other-pipeline-name", otherPipelineConfig, 0, db.PipelineUnpaused)
+			otherPipeline, _, err = team.SavePipeline("other-pipeline-name", otherPipelineConfig, 0, db.PipelineUnpaused, "")
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -2172,7 +2350,7 @@ var _ = Describe("Pipeline", func() {
 					},
 				},
 			}
-			pipeline, _, err = team.SavePipeline("some-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+			pipeline, _, err = team.SavePipeline("some-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused, "")
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err = pipeline.Job("some-job")
@@ -2192,7 +2370,7 @@ var _ = Describe("Pipeline", func() {
 				Expect(found).To(BeTrue())
 			}
 
-			otherPipeline, _, err := team.SavePipeline("another-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+			otherPipeline, _, err := team.SavePipeline("another-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused, "")
 			Expect(err).ToNot(HaveOccurred())
 
 			otherJob, found, err := otherPipeline.Job("some-job")
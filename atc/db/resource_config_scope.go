@@ -304,14 +304,14 @@ func incrementCheckOrder(tx Tx, r ResourceConfigScope, version string) error {
 	return err
 }
 
-func bumpCacheIndexForPipelinesUsingResourceConfigScope(conn Conn, rcsID int) error {
+func bumpCacheIndexForPipelinesUsingResourceConfigScope(runner sq.BaseRunner, rcsID int) error {
 	rows, err := psql.Select("p.id").
 		From("pipelines p").
 		Join("resources r ON r.pipeline_id = p.id").
 		Where(sq.Eq{
 			"r.resource_config_scope_id": rcsID,
 		}).
-		RunWith(conn).
+		RunWith(runner).
 		Query()
 	if err != nil {
 		return err
@@ -334,7 +334,7 @@ func bumpCacheIndexForPipelinesUsingResourceConfigScope(conn Conn, rcsID int) er
 			Where(sq.Eq{
 				"id": p,
 			}).
-			RunWith(conn).
+			RunWith(runner).
 			Exec()
 		if err != nil {
 			return err
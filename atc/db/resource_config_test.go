@@ -34,6 +34,7 @@ var _ = Describe("ResourceConfig", func() {
 				config,
 				0,
 				db.PipelineUnpaused,
+				"",
 			)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(created).To(BeTrue())
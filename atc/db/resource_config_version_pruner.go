@@ -0,0 +1,202 @@
+package db
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+//go:generate counterfeiter . ResourceConfigVersionPruner
+
+// ResourceConfigVersionPruner removes resource config versions that fall
+// outside the version retention of every resource using them.
+type ResourceConfigVersionPruner interface {
+	PruneVersions(defaults atc.VersionRetention) (int, error)
+}
+
+type resourceConfigVersionPruner struct {
+	conn Conn
+}
+
+func NewResourceConfigVersionPruner(conn Conn) ResourceConfigVersionPruner {
+	return &resourceConfigVersionPruner{
+		conn: conn,
+	}
+}
+
+// PruneVersions applies each active resource's version retention, falling
+// back to the defaults for resources that don't configure one, and returns
+// the number of versions removed.
+//
+// The newest version of every scope is always kept, so that a resource that
+// hasn't changed in a while still has a version. A scope shared by several
+// resources keeps any version that one of them would keep. Versions used by
+// builds, pinned through the API or the config, or disabled are never
+// removed.
+func (p *resourceConfigVersionPruner) PruneVersions(defaults atc.VersionRetention) (int, error) {
+	retentions, err := p.scopeRetentions(defaults)
+	if err != nil {
+		return 0, err
+	}
+
+	pruned := 0
+	for scopeID, retention := range retentions {
+		affected, err := p.pruneScope(scopeID, retention)
+		if err != nil {
+			return pruned, err
+		}
+
+		pruned += affected
+	}
+
+	return pruned, nil
+}
+
+// pruneScope removes the versions of a scope that fall outside its retention.
+// Pipelines using the scope have their cache index bumped in the same
+// transaction, so that they don't go on scheduling with removed versions.
+func (p *resourceConfigVersionPruner) pruneScope(scopeID int, retention atc.VersionRetention) (int, error) {
+	tx, err := p.conn.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	defer Rollback(tx)
+
+	result, err := tx.Exec(`
+		DELETE FROM resource_config_versions v
+		WHERE v.id IN (
+			SELECT ranked.id
+			FROM (
+				SELECT id, created_at, rank() OVER (ORDER BY check_order DESC) AS rank
+				FROM resource_config_versions
+				WHERE resource_config_scope_id = $1
+				AND check_order <> 0
+			) ranked
+			WHERE ranked.rank > GREATEST($2, 1)
+			AND ($3 = 0 OR ranked.created_at < NOW() - ($3 * interval '1 day'))
+		)
+		AND NOT EXISTS (
+			SELECT 1
+			FROM build_resource_config_version_inputs i
+			JOIN resources r ON r.id = i.resource_id
+			WHERE r.resource_config_scope_id = v.resource_config_scope_id
+			AND i.version_md5 = v.version_md5
+		)
+		AND NOT EXISTS (
+			SELECT 1
+			FROM build_resource_config_version_outputs o
+			JOIN resources r ON r.id = o.resource_id
+			WHERE r.resource_config_scope_id = v.resource_config_scope_id
+			AND o.version_md5 = v.version_md5
+		)
+		AND NOT EXISTS (
+			SELECT 1
+			FROM resource_disabled_versions d
+			JOIN resources r ON r.id = d.resource_id
+			WHERE r.resource_config_scope_id = v.resource_config_scope_id
+			AND d.version_md5 = v.version_md5
+		)
+		AND NOT EXISTS (
+			SELECT 1
+			FROM resource_pins rp
+			JOIN resources r ON r.id = rp.resource_id
+			WHERE r.resource_config_scope_id = v.resource_config_scope_id
+			AND v.version @> rp.version
+		)
+		AND NOT EXISTS (
+			SELECT 1
+			FROM resources r, jsonb_array_elements(r.config_pinned_versions) pv
+			WHERE r.resource_config_scope_id = v.resource_config_scope_id
+			AND v.version @> pv.value
+		)
+	`, scopeID, retention.Latest, retention.Days)
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if affected > 0 {
+		err = bumpCacheIndexForPipelinesUsingResourceConfigScope(tx, scopeID)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(affected), nil
+}
+
+// scopeRetentions merges the retention of the active resources using each
+// scope. Scopes that any resource keeps forever, or that also hold resource
+// type versions, are left out.
+func (p *resourceConfigVersionPruner) scopeRetentions(defaults atc.VersionRetention) (map[int]atc.VersionRetention, error) {
+	rows, err := psql.Select("r.resource_config_scope_id, r.version_retention_latest, r.version_retention_days").
+		From("resources r").
+		Join("resource_config_scopes s ON s.id = r.resource_config_scope_id").
+		Where(sq.Eq{"r.active": true}).
+		Where(sq.Expr("NOT EXISTS (SELECT 1 FROM resource_types t WHERE t.resource_config_id = s.resource_config_id)")).
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	retentions := map[int]atc.VersionRetention{}
+	keepForever := map[int]bool{}
+
+	for rows.Next() {
+		var (
+			scopeID      int
+			latest, days sql.NullInt64
+			retention    atc.VersionRetention
+		)
+
+		err = rows.Scan(&scopeID, &latest, &days)
+		if err != nil {
+			return nil, err
+		}
+
+		if latest.Valid || days.Valid {
+			retention = atc.VersionRetention{
+				Latest: int(latest.Int64),
+				Days:   int(days.Int64),
+			}
+		} else {
+			retention = defaults
+		}
+
+		if retention.Latest == 0 && retention.Days == 0 {
+			keepForever[scopeID] = true
+			continue
+		}
+
+		merged := retentions[scopeID]
+		if retention.Latest > merged.Latest {
+			merged.Latest = retention.Latest
+		}
+
+		if retention.Days > merged.Days {
+			merged.Days = retention.Days
+		}
+
+		retentions[scopeID] = merged
+	}
+
+	for scopeID := range keepForever {
+		delete(retentions, scopeID)
+	}
+
+	return retentions, nil
+}
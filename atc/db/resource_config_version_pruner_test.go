@@ -0,0 +1,175 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ResourceConfigVersionPruner", func() {
+	var (
+		pruner        db.ResourceConfigVersionPruner
+		pipeline      db.Pipeline
+		resource      db.Resource
+		resourceScope db.ResourceConfigScope
+		retention     *atc.VersionRetention
+		defaults      atc.VersionRetention
+	)
+
+	BeforeEach(func() {
+		pruner = db.NewResourceConfigVersionPruner(dbConn)
+		retention = &atc.VersionRetention{Latest: 2}
+		defaults = atc.VersionRetention{}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		pipeline, _, err = defaultTeam.SavePipeline("retention-pipeline", atc.Config{
+			Resources: atc.ResourceConfigs{
+				{
+					Name:             "some-resource",
+					Type:             "some-base-resource-type",
+					Source:           atc.Source{"some": "retention"},
+					VersionRetention: retention,
+				},
+			},
+		}, db.ConfigVersion(0), db.PipelineUnpaused, "")
+		Expect(err).ToNot(HaveOccurred())
+
+		var found bool
+		resource, found, err = pipeline.Resource("some-resource")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		resourceScope, err = resource.SetResourceConfig(logger, atc.Source{"some": "retention"}, creds.VersionedResourceTypes{})
+		Expect(err).ToNot(HaveOccurred())
+
+		err = resourceScope.SaveVersions([]atc.Version{
+			{"ref": "v1"},
+			{"ref": "v2"},
+			{"ref": "v3"},
+			{"ref": "v4"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	versionExists := func(ref string) bool {
+		_, found, err := resourceScope.FindVersion(atc.Version{"ref": ref})
+		Expect(err).ToNot(HaveOccurred())
+		return found
+	}
+
+	It("keeps only the latest versions", func() {
+		pruned, err := pruner.PruneVersions(defaults)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pruned).To(Equal(2))
+
+		Expect(versionExists("v1")).To(BeFalse())
+		Expect(versionExists("v2")).To(BeFalse())
+		Expect(versionExists("v3")).To(BeTrue())
+		Expect(versionExists("v4")).To(BeTrue())
+	})
+
+	It("stops the pipeline's cached versions from including the removed versions", func() {
+		versions, err := pipeline.LoadVersionsDB()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(versions.ResourceVersions).To(HaveLen(4))
+
+		_, err = pruner.PruneVersions(defaults)
+		Expect(err).ToNot(HaveOccurred())
+
+		versions, err = pipeline.LoadVersionsDB()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(versions.ResourceVersions).To(HaveLen(2))
+	})
+
+	Context("when an old version is disabled", func() {
+		JustBeforeEach(func() {
+			version, found, err := resourceScope.FindVersion(atc.Version{"ref": "v1"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			err = resource.DisableVersion(version.ID())
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("keeps the disabled version", func() {
+			pruned, err := pruner.PruneVersions(defaults)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pruned).To(Equal(1))
+
+			Expect(versionExists("v1")).To(BeTrue())
+			Expect(versionExists("v2")).To(BeFalse())
+		})
+	})
+
+	Context("when an old version is pinned", func() {
+		JustBeforeEach(func() {
+			version, found, err := resourceScope.FindVersion(atc.Version{"ref": "v2"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			err = resource.PinVersion(version.ID())
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("keeps the pinned version", func() {
+			_, err := pruner.PruneVersions(defaults)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(versionExists("v1")).To(BeFalse())
+			Expect(versionExists("v2")).To(BeTrue())
+		})
+	})
+
+	Context("when only a number of days is retained", func() {
+		BeforeEach(func() {
+			retention = &atc.VersionRetention{Days: 1}
+		})
+
+		JustBeforeEach(func() {
+			_, err := dbConn.Exec(`
+				UPDATE resource_config_versions
+				SET created_at = NOW() - interval '2 days'
+				WHERE resource_config_scope_id = $1
+			`, resourceScope.ID())
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("keeps the latest version even though it has expired", func() {
+			pruned, err := pruner.PruneVersions(defaults)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pruned).To(Equal(3))
+
+			Expect(versionExists("v3")).To(BeFalse())
+			Expect(versionExists("v4")).To(BeTrue())
+		})
+	})
+
+	Context("when the resource does not configure a retention", func() {
+		BeforeEach(func() {
+			retention = nil
+		})
+
+		It("keeps every version by default", func() {
+			pruned, err := pruner.PruneVersions(defaults)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pruned).To(BeZero())
+		})
+
+		Context("when defaults are given", func() {
+			BeforeEach(func() {
+				defaults = atc.VersionRetention{Latest: 3}
+			})
+
+			It("applies the defaults", func() {
+				pruned, err := pruner.PruneVersions(defaults)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pruned).To(Equal(1))
+				Expect(versionExists("v1")).To(BeFalse())
+			})
+		})
+	})
+})
@@ -55,6 +55,7 @@ var _ = Describe("Resource", func() {
 			},
 			0,
 			db.PipelineUnpaused,
+			"",
 		)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(created).To(BeTrue())
@@ -242,6 +243,7 @@ var _ = Describe("Resource", func() {
 				config,
 				0,
 				db.PipelineUnpaused,
+				"",
 			)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(created).To(BeTrue())
@@ -462,6 +464,7 @@ var _ = Describe("Resource", func() {
 							config,
 							pipeline.ConfigVersion(),
 							db.PipelineUnpaused,
+							"",
 						)
 						Expect(err).ToNot(HaveOccurred())
 
@@ -494,6 +497,7 @@ var _ = Describe("Resource", func() {
 							config,
 							pipeline.ConfigVersion(),
 							db.PipelineUnpaused,
+							"",
 						)
 						Expect(err).ToNot(HaveOccurred())
 
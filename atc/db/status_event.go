@@ -0,0 +1,360 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+var ErrStatusEventStreamClosed = errors.New("status event stream closed")
+
+const statusEventsChannel = "status_events"
+
+// IDs are assigned to events when they are inserted, but the events only become
+// visible once their transaction commits, so an event can show up after events
+// with higher IDs. IDs skipped over by a stream are looked up again until they
+// show up or statusEventGapTimeout passes, after which their transaction is
+// assumed to have rolled back.
+const (
+	statusEventGapTimeout = time.Minute
+	maxStatusEventGaps    = 1000
+)
+
+type StatusEvent struct {
+	ID           int64
+	Type         atc.StatusEventType
+	TeamName     string
+	PipelineName string
+	JobName      string
+	BuildID      int
+	BuildName    string
+	BuildStatus  BuildStatus
+	CreatedAt    time.Time
+}
+
+// StatusEventFilter narrows down the events streamed by StatusEvents. Events
+// are visible if they belong to one of the VisibleTeams or to a public
+// pipeline, or always if AllTeams is set. The remaining fields are ignored
+// when empty.
+type StatusEventFilter struct {
+	VisibleTeams []string
+	AllTeams     bool
+
+	TeamName     string
+	PipelineName string
+	JobName      string
+}
+
+//go:generate counterfeiter . StatusEventFactory
+
+type StatusEventFactory interface {
+	StatusEvents(filter StatusEventFilter, after int64) (StatusEventSource, error)
+	DeleteStatusEventsBefore(cutoff time.Time) error
+}
+
+//go:generate counterfeiter . StatusEventSource
+
+type StatusEventSource interface {
+	Next() (StatusEvent, error)
+	Close() error
+}
+
+type statusEventFactory struct {
+	conn Conn
+}
+
+func NewStatusEventFactory(conn Conn) StatusEventFactory {
+	return &statusEventFactory{
+		conn: conn,
+	}
+}
+
+// StatusEvents streams the events matching the filter which come after the
+// given event ID. An ID of zero streams only events that happen from now on.
+func (f *statusEventFactory) StatusEvents(filter StatusEventFilter, after int64) (StatusEventSource, error) {
+	if after == 0 {
+		err := psql.Select("COALESCE(MAX(id), 0)").
+			From("status_events").
+			RunWith(f.conn).
+			QueryRow().
+			Scan(&after)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	notifier, err := newConditionNotifier(f.conn.Bus(), statusEventsChannel, func() (bool, error) {
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	source := &statusEventSource{
+		conn:     f.conn,
+		notifier: notifier,
+		where:    filter.conditions(),
+		gaps:     map[int64]time.Time{},
+
+		events: make(chan StatusEvent, 100),
+		stop:   make(chan struct{}),
+		wg:     new(sync.WaitGroup),
+	}
+
+	source.wg.Add(1)
+	go source.collectEvents(after)
+
+	return source, nil
+}
+
+func (f *statusEventFactory) DeleteStatusEventsBefore(cutoff time.Time) error {
+	_, err := psql.Delete("status_events").
+		Where(sq.Lt{"created_at": cutoff}).
+		RunWith(f.conn).
+		Exec()
+
+	return err
+}
+
+func (filter StatusEventFilter) conditions() sq.And {
+	where := sq.And{}
+
+	if !filter.AllTeams {
+		where = append(where, sq.Or{
+			sq.Eq{"team_name": filter.VisibleTeams},
+			sq.Expr("pipeline_id IN (SELECT id FROM pipelines WHERE public)"),
+		})
+	}
+
+	if filter.TeamName != "" {
+		where = append(where, sq.Eq{"team_name": filter.TeamName})
+	}
+
+	if filter.PipelineName != "" {
+		where = append(where, sq.Eq{"pipeline_name": filter.PipelineName})
+	}
+
+	if filter.JobName != "" {
+		where = append(where, sq.Eq{"job_name": filter.JobName})
+	}
+
+	return where
+}
+
+type statusEventSource struct {
+	conn     Conn
+	notifier Notifier
+	where    sq.And
+
+	// gaps are the IDs below the cursor which have not shown up yet, along
+	// with when they were first skipped
+	gaps map[int64]time.Time
+
+	events chan StatusEvent
+	stop   chan struct{}
+	err    error
+	wg     *sync.WaitGroup
+}
+
+func (source *statusEventSource) Next() (StatusEvent, error) {
+	e, ok := <-source.events
+	if !ok {
+		return StatusEvent{}, source.err
+	}
+
+	return e, nil
+}
+
+func (source *statusEventSource) Close() error {
+	select {
+	case <-source.stop:
+		return nil
+	default:
+		close(source.stop)
+	}
+
+	source.wg.Wait()
+
+	return source.notifier.Close()
+}
+
+func (source *statusEventSource) collectEvents(cursor int64) {
+	defer source.wg.Done()
+
+	var batchSize = cap(source.events)
+
+	for {
+		select {
+		case <-source.stop:
+			source.err = ErrStatusEventStreamClosed
+			close(source.events)
+			return
+		default:
+		}
+
+		rows, err := psql.Select("id, type, team_name, pipeline_name, job_name, build_id, build_name, build_status, created_at").
+			Column(sq.Alias(source.where, "visible")).
+			From("status_events").
+			Where(sq.Or{
+				sq.Gt{"id": cursor},
+				sq.Eq{"id": source.pendingGaps()},
+			}).
+			OrderBy("id ASC").
+			Limit(uint64(batchSize)).
+			RunWith(source.conn).
+			Query()
+		if err != nil {
+			source.err = err
+			close(source.events)
+			return
+		}
+
+		rowsReturned := 0
+
+		for rows.Next() {
+			rowsReturned++
+
+			ev, visible, err := scanStatusEvent(rows)
+			if err != nil {
+				_ = rows.Close()
+
+				source.err = err
+				close(source.events)
+				return
+			}
+
+			if ev.ID > cursor {
+				source.skip(cursor, ev.ID)
+				cursor = ev.ID
+			} else {
+				delete(source.gaps, ev.ID)
+			}
+
+			// events hidden by the filter are still read so that they are
+			// not mistaken for gaps
+			if !visible {
+				continue
+			}
+
+			select {
+			case source.events <- ev:
+			case <-source.stop:
+				_ = rows.Close()
+
+				source.err = ErrStatusEventStreamClosed
+				close(source.events)
+				return
+			}
+		}
+
+		if rowsReturned == batchSize {
+			// still more events
+			continue
+		}
+
+		select {
+		case <-source.notifier.Notify():
+		case <-source.stop:
+			source.err = ErrStatusEventStreamClosed
+			close(source.events)
+			return
+		}
+	}
+}
+
+// skip records the IDs between the cursor and the next event as gaps.
+func (source *statusEventSource) skip(cursor int64, next int64) {
+	now := time.Now()
+
+	for id := cursor + 1; id < next && len(source.gaps) < maxStatusEventGaps; id++ {
+		source.gaps[id] = now
+	}
+}
+
+// pendingGaps forgets the gaps which have been waited on for too long and
+// returns the rest.
+func (source *statusEventSource) pendingGaps() []int64 {
+	ids := []int64{}
+	for id, skipped := range source.gaps {
+		if time.Since(skipped) > statusEventGapTimeout {
+			delete(source.gaps, id)
+			continue
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+func scanStatusEvent(row scannable) (StatusEvent, bool, error) {
+	var (
+		ev                                            StatusEvent
+		eventType                                     string
+		pipelineName, jobName, buildName, buildStatus sql.NullString
+		buildID                                       sql.NullInt64
+		visible                                       bool
+	)
+
+	err := row.Scan(&ev.ID, &eventType, &ev.TeamName, &pipelineName, &jobName, &buildID, &buildName, &buildStatus, &ev.CreatedAt, &visible)
+	if err != nil {
+		return StatusEvent{}, false, err
+	}
+
+	ev.Type = atc.StatusEventType(eventType)
+	ev.PipelineName = pipelineName.String
+	ev.JobName = jobName.String
+	ev.BuildID = int(buildID.Int64)
+	ev.BuildName = buildName.String
+	ev.BuildStatus = BuildStatus(buildStatus.String)
+
+	return ev, visible, nil
+}
+
+// saveBuildStatusEvent records an event for the build as it is within the
+// transaction. Listeners are notified once the transaction commits.
+func saveBuildStatusEvent(tx Tx, buildID int, eventType atc.StatusEventType) error {
+	_, err := tx.Exec(`
+		INSERT INTO status_events (type, team_id, team_name, pipeline_id, pipeline_name, job_id, job_name, build_id, build_name, build_status)
+		SELECT $1, b.team_id, t.name, b.pipeline_id, p.name, b.job_id, j.name, b.id, b.name, b.status
+		FROM builds b
+		JOIN teams t ON t.id = b.team_id
+		LEFT JOIN pipelines p ON p.id = b.pipeline_id
+		LEFT JOIN jobs j ON j.id = b.job_id
+		WHERE b.id = $2
+	`, string(eventType), buildID)
+	if err != nil {
+		return err
+	}
+
+	return notifyStatusEvent(tx)
+}
+
+// saveJobStatusEvent records an event for the job. Listeners are notified once
+// the transaction commits.
+func saveJobStatusEvent(tx Tx, jobID int, eventType atc.StatusEventType) error {
+	_, err := tx.Exec(`
+		INSERT INTO status_events (type, team_id, team_name, pipeline_id, pipeline_name, job_id, job_name)
+		SELECT $1, p.team_id, t.name, p.id, p.name, j.id, j.name
+		FROM jobs j
+		JOIN pipelines p ON p.id = j.pipeline_id
+		JOIN teams t ON t.id = p.team_id
+		WHERE j.id = $2
+	`, string(eventType), jobID)
+	if err != nil {
+		return err
+	}
+
+	return notifyStatusEvent(tx)
+}
+
+// notifyStatusEvent notifies within the transaction, which Postgres delivers
+// only if and when it commits.
+func notifyStatusEvent(tx Tx) error {
+	_, err := tx.Exec("NOTIFY " + statusEventsChannel)
+	return err
+}
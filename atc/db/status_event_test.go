@@ -0,0 +1,195 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StatusEventFactory", func() {
+	var statusEventFactory db.StatusEventFactory
+
+	BeforeEach(func() {
+		statusEventFactory = db.NewStatusEventFactory(dbConn)
+	})
+
+	Describe("StatusEvents", func() {
+		var (
+			source db.StatusEventSource
+			filter db.StatusEventFilter
+		)
+
+		BeforeEach(func() {
+			filter = db.StatusEventFilter{VisibleTeams: []string{defaultTeam.Name()}}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			source, err = statusEventFactory.StatusEvents(filter, 0)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(source.Close()).To(Succeed())
+		})
+
+		It("streams build and job changes as they happen", func() {
+			build, err := defaultJob.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			started, err := build.Start(atc.Plan{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			err = build.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = defaultJob.Pause()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = defaultJob.Unpause()
+			Expect(err).ToNot(HaveOccurred())
+
+			var events []db.StatusEvent
+			for i := 0; i < 5; i++ {
+				ev, err := source.Next()
+				Expect(err).ToNot(HaveOccurred())
+				events = append(events, ev)
+			}
+
+			Expect(events[0].Type).To(Equal(atc.StatusEventBuildCreated))
+			Expect(events[0].TeamName).To(Equal(defaultTeam.Name()))
+			Expect(events[0].PipelineName).To(Equal(defaultPipeline.Name()))
+			Expect(events[0].JobName).To(Equal(defaultJob.Name()))
+			Expect(events[0].BuildID).To(Equal(build.ID()))
+			Expect(events[0].BuildName).To(Equal(build.Name()))
+			Expect(events[0].BuildStatus).To(Equal(db.BuildStatusPending))
+
+			Expect(events[1].Type).To(Equal(atc.StatusEventBuildStarted))
+			Expect(events[1].BuildStatus).To(Equal(db.BuildStatusStarted))
+
+			Expect(events[2].Type).To(Equal(atc.StatusEventBuildFinished))
+			Expect(events[2].BuildStatus).To(Equal(db.BuildStatusSucceeded))
+
+			Expect(events[3].Type).To(Equal(atc.StatusEventJobPaused))
+			Expect(events[3].JobName).To(Equal(defaultJob.Name()))
+			Expect(events[3].BuildID).To(BeZero())
+
+			Expect(events[4].Type).To(Equal(atc.StatusEventJobUnpaused))
+		})
+
+		Context("when an earlier event commits after a later one", func() {
+			It("still streams the earlier event", func() {
+				tx, err := dbConn.Begin()
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = tx.Exec(`
+					INSERT INTO status_events (type, team_id, team_name, pipeline_id, pipeline_name, job_id, job_name)
+					VALUES ($1, $2, $3, $4, $5, $6, $7)
+				`, string(atc.StatusEventJobPaused), defaultTeam.ID(), defaultTeam.Name(), defaultPipeline.ID(), defaultPipeline.Name(), defaultJob.ID(), defaultJob.Name())
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = defaultJob.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				later, err := source.Next()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(later.Type).To(Equal(atc.StatusEventBuildCreated))
+
+				_, err = tx.Exec("NOTIFY status_events")
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(tx.Commit()).To(Succeed())
+
+				earlier, err := source.Next()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(earlier.Type).To(Equal(atc.StatusEventJobPaused))
+				Expect(earlier.ID).To(BeNumerically("<", later.ID))
+			})
+		})
+
+		Context("when filtering by job", func() {
+			BeforeEach(func() {
+				filter.JobName = "some-other-job"
+			})
+
+			It("skips events of other jobs", func() {
+				_, err := defaultJob.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = defaultTeam.CreateOneOffBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				events := make(chan db.StatusEvent, 1)
+				go func() {
+					defer GinkgoRecover()
+
+					ev, err := source.Next()
+					if err == nil {
+						events <- ev
+					}
+				}()
+
+				Consistently(events).ShouldNot(Receive())
+			})
+		})
+
+		Context("when the events belong to another team", func() {
+			var otherTeam db.Team
+
+			BeforeEach(func() {
+				var err error
+				otherTeam, err = teamFactory.CreateTeam(atc.Team{Name: "some-other-team"})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("only streams events of public pipelines", func() {
+				_, err := otherTeam.CreateOneOffBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				pipeline, _, err := otherTeam.SavePipeline("public-pipeline", atc.Config{
+					Jobs: atc.JobConfigs{{Name: "public-job"}},
+				}, db.ConfigVersion(0), db.PipelineUnpaused, "")
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pipeline.Expose()).To(Succeed())
+
+				job, found, err := pipeline.Job("public-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(job.Pause()).To(Succeed())
+
+				ev, err := source.Next()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(ev.Type).To(Equal(atc.StatusEventJobPaused))
+				Expect(ev.TeamName).To(Equal("some-other-team"))
+				Expect(ev.JobName).To(Equal("public-job"))
+			})
+		})
+	})
+
+	Describe("DeleteStatusEventsBefore", func() {
+		It("removes older events", func() {
+			_, err := defaultJob.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = dbConn.Exec("UPDATE status_events SET created_at = NOW() - '2 hours'::interval")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = statusEventFactory.DeleteStatusEventsBefore(time.Now().Add(-time.Hour))
+			Expect(err).ToNot(HaveOccurred())
+
+			var count int
+			err = dbConn.QueryRow("SELECT COUNT(*) FROM status_events").Scan(&count)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(Equal(1))
+		})
+	})
+})
@@ -0,0 +1,178 @@
+package db
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// TaskResult is a successful task run whose outputs can be reused by later
+// runs with the same key. Outputs maps each output name to the handle of the
+// volume holding it.
+type TaskResult struct {
+	ID           int
+	BuildID      int
+	BuildName    string
+	JobName      string
+	PipelineName string
+	Outputs      map[string]string
+}
+
+//go:generate counterfeiter . TaskResultFactory
+
+type TaskResultFactory interface {
+	FindTaskResult(teamID int, key string) (TaskResult, bool, error)
+	SaveTaskResult(teamID int, key string, buildID int, outputs map[string]int) error
+	UseTaskResult(id int) error
+}
+
+type taskResultFactory struct {
+	conn Conn
+}
+
+func NewTaskResultFactory(conn Conn) TaskResultFactory {
+	return &taskResultFactory{
+		conn: conn,
+	}
+}
+
+// FindTaskResult returns the most recent result for the key whose outputs
+// are all still present as created volumes.
+func (f *taskResultFactory) FindTaskResult(teamID int, key string) (TaskResult, bool, error) {
+	rows, err := psql.Select("r.id, r.build_id, b.name, j.name, p.name, r.outputs").
+		From("task_results r").
+		Join("builds b ON b.id = r.build_id").
+		LeftJoin("jobs j ON j.id = b.job_id").
+		LeftJoin("pipelines p ON p.id = b.pipeline_id").
+		Where(sq.Eq{
+			"r.team_id": teamID,
+			"r.key":     key,
+		}).
+		OrderBy("r.id DESC").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return TaskResult{}, false, err
+	}
+
+	type candidate struct {
+		result  TaskResult
+		outputs int
+	}
+
+	var candidates []candidate
+	for rows.Next() {
+		var c candidate
+		var jobName, pipelineName sql.NullString
+
+		err = rows.Scan(&c.result.ID, &c.result.BuildID, &c.result.BuildName, &jobName, &pipelineName, &c.outputs)
+		if err != nil {
+			Close(rows)
+			return TaskResult{}, false, err
+		}
+
+		c.result.JobName = jobName.String
+		c.result.PipelineName = pipelineName.String
+
+		candidates = append(candidates, c)
+	}
+
+	Close(rows)
+
+	for _, c := range candidates {
+		outputs, err := f.resultOutputs(teamID, c.result.ID)
+		if err != nil {
+			return TaskResult{}, false, err
+		}
+
+		if len(outputs) != c.outputs {
+			continue
+		}
+
+		c.result.Outputs = outputs
+
+		return c.result, true, nil
+	}
+
+	return TaskResult{}, false, nil
+}
+
+func (f *taskResultFactory) resultOutputs(teamID int, resultID int) (map[string]string, error) {
+	rows, err := psql.Select("o.name, v.handle").
+		From("task_result_outputs o").
+		Join("volumes v ON v.worker_artifact_id = o.worker_artifact_id").
+		Where(sq.Eq{
+			"o.task_result_id": resultID,
+			"v.team_id":        teamID,
+			"v.state":          VolumeStateCreated,
+		}).
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	outputs := map[string]string{}
+	for rows.Next() {
+		var name, handle string
+
+		err = rows.Scan(&name, &handle)
+		if err != nil {
+			return nil, err
+		}
+
+		outputs[name] = handle
+	}
+
+	return outputs, nil
+}
+
+// SaveTaskResult records a successful run under the key, given the worker
+// artifact ID of each of its outputs.
+func (f *taskResultFactory) SaveTaskResult(teamID int, key string, buildID int, outputs map[string]int) error {
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	var resultID int
+	err = psql.Insert("task_results").
+		Columns("team_id", "key", "build_id", "outputs").
+		Values(teamID, key, buildID, len(outputs)).
+		Suffix("RETURNING id").
+		RunWith(tx).
+		QueryRow().
+		Scan(&resultID)
+	if err != nil {
+		return err
+	}
+
+	for name, artifactID := range outputs {
+		_, err = psql.Insert("task_result_outputs").
+			Columns("task_result_id", "name", "worker_artifact_id").
+			Values(resultID, name, artifactID).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// UseTaskResult bumps the result's last use, which keeps its outputs from
+// being garbage collected for another retention period.
+func (f *taskResultFactory) UseTaskResult(id int) error {
+	_, err := psql.Update("task_results").
+		Set("last_used_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": id}).
+		RunWith(f.conn).
+		Exec()
+
+	return err
+}
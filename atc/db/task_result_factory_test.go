@@ -0,0 +1,108 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TaskResultFactory", func() {
+	var (
+		taskResultFactory db.TaskResultFactory
+		build             db.Build
+		createdVolume     db.CreatedVolume
+		workerArtifact    db.WorkerArtifact
+	)
+
+	BeforeEach(func() {
+		taskResultFactory = db.NewTaskResultFactory(dbConn)
+
+		var err error
+		build, err = defaultJob.CreateBuild()
+		Expect(err).ToNot(HaveOccurred())
+
+		creatingVolume, err := volumeRepository.CreateVolume(defaultTeam.ID(), defaultWorker.Name(), db.VolumeTypeArtifact)
+		Expect(err).ToNot(HaveOccurred())
+
+		createdVolume, err = creatingVolume.Created()
+		Expect(err).ToNot(HaveOccurred())
+
+		workerArtifact, err = createdVolume.InitializeRetainedArtifact("some-output", build.ID())
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("FindTaskResult", func() {
+		Context("when no result was saved for the key", func() {
+			It("returns false", func() {
+				_, found, err := taskResultFactory.FindTaskResult(defaultTeam.ID(), "some-key")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when a result was saved for the key", func() {
+			BeforeEach(func() {
+				err := taskResultFactory.SaveTaskResult(defaultTeam.ID(), "some-key", build.ID(), map[string]int{
+					"some-output": workerArtifact.ID(),
+				})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns the result with the volumes of its outputs", func() {
+				result, found, err := taskResultFactory.FindTaskResult(defaultTeam.ID(), "some-key")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(result.BuildID).To(Equal(build.ID()))
+				Expect(result.BuildName).To(Equal(build.Name()))
+				Expect(result.JobName).To(Equal(defaultJob.Name()))
+				Expect(result.PipelineName).To(Equal(defaultPipeline.Name()))
+				Expect(result.Outputs).To(Equal(map[string]string{
+					"some-output": createdVolume.Handle(),
+				}))
+			})
+
+			It("is scoped to the team", func() {
+				_, found, err := taskResultFactory.FindTaskResult(defaultTeam.ID()+1, "some-key")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			Context("when an output volume is gone", func() {
+				BeforeEach(func() {
+					_, err := createdVolume.Destroying()
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("returns false", func() {
+					_, found, err := taskResultFactory.FindTaskResult(defaultTeam.ID(), "some-key")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeFalse())
+				})
+			})
+		})
+	})
+
+	Describe("UseTaskResult", func() {
+		It("bumps the last use of the result", func() {
+			err := taskResultFactory.SaveTaskResult(defaultTeam.ID(), "some-key", build.ID(), map[string]int{
+				"some-output": workerArtifact.ID(),
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = dbConn.Exec("UPDATE task_results SET last_used_at = NOW() - '1 day'::interval")
+			Expect(err).ToNot(HaveOccurred())
+
+			result, found, err := taskResultFactory.FindTaskResult(defaultTeam.ID(), "some-key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			err = taskResultFactory.UseTaskResult(result.ID)
+			Expect(err).ToNot(HaveOccurred())
+
+			var recent bool
+			err = dbConn.QueryRow("SELECT last_used_at > NOW() - '1 hour'::interval FROM task_results").Scan(&recent)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(recent).To(BeTrue())
+		})
+	})
+})
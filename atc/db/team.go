@@ -35,6 +35,7 @@ type Team interface {
 		config atc.Config,
 		from ConfigVersion,
 		pausedState PipelinePausedState,
+		savedBy string,
 	) (Pipeline, bool, error)
 
 	Pipeline(pipelineName string) (Pipeline, bool, error)
@@ -59,13 +60,18 @@ type Team interface {
 	IsContainerWithinTeam(string, bool) (bool, error)
 
 	FindContainerByHandle(string) (Container, bool, error)
-	FindCheckContainers(lager.Logger, string, string, creds.Secrets) ([]Container, map[int]time.Time, error)
+	FindCheckContainers(lager.Logger, string, string, creds.Secrets, atc.SourceDefaults) ([]Container, map[int]time.Time, error)
 	FindContainersByMetadata(ContainerMetadata) ([]Container, error)
 	FindCreatedContainerByHandle(string) (CreatedContainer, bool, error)
 	FindWorkerForContainer(handle string) (Worker, bool, error)
 	FindWorkerForVolume(handle string) (Worker, bool, error)
 
 	UpdateProviderAuth(auth atc.TeamAuth) error
+
+	Quota() atc.TeamQuota
+	UpdateQuota(quota atc.TeamQuota) error
+	QuotaUsage() (TeamQuotaUsage, error)
+	AcquireBuildQuotaLock(logger lager.Logger) (lock.Lock, bool, error)
 }
 
 type team struct {
@@ -77,6 +83,8 @@ type team struct {
 	admin bool
 
 	auth atc.TeamAuth
+
+	quota atc.TeamQuota
 }
 
 func (t *team) ID() int      { return t.id }
@@ -85,6 +93,8 @@ func (t *team) Admin() bool  { return t.admin }
 
 func (t *team) Auth() atc.TeamAuth { return t.auth }
 
+func (t *team) Quota() atc.TeamQuota { return t.quota }
+
 func (t *team) Delete() error {
 	_, err := psql.Delete("teams").
 		Where(sq.Eq{
@@ -345,6 +355,7 @@ func (t *team) SavePipeline(
 	config atc.Config,
 	from ConfigVersion,
 	pausedState PipelinePausedState,
+	savedBy string,
 ) (Pipeline, bool, error) {
 	groupsPayload, err := json.Marshal(config.Groups)
 	if err != nil {
@@ -402,9 +413,12 @@ func (t *team) SavePipeline(
 
 		created = true
 	} else {
+		// the cached versions DB only holds the versions of resources which
+		// inputs filter, so it is invalidated by the new config
 		update := psql.Update("pipelines").
 			Set("groups", groupsPayload).
 			Set("version", sq.Expr("nextval('config_version_seq')")).
+			Set("cache_index", sq.Expr("cache_index + 1")).
 			Where(sq.Eq{
 				"name":    pipelineName,
 				"version": from,
@@ -437,6 +451,30 @@ func (t *team) SavePipeline(
 			return nil, false, err
 		}
 
+		_, err = tx.Exec(`
+      DELETE FROM jobs_cross_pipeline_passed
+      WHERE job_id in (
+        SELECT j.id
+        FROM jobs j
+        WHERE j.pipeline_id = $1
+      )
+		`, pipelineID)
+		if err != nil {
+			return nil, false, err
+		}
+
+		_, err = tx.Exec(`
+      DELETE FROM jobs_filtered_inputs
+      WHERE job_id in (
+        SELECT j.id
+        FROM jobs j
+        WHERE j.pipeline_id = $1
+      )
+		`, pipelineID)
+		if err != nil {
+			return nil, false, err
+		}
+
 		_, err = tx.Exec(`
 			UPDATE jobs
 			SET active = false
@@ -465,8 +503,10 @@ func (t *team) SavePipeline(
 		}
 	}
 
+	pinnedVersions := configPinnedVersions(config)
+
 	for _, resource := range config.Resources {
-		err = t.saveResource(tx, resource, pipelineID)
+		err = t.saveResource(tx, resource, pinnedVersions[resource.Name], pipelineID)
 		if err != nil {
 			return nil, false, err
 		}
@@ -506,6 +546,20 @@ func (t *team) SavePipeline(
 				return nil, false, err
 			}
 		}
+
+		for _, ref := range job.CrossPipelinePassed() {
+			err = t.registerCrossPipelinePassed(tx, job.Name, ref, pipelineID)
+			if err != nil {
+				return nil, false, err
+			}
+		}
+
+		for _, resourceName := range job.FilteredResources() {
+			err = t.registerFilteredInput(tx, job.Name, resourceName, pipelineID)
+			if err != nil {
+				return nil, false, err
+			}
+		}
 	}
 
 	err = removeUnusedWorkerTaskCaches(tx, pipelineID, config.Jobs)
@@ -526,6 +580,11 @@ func (t *team) SavePipeline(
 		return nil, false, err
 	}
 
+	err = t.saveConfigHistory(tx, config, pipeline.ID(), pipeline.ConfigVersion(), savedBy)
+	if err != nil {
+		return nil, false, err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return nil, false, err
@@ -798,7 +857,7 @@ func (t *team) UpdateProviderAuth(auth atc.TeamAuth) error {
 		UPDATE teams
 		SET auth = $1, legacy_auth = NULL, nonce = NULL
 		WHERE id = $2
-		RETURNING id, name, admin, auth, nonce
+		RETURNING id, name, admin, auth, nonce, max_running_builds, max_containers
 	`
 	err = t.queryTeam(tx, query, jsonEncodedProviderAuth, t.id)
 	if err != nil {
@@ -808,7 +867,72 @@ func (t *team) UpdateProviderAuth(auth atc.TeamAuth) error {
 	return tx.Commit()
 }
 
-func (t *team) FindCheckContainers(logger lager.Logger, pipelineName string, resourceName string, secretManager creds.Secrets) ([]Container, map[int]time.Time, error) {
+func (t *team) UpdateQuota(quota atc.TeamQuota) error {
+	_, err := psql.Update("teams").
+		Set("max_running_builds", quota.MaxRunningBuilds).
+		Set("max_containers", quota.MaxContainers).
+		Where(sq.Eq{
+			"id": t.id,
+		}).
+		RunWith(t.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	t.quota = quota
+
+	return nil
+}
+
+// TeamQuotaUsage is the quota of a team along with how much of it is in use.
+type TeamQuotaUsage struct {
+	TeamName string
+	Quota    atc.TeamQuota
+	Usage    atc.TeamUsage
+}
+
+// QuotaUsage counts the builds of the team that are running and its
+// containers that are being created or are created. The quota and name are
+// read along with them so that they are up to date even for teams that were
+// not loaded, e.g. from GetByID.
+func (t *team) QuotaUsage() (TeamQuotaUsage, error) {
+	var quotaUsage TeamQuotaUsage
+
+	err := psql.Select("t.name, t.max_running_builds, t.max_containers").
+		Column(sq.Expr("(SELECT COUNT(*) FROM builds b WHERE b.team_id = t.id AND b.status = ?)", BuildStatusStarted)).
+		Column(sq.Expr("(SELECT COUNT(*) FROM containers c WHERE c.team_id = t.id AND c.state IN (?, ?))", atc.ContainerStateCreating, atc.ContainerStateCreated)).
+		From("teams t").
+		Where(sq.Eq{"t.id": t.id}).
+		RunWith(t.conn).
+		QueryRow().
+		Scan(
+			&quotaUsage.TeamName,
+			&quotaUsage.Quota.MaxRunningBuilds,
+			&quotaUsage.Quota.MaxContainers,
+			&quotaUsage.Usage.RunningBuilds,
+			&quotaUsage.Usage.Containers,
+		)
+	if err != nil {
+		return TeamQuotaUsage{}, err
+	}
+
+	return quotaUsage, nil
+}
+
+// AcquireBuildQuotaLock is held while checking the running builds of the team
+// against its quota and starting a build, so that builds of different
+// pipelines can't all start on the last free slot.
+func (t *team) AcquireBuildQuotaLock(logger lager.Logger) (lock.Lock, bool, error) {
+	return t.lockFactory.Acquire(
+		logger.Session("lock", lager.Data{
+			"team": t.name,
+		}),
+		lock.NewTeamBuildQuotaLockID(t.id),
+	)
+}
+
+func (t *team) FindCheckContainers(logger lager.Logger, pipelineName string, resourceName string, secretManager creds.Secrets, sourceDefaults atc.SourceDefaults) ([]Container, map[int]time.Time, error) {
 	pipeline, found, err := t.Pipeline(pipelineName)
 	if err != nil {
 		return nil, nil, err
@@ -834,7 +958,7 @@ func (t *team) FindCheckContainers(logger lager.Logger, pipelineName string, res
 
 	versionedResourceTypes := pipelineResourceTypes.Deserialize()
 
-	source, err := creds.NewSource(variables, resource.Source()).Evaluate()
+	source, err := creds.NewResourceSource(variables, resource.Type(), resource.Source(), sourceDefaults).Evaluate()
 	if err != nil {
 		return nil, nil, err
 	}
@@ -844,7 +968,7 @@ func (t *team) FindCheckContainers(logger lager.Logger, pipelineName string, res
 		logger,
 		resource.Type(),
 		source,
-		creds.NewVersionedResourceTypes(variables, versionedResourceTypes),
+		creds.NewVersionedResourceTypes(variables, versionedResourceTypes).WithSourceDefaults(sourceDefaults),
 	)
 	if err != nil {
 		return nil, nil, err
@@ -1034,6 +1158,37 @@ func (t *team) saveJob(tx Tx, job atc.JobConfig, pipelineID int, groups []string
 	return swallowUniqueViolation(err)
 }
 
+func (t *team) saveConfigHistory(tx Tx, config atc.Config, pipelineID int, version ConfigVersion, savedBy string) error {
+	configPayload, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	es := t.conn.EncryptionStrategy()
+	encryptedPayload, nonce, err := es.Encrypt(configPayload)
+	if err != nil {
+		return err
+	}
+
+	var user sql.NullString
+	if savedBy != "" {
+		user = sql.NullString{String: savedBy, Valid: true}
+	}
+
+	_, err = psql.Insert("pipeline_configs").
+		SetMap(map[string]interface{}{
+			"pipeline_id": pipelineID,
+			"version":     version,
+			"config":      encryptedPayload,
+			"nonce":       nonce,
+			"saved_by":    user,
+		}).
+		RunWith(tx).
+		Exec()
+
+	return err
+}
+
 func (t *team) registerSerialGroup(tx Tx, jobName, serialGroup string, pipelineID int) error {
 	_, err := tx.Exec(`
     INSERT INTO jobs_serial_groups (serial_group, job_id) VALUES
@@ -1050,7 +1205,35 @@ func (t *team) registerSerialGroup(tx Tx, jobName, serialGroup string, pipelineI
 	return swallowUniqueViolation(err)
 }
 
-func (t *team) saveResource(tx Tx, resource atc.ResourceConfig, pipelineID int) error {
+func (t *team) registerCrossPipelinePassed(tx Tx, jobName string, ref atc.PassedJobReference, pipelineID int) error {
+	_, err := tx.Exec(`
+    INSERT INTO jobs_cross_pipeline_passed (job_id, passed_pipeline_name, passed_job_name) VALUES
+    ((SELECT j.id
+        FROM jobs j
+       WHERE j.name = $1
+         AND j.pipeline_id = $2
+       LIMIT 1), $3, $4);`,
+		jobName, pipelineID, ref.PipelineName, ref.JobName,
+	)
+
+	return swallowUniqueViolation(err)
+}
+
+func (t *team) registerFilteredInput(tx Tx, jobName string, resourceName string, pipelineID int) error {
+	_, err := tx.Exec(`
+    INSERT INTO jobs_filtered_inputs (job_id, resource_name) VALUES
+    ((SELECT j.id
+        FROM jobs j
+       WHERE j.name = $1
+         AND j.pipeline_id = $2
+       LIMIT 1), $3);`,
+		jobName, pipelineID, resourceName,
+	)
+
+	return swallowUniqueViolation(err)
+}
+
+func (t *team) saveResource(tx Tx, resource atc.ResourceConfig, pinnedVersions []atc.Version, pipelineID int) error {
 	configPayload, err := json.Marshal(resource)
 	if err != nil {
 		return err
@@ -1062,11 +1245,26 @@ func (t *team) saveResource(tx Tx, resource atc.ResourceConfig, pipelineID int)
 		return err
 	}
 
+	if pinnedVersions == nil {
+		pinnedVersions = []atc.Version{}
+	}
+
+	pinnedPayload, err := json.Marshal(pinnedVersions)
+	if err != nil {
+		return err
+	}
+
+	var retentionLatest, retentionDays sql.NullInt64
+	if resource.VersionRetention != nil {
+		retentionLatest = sql.NullInt64{Int64: int64(resource.VersionRetention.Latest), Valid: true}
+		retentionDays = sql.NullInt64{Int64: int64(resource.VersionRetention.Days), Valid: true}
+	}
+
 	updated, err := checkIfRowsUpdated(tx, `
 		UPDATE resources
-		SET config = $3, active = true, nonce = $4
+		SET config = $3, active = true, nonce = $4, version_retention_latest = $5, version_retention_days = $6, config_pinned_versions = $7
 		WHERE name = $1 AND pipeline_id = $2
-	`, resource.Name, pipelineID, encryptedPayload, nonce)
+	`, resource.Name, pipelineID, encryptedPayload, nonce, retentionLatest, retentionDays, pinnedPayload)
 	if err != nil {
 		return err
 	}
@@ -1091,13 +1289,36 @@ func (t *team) saveResource(tx Tx, resource atc.ResourceConfig, pipelineID int)
 	}
 
 	_, err = tx.Exec(`
-		INSERT INTO resources (name, pipeline_id, config, active, nonce)
-		VALUES ($1, $2, $3, true, $4)
-	`, resource.Name, pipelineID, encryptedPayload, nonce)
+		INSERT INTO resources (name, pipeline_id, config, active, nonce, version_retention_latest, version_retention_days, config_pinned_versions)
+		VALUES ($1, $2, $3, true, $4, $5, $6, $7)
+	`, resource.Name, pipelineID, encryptedPayload, nonce, retentionLatest, retentionDays, pinnedPayload)
 
 	return swallowUniqueViolation(err)
 }
 
+// configPinnedVersions returns the versions pinned by the config for each
+// resource, either on the resource itself or by get steps, so that version
+// retention never removes them.
+func configPinnedVersions(config atc.Config) map[string][]atc.Version {
+	pinned := map[string][]atc.Version{}
+
+	for _, resource := range config.Resources {
+		if resource.Version != nil {
+			pinned[resource.Name] = append(pinned[resource.Name], resource.Version)
+		}
+	}
+
+	for _, job := range config.Jobs {
+		for _, input := range job.Inputs() {
+			if input.Version != nil && input.Version.Pinned != nil {
+				pinned[input.Resource] = append(pinned[input.Resource], input.Version.Pinned)
+			}
+		}
+	}
+
+	return pinned
+}
+
 func (t *team) saveResourceType(tx Tx, resourceType atc.ResourceType, pipelineID int) error {
 	configPayload, err := json.Marshal(resourceType)
 	if err != nil {
@@ -1260,6 +1481,8 @@ func (t *team) queryTeam(tx Tx, query string, params ...interface{}) error {
 		&t.admin,
 		&providerAuth,
 		&nonce,
+		&t.quota.MaxRunningBuilds,
+		&t.quota.MaxContainers,
 	)
 	if err != nil {
 		return err
@@ -153,6 +153,7 @@ type CreatedVolume interface {
 
 	InitializeResourceCache(UsedResourceCache) error
 	InitializeArtifact(name string, buildID int) (WorkerArtifact, error)
+	InitializeRetainedArtifact(name string, buildID int) (WorkerArtifact, error)
 	InitializeTaskCache(int, string, string) error
 
 	ContainerHandle() string
@@ -393,6 +394,24 @@ func (volume *createdVolume) InitializeResourceCache(resourceCache UsedResourceC
 }
 
 func (volume *createdVolume) InitializeArtifact(name string, buildID int) (WorkerArtifact, error) {
+	return volume.initializeArtifact(atc.WorkerArtifact{
+		Name:    name,
+		BuildID: buildID,
+	})
+}
+
+// InitializeRetainedArtifact is like InitializeArtifact, but the artifact is
+// kept for the retained output retention period rather than only long enough
+// for a one-off build to download it.
+func (volume *createdVolume) InitializeRetainedArtifact(name string, buildID int) (WorkerArtifact, error) {
+	return volume.initializeArtifact(atc.WorkerArtifact{
+		Name:     name,
+		BuildID:  buildID,
+		Retained: true,
+	})
+}
+
+func (volume *createdVolume) initializeArtifact(atcWorkerArtifact atc.WorkerArtifact) (WorkerArtifact, error) {
 	tx, err := volume.conn.Begin()
 	if err != nil {
 		return nil, err
@@ -400,11 +419,6 @@ func (volume *createdVolume) InitializeArtifact(name string, buildID int) (Worke
 
 	defer Rollback(tx)
 
-	atcWorkerArtifact := atc.WorkerArtifact{
-		Name:    name,
-		BuildID: buildID,
-	}
-
 	workerArtifact, err := saveWorkerArtifact(tx, volume.conn, atcWorkerArtifact)
 	if err != nil {
 		return nil, err
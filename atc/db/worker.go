@@ -16,6 +16,7 @@ import (
 var (
 	ErrWorkerNotPresent         = errors.New("worker not present in db")
 	ErrCannotPruneRunningWorker = errors.New("worker not stalled for pruning")
+	ErrWorkerNotQuarantined     = errors.New("worker is not quarantined")
 )
 
 type ContainerOwnerDisappearedError struct {
@@ -26,6 +27,24 @@ func (e ContainerOwnerDisappearedError) Error() string {
 	return fmt.Sprintf("container owner %T disappeared", e.owner)
 }
 
+// ContainerQuotaReachedError is returned when creating a container for a build
+// would exceed the container quota of its team. BuildContainers is how many
+// containers the build itself already has.
+type ContainerQuotaReachedError struct {
+	TeamName        string
+	Containers      int
+	MaxContainers   int
+	BuildContainers int
+}
+
+// containerQuotaWaitExpiry is how long a build counts as waiting for its
+// team's container quota after it was last refused a container.
+const containerQuotaWaitExpiry = time.Minute
+
+func (e ContainerQuotaReachedError) Error() string {
+	return fmt.Sprintf("team '%s' has reached its container quota (%d/%d containers in use)", e.TeamName, e.Containers, e.MaxContainers)
+}
+
 type WorkerState string
 
 const (
@@ -34,6 +53,8 @@ const (
 	WorkerStateLanding  = WorkerState("landing")
 	WorkerStateLanded   = WorkerState("landed")
 	WorkerStateRetiring = WorkerState("retiring")
+
+	WorkerStateQuarantined = WorkerState("quarantined")
 )
 
 //go:generate counterfeiter . Worker
@@ -54,11 +75,14 @@ type Worker interface {
 	ResourceTypes() []atc.WorkerResourceType
 	Platform() string
 	Tags() []string
+	Capabilities() []string
 	TeamID() int
 	TeamName() string
 	StartTime() int64
 	ExpiresAt() time.Time
 	Ephemeral() bool
+	QuarantinedUntil() time.Time
+	KeyFingerprint() string
 
 	Reload() (bool, error)
 
@@ -67,6 +91,9 @@ type Worker interface {
 	Prune() error
 	Delete() error
 
+	Quarantine(duration time.Duration) (bool, error)
+	ReleaseQuarantine() error
+
 	FindContainerOnWorker(owner ContainerOwner) (CreatingContainer, CreatedContainer, error)
 	CreateContainer(owner ContainerOwner, meta ContainerMetadata) (CreatingContainer, error)
 }
@@ -87,12 +114,15 @@ type worker struct {
 	resourceTypes    []atc.WorkerResourceType
 	platform         string
 	tags             []string
+	capabilities     []string
 	teamID           int
 	teamName         string
 	startTime        int64
 	expiresAt        time.Time
 	certsPath        *string
 	ephemeral        bool
+	quarantinedUntil time.Time
+	keyFingerprint   string
 }
 
 func (worker *worker) Name() string             { return worker.name }
@@ -110,6 +140,7 @@ func (worker *worker) ActiveVolumes() int                      { return worker.a
 func (worker *worker) ResourceTypes() []atc.WorkerResourceType { return worker.resourceTypes }
 func (worker *worker) Platform() string                        { return worker.platform }
 func (worker *worker) Tags() []string                          { return worker.tags }
+func (worker *worker) Capabilities() []string                  { return worker.capabilities }
 func (worker *worker) TeamID() int                             { return worker.teamID }
 func (worker *worker) TeamName() string                        { return worker.teamName }
 func (worker *worker) Ephemeral() bool                         { return worker.ephemeral }
@@ -118,6 +149,12 @@ func (worker *worker) Ephemeral() bool                         { return worker.e
 func (worker *worker) StartTime() int64     { return worker.startTime }
 func (worker *worker) ExpiresAt() time.Time { return worker.expiresAt }
 
+func (worker *worker) QuarantinedUntil() time.Time { return worker.quarantinedUntil }
+
+// KeyFingerprint is the fingerprint of the key the worker registered with,
+// if it registered through the TSA.
+func (worker *worker) KeyFingerprint() string { return worker.keyFingerprint }
+
 func (worker *worker) Reload() (bool, error) {
 	row := workersQuery.Where(sq.Eq{"w.name": worker.name}).
 		RunWith(worker.conn).
@@ -189,6 +226,78 @@ func (worker *worker) Retire() error {
 	return nil
 }
 
+// Quarantine takes a running worker out of container placement until the
+// given duration elapses or the quarantine is released. Workers in any other
+// state are left untouched, in which case false is returned.
+func (worker *worker) Quarantine(duration time.Duration) (bool, error) {
+	result, err := psql.Update("workers").
+		Set("state", string(WorkerStateQuarantined)).
+		Set("quarantined_until", sq.Expr(fmt.Sprintf(`NOW() + '%d second'::INTERVAL`, int(duration.Seconds())))).
+		Where(sq.Eq{
+			"name":  worker.name,
+			"state": string(WorkerStateRunning),
+		}).
+		RunWith(worker.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if count == 0 {
+		return false, nil
+	}
+
+	worker.state = WorkerStateQuarantined
+
+	return true, nil
+}
+
+func (worker *worker) ReleaseQuarantine() error {
+	result, err := psql.Update("workers").
+		Set("state", string(WorkerStateRunning)).
+		Set("quarantined_until", nil).
+		Where(sq.Eq{
+			"name":  worker.name,
+			"state": string(WorkerStateQuarantined),
+		}).
+		RunWith(worker.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		var one int
+		err := psql.Select("1").From("workers").Where(sq.Eq{"name": worker.name}).
+			RunWith(worker.conn).
+			QueryRow().
+			Scan(&one)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ErrWorkerNotPresent
+			}
+			return err
+		}
+
+		return ErrWorkerNotQuarantined
+	}
+
+	worker.state = WorkerStateRunning
+	worker.quarantinedUntil = time.Time{}
+
+	return nil
+}
+
 func (worker *worker) Prune() error {
 	rows, err := sq.Delete("workers").
 		Where(sq.Eq{
@@ -303,6 +412,22 @@ func (worker *worker) CreateContainer(owner ContainerOwner, meta ContainerMetada
 		insMap[k] = v
 	}
 
+	if teamID, ok := insMap["team_id"]; ok && meta.BuildID != 0 {
+		err = reserveTeamContainer(tx, teamID, meta.BuildID)
+		if quotaErr, ok := err.(ContainerQuotaReachedError); ok {
+			err = markWaitingForContainers(worker.conn, meta.BuildID)
+			if err != nil {
+				return nil, err
+			}
+
+			return nil, quotaErr
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	err = psql.Insert("containers").
 		SetMap(insMap).
 		Suffix("RETURNING id, " + strings.Join(containerMetadataColumns, ", ")).
@@ -331,6 +456,94 @@ func (worker *worker) CreateContainer(owner ContainerOwner, meta ContainerMetada
 	), nil
 }
 
+// reserveTeamContainer checks that the team can have another container for the
+// build. When the team has a quota it is locked until the transaction ends, so
+// that the container is inserted before anyone else counts the containers of
+// the team.
+//
+// Builds keep their containers until they finish, so builds waiting for the
+// quota can end up holding all of the team's containers between them. When
+// that happens the build is let through, even though it exceeds the quota.
+func reserveTeamContainer(tx Tx, teamID interface{}, buildID int) error {
+	var maxContainers int
+	err := psql.Select("max_containers").
+		From("teams").
+		Where(sq.Eq{"id": teamID}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&maxContainers)
+	if err != nil {
+		return err
+	}
+
+	if maxContainers == 0 {
+		return nil
+	}
+
+	var quotaErr ContainerQuotaReachedError
+	err = psql.Select("name, max_containers").
+		From("teams").
+		Where(sq.Eq{"id": teamID}).
+		Suffix("FOR NO KEY UPDATE").
+		RunWith(tx).
+		QueryRow().
+		Scan(&quotaErr.TeamName, &quotaErr.MaxContainers)
+	if err != nil {
+		return err
+	}
+
+	if quotaErr.MaxContainers == 0 {
+		return nil
+	}
+
+	var runningContainers int
+	err = psql.Select("COUNT(*)").
+		Column(sq.Expr("COUNT(*) FILTER (WHERE c.meta_build_id = ?)", buildID)).
+		Column(sq.Expr("COUNT(*) FILTER (WHERE c.meta_build_id IS DISTINCT FROM ? AND (b.waiting_for_containers_until IS NULL OR b.waiting_for_containers_until < now()))", buildID)).
+		From("containers c").
+		LeftJoin("builds b ON b.id = c.meta_build_id").
+		Where(sq.Eq{
+			"c.team_id": teamID,
+			"c.state":   []string{atc.ContainerStateCreating, atc.ContainerStateCreated},
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&quotaErr.Containers, &quotaErr.BuildContainers, &runningContainers)
+	if err != nil {
+		return err
+	}
+
+	deadlocked := quotaErr.BuildContainers > 0 && runningContainers == 0
+	if quotaErr.Containers >= quotaErr.MaxContainers && !deadlocked {
+		return quotaErr
+	}
+
+	_, err = psql.Update("builds").
+		Set("waiting_for_containers_until", nil).
+		Where(sq.And{
+			sq.Eq{"id": buildID},
+			sq.NotEq{"waiting_for_containers_until": nil},
+		}).
+		RunWith(tx).
+		Exec()
+
+	return err
+}
+
+// markWaitingForContainers records that the build is waiting for its team's
+// container quota, so that other builds can tell when all of the team's
+// containers are held by waiting builds. It expires unless the build keeps
+// waiting.
+func markWaitingForContainers(conn Conn, buildID int) error {
+	_, err := psql.Update("builds").
+		Set("waiting_for_containers_until", sq.Expr(fmt.Sprintf("now() + '%d seconds'::interval", int(containerQuotaWaitExpiry.Seconds())))).
+		Where(sq.Eq{"id": buildID}).
+		RunWith(conn).
+		Exec()
+
+	return err
+}
+
 func (worker *worker) findContainer(whereClause sq.Sqlizer) (CreatingContainer, CreatedContainer, error) {
 	creating, created, destroying, _, err := scanContainer(
 		selectContainers().
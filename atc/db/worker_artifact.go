@@ -17,6 +17,7 @@ type WorkerArtifact interface {
 	Name() string
 	BuildID() int
 	CreatedAt() time.Time
+	Retained() bool
 	Volume(teamID int) (CreatedVolume, bool, error)
 }
 
@@ -27,12 +28,14 @@ type artifact struct {
 	name      string
 	buildID   int
 	createdAt time.Time
+	retained  bool
 }
 
 func (a *artifact) ID() int              { return a.id }
 func (a *artifact) Name() string         { return a.name }
 func (a *artifact) BuildID() int         { return a.buildID }
 func (a *artifact) CreatedAt() time.Time { return a.createdAt }
+func (a *artifact) Retained() bool       { return a.retained }
 
 func (a *artifact) Volume(teamID int) (CreatedVolume, bool, error) {
 	where := map[string]interface{}{
@@ -57,7 +60,8 @@ func saveWorkerArtifact(tx Tx, conn Conn, atcArtifact atc.WorkerArtifact) (Worke
 	var artifactID int
 
 	values := map[string]interface{}{
-		"name": atcArtifact.Name,
+		"name":     atcArtifact.Name,
+		"retained": atcArtifact.Retained,
 	}
 
 	if atcArtifact.BuildID != 0 {
@@ -96,14 +100,14 @@ func getWorkerArtifact(tx Tx, conn Conn, id int) (WorkerArtifact, bool, error) {
 
 	artifact := &artifact{conn: conn}
 
-	err := psql.Select("id", "created_at", "name", "build_id").
+	err := psql.Select("id", "created_at", "name", "build_id", "retained").
 		From("worker_artifacts").
 		Where(sq.Eq{
 			"id": id,
 		}).
 		RunWith(tx).
 		QueryRow().
-		Scan(&artifact.id, &createdAtTime, &artifact.name, &buildID)
+		Scan(&artifact.id, &createdAtTime, &artifact.name, &buildID, &artifact.retained)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, false, nil
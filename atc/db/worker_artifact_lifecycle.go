@@ -1,13 +1,16 @@
 package db
 
 import (
+	"fmt"
+	"time"
+
 	sq "github.com/Masterminds/squirrel"
 )
 
 //go:generate counterfeiter . WorkerArtifactLifecycle
 
 type WorkerArtifactLifecycle interface {
-	RemoveExpiredArtifacts() error
+	RemoveExpiredArtifacts(retainedRetention time.Duration) error
 }
 
 type artifactLifecycle struct {
@@ -20,10 +23,46 @@ func NewArtifactLifecycle(conn Conn) *artifactLifecycle {
 	}
 }
 
-func (lifecycle *artifactLifecycle) RemoveExpiredArtifacts() error {
+// RemoveExpiredArtifacts removes artifacts older than 12 hours, or, for
+// retained task outputs, older than the given retention. Retained outputs of a
+// cached task result are kept until the result has not been used for the
+// retention. A retention of zero keeps retained outputs forever.
+//
+// Task results which lost any of their outputs are removed along with them.
+func (lifecycle *artifactLifecycle) RemoveExpiredArtifacts(retainedRetention time.Duration) error {
+	expired := sq.Or{
+		sq.And{
+			sq.Eq{"retained": false},
+			sq.Expr("created_at < NOW() - interval '12 hours'"),
+		},
+	}
+
+	if retainedRetention != 0 {
+		interval := fmt.Sprintf("interval '%d seconds'", int64(retainedRetention.Seconds()))
+
+		expired = append(expired, sq.And{
+			sq.Eq{"retained": true},
+			sq.Expr("created_at < NOW() - " + interval),
+			sq.Expr(`NOT EXISTS (
+				SELECT 1
+				FROM task_result_outputs o
+				JOIN task_results r ON r.id = o.task_result_id
+				WHERE o.worker_artifact_id = worker_artifacts.id
+				AND r.last_used_at >= NOW() - ` + interval + `
+			)`),
+		})
+	}
 
 	_, err := psql.Delete("worker_artifacts").
-		Where(sq.Expr("created_at < NOW() - interval '12 hours'")).
+		Where(expired).
+		RunWith(lifecycle.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Delete("task_results").
+		Where(sq.Expr("outputs > (SELECT COUNT(*) FROM task_result_outputs o WHERE o.task_result_id = task_results.id)")).
 		RunWith(lifecycle.conn).
 		Exec()
 
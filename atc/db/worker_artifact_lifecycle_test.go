@@ -1,6 +1,8 @@
 package db_test
 
 import (
+	"time"
+
 	"github.com/concourse/concourse/atc/db"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -14,8 +16,14 @@ var _ = Describe("WorkerArtifactLifecycle", func() {
 	})
 
 	Describe("RemoveExpiredArtifacts", func() {
+		var retainedRetention time.Duration
+
+		BeforeEach(func() {
+			retainedRetention = 24 * time.Hour
+		})
+
 		JustBeforeEach(func() {
-			err := workerArtifactLifecycle.RemoveExpiredArtifacts()
+			err := workerArtifactLifecycle.RemoveExpiredArtifacts(retainedRetention)
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -51,5 +59,97 @@ var _ = Describe("WorkerArtifactLifecycle", func() {
 				Expect(count).To(Equal(1))
 			})
 		})
+
+		Context("when artifacts are retained", func() {
+			BeforeEach(func() {
+				_, err := dbConn.Exec("INSERT INTO worker_artifacts(name, retained, created_at) VALUES('recent', true, NOW() - '13 hours'::interval)")
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = dbConn.Exec("INSERT INTO worker_artifacts(name, retained, created_at) VALUES('old', true, NOW() - '25 hours'::interval)")
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("keeps them for the retention period", func() {
+				var names []string
+				rows, err := dbConn.Query("SELECT name FROM worker_artifacts")
+				Expect(err).ToNot(HaveOccurred())
+
+				for rows.Next() {
+					var name string
+					Expect(rows.Scan(&name)).To(Succeed())
+					names = append(names, name)
+				}
+
+				Expect(names).To(ConsistOf("recent"))
+			})
+
+			Context("when the retention is zero", func() {
+				BeforeEach(func() {
+					retainedRetention = 0
+				})
+
+				It("keeps them forever", func() {
+					var count int
+					err := dbConn.QueryRow("SELECT count(*) from worker_artifacts").Scan(&count)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(count).To(Equal(2))
+				})
+			})
+		})
+
+		Context("when retained artifacts are outputs of a task result", func() {
+			var taskResultFactory db.TaskResultFactory
+
+			BeforeEach(func() {
+				taskResultFactory = db.NewTaskResultFactory(dbConn)
+
+				build, err := defaultTeam.CreateOneOffBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				var usedID, unusedID int
+				err = dbConn.QueryRow("INSERT INTO worker_artifacts(name, retained, created_at) VALUES('used', true, NOW() - '25 hours'::interval) RETURNING id").Scan(&usedID)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = dbConn.QueryRow("INSERT INTO worker_artifacts(name, retained, created_at) VALUES('unused', true, NOW() - '25 hours'::interval) RETURNING id").Scan(&unusedID)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = taskResultFactory.SaveTaskResult(defaultTeam.ID(), "used-key", build.ID(), map[string]int{"out": usedID})
+				Expect(err).ToNot(HaveOccurred())
+
+				err = taskResultFactory.SaveTaskResult(defaultTeam.ID(), "unused-key", build.ID(), map[string]int{"out": unusedID})
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = dbConn.Exec("UPDATE task_results SET last_used_at = NOW() - '25 hours'::interval WHERE key = 'unused-key'")
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("keeps the outputs of recently used results", func() {
+				var names []string
+				rows, err := dbConn.Query("SELECT name FROM worker_artifacts")
+				Expect(err).ToNot(HaveOccurred())
+
+				for rows.Next() {
+					var name string
+					Expect(rows.Scan(&name)).To(Succeed())
+					names = append(names, name)
+				}
+
+				Expect(names).To(ConsistOf("used"))
+			})
+
+			It("removes the results whose outputs were removed", func() {
+				var keys []string
+				rows, err := dbConn.Query("SELECT key FROM task_results")
+				Expect(err).ToNot(HaveOccurred())
+
+				for rows.Next() {
+					var key string
+					Expect(rows.Scan(&key)).To(Succeed())
+					keys = append(keys, key)
+				}
+
+				Expect(keys).To(ConsistOf("used-key"))
+			})
+		})
 	})
 })
@@ -49,11 +49,14 @@ var workersQuery = psql.Select(`
 		w.resource_types,
 		w.platform,
 		w.tags,
+		w.capabilities,
 		t.name,
 		w.team_id,
 		w.start_time,
 		w.expires,
-		w.ephemeral
+		w.ephemeral,
+		w.quarantined_until,
+		w.key_fingerprint
 	`).
 	From("workers w").
 	LeftJoin("teams t ON w.team_id = t.id")
@@ -123,22 +126,25 @@ func getWorkers(conn Conn, query sq.SelectBuilder) ([]Worker, error) {
 
 func scanWorker(worker *worker, row scannable) error {
 	var (
-		version       sql.NullString
-		addStr        sql.NullString
-		state         string
-		bcURLStr      sql.NullString
-		certsPathStr  sql.NullString
-		httpProxyURL  sql.NullString
-		httpsProxyURL sql.NullString
-		noProxy       sql.NullString
-		resourceTypes []byte
-		platform      sql.NullString
-		tags          []byte
-		teamName      sql.NullString
-		teamID        sql.NullInt64
-		startTime     sql.NullInt64
-		expiresAt     *time.Time
-		ephemeral     sql.NullBool
+		version          sql.NullString
+		addStr           sql.NullString
+		state            string
+		bcURLStr         sql.NullString
+		certsPathStr     sql.NullString
+		httpProxyURL     sql.NullString
+		httpsProxyURL    sql.NullString
+		noProxy          sql.NullString
+		resourceTypes    []byte
+		platform         sql.NullString
+		tags             []byte
+		capabilities     []byte
+		teamName         sql.NullString
+		teamID           sql.NullInt64
+		startTime        sql.NullInt64
+		expiresAt        *time.Time
+		ephemeral        sql.NullBool
+		quarantinedUntil *time.Time
+		keyFingerprint   sql.NullString
 	)
 
 	err := row.Scan(
@@ -156,11 +162,14 @@ func scanWorker(worker *worker, row scannable) error {
 		&resourceTypes,
 		&platform,
 		&tags,
+		&capabilities,
 		&teamName,
 		&teamID,
 		&startTime,
 		&expiresAt,
 		&ephemeral,
+		&quarantinedUntil,
+		&keyFingerprint,
 	)
 	if err != nil {
 		return err
@@ -192,6 +201,10 @@ func scanWorker(worker *worker, row scannable) error {
 		worker.expiresAt = *expiresAt
 	}
 
+	if quarantinedUntil != nil {
+		worker.quarantinedUntil = *quarantinedUntil
+	}
+
 	if httpProxyURL.Valid {
 		worker.httpProxyURL = httpProxyURL.String
 	}
@@ -220,11 +233,22 @@ func scanWorker(worker *worker, row scannable) error {
 		worker.ephemeral = ephemeral.Bool
 	}
 
+	if keyFingerprint.Valid {
+		worker.keyFingerprint = keyFingerprint.String
+	}
+
 	err = json.Unmarshal(resourceTypes, &worker.resourceTypes)
 	if err != nil {
 		return err
 	}
 
+	if capabilities != nil {
+		err = json.Unmarshal(capabilities, &worker.capabilities)
+		if err != nil {
+			return err
+		}
+	}
+
 	return json.Unmarshal(tags, &worker.tags)
 }
 
@@ -250,6 +274,7 @@ func (f *workerFactory) HeartbeatWorker(atcWorker atc.Worker, ttl time.Duration)
 		When("'landing'::worker_state", "'landing'::worker_state").
 		When("'landed'::worker_state", "'landed'::worker_state").
 		When("'retiring'::worker_state", "'retiring'::worker_state").
+		When("'quarantined'::worker_state", "'quarantined'::worker_state").
 		Else("'running'::worker_state").
 		ToSql()
 
@@ -380,6 +405,11 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		return nil, err
 	}
 
+	capabilities, err := json.Marshal(atcWorker.Capabilities)
+	if err != nil {
+		return nil, err
+	}
+
 	expires := "NULL"
 	if ttl != 0 {
 		expires = fmt.Sprintf(`NOW() + '%d second'::INTERVAL`, int(ttl.Seconds()))
@@ -397,12 +427,18 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		workerVersion = &atcWorker.Version
 	}
 
+	var keyFingerprint *string
+	if atcWorker.KeyFingerprint != "" {
+		keyFingerprint = &atcWorker.KeyFingerprint
+	}
+
 	values := []interface{}{
 		atcWorker.GardenAddr,
 		atcWorker.ActiveContainers,
 		atcWorker.ActiveVolumes,
 		resourceTypes,
 		tags,
+		capabilities,
 		atcWorker.Platform,
 		atcWorker.BaggageclaimURL,
 		atcWorker.CertsPath,
@@ -415,6 +451,7 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		string(workerState),
 		teamID,
 		atcWorker.Ephemeral,
+		keyFingerprint,
 	}
 
 	conflictValues := values
@@ -434,6 +471,7 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 			"active_volumes",
 			"resource_types",
 			"tags",
+			"capabilities",
 			"platform",
 			"baggageclaim_url",
 			"certs_path",
@@ -446,6 +484,7 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 			"state",
 			"team_id",
 			"ephemeral",
+			"key_fingerprint",
 		).
 		Values(append([]interface{}{sq.Expr(expires)}, values...)...).
 		Suffix(`
@@ -456,6 +495,7 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 				active_volumes = ?,
 				resource_types = ?,
 				tags = ?,
+				capabilities = ?,
 				platform = ?,
 				baggageclaim_url = ?,
 				certs_path = ?,
@@ -467,7 +507,8 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 				start_time = ?,
 				state = ?,
 				team_id = ?,
-				ephemeral = ?
+				ephemeral = ?,
+				key_fingerprint = ?
 			WHERE `+matchTeamUpsert,
 			conflictValues...,
 		).
@@ -506,10 +547,12 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		resourceTypes:    atcWorker.ResourceTypes,
 		platform:         atcWorker.Platform,
 		tags:             atcWorker.Tags,
+		capabilities:     atcWorker.Capabilities,
 		teamName:         atcWorker.Team,
 		teamID:           workerTeamID,
 		startTime:        atcWorker.StartTime,
 		ephemeral:        atcWorker.Ephemeral,
+		keyFingerprint:   atcWorker.KeyFingerprint,
 		conn:             conn,
 	}
 
@@ -47,6 +47,8 @@ var _ = Describe("WorkerFactory", func() {
 			Tags:      atc.Tags{"some", "tags"},
 			Name:      "some-name",
 			StartTime: 55,
+
+			Capabilities: []string{atc.WorkerCapabilityPidsLimit},
 		}
 	})
 
@@ -243,6 +245,7 @@ var _ = Describe("WorkerFactory", func() {
 				}))
 				Expect(foundWorker.Platform()).To(Equal("some-platform"))
 				Expect(foundWorker.Tags()).To(Equal([]string{"some", "tags"}))
+				Expect(foundWorker.Capabilities()).To(Equal([]string{atc.WorkerCapabilityPidsLimit}))
 				Expect(foundWorker.StartTime()).To(Equal(int64(55)))
 				Expect(foundWorker.State()).To(Equal(db.WorkerStateRunning))
 			})
@@ -582,7 +585,7 @@ var _ = Describe("WorkerFactory", func() {
 								},
 							},
 						},
-					}, db.ConfigVersion(0), db.PipelineUnpaused)
+					}, db.ConfigVersion(0), db.PipelineUnpaused, "")
 					Expect(err).NotTo(HaveOccurred())
 
 					taggedWorkerSpec := atc.Worker{
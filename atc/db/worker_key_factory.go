@@ -0,0 +1,201 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+)
+
+var ErrWorkerKeyAlreadyExists = errors.New("worker key already exists")
+
+// WorkerKey is a public key which workers may use to register through the
+// TSA. Keys without a team are global and may register workers for any team.
+type WorkerKey struct {
+	ID          int
+	Name        string
+	TeamID      int
+	TeamName    string
+	PublicKey   string
+	Fingerprint string
+	CreatedAt   time.Time
+}
+
+type WorkerKeySpec struct {
+	Name        string
+	PublicKey   string
+	Fingerprint string
+}
+
+//go:generate counterfeiter . WorkerKeyFactory
+
+type WorkerKeyFactory interface {
+	CreateGlobalKey(spec WorkerKeySpec) (WorkerKey, error)
+	CreateTeamKey(teamID int, spec WorkerKeySpec) (WorkerKey, error)
+
+	AllKeys() ([]WorkerKey, error)
+	GlobalKeys() ([]WorkerKey, error)
+	TeamKeys(teamID int) ([]WorkerKey, error)
+
+	DeleteGlobalKey(name string) (bool, error)
+	DeleteTeamKey(teamID int, name string) (bool, error)
+}
+
+type workerKeyFactory struct {
+	conn Conn
+}
+
+func NewWorkerKeyFactory(conn Conn) WorkerKeyFactory {
+	return &workerKeyFactory{
+		conn: conn,
+	}
+}
+
+var workerKeysQuery = psql.Select(`
+		k.id,
+		k.name,
+		k.team_id,
+		t.name,
+		k.public_key,
+		k.fingerprint,
+		k.created_at
+	`).
+	From("worker_keys k").
+	LeftJoin("teams t ON t.id = k.team_id")
+
+func (f *workerKeyFactory) CreateGlobalKey(spec WorkerKeySpec) (WorkerKey, error) {
+	return f.create(nil, spec)
+}
+
+func (f *workerKeyFactory) CreateTeamKey(teamID int, spec WorkerKeySpec) (WorkerKey, error) {
+	return f.create(&teamID, spec)
+}
+
+func (f *workerKeyFactory) create(teamID *int, spec WorkerKeySpec) (WorkerKey, error) {
+	var id int
+	err := psql.Insert("worker_keys").
+		Columns("name", "team_id", "public_key", "fingerprint").
+		Values(spec.Name, teamID, spec.PublicKey, spec.Fingerprint).
+		Suffix("RETURNING id").
+		RunWith(f.conn).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode {
+			return WorkerKey{}, ErrWorkerKeyAlreadyExists
+		}
+
+		return WorkerKey{}, err
+	}
+
+	keys, err := f.keys(sq.Eq{"k.id": id})
+	if err != nil {
+		return WorkerKey{}, err
+	}
+
+	if len(keys) == 0 {
+		return WorkerKey{}, errors.New("worker key disappeared after creation")
+	}
+
+	return keys[0], nil
+}
+
+func (f *workerKeyFactory) AllKeys() ([]WorkerKey, error) {
+	return f.keys(nil)
+}
+
+func (f *workerKeyFactory) GlobalKeys() ([]WorkerKey, error) {
+	return f.keys(sq.Eq{"k.team_id": nil})
+}
+
+func (f *workerKeyFactory) TeamKeys(teamID int) ([]WorkerKey, error) {
+	return f.keys(sq.Eq{"k.team_id": teamID})
+}
+
+func (f *workerKeyFactory) DeleteGlobalKey(name string) (bool, error) {
+	return f.delete(sq.Eq{
+		"team_id": nil,
+		"name":    name,
+	})
+}
+
+func (f *workerKeyFactory) DeleteTeamKey(teamID int, name string) (bool, error) {
+	return f.delete(sq.Eq{
+		"team_id": teamID,
+		"name":    name,
+	})
+}
+
+func (f *workerKeyFactory) delete(where sq.Sqlizer) (bool, error) {
+	result, err := psql.Delete("worker_keys").
+		Where(where).
+		RunWith(f.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (f *workerKeyFactory) keys(where sq.Sqlizer) ([]WorkerKey, error) {
+	query := workerKeysQuery
+	if where != nil {
+		query = query.Where(where)
+	}
+
+	rows, err := query.
+		OrderBy("t.name NULLS FIRST", "k.name").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	keys := []WorkerKey{}
+	for rows.Next() {
+		key, err := scanWorkerKey(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func scanWorkerKey(row scannable) (WorkerKey, error) {
+	var (
+		key      WorkerKey
+		teamID   sql.NullInt64
+		teamName sql.NullString
+	)
+
+	err := row.Scan(
+		&key.ID,
+		&key.Name,
+		&teamID,
+		&teamName,
+		&key.PublicKey,
+		&key.Fingerprint,
+		&key.CreatedAt,
+	)
+	if err != nil {
+		return WorkerKey{}, err
+	}
+
+	key.TeamID = int(teamID.Int64)
+	key.TeamName = teamName.String
+
+	return key, nil
+}
@@ -0,0 +1,154 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WorkerKeyFactory", func() {
+	var workerKeyFactory db.WorkerKeyFactory
+
+	globalSpec := db.WorkerKeySpec{
+		Name:        "some-key",
+		PublicKey:   "ssh-ed25519 AAAA",
+		Fingerprint: "SHA256:global",
+	}
+
+	teamSpec := db.WorkerKeySpec{
+		Name:        "some-key",
+		PublicKey:   "ssh-ed25519 BBBB",
+		Fingerprint: "SHA256:team",
+	}
+
+	BeforeEach(func() {
+		workerKeyFactory = db.NewWorkerKeyFactory(dbConn)
+	})
+
+	Describe("CreateGlobalKey", func() {
+		It("creates a key without a team", func() {
+			key, err := workerKeyFactory.CreateGlobalKey(globalSpec)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(key.Name).To(Equal("some-key"))
+			Expect(key.TeamID).To(BeZero())
+			Expect(key.TeamName).To(BeEmpty())
+			Expect(key.Fingerprint).To(Equal("SHA256:global"))
+		})
+
+		Context("when a global key with the same name exists", func() {
+			BeforeEach(func() {
+				_, err := workerKeyFactory.CreateGlobalKey(globalSpec)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns ErrWorkerKeyAlreadyExists", func() {
+				_, err := workerKeyFactory.CreateGlobalKey(teamSpec)
+				Expect(err).To(Equal(db.ErrWorkerKeyAlreadyExists))
+			})
+		})
+	})
+
+	Describe("CreateTeamKey", func() {
+		BeforeEach(func() {
+			_, err := workerKeyFactory.CreateGlobalKey(globalSpec)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("allows the same name as a global key", func() {
+			key, err := workerKeyFactory.CreateTeamKey(defaultTeam.ID(), teamSpec)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(key.TeamID).To(Equal(defaultTeam.ID()))
+			Expect(key.TeamName).To(Equal(defaultTeam.Name()))
+		})
+
+		Context("when the key is already added", func() {
+			It("returns ErrWorkerKeyAlreadyExists", func() {
+				spec := globalSpec
+				spec.Name = "other-key"
+				_, err := workerKeyFactory.CreateTeamKey(defaultTeam.ID(), spec)
+				Expect(err).To(Equal(db.ErrWorkerKeyAlreadyExists))
+			})
+		})
+	})
+
+	Describe("listing keys", func() {
+		var otherTeam db.Team
+
+		BeforeEach(func() {
+			var err error
+			otherTeam, err = teamFactory.CreateTeam(atc.Team{Name: "worker-key-team"})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = workerKeyFactory.CreateGlobalKey(globalSpec)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = workerKeyFactory.CreateTeamKey(defaultTeam.ID(), teamSpec)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = workerKeyFactory.CreateTeamKey(otherTeam.ID(), db.WorkerKeySpec{
+				Name:        "other-key",
+				PublicKey:   "ssh-ed25519 CCCC",
+				Fingerprint: "SHA256:other",
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("lists every key", func() {
+			keys, err := workerKeyFactory.AllKeys()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(keys).To(HaveLen(3))
+		})
+
+		It("lists the global keys", func() {
+			keys, err := workerKeyFactory.GlobalKeys()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(keys).To(HaveLen(1))
+			Expect(keys[0].Fingerprint).To(Equal("SHA256:global"))
+		})
+
+		It("lists a team's keys", func() {
+			keys, err := workerKeyFactory.TeamKeys(otherTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(keys).To(HaveLen(1))
+			Expect(keys[0].Name).To(Equal("other-key"))
+		})
+	})
+
+	Describe("deleting keys", func() {
+		BeforeEach(func() {
+			_, err := workerKeyFactory.CreateGlobalKey(globalSpec)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = workerKeyFactory.CreateTeamKey(defaultTeam.ID(), teamSpec)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("deletes only the global key", func() {
+			found, err := workerKeyFactory.DeleteGlobalKey("some-key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			keys, err := workerKeyFactory.AllKeys()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(keys).To(HaveLen(1))
+			Expect(keys[0].TeamID).To(Equal(defaultTeam.ID()))
+		})
+
+		It("deletes only the team's key", func() {
+			found, err := workerKeyFactory.DeleteTeamKey(defaultTeam.ID(), "some-key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			keys, err := workerKeyFactory.GlobalKeys()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(keys).To(HaveLen(1))
+		})
+
+		It("returns false when the key does not exist", func() {
+			found, err := workerKeyFactory.DeleteTeamKey(defaultTeam.ID(), "bogus-key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+})
@@ -13,6 +13,7 @@ type WorkerLifecycle interface {
 	StallUnresponsiveWorkers() ([]string, error)
 	LandFinishedLandingWorkers() ([]string, error)
 	DeleteFinishedRetiringWorkers() ([]string, error)
+	ReleaseExpiredQuarantinedWorkers() ([]string, error)
 	GetWorkerStateByName() (map[string]WorkerState, error)
 }
 
@@ -67,6 +68,28 @@ func (lifecycle *workerLifecycle) StallUnresponsiveWorkers() ([]string, error) {
 	return workersAffected(rows)
 }
 
+func (lifecycle *workerLifecycle) ReleaseExpiredQuarantinedWorkers() ([]string, error) {
+	query, args, err := psql.Update("workers").
+		SetMap(map[string]interface{}{
+			"state":             string(WorkerStateRunning),
+			"quarantined_until": nil,
+		}).
+		Where(sq.Eq{"state": string(WorkerStateQuarantined)}).
+		Where(sq.Expr("quarantined_until < NOW()")).
+		Suffix("RETURNING name").
+		ToSql()
+	if err != nil {
+		return []string{}, err
+	}
+
+	rows, err := lifecycle.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return workersAffected(rows)
+}
+
 func (lifecycle *workerLifecycle) DeleteFinishedRetiringWorkers() ([]string, error) {
 	// Squirrel does not have default support for subqueries in where clauses.
 	// We hacked together a way to do it
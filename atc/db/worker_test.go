@@ -388,4 +388,81 @@ var _ = Describe("Worker", func() {
 			})
 		})
 	})
+
+	Describe("CreateContainer", func() {
+		var (
+			worker Worker
+			build  Build
+		)
+
+		BeforeEach(func() {
+			var err error
+			worker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+
+			build, err = defaultTeam.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the team has a container quota", func() {
+			var otherBuild Build
+
+			BeforeEach(func() {
+				err := defaultTeam.UpdateQuota(atc.TeamQuota{MaxContainers: 2})
+				Expect(err).NotTo(HaveOccurred())
+
+				otherBuild, err = defaultTeam.CreateOneOffBuild()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			createContainer := func(build Build, planID string) (CreatingContainer, error) {
+				return worker.CreateContainer(
+					NewBuildStepContainerOwner(build.ID(), atc.PlanID(planID), defaultTeam.ID()),
+					ContainerMetadata{Type: "task", BuildID: build.ID()},
+				)
+			}
+
+			It("fails once the team has as many containers as the quota allows", func() {
+				_, err := createContainer(build, "1")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = createContainer(build, "2")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = createContainer(otherBuild, "1")
+				Expect(err).To(Equal(ContainerQuotaReachedError{
+					TeamName:        defaultTeam.Name(),
+					Containers:      2,
+					MaxContainers:   2,
+					BuildContainers: 0,
+				}))
+			})
+
+			Context("when every container of the team is held by a waiting build", func() {
+				BeforeEach(func() {
+					_, err := createContainer(build, "1")
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = createContainer(otherBuild, "1")
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = createContainer(build, "2")
+					Expect(err).To(BeAssignableToTypeOf(ContainerQuotaReachedError{}))
+				})
+
+				It("lets one of the builds through", func() {
+					_, err := createContainer(otherBuild, "2")
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = createContainer(build, "2")
+					Expect(err).To(Equal(ContainerQuotaReachedError{
+						TeamName:        defaultTeam.Name(),
+						Containers:      3,
+						MaxContainers:   2,
+						BuildContainers: 1,
+					}))
+				})
+			})
+		})
+	})
 })
@@ -3,6 +3,7 @@ package atc
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"math"
 	"reflect"
 	"regexp"
@@ -27,10 +28,7 @@ var VersionConfigDecodeHook = func(
 	switch {
 	case srcType.Kind() == reflect.String:
 		if s, ok := data.(string); ok {
-			return VersionConfig{
-				Every:  s == VersionEvery,
-				Latest: s == VersionLatest,
-			}, nil
+			return versionConfigFromString(s), nil
 		}
 	case srcType.Kind() == reflect.Map:
 		version := Version{}
@@ -163,6 +161,93 @@ var ContainerLimitsParser = func(data interface{}) (ContainerLimits, error) {
 			helper := uint64(uVal)
 			c.CPU = &helper
 
+		} else if key == "disk" {
+			var diskBytes uint64
+			switch val.(type) {
+			case string:
+				diskBytes, err = parseMemoryLimit(val.(string))
+				if err != nil {
+					return ContainerLimits{}, errors.New("could not parse container disk limit")
+				}
+			case *string:
+				if val.(*string) == nil {
+					c.Disk = nil
+					continue
+				}
+				diskBytes, err = parseMemoryLimit(*val.(*string))
+				if err != nil {
+					return ContainerLimits{}, errors.New("could not parse container disk limit")
+				}
+			case float64:
+				if val.(float64) < 0 {
+					return ContainerLimits{}, errors.New("disk limit must not be negative")
+				}
+				diskBytes = uint64(val.(float64))
+			case int:
+				if val.(int) < 0 {
+					return ContainerLimits{}, errors.New("disk limit must not be negative")
+				}
+				diskBytes = uint64(val.(int))
+			default:
+				return ContainerLimits{}, errors.New("could not parse container disk limit")
+			}
+
+			// 0 means unlimited
+			if diskBytes == 0 {
+				c.Disk = nil
+				continue
+			}
+			c.Disk = &diskBytes
+
+		} else if key == "pids" {
+			var pids int
+			switch val.(type) {
+			case float64:
+				pids = int(val.(float64))
+			case int:
+				pids = val.(int)
+			case *int:
+				if val.(*int) == nil {
+					c.Pids = nil
+					continue
+				}
+				pids = *val.(*int)
+			default:
+				return ContainerLimits{}, errors.New("pids limit must be an integer")
+			}
+
+			if pids < 0 {
+				return ContainerLimits{}, errors.New("pids limit must not be negative")
+			}
+
+			// 0 means unlimited
+			if pids == 0 {
+				c.Pids = nil
+				continue
+			}
+			helper := uint64(pids)
+			c.Pids = &helper
+
+		} else if key == "network" {
+			var network string
+			switch val.(type) {
+			case string:
+				network = val.(string)
+			case *string:
+				if val.(*string) == nil {
+					continue
+				}
+				network = *val.(*string)
+			default:
+				return ContainerLimits{}, errors.New("network must be a string")
+			}
+
+			switch network {
+			case "", ContainerNetworkDefault, ContainerNetworkNone:
+				c.Network = network
+			default:
+				return ContainerLimits{}, fmt.Errorf("network must be '%s' or '%s'", ContainerNetworkNone, ContainerNetworkDefault)
+			}
 		}
 	}
 
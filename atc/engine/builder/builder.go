@@ -21,6 +21,7 @@ type StepFactory interface {
 	TaskStep(atc.Plan, db.Build, db.ContainerMetadata, exec.TaskDelegate) exec.Step
 	ArtifactInputStep(atc.Plan, db.Build, exec.BuildStepDelegate) exec.Step
 	ArtifactOutputStep(atc.Plan, db.Build, exec.BuildStepDelegate) exec.Step
+	LockStep(atc.Plan, db.Build, exec.Step, exec.BuildStepDelegate) exec.Step
 }
 
 //go:generate counterfeiter . DelegateFactory
@@ -80,6 +81,10 @@ func (builder *stepBuilder) buildStep(build db.Build, plan atc.Plan) exec.Step {
 		return builder.buildTimeoutStep(build, plan)
 	}
 
+	if plan.Lock != nil {
+		return builder.buildLockStep(build, plan)
+	}
+
 	if plan.Try != nil {
 		return builder.buildTryStep(build, plan)
 	}
@@ -178,6 +183,19 @@ func (builder *stepBuilder) buildTimeoutStep(build db.Build, plan atc.Plan) exec
 	return exec.Timeout(step, plan.Timeout.Duration)
 }
 
+func (builder *stepBuilder) buildLockStep(build db.Build, plan atc.Plan) exec.Step {
+	innerPlan := plan.Lock.Step
+	innerPlan.Attempts = plan.Attempts
+	step := builder.buildStep(build, innerPlan)
+
+	return builder.stepFactory.LockStep(
+		plan,
+		build,
+		step,
+		builder.delegateFactory.BuildStepDelegate(build, plan.ID),
+	)
+}
+
 func (builder *stepBuilder) buildTryStep(build db.Build, plan atc.Plan) exec.Step {
 	innerPlan := plan.Try.Step
 	innerPlan.Attempts = plan.Attempts
@@ -7,6 +7,7 @@ import (
 	"github.com/concourse/concourse/atc/engine/builder"
 	"github.com/concourse/concourse/atc/engine/builder/builderfakes"
 	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -586,6 +587,42 @@ var _ = Describe("Builder", func() {
 					})
 				})
 
+				Context("with a lock", func() {
+					var (
+						taskPlan atc.Plan
+						taskStep *execfakes.FakeStep
+					)
+
+					BeforeEach(func() {
+						taskPlan = planFactory.NewPlan(atc.TaskPlan{
+							Name:       "some-task",
+							ConfigPath: "some-input/build.yml",
+						})
+
+						expectedPlan = planFactory.NewPlan(atc.LockPlan{
+							Name:     "some-lock",
+							Capacity: 2,
+							Step:     taskPlan,
+						})
+
+						taskStep = new(execfakes.FakeStep)
+						fakeStepFactory.TaskStepReturns(taskStep)
+					})
+
+					It("wraps the step in a lock step", func() {
+						Expect(fakeStepFactory.LockStepCallCount()).To(Equal(1))
+
+						plan, build, step, _ := fakeStepFactory.LockStepArgsForCall(0)
+						Expect(plan).To(Equal(expectedPlan))
+						Expect(build).To(Equal(fakeBuild))
+						Expect(step).To(Equal(taskStep))
+
+						build, planID := fakeDelegateFactory.BuildStepDelegateArgsForCall(0)
+						Expect(build).To(Equal(fakeBuild))
+						Expect(planID).To(Equal(expectedPlan.ID))
+					})
+				})
+
 				Context("running hooked composes", func() {
 					Context("with all the hooks", func() {
 						var (
@@ -52,6 +52,20 @@ type FakeStepFactory struct {
 	getStepReturnsOnCall map[int]struct {
 		result1 exec.Step
 	}
+	LockStepStub        func(atc.Plan, db.Build, exec.Step, exec.BuildStepDelegate) exec.Step
+	lockStepMutex       sync.RWMutex
+	lockStepArgsForCall []struct {
+		arg1 atc.Plan
+		arg2 db.Build
+		arg3 exec.Step
+		arg4 exec.BuildStepDelegate
+	}
+	lockStepReturns struct {
+		result1 exec.Step
+	}
+	lockStepReturnsOnCall map[int]struct {
+		result1 exec.Step
+	}
 	PutStepStub        func(atc.Plan, db.Build, exec.StepMetadata, db.ContainerMetadata, exec.PutDelegate) exec.Step
 	putStepMutex       sync.RWMutex
 	putStepArgsForCall []struct {
@@ -273,6 +287,69 @@ func (fake *FakeStepFactory) GetStepReturnsOnCall(i int, result1 exec.Step) {
 	}{result1}
 }
 
+func (fake *FakeStepFactory) LockStep(arg1 atc.Plan, arg2 db.Build, arg3 exec.Step, arg4 exec.BuildStepDelegate) exec.Step {
+	fake.lockStepMutex.Lock()
+	ret, specificReturn := fake.lockStepReturnsOnCall[len(fake.lockStepArgsForCall)]
+	fake.lockStepArgsForCall = append(fake.lockStepArgsForCall, struct {
+		arg1 atc.Plan
+		arg2 db.Build
+		arg3 exec.Step
+		arg4 exec.BuildStepDelegate
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("LockStep", []interface{}{arg1, arg2, arg3, arg4})
+	fake.lockStepMutex.Unlock()
+	if fake.LockStepStub != nil {
+		return fake.LockStepStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.lockStepReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeStepFactory) LockStepCallCount() int {
+	fake.lockStepMutex.RLock()
+	defer fake.lockStepMutex.RUnlock()
+	return len(fake.lockStepArgsForCall)
+}
+
+func (fake *FakeStepFactory) LockStepCalls(stub func(atc.Plan, db.Build, exec.Step, exec.BuildStepDelegate) exec.Step) {
+	fake.lockStepMutex.Lock()
+	defer fake.lockStepMutex.Unlock()
+	fake.LockStepStub = stub
+}
+
+func (fake *FakeStepFactory) LockStepArgsForCall(i int) (atc.Plan, db.Build, exec.Step, exec.BuildStepDelegate) {
+	fake.lockStepMutex.RLock()
+	defer fake.lockStepMutex.RUnlock()
+	argsForCall := fake.lockStepArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeStepFactory) LockStepReturns(result1 exec.Step) {
+	fake.lockStepMutex.Lock()
+	defer fake.lockStepMutex.Unlock()
+	fake.LockStepStub = nil
+	fake.lockStepReturns = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeStepFactory) LockStepReturnsOnCall(i int, result1 exec.Step) {
+	fake.lockStepMutex.Lock()
+	defer fake.lockStepMutex.Unlock()
+	fake.LockStepStub = nil
+	if fake.lockStepReturnsOnCall == nil {
+		fake.lockStepReturnsOnCall = make(map[int]struct {
+			result1 exec.Step
+		})
+	}
+	fake.lockStepReturnsOnCall[i] = struct {
+		result1 exec.Step
+	}{result1}
+}
+
 func (fake *FakeStepFactory) PutStep(arg1 atc.Plan, arg2 db.Build, arg3 exec.StepMetadata, arg4 db.ContainerMetadata, arg5 exec.PutDelegate) exec.Step {
 	fake.putStepMutex.Lock()
 	ret, specificReturn := fake.putStepReturnsOnCall[len(fake.putStepArgsForCall)]
@@ -409,6 +486,8 @@ func (fake *FakeStepFactory) Invocations() map[string][][]interface{} {
 	defer fake.artifactOutputStepMutex.RUnlock()
 	fake.getStepMutex.RLock()
 	defer fake.getStepMutex.RUnlock()
+	fake.lockStepMutex.RLock()
+	defer fake.lockStepMutex.RUnlock()
 	fake.putStepMutex.RLock()
 	defer fake.putStepMutex.RUnlock()
 	fake.taskStepMutex.RLock()
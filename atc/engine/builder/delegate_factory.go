@@ -53,10 +53,11 @@ type getDelegate struct {
 	clock       clock.Clock
 }
 
-func (d *getDelegate) Initializing(logger lager.Logger) {
+func (d *getDelegate) Initializing(logger lager.Logger, appliedDefaults []string) {
 	err := d.build.SaveEvent(event.InitializeGet{
-		Origin: d.eventOrigin,
-		Time:   time.Now().Unix(),
+		Origin:          d.eventOrigin,
+		Time:            time.Now().Unix(),
+		AppliedDefaults: appliedDefaults,
 	})
 	if err != nil {
 		logger.Error("failed-to-save-initialize-get-event", err)
@@ -171,11 +172,12 @@ type taskDelegate struct {
 	eventOrigin event.Origin
 }
 
-func (d *taskDelegate) Initializing(logger lager.Logger, taskConfig atc.TaskConfig) {
+func (d *taskDelegate) Initializing(logger lager.Logger, taskConfig atc.TaskConfig, appliedDefaults []string) {
 	err := d.build.SaveEvent(event.InitializeTask{
-		Origin:     d.eventOrigin,
-		Time:       time.Now().Unix(),
-		TaskConfig: event.ShadowTaskConfig(taskConfig),
+		Origin:          d.eventOrigin,
+		Time:            time.Now().Unix(),
+		TaskConfig:      event.ShadowTaskConfig(taskConfig),
+		AppliedDefaults: appliedDefaults,
 	})
 	if err != nil {
 		logger.Error("failed-to-save-initialize-task-event", err)
@@ -270,6 +272,23 @@ func (delegate *buildStepDelegate) Errored(logger lager.Logger, message string)
 	}
 }
 
+func (delegate *buildStepDelegate) SelectedWorker(logger lager.Logger, workerName string, cache atc.CacheStatus) {
+	err := delegate.build.SaveEvent(event.SelectedWorker{
+		Time: delegate.clock.Now().Unix(),
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		WorkerName: workerName,
+		Cache:      cache,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-selected-worker-event", err)
+		return
+	}
+
+	logger.Info("selected-worker", lager.Data{"worker": workerName, "cache": cache})
+}
+
 func newDBEventWriter(build db.Build, origin event.Origin, clock clock.Clock) io.Writer {
 	return &dbEventWriter{
 		build:  build,
@@ -48,6 +48,20 @@ var _ = Describe("DelegateFactory", func() {
 			delegate = builder.NewGetDelegate(fakeBuild, "some-plan-id", fakeClock)
 		})
 
+		Describe("Initializing", func() {
+			JustBeforeEach(func() {
+				delegate.Initializing(logger, []string{"source defaults for registry-image"})
+			})
+
+			It("saves an event with the applied defaults", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+				savedEvent := fakeBuild.SaveEventArgsForCall(0).(event.InitializeGet)
+				Expect(savedEvent.Origin).To(Equal(event.Origin{ID: event.OriginID("some-plan-id")}))
+				Expect(savedEvent.AppliedDefaults).To(Equal([]string{"source defaults for registry-image"}))
+			})
+		})
+
 		Describe("Finished", func() {
 			JustBeforeEach(func() {
 				delegate.Finished(logger, exitStatus, info)
@@ -113,7 +127,7 @@ var _ = Describe("DelegateFactory", func() {
 
 		Describe("Initializing", func() {
 			JustBeforeEach(func() {
-				delegate.Initializing(logger, config)
+				delegate.Initializing(logger, config, []string{"registry rewrite docker.io => mirror.example.com"})
 			})
 
 			It("saves an event", func() {
@@ -121,6 +135,11 @@ var _ = Describe("DelegateFactory", func() {
 				event := fakeBuild.SaveEventArgsForCall(0)
 				Expect(event.EventType()).To(Equal(atc.EventType("initialize-task")))
 			})
+
+			It("records the applied defaults", func() {
+				savedEvent := fakeBuild.SaveEventArgsForCall(0)
+				Expect(savedEvent.(event.InitializeTask).AppliedDefaults).To(Equal([]string{"registry rewrite docker.io => mirror.example.com"}))
+			})
 		})
 
 		Describe("Starting", func() {
@@ -318,5 +337,23 @@ var _ = Describe("DelegateFactory", func() {
 				})
 			})
 		})
+
+		Describe("SelectedWorker", func() {
+			JustBeforeEach(func() {
+				delegate.SelectedWorker(logger, "some-worker", atc.CacheHit)
+			})
+
+			It("saves it with the current time", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.SelectedWorker{
+					Time:       123456789,
+					WorkerName: "some-worker",
+					Cache:      atc.CacheHit,
+					Origin: event.Origin{
+						ID: "some-plan-id",
+					},
+				}))
+			})
+		})
 	})
 })
@@ -10,6 +10,7 @@ import (
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/worker"
 )
@@ -22,8 +23,12 @@ type stepFactory struct {
 	resourceConfigFactory db.ResourceConfigFactory
 	secretManager         creds.Secrets
 	defaultLimits         atc.ContainerLimits
+	sourceDefaults        atc.SourceDefaults
 	strategy              worker.ContainerPlacementStrategy
 	resourceFactory       resource.ResourceFactory
+	policyChecker         policy.Checker
+	taskResultFactory     db.TaskResultFactory
+	namedLockFactory      db.NamedLockFactory
 }
 
 func NewStepFactory(
@@ -34,8 +39,12 @@ func NewStepFactory(
 	resourceConfigFactory db.ResourceConfigFactory,
 	secretManager creds.Secrets,
 	defaultLimits atc.ContainerLimits,
+	sourceDefaults atc.SourceDefaults,
 	strategy worker.ContainerPlacementStrategy,
 	resourceFactory resource.ResourceFactory,
+	policyChecker policy.Checker,
+	taskResultFactory db.TaskResultFactory,
+	namedLockFactory db.NamedLockFactory,
 ) *stepFactory {
 	return &stepFactory{
 		pool:                  pool,
@@ -45,8 +54,12 @@ func NewStepFactory(
 		resourceConfigFactory: resourceConfigFactory,
 		secretManager:         secretManager,
 		defaultLimits:         defaultLimits,
+		sourceDefaults:        sourceDefaults,
 		strategy:              strategy,
 		resourceFactory:       resourceFactory,
+		policyChecker:         policyChecker,
+		taskResultFactory:     taskResultFactory,
+		namedLockFactory:      namedLockFactory,
 	}
 }
 
@@ -67,7 +80,7 @@ func (factory *stepFactory) GetStep(
 		plan.Get.Name,
 		plan.Get.Type,
 		plan.Get.Resource,
-		creds.NewSource(variables, plan.Get.Source),
+		creds.NewResourceSource(variables, plan.Get.Type, plan.Get.Source, factory.sourceDefaults),
 		creds.NewParams(variables, plan.Get.Params),
 		exec.NewVersionSourceFromPlan(plan.Get),
 		plan.Get.Tags,
@@ -81,7 +94,7 @@ func (factory *stepFactory) GetStep(
 		factory.resourceCacheFactory,
 		stepMetadata,
 
-		creds.NewVersionedResourceTypes(variables, plan.Get.VersionedResourceTypes),
+		creds.NewVersionedResourceTypes(variables, plan.Get.VersionedResourceTypes).WithSourceDefaults(factory.sourceDefaults),
 
 		factory.strategy,
 		factory.pool,
@@ -120,7 +133,7 @@ func (factory *stepFactory) PutStep(
 		plan.Put.Name,
 		plan.Put.Type,
 		plan.Put.Resource,
-		creds.NewSource(variables, plan.Put.Source),
+		creds.NewResourceSource(variables, plan.Put.Type, plan.Put.Source, factory.sourceDefaults),
 		creds.NewParams(variables, plan.Put.Params),
 		plan.Put.Tags,
 		putInputs,
@@ -132,10 +145,11 @@ func (factory *stepFactory) PutStep(
 		workerMetadata,
 		stepMetadata,
 
-		creds.NewVersionedResourceTypes(variables, plan.Put.VersionedResourceTypes),
+		creds.NewVersionedResourceTypes(variables, plan.Put.VersionedResourceTypes).WithSourceDefaults(factory.sourceDefaults),
 
 		factory.strategy,
 		factory.resourceFactory,
+		factory.policyChecker,
 	)
 
 	return exec.LogError(putStep, delegate)
@@ -186,6 +200,8 @@ func (factory *stepFactory) TaskStep(
 		plan.Task.Tags,
 		plan.Task.InputMapping,
 		plan.Task.OutputMapping,
+		plan.Task.RetainOutputs,
+		plan.Task.CacheResult,
 
 		workingDirectory,
 		plan.Task.ImageArtifactName,
@@ -193,16 +209,21 @@ func (factory *stepFactory) TaskStep(
 		delegate,
 
 		factory.pool,
+		factory.client,
 		build.TeamID(),
+		build.TeamName(),
 		build.ID(),
 		build.JobID(),
 		plan.Task.Name,
 		plan.ID,
 		containerMetadata,
 
-		creds.NewVersionedResourceTypes(credMgrVariables, plan.Task.VersionedResourceTypes),
+		creds.NewVersionedResourceTypes(credMgrVariables, plan.Task.VersionedResourceTypes).WithSourceDefaults(factory.sourceDefaults),
 		factory.defaultLimits,
+		factory.sourceDefaults,
 		factory.strategy,
+		factory.policyChecker,
+		factory.taskResultFactory,
 	)
 
 	return exec.LogError(taskStep, delegate)
@@ -223,3 +244,12 @@ func (factory *stepFactory) ArtifactOutputStep(
 ) exec.Step {
 	return exec.NewArtifactOutputStep(plan, build, factory.client, delegate)
 }
+
+func (factory *stepFactory) LockStep(
+	plan atc.Plan,
+	build db.Build,
+	step exec.Step,
+	delegate exec.BuildStepDelegate,
+) exec.Step {
+	return exec.NewLockStep(plan.ID, *plan.Lock, build.TeamID(), build.ID(), factory.namedLockFactory, delegate, step)
+}
@@ -32,9 +32,10 @@ type StepBuilder interface {
 	BuildStep(db.Build) (exec.Step, error)
 }
 
-func NewEngine(builder StepBuilder) Engine {
+func NewEngine(builder StepBuilder, registry *metric.Registry) Engine {
 	return &engine{
-		builder: builder,
+		builder:  builder,
+		registry: registry,
 
 		release:       make(chan bool),
 		trackedStates: new(sync.Map),
@@ -43,7 +44,8 @@ func NewEngine(builder StepBuilder) Engine {
 }
 
 type engine struct {
-	builder StepBuilder
+	builder  StepBuilder
+	registry *metric.Registry
 
 	release       chan bool
 	trackedStates *sync.Map
@@ -59,6 +61,7 @@ func (engine *engine) LookupBuild(logger lager.Logger, build db.Build) Build {
 		cancel,
 		build,
 		engine.builder,
+		engine.registry,
 		engine.release,
 		engine.trackedStates,
 		engine.waitGroup,
@@ -82,6 +85,7 @@ func NewBuild(
 	cancel func(),
 	build db.Build,
 	builder StepBuilder,
+	registry *metric.Registry,
 	release chan bool,
 	trackedStates *sync.Map,
 	waitGroup *sync.WaitGroup,
@@ -90,8 +94,9 @@ func NewBuild(
 		ctx:    ctx,
 		cancel: cancel,
 
-		build:   build,
-		builder: builder,
+		build:    build,
+		builder:  builder,
+		registry: registry,
 
 		release:       release,
 		trackedStates: trackedStates,
@@ -103,8 +108,9 @@ type execBuild struct {
 	ctx    context.Context
 	cancel func()
 
-	build   db.Build
-	builder StepBuilder
+	build    db.Build
+	builder  StepBuilder
+	registry *metric.Registry
 
 	release       chan bool
 	trackedStates *sync.Map
@@ -231,7 +237,7 @@ func (build *execBuild) trackStarted(logger lager.Logger) {
 		BuildName:    build.build.Name(),
 		BuildID:      build.build.ID(),
 		TeamName:     build.build.TeamName(),
-	}.Emit(logger)
+	}.Emit(logger, build.registry)
 }
 
 func (build *execBuild) trackFinished(logger lager.Logger) {
@@ -255,7 +261,7 @@ func (build *execBuild) trackFinished(logger lager.Logger) {
 			BuildStatus:   build.build.Status(),
 			BuildDuration: build.build.EndTime().Sub(build.build.StartTime()),
 			TeamName:      build.build.TeamName(),
-		}.Emit(logger)
+		}.Emit(logger, build.registry)
 	}
 }
 
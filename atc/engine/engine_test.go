@@ -46,7 +46,7 @@ var _ = Describe("Engine", func() {
 		)
 
 		BeforeEach(func() {
-			engine = NewEngine(fakeStepBuilder)
+			engine = NewEngine(fakeStepBuilder, nil)
 		})
 
 		JustBeforeEach(func() {
@@ -83,6 +83,7 @@ var _ = Describe("Engine", func() {
 				func() { cancel <- true },
 				fakeBuild,
 				fakeStepBuilder,
+				nil,
 				release,
 				trackedStates,
 				waitGroup,
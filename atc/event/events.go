@@ -21,13 +21,14 @@ func (FinishTask) EventType() atc.EventType  { return EventTypeFinishTask }
 func (FinishTask) Version() atc.EventVersion { return "4.0" }
 
 type InitializeTask struct {
-	Time       int64      `json:"time"`
-	Origin     Origin     `json:"origin"`
-	TaskConfig TaskConfig `json:"config"`
+	Time            int64      `json:"time"`
+	Origin          Origin     `json:"origin"`
+	TaskConfig      TaskConfig `json:"config"`
+	AppliedDefaults []string   `json:"applied_defaults,omitempty"`
 }
 
 func (InitializeTask) EventType() atc.EventType  { return EventTypeInitializeTask }
-func (InitializeTask) Version() atc.EventVersion { return "4.0" }
+func (InitializeTask) Version() atc.EventVersion { return "4.1" }
 
 // shadow the real atc.TaskConfig
 type TaskConfig struct {
@@ -112,12 +113,13 @@ const (
 )
 
 type InitializeGet struct {
-	Origin Origin `json:"origin"`
-	Time   int64  `json:"time,omitempty"`
+	Origin          Origin   `json:"origin"`
+	Time            int64    `json:"time,omitempty"`
+	AppliedDefaults []string `json:"applied_defaults,omitempty"`
 }
 
 func (InitializeGet) EventType() atc.EventType  { return EventTypeInitializeGet }
-func (InitializeGet) Version() atc.EventVersion { return "1.0" }
+func (InitializeGet) Version() atc.EventVersion { return "1.1" }
 
 type StartGet struct {
 	Origin Origin `json:"origin"`
@@ -164,3 +166,13 @@ type FinishPut struct {
 
 func (FinishPut) EventType() atc.EventType  { return EventTypeFinishPut }
 func (FinishPut) Version() atc.EventVersion { return "5.1" }
+
+type SelectedWorker struct {
+	Time       int64           `json:"time"`
+	Origin     Origin          `json:"origin"`
+	WorkerName string          `json:"selected_worker"`
+	Cache      atc.CacheStatus `json:"cache,omitempty"`
+}
+
+func (SelectedWorker) EventType() atc.EventType  { return EventTypeSelectedWorker }
+func (SelectedWorker) Version() atc.EventVersion { return "1.0" }
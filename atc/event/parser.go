@@ -46,6 +46,7 @@ func init() {
 	RegisterEvent(Status{})
 	RegisterEvent(Log{})
 	RegisterEvent(Error{})
+	RegisterEvent(SelectedWorker{})
 
 	// deprecated:
 	RegisterEvent(InitializeV10{})
@@ -36,6 +36,9 @@ const (
 	// finished putting something
 	EventTypeFinishPut atc.EventType = "finish-put"
 
+	// worker chosen for a step's container
+	EventTypeSelectedWorker atc.EventType = "selected-worker"
+
 	// error occurred
 	EventTypeError atc.EventType = "error"
 )
@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec"
 )
@@ -35,10 +36,18 @@ type FakeGetDelegate struct {
 	imageVersionDeterminedReturnsOnCall map[int]struct {
 		result1 error
 	}
-	InitializingStub        func(lager.Logger)
+	InitializingStub        func(lager.Logger, []string)
 	initializingMutex       sync.RWMutex
 	initializingArgsForCall []struct {
 		arg1 lager.Logger
+		arg2 []string
+	}
+	SelectedWorkerStub        func(lager.Logger, string, atc.CacheStatus)
+	selectedWorkerMutex       sync.RWMutex
+	selectedWorkerArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 atc.CacheStatus
 	}
 	StartingStub        func(lager.Logger)
 	startingMutex       sync.RWMutex
@@ -194,15 +203,21 @@ func (fake *FakeGetDelegate) ImageVersionDeterminedReturnsOnCall(i int, result1
 	}{result1}
 }
 
-func (fake *FakeGetDelegate) Initializing(arg1 lager.Logger) {
+func (fake *FakeGetDelegate) Initializing(arg1 lager.Logger, arg2 []string) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
 	fake.initializingMutex.Lock()
 	fake.initializingArgsForCall = append(fake.initializingArgsForCall, struct {
 		arg1 lager.Logger
-	}{arg1})
-	fake.recordInvocation("Initializing", []interface{}{arg1})
+		arg2 []string
+	}{arg1, arg2Copy})
+	fake.recordInvocation("Initializing", []interface{}{arg1, arg2Copy})
 	fake.initializingMutex.Unlock()
 	if fake.InitializingStub != nil {
-		fake.InitializingStub(arg1)
+		fake.InitializingStub(arg1, arg2)
 	}
 }
 
@@ -212,17 +227,50 @@ func (fake *FakeGetDelegate) InitializingCallCount() int {
 	return len(fake.initializingArgsForCall)
 }
 
-func (fake *FakeGetDelegate) InitializingCalls(stub func(lager.Logger)) {
+func (fake *FakeGetDelegate) InitializingCalls(stub func(lager.Logger, []string)) {
 	fake.initializingMutex.Lock()
 	defer fake.initializingMutex.Unlock()
 	fake.InitializingStub = stub
 }
 
-func (fake *FakeGetDelegate) InitializingArgsForCall(i int) lager.Logger {
+func (fake *FakeGetDelegate) InitializingArgsForCall(i int) (lager.Logger, []string) {
 	fake.initializingMutex.RLock()
 	defer fake.initializingMutex.RUnlock()
 	argsForCall := fake.initializingArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeGetDelegate) SelectedWorker(arg1 lager.Logger, arg2 string, arg3 atc.CacheStatus) {
+	fake.selectedWorkerMutex.Lock()
+	fake.selectedWorkerArgsForCall = append(fake.selectedWorkerArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 atc.CacheStatus
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("SelectedWorker", []interface{}{arg1, arg2, arg3})
+	fake.selectedWorkerMutex.Unlock()
+	if fake.SelectedWorkerStub != nil {
+		fake.SelectedWorkerStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeGetDelegate) SelectedWorkerCallCount() int {
+	fake.selectedWorkerMutex.RLock()
+	defer fake.selectedWorkerMutex.RUnlock()
+	return len(fake.selectedWorkerArgsForCall)
+}
+
+func (fake *FakeGetDelegate) SelectedWorkerCalls(stub func(lager.Logger, string, atc.CacheStatus)) {
+	fake.selectedWorkerMutex.Lock()
+	defer fake.selectedWorkerMutex.Unlock()
+	fake.SelectedWorkerStub = stub
+}
+
+func (fake *FakeGetDelegate) SelectedWorkerArgsForCall(i int) (lager.Logger, string, atc.CacheStatus) {
+	fake.selectedWorkerMutex.RLock()
+	defer fake.selectedWorkerMutex.RUnlock()
+	argsForCall := fake.selectedWorkerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeGetDelegate) Starting(arg1 lager.Logger) {
@@ -371,6 +419,8 @@ func (fake *FakeGetDelegate) Invocations() map[string][][]interface{} {
 	defer fake.imageVersionDeterminedMutex.RUnlock()
 	fake.initializingMutex.RLock()
 	defer fake.initializingMutex.RUnlock()
+	fake.selectedWorkerMutex.RLock()
+	defer fake.selectedWorkerMutex.RUnlock()
 	fake.startingMutex.RLock()
 	defer fake.startingMutex.RUnlock()
 	fake.stderrMutex.RLock()
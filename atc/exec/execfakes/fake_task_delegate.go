@@ -35,11 +35,19 @@ type FakeTaskDelegate struct {
 	imageVersionDeterminedReturnsOnCall map[int]struct {
 		result1 error
 	}
-	InitializingStub        func(lager.Logger, atc.TaskConfig)
+	InitializingStub        func(lager.Logger, atc.TaskConfig, []string)
 	initializingMutex       sync.RWMutex
 	initializingArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 atc.TaskConfig
+		arg3 []string
+	}
+	SelectedWorkerStub        func(lager.Logger, string, atc.CacheStatus)
+	selectedWorkerMutex       sync.RWMutex
+	selectedWorkerArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 atc.CacheStatus
 	}
 	StartingStub        func(lager.Logger, atc.TaskConfig)
 	startingMutex       sync.RWMutex
@@ -195,16 +203,22 @@ func (fake *FakeTaskDelegate) ImageVersionDeterminedReturnsOnCall(i int, result1
 	}{result1}
 }
 
-func (fake *FakeTaskDelegate) Initializing(arg1 lager.Logger, arg2 atc.TaskConfig) {
+func (fake *FakeTaskDelegate) Initializing(arg1 lager.Logger, arg2 atc.TaskConfig, arg3 []string) {
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
 	fake.initializingMutex.Lock()
 	fake.initializingArgsForCall = append(fake.initializingArgsForCall, struct {
 		arg1 lager.Logger
 		arg2 atc.TaskConfig
-	}{arg1, arg2})
-	fake.recordInvocation("Initializing", []interface{}{arg1, arg2})
+		arg3 []string
+	}{arg1, arg2, arg3Copy})
+	fake.recordInvocation("Initializing", []interface{}{arg1, arg2, arg3Copy})
 	fake.initializingMutex.Unlock()
 	if fake.InitializingStub != nil {
-		fake.InitializingStub(arg1, arg2)
+		fake.InitializingStub(arg1, arg2, arg3)
 	}
 }
 
@@ -214,17 +228,50 @@ func (fake *FakeTaskDelegate) InitializingCallCount() int {
 	return len(fake.initializingArgsForCall)
 }
 
-func (fake *FakeTaskDelegate) InitializingCalls(stub func(lager.Logger, atc.TaskConfig)) {
+func (fake *FakeTaskDelegate) InitializingCalls(stub func(lager.Logger, atc.TaskConfig, []string)) {
 	fake.initializingMutex.Lock()
 	defer fake.initializingMutex.Unlock()
 	fake.InitializingStub = stub
 }
 
-func (fake *FakeTaskDelegate) InitializingArgsForCall(i int) (lager.Logger, atc.TaskConfig) {
+func (fake *FakeTaskDelegate) InitializingArgsForCall(i int) (lager.Logger, atc.TaskConfig, []string) {
 	fake.initializingMutex.RLock()
 	defer fake.initializingMutex.RUnlock()
 	argsForCall := fake.initializingArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTaskDelegate) SelectedWorker(arg1 lager.Logger, arg2 string, arg3 atc.CacheStatus) {
+	fake.selectedWorkerMutex.Lock()
+	fake.selectedWorkerArgsForCall = append(fake.selectedWorkerArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 atc.CacheStatus
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("SelectedWorker", []interface{}{arg1, arg2, arg3})
+	fake.selectedWorkerMutex.Unlock()
+	if fake.SelectedWorkerStub != nil {
+		fake.SelectedWorkerStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeTaskDelegate) SelectedWorkerCallCount() int {
+	fake.selectedWorkerMutex.RLock()
+	defer fake.selectedWorkerMutex.RUnlock()
+	return len(fake.selectedWorkerArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) SelectedWorkerCalls(stub func(lager.Logger, string, atc.CacheStatus)) {
+	fake.selectedWorkerMutex.Lock()
+	defer fake.selectedWorkerMutex.Unlock()
+	fake.SelectedWorkerStub = stub
+}
+
+func (fake *FakeTaskDelegate) SelectedWorkerArgsForCall(i int) (lager.Logger, string, atc.CacheStatus) {
+	fake.selectedWorkerMutex.RLock()
+	defer fake.selectedWorkerMutex.RUnlock()
+	argsForCall := fake.selectedWorkerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeTaskDelegate) Starting(arg1 lager.Logger, arg2 atc.TaskConfig) {
@@ -374,6 +421,8 @@ func (fake *FakeTaskDelegate) Invocations() map[string][][]interface{} {
 	defer fake.imageVersionDeterminedMutex.RUnlock()
 	fake.initializingMutex.RLock()
 	defer fake.initializingMutex.RUnlock()
+	fake.selectedWorkerMutex.RLock()
+	defer fake.selectedWorkerMutex.RUnlock()
 	fake.startingMutex.RLock()
 	defer fake.startingMutex.RUnlock()
 	fake.stderrMutex.RLock()
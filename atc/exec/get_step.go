@@ -38,7 +38,7 @@ func (e ErrResourceNotFound) Error() string {
 type GetDelegate interface {
 	BuildStepDelegate
 
-	Initializing(lager.Logger)
+	Initializing(lager.Logger, []string)
 	Starting(lager.Logger)
 	Finished(lager.Logger, ExitStatus, VersionInfo)
 }
@@ -158,7 +158,12 @@ func (step *GetStep) Run(ctx context.Context, state RunState) error {
 		"job-id":    step.build.JobID(),
 	})
 
-	step.delegate.Initializing(logger)
+	appliedDefaults, err := step.appliedDefaults()
+	if err != nil {
+		logger.Error("failed-to-describe-applied-defaults", err)
+	}
+
+	step.delegate.Initializing(logger, appliedDefaults)
 
 	version, err := step.versionSource.Version(state)
 	if err != nil {
@@ -219,6 +224,18 @@ func (step *GetStep) Run(ctx context.Context, state RunState) error {
 		return err
 	}
 
+	_, cached, err := resourceInstance.FindOn(logger, chosenWorker)
+	if err != nil {
+		return err
+	}
+
+	cacheStatus := atc.CacheMiss
+	if cached {
+		cacheStatus = atc.CacheHit
+	}
+
+	step.delegate.SelectedWorker(logger, chosenWorker.Name(), cacheStatus)
+
 	step.delegate.Starting(logger)
 
 	versionedSource, err := step.resourceFetcher.Fetch(
@@ -292,6 +309,22 @@ func (step *GetStep) Run(ctx context.Context, state RunState) error {
 	return nil
 }
 
+// appliedDefaults describes the operator's defaults and registry rewrite rules
+// that change the source of the resource or of its type.
+func (step *GetStep) appliedDefaults() ([]string, error) {
+	applied, err := step.source.AppliedDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	typeApplied, err := step.resourceTypes.AppliedDefaults(step.resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(applied, typeApplied...), nil
+}
+
 // Succeeded returns true if the resource was successfully fetched.
 func (step *GetStep) Succeeded() bool {
 	return step.succeeded
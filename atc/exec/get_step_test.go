@@ -74,6 +74,7 @@ var _ = Describe("GetStep", func() {
 		fakeWorker = new(workerfakes.FakeWorker)
 		fakeResourceFetcher = new(resourcefakes.FakeFetcher)
 		fakePool = new(workerfakes.FakePool)
+		fakePool.FindOrChooseWorkerForContainerReturns(fakeWorker, nil)
 		fakeStrategy = new(workerfakes.FakeContainerPlacementStrategy)
 		fakeResourceCacheFactory = new(dbfakes.FakeResourceCacheFactory)
 
@@ -228,6 +229,25 @@ var _ = Describe("GetStep", func() {
 			Expect(resourceInstance.LockName("fake-worker")).To(Equal(expectedLockName))
 		})
 
+		It("reports the selected worker and that the version was not cached", func() {
+			Expect(fakeDelegate.SelectedWorkerCallCount()).To(Equal(1))
+			_, workerName, cache := fakeDelegate.SelectedWorkerArgsForCall(0)
+			Expect(workerName).To(Equal("some-worker"))
+			Expect(cache).To(Equal(atc.CacheMiss))
+		})
+
+		Context("when the version is already cached on the worker", func() {
+			BeforeEach(func() {
+				fakeWorker.FindVolumeForResourceCacheReturns(new(workerfakes.FakeVolume), true, nil)
+			})
+
+			It("reports a cache hit", func() {
+				Expect(fakeDelegate.SelectedWorkerCallCount()).To(Equal(1))
+				_, _, cache := fakeDelegate.SelectedWorkerArgsForCall(0)
+				Expect(cache).To(Equal(atc.CacheHit))
+			})
+		})
+
 		Context("when fetching resource succeeds", func() {
 			BeforeEach(func() {
 				fakeVersionedSource.VersionReturns(atc.Version{"some": "version"})
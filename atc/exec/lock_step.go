@@ -0,0 +1,118 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// LockStep holds a named lock of the team while running a step.
+type LockStep struct {
+	planID      atc.PlanID
+	plan        atc.LockPlan
+	teamID      int
+	buildID     int
+	lockFactory db.NamedLockFactory
+	delegate    BuildStepDelegate
+	step        Step
+}
+
+func NewLockStep(
+	planID atc.PlanID,
+	plan atc.LockPlan,
+	teamID int,
+	buildID int,
+	lockFactory db.NamedLockFactory,
+	delegate BuildStepDelegate,
+	step Step,
+) Step {
+	return &LockStep{
+		planID:      planID,
+		plan:        plan,
+		teamID:      teamID,
+		buildID:     buildID,
+		lockFactory: lockFactory,
+		delegate:    delegate,
+		step:        step,
+	}
+}
+
+// Run queues for the lock, waits until it is acquired and then runs the nested
+// step. The lock is released once the nested step exits, or right away if the
+// step is interrupted while waiting. A resumed build picks up the claim it
+// made before.
+func (step *LockStep) Run(ctx context.Context, state RunState) error {
+	logger := lagerctx.FromContext(ctx).Session("lock", lager.Data{
+		"lock": step.plan.Name,
+	})
+
+	claimID, err := step.lockFactory.ClaimNamedLock(step.teamID, step.plan.Name, step.plan.Capacity, step.buildID, step.planID)
+	if err != nil {
+		logger.Error("failed-to-claim-lock", err)
+		return err
+	}
+
+	defer func() {
+		err := step.lockFactory.ReleaseNamedLock(claimID)
+		if err != nil {
+			logger.Error("failed-to-release-lock", err)
+		}
+	}()
+
+	err = step.waitForLock(ctx, logger, claimID)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("acquired")
+
+	return step.step.Run(ctx, state)
+}
+
+func (step *LockStep) waitForLock(ctx context.Context, logger lager.Logger, claimID int) error {
+	notifier, err := step.lockFactory.NamedLockNotifier()
+	if err != nil {
+		logger.Error("failed-to-listen-for-lock", err)
+		return err
+	}
+
+	defer notifier.Close()
+
+	waiting := false
+	for {
+		acquired, err := step.lockFactory.AcquireNamedLock(claimID)
+		if err != nil {
+			logger.Error("failed-to-acquire-lock", err)
+			return err
+		}
+
+		if acquired {
+			if waiting {
+				fmt.Fprintf(step.delegate.Stdout(), "acquired lock '%s'\n", step.plan.Name)
+			}
+
+			return nil
+		}
+
+		if !waiting {
+			logger.Info("waiting")
+			fmt.Fprintf(step.delegate.Stdout(), "waiting for lock '%s'...\n", step.plan.Name)
+			waiting = true
+		}
+
+		select {
+		case <-notifier.Notify():
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Succeeded is true if the nested step completed successfully.
+func (step *LockStep) Succeeded() bool {
+	return step.step.Succeeded()
+}
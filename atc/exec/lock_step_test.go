@@ -0,0 +1,156 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/artifact"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("LockStep", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeStep        *execfakes.FakeStep
+		fakeLockFactory *dbfakes.FakeNamedLockFactory
+		fakeNotifier    *dbfakes.FakeNotifier
+		fakeDelegate    *execfakes.FakeBuildStepDelegate
+		notifications   chan struct{}
+		stdoutBuf       *gbytes.Buffer
+		repo            *artifact.Repository
+		state           *execfakes.FakeRunState
+		step            Step
+		stepErr         error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		fakeStep = new(execfakes.FakeStep)
+
+		fakeLockFactory = new(dbfakes.FakeNamedLockFactory)
+		fakeLockFactory.ClaimNamedLockReturns(42, nil)
+		fakeLockFactory.AcquireNamedLockReturns(true, nil)
+
+		notifications = make(chan struct{}, 1)
+		fakeNotifier = new(dbfakes.FakeNotifier)
+		fakeNotifier.NotifyReturns(notifications)
+		fakeLockFactory.NamedLockNotifierReturns(fakeNotifier, nil)
+
+		stdoutBuf = gbytes.NewBuffer()
+		fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+		fakeDelegate.StdoutReturns(stdoutBuf)
+
+		repo = artifact.NewRepository()
+		state = new(execfakes.FakeRunState)
+		state.ArtifactsReturns(repo)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step = NewLockStep(
+			atc.PlanID("some-plan"),
+			atc.LockPlan{Name: "some-lock", Capacity: 2},
+			1,
+			123,
+			fakeLockFactory,
+			fakeDelegate,
+			fakeStep,
+		)
+
+		stepErr = step.Run(ctx, state)
+	})
+
+	It("claims the lock for the step of the build", func() {
+		Expect(fakeLockFactory.ClaimNamedLockCallCount()).To(Equal(1))
+		teamID, name, capacity, buildID, planID := fakeLockFactory.ClaimNamedLockArgsForCall(0)
+		Expect(teamID).To(Equal(1))
+		Expect(name).To(Equal("some-lock"))
+		Expect(capacity).To(Equal(2))
+		Expect(buildID).To(Equal(123))
+		Expect(planID).To(Equal(atc.PlanID("some-plan")))
+	})
+
+	It("runs the step and releases the lock", func() {
+		Expect(stepErr).ToNot(HaveOccurred())
+		Expect(fakeStep.RunCallCount()).To(Equal(1))
+
+		Expect(fakeLockFactory.ReleaseNamedLockCallCount()).To(Equal(1))
+		Expect(fakeLockFactory.ReleaseNamedLockArgsForCall(0)).To(Equal(42))
+		Expect(fakeNotifier.CloseCallCount()).To(Equal(1))
+	})
+
+	Context("when the step fails", func() {
+		BeforeEach(func() {
+			fakeStep.RunReturns(errors.New("nope"))
+		})
+
+		It("returns the error and releases the lock", func() {
+			Expect(stepErr).To(MatchError("nope"))
+			Expect(fakeLockFactory.ReleaseNamedLockCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the lock is held by another build", func() {
+		BeforeEach(func() {
+			fakeLockFactory.AcquireNamedLockReturnsOnCall(0, false, nil)
+			fakeLockFactory.AcquireNamedLockReturnsOnCall(1, true, nil)
+			notifications <- struct{}{}
+		})
+
+		It("waits until it is released", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(fakeLockFactory.AcquireNamedLockCallCount()).To(Equal(2))
+			Expect(fakeStep.RunCallCount()).To(Equal(1))
+
+			Expect(stdoutBuf).To(gbytes.Say("waiting for lock 'some-lock'"))
+			Expect(stdoutBuf).To(gbytes.Say("acquired lock 'some-lock'"))
+		})
+	})
+
+	Context("when interrupted while waiting", func() {
+		BeforeEach(func() {
+			fakeLockFactory.AcquireNamedLockReturns(false, nil)
+			cancel()
+		})
+
+		It("gives up the claim without running the step", func() {
+			Expect(stepErr).To(Equal(context.Canceled))
+			Expect(fakeStep.RunCallCount()).To(BeZero())
+			Expect(fakeLockFactory.ReleaseNamedLockCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when claiming the lock fails", func() {
+		BeforeEach(func() {
+			fakeLockFactory.ClaimNamedLockReturns(0, errors.New("nope"))
+		})
+
+		It("returns the error without running the step", func() {
+			Expect(stepErr).To(MatchError("nope"))
+			Expect(fakeStep.RunCallCount()).To(BeZero())
+			Expect(fakeLockFactory.ReleaseNamedLockCallCount()).To(BeZero())
+		})
+	})
+
+	Describe("Succeeded", func() {
+		BeforeEach(func() {
+			fakeStep.SucceededReturns(true)
+		})
+
+		It("is true if the step succeeded", func() {
+			Expect(step.Succeeded()).To(BeTrue())
+		})
+	})
+})
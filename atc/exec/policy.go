@@ -0,0 +1,74 @@
+package exec
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/policy"
+)
+
+// StepPolicyData is the configuration fragment sent to the policy checker
+// before a task or put step creates its container.
+type StepPolicyData struct {
+	Step       string   `json:"step"`
+	Name       string   `json:"name"`
+	Job        string   `json:"job,omitempty"`
+	Privileged bool     `json:"privileged"`
+	Tags       atc.Tags `json:"tags,omitempty"`
+
+	Platform     string `json:"platform,omitempty"`
+	ResourceType string `json:"resource_type,omitempty"`
+
+	Image *StepPolicyImage `json:"image,omitempty"`
+}
+
+// StepPolicyImage describes the image a step's container will run. Only the
+// repository and tag are taken from the image source, so that credentials
+// configured alongside them are never sent to the policy agent.
+type StepPolicyImage struct {
+	Type       string `json:"type,omitempty"`
+	Repository string `json:"repository,omitempty"`
+	Tag        string `json:"tag,omitempty"`
+	RootfsURI  string `json:"rootfs_uri,omitempty"`
+	Artifact   string `json:"artifact,omitempty"`
+}
+
+func policyImage(imageType string, source atc.Source) *StepPolicyImage {
+	image := &StepPolicyImage{Type: imageType}
+
+	if repository, ok := source["repository"].(string); ok {
+		image.Repository = repository
+	}
+
+	if tag, ok := source["tag"].(string); ok {
+		image.Tag = tag
+	}
+
+	return image
+}
+
+// checkPolicy consults the policy checker, writing any warnings to the build
+// output. A denial is returned as a policy.DeniedError so that it errors the
+// step.
+func checkPolicy(checker policy.Checker, input policy.Input, stderr io.Writer) error {
+	result, err := checker.Check(input)
+	if err != nil {
+		return err
+	}
+
+	if result.Denied() {
+		return policy.DeniedError{
+			Action:  input.Action,
+			Reasons: result.Reasons,
+		}
+	}
+
+	if result.Warned() {
+		for _, reason := range result.Reasons {
+			fmt.Fprintln(stderr, "[WARNING] policy check:", reason)
+		}
+	}
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/worker"
 )
@@ -49,6 +50,7 @@ type PutStep struct {
 
 	strategy        worker.ContainerPlacementStrategy
 	resourceFactory resource.ResourceFactory
+	policyChecker   policy.Checker
 }
 
 func NewPutStep(
@@ -69,6 +71,7 @@ func NewPutStep(
 	resourceTypes creds.VersionedResourceTypes,
 	strategy worker.ContainerPlacementStrategy,
 	resourceFactory resource.ResourceFactory,
+	policyChecker policy.Checker,
 ) *PutStep {
 	return &PutStep{
 		build: build,
@@ -89,6 +92,30 @@ func NewPutStep(
 		resourceTypes:         resourceTypes,
 		strategy:              strategy,
 		resourceFactory:       resourceFactory,
+		policyChecker:         policyChecker,
+	}
+}
+
+func (step *PutStep) policyInput() policy.Input {
+	data := StepPolicyData{
+		Step:         "put",
+		Name:         step.name,
+		Job:          step.build.JobName(),
+		Tags:         step.tags,
+		ResourceType: step.resourceType,
+	}
+
+	customType, found := step.resourceTypes.Lookup(step.resourceType)
+	if found {
+		data.Privileged = customType.Privileged
+		data.Image = policyImage(customType.Type, customType.ResourceType.Source)
+	}
+
+	return policy.Input{
+		Action:   policy.ActionRunPut,
+		Team:     step.build.TeamName(),
+		Pipeline: step.build.PipelineName(),
+		Data:     data,
 	}
 }
 
@@ -109,6 +136,11 @@ func (step *PutStep) Run(ctx context.Context, state RunState) error {
 
 	step.delegate.Initializing(logger)
 
+	err := checkPolicy(step.policyChecker, step.policyInput(), step.delegate.Stderr())
+	if err != nil {
+		return err
+	}
+
 	containerInputs, err := step.inputs.FindAll(state.Artifacts())
 	if err != nil {
 		return err
@@ -141,6 +173,8 @@ func (step *PutStep) Run(ctx context.Context, state RunState) error {
 		return err
 	}
 
+	step.delegate.SelectedWorker(logger, chosenWorker.Name(), "")
+
 	containerSpec.BindMounts = []worker.BindMountSource{
 		&worker.CertsVolumeMount{Logger: logger},
 	}
@@ -158,7 +192,9 @@ func (step *PutStep) Run(ctx context.Context, state RunState) error {
 		return err
 	}
 
-	source, err := step.source.Evaluate()
+	// the put pushes to the repository as configured; only pulling images goes
+	// through the registry rewrite rules
+	source, err := step.source.WithoutRegistryRewrites().Evaluate()
 	if err != nil {
 		return err
 	}
@@ -199,7 +235,14 @@ func (step *PutStep) Run(ctx context.Context, state RunState) error {
 
 	if step.resource != "" {
 		logger = logger.WithData(lager.Data{"step": step.name, "resource": step.resource, "resource-type": step.resourceType, "version": step.versionInfo.Version})
-		err = step.build.SaveOutput(logger, step.resourceType, source, step.resourceTypes, step.versionInfo.Version, db.NewResourceConfigMetadataFields(step.versionInfo.Metadata), step.name, step.resource)
+
+		// save the output for the source the resource is checked with
+		outputSource, err := step.source.Evaluate()
+		if err != nil {
+			return err
+		}
+
+		err = step.build.SaveOutput(logger, step.resourceType, outputSource, step.resourceTypes, step.versionInfo.Version, db.NewResourceConfigMetadataFields(step.versionInfo.Metadata), step.name, step.resource)
 		if err != nil {
 			logger.Error("failed-to-save-output", err)
 			return err
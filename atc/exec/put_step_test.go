@@ -12,6 +12,8 @@ import (
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/artifact"
 	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/atc/policy/policyfakes"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/resource/resourcefakes"
 	"github.com/concourse/concourse/atc/worker"
@@ -35,6 +37,7 @@ var _ = Describe("PutStep", func() {
 		fakeWorker                *workerfakes.FakeWorker
 		fakeResourceFactory       *resourcefakes.FakeResourceFactory
 		fakeResourceConfigFactory *dbfakes.FakeResourceConfigFactory
+		fakePolicyChecker         *policyfakes.FakeChecker
 
 		variables creds.Variables
 
@@ -48,6 +51,7 @@ var _ = Describe("PutStep", func() {
 		fakeDelegate *execfakes.FakePutDelegate
 
 		resourceTypes creds.VersionedResourceTypes
+		source        creds.Source
 
 		repo  *artifact.Repository
 		state *execfakes.FakeRunState
@@ -77,6 +81,8 @@ var _ = Describe("PutStep", func() {
 		fakeWorker = new(workerfakes.FakeWorker)
 		fakeResourceFactory = new(resourcefakes.FakeResourceFactory)
 		fakeResourceConfigFactory = new(dbfakes.FakeResourceConfigFactory)
+		fakePolicyChecker = new(policyfakes.FakeChecker)
+		fakePolicyChecker.CheckReturns(policy.Result{Decision: policy.DecisionAllow}, nil)
 		variables = template.StaticVariables{
 			"custom-param": "source",
 			"source-param": "super-secret-source",
@@ -106,6 +112,8 @@ var _ = Describe("PutStep", func() {
 		stepErr = nil
 
 		putInputs = exec.NewAllInputs()
+
+		source = creds.NewSource(variables, atc.Source{"some": "((source-param))"})
 	})
 
 	AfterEach(func() {
@@ -118,7 +126,7 @@ var _ = Describe("PutStep", func() {
 			"some-name",
 			"some-resource-type",
 			pipelineResourceName,
-			creds.NewSource(variables, atc.Source{"some": "((source-param))"}),
+			source,
 			creds.NewParams(variables, atc.Params{"some-param": "some-value"}),
 			[]string{"some", "tags"},
 			putInputs,
@@ -131,6 +139,7 @@ var _ = Describe("PutStep", func() {
 			resourceTypes,
 			fakeStrategy,
 			fakeResourceFactory,
+			fakePolicyChecker,
 		)
 
 		stepErr = putStep.Run(ctx, state)
@@ -290,6 +299,26 @@ var _ = Describe("PutStep", func() {
 				Expect(resourceName).To(Equal("some-resource"))
 			})
 
+			Context("when the source has registry rewrite rules", func() {
+				BeforeEach(func() {
+					source = creds.NewResourceSource(variables, "registry-image", atc.Source{"repository": "busybox"}, atc.SourceDefaults{
+						RegistryRewriteRules: []atc.RegistryRewriteRule{
+							{From: "docker.io", To: "mirror.example.com"},
+						},
+					})
+				})
+
+				It("puts to the configured repository", func() {
+					_, _, putSource, _ := fakeResource.PutArgsForCall(0)
+					Expect(putSource).To(Equal(atc.Source{"repository": "busybox"}))
+				})
+
+				It("saves the output for the rewritten repository that is checked", func() {
+					_, _, actualSource, _, _, _, _, _ := fakeBuild.SaveOutputArgsForCall(0)
+					Expect(actualSource).To(Equal(atc.Source{"repository": "mirror.example.com/library/busybox"}))
+				})
+			})
+
 			Context("when the resource is blank", func() {
 				BeforeEach(func() {
 					pipelineResourceName = ""
@@ -378,6 +407,66 @@ var _ = Describe("PutStep", func() {
 			})
 		})
 
+		Describe("policy checks", func() {
+			BeforeEach(func() {
+				fakeBuild.TeamNameReturns("some-team")
+				fakeBuild.PipelineNameReturns("some-pipeline")
+				fakeBuild.JobNameReturns("some-job")
+
+				fakePool.FindOrChooseWorkerForContainerReturns(fakeWorker, nil)
+				fakeWorker.FindOrCreateContainerReturns(nil, errors.New("not here"))
+			})
+
+			It("checks the put's resource type", func() {
+				Expect(fakePolicyChecker.CheckCallCount()).To(Equal(1))
+				Expect(fakePolicyChecker.CheckArgsForCall(0)).To(Equal(policy.Input{
+					Action:   policy.ActionRunPut,
+					Team:     "some-team",
+					Pipeline: "some-pipeline",
+					Data: exec.StepPolicyData{
+						Step:         "put",
+						Name:         "some-name",
+						Job:          "some-job",
+						Tags:         []string{"some", "tags"},
+						ResourceType: "some-resource-type",
+					},
+				}))
+			})
+
+			Context("when the policy denies the put", func() {
+				BeforeEach(func() {
+					fakePolicyChecker.CheckReturns(policy.Result{
+						Decision: policy.DecisionDeny,
+						Reasons:  []string{"puts are frozen"},
+					}, nil)
+				})
+
+				It("returns the denial", func() {
+					Expect(stepErr).To(Equal(policy.DeniedError{
+						Action:  policy.ActionRunPut,
+						Reasons: []string{"puts are frozen"},
+					}))
+				})
+
+				It("does not create a container", func() {
+					Expect(fakePool.FindOrChooseWorkerForContainerCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the policy warns about the put", func() {
+				BeforeEach(func() {
+					fakePolicyChecker.CheckReturns(policy.Result{
+						Decision: policy.DecisionWarn,
+						Reasons:  []string{"puts are discouraged"},
+					}, nil)
+				})
+
+				It("writes the warning to stderr", func() {
+					Expect(stderrBuf).To(gbytes.Say(`\[WARNING\] policy check: puts are discouraged`))
+				})
+			})
+		})
+
 		Context("when find or choosing a worker fails", func() {
 			disaster := errors.New("nope")
 
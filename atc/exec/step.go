@@ -40,6 +40,11 @@ type BuildStepDelegate interface {
 	Stderr() io.Writer
 
 	Errored(lager.Logger, string)
+
+	// SelectedWorker is called once the step has chosen a worker for its
+	// container. The cache status is empty for steps which don't use a
+	// resource cache.
+	SelectedWorker(lager.Logger, string, atc.CacheStatus)
 }
 
 //go:generate counterfeiter . RunState
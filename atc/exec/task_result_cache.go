@@ -0,0 +1,169 @@
+package exec
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec/artifact"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+const absentInputDigest = "absent"
+
+// taskResultKey is everything which determines the outcome of a task with
+// cache_result. Its hash is the key its results are saved under.
+type taskResultKey struct {
+	Config        atc.TaskConfig    `json:"config"`
+	Privileged    bool              `json:"privileged"`
+	InputMapping  map[string]string `json:"input_mapping"`
+	OutputMapping map[string]string `json:"output_mapping"`
+	Image         string            `json:"image"`
+	Inputs        map[string]string `json:"inputs"`
+}
+
+// errResultNotCacheable is returned when the task's result can't be keyed
+// without fetching its image or streaming its inputs.
+type errResultNotCacheable struct {
+	reason string
+}
+
+func (err errResultNotCacheable) Error() string {
+	return "result not cacheable: " + err.reason
+}
+
+// resultKey computes the key of the task's result before a worker is chosen.
+// The image and inputs are identified without fetching or streaming them:
+// resource versions by their resource cache and other artifacts by their
+// volume. The image resource must therefore pin its version.
+func (action *TaskStep) resultKey(repository *artifact.Repository, config atc.TaskConfig) (string, error) {
+	key := taskResultKey{
+		Config:        config,
+		Privileged:    bool(action.privileged),
+		InputMapping:  action.inputMapping,
+		OutputMapping: action.outputMapping,
+		Inputs:        map[string]string{},
+	}
+
+	if action.imageArtifactName != "" {
+		source, found := repository.SourceFor(artifact.Name(action.imageArtifactName))
+		if !found {
+			return "", MissingTaskImageSourceError{action.imageArtifactName}
+		}
+
+		identity, ok := artifactIdentity(source)
+		if !ok {
+			return "", errResultNotCacheable{"image artifact '" + action.imageArtifactName + "' is not a resource version or volume"}
+		}
+
+		key.Image = identity
+	} else if config.ImageResource != nil {
+		if config.ImageResource.Version == nil {
+			return "", errResultNotCacheable{"image_resource has no pinned version"}
+		}
+
+		version, err := json.Marshal(config.ImageResource.Version)
+		if err != nil {
+			return "", err
+		}
+
+		key.Image = string(version)
+	}
+
+	for _, input := range config.Inputs {
+		inputName := input.Name
+		if sourceName, ok := action.inputMapping[inputName]; ok {
+			inputName = sourceName
+		}
+
+		source, found := repository.SourceFor(artifact.Name(inputName))
+		if !found {
+			key.Inputs[input.Name] = absentInputDigest
+			continue
+		}
+
+		identity, ok := artifactIdentity(source)
+		if !ok {
+			return "", errResultNotCacheable{"input '" + input.Name + "' is not a resource version or volume"}
+		}
+
+		key.Inputs[input.Name] = identity
+	}
+
+	payload, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x", sha256.Sum256(payload)), nil
+}
+
+// reuseResult registers the outputs of an earlier run saved under the key in
+// place of running the task. It returns false if there is no such run or any
+// of its output volumes can no longer be found.
+func (action *TaskStep) reuseResult(logger lager.Logger, repository *artifact.Repository, key string) (bool, error) {
+	result, found, err := action.taskResultFactory.FindTaskResult(action.teamID, key)
+	if err != nil {
+		return false, err
+	}
+
+	if !found {
+		return false, nil
+	}
+
+	volumes := map[string]worker.Volume{}
+	for name, handle := range result.Outputs {
+		volume, found, err := action.workerClient.FindVolume(logger, action.teamID, handle)
+		if err != nil {
+			return false, err
+		}
+
+		if !found {
+			logger.Info("cached-output-volume-not-found", lager.Data{"output": name, "handle": handle})
+			return false, nil
+		}
+
+		volumes[name] = volume
+	}
+
+	for name, volume := range volumes {
+		repository.RegisterSource(artifact.Name(name), NewTaskArtifactSource(volume))
+	}
+
+	err = action.taskResultFactory.UseTaskResult(result.ID)
+	if err != nil {
+		return false, err
+	}
+
+	logger.Info("reused-result", lager.Data{"build-id": result.BuildID})
+
+	fmt.Fprintf(action.delegate.Stdout(), "reusing the result of build %s\n", taskResultBuild(result))
+
+	return true, nil
+}
+
+func taskResultBuild(result db.TaskResult) string {
+	if result.JobName == "" {
+		return "#" + result.BuildName
+	}
+
+	return fmt.Sprintf("%s/%s #%s", result.PipelineName, result.JobName, result.BuildName)
+}
+
+// artifactIdentity identifies an artifact without reading its contents.
+// Resource versions are identified by their resource cache and anything else
+// by its volume. Outputs reused from an earlier result keep their volume, so
+// tasks depending on them can be cached too.
+func artifactIdentity(source worker.ArtifactSource) (string, bool) {
+	switch source := source.(type) {
+	case *getArtifactSource:
+		return fmt.Sprintf("resource-cache:%d", source.resourceInstance.ResourceCache().ID()), true
+	case *taskArtifactSource:
+		return "volume:" + source.Handle(), true
+	default:
+		return "", false
+	}
+}
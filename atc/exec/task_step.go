@@ -18,6 +18,7 @@ import (
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec/artifact"
+	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/worker"
 )
 
@@ -58,7 +59,7 @@ func (err TaskImageSourceParametersError) Error() string {
 type TaskDelegate interface {
 	BuildStepDelegate
 
-	Initializing(lager.Logger, atc.TaskConfig)
+	Initializing(lager.Logger, atc.TaskConfig, []string)
 	Starting(lager.Logger, atc.TaskConfig)
 	Finished(lager.Logger, ExitStatus)
 }
@@ -71,6 +72,8 @@ type TaskStep struct {
 	tags          atc.Tags
 	inputMapping  map[string]string
 	outputMapping map[string]string
+	retainOutputs bool
+	cacheResult   bool
 
 	artifactsRoot     string
 	imageArtifactName string
@@ -78,7 +81,9 @@ type TaskStep struct {
 	delegate TaskDelegate
 
 	workerPool        worker.Pool
+	workerClient      worker.Client
 	teamID            int
+	teamName          string
 	buildID           int
 	jobID             int
 	stepName          string
@@ -87,11 +92,14 @@ type TaskStep struct {
 
 	resourceTypes creds.VersionedResourceTypes
 
-	defaultLimits atc.ContainerLimits
+	defaultLimits  atc.ContainerLimits
+	sourceDefaults atc.SourceDefaults
 
 	succeeded bool
 
-	strategy worker.ContainerPlacementStrategy
+	strategy          worker.ContainerPlacementStrategy
+	policyChecker     policy.Checker
+	taskResultFactory db.TaskResultFactory
 }
 
 func NewTaskStep(
@@ -100,11 +108,15 @@ func NewTaskStep(
 	tags atc.Tags,
 	inputMapping map[string]string,
 	outputMapping map[string]string,
+	retainOutputs bool,
+	cacheResult bool,
 	artifactsRoot string,
 	imageArtifactName string,
 	delegate TaskDelegate,
 	workerPool worker.Pool,
+	workerClient worker.Client,
 	teamID int,
+	teamName string,
 	buildID int,
 	jobID int,
 	stepName string,
@@ -112,7 +124,10 @@ func NewTaskStep(
 	containerMetadata db.ContainerMetadata,
 	resourceTypes creds.VersionedResourceTypes,
 	defaultLimits atc.ContainerLimits,
+	sourceDefaults atc.SourceDefaults,
 	strategy worker.ContainerPlacementStrategy,
+	policyChecker policy.Checker,
+	taskResultFactory db.TaskResultFactory,
 ) Step {
 	return &TaskStep{
 		privileged:        privileged,
@@ -120,11 +135,15 @@ func NewTaskStep(
 		tags:              tags,
 		inputMapping:      inputMapping,
 		outputMapping:     outputMapping,
+		retainOutputs:     retainOutputs,
+		cacheResult:       cacheResult,
 		artifactsRoot:     artifactsRoot,
 		imageArtifactName: imageArtifactName,
 		delegate:          delegate,
 		workerPool:        workerPool,
+		workerClient:      workerClient,
 		teamID:            teamID,
+		teamName:          teamName,
 		buildID:           buildID,
 		jobID:             jobID,
 		stepName:          stepName,
@@ -132,7 +151,10 @@ func NewTaskStep(
 		containerMetadata: containerMetadata,
 		resourceTypes:     resourceTypes,
 		defaultLimits:     defaultLimits,
+		sourceDefaults:    sourceDefaults,
 		strategy:          strategy,
+		policyChecker:     policyChecker,
+		taskResultFactory: taskResultFactory,
 	}
 }
 
@@ -151,6 +173,15 @@ func NewTaskStep(
 // are registered with the artifact.Repository. If no outputs are specified, the
 // task's entire working directory is registered as an ArtifactSource under the
 // name of the task.
+//
+// If the task retains its outputs, they are also saved as artifacts of the
+// build once the script exits, whether or not it succeeded.
+//
+// If the task caches its result, a key is computed from its config, image and
+// the identities of its inputs before a worker is chosen. If an earlier
+// successful run with the same key still has all of its output volumes, those
+// are registered instead and no container is created. Otherwise the outputs of
+// a successful run are retained and saved under the key.
 func (action *TaskStep) Run(ctx context.Context, state RunState) error {
 	logger := lagerctx.FromContext(ctx)
 	logger = logger.Session("task-step", lager.Data{
@@ -175,8 +206,51 @@ func (action *TaskStep) Run(ctx context.Context, state RunState) error {
 	if config.Limits.Memory == nil {
 		config.Limits.Memory = action.defaultLimits.Memory
 	}
+	if config.Limits.Disk == nil {
+		config.Limits.Disk = action.defaultLimits.Disk
+	}
+	if config.Limits.Pids == nil {
+		config.Limits.Pids = action.defaultLimits.Pids
+	}
+	if config.Limits.Network == "" {
+		config.Limits.Network = action.defaultLimits.Network
+	}
+
+	appliedDefaults, err := action.appliedDefaults(config)
+	if err != nil {
+		logger.Error("failed-to-describe-applied-defaults", err)
+	}
+
+	action.delegate.Initializing(logger, config, appliedDefaults)
+
+	err = checkPolicy(action.policyChecker, action.policyInput(config), action.delegate.Stderr())
+	if err != nil {
+		return err
+	}
+
+	var resultKey string
+	if action.cacheResult {
+		resultKey, err = action.resultKey(repository, config)
+		if notCacheable, ok := err.(errResultNotCacheable); ok {
+			logger.Info("result-not-cacheable", lager.Data{"reason": notCacheable.reason})
+			fmt.Fprintln(action.delegate.Stderr(), "[WARNING] not caching the task's result:", notCacheable.reason)
+		} else if err != nil {
+			return err
+		}
+
+		if resultKey != "" {
+			reused, err := action.reuseResult(logger, repository, resultKey)
+			if err != nil {
+				return err
+			}
 
-	action.delegate.Initializing(logger, config)
+			if reused {
+				action.delegate.Finished(logger, ExitStatus(0))
+				action.succeeded = true
+				return nil
+			}
+		}
+	}
 
 	containerSpec, err := action.containerSpec(logger, repository, config)
 	if err != nil {
@@ -194,6 +268,8 @@ func (action *TaskStep) Run(ctx context.Context, state RunState) error {
 		return err
 	}
 
+	action.delegate.SelectedWorker(logger, chosenWorker.Name(), "")
+
 	container, err := chosenWorker.FindOrCreateContainer(
 		ctx,
 		logger,
@@ -298,6 +374,27 @@ func (action *TaskStep) Run(ctx context.Context, state RunState) error {
 			return err
 		}
 
+		cacheResult := resultKey != "" && processStatus == 0
+
+		if action.retainOutputs || cacheResult {
+			retained, err := action.retainOutputVolumes(logger, config, container)
+			if err != nil {
+				return err
+			}
+
+			if cacheResult {
+				outputs := map[string]int{}
+				for name, retainedArtifact := range retained {
+					outputs[name] = retainedArtifact.ID()
+				}
+
+				err = action.taskResultFactory.SaveTaskResult(action.teamID, resultKey, action.buildID, outputs)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
 		action.delegate.Finished(logger, ExitStatus(processStatus))
 
 		err = container.SetProperty(taskExitStatusPropertyName, fmt.Sprintf("%d", processStatus))
@@ -311,6 +408,33 @@ func (action *TaskStep) Run(ctx context.Context, state RunState) error {
 	}
 }
 
+func (action *TaskStep) policyInput(config atc.TaskConfig) policy.Input {
+	data := StepPolicyData{
+		Step:       "task",
+		Name:       action.stepName,
+		Job:        action.containerMetadata.JobName,
+		Privileged: bool(action.privileged),
+		Tags:       action.tags,
+		Platform:   config.Platform,
+	}
+
+	switch {
+	case action.imageArtifactName != "":
+		data.Image = &StepPolicyImage{Artifact: action.imageArtifactName}
+	case config.ImageResource != nil:
+		data.Image = policyImage(config.ImageResource.Type, config.ImageResource.Source)
+	case config.RootfsURI != "":
+		data.Image = &StepPolicyImage{RootfsURI: config.RootfsURI}
+	}
+
+	return policy.Input{
+		Action:   policy.ActionRunTask,
+		Team:     action.teamName,
+		Pipeline: action.containerMetadata.PipelineName,
+		Data:     data,
+	}
+}
+
 func (action *TaskStep) Succeeded() bool {
 	return action.succeeded
 }
@@ -334,7 +458,7 @@ func (action *TaskStep) imageSpec(logger lager.Logger, repository *artifact.Repo
 	} else if config.ImageResource != nil {
 		imageSpec.ImageResource = &worker.ImageResource{
 			Type:    config.ImageResource.Type,
-			Source:  creds.NewSource(boshtemplate.StaticVariables{}, config.ImageResource.Source),
+			Source:  creds.NewResourceSource(boshtemplate.StaticVariables{}, config.ImageResource.Type, config.ImageResource.Source, action.sourceDefaults),
 			Params:  config.ImageResource.Params,
 			Version: config.ImageResource.Version,
 		}
@@ -387,6 +511,28 @@ func (action *TaskStep) containerInputs(logger lager.Logger, repository *artifac
 	return inputs, nil
 }
 
+// appliedDefaults describes the operator's defaults and registry rewrite rules
+// that change the source of the task's image resource or of its type.
+func (action *TaskStep) appliedDefaults(config atc.TaskConfig) ([]string, error) {
+	if config.ImageResource == nil {
+		return nil, nil
+	}
+
+	source := creds.NewResourceSource(boshtemplate.StaticVariables{}, config.ImageResource.Type, config.ImageResource.Source, action.sourceDefaults)
+
+	applied, err := source.AppliedDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	typeApplied, err := action.resourceTypes.AppliedDefaults(config.ImageResource.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(applied, typeApplied...), nil
+}
+
 func (action *TaskStep) containerSpec(logger lager.Logger, repository *artifact.Repository, config atc.TaskConfig) (worker.ContainerSpec, error) {
 	imageSpec, err := action.imageSpec(logger, repository, config)
 	if err != nil {
@@ -426,6 +572,7 @@ func (action *TaskStep) workerSpec(logger lager.Logger, resourceTypes creds.Vers
 		Tags:          action.tags,
 		TeamID:        action.teamID,
 		ResourceTypes: resourceTypes,
+		Capabilities:  config.Limits.RequiredCapabilities(),
 	}
 
 	imageSpec, err := action.imageSpec(logger, repository, config)
@@ -440,6 +587,42 @@ func (action *TaskStep) workerSpec(logger lager.Logger, resourceTypes creds.Vers
 	return workerSpec, nil
 }
 
+func (action *TaskStep) retainOutputVolumes(logger lager.Logger, config atc.TaskConfig, container worker.Container) (map[string]db.WorkerArtifact, error) {
+	volumeMounts := container.VolumeMounts()
+
+	retained := map[string]db.WorkerArtifact{}
+
+	for _, output := range config.Outputs {
+		outputName := output.Name
+		if destinationName, ok := action.outputMapping[output.Name]; ok {
+			outputName = destinationName
+		}
+
+		outputPath := artifactsPath(output, action.artifactsRoot)
+
+		for _, mount := range volumeMounts {
+			if filepath.Clean(mount.MountPath) != filepath.Clean(outputPath) {
+				continue
+			}
+
+			artifact, err := mount.Volume.InitializeRetainedArtifact(outputName, action.buildID)
+			if err != nil {
+				return nil, err
+			}
+
+			logger.Info("retained-output", lager.Data{
+				"output":      outputName,
+				"handle":      mount.Volume.Handle(),
+				"artifact-id": artifact.ID(),
+			})
+
+			retained[outputName] = artifact
+		}
+	}
+
+	return retained, nil
+}
+
 func (action *TaskStep) registerOutputs(logger lager.Logger, repository *artifact.Repository, config atc.TaskConfig, container worker.Container) error {
 	volumeMounts := container.VolumeMounts()
 
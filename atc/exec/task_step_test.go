@@ -17,9 +17,12 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/artifact"
 	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/atc/policy/policyfakes"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/workerfakes"
 	. "github.com/onsi/ginkgo"
@@ -35,9 +38,13 @@ var _ = Describe("TaskStep", func() {
 		cancel func()
 
 		fakePool     *workerfakes.FakePool
+		fakeClient   *workerfakes.FakeClient
 		fakeWorker   *workerfakes.FakeWorker
 		fakeStrategy *workerfakes.FakeContainerPlacementStrategy
 
+		fakePolicyChecker     *policyfakes.FakeChecker
+		fakeTaskResultFactory *dbfakes.FakeTaskResultFactory
+
 		stdoutBuf *gbytes.Buffer
 		stderrBuf *gbytes.Buffer
 
@@ -46,16 +53,19 @@ var _ = Describe("TaskStep", func() {
 
 		fakeDelegate *execfakes.FakeTaskDelegate
 
-		privileged    exec.Privileged
-		tags          []string
-		teamID        int
-		buildID       int
-		planID        atc.PlanID
-		jobID         int
-		configSource  *execfakes.FakeTaskConfigSource
-		resourceTypes creds.VersionedResourceTypes
-		inputMapping  map[string]string
-		outputMapping map[string]string
+		privileged     exec.Privileged
+		tags           []string
+		teamID         int
+		buildID        int
+		planID         atc.PlanID
+		jobID          int
+		configSource   *execfakes.FakeTaskConfigSource
+		resourceTypes  creds.VersionedResourceTypes
+		sourceDefaults atc.SourceDefaults
+		inputMapping   map[string]string
+		outputMapping  map[string]string
+		retainOutputs  bool
+		cacheResult    bool
 
 		repo  *artifact.Repository
 		state *execfakes.FakeRunState
@@ -71,8 +81,14 @@ var _ = Describe("TaskStep", func() {
 
 		fakeWorker = new(workerfakes.FakeWorker)
 		fakePool = new(workerfakes.FakePool)
+		fakeClient = new(workerfakes.FakeClient)
 		fakeStrategy = new(workerfakes.FakeContainerPlacementStrategy)
 
+		fakePolicyChecker = new(policyfakes.FakeChecker)
+		fakePolicyChecker.CheckReturns(policy.Result{Decision: policy.DecisionAllow}, nil)
+
+		fakeTaskResultFactory = new(dbfakes.FakeTaskResultFactory)
+
 		stdoutBuf = gbytes.NewBuffer()
 		stderrBuf = gbytes.NewBuffer()
 
@@ -104,8 +120,16 @@ var _ = Describe("TaskStep", func() {
 			},
 		})
 
+		sourceDefaults = atc.SourceDefaults{
+			RegistryRewriteRules: []atc.RegistryRewriteRule{
+				{From: "docker.io", To: "mirror.example.com"},
+			},
+		}
+
 		inputMapping = nil
 		outputMapping = nil
+		retainOutputs = false
+		cacheResult = false
 		imageArtifactName = ""
 
 		containerMetadata = db.ContainerMetadata{
@@ -123,11 +147,15 @@ var _ = Describe("TaskStep", func() {
 			tags,
 			inputMapping,
 			outputMapping,
+			retainOutputs,
+			cacheResult,
 			"some-artifact-root",
 			imageArtifactName,
 			fakeDelegate,
 			fakePool,
+			fakeClient,
 			teamID,
+			"some-team",
 			buildID,
 			jobID,
 			"some-task",
@@ -135,7 +163,10 @@ var _ = Describe("TaskStep", func() {
 			containerMetadata,
 			resourceTypes,
 			atc.ContainerLimits{},
+			sourceDefaults,
 			fakeStrategy,
+			fakePolicyChecker,
+			fakeTaskResultFactory,
 		)
 
 		stepErr = taskStep.Run(ctx, state)
@@ -180,6 +211,13 @@ var _ = Describe("TaskStep", func() {
 				fakeWorker.FindOrCreateContainerReturns(fakeContainer, nil)
 			})
 
+			It("reports the selected worker", func() {
+				Expect(fakeDelegate.SelectedWorkerCallCount()).To(Equal(1))
+				_, workerName, cache := fakeDelegate.SelectedWorkerArgsForCall(0)
+				Expect(workerName).To(Equal("some-worker"))
+				Expect(cache).To(BeEmpty())
+			})
+
 			It("finds or chooses a worker", func() {
 				Expect(fakePool.FindOrChooseWorkerForContainerCallCount()).To(Equal(1))
 				_, owner, containerSpec, workerSpec, strategy := fakePool.FindOrChooseWorkerForContainerArgsForCall(0)
@@ -194,7 +232,7 @@ var _ = Describe("TaskStep", func() {
 					ImageSpec: worker.ImageSpec{
 						ImageResource: &worker.ImageResource{
 							Type:    "docker",
-							Source:  creds.NewSource(template.StaticVariables{}, atc.Source{"some": "secret-source-param"}),
+							Source:  creds.NewResourceSource(template.StaticVariables{}, "docker", atc.Source{"some": "secret-source-param"}, sourceDefaults),
 							Params:  &atc.Params{"some": "params"},
 							Version: &atc.Version{"some": "version"},
 						},
@@ -220,6 +258,23 @@ var _ = Describe("TaskStep", func() {
 				Expect(strategy).To(Equal(fakeStrategy))
 			})
 
+			Context("when the task limits its pids and network", func() {
+				BeforeEach(func() {
+					pids := uint64(64)
+					fetchedConfig.Limits.Pids = &pids
+					fetchedConfig.Limits.Network = atc.ContainerNetworkNone
+					configSource.FetchConfigReturns(fetchedConfig, nil)
+				})
+
+				It("chooses a worker that can enforce the limits", func() {
+					_, _, _, workerSpec, _ := fakePool.FindOrChooseWorkerForContainerArgsForCall(0)
+					Expect(workerSpec.Capabilities).To(Equal([]string{
+						atc.WorkerCapabilityPidsLimit,
+						atc.WorkerCapabilityNetworkNone,
+					}))
+				})
+			})
+
 			Context("when the task's container is either found or created", func() {
 				var (
 					fakeContainer *workerfakes.FakeContainer
@@ -233,7 +288,7 @@ var _ = Describe("TaskStep", func() {
 
 				Describe("before creating a container", func() {
 					BeforeEach(func() {
-						fakeDelegate.InitializingStub = func(lager.Logger, atc.TaskConfig) {
+						fakeDelegate.InitializingStub = func(lager.Logger, atc.TaskConfig, []string) {
 							defer GinkgoRecover()
 							Expect(fakeWorker.FindOrCreateContainerCallCount()).To(BeZero())
 						}
@@ -265,7 +320,7 @@ var _ = Describe("TaskStep", func() {
 						ImageSpec: worker.ImageSpec{
 							ImageResource: &worker.ImageResource{
 								Type:    "docker",
-								Source:  creds.NewSource(template.StaticVariables{}, atc.Source{"some": "secret-source-param"}),
+								Source:  creds.NewResourceSource(template.StaticVariables{}, "docker", atc.Source{"some": "secret-source-param"}, sourceDefaults),
 								Params:  &atc.Params{"some": "params"},
 								Version: &atc.Version{"some": "version"},
 							},
@@ -789,6 +844,311 @@ var _ = Describe("TaskStep", func() {
 							}))
 						})
 
+						Describe("retaining outputs", func() {
+							var (
+								fakeOutputVolume      *workerfakes.FakeVolume
+								fakeOtherOutputVolume *workerfakes.FakeVolume
+							)
+
+							BeforeEach(func() {
+								outputMapping = map[string]string{"some-other-output": "some-mapped-output"}
+
+								fakeOutputVolume = new(workerfakes.FakeVolume)
+								fakeOutputVolume.InitializeRetainedArtifactReturns(new(dbfakes.FakeWorkerArtifact), nil)
+								fakeOtherOutputVolume = new(workerfakes.FakeVolume)
+								fakeOtherOutputVolume.InitializeRetainedArtifactReturns(new(dbfakes.FakeWorkerArtifact), nil)
+
+								fakeContainer.VolumeMountsReturns([]worker.VolumeMount{
+									{Volume: fakeOutputVolume, MountPath: "some-artifact-root/some-output-configured-path/"},
+									{Volume: fakeOtherOutputVolume, MountPath: "some-artifact-root/some-other-output/"},
+								})
+
+								fakeProcess.WaitReturns(1, nil)
+							})
+
+							It("does not retain them by default", func() {
+								Expect(fakeOutputVolume.InitializeRetainedArtifactCallCount()).To(BeZero())
+								Expect(fakeOtherOutputVolume.InitializeRetainedArtifactCallCount()).To(BeZero())
+							})
+
+							Context("when the task retains its outputs", func() {
+								BeforeEach(func() {
+									retainOutputs = true
+								})
+
+								It("saves them as artifacts of the build even though the task failed", func() {
+									Expect(stepErr).ToNot(HaveOccurred())
+
+									Expect(fakeOutputVolume.InitializeRetainedArtifactCallCount()).To(Equal(1))
+									name, artifactBuildID := fakeOutputVolume.InitializeRetainedArtifactArgsForCall(0)
+									Expect(name).To(Equal("some-output"))
+									Expect(artifactBuildID).To(Equal(buildID))
+
+									Expect(fakeOtherOutputVolume.InitializeRetainedArtifactCallCount()).To(Equal(1))
+									name, _ = fakeOtherOutputVolume.InitializeRetainedArtifactArgsForCall(0)
+									Expect(name).To(Equal("some-mapped-output"))
+								})
+
+								Context("when saving the artifact fails", func() {
+									disaster := errors.New("nope")
+
+									BeforeEach(func() {
+										fakeOutputVolume.InitializeRetainedArtifactReturns(nil, disaster)
+									})
+
+									It("returns the error", func() {
+										Expect(stepErr).To(Equal(disaster))
+									})
+								})
+							})
+						})
+
+						Describe("caching the result", func() {
+							var (
+								fakeOutputVolume      *workerfakes.FakeVolume
+								fakeOtherOutputVolume *workerfakes.FakeVolume
+							)
+
+							BeforeEach(func() {
+								outputMapping = map[string]string{"some-other-output": "some-mapped-output"}
+
+								fakeArtifact := new(dbfakes.FakeWorkerArtifact)
+								fakeArtifact.IDReturns(1)
+								fakeOutputVolume = new(workerfakes.FakeVolume)
+								fakeOutputVolume.InitializeRetainedArtifactReturns(fakeArtifact, nil)
+
+								fakeOtherArtifact := new(dbfakes.FakeWorkerArtifact)
+								fakeOtherArtifact.IDReturns(2)
+								fakeOtherOutputVolume = new(workerfakes.FakeVolume)
+								fakeOtherOutputVolume.InitializeRetainedArtifactReturns(fakeOtherArtifact, nil)
+
+								fakeContainer.VolumeMountsReturns([]worker.VolumeMount{
+									{Volume: fakeOutputVolume, MountPath: "some-artifact-root/some-output-configured-path/"},
+									{Volume: fakeOtherOutputVolume, MountPath: "some-artifact-root/some-other-output/"},
+								})
+
+								fakeProcess.WaitReturns(0, nil)
+							})
+
+							It("does not look for a cached result by default", func() {
+								Expect(fakeTaskResultFactory.FindTaskResultCallCount()).To(BeZero())
+								Expect(fakeTaskResultFactory.SaveTaskResultCallCount()).To(BeZero())
+							})
+
+							Context("when the task caches its result", func() {
+								BeforeEach(func() {
+									cacheResult = true
+								})
+
+								Context("when there is no cached result", func() {
+									It("runs the task", func() {
+										Expect(fakeContainer.RunCallCount()).To(Equal(1))
+									})
+
+									It("saves the retained outputs under the key it looked up", func() {
+										Expect(fakeTaskResultFactory.FindTaskResultCallCount()).To(Equal(1))
+										lookupTeamID, lookupKey := fakeTaskResultFactory.FindTaskResultArgsForCall(0)
+										Expect(lookupTeamID).To(Equal(teamID))
+										Expect(lookupKey).ToNot(BeEmpty())
+
+										Expect(fakeTaskResultFactory.SaveTaskResultCallCount()).To(Equal(1))
+										savedTeamID, savedKey, savedBuildID, outputs := fakeTaskResultFactory.SaveTaskResultArgsForCall(0)
+										Expect(savedTeamID).To(Equal(teamID))
+										Expect(savedKey).To(Equal(lookupKey))
+										Expect(savedBuildID).To(Equal(buildID))
+										Expect(outputs).To(Equal(map[string]int{
+											"some-output":        1,
+											"some-mapped-output": 2,
+										}))
+									})
+
+									Context("when the task fails", func() {
+										BeforeEach(func() {
+											fakeProcess.WaitReturns(1, nil)
+										})
+
+										It("does not save the result", func() {
+											Expect(fakeOutputVolume.InitializeRetainedArtifactCallCount()).To(BeZero())
+											Expect(fakeTaskResultFactory.SaveTaskResultCallCount()).To(BeZero())
+										})
+									})
+								})
+
+								Context("when there is a cached result", func() {
+									var fakeCachedVolume *workerfakes.FakeVolume
+
+									BeforeEach(func() {
+										fakeTaskResultFactory.FindTaskResultReturns(db.TaskResult{
+											ID:           7,
+											BuildID:      3,
+											BuildName:    "3",
+											JobName:      "some-job",
+											PipelineName: "some-pipeline",
+											Outputs: map[string]string{
+												"some-output": "some-cached-handle",
+											},
+										}, true, nil)
+
+										fakeCachedVolume = new(workerfakes.FakeVolume)
+										fakeCachedVolume.HandleReturns("some-cached-handle")
+									})
+
+									Context("when its volumes are still present", func() {
+										BeforeEach(func() {
+											fakeClient.FindVolumeReturns(fakeCachedVolume, true, nil)
+										})
+
+										It("does not choose a worker or create a container", func() {
+											Expect(stepErr).ToNot(HaveOccurred())
+											Expect(fakePool.FindOrChooseWorkerForContainerCallCount()).To(BeZero())
+											Expect(fakeWorker.FindOrCreateContainerCallCount()).To(BeZero())
+											Expect(fakeContainer.RunCallCount()).To(BeZero())
+										})
+
+										It("registers the cached outputs", func() {
+											_, teamIDArg, handle := fakeClient.FindVolumeArgsForCall(0)
+											Expect(teamIDArg).To(Equal(teamID))
+											Expect(handle).To(Equal("some-cached-handle"))
+
+											source, found := repo.SourceFor("some-output")
+											Expect(found).To(BeTrue())
+											Expect(source).To(Equal(exec.NewTaskArtifactSource(fakeCachedVolume)))
+										})
+
+										It("says which build it reused", func() {
+											Expect(stdoutBuf).To(gbytes.Say("reusing the result of build some-pipeline/some-job #3"))
+										})
+
+										It("succeeds", func() {
+											Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+											_, status := fakeDelegate.FinishedArgsForCall(0)
+											Expect(status).To(Equal(exec.ExitStatus(0)))
+											Expect(taskStep.Succeeded()).To(BeTrue())
+										})
+
+										It("marks the result as used", func() {
+											Expect(fakeTaskResultFactory.UseTaskResultCallCount()).To(Equal(1))
+											Expect(fakeTaskResultFactory.UseTaskResultArgsForCall(0)).To(Equal(7))
+										})
+
+										It("does not save the result again", func() {
+											Expect(fakeTaskResultFactory.SaveTaskResultCallCount()).To(BeZero())
+										})
+									})
+
+									Context("when one of its volumes is gone", func() {
+										BeforeEach(func() {
+											fakeClient.FindVolumeReturns(nil, false, nil)
+										})
+
+										It("runs the task", func() {
+											Expect(fakeContainer.RunCallCount()).To(Equal(1))
+											Expect(fakeTaskResultFactory.UseTaskResultCallCount()).To(BeZero())
+										})
+									})
+								})
+
+								Context("when the task has inputs", func() {
+									var fakeInputVolume *workerfakes.FakeVolume
+
+									BeforeEach(func() {
+										configSource.FetchConfigReturns(atc.TaskConfig{
+											Platform:  "some-platform",
+											RootfsURI: "some-image",
+											Run: atc.TaskRunConfig{
+												Path: "ls",
+											},
+											Inputs: []atc.TaskInputConfig{
+												{Name: "some-input"},
+											},
+											Outputs: []atc.TaskOutputConfig{
+												{Name: "some-output", Path: "some-output-configured-path"},
+												{Name: "some-other-output"},
+											},
+										}, nil)
+
+										fakeInputVolume = new(workerfakes.FakeVolume)
+										fakeInputVolume.HandleReturns("some-input-handle")
+									})
+
+									Context("when the input is a volume", func() {
+										BeforeEach(func() {
+											repo.RegisterSource("some-input", exec.NewTaskArtifactSource(fakeInputVolume))
+										})
+
+										It("keys the result on the volume without streaming it", func() {
+											Expect(fakeTaskResultFactory.FindTaskResultCallCount()).To(Equal(1))
+											_, key := fakeTaskResultFactory.FindTaskResultArgsForCall(0)
+
+											Expect(fakeInputVolume.StreamOutCallCount()).To(BeZero())
+
+											otherInputVolume := new(workerfakes.FakeVolume)
+											otherInputVolume.HandleReturns("some-other-input-handle")
+											repo.RegisterSource("some-input", exec.NewTaskArtifactSource(otherInputVolume))
+
+											Expect(taskStep.Run(ctx, state)).To(Succeed())
+
+											Expect(fakeTaskResultFactory.FindTaskResultCallCount()).To(Equal(2))
+											_, otherKey := fakeTaskResultFactory.FindTaskResultArgsForCall(1)
+											Expect(otherKey).ToNot(Equal(key))
+										})
+									})
+
+									Context("when the input can't be identified without streaming it", func() {
+										BeforeEach(func() {
+											repo.RegisterSource("some-input", new(workerfakes.FakeArtifactSource))
+										})
+
+										It("runs the task without caching its result", func() {
+											Expect(stepErr).ToNot(HaveOccurred())
+											Expect(fakeTaskResultFactory.FindTaskResultCallCount()).To(BeZero())
+											Expect(fakeContainer.RunCallCount()).To(Equal(1))
+											Expect(fakeTaskResultFactory.SaveTaskResultCallCount()).To(BeZero())
+										})
+
+										It("warns that the result is not cached", func() {
+											Expect(stderrBuf).To(gbytes.Say("not caching the task's result: input 'some-input' is not a resource version or volume"))
+										})
+									})
+								})
+
+								Context("when the image resource has no pinned version", func() {
+									BeforeEach(func() {
+										configSource.FetchConfigReturns(atc.TaskConfig{
+											Platform: "some-platform",
+											ImageResource: &atc.ImageResource{
+												Type:   "docker",
+												Source: atc.Source{"some": "source"},
+											},
+											Run: atc.TaskRunConfig{
+												Path: "ls",
+											},
+										}, nil)
+									})
+
+									It("runs the task without caching its result", func() {
+										Expect(stepErr).ToNot(HaveOccurred())
+										Expect(fakeTaskResultFactory.FindTaskResultCallCount()).To(BeZero())
+										Expect(fakeContainer.RunCallCount()).To(Equal(1))
+										Expect(stderrBuf).To(gbytes.Say("not caching the task's result: image_resource has no pinned version"))
+									})
+								})
+
+								Context("when looking up the cached result fails", func() {
+									disaster := errors.New("nope")
+
+									BeforeEach(func() {
+										fakeTaskResultFactory.FindTaskResultReturns(db.TaskResult{}, false, disaster)
+									})
+
+									It("returns the error", func() {
+										Expect(stepErr).To(Equal(disaster))
+										Expect(fakeContainer.RunCallCount()).To(BeZero())
+									})
+								})
+							})
+						})
+
 						Context("when the process exits 0", func() {
 							BeforeEach(func() {
 								fakeProcess.WaitReturns(0, nil)
@@ -1338,7 +1698,7 @@ var _ = Describe("TaskStep", func() {
 							_, _, containerSpec, workerSpec, _ := fakePool.FindOrChooseWorkerForContainerArgsForCall(0)
 							Expect(containerSpec.ImageSpec.ImageResource).To(Equal(&worker.ImageResource{
 								Type:    "docker",
-								Source:  creds.NewSource(template.StaticVariables{}, atc.Source{"some": "super-secret-source"}),
+								Source:  creds.NewResourceSource(template.StaticVariables{}, "docker", atc.Source{"some": "super-secret-source"}, sourceDefaults),
 								Params:  &atc.Params{"some": "params"},
 								Version: &atc.Version{"some": "version"},
 							}))
@@ -1656,6 +2016,88 @@ var _ = Describe("TaskStep", func() {
 			})
 		})
 
+		Describe("policy checks", func() {
+			BeforeEach(func() {
+				privileged = true
+				containerMetadata.PipelineName = "some-pipeline"
+				containerMetadata.JobName = "some-job"
+
+				fakePool.FindOrChooseWorkerForContainerReturns(fakeWorker, nil)
+				fakeWorker.FindOrCreateContainerReturns(nil, errors.New("not here"))
+			})
+
+			It("checks the task's image and privileges", func() {
+				Expect(fakePolicyChecker.CheckCallCount()).To(Equal(1))
+				Expect(fakePolicyChecker.CheckArgsForCall(0)).To(Equal(policy.Input{
+					Action:   policy.ActionRunTask,
+					Team:     "some-team",
+					Pipeline: "some-pipeline",
+					Data: exec.StepPolicyData{
+						Step:       "task",
+						Name:       "some-task",
+						Job:        "some-job",
+						Privileged: true,
+						Tags:       []string{"step", "tags"},
+						Platform:   "some-platform",
+						Image:      &exec.StepPolicyImage{Type: "docker"},
+					},
+				}))
+			})
+
+			Context("when the policy denies the task", func() {
+				BeforeEach(func() {
+					fakePolicyChecker.CheckReturns(policy.Result{
+						Decision: policy.DecisionDeny,
+						Reasons:  []string{"privileged tasks are not allowed"},
+					}, nil)
+				})
+
+				It("returns the denial", func() {
+					Expect(stepErr).To(Equal(policy.DeniedError{
+						Action:  policy.ActionRunTask,
+						Reasons: []string{"privileged tasks are not allowed"},
+					}))
+				})
+
+				It("does not create a container", func() {
+					Expect(fakePool.FindOrChooseWorkerForContainerCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the policy warns about the task", func() {
+				BeforeEach(func() {
+					fakePolicyChecker.CheckReturns(policy.Result{
+						Decision: policy.DecisionWarn,
+						Reasons:  []string{"privileged tasks are discouraged"},
+					}, nil)
+				})
+
+				It("writes the warning to stderr", func() {
+					Expect(stderrBuf).To(gbytes.Say(`\[WARNING\] policy check: privileged tasks are discouraged`))
+				})
+
+				It("still runs the task", func() {
+					Expect(fakePool.FindOrChooseWorkerForContainerCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when checking the policy fails", func() {
+				disaster := errors.New("agent unavailable")
+
+				BeforeEach(func() {
+					fakePolicyChecker.CheckReturns(policy.Result{}, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(stepErr).To(Equal(disaster))
+				})
+
+				It("does not create a container", func() {
+					Expect(fakePool.FindOrChooseWorkerForContainerCallCount()).To(BeZero())
+				})
+			})
+		})
+
 		Context("when getting the config fails", func() {
 			disaster := errors.New("nope")
 
@@ -2,6 +2,7 @@ package gc
 
 import (
 	"context"
+	"time"
 
 	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/concourse/atc/db"
@@ -9,11 +10,16 @@ import (
 
 type artifactCollector struct {
 	artifactLifecycle db.WorkerArtifactLifecycle
+	retainedRetention time.Duration
 }
 
-func NewArtifactCollector(artifactLifecycle db.WorkerArtifactLifecycle) *artifactCollector {
+// NewArtifactCollector removes expired artifacts. Task outputs retained with
+// retain_outputs are kept for the retained retention period instead, or
+// forever if it is zero.
+func NewArtifactCollector(artifactLifecycle db.WorkerArtifactLifecycle, retainedRetention time.Duration) *artifactCollector {
 	return &artifactCollector{
 		artifactLifecycle: artifactLifecycle,
+		retainedRetention: retainedRetention,
 	}
 }
 
@@ -23,5 +29,5 @@ func (a *artifactCollector) Run(ctx context.Context) error {
 	logger.Debug("start")
 	defer logger.Debug("done")
 
-	return a.artifactLifecycle.RemoveExpiredArtifacts()
+	return a.artifactLifecycle.RemoveExpiredArtifacts(a.retainedRetention)
 }
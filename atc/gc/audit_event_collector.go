@@ -0,0 +1,36 @@
+package gc
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type auditEventCollector struct {
+	auditEventFactory db.AuditEventFactory
+	retention         time.Duration
+}
+
+// NewAuditEventCollector removes audit events older than the retention
+// period. A retention of zero keeps events forever.
+func NewAuditEventCollector(auditEventFactory db.AuditEventFactory, retention time.Duration) *auditEventCollector {
+	return &auditEventCollector{
+		auditEventFactory: auditEventFactory,
+		retention:         retention,
+	}
+}
+
+func (a *auditEventCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("audit-event-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	if a.retention == 0 {
+		return nil
+	}
+
+	return a.auditEventFactory.DeleteAuditEventsBefore(time.Now().Add(-a.retention))
+}
@@ -0,0 +1,48 @@
+package gc_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/gc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditEventCollector", func() {
+	var (
+		collector             gc.Collector
+		fakeAuditEventFactory *dbfakes.FakeAuditEventFactory
+		retention             time.Duration
+	)
+
+	BeforeEach(func() {
+		fakeAuditEventFactory = new(dbfakes.FakeAuditEventFactory)
+		retention = 24 * time.Hour
+	})
+
+	JustBeforeEach(func() {
+		collector = gc.NewAuditEventCollector(fakeAuditEventFactory, retention)
+
+		err := collector.Run(context.TODO())
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("removes events older than the retention period", func() {
+		Expect(fakeAuditEventFactory.DeleteAuditEventsBeforeCallCount()).To(Equal(1))
+
+		cutoff := fakeAuditEventFactory.DeleteAuditEventsBeforeArgsForCall(0)
+		Expect(cutoff).To(BeTemporally("~", time.Now().Add(-24*time.Hour), time.Minute))
+	})
+
+	Context("when the retention period is zero", func() {
+		BeforeEach(func() {
+			retention = 0
+		})
+
+		It("keeps every event", func() {
+			Expect(fakeAuditEventFactory.DeleteAuditEventsBeforeCallCount()).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,118 @@
+package gc
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type buildRecordCollector struct {
+	pipelineFactory          db.PipelineFactory
+	batchSize                int
+	drainerConfigured        bool
+	buildRetentionCalculator BuildRetentionCalculator
+}
+
+// NewBuildRecordCollector deletes whole builds of each job that fall outside
+// its build retention, unlike the build log collector which only deletes
+// their events.
+func NewBuildRecordCollector(
+	pipelineFactory db.PipelineFactory,
+	batchSize int,
+	buildRetentionCalculator BuildRetentionCalculator,
+	drainerConfigured bool,
+) Collector {
+	return &buildRecordCollector{
+		pipelineFactory:          pipelineFactory,
+		batchSize:                batchSize,
+		drainerConfigured:        drainerConfigured,
+		buildRetentionCalculator: buildRetentionCalculator,
+	}
+}
+
+type passedJob struct {
+	teamID       int
+	pipelineName string
+	jobName      string
+}
+
+func (brc *buildRecordCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("build-record-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	pipelines, err := brc.pipelineFactory.AllPipelines()
+	if err != nil {
+		logger.Error("failed-to-get-pipelines", err)
+		return err
+	}
+
+	pipelineJobs := make([]db.Jobs, len(pipelines))
+	passedJobs := map[passedJob]bool{}
+
+	// passed constraints are collected from every pipeline, paused or not, so
+	// that builds they depend on are kept
+	for i, pipeline := range pipelines {
+		jobs, err := pipeline.Jobs()
+		if err != nil {
+			logger.Error("failed-to-get-jobs", err)
+			return err
+		}
+
+		pipelineJobs[i] = jobs
+
+		for _, job := range jobs {
+			for _, input := range job.Config().Inputs() {
+				for _, passed := range input.Passed {
+					ref, ok := atc.ParsePassedJobReference(passed)
+					if !ok {
+						ref = atc.PassedJobReference{
+							PipelineName: pipeline.Name(),
+							JobName:      passed,
+						}
+					}
+
+					passedJobs[passedJob{pipeline.TeamID(), ref.PipelineName, ref.JobName}] = true
+				}
+			}
+		}
+	}
+
+	for i, pipeline := range pipelines {
+		if pipeline.Paused() {
+			continue
+		}
+
+		for _, job := range pipelineJobs[i] {
+			retention := brc.buildRetentionCalculator.BuildsToRetain(job)
+			if retention.Builds == 0 && retention.Days == 0 {
+				continue
+			}
+
+			keepPassedVersions := passedJobs[passedJob{pipeline.TeamID(), pipeline.Name(), job.Name()}]
+
+			deleted, err := job.DeleteExpiredBuilds(retention, keepPassedVersions, brc.drainerConfigured, brc.batchSize)
+			if err != nil {
+				logger.Error("failed-to-delete-expired-builds", err, lager.Data{
+					"pipeline": pipeline.Name(),
+					"job":      job.Name(),
+				})
+				return err
+			}
+
+			if deleted > 0 {
+				logger.Info("deleted-builds", lager.Data{
+					"pipeline": pipeline.Name(),
+					"job":      job.Name(),
+					"count":    deleted,
+				})
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/concourse/concourse/atc/gc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildRecordCollector", func() {
+	var (
+		collector           Collector
+		fakePipelineFactory *dbfakes.FakePipelineFactory
+		retentionCalc       BuildRetentionCalculator
+		drainerConfigured   bool
+
+		fakePipeline *dbfakes.FakePipeline
+		fakeJob      *dbfakes.FakeJob
+		otherJob     *dbfakes.FakeJob
+
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakePipelineFactory = new(dbfakes.FakePipelineFactory)
+		retentionCalc = NewBuildRetentionCalculator(0, 0, 0, 0)
+		drainerConfigured = false
+
+		fakeJob = new(dbfakes.FakeJob)
+		fakeJob.NameReturns("some-job")
+		fakeJob.ConfigReturns(atc.JobConfig{
+			Name:           "some-job",
+			BuildRetention: &atc.BuildRetention{Builds: 3, Days: 2},
+		})
+
+		otherJob = new(dbfakes.FakeJob)
+		otherJob.NameReturns("other-job")
+		otherJob.ConfigReturns(atc.JobConfig{Name: "other-job"})
+
+		fakePipeline = new(dbfakes.FakePipeline)
+		fakePipeline.NameReturns("some-pipeline")
+		fakePipeline.TeamIDReturns(1)
+		fakePipeline.JobsReturns(db.Jobs{fakeJob, otherJob}, nil)
+
+		fakePipelineFactory.AllPipelinesReturns([]db.Pipeline{fakePipeline}, nil)
+	})
+
+	JustBeforeEach(func() {
+		collector = NewBuildRecordCollector(
+			fakePipelineFactory,
+			5,
+			retentionCalc,
+			drainerConfigured,
+		)
+
+		runErr = collector.Run(context.TODO())
+	})
+
+	It("deletes expired builds of jobs with a build retention", func() {
+		Expect(runErr).ToNot(HaveOccurred())
+		Expect(fakeJob.DeleteExpiredBuildsCallCount()).To(Equal(1))
+
+		retention, keepPassedVersions, onlyDrained, limit := fakeJob.DeleteExpiredBuildsArgsForCall(0)
+		Expect(retention).To(Equal(atc.BuildRetention{Builds: 3, Days: 2}))
+		Expect(keepPassedVersions).To(BeFalse())
+		Expect(onlyDrained).To(BeFalse())
+		Expect(limit).To(Equal(5))
+	})
+
+	It("leaves jobs without a build retention alone", func() {
+		Expect(otherJob.DeleteExpiredBuildsCallCount()).To(BeZero())
+	})
+
+	Context("when a drainer is configured", func() {
+		BeforeEach(func() {
+			drainerConfigured = true
+		})
+
+		It("only deletes drained builds", func() {
+			_, _, onlyDrained, _ := fakeJob.DeleteExpiredBuildsArgsForCall(0)
+			Expect(onlyDrained).To(BeTrue())
+		})
+	})
+
+	Context("when a job in the same pipeline has the job in a passed constraint", func() {
+		BeforeEach(func() {
+			otherJob.ConfigReturns(atc.JobConfig{
+				Name: "other-job",
+				Plan: atc.PlanSequence{
+					{Get: "some-resource", Passed: []string{"some-job"}},
+				},
+			})
+		})
+
+		It("keeps the versions that passed the job", func() {
+			_, keepPassedVersions, _, _ := fakeJob.DeleteExpiredBuildsArgsForCall(0)
+			Expect(keepPassedVersions).To(BeTrue())
+		})
+	})
+
+	Context("when a job in another pipeline of the team has the job in a passed constraint", func() {
+		var otherPipeline *dbfakes.FakePipeline
+
+		BeforeEach(func() {
+			downstreamJob := new(dbfakes.FakeJob)
+			downstreamJob.ConfigReturns(atc.JobConfig{
+				Name: "downstream-job",
+				Plan: atc.PlanSequence{
+					{Get: "some-resource", Passed: []string{"some-pipeline/some-job"}},
+				},
+			})
+
+			otherPipeline = new(dbfakes.FakePipeline)
+			otherPipeline.NameReturns("other-pipeline")
+			otherPipeline.TeamIDReturns(1)
+			otherPipeline.PausedReturns(true)
+			otherPipeline.JobsReturns(db.Jobs{downstreamJob}, nil)
+
+			fakePipelineFactory.AllPipelinesReturns([]db.Pipeline{fakePipeline, otherPipeline}, nil)
+		})
+
+		It("keeps the versions that passed the job, even if the pipeline is paused", func() {
+			_, keepPassedVersions, _, _ := fakeJob.DeleteExpiredBuildsArgsForCall(0)
+			Expect(keepPassedVersions).To(BeTrue())
+		})
+
+		Context("when the pipeline belongs to another team", func() {
+			BeforeEach(func() {
+				otherPipeline.TeamIDReturns(2)
+			})
+
+			It("does not keep the versions", func() {
+				_, keepPassedVersions, _, _ := fakeJob.DeleteExpiredBuildsArgsForCall(0)
+				Expect(keepPassedVersions).To(BeFalse())
+			})
+		})
+	})
+
+	Context("when the pipeline is paused", func() {
+		BeforeEach(func() {
+			fakePipeline.PausedReturns(true)
+		})
+
+		It("does not delete its builds", func() {
+			Expect(fakeJob.DeleteExpiredBuildsCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the cluster has a maximum build retention", func() {
+		BeforeEach(func() {
+			retentionCalc = NewBuildRetentionCalculator(0, 10, 0, 0)
+		})
+
+		It("applies it to jobs without a build retention", func() {
+			Expect(otherJob.DeleteExpiredBuildsCallCount()).To(Equal(1))
+
+			retention, _, _, _ := otherJob.DeleteExpiredBuildsArgsForCall(0)
+			Expect(retention).To(Equal(atc.BuildRetention{Builds: 10}))
+		})
+	})
+
+	Context("when getting the jobs fails", func() {
+		BeforeEach(func() {
+			fakePipeline.JobsReturns(nil, errors.New("nope"))
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(MatchError("nope"))
+		})
+	})
+
+	Context("when deleting builds fails", func() {
+		BeforeEach(func() {
+			fakeJob.DeleteExpiredBuildsReturns(0, errors.New("nope"))
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(MatchError("nope"))
+		})
+	})
+})
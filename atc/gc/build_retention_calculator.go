@@ -0,0 +1,66 @@
+package gc
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type BuildRetentionCalculator interface {
+	BuildsToRetain(db.Job) atc.BuildRetention
+}
+
+type buildRetentionCalculator struct {
+	defaultBuildsToRetain     uint64
+	maxBuildsToRetain         uint64
+	defaultDaysToRetainBuilds uint64
+	maxDaysToRetainBuilds     uint64
+}
+
+func NewBuildRetentionCalculator(
+	defaultBuildsToRetain uint64,
+	maxBuildsToRetain uint64,
+	defaultDaysToRetainBuilds uint64,
+	maxDaysToRetainBuilds uint64,
+) BuildRetentionCalculator {
+	return &buildRetentionCalculator{
+		defaultBuildsToRetain:     defaultBuildsToRetain,
+		maxBuildsToRetain:         maxBuildsToRetain,
+		defaultDaysToRetainBuilds: defaultDaysToRetainBuilds,
+		maxDaysToRetainBuilds:     maxDaysToRetainBuilds,
+	}
+}
+
+// BuildsToRetain returns the job's build retention, falling back to the
+// defaults for values the job doesn't set and capping them at the maximums.
+// A maximum also applies to jobs which would otherwise keep every build.
+func (brc *buildRetentionCalculator) BuildsToRetain(job db.Job) atc.BuildRetention {
+	var retention atc.BuildRetention
+	if job.Config().BuildRetention != nil {
+		retention = *job.Config().BuildRetention
+	}
+
+	if retention.Builds == 0 {
+		retention.Builds = int(brc.defaultBuildsToRetain)
+	}
+
+	if retention.Days == 0 {
+		retention.Days = int(brc.defaultDaysToRetainBuilds)
+	}
+
+	return atc.BuildRetention{
+		Builds: capRetention(retention.Builds, brc.maxBuildsToRetain),
+		Days:   capRetention(retention.Days, brc.maxDaysToRetainBuilds),
+	}
+}
+
+func capRetention(value int, max uint64) int {
+	if max == 0 {
+		return value
+	}
+
+	if value > 0 && value < int(max) {
+		return value
+	}
+
+	return int(max)
+}
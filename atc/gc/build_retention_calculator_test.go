@@ -0,0 +1,55 @@
+package gc_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/concourse/concourse/atc/gc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildRetentionCalculator", func() {
+	It("nothing set gives all", func() {
+		retention := NewBuildRetentionCalculator(0, 0, 0, 0).BuildsToRetain(makeRetainedJob(nil))
+		Expect(retention).To(Equal(atc.BuildRetention{}))
+	})
+	It("nothing set but job gives job", func() {
+		retention := NewBuildRetentionCalculator(0, 0, 0, 0).BuildsToRetain(makeRetainedJob(&atc.BuildRetention{Builds: 3}))
+		Expect(retention).To(Equal(atc.BuildRetention{Builds: 3}))
+	})
+	It("default set gives default", func() {
+		retention := NewBuildRetentionCalculator(5, 0, 7, 0).BuildsToRetain(makeRetainedJob(nil))
+		Expect(retention).To(Equal(atc.BuildRetention{Builds: 5, Days: 7}))
+	})
+	It("default and job set gives job", func() {
+		retention := NewBuildRetentionCalculator(5, 0, 7, 0).BuildsToRetain(makeRetainedJob(&atc.BuildRetention{Builds: 6}))
+		Expect(retention).To(Equal(atc.BuildRetention{Builds: 6, Days: 7}))
+	})
+	It("job set and max set gives max if lower", func() {
+		retention := NewBuildRetentionCalculator(0, 4, 0, 2).BuildsToRetain(makeRetainedJob(&atc.BuildRetention{Builds: 6, Days: 1}))
+		Expect(retention).To(Equal(atc.BuildRetention{Builds: 4, Days: 1}))
+	})
+	It("max only set gives max", func() {
+		retention := NewBuildRetentionCalculator(0, 4, 0, 2).BuildsToRetain(makeRetainedJob(nil))
+		Expect(retention).To(Equal(atc.BuildRetention{Builds: 4, Days: 2}))
+	})
+	It("ignores build log retention", func() {
+		job := new(dbfakes.FakeJob)
+		job.ConfigReturns(atc.JobConfig{
+			BuildLogRetention: &atc.BuildLogRetention{Builds: 3, Days: 3},
+		})
+
+		retention := NewBuildRetentionCalculator(0, 0, 0, 0).BuildsToRetain(job)
+		Expect(retention).To(Equal(atc.BuildRetention{}))
+	})
+})
+
+func makeRetainedJob(retention *atc.BuildRetention) db.Job {
+	rv := new(dbfakes.FakeJob)
+	rv.ConfigReturns(atc.JobConfig{
+		BuildRetention: retention,
+	})
+	return rv
+}
@@ -20,17 +20,20 @@ type containerCollector struct {
 	containerRepository         db.ContainerRepository
 	jobRunner                   WorkerJobRunner
 	missingContainerGracePeriod time.Duration
+	registry                    *metric.Registry
 }
 
 func NewContainerCollector(
 	containerRepository db.ContainerRepository,
 	jobRunner WorkerJobRunner,
 	missingContainerGracePeriod time.Duration,
+	registry *metric.Registry,
 ) Collector {
 	return &containerCollector{
 		containerRepository:         containerRepository,
 		jobRunner:                   jobRunner,
 		missingContainerGracePeriod: missingContainerGracePeriod,
+		registry:                    registry,
 	}
 }
 
@@ -91,7 +94,7 @@ func (c *containerCollector) cleanupFailedContainers(logger lager.Logger) error
 
 	metric.FailedContainersToBeGarbageCollected{
 		Containers: failedContainersLen,
-	}.Emit(logger)
+	}.Emit(logger, c.registry)
 
 	return nil
 }
@@ -114,15 +117,15 @@ func (c *containerCollector) cleanupOrphanedContainers(logger lager.Logger) erro
 
 	metric.CreatingContainersToBeGarbageCollected{
 		Containers: len(creatingContainers),
-	}.Emit(logger)
+	}.Emit(logger, c.registry)
 
 	metric.CreatedContainersToBeGarbageCollected{
 		Containers: len(createdContainers),
-	}.Emit(logger)
+	}.Emit(logger, c.registry)
 
 	metric.DestroyingContainersToBeGarbageCollected{
 		Containers: len(destroyingContainers),
-	}.Emit(logger)
+	}.Emit(logger, c.registry)
 
 	var workerCreatedContainers = make(map[string][]db.CreatedContainer)
 
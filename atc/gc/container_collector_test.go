@@ -59,12 +59,14 @@ var _ = Describe("ContainerCollector", func() {
 			fakeContainerRepository,
 			fakeJobRunner,
 			missingContainerGracePeriod,
+			nil,
 		)
 
 		fakeCollector = gc.NewContainerCollector(
 			fakeContainerRepository,
 			fakeJobRunner,
 			missingContainerGracePeriod,
+			nil,
 		)
 	})
 
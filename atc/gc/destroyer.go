@@ -25,6 +25,7 @@ type destroyer struct {
 	logger              lager.Logger
 	containerRepository db.ContainerRepository
 	volumeRepository    db.VolumeRepository
+	registry            *metric.Registry
 }
 
 // NewDestroyer provides a constructor for a Destroyer interface implementation
@@ -32,11 +33,13 @@ func NewDestroyer(
 	logger lager.Logger,
 	containerRepository db.ContainerRepository,
 	volumeRepository db.VolumeRepository,
+	registry *metric.Registry,
 ) Destroyer {
 	return &destroyer{
 		logger:              logger,
 		containerRepository: containerRepository,
 		volumeRepository:    volumeRepository,
+		registry:            registry,
 	}
 }
 
@@ -101,7 +104,7 @@ func (d *destroyer) FindDestroyingVolumesForGc(workerName string) ([]string, err
 
 	metric.DestroyingVolumesToBeGarbageCollected{
 		Volumes: len(destroyingVolumesHandles),
-	}.Emit(d.logger)
+	}.Emit(d.logger, d.registry)
 
 	return destroyingVolumesHandles, nil
 }
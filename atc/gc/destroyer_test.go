@@ -28,6 +28,7 @@ var _ = Describe("Destroyer", func() {
 			logger,
 			fakeContainerRepository,
 			fakeVolumeRepository,
+			nil,
 		)
 	})
 
@@ -0,0 +1,36 @@
+package gc
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type hijackSessionCollector struct {
+	hijackSessionFactory db.HijackSessionFactory
+	retention            time.Duration
+}
+
+// NewHijackSessionCollector removes recorded hijack sessions started before
+// the retention period. A retention of zero keeps sessions forever.
+func NewHijackSessionCollector(hijackSessionFactory db.HijackSessionFactory, retention time.Duration) *hijackSessionCollector {
+	return &hijackSessionCollector{
+		hijackSessionFactory: hijackSessionFactory,
+		retention:            retention,
+	}
+}
+
+func (h *hijackSessionCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("hijack-session-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	if h.retention == 0 {
+		return nil
+	}
+
+	return h.hijackSessionFactory.DeleteHijackSessionsBefore(time.Now().Add(-h.retention))
+}
@@ -0,0 +1,62 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/gc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HijackSessionCollector", func() {
+	var (
+		collector                gc.Collector
+		fakeHijackSessionFactory *dbfakes.FakeHijackSessionFactory
+		retention                time.Duration
+		err                      error
+	)
+
+	BeforeEach(func() {
+		fakeHijackSessionFactory = new(dbfakes.FakeHijackSessionFactory)
+		retention = time.Hour
+	})
+
+	JustBeforeEach(func() {
+		collector = gc.NewHijackSessionCollector(fakeHijackSessionFactory, retention)
+
+		err = collector.Run(context.TODO())
+	})
+
+	It("removes sessions older than the retention period", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeHijackSessionFactory.DeleteHijackSessionsBeforeCallCount()).To(Equal(1))
+
+		cutoff := fakeHijackSessionFactory.DeleteHijackSessionsBeforeArgsForCall(0)
+		Expect(cutoff).To(BeTemporally("~", time.Now().Add(-time.Hour), time.Minute))
+	})
+
+	Context("when the retention is zero", func() {
+		BeforeEach(func() {
+			retention = 0
+		})
+
+		It("keeps every session", func() {
+			Expect(fakeHijackSessionFactory.DeleteHijackSessionsBeforeCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when removing the sessions fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeHijackSessionFactory.DeleteHijackSessionsBeforeReturns(disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(Equal(disaster))
+		})
+	})
+})
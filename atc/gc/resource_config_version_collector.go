@@ -0,0 +1,46 @@
+package gc
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/metric"
+)
+
+type resourceConfigVersionCollector struct {
+	pruner   db.ResourceConfigVersionPruner
+	defaults atc.VersionRetention
+}
+
+// NewResourceConfigVersionCollector prunes resource config versions using
+// each resource's version retention, or the given defaults for resources
+// that don't configure one.
+func NewResourceConfigVersionCollector(pruner db.ResourceConfigVersionPruner, defaults atc.VersionRetention) *resourceConfigVersionCollector {
+	return &resourceConfigVersionCollector{
+		pruner:   pruner,
+		defaults: defaults,
+	}
+}
+
+func (rcvc *resourceConfigVersionCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("resource-config-version-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	pruned, err := rcvc.pruner.PruneVersions(rcvc.defaults)
+	metric.ResourceConfigVersionsPruned.IncDelta(pruned)
+	if err != nil {
+		logger.Error("failed-to-prune-versions", err)
+		return err
+	}
+
+	if pruned > 0 {
+		logger.Info("pruned-versions", lager.Data{"count": pruned})
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/gc"
+	"github.com/concourse/concourse/atc/metric"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ResourceConfigVersionCollector", func() {
+	var (
+		collector  gc.Collector
+		fakePruner *dbfakes.FakeResourceConfigVersionPruner
+		defaults   atc.VersionRetention
+		runErr     error
+	)
+
+	BeforeEach(func() {
+		fakePruner = new(dbfakes.FakeResourceConfigVersionPruner)
+		defaults = atc.VersionRetention{Latest: 100, Days: 30}
+
+		metric.ResourceConfigVersionsPruned.Delta()
+	})
+
+	JustBeforeEach(func() {
+		collector = gc.NewResourceConfigVersionCollector(fakePruner, defaults)
+		runErr = collector.Run(context.TODO())
+	})
+
+	Context("when versions are pruned", func() {
+		BeforeEach(func() {
+			fakePruner.PruneVersionsReturns(42, nil)
+		})
+
+		It("prunes using the default retention", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(fakePruner.PruneVersionsCallCount()).To(Equal(1))
+			Expect(fakePruner.PruneVersionsArgsForCall(0)).To(Equal(defaults))
+		})
+
+		It("counts the pruned versions", func() {
+			Expect(metric.ResourceConfigVersionsPruned.Delta()).To(Equal(42))
+		})
+	})
+
+	Context("when pruning fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakePruner.PruneVersionsReturns(3, disaster)
+		})
+
+		It("returns the error and still counts what was pruned", func() {
+			Expect(runErr).To(Equal(disaster))
+			Expect(metric.ResourceConfigVersionsPruned.Delta()).To(Equal(3))
+		})
+	})
+})
@@ -0,0 +1,32 @@
+package gc
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type statusEventCollector struct {
+	statusEventFactory db.StatusEventFactory
+	retention          time.Duration
+}
+
+// NewStatusEventCollector removes status events older than the retention
+// period. They are only kept so that clients can resume the stream.
+func NewStatusEventCollector(statusEventFactory db.StatusEventFactory, retention time.Duration) *statusEventCollector {
+	return &statusEventCollector{
+		statusEventFactory: statusEventFactory,
+		retention:          retention,
+	}
+}
+
+func (s *statusEventCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("status-event-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	return s.statusEventFactory.DeleteStatusEventsBefore(time.Now().Add(-s.retention))
+}
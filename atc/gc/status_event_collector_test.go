@@ -0,0 +1,50 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/gc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StatusEventCollector", func() {
+	var (
+		collector              gc.Collector
+		fakeStatusEventFactory *dbfakes.FakeStatusEventFactory
+		err                    error
+	)
+
+	BeforeEach(func() {
+		fakeStatusEventFactory = new(dbfakes.FakeStatusEventFactory)
+	})
+
+	JustBeforeEach(func() {
+		collector = gc.NewStatusEventCollector(fakeStatusEventFactory, time.Hour)
+
+		err = collector.Run(context.TODO())
+	})
+
+	It("removes events older than the retention period", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeStatusEventFactory.DeleteStatusEventsBeforeCallCount()).To(Equal(1))
+
+		cutoff := fakeStatusEventFactory.DeleteStatusEventsBeforeArgsForCall(0)
+		Expect(cutoff).To(BeTemporally("~", time.Now().Add(-time.Hour), time.Minute))
+	})
+
+	Context("when removing the events fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeStatusEventFactory.DeleteStatusEventsBeforeReturns(disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(Equal(disaster))
+		})
+	})
+})
@@ -14,15 +14,18 @@ import (
 type volumeCollector struct {
 	volumeRepository         db.VolumeRepository
 	missingVolumeGracePeriod time.Duration
+	registry                 *metric.Registry
 }
 
 func NewVolumeCollector(
 	volumeRepository db.VolumeRepository,
 	missingVolumeGracePeriod time.Duration,
+	registry *metric.Registry,
 ) Collector {
 	return &volumeCollector{
 		volumeRepository:         volumeRepository,
 		missingVolumeGracePeriod: missingVolumeGracePeriod,
+		registry:                 registry,
 	}
 }
 
@@ -70,7 +73,7 @@ func (vc *volumeCollector) cleanupFailedVolumes(logger lager.Logger) error {
 
 	metric.FailedVolumesToBeGarbageCollected{
 		Volumes: failedVolumesLen,
-	}.Emit(logger)
+	}.Emit(logger, vc.registry)
 
 	return nil
 }
@@ -90,7 +93,7 @@ func (vc *volumeCollector) markOrphanedVolumesAsDestroying(logger lager.Logger)
 
 	metric.CreatedVolumesToBeGarbageCollected{
 		Volumes: len(orphanedVolumesHandles),
-	}.Emit(logger)
+	}.Emit(logger, vc.registry)
 
 	for _, orphanedVolume := range orphanedVolumesHandles {
 		// queue
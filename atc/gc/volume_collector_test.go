@@ -38,6 +38,7 @@ var _ = Describe("VolumeCollector", func() {
 		volumeCollector = gc.NewVolumeCollector(
 			volumeRepository,
 			missingVolumeGracePeriod,
+			nil,
 		)
 	})
 
@@ -73,6 +74,7 @@ var _ = Describe("VolumeCollector", func() {
 				volumeCollector = gc.NewVolumeCollector(
 					fakeVolumeRepository,
 					missingVolumeGracePeriod,
+					nil,
 				)
 
 				err = volumeCollector.Run(context.TODO())
@@ -11,11 +11,13 @@ import (
 
 type workerCollector struct {
 	workerLifecycle db.WorkerLifecycle
+	registry        *metric.Registry
 }
 
-func NewWorkerCollector(workerLifecycle db.WorkerLifecycle) Collector {
+func NewWorkerCollector(workerLifecycle db.WorkerLifecycle, registry *metric.Registry) Collector {
 	return &workerCollector{
 		workerLifecycle: workerLifecycle,
+		registry:        registry,
 	}
 }
 
@@ -65,6 +67,16 @@ func (wc *workerCollector) Run(ctx context.Context) error {
 		logger.Info("marked-workers-as-landed", lager.Data{"count": len(affected), "workers": affected})
 	}
 
+	affected, err = wc.workerLifecycle.ReleaseExpiredQuarantinedWorkers()
+	if err != nil {
+		logger.Error("failed-to-release-expired-quarantined-workers", err)
+		return err
+	}
+
+	if len(affected) > 0 {
+		logger.Info("released-quarantined-workers", lager.Data{"count": len(affected), "workers": affected})
+	}
+
 	workerStateByName, err := wc.workerLifecycle.GetWorkerStateByName()
 
 	if err != nil {
@@ -72,7 +84,7 @@ func (wc *workerCollector) Run(ctx context.Context) error {
 	} else {
 		metric.WorkersState{
 			WorkerStateByName: workerStateByName,
-		}.Emit(logger)
+		}.Emit(logger, wc.registry)
 	}
 
 	return nil
@@ -21,12 +21,13 @@ var _ = Describe("WorkerCollector", func() {
 	BeforeEach(func() {
 		fakeWorkerLifecycle = new(dbfakes.FakeWorkerLifecycle)
 
-		workerCollector = gc.NewWorkerCollector(fakeWorkerLifecycle)
+		workerCollector = gc.NewWorkerCollector(fakeWorkerLifecycle, nil)
 
 		fakeWorkerLifecycle.DeleteUnresponsiveEphemeralWorkersReturns(nil, nil)
 		fakeWorkerLifecycle.StallUnresponsiveWorkersReturns(nil, nil)
 		fakeWorkerLifecycle.DeleteFinishedRetiringWorkersReturns(nil, nil)
 		fakeWorkerLifecycle.LandFinishedLandingWorkersReturns(nil, nil)
+		fakeWorkerLifecycle.ReleaseExpiredQuarantinedWorkersReturns(nil, nil)
 	})
 
 	Describe("Run", func() {
@@ -58,6 +59,13 @@ var _ = Describe("WorkerCollector", func() {
 			Expect(fakeWorkerLifecycle.LandFinishedLandingWorkersCallCount()).To(Equal(1))
 		})
 
+		It("tells the worker factory to release expired quarantined workers", func() {
+			err := workerCollector.Run(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeWorkerLifecycle.ReleaseExpiredQuarantinedWorkersCallCount()).To(Equal(1))
+		})
+
 		It("returns an error if stalling unresponsive workers fails", func() {
 			returnedErr := errors.New("some-error")
 			fakeWorkerLifecycle.StallUnresponsiveWorkersReturns(nil, returnedErr)
@@ -82,5 +90,13 @@ var _ = Describe("WorkerCollector", func() {
 			Expect(err).To(MatchError(returnedErr))
 		})
 
+		It("returns an error if releasing expired quarantined workers fails", func() {
+			returnedErr := errors.New("some-error")
+			fakeWorkerLifecycle.ReleaseExpiredQuarantinedWorkersReturns(nil, returnedErr)
+
+			err := workerCollector.Run(context.TODO())
+			Expect(err).To(MatchError(returnedErr))
+		})
+
 	})
 })
@@ -0,0 +1,18 @@
+package atc
+
+type HijackSession struct {
+	ID       int    `json:"id"`
+	TeamName string `json:"team_name"`
+
+	ContainerHandle string `json:"container_handle"`
+	WorkerName      string `json:"worker_name"`
+	Username        string `json:"username"`
+
+	Path        string   `json:"path"`
+	Args        []string `json:"args,omitempty"`
+	ProcessUser string   `json:"process_user,omitempty"`
+
+	StartedAt  int64 `json:"started_at"`
+	EndedAt    int64 `json:"ended_at,omitempty"`
+	ExitStatus *int  `json:"exit_status,omitempty"`
+}
@@ -1,5 +1,7 @@
 package atc
 
+import "strings"
+
 type JobConfig struct {
 	Name    string `yaml:"name" json:"name" mapstructure:"name"`
 	OldName string `yaml:"old_name,omitempty" json:"old_name,omitempty" mapstructure:"old_name"`
@@ -13,6 +15,13 @@ type JobConfig struct {
 	BuildLogsToRetain    int      `yaml:"build_logs_to_retain,omitempty" json:"build_logs_to_retain,omitempty" mapstructure:"build_logs_to_retain"`
 
 	BuildLogRetention *BuildLogRetention `yaml:"build_log_retention,omitempty" json:"build_log_retention,omitempty" mapstructure:"build_log_retention"`
+	BuildRetention    *BuildRetention    `yaml:"build_retention,omitempty" json:"build_retention,omitempty" mapstructure:"build_retention"`
+
+	Schedule *ScheduleConfig `yaml:"schedule,omitempty" json:"schedule,omitempty" mapstructure:"schedule"`
+
+	// holds a named lock of the team for the whole build, including hooks
+	Lock         string `yaml:"lock,omitempty" json:"lock,omitempty" mapstructure:"lock"`
+	LockCapacity int    `yaml:"lock_capacity,omitempty" json:"lock_capacity,omitempty" mapstructure:"lock_capacity"`
 
 	Plan PlanSequence `yaml:"plan,omitempty" json:"plan,omitempty" mapstructure:"plan"`
 
@@ -28,6 +37,26 @@ type BuildLogRetention struct {
 	Days   int `yaml:"days,omitempty" json:"days,omitempty" mapstructure:"days"`
 }
 
+// BuildRetention limits how many of a job's builds are kept at all, as
+// opposed to BuildLogRetention which only removes their logs. A build is
+// removed once it is outside both the latest Builds and the last Days.
+type BuildRetention struct {
+	Builds int `yaml:"builds,omitempty" json:"builds,omitempty" mapstructure:"builds"`
+	Days   int `yaml:"days,omitempty" json:"days,omitempty" mapstructure:"days"`
+}
+
+const (
+	ScheduleMissedSkip    = "skip"
+	ScheduleMissedCatchUp = "catch-up"
+)
+
+type ScheduleConfig struct {
+	Cron     string `yaml:"cron" json:"cron" mapstructure:"cron"`
+	Location string `yaml:"location,omitempty" json:"location,omitempty" mapstructure:"location"`
+	Missed   string `yaml:"missed,omitempty" json:"missed,omitempty" mapstructure:"missed"`
+	Jitter   string `yaml:"jitter,omitempty" json:"jitter,omitempty" mapstructure:"jitter"`
+}
+
 func (config JobConfig) Hooks() Hooks {
 	return Hooks{Abort: config.Abort, Error: config.Error, Failure: config.Failure, Ensure: config.Ensure, Success: config.Success}
 }
@@ -189,3 +218,68 @@ func (config JobConfig) Outputs() []JobOutput {
 
 	return outputs
 }
+
+// PassedJobReference is a job in another pipeline of the same team, referenced
+// by a passed constraint as 'pipeline/job'.
+type PassedJobReference struct {
+	PipelineName string
+	JobName      string
+}
+
+func (ref PassedJobReference) String() string {
+	return ref.PipelineName + "/" + ref.JobName
+}
+
+// ParsePassedJobReference returns the job referenced by a passed constraint
+// of the form 'pipeline/job'. It returns false for jobs in the same pipeline
+// and for malformed references.
+func ParsePassedJobReference(passed string) (PassedJobReference, bool) {
+	segs := strings.Split(passed, "/")
+	if len(segs) != 2 || segs[0] == "" || segs[1] == "" {
+		return PassedJobReference{}, false
+	}
+
+	return PassedJobReference{
+		PipelineName: segs[0],
+		JobName:      segs[1],
+	}, true
+}
+
+// CrossPipelinePassed returns the jobs in other pipelines that the job's
+// inputs must have passed through.
+func (config JobConfig) CrossPipelinePassed() []PassedJobReference {
+	var refs []PassedJobReference
+
+	seen := map[PassedJobReference]bool{}
+	for _, input := range config.Inputs() {
+		for _, passed := range input.Passed {
+			ref, ok := ParsePassedJobReference(passed)
+			if !ok || seen[ref] {
+				continue
+			}
+
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+
+	return refs
+}
+
+// FilteredResources returns the names of the resources the job's inputs
+// restrict with a version filter.
+func (config JobConfig) FilteredResources() []string {
+	var resources []string
+
+	seen := map[string]bool{}
+	for _, input := range config.Inputs() {
+		if input.Version == nil || input.Version.Filter == "" || seen[input.Resource] {
+			continue
+		}
+
+		seen[input.Resource] = true
+		resources = append(resources, input.Resource)
+	}
+
+	return resources
+}
@@ -1018,4 +1018,19 @@ var _ = Describe("JobConfig", func() {
 			})
 		})
 	})
+
+	Describe("FilteredResources", func() {
+		It("returns each resource whose inputs have a version filter once", func() {
+			jobConfig := atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{Get: "a", Version: &atc.VersionConfig{Latest: true, Filter: "ref =~ ^release/"}},
+					{Get: "b", Resource: "a", Version: &atc.VersionConfig{Every: true, Filter: "semver(tag) >=1"}},
+					{Get: "c", Version: &atc.VersionConfig{Every: true}},
+					{Get: "d"},
+				},
+			}
+
+			Expect(jobConfig.FilteredResources()).To(Equal([]string{"a"}))
+		})
+	})
 })
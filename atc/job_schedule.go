@@ -0,0 +1,79 @@
+package atc
+
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/concourse/concourse/atc/cron"
+)
+
+// JobSchedule is the parsed form of a job's schedule configuration.
+type JobSchedule struct {
+	Schedule cron.Schedule
+	Location *time.Location
+	CatchUp  bool
+	Jitter   time.Duration
+}
+
+func ParseJobSchedule(config ScheduleConfig) (JobSchedule, error) {
+	schedule, err := cron.Parse(config.Cron)
+	if err != nil {
+		return JobSchedule{}, err
+	}
+
+	location := time.UTC
+	if config.Location != "" {
+		location, err = time.LoadLocation(config.Location)
+		if err != nil {
+			return JobSchedule{}, fmt.Errorf("invalid location '%s': %s", config.Location, err)
+		}
+	}
+
+	var catchUp bool
+	switch config.Missed {
+	case "", ScheduleMissedSkip:
+	case ScheduleMissedCatchUp:
+		catchUp = true
+	default:
+		return JobSchedule{}, fmt.Errorf("invalid missed policy '%s' (must be '%s' or '%s')", config.Missed, ScheduleMissedSkip, ScheduleMissedCatchUp)
+	}
+
+	var jitter time.Duration
+	if config.Jitter != "" {
+		jitter, err = time.ParseDuration(config.Jitter)
+		if err != nil {
+			return JobSchedule{}, fmt.Errorf("invalid jitter '%s': %s", config.Jitter, err)
+		}
+
+		if jitter < 0 {
+			return JobSchedule{}, fmt.Errorf("invalid jitter '%s': must not be negative", config.Jitter)
+		}
+	}
+
+	return JobSchedule{
+		Schedule: schedule,
+		Location: location,
+		CatchUp:  catchUp,
+		Jitter:   jitter,
+	}, nil
+}
+
+// Next returns the first slot after t in the schedule's location.
+func (s JobSchedule) Next(t time.Time) time.Time {
+	return s.Schedule.Next(t.In(s.Location))
+}
+
+// FireTime returns when the given slot actually fires for a job, i.e. the
+// slot delayed by the job's jitter. The jitter is derived from the job and
+// the slot so that every evaluation agrees on it without storing it.
+func (s JobSchedule) FireTime(jobID int, slot time.Time) time.Time {
+	if s.Jitter == 0 {
+		return slot
+	}
+
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%d/%d", jobID, slot.Unix())
+
+	return slot.Add(time.Duration(h.Sum64() % uint64(s.Jitter)))
+}
@@ -0,0 +1,101 @@
+package atc_test
+
+import (
+	"time"
+
+	. "github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JobSchedule", func() {
+	var config ScheduleConfig
+
+	BeforeEach(func() {
+		config = ScheduleConfig{
+			Cron: "0 2 * * *",
+		}
+	})
+
+	Describe("ParseJobSchedule", func() {
+		It("defaults to UTC and skipping missed runs", func() {
+			schedule, err := ParseJobSchedule(config)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schedule.Location).To(Equal(time.UTC))
+			Expect(schedule.CatchUp).To(BeFalse())
+			Expect(schedule.Jitter).To(BeZero())
+		})
+
+		It("parses the location, missed policy and jitter", func() {
+			config.Location = "Europe/Berlin"
+			config.Missed = "catch-up"
+			config.Jitter = "5m"
+
+			schedule, err := ParseJobSchedule(config)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schedule.Location.String()).To(Equal("Europe/Berlin"))
+			Expect(schedule.CatchUp).To(BeTrue())
+			Expect(schedule.Jitter).To(Equal(5 * time.Minute))
+		})
+
+		It("rejects an unknown location", func() {
+			config.Location = "Mars/Olympus_Mons"
+
+			_, err := ParseJobSchedule(config)
+			Expect(err).To(MatchError(ContainSubstring("invalid location")))
+		})
+
+		It("rejects an unknown missed policy", func() {
+			config.Missed = "sometimes"
+
+			_, err := ParseJobSchedule(config)
+			Expect(err).To(MatchError(ContainSubstring("invalid missed policy")))
+		})
+
+		It("rejects a negative jitter", func() {
+			config.Jitter = "-1m"
+
+			_, err := ParseJobSchedule(config)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Next", func() {
+		It("evaluates the schedule in its location", func() {
+			config.Location = "America/New_York"
+
+			schedule, err := ParseJobSchedule(config)
+			Expect(err).NotTo(HaveOccurred())
+
+			next := schedule.Next(time.Date(2019, 5, 10, 12, 0, 0, 0, time.UTC))
+			Expect(next.UTC()).To(Equal(time.Date(2019, 5, 11, 6, 0, 0, 0, time.UTC)))
+		})
+	})
+
+	Describe("FireTime", func() {
+		var slot time.Time
+
+		BeforeEach(func() {
+			slot = time.Date(2019, 5, 11, 2, 0, 0, 0, time.UTC)
+		})
+
+		It("fires on the slot without jitter", func() {
+			schedule, err := ParseJobSchedule(config)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(schedule.FireTime(1, slot)).To(Equal(slot))
+		})
+
+		It("delays the slot by a stable amount within the jitter", func() {
+			config.Jitter = "10m"
+
+			schedule, err := ParseJobSchedule(config)
+			Expect(err).NotTo(HaveOccurred())
+
+			fireTime := schedule.FireTime(1, slot)
+			Expect(fireTime).To(BeTemporally(">=", slot))
+			Expect(fireTime).To(BeTemporally("<", slot.Add(10*time.Minute)))
+			Expect(schedule.FireTime(1, slot)).To(Equal(fireTime))
+		})
+	})
+})
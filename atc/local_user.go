@@ -0,0 +1,13 @@
+package atc
+
+type LocalUser struct {
+	Username  string `json:"username"`
+	Disabled  bool   `json:"disabled"`
+	CreatedAt int64  `json:"created_at"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+type LocalUserRequest struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password"`
+}
@@ -2,7 +2,7 @@ package metric
 
 import (
 	"fmt"
-	"strings"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/lager"
@@ -54,71 +54,105 @@ func WireEmitters(group *flags.Group) {
 	}
 }
 
-type eventEmission struct {
-	event  Event
-	logger lager.Logger
-}
+// DefaultBufferSize is the number of events queued for each emitter before
+// further events for that emitter are dropped.
+const DefaultBufferSize = 1000
 
-var (
-	emitter         Emitter
-	eventHost       string
-	eventAttributes map[string]string
-	emissions       chan eventEmission
-)
-
-func Initialize(logger lager.Logger, host string, attributes map[string]string) error {
-	var (
-		emitterDescriptions []string
-		err                 error
-	)
+// Initialize constructs a Registry containing an emitter for every configured
+// EmitterFactory. The registry must be given to everything that emits
+// metrics, and is nil if no emitter is configured.
+func Initialize(logger lager.Logger, host string, attributes map[string]string, bufferSize int) (*Registry, error) {
+	r := NewRegistry(host, attributes, bufferSize)
 
 	for _, factory := range emitterFactories {
-		if factory.IsConfigured() {
-			emitterDescriptions = append(emitterDescriptions, factory.Description())
+		if !factory.IsConfigured() {
+			continue
 		}
-	}
-	if len(emitterDescriptions) > 1 {
-		return fmt.Errorf("Multiple emitters configured: %s", strings.Join(emitterDescriptions, ", "))
-	}
 
-	for _, factory := range emitterFactories {
-		if factory.IsConfigured() {
-			emitter, err = factory.NewEmitter()
-			if err != nil {
-				return err
-			}
+		emitter, err := factory.NewEmitter()
+		if err != nil {
+			r.Close()
+			return nil, err
 		}
-	}
 
-	if emitter == nil {
-		return nil
+		r.AddEmitter(factory.Description(), emitter)
 	}
 
-	emitter = emitter
-	eventHost = host
-	eventAttributes = attributes
-	emissions = make(chan eventEmission, 1000)
-
-	go emitLoop()
+	if len(r.queues) == 0 {
+		return nil, nil
+	}
 
-	return nil
+	return r, nil
 }
 
+// Deinitialize forgets the registered emitter factories.
 func Deinitialize(logger lager.Logger) {
-	close(emissions)
 	emitterFactories = nil
 }
 
-func emit(logger lager.Logger, event Event) {
-	if emitter == nil {
+// Registry fans events out to any number of emitters. Each emitter has its
+// own queue and flush loop, so a slow emitter only drops its own events. A nil
+// Registry discards every event.
+type Registry struct {
+	host       string
+	attributes map[string]string
+	bufferSize int
+
+	queuesL sync.RWMutex
+	queues  []*emitterQueue
+}
+
+type emitterQueue struct {
+	description string
+	emitter     Emitter
+	emissions   chan eventEmission
+	dropped     Meter
+	done        chan struct{}
+}
+
+type eventEmission struct {
+	event  Event
+	logger lager.Logger
+}
+
+func NewRegistry(host string, attributes map[string]string, bufferSize int) *Registry {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+
+	return &Registry{
+		host:       host,
+		attributes: attributes,
+		bufferSize: bufferSize,
+	}
+}
+
+// AddEmitter starts flushing events to the given emitter.
+func (r *Registry) AddEmitter(description string, emitter Emitter) {
+	queue := &emitterQueue{
+		description: description,
+		emitter:     emitter,
+		emissions:   make(chan eventEmission, r.bufferSize),
+		done:        make(chan struct{}),
+	}
+
+	go queue.flushLoop()
+
+	r.queuesL.Lock()
+	r.queues = append(r.queues, queue)
+	r.queuesL.Unlock()
+}
+
+func (r *Registry) Emit(logger lager.Logger, event Event) {
+	if r == nil {
 		return
 	}
 
-	event.Host = eventHost
+	event.Host = r.host
 	event.Time = time.Now()
 
 	mergedAttributes := map[string]string{}
-	for k, v := range eventAttributes {
+	for k, v := range r.attributes {
 		mergedAttributes[k] = v
 	}
 
@@ -130,15 +164,64 @@ func emit(logger lager.Logger, event Event) {
 
 	event.Attributes = mergedAttributes
 
-	select {
-	case emissions <- eventEmission{logger: logger, event: event}:
-	default:
-		logger.Error("queue-full", nil)
+	r.queuesL.RLock()
+	defer r.queuesL.RUnlock()
+
+	for _, queue := range r.queues {
+		select {
+		case queue.emissions <- eventEmission{logger: logger, event: event}:
+		default:
+			queue.dropped.Inc()
+			logger.Error("queue-full", nil, lager.Data{
+				"emitter": queue.description,
+			})
+		}
 	}
 }
 
-func emitLoop() {
-	for emission := range emissions {
-		emitter.Emit(emission.logger.Session("emit"), emission.event)
+// Dropped returns the number of events each emitter has dropped because its
+// queue was full since the last call, keyed by the emitter's description.
+func (r *Registry) Dropped() map[string]int {
+	if r == nil {
+		return map[string]int{}
+	}
+
+	r.queuesL.RLock()
+	defer r.queuesL.RUnlock()
+
+	dropped := map[string]int{}
+	for _, queue := range r.queues {
+		dropped[queue.description] += queue.dropped.Delta()
+	}
+
+	return dropped
+}
+
+// Close stops accepting events and waits for every emitter to flush the
+// events already queued.
+func (r *Registry) Close() {
+	if r == nil {
+		return
+	}
+
+	r.queuesL.Lock()
+	queues := r.queues
+	r.queues = nil
+	r.queuesL.Unlock()
+
+	for _, queue := range queues {
+		close(queue.emissions)
+	}
+
+	for _, queue := range queues {
+		<-queue.done
+	}
+}
+
+func (q *emitterQueue) flushLoop() {
+	defer close(q.done)
+
+	for emission := range q.emissions {
+		q.emitter.Emit(emission.logger.Session("emit"), emission.event)
 	}
 }
@@ -0,0 +1,79 @@
+package metric_test
+
+import (
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/metric/metricfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Registry", func() {
+	var (
+		logger   *lagertest.TestLogger
+		registry *metric.Registry
+
+		fastEmitter *metricfakes.FakeEmitter
+		slowEmitter *metricfakes.FakeEmitter
+		unblock     chan struct{}
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		registry = metric.NewRegistry("some-host", map[string]string{"some": "attribute"}, 2)
+
+		unblock = make(chan struct{})
+
+		fastEmitter = new(metricfakes.FakeEmitter)
+		slowEmitter = new(metricfakes.FakeEmitter)
+		slowEmitter.EmitStub = func(lager.Logger, metric.Event) {
+			<-unblock
+		}
+
+		registry.AddEmitter("fast", fastEmitter)
+		registry.AddEmitter("slow", slowEmitter)
+	})
+
+	AfterEach(func() {
+		close(unblock)
+		registry.Close()
+	})
+
+	It("sends events to every emitter with the host and attributes attached", func() {
+		registry.Emit(logger, metric.Event{Name: "some-event", Attributes: map[string]string{"other": "attribute"}})
+
+		Eventually(fastEmitter.EmitCallCount).Should(Equal(1))
+		Eventually(slowEmitter.EmitCallCount).Should(Equal(1))
+
+		_, event := fastEmitter.EmitArgsForCall(0)
+		Expect(event.Name).To(Equal("some-event"))
+		Expect(event.Host).To(Equal("some-host"))
+		Expect(event.Attributes).To(Equal(map[string]string{
+			"some":  "attribute",
+			"other": "attribute",
+		}))
+	})
+
+	Context("when one emitter falls behind", func() {
+		BeforeEach(func() {
+			for i := 0; i < 10; i++ {
+				registry.Emit(logger, metric.Event{Name: "some-event"})
+				Eventually(fastEmitter.EmitCallCount).Should(Equal(i + 1))
+			}
+		})
+
+		It("only drops events for that emitter", func() {
+			Expect(fastEmitter.EmitCallCount()).To(Equal(10))
+
+			dropped := registry.Dropped()
+			Expect(dropped["fast"]).To(BeZero())
+			Expect(dropped["slow"]).To(BeNumerically(">=", 7))
+
+			By("resetting the count once it has been read")
+			Expect(registry.Dropped()["slow"]).To(BeZero())
+		})
+	})
+})
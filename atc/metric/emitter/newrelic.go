@@ -123,6 +123,7 @@ func (emitter *NewRelicEmitter) Emit(logger lager.Logger, event metric.Event) {
 		"worker volumes",
 		"http response time",
 		"database queries",
+		"database pool queries",
 		"database connections":
 		payload = append(payload, emitter.simplePayload(logger, event, ""))
 
@@ -28,8 +28,12 @@ type PrometheusEmitter struct {
 	dbConnections  *prometheus.GaugeVec
 	dbQueriesTotal prometheus.Counter
 
+	dbPoolQueriesTotal *prometheus.CounterVec
+
 	errorLogs *prometheus.CounterVec
 
+	eventsDropped *prometheus.CounterVec
+
 	httpRequestsDuration *prometheus.HistogramVec
 
 	locksHeld *prometheus.GaugeVec
@@ -78,6 +82,17 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	)
 	prometheus.MustRegister(errorLogs)
 
+	// metrics about metrics
+	eventsDropped := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "metrics",
+			Name:      "events_dropped_total",
+			Help:      "Number of metric events dropped because an emitter's queue was full",
+		}, []string{"emitter"},
+	)
+	prometheus.MustRegister(eventsDropped)
+
 	// lock metrics
 	locksHeld := prometheus.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: "concourse",
@@ -258,6 +273,17 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	)
 	prometheus.MustRegister(dbConnections)
 
+	dbPoolQueriesTotal := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "db",
+			Name:      "pool_queries_total",
+			Help:      "Total number of Concourse database queries per connection pool",
+		},
+		[]string{"dbname"},
+	)
+	prometheus.MustRegister(dbPoolQueriesTotal)
+
 	resourceChecksVec := prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: "concourse",
@@ -289,8 +315,12 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 		dbConnections:  dbConnections,
 		dbQueriesTotal: dbQueriesTotal,
 
+		dbPoolQueriesTotal: dbPoolQueriesTotal,
+
 		errorLogs: errorLogs,
 
+		eventsDropped: eventsDropped,
+
 		httpRequestsDuration: httpRequestsDuration,
 
 		locksHeld: locksHeld,
@@ -348,8 +378,12 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 		emitter.databaseMetrics(logger, event)
 	case "database connections":
 		emitter.databaseMetrics(logger, event)
+	case "database pool queries":
+		emitter.databaseMetrics(logger, event)
 	case "resource checked":
 		emitter.resourceMetric(logger, event)
+	case "events dropped":
+		emitter.eventsDroppedMetric(logger, event)
 	default:
 		// unless we have a specific metric, we do nothing
 	}
@@ -565,11 +599,34 @@ func (emitter *PrometheusEmitter) databaseMetrics(logger lager.Logger, event met
 			return
 		}
 		emitter.dbConnections.WithLabelValues(connectionName).Set(float64(value))
+	case "database pool queries":
+		connectionName, exists := event.Attributes["ConnectionName"]
+		if !exists {
+			logger.Error("failed-to-connection-name-in-event", fmt.Errorf("expected ConnectionName to exist in event.Attributes"))
+			return
+		}
+		emitter.dbPoolQueriesTotal.WithLabelValues(connectionName).Add(float64(value))
 	default:
 	}
 
 }
 
+func (emitter *PrometheusEmitter) eventsDroppedMetric(logger lager.Logger, event metric.Event) {
+	value, ok := event.Value.(int)
+	if !ok {
+		logger.Error("events-dropped-value-type-mismatch", fmt.Errorf("expected event.Value to be a int"))
+		return
+	}
+
+	emitterName, exists := event.Attributes["emitter"]
+	if !exists {
+		logger.Error("failed-to-find-emitter-in-event", fmt.Errorf("expected emitter to exist in event.Attributes"))
+		return
+	}
+
+	emitter.eventsDropped.WithLabelValues(emitterName).Add(float64(value))
+}
+
 func (emitter *PrometheusEmitter) resourceMetric(logger lager.Logger, event metric.Event) {
 	pipeline, exists := event.Attributes["pipeline"]
 	if !exists {
@@ -6,12 +6,14 @@ import (
 )
 
 type ErrorSinkCollector struct {
-	logger lager.Logger
+	logger   lager.Logger
+	registry *Registry
 }
 
-func NewErrorSinkCollector(logger lager.Logger) ErrorSinkCollector {
+func NewErrorSinkCollector(logger lager.Logger, registry *Registry) ErrorSinkCollector {
 	return ErrorSinkCollector{
-		logger: logger,
+		logger:   logger,
+		registry: registry,
 	}
 }
 
@@ -27,5 +29,5 @@ func (c *ErrorSinkCollector) Log(f lager.LogFormat) {
 	ErrorLog{
 		Value:   1,
 		Message: f.Message,
-	}.Emit(c.logger)
+	}.Emit(c.logger, c.registry)
 }
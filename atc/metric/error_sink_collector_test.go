@@ -13,23 +13,21 @@ var _ = Describe("ErrorSinkCollector", func() {
 	var (
 		errorSinkCollector metric.ErrorSinkCollector
 		emitter            *metricfakes.FakeEmitter
+		registry           *metric.Registry
 	)
 
 	BeforeEach(func() {
 		testLogger := lager.NewLogger("test")
 
-		errorSinkCollector = metric.NewErrorSinkCollector(testLogger)
-
-		emitterFactory := &metricfakes.FakeEmitterFactory{}
 		emitter = &metricfakes.FakeEmitter{}
-		metric.RegisterEmitter(emitterFactory)
-		emitterFactory.IsConfiguredReturns(true)
-		emitterFactory.NewEmitterReturns(emitter, nil)
-		metric.Initialize(testLogger, "test", map[string]string{})
+		registry = metric.NewRegistry("test", map[string]string{}, metric.DefaultBufferSize)
+		registry.AddEmitter("fake", emitter)
+
+		errorSinkCollector = metric.NewErrorSinkCollector(testLogger, registry)
 	})
 
 	AfterEach(func() {
-		metric.Deinitialize(nil)
+		registry.Close()
 	})
 
 	Context("Log", func() {
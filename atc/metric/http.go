@@ -8,16 +8,18 @@ import (
 )
 
 type MetricsHandler struct {
-	Logger  lager.Logger
-	Route   string
-	Handler http.Handler
+	Logger   lager.Logger
+	Registry *Registry
+	Route    string
+	Handler  http.Handler
 }
 
-func WrapHandler(logger lager.Logger, route string, handler http.Handler) http.Handler {
+func WrapHandler(logger lager.Logger, registry *Registry, route string, handler http.Handler) http.Handler {
 	return MetricsHandler{
-		Logger:  logger,
-		Route:   route,
-		Handler: handler,
+		Logger:   logger,
+		Registry: registry,
+		Route:    route,
+		Handler:  handler,
 	}
 }
 
@@ -30,5 +32,5 @@ func (handler MetricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		Method:     r.Method,
 		StatusCode: metrics.Code,
 		Duration:   metrics.Duration,
-	}.Emit(handler.Logger)
+	}.Emit(handler.Logger, handler.Registry)
 }
@@ -30,27 +30,23 @@ var dummyLogger = lager.NewLogger("dont care")
 
 var _ = Describe("MetricsHandler", func() {
 	var (
-		ts      *httptest.Server
-		emitter *metricfakes.FakeEmitter
+		ts       *httptest.Server
+		emitter  *metricfakes.FakeEmitter
+		registry *metric.Registry
 	)
 
 	BeforeEach(func() {
-		emitterFactory := &metricfakes.FakeEmitterFactory{}
 		emitter = &metricfakes.FakeEmitter{}
-
-		metric.RegisterEmitter(emitterFactory)
-		emitterFactory.IsConfiguredReturns(true)
-		emitterFactory.NewEmitterReturns(emitter, nil)
-
-		metric.Initialize(dummyLogger, "test", map[string]string{})
+		registry = metric.NewRegistry("test", map[string]string{}, metric.DefaultBufferSize)
+		registry.AddEmitter("fake", emitter)
 
 		ts = httptest.NewServer(
-			WrapHandler(dummyLogger, "ApiEndpoint", http.HandlerFunc(noopHandler)))
+			WrapHandler(dummyLogger, registry, "ApiEndpoint", http.HandlerFunc(noopHandler)))
 	})
 
 	AfterEach(func() {
 		ts.Close()
-		metric.Deinitialize(dummyLogger)
+		registry.Close()
 	})
 
 	Context("when serving requests", func() {
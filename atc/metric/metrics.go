@@ -21,13 +21,14 @@ var FailedVolumes = Meter(0)
 
 var ContainersDeleted = Meter(0)
 var VolumesDeleted = Meter(0)
+var ResourceConfigVersionsPruned = Meter(0)
 
 type SchedulingFullDuration struct {
 	PipelineName string
 	Duration     time.Duration
 }
 
-func (event SchedulingFullDuration) Emit(logger lager.Logger) {
+func (event SchedulingFullDuration) Emit(logger lager.Logger, registry *Registry) {
 	state := EventStateOK
 
 	if event.Duration > time.Second {
@@ -38,7 +39,7 @@ func (event SchedulingFullDuration) Emit(logger lager.Logger) {
 		state = EventStateCritical
 	}
 
-	emit(
+	registry.Emit(
 		logger.Session("full-scheduling-duration"),
 		Event{
 			Name:  "scheduling: full duration (ms)",
@@ -56,7 +57,7 @@ type SchedulingLoadVersionsDuration struct {
 	Duration     time.Duration
 }
 
-func (event SchedulingLoadVersionsDuration) Emit(logger lager.Logger) {
+func (event SchedulingLoadVersionsDuration) Emit(logger lager.Logger, registry *Registry) {
 	state := EventStateOK
 
 	if event.Duration > time.Second {
@@ -67,7 +68,7 @@ func (event SchedulingLoadVersionsDuration) Emit(logger lager.Logger) {
 		state = EventStateCritical
 	}
 
-	emit(
+	registry.Emit(
 		logger.Session("loading-versions-duration"),
 		Event{
 			Name:  "scheduling: loading versions duration (ms)",
@@ -86,7 +87,7 @@ type SchedulingJobDuration struct {
 	Duration     time.Duration
 }
 
-func (event SchedulingJobDuration) Emit(logger lager.Logger) {
+func (event SchedulingJobDuration) Emit(logger lager.Logger, registry *Registry) {
 	state := EventStateOK
 
 	if event.Duration > time.Second {
@@ -97,7 +98,7 @@ func (event SchedulingJobDuration) Emit(logger lager.Logger) {
 		state = EventStateCritical
 	}
 
-	emit(
+	registry.Emit(
 		logger.Session("job-scheduling-duration"),
 		Event{
 			Name:  "scheduling: job duration (ms)",
@@ -117,8 +118,8 @@ type WorkerContainers struct {
 	Containers int
 }
 
-func (event WorkerContainers) Emit(logger lager.Logger) {
-	emit(
+func (event WorkerContainers) Emit(logger lager.Logger, registry *Registry) {
+	registry.Emit(
 		logger.Session("worker-containers"),
 		Event{
 			Name:  "worker containers",
@@ -138,8 +139,8 @@ type WorkerVolumes struct {
 	Volumes    int
 }
 
-func (event WorkerVolumes) Emit(logger lager.Logger) {
-	emit(
+func (event WorkerVolumes) Emit(logger lager.Logger, registry *Registry) {
+	registry.Emit(
 		logger.Session("worker-volumes"),
 		Event{
 			Name:  "worker volumes",
@@ -153,12 +154,33 @@ func (event WorkerVolumes) Emit(logger lager.Logger) {
 	)
 }
 
+type WorkerQuarantined struct {
+	WorkerName string
+	Failures   int
+	Attempts   int
+}
+
+func (event WorkerQuarantined) Emit(logger lager.Logger, registry *Registry) {
+	registry.Emit(
+		logger.Session("worker-quarantined"),
+		Event{
+			Name:  "worker quarantined",
+			Value: event.Failures,
+			State: EventStateWarning,
+			Attributes: map[string]string{
+				"worker":   event.WorkerName,
+				"attempts": strconv.Itoa(event.Attempts),
+			},
+		},
+	)
+}
+
 type VolumesToBeGarbageCollected struct {
 	Volumes int
 }
 
-func (event VolumesToBeGarbageCollected) Emit(logger lager.Logger) {
-	emit(
+func (event VolumesToBeGarbageCollected) Emit(logger lager.Logger, registry *Registry) {
+	registry.Emit(
 		logger.Session("gc-found-orphaned-volumes-for-deletion"),
 		Event{
 			Name:       "orphaned volumes to be garbage collected",
@@ -173,8 +195,8 @@ type CreatingContainersToBeGarbageCollected struct {
 	Containers int
 }
 
-func (event CreatingContainersToBeGarbageCollected) Emit(logger lager.Logger) {
-	emit(
+func (event CreatingContainersToBeGarbageCollected) Emit(logger lager.Logger, registry *Registry) {
+	registry.Emit(
 		logger.Session("gc-found-creating-containers-for-deletion"),
 		Event{
 			Name:       "creating containers to be garbage collected",
@@ -189,8 +211,8 @@ type CreatedContainersToBeGarbageCollected struct {
 	Containers int
 }
 
-func (event CreatedContainersToBeGarbageCollected) Emit(logger lager.Logger) {
-	emit(
+func (event CreatedContainersToBeGarbageCollected) Emit(logger lager.Logger, registry *Registry) {
+	registry.Emit(
 		logger.Session("gc-found-created-ccontainers-for-deletion"),
 		Event{
 			Name:       "created containers to be garbage collected",
@@ -205,8 +227,8 @@ type DestroyingContainersToBeGarbageCollected struct {
 	Containers int
 }
 
-func (event DestroyingContainersToBeGarbageCollected) Emit(logger lager.Logger) {
-	emit(
+func (event DestroyingContainersToBeGarbageCollected) Emit(logger lager.Logger, registry *Registry) {
+	registry.Emit(
 		logger.Session("gc-found-destroying-containers-for-deletion"),
 		Event{
 			Name:       "destroying containers to be garbage collected",
@@ -221,8 +243,8 @@ type FailedContainersToBeGarbageCollected struct {
 	Containers int
 }
 
-func (event FailedContainersToBeGarbageCollected) Emit(logger lager.Logger) {
-	emit(
+func (event FailedContainersToBeGarbageCollected) Emit(logger lager.Logger, registry *Registry) {
+	registry.Emit(
 		logger.Session("gc-found-failed-containers-for-deletion"),
 		Event{
 			Name:       "failed containers to be garbage collected",
@@ -237,8 +259,8 @@ type CreatedVolumesToBeGarbageCollected struct {
 	Volumes int
 }
 
-func (event CreatedVolumesToBeGarbageCollected) Emit(logger lager.Logger) {
-	emit(
+func (event CreatedVolumesToBeGarbageCollected) Emit(logger lager.Logger, registry *Registry) {
+	registry.Emit(
 		logger.Session("gc-found-created-volumes-for-deletion"),
 		Event{
 			Name:       "created volumes to be garbage collected",
@@ -253,8 +275,8 @@ type DestroyingVolumesToBeGarbageCollected struct {
 	Volumes int
 }
 
-func (event DestroyingVolumesToBeGarbageCollected) Emit(logger lager.Logger) {
-	emit(
+func (event DestroyingVolumesToBeGarbageCollected) Emit(logger lager.Logger, registry *Registry) {
+	registry.Emit(
 		logger.Session("gc-found-destroying-volumes-for-deletion"),
 		Event{
 			Name:       "destroying volumes to be garbage collected",
@@ -269,8 +291,8 @@ type FailedVolumesToBeGarbageCollected struct {
 	Volumes int
 }
 
-func (event FailedVolumesToBeGarbageCollected) Emit(logger lager.Logger) {
-	emit(
+func (event FailedVolumesToBeGarbageCollected) Emit(logger lager.Logger, registry *Registry) {
+	registry.Emit(
 		logger.Session("gc-found-failed-volumes-for-deletion"),
 		Event{
 			Name:       "failed volumes to be garbage collected",
@@ -285,8 +307,8 @@ type GarbageCollectionContainerCollectorJobDropped struct {
 	WorkerName string
 }
 
-func (event GarbageCollectionContainerCollectorJobDropped) Emit(logger lager.Logger) {
-	emit(
+func (event GarbageCollectionContainerCollectorJobDropped) Emit(logger lager.Logger, registry *Registry) {
+	registry.Emit(
 		logger.Session("gc-container-collector-dropped"),
 		Event{
 			Name:  "GC container collector job dropped",
@@ -307,8 +329,8 @@ type BuildStarted struct {
 	TeamName     string
 }
 
-func (event BuildStarted) Emit(logger lager.Logger) {
-	emit(
+func (event BuildStarted) Emit(logger lager.Logger, registry *Registry) {
+	registry.Emit(
 		logger.Session("build-started"),
 		Event{
 			Name:  "build started",
@@ -335,8 +357,8 @@ type BuildFinished struct {
 	TeamName      string
 }
 
-func (event BuildFinished) Emit(logger lager.Logger) {
-	emit(
+func (event BuildFinished) Emit(logger lager.Logger, registry *Registry) {
+	registry.Emit(
 		logger.Session("build-finished"),
 		Event{
 			Name:  "build finished",
@@ -363,8 +385,8 @@ type ErrorLog struct {
 	Value   int
 }
 
-func (e ErrorLog) Emit(logger lager.Logger) {
-	emit(
+func (e ErrorLog) Emit(logger lager.Logger, registry *Registry) {
+	registry.Emit(
 		logger.Session("error-log"),
 		Event{
 			Name:  "error log",
@@ -385,7 +407,7 @@ type HTTPResponseTime struct {
 	Duration   time.Duration
 }
 
-func (event HTTPResponseTime) Emit(logger lager.Logger) {
+func (event HTTPResponseTime) Emit(logger lager.Logger, registry *Registry) {
 	state := EventStateOK
 
 	if event.Duration > 100*time.Millisecond {
@@ -396,7 +418,7 @@ func (event HTTPResponseTime) Emit(logger lager.Logger) {
 		state = EventStateCritical
 	}
 
-	emit(
+	registry.Emit(
 		logger.Session("http-response-time"),
 		Event{
 			Name:  "http response time",
@@ -419,12 +441,12 @@ type ResourceCheck struct {
 	Success      bool
 }
 
-func (event ResourceCheck) Emit(logger lager.Logger) {
+func (event ResourceCheck) Emit(logger lager.Logger, registry *Registry) {
 	state := EventStateOK
 	if !event.Success {
 		state = EventStateWarning
 	}
-	emit(
+	registry.Emit(
 		logger.Session("resource-check"),
 		Event{
 			Name:  "resource checked",
@@ -453,8 +475,8 @@ type LockAcquired struct {
 	LockType string
 }
 
-func (event LockAcquired) Emit(logger lager.Logger) {
-	emit(
+func (event LockAcquired) Emit(logger lager.Logger, registry *Registry) {
+	registry.Emit(
 		logger.Session("lock-acquired"),
 		Event{
 			Name:  "lock held",
@@ -471,8 +493,8 @@ type LockReleased struct {
 	LockType string
 }
 
-func (event LockReleased) Emit(logger lager.Logger) {
-	emit(
+func (event LockReleased) Emit(logger lager.Logger, registry *Registry) {
+	registry.Emit(
 		logger.Session("lock-released"),
 		Event{
 			Name:  "lock held",
@@ -485,7 +507,9 @@ func (event LockReleased) Emit(logger lager.Logger) {
 	)
 }
 
-func LogLockAcquired(logger lager.Logger, lockID lock.LockID) {
+// LogLockAcquired is given to the lock factory to emit a metric whenever a
+// lock is acquired.
+func (r *Registry) LogLockAcquired(logger lager.Logger, lockID lock.LockID) {
 	logger.Debug("acquired")
 
 	if len(lockID) == 0 {
@@ -493,11 +517,13 @@ func LogLockAcquired(logger lager.Logger, lockID lock.LockID) {
 	}
 
 	if lockType, ok := lockTypeNames[lockID[0]]; ok {
-		LockAcquired{LockType: lockType}.Emit(logger)
+		LockAcquired{LockType: lockType}.Emit(logger, r)
 	}
 }
 
-func LogLockReleased(logger lager.Logger, lockID lock.LockID) {
+// LogLockReleased is given to the lock factory to emit a metric whenever a
+// lock is released.
+func (r *Registry) LogLockReleased(logger lager.Logger, lockID lock.LockID) {
 	logger.Debug("released")
 
 	if len(lockID) == 0 {
@@ -505,7 +531,7 @@ func LogLockReleased(logger lager.Logger, lockID lock.LockID) {
 	}
 
 	if lockType, ok := lockTypeNames[lockID[0]]; ok {
-		LockReleased{LockType: lockType}.Emit(logger)
+		LockReleased{LockType: lockType}.Emit(logger, r)
 	}
 }
 
@@ -513,7 +539,7 @@ type WorkersState struct {
 	WorkerStateByName map[string]db.WorkerState
 }
 
-func (event WorkersState) Emit(logger lager.Logger) {
+func (event WorkersState) Emit(logger lager.Logger, registry *Registry) {
 	var (
 		perStateCounter = map[db.WorkerState]int{}
 		eventState      EventState
@@ -530,13 +556,13 @@ func (event WorkersState) Emit(logger lager.Logger) {
 	}
 
 	for state, count := range perStateCounter {
-		if state == db.WorkerStateStalled && count > 0 {
+		if (state == db.WorkerStateStalled || state == db.WorkerStateQuarantined) && count > 0 {
 			eventState = EventStateWarning
 		} else {
 			eventState = EventStateOK
 		}
 
-		emit(
+		registry.Emit(
 			logger.Session("worker-state"),
 			Event{
 				Name:  "worker state",
@@ -549,3 +575,30 @@ func (event WorkersState) Emit(logger lager.Logger) {
 		)
 	}
 }
+
+type TeamQuotaSaturation struct {
+	TeamName string
+	Resource string
+	Used     int
+	Limit    int
+}
+
+func (event TeamQuotaSaturation) Emit(logger lager.Logger, registry *Registry) {
+	state := EventStateOK
+	if event.Used >= event.Limit {
+		state = EventStateWarning
+	}
+
+	registry.Emit(
+		logger.Session("team-quota-saturation"),
+		Event{
+			Name:  "team quota saturation",
+			Value: float64(event.Used) / float64(event.Limit),
+			State: state,
+			Attributes: map[string]string{
+				"team_name": event.TeamName,
+				"resource":  event.Resource,
+			},
+		},
+	)
+}
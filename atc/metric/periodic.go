@@ -9,7 +9,10 @@ import (
 	"github.com/tedsuo/ifrit"
 )
 
-func PeriodicallyEmit(logger lager.Logger, interval time.Duration) ifrit.Runner {
+// PeriodicallyEmit emits gauges and meters that are not tied to a particular
+// event, as well as the number of events each emitter of the registry has
+// dropped, to the given registry.
+func PeriodicallyEmit(logger lager.Logger, registry *Registry, interval time.Duration) ifrit.Runner {
 	return ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
 		ticker := time.NewTicker(interval)
 		defer ticker.Stop()
@@ -21,14 +24,16 @@ func PeriodicallyEmit(logger lager.Logger, interval time.Duration) ifrit.Runner
 			case <-signals:
 				return nil
 			case <-ticker.C:
-				tick(logger.Session("tick"))
+				if registry != nil {
+					tick(logger.Session("tick"), registry)
+				}
 			}
 		}
 	})
 }
 
-func tick(logger lager.Logger) {
-	emit(
+func tick(logger lager.Logger, registry *Registry) {
+	registry.Emit(
 		logger.Session("database-queries"),
 		Event{
 			Name:  "database queries",
@@ -39,7 +44,7 @@ func tick(logger lager.Logger) {
 
 	if len(Databases) > 0 {
 		for _, database := range Databases {
-			emit(
+			registry.Emit(
 				logger.Session("database-connections"),
 				Event{
 					Name:  "database connections",
@@ -50,10 +55,24 @@ func tick(logger lager.Logger) {
 					},
 				},
 			)
+
+			if counter, ok := database.(QueryCounter); ok {
+				registry.Emit(
+					logger.Session("database-pool-queries"),
+					Event{
+						Name:  "database pool queries",
+						Value: counter.QueryCount(),
+						State: EventStateOK,
+						Attributes: map[string]string{
+							"ConnectionName": database.Name(),
+						},
+					},
+				)
+			}
 		}
 	}
 
-	emit(
+	registry.Emit(
 		logger.Session("containers-deleted"),
 		Event{
 			Name:  "containers deleted",
@@ -62,7 +81,7 @@ func tick(logger lager.Logger) {
 		},
 	)
 
-	emit(
+	registry.Emit(
 		logger.Session("volumes-deleted"),
 		Event{
 			Name:  "volumes deleted",
@@ -71,7 +90,16 @@ func tick(logger lager.Logger) {
 		},
 	)
 
-	emit(
+	registry.Emit(
+		logger.Session("resource-config-versions-pruned"),
+		Event{
+			Name:  "resource config versions pruned",
+			Value: ResourceConfigVersionsPruned.Delta(),
+			State: EventStateOK,
+		},
+	)
+
+	registry.Emit(
 		logger.Session("containers-created"),
 		Event{
 			Name:  "containers created",
@@ -80,7 +108,7 @@ func tick(logger lager.Logger) {
 		},
 	)
 
-	emit(
+	registry.Emit(
 		logger.Session("volumes-created"),
 		Event{
 			Name:  "volumes created",
@@ -89,7 +117,7 @@ func tick(logger lager.Logger) {
 		},
 	)
 
-	emit(
+	registry.Emit(
 		logger.Session("failed-containers"),
 		Event{
 			Name:  "failed containers",
@@ -98,7 +126,7 @@ func tick(logger lager.Logger) {
 		},
 	)
 
-	emit(
+	registry.Emit(
 		logger.Session("failed-volumes"),
 		Event{
 			Name:  "failed volumes",
@@ -107,10 +135,24 @@ func tick(logger lager.Logger) {
 		},
 	)
 
+	for emitter, dropped := range registry.Dropped() {
+		registry.Emit(
+			logger.Session("events-dropped"),
+			Event{
+				Name:  "events dropped",
+				Value: dropped,
+				State: EventStateOK,
+				Attributes: map[string]string{
+					"emitter": emitter,
+				},
+			},
+		)
+	}
+
 	var memStats runtime.MemStats
 	runtime.ReadMemStats(&memStats)
 
-	emit(
+	registry.Emit(
 		logger.Session("gc-pause-total-duration"),
 		Event{
 			Name:  "gc pause total duration",
@@ -119,7 +161,7 @@ func tick(logger lager.Logger) {
 		},
 	)
 
-	emit(
+	registry.Emit(
 		logger.Session("mallocs"),
 		Event{
 			Name:  "mallocs",
@@ -128,7 +170,7 @@ func tick(logger lager.Logger) {
 		},
 	)
 
-	emit(
+	registry.Emit(
 		logger.Session("frees"),
 		Event{
 			Name:  "frees",
@@ -137,7 +179,7 @@ func tick(logger lager.Logger) {
 		},
 	)
 
-	emit(
+	registry.Emit(
 		logger.Session("goroutines"),
 		Event{
 			Name:  "goroutines",
@@ -18,7 +18,8 @@ import (
 
 var _ = Describe("Periodic emission of metrics", func() {
 	var (
-		emitter *metricfakes.FakeEmitter
+		emitter  *metricfakes.FakeEmitter
+		registry *metric.Registry
 
 		process ifrit.Process
 	)
@@ -28,21 +29,25 @@ var _ = Describe("Periodic emission of metrics", func() {
 		emitter = &metricfakes.FakeEmitter{}
 
 		metric.RegisterEmitter(emitterFactory)
+		emitterFactory.DescriptionReturns("fake")
 		emitterFactory.IsConfiguredReturns(true)
 		emitterFactory.NewEmitterReturns(emitter, nil)
 		a := &dbfakes.FakeConn{}
 		a.NameReturns("A")
 		b := &dbfakes.FakeConn{}
 		b.NameReturns("B")
-		metric.Databases = []db.Conn{a, b}
-		metric.Initialize(nil, "test", map[string]string{})
+		metric.Databases = []db.Conn{a, metric.CountQueries(b)}
+		var err error
+		registry, err = metric.Initialize(nil, "test", map[string]string{}, metric.DefaultBufferSize)
+		Expect(err).NotTo(HaveOccurred())
 
-		process = ifrit.Invoke(metric.PeriodicallyEmit(lager.NewLogger("dont care"), 250*time.Millisecond))
+		process = ifrit.Invoke(metric.PeriodicallyEmit(lager.NewLogger("dont care"), registry, 250*time.Millisecond))
 	})
 
 	AfterEach(func() {
 		process.Signal(os.Interrupt)
 		<-process.Wait()
+		registry.Close()
 		metric.Deinitialize(nil)
 	})
 
@@ -79,5 +84,31 @@ var _ = Describe("Periodic emission of metrics", func() {
 				),
 			),
 		)
+
+		By("emits database queries for each counted pool")
+		Expect(emitter.Invocations()["Emit"]).To(
+			ContainElement(
+				ContainElement(
+					MatchFields(IgnoreExtras, Fields{
+						"Name":       Equal("database pool queries"),
+						"Attributes": Equal(map[string]string{"ConnectionName": "B"}),
+					}),
+				),
+			),
+		)
+	})
+
+	It("emits the number of events dropped by each emitter", func() {
+		Eventually(emitter.EmitCallCount).Should(BeNumerically(">=", 1))
+		Eventually(emitter.Invocations).Should(HaveKeyWithValue("Emit",
+			ContainElement(
+				ContainElement(
+					MatchFields(IgnoreExtras, Fields{
+						"Name":       Equal("events dropped"),
+						"Attributes": Equal(map[string]string{"emitter": "fake"}),
+					}),
+				),
+			),
+		))
 	})
 })
@@ -7,30 +7,46 @@ import (
 	"github.com/concourse/concourse/atc/db"
 )
 
+// QueryCounter is implemented by connections wrapped with CountQueries, so
+// that queries can be reported per connection pool as well as globally.
+type QueryCounter interface {
+	QueryCount() int
+}
+
 func CountQueries(conn db.Conn) db.Conn {
 	return &countingConn{
-		Conn: conn,
+		Conn:    conn,
+		queries: new(Meter),
 	}
 }
 
 type countingConn struct {
 	db.Conn
+
+	queries *Meter
+}
+
+func (e *countingConn) QueryCount() int {
+	return e.queries.Delta()
 }
 
 func (e *countingConn) Query(query string, args ...interface{}) (*sql.Rows, error) {
 	DatabaseQueries.Inc()
+	e.queries.Inc()
 
 	return e.Conn.Query(query, args...)
 }
 
 func (e *countingConn) QueryRow(query string, args ...interface{}) squirrel.RowScanner {
 	DatabaseQueries.Inc()
+	e.queries.Inc()
 
 	return e.Conn.QueryRow(query, args...)
 }
 
 func (e *countingConn) Exec(query string, args ...interface{}) (sql.Result, error) {
 	DatabaseQueries.Inc()
+	e.queries.Inc()
 
 	return e.Conn.Exec(query, args...)
 }
@@ -41,27 +57,32 @@ func (e *countingConn) Begin() (db.Tx, error) {
 		return tx, err
 	}
 
-	return &countingTx{Tx: tx}, nil
+	return &countingTx{Tx: tx, queries: e.queries}, nil
 }
 
 type countingTx struct {
 	db.Tx
+
+	queries *Meter
 }
 
 func (e *countingTx) Query(query string, args ...interface{}) (*sql.Rows, error) {
 	DatabaseQueries.Inc()
+	e.queries.Inc()
 
 	return e.Tx.Query(query, args...)
 }
 
 func (e *countingTx) QueryRow(query string, args ...interface{}) squirrel.RowScanner {
 	DatabaseQueries.Inc()
+	e.queries.Inc()
 
 	return e.Tx.QueryRow(query, args...)
 }
 
 func (e *countingTx) Exec(query string, args ...interface{}) (sql.Result, error) {
 	DatabaseQueries.Inc()
+	e.queries.Inc()
 
 	return e.Tx.Exec(query, args...)
 }
@@ -66,5 +66,26 @@ var _ = Describe("Counting Database Queries", func() {
 
 			Expect(metric.DatabaseQueries.Delta()).To(Equal(1))
 		})
+
+		It("counts queries for the connection's own pool", func() {
+			otherConn := metric.CountQueries(new(dbfakes.FakeConn))
+
+			_, err := countingConn.Query("SELECT $1::int", 1)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = otherConn.Exec("SELECT $1::int", 1)
+			Expect(err).NotTo(HaveOccurred())
+
+			underlyingConn.BeginReturns(&dbfakes.FakeTx{}, nil)
+
+			tx, err := countingConn.Begin()
+			Expect(err).NotTo(HaveOccurred())
+
+			tx.QueryRow("SELECT $1::int", 1)
+
+			Expect(countingConn.(metric.QueryCounter).QueryCount()).To(Equal(2))
+			Expect(otherConn.(metric.QueryCounter).QueryCount()).To(Equal(1))
+			Expect(countingConn.(metric.QueryCounter).QueryCount()).To(BeZero())
+		})
 	})
 })
@@ -0,0 +1,17 @@
+package atc
+
+type NamedLock struct {
+	Name     string           `json:"name"`
+	Capacity int              `json:"capacity"`
+	Holders  []NamedLockClaim `json:"holders"`
+	Waiters  []NamedLockClaim `json:"waiters"`
+}
+
+type NamedLockClaim struct {
+	BuildID      int    `json:"build_id"`
+	BuildName    string `json:"build_name"`
+	JobName      string `json:"job_name,omitempty"`
+	PipelineName string `json:"pipeline_name,omitempty"`
+	ClaimedAt    int64  `json:"claimed_at"`
+	AcquiredAt   int64  `json:"acquired_at,omitempty"`
+}
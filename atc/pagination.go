@@ -5,6 +5,7 @@ const (
 	LinkRelPrevious = "previous"
 
 	PaginationQueryTimestamps = "timestamps"
+	PaginationQueryFilter     = "filter"
 	PaginationQuerySince      = "since"
 	PaginationQueryUntil      = "until"
 	PaginationQueryFrom       = "from"
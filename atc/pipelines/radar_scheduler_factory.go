@@ -7,6 +7,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/radar"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/scheduler"
@@ -27,26 +28,35 @@ type radarSchedulerFactory struct {
 	pool                         worker.Pool
 	resourceFactory              resource.ResourceFactory
 	resourceConfigFactory        db.ResourceConfigFactory
+	teamFactory                  db.TeamFactory
 	resourceTypeCheckingInterval time.Duration
 	resourceCheckingInterval     time.Duration
+	sourceDefaults               atc.SourceDefaults
 	strategy                     worker.ContainerPlacementStrategy
+	registry                     *metric.Registry
 }
 
 func NewRadarSchedulerFactory(
 	pool worker.Pool,
 	resourceFactory resource.ResourceFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
+	teamFactory db.TeamFactory,
 	resourceTypeCheckingInterval time.Duration,
 	resourceCheckingInterval time.Duration,
+	sourceDefaults atc.SourceDefaults,
 	strategy worker.ContainerPlacementStrategy,
+	registry *metric.Registry,
 ) RadarSchedulerFactory {
 	return &radarSchedulerFactory{
 		pool:                         pool,
 		resourceFactory:              resourceFactory,
 		resourceConfigFactory:        resourceConfigFactory,
+		teamFactory:                  teamFactory,
 		resourceTypeCheckingInterval: resourceTypeCheckingInterval,
 		resourceCheckingInterval:     resourceCheckingInterval,
+		sourceDefaults:               sourceDefaults,
 		strategy:                     strategy,
+		registry:                     registry,
 	}
 }
 
@@ -61,8 +71,10 @@ func (rsf *radarSchedulerFactory) BuildScanRunnerFactory(dbPipeline db.Pipeline,
 		clock.NewClock(),
 		externalURL,
 		variables,
+		rsf.sourceDefaults,
 		rsf.strategy,
 		notifications,
+		rsf.registry,
 	)
 }
 
@@ -76,12 +88,15 @@ func (rsf *radarSchedulerFactory) BuildScheduler(pipeline db.Pipeline) scheduler
 		InputMapper: inputMapper,
 		BuildStarter: scheduler.NewBuildStarter(
 			pipeline,
+			rsf.teamFactory.GetByID(pipeline.TeamID()),
 			maxinflight.NewUpdater(pipeline),
 			factory.NewBuildFactory(
 				pipeline.ID(),
 				atc.NewPlanFactory(time.Now().Unix()),
 			),
 			inputMapper,
+			rsf.registry,
 		),
+		Clock: clock.NewClock(),
 	}
 }
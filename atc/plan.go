@@ -17,6 +17,7 @@ type Plan struct {
 	OnFailure  *OnFailurePlan  `json:"on_failure,omitempty"`
 	Try        *TryPlan        `json:"try,omitempty"`
 	Timeout    *TimeoutPlan    `json:"timeout,omitempty"`
+	Lock       *LockPlan       `json:"lock,omitempty"`
 	Retry      *RetryPlan      `json:"retry,omitempty"`
 
 	// used for 'fly execute'
@@ -68,6 +69,12 @@ type TimeoutPlan struct {
 	Duration string `json:"duration"`
 }
 
+type LockPlan struct {
+	Step     Plan   `json:"step"`
+	Name     string `json:"name"`
+	Capacity int    `json:"capacity,omitempty"`
+}
+
 type TryPlan struct {
 	Step Plan `json:"step"`
 }
@@ -122,6 +129,9 @@ type TaskPlan struct {
 	OutputMapping     map[string]string `json:"output_mapping,omitempty"`
 	ImageArtifactName string            `json:"image,omitempty"`
 
+	RetainOutputs bool `json:"retain_outputs,omitempty"`
+	CacheResult   bool `json:"cache_result,omitempty"`
+
 	VersionedResourceTypes VersionedResourceTypes `json:"resource_types,omitempty"`
 }
 
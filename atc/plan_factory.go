@@ -51,6 +51,8 @@ func (factory PlanFactory) NewPlan(step Step) Plan {
 		plan.Try = &t
 	case TimeoutPlan:
 		plan.Timeout = &t
+	case LockPlan:
+		plan.Lock = &t
 	case RetryPlan:
 		plan.Retry = &t
 	case ArtifactInputPlan:
@@ -0,0 +1,77 @@
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// NewAgentChecker returns a Checker which consults an HTTP policy agent. The
+// input is POSTed to the URL as {"input": ...}, which is the request body
+// expected by OPA's data API, and the agent responds with
+// {"result": {"decision": "allow|deny|warn", "reasons": [...]}}.
+//
+// A response without a result, as OPA gives when the policy is undefined,
+// allows the action.
+func NewAgentChecker(url string, timeout time.Duration) Checker {
+	return &agentChecker{
+		url: url,
+		client: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+type agentChecker struct {
+	url    string
+	client *http.Client
+}
+
+type agentRequest struct {
+	Input Input `json:"input"`
+}
+
+type agentResponse struct {
+	Result *Result `json:"result"`
+}
+
+func (checker *agentChecker) Check(input Input) (Result, error) {
+	input.Service = "concourse"
+
+	payload, err := json.Marshal(agentRequest{Input: input})
+	if err != nil {
+		return Result{}, err
+	}
+
+	response, err := checker.client.Post(checker.url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return Result{}, fmt.Errorf("policy check failed: %s", err)
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(response.Body)
+		return Result{}, fmt.Errorf("policy check failed: agent returned %s: %s", response.Status, body)
+	}
+
+	var decoded agentResponse
+	err = json.NewDecoder(response.Body).Decode(&decoded)
+	if err != nil {
+		return Result{}, fmt.Errorf("policy check failed: malformed agent response: %s", err)
+	}
+
+	if decoded.Result == nil {
+		return Result{Decision: DecisionAllow}, nil
+	}
+
+	switch decoded.Result.Decision {
+	case DecisionAllow, DecisionDeny, DecisionWarn:
+		return *decoded.Result, nil
+	default:
+		return Result{}, fmt.Errorf("policy check failed: unknown decision '%s'", decoded.Result.Decision)
+	}
+}
@@ -0,0 +1,118 @@
+package policy_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc/policy"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("AgentChecker", func() {
+	var (
+		agent   *ghttp.Server
+		checker policy.Checker
+
+		result   policy.Result
+		checkErr error
+	)
+
+	BeforeEach(func() {
+		agent = ghttp.NewServer()
+		checker = policy.NewAgentChecker(agent.URL()+"/v1/data/concourse/decision", time.Second)
+	})
+
+	AfterEach(func() {
+		agent.Close()
+	})
+
+	JustBeforeEach(func() {
+		result, checkErr = checker.Check(policy.Input{
+			Action:   policy.ActionRunTask,
+			User:     "some-user",
+			Team:     "some-team",
+			Pipeline: "some-pipeline",
+			Data:     map[string]interface{}{"privileged": true},
+		})
+	})
+
+	Context("when the agent denies the action", func() {
+		BeforeEach(func() {
+			agent.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/v1/data/concourse/decision"),
+					ghttp.VerifyJSON(`{
+						"input": {
+							"service": "concourse",
+							"action": "RunTask",
+							"user": "some-user",
+							"team": "some-team",
+							"pipeline": "some-pipeline",
+							"data": {"privileged": true}
+						}
+					}`),
+					ghttp.RespondWith(http.StatusOK, `{"result": {"decision": "deny", "reasons": ["privileged tasks are not allowed"]}}`),
+				),
+			)
+		})
+
+		It("returns the decision with its reasons", func() {
+			Expect(checkErr).NotTo(HaveOccurred())
+			Expect(result.Denied()).To(BeTrue())
+			Expect(result.Reasons).To(Equal([]string{"privileged tasks are not allowed"}))
+		})
+	})
+
+	Context("when the agent warns about the action", func() {
+		BeforeEach(func() {
+			agent.AppendHandlers(
+				ghttp.RespondWith(http.StatusOK, `{"result": {"decision": "warn", "reasons": ["consider a smaller image"]}}`),
+			)
+		})
+
+		It("returns the warning", func() {
+			Expect(checkErr).NotTo(HaveOccurred())
+			Expect(result.Warned()).To(BeTrue())
+			Expect(result.Denied()).To(BeFalse())
+		})
+	})
+
+	Context("when the policy is undefined", func() {
+		BeforeEach(func() {
+			agent.AppendHandlers(
+				ghttp.RespondWith(http.StatusOK, `{}`),
+			)
+		})
+
+		It("allows the action", func() {
+			Expect(checkErr).NotTo(HaveOccurred())
+			Expect(result.Decision).To(Equal(policy.DecisionAllow))
+		})
+	})
+
+	Context("when the agent returns an unknown decision", func() {
+		BeforeEach(func() {
+			agent.AppendHandlers(
+				ghttp.RespondWith(http.StatusOK, `{"result": {"decision": "maybe"}}`),
+			)
+		})
+
+		It("returns an error", func() {
+			Expect(checkErr).To(MatchError(ContainSubstring("unknown decision 'maybe'")))
+		})
+	})
+
+	Context("when the agent fails", func() {
+		BeforeEach(func() {
+			agent.AppendHandlers(
+				ghttp.RespondWith(http.StatusInternalServerError, "oh no"),
+			)
+		})
+
+		It("returns an error", func() {
+			Expect(checkErr).To(MatchError(ContainSubstring("oh no")))
+		})
+	})
+})
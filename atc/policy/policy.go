@@ -0,0 +1,76 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	ActionSaveConfig = "SaveConfig"
+	ActionSetTeam    = "SetTeam"
+	ActionRunTask    = "RunTask"
+	ActionRunPut     = "RunPut"
+)
+
+type Decision string
+
+const (
+	DecisionAllow Decision = "allow"
+	DecisionDeny  Decision = "deny"
+	DecisionWarn  Decision = "warn"
+)
+
+//go:generate counterfeiter . Checker
+
+// A Checker asks a policy agent whether an action may be performed.
+type Checker interface {
+	Check(Input) (Result, error)
+}
+
+// Input describes the action being checked. Data holds the fragment of
+// configuration the action applies to, e.g. the pipeline config for
+// SaveConfig or the task's image and privileges for RunTask.
+type Input struct {
+	Service  string      `json:"service"`
+	Action   string      `json:"action"`
+	User     string      `json:"user,omitempty"`
+	Team     string      `json:"team,omitempty"`
+	Pipeline string      `json:"pipeline,omitempty"`
+	Data     interface{} `json:"data,omitempty"`
+}
+
+type Result struct {
+	Decision Decision `json:"decision"`
+	Reasons  []string `json:"reasons,omitempty"`
+}
+
+func (result Result) Denied() bool {
+	return result.Decision == DecisionDeny
+}
+
+func (result Result) Warned() bool {
+	return result.Decision == DecisionWarn
+}
+
+// DeniedError is returned when a policy agent denies an action, so that the
+// reasons end up in front of whoever attempted it.
+type DeniedError struct {
+	Action  string
+	Reasons []string
+}
+
+func (err DeniedError) Error() string {
+	if len(err.Reasons) == 0 {
+		return fmt.Sprintf("policy check denied %s", err.Action)
+	}
+
+	return fmt.Sprintf("policy check denied %s: %s", err.Action, strings.Join(err.Reasons, "; "))
+}
+
+// NoopChecker allows everything. It is used when no policy agent is
+// configured.
+type NoopChecker struct{}
+
+func (NoopChecker) Check(Input) (Result, error) {
+	return Result{Decision: DecisionAllow}, nil
+}
@@ -0,0 +1,18 @@
+package policy_test
+
+import (
+	"github.com/concourse/concourse/atc/policy"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeniedError", func() {
+	It("includes the reasons", func() {
+		err := policy.DeniedError{
+			Action:  policy.ActionSaveConfig,
+			Reasons: []string{"privileged tasks are not allowed", "jobs must not be public"},
+		}
+
+		Expect(err.Error()).To(Equal("policy check denied SaveConfig: privileged tasks are not allowed; jobs must not be public"))
+	})
+})
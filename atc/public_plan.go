@@ -20,6 +20,7 @@ func (plan Plan) Public() *json.RawMessage {
 		Try            *json.RawMessage `json:"try,omitempty"`
 		DependentGet   *json.RawMessage `json:"dependent_get,omitempty"`
 		Timeout        *json.RawMessage `json:"timeout,omitempty"`
+		Lock           *json.RawMessage `json:"lock,omitempty"`
 		Retry          *json.RawMessage `json:"retry,omitempty"`
 		ArtifactInput  *json.RawMessage `json:"artifact_input,omitempty"`
 		ArtifactOutput *json.RawMessage `json:"artifact_output,omitempty"`
@@ -79,6 +80,10 @@ func (plan Plan) Public() *json.RawMessage {
 		public.Timeout = plan.Timeout.Public()
 	}
 
+	if plan.Lock != nil {
+		public.Lock = plan.Lock.Public()
+	}
+
 	if plan.Retry != nil {
 		public.Retry = plan.Retry.Public()
 	}
@@ -244,6 +249,18 @@ func (plan TimeoutPlan) Public() *json.RawMessage {
 	})
 }
 
+func (plan LockPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Step     *json.RawMessage `json:"step"`
+		Name     string           `json:"name"`
+		Capacity int              `json:"capacity,omitempty"`
+	}{
+		Step:     plan.Step.Public(),
+		Name:     plan.Name,
+		Capacity: plan.Capacity,
+	})
+}
+
 func (plan TryPlan) Public() *json.RawMessage {
 	return enc(struct {
 		Step *json.RawMessage `json:"step"`
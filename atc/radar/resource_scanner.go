@@ -28,7 +28,9 @@ type resourceScanner struct {
 	dbPipeline            db.Pipeline
 	externalURL           string
 	variables             creds.Variables
+	sourceDefaults        atc.SourceDefaults
 	strategy              worker.ContainerPlacementStrategy
+	registry              *metric.Registry
 }
 
 func NewResourceScanner(
@@ -40,7 +42,9 @@ func NewResourceScanner(
 	dbPipeline db.Pipeline,
 	externalURL string,
 	variables creds.Variables,
+	sourceDefaults atc.SourceDefaults,
 	strategy worker.ContainerPlacementStrategy,
+	registry *metric.Registry,
 ) Scanner {
 	return &resourceScanner{
 		clock:                 clock,
@@ -51,7 +55,9 @@ func NewResourceScanner(
 		dbPipeline:            dbPipeline,
 		externalURL:           externalURL,
 		variables:             variables,
+		sourceDefaults:        sourceDefaults,
 		strategy:              strategy,
+		registry:              registry,
 	}
 }
 
@@ -157,9 +163,9 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceID int, fromVe
 	versionedResourceTypes := creds.NewVersionedResourceTypes(
 		scanner.variables,
 		resourceTypes.Deserialize(),
-	)
+	).WithSourceDefaults(scanner.sourceDefaults)
 
-	source, err := creds.NewSource(scanner.variables, savedResource.Source()).Evaluate()
+	source, err := creds.NewResourceSource(scanner.variables, savedResource.Type(), savedResource.Source(), scanner.sourceDefaults).Evaluate()
 	if err != nil {
 		logger.Error("failed-to-evaluate-resource-source", err)
 		scanner.setResourceCheckError(logger, savedResource, err)
@@ -368,7 +374,7 @@ func (scanner *resourceScanner) check(
 		ResourceName: savedResource.Name(),
 		TeamName:     scanner.dbPipeline.TeamName(),
 		Success:      err == nil,
-	}.Emit(logger)
+	}.Emit(logger, scanner.registry)
 
 	if err != nil {
 		if rErr, ok := err.(resource.ErrResourceScriptFailed); ok {
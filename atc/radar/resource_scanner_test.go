@@ -130,7 +130,9 @@ var _ = Describe("ResourceScanner", func() {
 			fakeDBPipeline,
 			"https://www.example.com",
 			variables,
+			atc.SourceDefaults{},
 			fakeStrategy,
+			nil,
 		)
 	})
 
@@ -242,7 +244,7 @@ var _ = Describe("ResourceScanner", func() {
 					Expect(resourceSource).To(Equal(atc.Source{"uri": "some-secret-sauce"}))
 					Expect(resourceTypes).To(Equal(creds.NewVersionedResourceTypes(variables, atc.VersionedResourceTypes{
 						versionedResourceType,
-					})))
+					}).WithSourceDefaults(atc.SourceDefaults{})))
 
 					Expect(fakeDBResource.SetCheckSetupErrorCallCount()).To(Equal(1))
 					err := fakeDBResource.SetCheckSetupErrorArgsForCall(0)
@@ -263,7 +265,7 @@ var _ = Describe("ResourceScanner", func() {
 					Expect(workerSpec).To(Equal(worker.WorkerSpec{
 						ResourceType:  "git",
 						Tags:          atc.Tags{"some-tag"},
-						ResourceTypes: creds.NewVersionedResourceTypes(variables, atc.VersionedResourceTypes{versionedResourceType}),
+						ResourceTypes: creds.NewVersionedResourceTypes(variables, atc.VersionedResourceTypes{versionedResourceType}).WithSourceDefaults(atc.SourceDefaults{}),
 						TeamID:        123,
 					}))
 
@@ -285,7 +287,7 @@ var _ = Describe("ResourceScanner", func() {
 					}))
 					Expect(resourceTypes).To(Equal(creds.NewVersionedResourceTypes(variables, atc.VersionedResourceTypes{
 						versionedResourceType,
-					})))
+					}).WithSourceDefaults(atc.SourceDefaults{})))
 				})
 
 				Context("when the resource config has a specified check interval", func() {
@@ -674,7 +676,7 @@ var _ = Describe("ResourceScanner", func() {
 				Expect(resourceSource).To(Equal(atc.Source{"uri": "some-secret-sauce"}))
 				Expect(resourceTypes).To(Equal(creds.NewVersionedResourceTypes(variables, atc.VersionedResourceTypes{
 					versionedResourceType,
-				})))
+				}).WithSourceDefaults(atc.SourceDefaults{})))
 
 				Expect(fakeDBResource.SetCheckSetupErrorCallCount()).To(Equal(1))
 				err := fakeDBResource.SetCheckSetupErrorArgsForCall(0)
@@ -695,7 +697,7 @@ var _ = Describe("ResourceScanner", func() {
 				Expect(workerSpec).To(Equal(worker.WorkerSpec{
 					ResourceType:  "git",
 					Tags:          atc.Tags{"some-tag"},
-					ResourceTypes: creds.NewVersionedResourceTypes(variables, atc.VersionedResourceTypes{versionedResourceType}),
+					ResourceTypes: creds.NewVersionedResourceTypes(variables, atc.VersionedResourceTypes{versionedResourceType}).WithSourceDefaults(atc.SourceDefaults{}),
 					TeamID:        123,
 				}))
 
@@ -716,7 +718,7 @@ var _ = Describe("ResourceScanner", func() {
 				}))
 				Expect(resourceTypes).To(Equal(creds.NewVersionedResourceTypes(variables, atc.VersionedResourceTypes{
 					versionedResourceType,
-				})))
+				}).WithSourceDefaults(atc.SourceDefaults{})))
 			})
 
 			It("grabs an immediate resource checking lock before checking, breaks lock after done", func() {
@@ -23,6 +23,7 @@ type resourceTypeScanner struct {
 	dbPipeline            db.Pipeline
 	externalURL           string
 	variables             creds.Variables
+	sourceDefaults        atc.SourceDefaults
 	strategy              worker.ContainerPlacementStrategy
 }
 
@@ -35,6 +36,7 @@ func NewResourceTypeScanner(
 	dbPipeline db.Pipeline,
 	externalURL string,
 	variables creds.Variables,
+	sourceDefaults atc.SourceDefaults,
 	strategy worker.ContainerPlacementStrategy,
 ) Scanner {
 	return &resourceTypeScanner{
@@ -46,6 +48,7 @@ func NewResourceTypeScanner(
 		dbPipeline:            dbPipeline,
 		externalURL:           externalURL,
 		variables:             variables,
+		sourceDefaults:        sourceDefaults,
 		strategy:              strategy,
 	}
 }
@@ -118,9 +121,9 @@ func (scanner *resourceTypeScanner) scan(logger lager.Logger, resourceTypeID int
 	versionedResourceTypes := creds.NewVersionedResourceTypes(
 		scanner.variables,
 		resourceTypes.Deserialize(),
-	)
+	).WithSourceDefaults(scanner.sourceDefaults)
 
-	source, err := creds.NewSource(scanner.variables, savedResourceType.Source()).Evaluate()
+	source, err := creds.NewResourceSource(scanner.variables, savedResourceType.Type(), savedResourceType.Source(), scanner.sourceDefaults).Evaluate()
 	if err != nil {
 		logger.Error("failed-to-evaluate-resource-type-source", err)
 		scanner.setCheckError(logger, savedResourceType, err)
@@ -108,6 +108,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 			fakeDBPipeline,
 			"https://www.example.com",
 			variables,
+			atc.SourceDefaults{},
 			fakeStrategy,
 		)
 	})
@@ -237,7 +238,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 						Expect(resourceSource).To(Equal(atc.Source{"custom": "some-secret-sauce"}))
 						Expect(resourceTypes).To(Equal(creds.NewVersionedResourceTypes(variables, atc.VersionedResourceTypes{
 							versionedResourceType,
-						})))
+						}).WithSourceDefaults(atc.SourceDefaults{})))
 
 						Expect(fakeResourceType.SetCheckSetupErrorCallCount()).To(Equal(1))
 						err := fakeResourceType.SetCheckSetupErrorArgsForCall(0)
@@ -251,7 +252,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 						Expect(containerSpec.TeamID).To(Equal(123))
 						Expect(workerSpec).To(Equal(worker.WorkerSpec{
 							ResourceType:  "registry-image",
-							ResourceTypes: creds.NewVersionedResourceTypes(variables, atc.VersionedResourceTypes{versionedResourceType}),
+							ResourceTypes: creds.NewVersionedResourceTypes(variables, atc.VersionedResourceTypes{versionedResourceType}).WithSourceDefaults(atc.SourceDefaults{}),
 							TeamID:        123,
 						}))
 
@@ -267,7 +268,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 						Expect(containerSpec.TeamID).To(Equal(123))
 						Expect(resourceTypes).To(Equal(creds.NewVersionedResourceTypes(variables, atc.VersionedResourceTypes{
 							versionedResourceType,
-						})))
+						}).WithSourceDefaults(atc.SourceDefaults{})))
 					})
 				})
 
@@ -601,7 +602,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 					Expect(resourceSource).To(Equal(atc.Source{"custom": "some-secret-sauce"}))
 					Expect(resourceTypes).To(Equal(creds.NewVersionedResourceTypes(variables, atc.VersionedResourceTypes{
 						versionedResourceType,
-					})))
+					}).WithSourceDefaults(atc.SourceDefaults{})))
 
 					_, owner, containerSpec, workerSpec, _ := fakePool.FindOrChooseWorkerForContainerArgsForCall(0)
 					Expect(owner).To(Equal(db.NewResourceConfigCheckSessionContainerOwner(fakeResourceConfig, ContainerExpiries)))
@@ -611,7 +612,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 					Expect(containerSpec.TeamID).To(Equal(123))
 					Expect(workerSpec).To(Equal(worker.WorkerSpec{
 						ResourceType:  "registry-image",
-						ResourceTypes: creds.NewVersionedResourceTypes(variables, atc.VersionedResourceTypes{versionedResourceType}),
+						ResourceTypes: creds.NewVersionedResourceTypes(variables, atc.VersionedResourceTypes{versionedResourceType}).WithSourceDefaults(atc.SourceDefaults{}),
 						TeamID:        123,
 					}))
 
@@ -627,7 +628,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 					Expect(containerSpec.TeamID).To(Equal(123))
 					Expect(resourceTypes).To(Equal(creds.NewVersionedResourceTypes(variables, atc.VersionedResourceTypes{
 						versionedResourceType,
-					})))
+					}).WithSourceDefaults(atc.SourceDefaults{})))
 				})
 			})
 
@@ -3,8 +3,10 @@ package radar
 import (
 	"time"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/worker"
 
@@ -36,8 +38,10 @@ func NewScanRunnerFactory(
 	clock clock.Clock,
 	externalURL string,
 	variables creds.Variables,
+	sourceDefaults atc.SourceDefaults,
 	strategy worker.ContainerPlacementStrategy,
 	notifications Notifications,
+	registry *metric.Registry,
 ) ScanRunnerFactory {
 	resourceTypeScanner := NewResourceTypeScanner(
 		clock,
@@ -48,6 +52,7 @@ func NewScanRunnerFactory(
 		dbPipeline,
 		externalURL,
 		variables,
+		sourceDefaults,
 		strategy,
 	)
 
@@ -60,7 +65,9 @@ func NewScanRunnerFactory(
 		dbPipeline,
 		externalURL,
 		variables,
+		sourceDefaults,
 		strategy,
+		registry,
 	)
 	return &scanRunnerFactory{
 		clock:               clock,
@@ -4,8 +4,10 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/clock"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/worker"
 )
@@ -27,7 +29,9 @@ type scannerFactory struct {
 	resourceCheckingInterval     time.Duration
 	externalURL                  string
 	secretManager                creds.Secrets
+	sourceDefaults               atc.SourceDefaults
 	strategy                     worker.ContainerPlacementStrategy
+	registry                     *metric.Registry
 }
 
 var ContainerExpiries = db.ContainerOwnerExpiries{
@@ -44,7 +48,9 @@ func NewScannerFactory(
 	resourceCheckingInterval time.Duration,
 	externalURL string,
 	secretManager creds.Secrets,
+	sourceDefaults atc.SourceDefaults,
 	strategy worker.ContainerPlacementStrategy,
+	registry *metric.Registry,
 ) ScannerFactory {
 	return &scannerFactory{
 		pool:                         pool,
@@ -54,7 +60,9 @@ func NewScannerFactory(
 		resourceTypeCheckingInterval: resourceTypeCheckingInterval,
 		externalURL:                  externalURL,
 		secretManager:                secretManager,
+		sourceDefaults:               sourceDefaults,
 		strategy:                     strategy,
+		registry:                     registry,
 	}
 }
 
@@ -70,7 +78,9 @@ func (f *scannerFactory) NewResourceScanner(dbPipeline db.Pipeline) Scanner {
 		dbPipeline,
 		f.externalURL,
 		variables,
+		f.sourceDefaults,
 		f.strategy,
+		f.registry,
 	)
 }
 
@@ -86,6 +96,7 @@ func (f *scannerFactory) NewResourceTypeScanner(dbPipeline db.Pipeline) Scanner
 		dbPipeline,
 		f.externalURL,
 		variables,
+		f.sourceDefaults,
 		f.strategy,
 	)
 }
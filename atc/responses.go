@@ -9,6 +9,14 @@ type SaveConfigResponse struct {
 	Warnings []ConfigWarning `json:"warnings,omitempty"`
 }
 
+// SetTeamResponse is the team as saved, along with any warnings about its
+// config, e.g. from the policy check.
+type SetTeamResponse struct {
+	Team
+
+	Warnings []ConfigWarning `json:"warnings,omitempty"`
+}
+
 type ConfigResponse struct {
 	Config Config `json:"config"`
 }
@@ -3,11 +3,15 @@ package atc
 import "github.com/tedsuo/rata"
 
 const (
-	SaveConfig = "SaveConfig"
-	GetConfig  = "GetConfig"
+	SaveConfig         = "SaveConfig"
+	GetConfig          = "GetConfig"
+	ListConfigVersions = "ListConfigVersions"
+	GetConfigVersion   = "GetConfigVersion"
+	DiffConfigVersions = "DiffConfigVersions"
 
 	GetBuild            = "GetBuild"
 	GetBuildPlan        = "GetBuildPlan"
+	GetBuildTimeline    = "GetBuildTimeline"
 	CreateBuild         = "CreateBuild"
 	ListBuilds          = "ListBuilds"
 	BuildEvents         = "BuildEvents"
@@ -65,13 +69,14 @@ const (
 	CreatePipelineBuild = "CreatePipelineBuild"
 	PipelineBadge       = "PipelineBadge"
 
-	RegisterWorker  = "RegisterWorker"
-	LandWorker      = "LandWorker"
-	RetireWorker    = "RetireWorker"
-	PruneWorker     = "PruneWorker"
-	HeartbeatWorker = "HeartbeatWorker"
-	ListWorkers     = "ListWorkers"
-	DeleteWorker    = "DeleteWorker"
+	RegisterWorker     = "RegisterWorker"
+	LandWorker         = "LandWorker"
+	RetireWorker       = "RetireWorker"
+	UnquarantineWorker = "UnquarantineWorker"
+	PruneWorker        = "PruneWorker"
+	HeartbeatWorker    = "HeartbeatWorker"
+	ListWorkers        = "ListWorkers"
+	DeleteWorker       = "DeleteWorker"
 
 	SetLogLevel = "SetLogLevel"
 	GetLogLevel = "GetLogLevel"
@@ -80,6 +85,14 @@ const (
 	GetInfo      = "Info"
 	GetInfoCreds = "InfoCreds"
 
+	ListLocalUsers       = "ListLocalUsers"
+	CreateLocalUser      = "CreateLocalUser"
+	SetLocalUserPassword = "SetLocalUserPassword"
+	DisableLocalUser     = "DisableLocalUser"
+	EnableLocalUser      = "EnableLocalUser"
+	ListAuditEvents      = "ListAuditEvents"
+	ListTeamAuditEvents  = "ListTeamAuditEvents"
+
 	ListContainers           = "ListContainers"
 	GetContainer             = "GetContainer"
 	HijackContainer          = "HijackContainer"
@@ -100,22 +113,50 @@ const (
 	CreateArtifact     = "CreateArtifact"
 	GetArtifact        = "GetArtifact"
 	ListBuildArtifacts = "ListBuildArtifacts"
+
+	CreateAPIToken = "CreateAPIToken"
+	ListAPITokens  = "ListAPITokens"
+	RevokeAPIToken = "RevokeAPIToken"
+
+	ListWorkerKeys      = "ListWorkerKeys"
+	CreateWorkerKey     = "CreateWorkerKey"
+	DeleteWorkerKey     = "DeleteWorkerKey"
+	ListTeamWorkerKeys  = "ListTeamWorkerKeys"
+	CreateTeamWorkerKey = "CreateTeamWorkerKey"
+	DeleteTeamWorkerKey = "DeleteTeamWorkerKey"
+
+	StatusEvents = "StatusEvents"
+
+	ListNamedLocks   = "ListNamedLocks"
+	ReleaseNamedLock = "ReleaseNamedLock"
+
+	ListHijackSessions        = "ListHijackSessions"
+	GetHijackSessionRecording = "GetHijackSessionRecording"
 )
 
 const (
 	ClearTaskCacheQueryPath = "cache_path"
 	SaveConfigCheckCreds    = "check_creds"
+	DiffConfigFrom          = "from"
+	DiffConfigTo            = "to"
+	StatusEventsTeam        = "team"
+	StatusEventsPipeline    = "pipeline"
+	StatusEventsJob         = "job"
 )
 
 var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "PUT", Name: SaveConfig},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "GET", Name: GetConfig},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/versions", Method: "GET", Name: ListConfigVersions},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/versions/:config_version", Method: "GET", Name: GetConfigVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/diff", Method: "GET", Name: DiffConfigVersions},
 
 	{Path: "/api/v1/teams/:team_name/builds", Method: "POST", Name: CreateBuild},
 
 	{Path: "/api/v1/builds", Method: "GET", Name: ListBuilds},
 	{Path: "/api/v1/builds/:build_id", Method: "GET", Name: GetBuild},
 	{Path: "/api/v1/builds/:build_id/plan", Method: "GET", Name: GetBuildPlan},
+	{Path: "/api/v1/builds/:build_id/timeline", Method: "GET", Name: GetBuildTimeline},
 	{Path: "/api/v1/builds/:build_id/events", Method: "GET", Name: BuildEvents},
 	{Path: "/api/v1/builds/:build_id/resources", Method: "GET", Name: BuildResources},
 	{Path: "/api/v1/builds/:build_id/abort", Method: "PUT", Name: AbortBuild},
@@ -176,6 +217,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/workers", Method: "POST", Name: RegisterWorker},
 	{Path: "/api/v1/workers/:worker_name/land", Method: "PUT", Name: LandWorker},
 	{Path: "/api/v1/workers/:worker_name/retire", Method: "PUT", Name: RetireWorker},
+	{Path: "/api/v1/workers/:worker_name/unquarantine", Method: "PUT", Name: UnquarantineWorker},
 	{Path: "/api/v1/workers/:worker_name/prune", Method: "PUT", Name: PruneWorker},
 	{Path: "/api/v1/workers/:worker_name/heartbeat", Method: "PUT", Name: HeartbeatWorker},
 	{Path: "/api/v1/workers/:worker_name", Method: "DELETE", Name: DeleteWorker},
@@ -187,6 +229,14 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/info", Method: "GET", Name: GetInfo},
 	{Path: "/api/v1/info/creds", Method: "GET", Name: GetInfoCreds},
 
+	{Path: "/api/v1/users/local", Method: "GET", Name: ListLocalUsers},
+	{Path: "/api/v1/users/local", Method: "POST", Name: CreateLocalUser},
+	{Path: "/api/v1/users/local/:username/password", Method: "PUT", Name: SetLocalUserPassword},
+	{Path: "/api/v1/users/local/:username/disable", Method: "PUT", Name: DisableLocalUser},
+	{Path: "/api/v1/users/local/:username/enable", Method: "PUT", Name: EnableLocalUser},
+	{Path: "/api/v1/audit-events", Method: "GET", Name: ListAuditEvents},
+	{Path: "/api/v1/teams/:team_name/audit-events", Method: "GET", Name: ListTeamAuditEvents},
+
 	{Path: "/api/v1/containers/destroying", Method: "GET", Name: ListDestroyingContainers},
 	{Path: "/api/v1/containers/report", Method: "PUT", Name: ReportWorkerContainers},
 	{Path: "/api/v1/teams/:team_name/containers", Method: "GET", Name: ListContainers},
@@ -206,4 +256,23 @@ var Routes = rata.Routes([]rata.Route{
 
 	{Path: "/api/v1/teams/:team_name/artifacts", Method: "POST", Name: CreateArtifact},
 	{Path: "/api/v1/teams/:team_name/artifacts/:artifact_id", Method: "GET", Name: GetArtifact},
+
+	{Path: "/api/v1/teams/:team_name/tokens", Method: "POST", Name: CreateAPIToken},
+	{Path: "/api/v1/teams/:team_name/tokens", Method: "GET", Name: ListAPITokens},
+	{Path: "/api/v1/teams/:team_name/tokens/:token_name", Method: "DELETE", Name: RevokeAPIToken},
+
+	{Path: "/api/v1/worker_keys", Method: "GET", Name: ListWorkerKeys},
+	{Path: "/api/v1/worker_keys", Method: "POST", Name: CreateWorkerKey},
+	{Path: "/api/v1/worker_keys/:key_name", Method: "DELETE", Name: DeleteWorkerKey},
+	{Path: "/api/v1/teams/:team_name/worker_keys", Method: "GET", Name: ListTeamWorkerKeys},
+	{Path: "/api/v1/teams/:team_name/worker_keys", Method: "POST", Name: CreateTeamWorkerKey},
+	{Path: "/api/v1/teams/:team_name/worker_keys/:key_name", Method: "DELETE", Name: DeleteTeamWorkerKey},
+
+	{Path: "/api/v1/events", Method: "GET", Name: StatusEvents},
+
+	{Path: "/api/v1/teams/:team_name/locks", Method: "GET", Name: ListNamedLocks},
+	{Path: "/api/v1/teams/:team_name/locks/:lock_name/release", Method: "PUT", Name: ReleaseNamedLock},
+
+	{Path: "/api/v1/teams/:team_name/hijack-sessions", Method: "GET", Name: ListHijackSessions},
+	{Path: "/api/v1/teams/:team_name/hijack-sessions/:session_id/recording", Method: "GET", Name: GetHijackSessionRecording},
 })
@@ -4,6 +4,8 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/lock"
+	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/scheduler/inputmapper"
 	"github.com/concourse/concourse/atc/scheduler/maxinflight"
 )
@@ -28,23 +30,29 @@ type BuildFactory interface {
 
 func NewBuildStarter(
 	pipeline db.Pipeline,
+	team db.Team,
 	maxInFlightUpdater maxinflight.Updater,
 	factory BuildFactory,
 	inputMapper inputmapper.InputMapper,
+	registry *metric.Registry,
 ) BuildStarter {
 	return &buildStarter{
 		pipeline:           pipeline,
+		team:               team,
 		maxInFlightUpdater: maxInFlightUpdater,
 		factory:            factory,
 		inputMapper:        inputMapper,
+		registry:           registry,
 	}
 }
 
 type buildStarter struct {
 	pipeline           db.Pipeline
+	team               db.Team
 	maxInFlightUpdater maxinflight.Updater
 	factory            BuildFactory
 	inputMapper        inputmapper.InputMapper
+	registry           *metric.Registry
 }
 
 func (s *buildStarter) TryStartPendingBuildsForJob(
@@ -146,6 +154,17 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return false, nil
 	}
 
+	quotaLock, quotaReached, err := s.reserveTeamBuildQuota(logger)
+	if err != nil {
+		return false, err
+	}
+	if quotaReached {
+		return false, nil
+	}
+	if quotaLock != nil {
+		defer quotaLock.Release()
+	}
+
 	updated, err := nextPendingBuild.Schedule()
 	if err != nil {
 		logger.Error("failed-to-update-build-to-scheduled", err)
@@ -196,3 +215,60 @@ func (s *buildStarter) tryStartNextPendingBuild(
 
 	return true, nil
 }
+
+// reserveTeamBuildQuota checks whether the team already runs as many builds as
+// its quota allows, in which case the pending build is held until one of them
+// finishes. If the team has a quota, the returned lock must be held until the
+// build is started, so that no other build takes the same slot in the
+// meantime.
+func (s *buildStarter) reserveTeamBuildQuota(logger lager.Logger) (lock.Lock, bool, error) {
+	quotaUsage, err := s.team.QuotaUsage()
+	if err != nil {
+		logger.Error("failed-to-get-team-quota-usage", err)
+		return nil, false, err
+	}
+
+	if quotaUsage.Quota.MaxRunningBuilds == 0 {
+		return nil, false, nil
+	}
+
+	quotaLock, acquired, err := s.team.AcquireBuildQuotaLock(logger)
+	if err != nil {
+		logger.Error("failed-to-acquire-team-build-quota-lock", err)
+		return nil, false, err
+	}
+
+	if !acquired {
+		logger.Debug("team-build-quota-lock-held")
+		return nil, true, nil
+	}
+
+	// count again now that nobody else can start builds of the team
+	quotaUsage, err = s.team.QuotaUsage()
+	if err != nil {
+		logger.Error("failed-to-get-team-quota-usage", err)
+		quotaLock.Release()
+		return nil, false, err
+	}
+
+	limit := quotaUsage.Quota.MaxRunningBuilds
+
+	metric.TeamQuotaSaturation{
+		TeamName: quotaUsage.TeamName,
+		Resource: "builds",
+		Used:     quotaUsage.Usage.RunningBuilds,
+		Limit:    limit,
+	}.Emit(logger, s.registry)
+
+	if limit != 0 && quotaUsage.Usage.RunningBuilds >= limit {
+		logger.Debug("team-build-quota-reached", lager.Data{
+			"running-builds":     quotaUsage.Usage.RunningBuilds,
+			"max-running-builds": limit,
+		})
+
+		quotaLock.Release()
+		return nil, true, nil
+	}
+
+	return quotaLock, false, nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/algorithm"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/db/lock/lockfakes"
 	"github.com/concourse/concourse/atc/scheduler"
 	"github.com/concourse/concourse/atc/scheduler/inputmapper/inputmapperfakes"
 	"github.com/concourse/concourse/atc/scheduler/maxinflight/maxinflightfakes"
@@ -22,6 +23,7 @@ import (
 var _ = Describe("BuildStarter", func() {
 	var (
 		fakePipeline    *dbfakes.FakePipeline
+		fakeTeam        *dbfakes.FakeTeam
 		fakeUpdater     *maxinflightfakes.FakeUpdater
 		fakeFactory     *schedulerfakes.FakeBuildFactory
 		pendingBuilds   []db.Build
@@ -34,11 +36,12 @@ var _ = Describe("BuildStarter", func() {
 
 	BeforeEach(func() {
 		fakePipeline = new(dbfakes.FakePipeline)
+		fakeTeam = new(dbfakes.FakeTeam)
 		fakeUpdater = new(maxinflightfakes.FakeUpdater)
 		fakeFactory = new(schedulerfakes.FakeBuildFactory)
 		fakeInputMapper = new(inputmapperfakes.FakeInputMapper)
 
-		buildStarter = scheduler.NewBuildStarter(fakePipeline, fakeUpdater, fakeFactory, fakeInputMapper)
+		buildStarter = scheduler.NewBuildStarter(fakePipeline, fakeTeam, fakeUpdater, fakeFactory, fakeInputMapper, nil)
 
 		disaster = errors.New("bad thing")
 	})
@@ -544,6 +547,35 @@ var _ = Describe("BuildStarter", func() {
 										Expect(pendingBuild3.StartCallCount()).To(Equal(1))
 										Expect(pendingBuild3.StartArgsForCall(0)).To(Equal(atc.Plan{Task: &atc.TaskPlan{ConfigPath: "some-task-1.yml"}}))
 									})
+
+									Context("when the team has a build quota", func() {
+										var (
+											fakeQuotaLock     *lockfakes.FakeLock
+											startedAtReleases []int
+										)
+
+										BeforeEach(func() {
+											fakeTeam.QuotaUsageReturns(db.TeamQuotaUsage{
+												Quota: atc.TeamQuota{MaxRunningBuilds: 5},
+											}, nil)
+
+											startedAtReleases = nil
+
+											fakeQuotaLock = new(lockfakes.FakeLock)
+											fakeQuotaLock.ReleaseStub = func() error {
+												started := pendingBuild1.StartCallCount() + pendingBuild2.StartCallCount() + pendingBuild3.StartCallCount()
+												startedAtReleases = append(startedAtReleases, started)
+												return nil
+											}
+
+											fakeTeam.AcquireBuildQuotaLockReturns(fakeQuotaLock, true, nil)
+										})
+
+										It("holds the team's build quota lock until each build is started", func() {
+											Expect(fakeTeam.AcquireBuildQuotaLockCallCount()).To(Equal(3))
+											Expect(startedAtReleases).To(Equal([]int{1, 2, 3}))
+										})
+									})
 								})
 							})
 						})
@@ -610,6 +642,68 @@ var _ = Describe("BuildStarter", func() {
 						itDoesntReturnAnErrorOrMarkTheBuildAsScheduled()
 						itUpdatedMaxInFlightForTheFirstBuild()
 					})
+
+					Context("when getting the team's quota usage fails", func() {
+						BeforeEach(func() {
+							fakeTeam.QuotaUsageReturns(db.TeamQuotaUsage{}, disaster)
+						})
+
+						itReturnsTheError()
+						itUpdatedMaxInFlightForTheFirstBuild()
+					})
+
+					Context("when the team runs as many builds as its quota allows", func() {
+						BeforeEach(func() {
+							fakeTeam.QuotaUsageReturns(db.TeamQuotaUsage{
+								Quota: atc.TeamQuota{MaxRunningBuilds: 2},
+								Usage: atc.TeamUsage{RunningBuilds: 2},
+							}, nil)
+
+							fakeTeam.AcquireBuildQuotaLockReturns(new(lockfakes.FakeLock), true, nil)
+						})
+
+						itDoesntReturnAnErrorOrMarkTheBuildAsScheduled()
+						itUpdatedMaxInFlightForTheFirstBuild()
+					})
+
+					Context("when another scheduler holds the team's build quota lock", func() {
+						BeforeEach(func() {
+							fakeTeam.QuotaUsageReturns(db.TeamQuotaUsage{
+								Quota: atc.TeamQuota{MaxRunningBuilds: 2},
+								Usage: atc.TeamUsage{RunningBuilds: 1},
+							}, nil)
+
+							fakeTeam.AcquireBuildQuotaLockReturns(nil, false, nil)
+						})
+
+						itDoesntReturnAnErrorOrMarkTheBuildAsScheduled()
+						itUpdatedMaxInFlightForTheFirstBuild()
+					})
+
+					Context("when the team runs as many builds as its quota allows once the lock is held", func() {
+						var fakeQuotaLock *lockfakes.FakeLock
+
+						BeforeEach(func() {
+							fakeTeam.QuotaUsageReturnsOnCall(0, db.TeamQuotaUsage{
+								Quota: atc.TeamQuota{MaxRunningBuilds: 2},
+								Usage: atc.TeamUsage{RunningBuilds: 1},
+							}, nil)
+							fakeTeam.QuotaUsageReturnsOnCall(1, db.TeamQuotaUsage{
+								Quota: atc.TeamQuota{MaxRunningBuilds: 2},
+								Usage: atc.TeamUsage{RunningBuilds: 2},
+							}, nil)
+
+							fakeQuotaLock = new(lockfakes.FakeLock)
+							fakeTeam.AcquireBuildQuotaLockReturns(fakeQuotaLock, true, nil)
+						})
+
+						itDoesntReturnAnErrorOrMarkTheBuildAsScheduled()
+						itUpdatedMaxInFlightForTheFirstBuild()
+
+						It("releases the lock", func() {
+							Expect(fakeQuotaLock.ReleaseCallCount()).To(Equal(1))
+						})
+					})
 				})
 			})
 		})
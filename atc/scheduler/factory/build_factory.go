@@ -38,13 +38,26 @@ func (factory *buildFactory) Create(
 		return atc.Plan{}, err
 	}
 
-	return factory.applyHooks(constructionParams{
+	plan, err = factory.applyHooks(constructionParams{
 		plan:          plan,
 		hooks:         job.Hooks(),
 		resources:     resources,
 		resourceTypes: resourceTypes,
 		inputs:        inputs,
 	})
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	if job.Lock != "" {
+		plan = factory.planFactory.NewPlan(atc.LockPlan{
+			Name:     job.Lock,
+			Capacity: job.LockCapacity,
+			Step:     plan,
+		})
+	}
+
+	return plan, nil
 }
 
 func (factory *buildFactory) constructPlanFromJob(
@@ -241,6 +254,8 @@ func (factory *buildFactory) constructUnhookedPlan(
 			InputMapping:      planConfig.InputMapping,
 			OutputMapping:     planConfig.OutputMapping,
 			ImageArtifactName: planConfig.ImageArtifactName,
+			RetainOutputs:     planConfig.RetainOutputs,
+			CacheResult:       planConfig.CacheResult,
 
 			VersionedResourceTypes: resourceTypes,
 		})
@@ -309,6 +324,14 @@ func (factory *buildFactory) constructUnhookedPlan(
 		})
 	}
 
+	if planConfig.Lock != "" {
+		plan = factory.planFactory.NewPlan(atc.LockPlan{
+			Name:     planConfig.Lock,
+			Capacity: planConfig.LockCapacity,
+			Step:     plan,
+		})
+	}
+
 	return plan, nil
 }
 
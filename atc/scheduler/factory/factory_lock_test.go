@@ -0,0 +1,125 @@
+package factory_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/scheduler/factory"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory Lock Step", func() {
+	var (
+		resourceTypes atc.VersionedResourceTypes
+
+		buildFactory        factory.BuildFactory
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(321)
+		expectedPlanFactory = atc.NewPlanFactory(321)
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+
+		resourceTypes = atc.VersionedResourceTypes{
+			{
+				ResourceType: atc.ResourceType{
+					Name:   "some-custom-resource",
+					Type:   "registry-image",
+					Source: atc.Source{"some": "custom-source"},
+				},
+				Version: atc.Version{"some": "version"},
+			},
+		}
+	})
+
+	Context("When there is a task with a lock", func() {
+		It("builds correctly", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task:         "first task",
+						Lock:         "some-lock",
+						LockCapacity: 2,
+					},
+				},
+			}, nil, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.LockPlan{
+				Name:     "some-lock",
+				Capacity: 2,
+				Step: expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:                   "first task",
+					VersionedResourceTypes: resourceTypes,
+				}),
+			})
+
+			Expect(actual).To(Equal(expected))
+		})
+
+		Context("with a timeout", func() {
+			It("does not count waiting for the lock towards the timeout", func() {
+				actual, err := buildFactory.Create(atc.JobConfig{
+					Plan: atc.PlanSequence{
+						{
+							Task:    "first task",
+							Lock:    "some-lock",
+							Timeout: "10s",
+						},
+					},
+				}, nil, resourceTypes, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				expected := expectedPlanFactory.NewPlan(atc.LockPlan{
+					Name: "some-lock",
+					Step: expectedPlanFactory.NewPlan(atc.TimeoutPlan{
+						Duration: "10s",
+						Step: expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:                   "first task",
+							VersionedResourceTypes: resourceTypes,
+						}),
+					}),
+				})
+
+				Expect(actual).To(Equal(expected))
+			})
+		})
+	})
+
+	Context("When the job has a lock", func() {
+		It("holds the lock around the whole plan, including hooks", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Lock:         "some-lock",
+				LockCapacity: 2,
+				Plan: atc.PlanSequence{
+					{
+						Task: "first task",
+					},
+				},
+				Ensure: &atc.PlanConfig{
+					Task: "cleanup task",
+				},
+			}, nil, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.LockPlan{
+				Name:     "some-lock",
+				Capacity: 2,
+				Step: expectedPlanFactory.NewPlan(atc.EnsurePlan{
+					Step: expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:                   "first task",
+						VersionedResourceTypes: resourceTypes,
+					}),
+					Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:                   "cleanup task",
+						VersionedResourceTypes: resourceTypes,
+					}),
+				}),
+			})
+
+			Expect(actual).To(Equal(expected))
+		})
+	})
+})
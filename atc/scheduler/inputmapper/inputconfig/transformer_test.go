@@ -106,6 +106,33 @@ var _ = Describe("Transformer", func() {
 				})
 			})
 
+			Context("when an input has a version filter", func() {
+				BeforeEach(func() {
+					jobInputs = []atc.JobInput{{
+						Name:     "job-input-1",
+						Resource: "r1",
+						Version:  &atc.VersionConfig{Latest: true, Filter: "semver(tag) >=1.4 <2"},
+					}}
+				})
+
+				It("parses the filter", func() {
+					Expect(tranformErr).NotTo(HaveOccurred())
+					Expect(algorithmInputs).To(HaveLen(1))
+					Expect(algorithmInputs[0].VersionFilter).NotTo(BeNil())
+					Expect(algorithmInputs[0].VersionFilter.Expression).To(Equal("semver(tag) >=1.4 <2"))
+				})
+
+				Context("when the filter is malformed", func() {
+					BeforeEach(func() {
+						jobInputs[0].Version.Filter = "semver(tag) >=banana"
+					})
+
+					It("returns an error", func() {
+						Expect(tranformErr).To(HaveOccurred())
+					})
+				})
+			})
+
 			Context("when an input has a pinned version", func() {
 				BeforeEach(func() {
 					jobInputs = []atc.JobInput{
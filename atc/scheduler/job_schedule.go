@@ -0,0 +1,85 @@
+package scheduler
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// MissedScheduleTolerance is how late a slot may be noticed before it is
+// considered missed rather than just due.
+const MissedScheduleTolerance = time.Minute
+
+// MaxCatchUpBuilds bounds the number of builds created at once for a job
+// whose schedule catches up on missed runs.
+const MaxCatchUpBuilds = 10
+
+func (s *Scheduler) triggerScheduledBuilds(logger lager.Logger, job db.Job) error {
+	config := job.Config().Schedule
+	if config == nil {
+		return nil
+	}
+
+	logger = logger.Session("trigger-scheduled-builds", lager.Data{"job": job.Name()})
+
+	schedule, err := atc.ParseJobSchedule(*config)
+	if err != nil {
+		// validated when the pipeline is configured
+		logger.Error("invalid-schedule", err)
+		return nil
+	}
+
+	now := s.Clock.Now()
+
+	firedAt := job.ScheduleFiredAt()
+	if firedAt.IsZero() {
+		// start counting from now rather than firing for the past
+		return job.SetScheduleFiredAt(now)
+	}
+
+	due := []time.Time{}
+	for slot := schedule.Next(firedAt); !slot.IsZero(); slot = schedule.Next(slot) {
+		if schedule.FireTime(job.ID(), slot).After(now) {
+			break
+		}
+
+		due = append(due, slot)
+		if len(due) > MaxCatchUpBuilds {
+			due = due[1:]
+		}
+	}
+
+	if len(due) == 0 {
+		return nil
+	}
+
+	latest := due[len(due)-1]
+
+	if job.Paused() {
+		logger.Debug("skipping-paused-job", lager.Data{"slot": latest})
+		return job.SetScheduleFiredAt(latest)
+	}
+
+	if !schedule.CatchUp {
+		if now.Sub(schedule.FireTime(job.ID(), latest)) > MissedScheduleTolerance {
+			logger.Info("skipping-missed-slot", lager.Data{"slot": latest})
+			return job.SetScheduleFiredAt(latest)
+		}
+
+		due = due[len(due)-1:]
+	}
+
+	for _, slot := range due {
+		build, err := job.CreateScheduledBuild(slot)
+		if err != nil {
+			logger.Error("failed-to-create-scheduled-build", err, lager.Data{"slot": slot})
+			return err
+		}
+
+		logger.Info("created-scheduled-build", lager.Data{"slot": slot, "build": build.Name()})
+	}
+
+	return nil
+}
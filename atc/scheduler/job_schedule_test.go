@@ -0,0 +1,206 @@
+package scheduler_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/algorithm"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/concourse/concourse/atc/scheduler"
+	"github.com/concourse/concourse/atc/scheduler/inputmapper/inputmapperfakes"
+	"github.com/concourse/concourse/atc/scheduler/schedulerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Scheduled builds", func() {
+	var (
+		fakePipeline     *dbfakes.FakePipeline
+		fakeInputMapper  *inputmapperfakes.FakeInputMapper
+		fakeBuildStarter *schedulerfakes.FakeBuildStarter
+		fakeClock        *fakeclock.FakeClock
+		fakeJob          *dbfakes.FakeJob
+
+		scheduleConfig *atc.ScheduleConfig
+
+		scheduler   *Scheduler
+		scheduleErr error
+	)
+
+	BeforeEach(func() {
+		fakePipeline = new(dbfakes.FakePipeline)
+		fakeInputMapper = new(inputmapperfakes.FakeInputMapper)
+		fakeBuildStarter = new(schedulerfakes.FakeBuildStarter)
+		fakeClock = fakeclock.NewFakeClock(time.Date(2019, 5, 10, 12, 0, 30, 0, time.UTC))
+
+		fakeInputMapper.SaveNextInputMappingReturns(algorithm.InputMapping{}, nil)
+		fakePipeline.GetAllPendingBuildsReturns(map[string][]db.Build{}, nil)
+
+		scheduleConfig = &atc.ScheduleConfig{Cron: "0 * * * *"}
+
+		fakeJob = new(dbfakes.FakeJob)
+		fakeJob.IDReturns(1)
+		fakeJob.NameReturns("some-job")
+		fakeJob.CreateScheduledBuildReturns(new(dbfakes.FakeBuild), nil)
+
+		scheduler = &Scheduler{
+			Pipeline:     fakePipeline,
+			InputMapper:  fakeInputMapper,
+			BuildStarter: fakeBuildStarter,
+			Clock:        fakeClock,
+		}
+	})
+
+	JustBeforeEach(func() {
+		fakeJob.ConfigReturns(atc.JobConfig{Name: "some-job", Schedule: scheduleConfig})
+
+		_, scheduleErr = scheduler.Schedule(
+			lagertest.NewTestLogger("test"),
+			&algorithm.VersionsDB{},
+			[]db.Job{fakeJob},
+			db.Resources{},
+			atc.VersionedResourceTypes{},
+		)
+	})
+
+	Context("when the job has no schedule", func() {
+		BeforeEach(func() {
+			scheduleConfig = nil
+		})
+
+		It("does not touch the schedule", func() {
+			Expect(scheduleErr).NotTo(HaveOccurred())
+			Expect(fakeJob.SetScheduleFiredAtCallCount()).To(BeZero())
+			Expect(fakeJob.CreateScheduledBuildCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the schedule has never fired", func() {
+		It("records the current time without creating a build", func() {
+			Expect(fakeJob.SetScheduleFiredAtCallCount()).To(Equal(1))
+			Expect(fakeJob.SetScheduleFiredAtArgsForCall(0)).To(Equal(fakeClock.Now()))
+			Expect(fakeJob.CreateScheduledBuildCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when no slot is due yet", func() {
+		BeforeEach(func() {
+			fakeJob.ScheduleFiredAtReturns(time.Date(2019, 5, 10, 12, 0, 0, 0, time.UTC))
+		})
+
+		It("does nothing", func() {
+			Expect(fakeJob.SetScheduleFiredAtCallCount()).To(BeZero())
+			Expect(fakeJob.CreateScheduledBuildCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when a slot has just become due", func() {
+		BeforeEach(func() {
+			fakeJob.ScheduleFiredAtReturns(time.Date(2019, 5, 10, 11, 0, 0, 0, time.UTC))
+		})
+
+		It("creates a build for the slot", func() {
+			Expect(scheduleErr).NotTo(HaveOccurred())
+			Expect(fakeJob.CreateScheduledBuildCallCount()).To(Equal(1))
+			Expect(fakeJob.CreateScheduledBuildArgsForCall(0)).To(Equal(time.Date(2019, 5, 10, 12, 0, 0, 0, time.UTC)))
+		})
+
+		Context("when the job is paused", func() {
+			BeforeEach(func() {
+				fakeJob.PausedReturns(true)
+			})
+
+			It("skips the slot without creating a build", func() {
+				Expect(fakeJob.CreateScheduledBuildCallCount()).To(BeZero())
+				Expect(fakeJob.SetScheduleFiredAtCallCount()).To(Equal(1))
+				Expect(fakeJob.SetScheduleFiredAtArgsForCall(0)).To(Equal(time.Date(2019, 5, 10, 12, 0, 0, 0, time.UTC)))
+			})
+		})
+
+		Context("when the schedule has a jitter", func() {
+			var fireTime time.Time
+
+			BeforeEach(func() {
+				scheduleConfig.Jitter = "10m"
+
+				schedule, err := atc.ParseJobSchedule(*scheduleConfig)
+				Expect(err).NotTo(HaveOccurred())
+
+				fireTime = schedule.FireTime(1, time.Date(2019, 5, 10, 12, 0, 0, 0, time.UTC))
+			})
+
+			Context("before the jittered fire time", func() {
+				BeforeEach(func() {
+					fakeClock.Increment(fireTime.Sub(fakeClock.Now()) - time.Second)
+				})
+
+				It("does not create a build yet", func() {
+					Expect(fakeJob.CreateScheduledBuildCallCount()).To(BeZero())
+				})
+			})
+
+			Context("at the jittered fire time", func() {
+				BeforeEach(func() {
+					fakeClock.Increment(fireTime.Sub(fakeClock.Now()))
+				})
+
+				It("creates the build", func() {
+					Expect(fakeJob.CreateScheduledBuildCallCount()).To(Equal(1))
+				})
+			})
+		})
+
+		Context("when creating the build fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeJob.CreateScheduledBuildReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(scheduleErr).To(Equal(disaster))
+			})
+		})
+	})
+
+	Context("when slots were missed", func() {
+		BeforeEach(func() {
+			fakeJob.ScheduleFiredAtReturns(time.Date(2019, 5, 10, 8, 30, 0, 0, time.UTC))
+			fakeClock.Increment(10 * time.Minute)
+		})
+
+		It("skips them by default", func() {
+			Expect(fakeJob.CreateScheduledBuildCallCount()).To(BeZero())
+			Expect(fakeJob.SetScheduleFiredAtCallCount()).To(Equal(1))
+			Expect(fakeJob.SetScheduleFiredAtArgsForCall(0)).To(Equal(time.Date(2019, 5, 10, 12, 0, 0, 0, time.UTC)))
+		})
+
+		Context("when the schedule catches up", func() {
+			BeforeEach(func() {
+				scheduleConfig.Missed = atc.ScheduleMissedCatchUp
+			})
+
+			It("creates a build for every missed slot", func() {
+				Expect(fakeJob.CreateScheduledBuildCallCount()).To(Equal(4))
+				Expect(fakeJob.CreateScheduledBuildArgsForCall(0)).To(Equal(time.Date(2019, 5, 10, 9, 0, 0, 0, time.UTC)))
+				Expect(fakeJob.CreateScheduledBuildArgsForCall(3)).To(Equal(time.Date(2019, 5, 10, 12, 0, 0, 0, time.UTC)))
+			})
+		})
+
+		Context("when more slots were missed than can be caught up", func() {
+			BeforeEach(func() {
+				scheduleConfig.Missed = atc.ScheduleMissedCatchUp
+				fakeJob.ScheduleFiredAtReturns(time.Date(2019, 5, 9, 12, 0, 0, 0, time.UTC))
+			})
+
+			It("only creates builds for the most recent slots", func() {
+				Expect(fakeJob.CreateScheduledBuildCallCount()).To(Equal(MaxCatchUpBuilds))
+				Expect(fakeJob.CreateScheduledBuildArgsForCall(MaxCatchUpBuilds - 1)).To(Equal(time.Date(2019, 5, 10, 12, 0, 0, 0, time.UTC)))
+			})
+		})
+	})
+})
@@ -32,6 +32,7 @@ type Runner struct {
 	Scheduler BuildScheduler
 	Noop      bool
 	Interval  time.Duration
+	Registry  *metric.Registry
 }
 
 func (runner *Runner) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
@@ -87,7 +88,7 @@ func (runner *Runner) tick(logger lager.Logger) error {
 		metric.SchedulingFullDuration{
 			PipelineName: runner.Pipeline.Name(),
 			Duration:     time.Since(start),
-		}.Emit(logger)
+		}.Emit(logger, runner.Registry)
 	}()
 
 	versions, err := runner.Pipeline.LoadVersionsDB()
@@ -99,7 +100,7 @@ func (runner *Runner) tick(logger lager.Logger) error {
 	metric.SchedulingLoadVersionsDuration{
 		PipelineName: runner.Pipeline.Name(),
 		Duration:     time.Since(start),
-	}.Emit(logger)
+	}.Emit(logger, runner.Registry)
 
 	found, err := runner.Pipeline.Reload()
 	if err != nil {
@@ -144,7 +145,7 @@ func (runner *Runner) tick(logger lager.Logger) error {
 			PipelineName: runner.Pipeline.Name(),
 			JobName:      jobName,
 			Duration:     duration,
-		}.Emit(sLog)
+		}.Emit(sLog, runner.Registry)
 	}
 
 	return err
@@ -3,6 +3,7 @@ package scheduler
 import (
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
@@ -14,6 +15,7 @@ type Scheduler struct {
 	Pipeline     db.Pipeline
 	InputMapper  inputmapper.InputMapper
 	BuildStarter BuildStarter
+	Clock        clock.Clock
 }
 
 func (s *Scheduler) Schedule(
@@ -28,6 +30,10 @@ func (s *Scheduler) Schedule(
 	for _, job := range jobs {
 		jStart := time.Now()
 		err := s.ensurePendingBuildExists(logger, versions, job, resources)
+		if err == nil {
+			err = s.triggerScheduledBuilds(logger, job)
+		}
+
 		jobSchedulingTime[job.Name()] = time.Since(jStart)
 
 		if err != nil {
@@ -0,0 +1,157 @@
+package atc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SourceDefaults are configured by the operator and change the source of
+// resources, resource types and image resources before they are used.
+type SourceDefaults struct {
+	// Resources maps resource types to defaults that are merged into the
+	// source of every resource of the type. Keys configured in the pipeline
+	// take precedence over the defaults.
+	Resources map[string]Source
+
+	// RegistryRewriteRules rewrite the repositories of images so that they
+	// are pulled from another registry, e.g. a mirror. Only the first
+	// matching rule is applied.
+	RegistryRewriteRules []RegistryRewriteRule
+}
+
+// DefaultRegistry is the registry of repositories that do not name one.
+const DefaultRegistry = "docker.io"
+
+// registryImageTypes are the resource types whose source has a repository
+// that the registry rewrite rules apply to.
+var registryImageTypes = map[string]bool{
+	"registry-image": true,
+	"docker-image":   true,
+}
+
+// Apply returns the source of a resource of the given type with the defaults
+// merged in. It also describes the defaults if they changed the source, so
+// that they can be shown in the build.
+func (defaults SourceDefaults) Apply(resourceType string, source Source) (Source, []string) {
+	typeDefaults, found := defaults.Resources[resourceType]
+	if !found {
+		return source, nil
+	}
+
+	merged := Source{}
+	for key, value := range typeDefaults {
+		merged[key] = value
+	}
+
+	for key, value := range source {
+		merged[key] = value
+	}
+
+	if len(merged) == len(source) {
+		return source, nil
+	}
+
+	return merged, []string{fmt.Sprintf("source defaults for %s", resourceType)}
+}
+
+// RewriteRegistry returns the source of a resource of the given type with its
+// repository rewritten by the first matching registry rewrite rule, which it
+// also describes. Only images that are pulled may be rewritten; pushing them
+// must go to the configured repository.
+func (defaults SourceDefaults) RewriteRegistry(resourceType string, source Source) (Source, []string) {
+	if !registryImageTypes[resourceType] {
+		return source, nil
+	}
+
+	repository, ok := source["repository"].(string)
+	if !ok {
+		return source, nil
+	}
+
+	for _, rule := range defaults.RegistryRewriteRules {
+		rewritten, matched := rule.Rewrite(repository)
+		if !matched {
+			continue
+		}
+
+		rewrittenSource := Source{}
+		for key, value := range source {
+			rewrittenSource[key] = value
+		}
+
+		rewrittenSource["repository"] = rewritten
+
+		return rewrittenSource, []string{rule.String()}
+	}
+
+	return source, nil
+}
+
+// RegistryRewriteRule makes repositories of the From registry be pulled from
+// the To registry instead. To may include a path prefix.
+type RegistryRewriteRule struct {
+	From string
+	To   string
+}
+
+func (rule *RegistryRewriteRule) UnmarshalFlag(value string) error {
+	from, to := splitRule(value)
+	if from == "" || to == "" {
+		return fmt.Errorf("invalid registry rewrite rule '%s', expected FROM=TO", value)
+	}
+
+	rule.From = normalizeRegistry(from)
+	rule.To = strings.TrimSuffix(to, "/")
+
+	return nil
+}
+
+func (rule RegistryRewriteRule) String() string {
+	return fmt.Sprintf("registry rewrite %s => %s", rule.From, rule.To)
+}
+
+// Rewrite returns the repository as pulled from the To registry, or false if
+// the repository is not in the From registry.
+func (rule RegistryRewriteRule) Rewrite(repository string) (string, bool) {
+	registry, path := splitRepository(repository)
+	if registry != rule.From {
+		return "", false
+	}
+
+	return rule.To + "/" + path, true
+}
+
+func splitRule(value string) (string, string) {
+	i := strings.Index(value, "=")
+	if i == -1 {
+		return "", ""
+	}
+
+	return value[:i], value[i+1:]
+}
+
+// splitRepository splits a repository into its registry and path the same way
+// docker does: the first component names a registry only if it looks like a
+// host, and official images of Docker Hub live under library/.
+func splitRepository(repository string) (string, string) {
+	i := strings.Index(repository, "/")
+	if i == -1 {
+		return DefaultRegistry, "library/" + repository
+	}
+
+	host := repository[:i]
+	if strings.ContainsAny(host, ".:") || host == "localhost" {
+		return normalizeRegistry(host), repository[i+1:]
+	}
+
+	return DefaultRegistry, repository
+}
+
+func normalizeRegistry(registry string) string {
+	switch registry {
+	case "index.docker.io", "registry-1.docker.io":
+		return DefaultRegistry
+	default:
+		return registry
+	}
+}
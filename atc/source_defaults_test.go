@@ -0,0 +1,113 @@
+package atc_test
+
+import (
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SourceDefaults", func() {
+	var defaults atc.SourceDefaults
+
+	BeforeEach(func() {
+		defaults = atc.SourceDefaults{
+			Resources: map[string]atc.Source{
+				"registry-image": {"username": "mirror-user", "password": "mirror-password"},
+			},
+			RegistryRewriteRules: []atc.RegistryRewriteRule{
+				{From: "docker.io", To: "mirror.example.com"},
+				{From: "gcr.io", To: "mirror.example.com/gcr"},
+			},
+		}
+	})
+
+	It("merges the defaults of the type into the source", func() {
+		source, applied := defaults.Apply("registry-image", atc.Source{
+			"repository": "mirror.example.com/some/image",
+			"password":   "pipeline-password",
+		})
+
+		Expect(source).To(Equal(atc.Source{
+			"repository": "mirror.example.com/some/image",
+			"username":   "mirror-user",
+			"password":   "pipeline-password",
+		}))
+		Expect(applied).To(Equal([]string{"source defaults for registry-image"}))
+	})
+
+	It("does not record defaults that did not change the source", func() {
+		_, applied := defaults.Apply("registry-image", atc.Source{
+			"repository": "quay.io/some/image",
+			"username":   "pipeline-user",
+			"password":   "pipeline-password",
+		})
+
+		Expect(applied).To(BeEmpty())
+	})
+
+	It("rewrites official images of Docker Hub", func() {
+		source, applied := defaults.RewriteRegistry("docker-image", atc.Source{"repository": "busybox"})
+
+		Expect(source).To(Equal(atc.Source{"repository": "mirror.example.com/library/busybox"}))
+		Expect(applied).To(Equal([]string{"registry rewrite docker.io => mirror.example.com"}))
+	})
+
+	It("rewrites images of Docker Hub users", func() {
+		source, _ := defaults.RewriteRegistry("docker-image", atc.Source{"repository": "concourse/git-resource"})
+		Expect(source["repository"]).To(Equal("mirror.example.com/concourse/git-resource"))
+
+		source, _ = defaults.RewriteRegistry("docker-image", atc.Source{"repository": "index.docker.io/concourse/git-resource"})
+		Expect(source["repository"]).To(Equal("mirror.example.com/concourse/git-resource"))
+	})
+
+	It("rewrites images of other registries with the first matching rule", func() {
+		source, applied := defaults.RewriteRegistry("docker-image", atc.Source{"repository": "gcr.io/some/image"})
+
+		Expect(source["repository"]).To(Equal("mirror.example.com/gcr/some/image"))
+		Expect(applied).To(Equal([]string{"registry rewrite gcr.io => mirror.example.com/gcr"}))
+	})
+
+	It("leaves images of registries without a rule alone", func() {
+		source, applied := defaults.RewriteRegistry("docker-image", atc.Source{"repository": "localhost:5000/some/image"})
+
+		Expect(source["repository"]).To(Equal("localhost:5000/some/image"))
+		Expect(applied).To(BeEmpty())
+	})
+
+	It("does not rewrite repositories when merging defaults", func() {
+		source, _ := defaults.Apply("registry-image", atc.Source{"repository": "busybox"})
+
+		Expect(source["repository"]).To(Equal("busybox"))
+	})
+
+	It("does not rewrite the sources of other types", func() {
+		source, applied := defaults.RewriteRegistry("git", atc.Source{"repository": "busybox"})
+
+		Expect(source).To(Equal(atc.Source{"repository": "busybox"}))
+		Expect(applied).To(BeEmpty())
+	})
+
+	It("does not modify the given source", func() {
+		given := atc.Source{"repository": "busybox"}
+
+		defaults.Apply("registry-image", given)
+		defaults.RewriteRegistry("registry-image", given)
+
+		Expect(given).To(Equal(atc.Source{"repository": "busybox"}))
+	})
+})
+
+var _ = Describe("RegistryRewriteRule", func() {
+	It("parses FROM=TO", func() {
+		var rule atc.RegistryRewriteRule
+		Expect(rule.UnmarshalFlag("index.docker.io=mirror.example.com/")).To(Succeed())
+		Expect(rule).To(Equal(atc.RegistryRewriteRule{From: "docker.io", To: "mirror.example.com"}))
+	})
+
+	It("fails without a target", func() {
+		var rule atc.RegistryRewriteRule
+		Expect(rule.UnmarshalFlag("docker.io")).To(HaveOccurred())
+		Expect(rule.UnmarshalFlag("docker.io=")).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,23 @@
+package atc
+
+type StatusEventType string
+
+const (
+	StatusEventBuildCreated  StatusEventType = "build-created"
+	StatusEventBuildStarted  StatusEventType = "build-started"
+	StatusEventBuildFinished StatusEventType = "build-finished"
+	StatusEventJobPaused     StatusEventType = "job-paused"
+	StatusEventJobUnpaused   StatusEventType = "job-unpaused"
+)
+
+// StatusEvent is a change to a build or job published on the status event
+// stream. Build is only set for build events.
+type StatusEvent struct {
+	ID           int64           `json:"id"`
+	Type         StatusEventType `json:"type"`
+	TeamName     string          `json:"team_name"`
+	PipelineName string          `json:"pipeline_name,omitempty"`
+	JobName      string          `json:"job_name,omitempty"`
+	Build        *Build          `json:"build,omitempty"`
+	Time         int64           `json:"time"`
+}
@@ -41,6 +41,47 @@ type TaskConfig struct {
 type ContainerLimits struct {
 	CPU    *uint64 `yaml:"cpu,omitempty" json:"cpu,omitempty"  mapstructure:"cpu"`
 	Memory *uint64 `yaml:"memory,omitempty" json:"memory,omitempty"  mapstructure:"memory"`
+
+	// Maximum bytes written to each of the container's writable volumes,
+	// including its rootfs. Nil or 0 means unlimited.
+	Disk *uint64 `yaml:"disk,omitempty" json:"disk,omitempty"  mapstructure:"disk"`
+
+	// Maximum number of processes and threads in the container. Nil or 0
+	// means unlimited.
+	Pids *uint64 `yaml:"pids,omitempty" json:"pids,omitempty"  mapstructure:"pids"`
+
+	// Network mode of the container, either ContainerNetworkNone or
+	// ContainerNetworkDefault. Empty means unspecified.
+	Network string `yaml:"network,omitempty" json:"network,omitempty"  mapstructure:"network"`
+}
+
+const (
+	// ContainerNetworkDefault gives the container the worker's usual network.
+	ContainerNetworkDefault = "default"
+
+	// ContainerNetworkNone gives the container no network besides loopback.
+	ContainerNetworkNone = "none"
+)
+
+// RequiredCapabilities are the worker capabilities needed to enforce the
+// limits. Containers with these limits must only be placed on workers that
+// advertise them.
+func (limits ContainerLimits) RequiredCapabilities() []string {
+	var capabilities []string
+
+	if limits.Disk != nil && *limits.Disk > 0 {
+		capabilities = append(capabilities, WorkerCapabilityDiskLimit)
+	}
+
+	if limits.Pids != nil && *limits.Pids > 0 {
+		capabilities = append(capabilities, WorkerCapabilityPidsLimit)
+	}
+
+	if limits.Network == ContainerNetworkNone {
+		capabilities = append(capabilities, WorkerCapabilityNetworkNone)
+	}
+
+	return capabilities
 }
 
 type ImageResource struct {
@@ -225,6 +225,96 @@ run: {path: a/file}
 				})
 			})
 
+			Context("when disk, pids and network limits are specified", func() {
+				It("parses them without any errors", func() {
+					data := []byte(`
+platform: beos
+container_limits: { disk: 2GB, pids: 100, network: none }
+
+run: {path: a/file}
+`)
+					task, err := NewTaskConfig(data)
+					Expect(err).ToNot(HaveOccurred())
+					disk := uint64(2147483648)
+					pids := uint64(100)
+					Expect(task.Limits).To(Equal(ContainerLimits{
+						Disk:    &disk,
+						Pids:    &pids,
+						Network: ContainerNetworkNone,
+					}))
+				})
+
+				It("requires workers that can enforce them", func() {
+					data := []byte(`
+platform: beos
+container_limits: { disk: 2GB, pids: 100, network: none }
+
+run: {path: a/file}
+`)
+					task, err := NewTaskConfig(data)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(task.Limits.RequiredCapabilities()).To(Equal([]string{
+						WorkerCapabilityDiskLimit,
+						WorkerCapabilityPidsLimit,
+						WorkerCapabilityNetworkNone,
+					}))
+				})
+			})
+
+			Context("when disk and pids limits are 0", func() {
+				It("leaves them unlimited, requiring nothing of workers", func() {
+					data := []byte(`
+platform: beos
+container_limits: { disk: 0, pids: 0 }
+
+run: {path: a/file}
+`)
+					task, err := NewTaskConfig(data)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(task.Limits).To(Equal(ContainerLimits{}))
+					Expect(task.Limits.RequiredCapabilities()).To(BeEmpty())
+				})
+
+				It("leaves them unlimited when given as defaults", func() {
+					disk := "0"
+					pids := 0
+
+					limits, err := ContainerLimitsParser(map[string]interface{}{
+						"disk": &disk,
+						"pids": &pids,
+					})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(limits).To(Equal(ContainerLimits{}))
+					Expect(limits.RequiredCapabilities()).To(BeEmpty())
+				})
+			})
+
+			Context("when a negative pids limit is provided", func() {
+				It("throws an error and does not continue", func() {
+					data := []byte(`
+platform: beos
+container_limits: { pids: -1 }
+
+run: {path: a/file}
+`)
+					_, err := NewTaskConfig(data)
+					Expect(err).To(MatchError(ContainSubstring("pids limit must not be negative")))
+				})
+			})
+
+			Context("when invalid disk limit value is provided", func() {
+				It("throws an error and does not continue", func() {
+					data := []byte(`
+platform: beos
+container_limits: { disk: lots }
+
+run: {path: a/file}
+`)
+					_, err := NewTaskConfig(data)
+					Expect(err).To(MatchError(ContainSubstring("could not parse container disk limit")))
+				})
+			})
+
 			Context("when invalid memory limit value is provided", func() {
 				It("throws an error and does not continue", func() {
 					data := []byte(`
@@ -1,9 +1,25 @@
 package atc
 
 type Team struct {
-	ID   int      `json:"id,omitempty"`
-	Name string   `json:"name,omitempty"`
-	Auth TeamAuth `json:"auth,omitempty"`
+	ID    int        `json:"id,omitempty"`
+	Name  string     `json:"name,omitempty"`
+	Auth  TeamAuth   `json:"auth,omitempty"`
+	Quota *TeamQuota `json:"quota,omitempty"`
+	Usage *TeamUsage `json:"usage,omitempty"`
 }
 
 type TeamAuth map[string]map[string][]string
+
+// TeamQuota limits how much a team may run at once. A limit of zero means
+// unlimited.
+type TeamQuota struct {
+	MaxRunningBuilds int `json:"max_running_builds,omitempty"`
+	MaxContainers    int `json:"max_containers,omitempty"`
+}
+
+// TeamUsage is how much a team is running at the moment, counted against its
+// quota.
+type TeamUsage struct {
+	RunningBuilds int `json:"running_builds"`
+	Containers    int `json:"containers"`
+}
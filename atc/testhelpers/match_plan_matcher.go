@@ -135,6 +135,11 @@ func stripIDs(plan atc.Plan) (atc.Plan, []string) {
 		ids = append(ids, subIDs...)
 	}
 
+	if plan.Lock != nil {
+		plan.Lock.Step, subIDs = stripIDs(plan.Lock.Step)
+		ids = append(ids, subIDs...)
+	}
+
 	if plan.Try != nil {
 		plan.Try.Step, subIDs = stripIDs(plan.Try.Step)
 		ids = append(ids, subIDs...)
@@ -52,6 +52,51 @@ func (c Config) Validate() ([]ConfigWarning, []string) {
 	return warnings, errorMessages
 }
 
+// ValidatePassedJobs checks that every job in another pipeline referenced by
+// a passed constraint exists. Config.Validate only checks that such
+// references are well-formed, as it has no access to other pipelines.
+func (c Config) ValidatePassedJobs(pipelineName string, jobExists func(PassedJobReference) (bool, error)) ([]string, error) {
+	errorMessages := []string{}
+
+	for _, job := range c.Jobs {
+		identifier := fmt.Sprintf("jobs.%s", job.Name)
+
+		for _, ref := range job.CrossPipelinePassed() {
+			if ref.PipelineName == pipelineName {
+				errorMessages = append(
+					errorMessages,
+					fmt.Sprintf(
+						"%s.passed references a job in its own pipeline ('%s'); use the job name ('%s')",
+						identifier,
+						ref,
+						ref.JobName,
+					),
+				)
+
+				continue
+			}
+
+			exists, err := jobExists(ref)
+			if err != nil {
+				return nil, err
+			}
+
+			if !exists {
+				errorMessages = append(
+					errorMessages,
+					fmt.Sprintf(
+						"%s.passed references an unknown job in another pipeline ('%s')",
+						identifier,
+						ref,
+					),
+				)
+			}
+		}
+	}
+
+	return errorMessages, nil
+}
+
 func validateGroups(c Config) error {
 	errorMessages := []string{}
 
@@ -137,6 +182,10 @@ func validateResources(c Config) error {
 		if resource.Type == "" {
 			errorMessages = append(errorMessages, identifier+" has no type")
 		}
+
+		if retention := resource.VersionRetention; retention != nil && (retention.Latest < 0 || retention.Days < 0) {
+			errorMessages = append(errorMessages, identifier+" has a negative version_retention")
+		}
 	}
 
 	errorMessages = append(errorMessages, validateResourcesUnused(c)...)
@@ -261,6 +310,43 @@ func validateJobs(c Config) ([]ConfigWarning, error) {
 			}
 		}
 
+		if job.BuildRetention != nil {
+			if job.BuildRetention.Builds < 0 {
+				errorMessages = append(
+					errorMessages,
+					identifier+fmt.Sprintf(" has negative build_retention.builds: %d", job.BuildRetention.Builds),
+				)
+			}
+			if job.BuildRetention.Days < 0 {
+				errorMessages = append(
+					errorMessages,
+					identifier+fmt.Sprintf(" has negative build_retention.days: %d", job.BuildRetention.Days),
+				)
+			}
+		}
+
+		if job.Schedule != nil {
+			_, err := ParseJobSchedule(*job.Schedule)
+			if err != nil {
+				errorMessages = append(
+					errorMessages,
+					identifier+fmt.Sprintf(" has an invalid schedule: %s", err),
+				)
+			}
+		}
+
+		if job.LockCapacity < 0 {
+			errorMessages = append(
+				errorMessages,
+				identifier+fmt.Sprintf(" has an invalid lock_capacity (%d)", job.LockCapacity),
+			)
+		} else if job.LockCapacity > 0 && job.Lock == "" {
+			errorMessages = append(
+				errorMessages,
+				identifier+" has a lock_capacity without a lock",
+			)
+		}
+
 		planWarnings, planErrMessages := validatePlan(c, identifier+".plan", PlanConfig{Do: &job.Plan})
 		warnings = append(warnings, planWarnings...)
 		errorMessages = append(errorMessages, planErrMessages...)
@@ -419,7 +505,7 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]ConfigWarning
 		identifier = fmt.Sprintf("%s.get.%s", identifier, plan.Get)
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"privileged", "config", "file"},
+			[]string{"privileged", "config", "file", "retain_outputs", "cache_result"},
 			plan, identifier)...,
 		)
 
@@ -449,6 +535,23 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]ConfigWarning
 		}
 
 		for _, job := range plan.Passed {
+			if strings.Contains(job, "/") {
+				// jobs in other pipelines are checked against the database when
+				// the pipeline is saved; see ValidatePassedJobs
+				if _, ok := ParsePassedJobReference(job); !ok {
+					errorMessages = append(
+						errorMessages,
+						fmt.Sprintf(
+							"%s.passed has an invalid reference to a job in another pipeline ('%s'); expected 'pipeline/job'",
+							identifier,
+							job,
+						),
+					)
+				}
+
+				continue
+			}
+
 			jobConfig, found := c.Jobs.Lookup(job)
 			if !found {
 				errorMessages = append(
@@ -490,11 +593,21 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]ConfigWarning
 			}
 		}
 
+		if plan.Version != nil && plan.Version.Filter != "" {
+			_, err := ParseVersionFilter(plan.Version.Filter)
+			if err != nil {
+				errorMessages = append(
+					errorMessages,
+					fmt.Sprintf("%s.version has an invalid filter: %s", identifier, err),
+				)
+			}
+		}
+
 	case plan.Put != "":
 		identifier = fmt.Sprintf("%s.put.%s", identifier, plan.Put)
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"passed", "trigger", "privileged", "config", "file"},
+			[]string{"passed", "trigger", "privileged", "config", "file", "retain_outputs", "cache_result"},
 			plan, identifier)...,
 		)
 
@@ -605,6 +718,14 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]ConfigWarning
 		}
 	}
 
+	if plan.LockCapacity < 0 {
+		subIdentifier := fmt.Sprintf("%s.lock_capacity", identifier)
+		errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has an invalid capacity (%d)", plan.LockCapacity))
+	} else if plan.LockCapacity > 0 && plan.Lock == "" {
+		subIdentifier := fmt.Sprintf("%s.lock_capacity", identifier)
+		errorMessages = append(errorMessages, subIdentifier+" is specified without a lock")
+	}
+
 	if plan.Attempts < 0 {
 		subIdentifier := fmt.Sprintf("%s.attempts", identifier)
 		errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has an invalid number of attempts (%d)", plan.Attempts))
@@ -643,6 +764,14 @@ func validateInapplicableFields(inapplicableFields []string, plan PlanConfig, id
 			if plan.TaskConfigPath != "" {
 				foundInapplicableFields = append(foundInapplicableFields, field)
 			}
+		case "retain_outputs":
+			if plan.RetainOutputs {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
+		case "cache_result":
+			if plan.CacheResult {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
 		}
 	}
 
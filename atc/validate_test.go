@@ -209,6 +209,17 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a resource has a negative version retention", func() {
+			BeforeEach(func() {
+				config.Resources[0].VersionRetention = &VersionRetention{Latest: -1}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource has a negative version_retention"))
+			})
+		})
+
 		Context("when a resource has no name or type", func() {
 			BeforeEach(func() {
 				config.Resources = append(config.Resources, ResourceConfig{
@@ -706,6 +717,75 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a put plan has retain_outputs specified", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put:           "some-resource",
+						RetainOutputs: true,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource has invalid fields specified (retain_outputs)"))
+				})
+			})
+
+			Context("when a get plan has a version filter", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:     "some-resource",
+						Version: &VersionConfig{Latest: true, Filter: "semver(tag) >=1.4 <2 and ref =~ ^release/"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(BeEmpty())
+				})
+
+				Context("when the filter is malformed", func() {
+					BeforeEach(func() {
+						config.Jobs[len(config.Jobs)-1].Plan[0].Version.Filter = "semver(tag) >=banana"
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.version has an invalid filter"))
+					})
+				})
+
+				Context("when the filter has an invalid regular expression", func() {
+					BeforeEach(func() {
+						config.Jobs[len(config.Jobs)-1].Plan[0].Version.Filter = "ref =~ ^release/("
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("invalid regular expression"))
+					})
+				})
+			})
+
+			Context("when a get plan has cache_result specified", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:         "some-resource",
+						CacheResult: true,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource has invalid fields specified (cache_result)"))
+				})
+			})
+
 			Context("when a task plan has invalid fields specified", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
@@ -1172,6 +1252,39 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a plan has an invalid lock capacity in a step", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:          "some-resource",
+						Lock:         "some-lock",
+						LockCapacity: -1,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("throws a validation error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.lock_capacity has an invalid capacity (-1)"))
+				})
+			})
+
+			Context("when a plan has a lock capacity without a lock", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:          "some-resource",
+						LockCapacity: 2,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("throws a validation error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.lock_capacity is specified without a lock"))
+				})
+			})
+
 			Context("when a plan has an invalid step within a try", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
@@ -1238,6 +1351,37 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a job's input's passed constraints reference a job in another pipeline", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:    "some-resource",
+						Passed: []string{"other-pipeline/other-job"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a job's input's passed constraints have a malformed cross-pipeline reference", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:    "some-resource",
+						Passed: []string{"other-pipeline/"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed has an invalid reference to a job in another pipeline ('other-pipeline/')"))
+				})
+			})
+
 			Context("when a job's input's passed constraints references a valid job that has the resource as an output", func() {
 				BeforeEach(func() {
 					config.Jobs[0].Plan = append(config.Jobs[0].Plan, PlanConfig{
@@ -1358,5 +1502,133 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a job has negative build_retention values", func() {
+			BeforeEach(func() {
+				config.Jobs[0].BuildRetention = &BuildRetention{
+					Builds: -1,
+					Days:   -1,
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job has negative build_retention.builds: -1"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job has negative build_retention.days: -1"))
+			})
+		})
+
+		Context("when a job has an invalid lock_capacity", func() {
+			BeforeEach(func() {
+				config.Jobs[0].Lock = "some-lock"
+				config.Jobs[0].LockCapacity = -1
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job has an invalid lock_capacity (-1)"))
+			})
+		})
+
+		Context("when a job has a lock_capacity without a lock", func() {
+			BeforeEach(func() {
+				config.Jobs[0].LockCapacity = 2
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job has a lock_capacity without a lock"))
+			})
+		})
+
+		Context("when a job has a valid schedule", func() {
+			BeforeEach(func() {
+				config.Jobs[0].Schedule = &ScheduleConfig{
+					Cron:     "0 2 * * mon-fri",
+					Location: "America/New_York",
+					Missed:   "catch-up",
+					Jitter:   "5m",
+				}
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+		})
+
+		Context("when a job has an invalid schedule", func() {
+			BeforeEach(func() {
+				config.Jobs[0].Schedule = &ScheduleConfig{
+					Cron: "0 25 * * *",
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job has an invalid schedule: invalid hour field"))
+			})
+		})
+
+	})
+})
+
+var _ = Describe("ValidatePassedJobs", func() {
+	var (
+		config Config
+
+		existingJobs  map[PassedJobReference]bool
+		errorMessages []string
+		err           error
+	)
+
+	BeforeEach(func() {
+		config = Config{
+			Jobs: JobConfigs{
+				{
+					Name: "some-job",
+					Plan: PlanSequence{
+						{Get: "some-resource", Passed: []string{"upstream/some-job", "some-local-job"}},
+						{Get: "some-other-resource", Passed: []string{"upstream/some-job"}},
+					},
+				},
+			},
+		}
+
+		existingJobs = map[PassedJobReference]bool{
+			{PipelineName: "upstream", JobName: "some-job"}: true,
+		}
+	})
+
+	JustBeforeEach(func() {
+		errorMessages, err = config.ValidatePassedJobs("some-pipeline", func(ref PassedJobReference) (bool, error) {
+			return existingJobs[ref], nil
+		})
+	})
+
+	It("accepts references to existing jobs", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(errorMessages).To(BeEmpty())
+	})
+
+	Context("when a referenced job does not exist", func() {
+		BeforeEach(func() {
+			existingJobs = map[PassedJobReference]bool{}
+		})
+
+		It("returns a single error for the job", func() {
+			Expect(errorMessages).To(Equal([]string{
+				"jobs.some-job.passed references an unknown job in another pipeline ('upstream/some-job')",
+			}))
+		})
+	})
+
+	Context("when a job references its own pipeline", func() {
+		BeforeEach(func() {
+			config.Jobs[0].Plan[0].Passed = []string{"some-pipeline/some-local-job"}
+			existingJobs[PassedJobReference{PipelineName: "some-pipeline", JobName: "some-local-job"}] = true
+		})
+
+		It("returns an error", func() {
+			Expect(errorMessages).To(ContainElement(ContainSubstring("references a job in its own pipeline ('some-pipeline/some-local-job')")))
+		})
 	})
 })
@@ -0,0 +1,165 @@
+package atc
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+// VersionFilterSeparator separates the version strategy ("latest" or "every")
+// from a filter expression in a step's version config, e.g.
+//
+//   version: latest where semver(tag) >=1.4 <2
+//   version: every where ref =~ ^release/
+const VersionFilterSeparator = " where "
+
+var semverClauseRegex = regexp.MustCompile(`^semver\(\s*([^()\s]+)\s*\)\s*(.*)$`)
+var semverOperatorRegex = regexp.MustCompile(`^(=|!=|>|<|>=|<=|~>)$`)
+
+// A VersionFilter restricts the versions of a resource which a step may use.
+// It is made up of one or more clauses joined by "and", each of which must
+// match for a version to be used:
+//
+//   semver(FIELD) CONSTRAINTS - the field parses as a semantic version
+//                               satisfying every constraint, e.g. ">=1.4 <2"
+//   FIELD =~ REGEX            - the field matches the regular expression
+//   FIELD !~ REGEX            - the field does not match the regular expression
+type VersionFilter struct {
+	Expression string
+	Clauses    []VersionFilterClause
+}
+
+type VersionFilterClause struct {
+	Field string
+
+	Semver  version.Constraints
+	Pattern *regexp.Regexp
+	Negate  bool
+}
+
+func ParseVersionFilter(expression string) (*VersionFilter, error) {
+	expression = strings.TrimSpace(expression)
+	if expression == "" {
+		return nil, errors.New("empty version filter")
+	}
+
+	filter := &VersionFilter{Expression: expression}
+
+	for _, raw := range strings.Split(expression, " and ") {
+		clause, err := parseVersionFilterClause(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, err
+		}
+
+		filter.Clauses = append(filter.Clauses, clause)
+	}
+
+	return filter, nil
+}
+
+func parseVersionFilterClause(raw string) (VersionFilterClause, error) {
+	if raw == "" {
+		return VersionFilterClause{}, errors.New("empty clause in version filter")
+	}
+
+	if matches := semverClauseRegex.FindStringSubmatch(raw); matches != nil {
+		constraints, err := parseSemverConstraints(matches[2])
+		if err != nil {
+			return VersionFilterClause{}, fmt.Errorf("invalid semver constraint in '%s': %s", raw, err)
+		}
+
+		return VersionFilterClause{
+			Field:  matches[1],
+			Semver: constraints,
+		}, nil
+	}
+
+	// the first operator in the clause separates the field from the pattern;
+	// the pattern itself may contain either operator
+	operator, i := "", -1
+	for _, candidate := range []string{"=~", "!~"} {
+		if j := strings.Index(raw, candidate); j != -1 && (i == -1 || j < i) {
+			operator, i = candidate, j
+		}
+	}
+
+	if i != -1 {
+		field := strings.TrimSpace(raw[:i])
+		if field == "" || strings.ContainsAny(field, " \t") {
+			return VersionFilterClause{}, fmt.Errorf("invalid field name in '%s'", raw)
+		}
+
+		pattern, err := regexp.Compile(strings.TrimSpace(raw[i+len(operator):]))
+		if err != nil {
+			return VersionFilterClause{}, fmt.Errorf("invalid regular expression in '%s': %s", raw, err)
+		}
+
+		return VersionFilterClause{
+			Field:   field,
+			Pattern: pattern,
+			Negate:  operator == "!~",
+		}, nil
+	}
+
+	return VersionFilterClause{}, fmt.Errorf("unknown clause '%s'; expected 'semver(FIELD) CONSTRAINTS', 'FIELD =~ REGEX' or 'FIELD !~ REGEX'", raw)
+}
+
+// parseSemverConstraints accepts constraints separated by whitespace or
+// commas, and operators separated from their versions by whitespace, i.e.
+// ">=1.4 <2", ">= 1.4, < 2" and ">=1.4,<2" are all equivalent.
+func parseSemverConstraints(raw string) (version.Constraints, error) {
+	tokens := strings.Fields(strings.Replace(raw, ",", " ", -1))
+	if len(tokens) == 0 {
+		return nil, errors.New("no constraints given")
+	}
+
+	constraints := []string{}
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		if semverOperatorRegex.MatchString(token) && i+1 < len(tokens) {
+			i++
+			token += tokens[i]
+		}
+
+		constraints = append(constraints, token)
+	}
+
+	return version.NewConstraint(strings.Join(constraints, ","))
+}
+
+// Matches returns true if every clause of the filter matches the version.
+// Versions missing a field referenced by the filter never match.
+func (filter VersionFilter) Matches(v Version) bool {
+	for _, clause := range filter.Clauses {
+		if !clause.Matches(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (clause VersionFilterClause) Matches(v Version) bool {
+	value, found := v[clause.Field]
+	if !found {
+		return false
+	}
+
+	if clause.Semver != nil {
+		semver, err := version.NewVersion(value)
+		if err != nil {
+			return false
+		}
+
+		return clause.Semver.Check(semver)
+	}
+
+	return clause.Pattern.MatchString(value) != clause.Negate
+}
+
+func (filter VersionFilter) String() string {
+	return filter.Expression
+}
@@ -29,8 +29,31 @@ type Worker struct {
 	StartTime int64    `json:"start_time"`
 	Ephemeral bool     `json:"ephemeral"`
 	State     string   `json:"state"`
+
+	// Capabilities are the container limits the worker can enforce.
+	Capabilities []string `json:"capabilities,omitempty"`
+
+	QuarantinedUntil int64 `json:"quarantined_until,omitempty"`
+
+	// KeyFingerprint is set by the TSA to the fingerprint of the key the worker
+	// authenticated with.
+	KeyFingerprint string `json:"key_fingerprint,omitempty"`
 }
 
+const (
+	// WorkerCapabilityDiskLimit is advertised by workers that limit the bytes
+	// written to the volumes of a container.
+	WorkerCapabilityDiskLimit = "disk-limit"
+
+	// WorkerCapabilityPidsLimit is advertised by workers that limit the number
+	// of processes in a container.
+	WorkerCapabilityPidsLimit = "pids-limit"
+
+	// WorkerCapabilityNetworkNone is advertised by workers that can create
+	// containers without a network.
+	WorkerCapabilityNetworkNone = "network-none"
+)
+
 var ErrInvalidWorkerVersion = errors.New("invalid worker version, only numeric characters are allowed")
 var ErrMissingWorkerGardenAddress = errors.New("missing garden address")
 var ErrNoWorkers = errors.New("no workers available for checking")
@@ -10,6 +10,7 @@ import (
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/metric"
@@ -17,6 +18,8 @@ import (
 
 const creatingContainerRetryDelay = 1 * time.Second
 
+const containerQuotaRetryDelay = 5 * time.Second
+
 func NewContainerProvider(
 	gardenClient garden.Client,
 	volumeClient VolumeClient,
@@ -25,6 +28,8 @@ func NewContainerProvider(
 	dbVolumeRepository db.VolumeRepository,
 	dbTeamFactory db.TeamFactory,
 	lockFactory lock.LockFactory,
+	quarantiner Quarantiner,
+	registry *metric.Registry,
 ) ContainerProvider {
 
 	return &containerProvider{
@@ -34,6 +39,8 @@ func NewContainerProvider(
 		dbVolumeRepository: dbVolumeRepository,
 		dbTeamFactory:      dbTeamFactory,
 		lockFactory:        lockFactory,
+		quarantiner:        quarantiner,
+		registry:           registry,
 		httpProxyURL:       dbWorker.HTTPProxyURL(),
 		httpsProxyURL:      dbWorker.HTTPSProxyURL(),
 		noProxy:            dbWorker.NoProxy(),
@@ -74,6 +81,8 @@ type containerProvider struct {
 	dbTeamFactory      db.TeamFactory
 
 	lockFactory lock.LockFactory
+	quarantiner Quarantiner
+	registry    *metric.Registry
 
 	worker        db.Worker
 	httpProxyURL  string
@@ -101,6 +110,8 @@ func (p *containerProvider) FindOrCreateContainer(
 		createdContainer  db.CreatedContainer
 		creatingContainer db.CreatingContainer
 		err               error
+
+		waitingForQuota bool
 	)
 
 	for {
@@ -137,11 +148,25 @@ func (p *containerProvider) FindOrCreateContainer(
 				owner,
 				metadata,
 			)
+			if quotaErr, ok := err.(db.ContainerQuotaReachedError); ok {
+				err = p.waitForContainerQuota(ctx, logger, delegate, quotaErr, waitingForQuota)
+				if err != nil {
+					return nil, err
+				}
+
+				waitingForQuota = true
+				continue
+			}
+
 			if err != nil {
 				logger.Error("failed-to-create-container-in-db", err)
 				return nil, err
 			}
 
+			if waitingForQuota {
+				fmt.Fprintf(delegate.Stdout(), "team container quota freed up\n")
+			}
+
 			logger = logger.WithData(lager.Data{"container": creatingContainer.Handle()})
 			logger.Debug("created-creating-container-in-db")
 		} else {
@@ -195,12 +220,14 @@ func (p *containerProvider) FindOrCreateContainer(
 					logger.Error("failed-to-mark-container-as-failed", err)
 				}
 				metric.FailedContainers.Inc()
+				p.quarantiner.RecordFailure(logger, p.worker)
 
 				logger.Error("failed-to-create-container-in-garden", err)
 				return nil, err
 			}
 
 			metric.ContainersCreated.Inc()
+			p.quarantiner.RecordSuccess(logger, p.worker)
 
 			logger.Debug("created-container-in-garden")
 		} else {
@@ -226,6 +253,41 @@ func (p *containerProvider) FindOrCreateContainer(
 	}
 }
 
+// waitForContainerQuota holds the creation of a container for a build while
+// the team has as many containers as its quota allows, letting the build know
+// that it is waiting.
+func (p *containerProvider) waitForContainerQuota(
+	ctx context.Context,
+	logger lager.Logger,
+	delegate ImageFetchingDelegate,
+	quotaErr db.ContainerQuotaReachedError,
+	waiting bool,
+) error {
+	metric.TeamQuotaSaturation{
+		TeamName: quotaErr.TeamName,
+		Resource: "containers",
+		Used:     quotaErr.Containers,
+		Limit:    quotaErr.MaxContainers,
+	}.Emit(logger, p.registry)
+
+	if !waiting {
+		logger.Info("waiting-for-team-container-quota", lager.Data{
+			"containers":       quotaErr.Containers,
+			"max-containers":   quotaErr.MaxContainers,
+			"build-containers": quotaErr.BuildContainers,
+		})
+
+		fmt.Fprintf(delegate.Stdout(), "waiting for team container quota (%d/%d containers in use)...\n", quotaErr.Containers, quotaErr.MaxContainers)
+	}
+
+	select {
+	case <-time.After(containerQuotaRetryDelay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func (p *containerProvider) FindCreatedContainerByHandle(
 	logger lager.Logger,
 	handle string,
@@ -476,6 +538,10 @@ func (p *containerProvider) createGardenContainer(
 		gardenProperties[userPropertyName] = fetchedImage.Metadata.User
 	}
 
+	if spec.Limits.Network == atc.ContainerNetworkNone {
+		gardenProperties[networkPropertyName] = atc.ContainerNetworkNone
+	}
+
 	env := append(fetchedImage.Metadata.Env, spec.Env...)
 
 	if p.httpProxyURL != "" {
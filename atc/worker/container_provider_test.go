@@ -43,6 +43,7 @@ var _ = Describe("ContainerProvider", func() {
 		fakeDBWorker           *dbfakes.FakeWorker
 		fakeDBVolumeRepository *dbfakes.FakeVolumeRepository
 		fakeLockFactory        *lockfakes.FakeLockFactory
+		fakeQuarantiner        *workerfakes.FakeQuarantiner
 
 		containerProvider ContainerProvider
 
@@ -93,6 +94,7 @@ var _ = Describe("ContainerProvider", func() {
 		}, nil)
 		fakeImageFactory.GetImageReturns(fakeImage, nil)
 		fakeLockFactory = new(lockfakes.FakeLockFactory)
+		fakeQuarantiner = new(workerfakes.FakeQuarantiner)
 
 		fakeDBTeamFactory := new(dbfakes.FakeTeamFactory)
 		fakeDBTeam = new(dbfakes.FakeTeam)
@@ -114,6 +116,8 @@ var _ = Describe("ContainerProvider", func() {
 			fakeDBVolumeRepository,
 			fakeDBTeamFactory,
 			fakeLockFactory,
+			fakeQuarantiner,
+			nil,
 		)
 
 		fakeLocalInput = new(workerfakes.FakeInputSource)
@@ -369,6 +373,62 @@ var _ = Describe("ContainerProvider", func() {
 				fakeDBWorker.FindContainerOnWorkerReturns(nil, nil, nil)
 			})
 
+			Context("when the team has reached its container quota", func() {
+				var (
+					stdout   *bytes.Buffer
+					quotaErr db.ContainerQuotaReachedError
+				)
+
+				BeforeEach(func() {
+					containerMetadata.BuildID = 42
+
+					stdout = new(bytes.Buffer)
+					fakeImageFetchingDelegate.StdoutReturns(stdout)
+
+					quotaErr = db.ContainerQuotaReachedError{
+						TeamName:      "some-team",
+						Containers:    10,
+						MaxContainers: 10,
+					}
+
+					fakeDBWorker.CreateContainerStub = func(db.ContainerOwner, db.ContainerMetadata) (db.CreatingContainer, error) {
+						return nil, quotaErr
+					}
+				})
+
+				Context("when the build has no containers yet", func() {
+					var cancel context.CancelFunc
+
+					BeforeEach(func() {
+						ctx, cancel = context.WithCancel(ctx)
+						cancel()
+					})
+
+					It("waits, letting the build know, until it is interrupted", func() {
+						Expect(findOrCreateErr).To(Equal(context.Canceled))
+						Expect(stdout.String()).To(Equal("waiting for team container quota (10/10 containers in use)...\n"))
+						Expect(fakeGardenClient.CreateCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the build already has containers", func() {
+					var cancel context.CancelFunc
+
+					BeforeEach(func() {
+						quotaErr.BuildContainers = 2
+
+						ctx, cancel = context.WithCancel(ctx)
+						cancel()
+					})
+
+					It("waits all the same", func() {
+						Expect(findOrCreateErr).To(Equal(context.Canceled))
+						Expect(stdout.String()).To(Equal("waiting for team container quota (10/10 containers in use)...\n"))
+						Expect(fakeGardenClient.CreateCallCount()).To(BeZero())
+					})
+				})
+			})
+
 			Context("when the certs volume does not exist on the worker", func() {
 				BeforeEach(func() {
 					fakeBaggageclaimClient.LookupVolumeReturns(nil, false, nil)
@@ -454,6 +514,25 @@ var _ = Describe("ContainerProvider", func() {
 				}))
 			})
 
+			Context("when the spec limits disk, pids and network", func() {
+				BeforeEach(func() {
+					disk := uint64(2048)
+					pids := uint64(64)
+					containerSpec.Limits.Disk = &disk
+					containerSpec.Limits.Pids = &pids
+					containerSpec.Limits.Network = atc.ContainerNetworkNone
+				})
+
+				It("creates the container in garden with the limits", func() {
+					Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+
+					actualSpec := fakeGardenClient.CreateArgsForCall(0)
+					Expect(actualSpec.Limits.Disk).To(Equal(garden.DiskLimits{ByteHard: 2048, Scope: garden.DiskLimitScopeExclusive}))
+					Expect(actualSpec.Limits.Pid).To(Equal(garden.PidLimits{Max: 64}))
+					Expect(actualSpec.Properties).To(HaveKeyWithValue("network", "none"))
+				})
+			})
+
 			Context("when the input and output destination paths overlap", func() {
 				var (
 					fakeRemoteInputUnderInput    *workerfakes.FakeInputSource
@@ -820,6 +899,12 @@ var _ = Describe("ContainerProvider", func() {
 				Expect(fakeCreatingContainer.CreatedCallCount()).To(Equal(1))
 			})
 
+			It("records the successful creation against the worker", func() {
+				Expect(fakeQuarantiner.RecordSuccessCallCount()).To(Equal(1))
+				_, recordedWorker := fakeQuarantiner.RecordSuccessArgsForCall(0)
+				Expect(recordedWorker).To(Equal(fakeDBWorker))
+			})
+
 			Context("when the fetched image was privileged", func() {
 				BeforeEach(func() {
 					fakeImage.FetchForContainerReturns(FetchedImage{
@@ -907,6 +992,12 @@ var _ = Describe("ContainerProvider", func() {
 				It("marks the container as failed", func() {
 					Expect(fakeCreatingContainer.FailedCallCount()).To(Equal(1))
 				})
+
+				It("records the failure against the worker", func() {
+					Expect(fakeQuarantiner.RecordFailureCallCount()).To(Equal(1))
+					_, recordedWorker := fakeQuarantiner.RecordFailureArgsForCall(0)
+					Expect(recordedWorker).To(Equal(fakeDBWorker))
+				})
 			})
 		})
 	})
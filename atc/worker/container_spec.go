@@ -15,6 +15,10 @@ type WorkerSpec struct {
 	Tags          []string
 	TeamID        int
 	ResourceTypes creds.VersionedResourceTypes
+
+	// Capabilities the worker must advertise, e.g. to enforce the limits of
+	// the container.
+	Capabilities []string
 }
 
 type ContainerSpec struct {
@@ -79,6 +83,14 @@ type ImageResource struct {
 type ContainerLimits struct {
 	CPU    *uint64
 	Memory *uint64
+	Disk   *uint64
+	Pids   *uint64
+
+	// Network is atc.ContainerNetworkNone to create the container without a
+	// network. It is passed to Garden as the "network" property, as Garden has
+	// no such limit. Only workers advertising atc.WorkerCapabilityNetworkNone
+	// honour it.
+	Network string
 }
 
 var GardenLimitDefault = uint64(0)
@@ -95,6 +107,12 @@ func (cl ContainerLimits) ToGardenLimits() garden.Limits {
 	} else {
 		gardenLimits.Memory = garden.MemoryLimits{LimitInBytes: *cl.Memory}
 	}
+	if cl.Disk != nil && *cl.Disk > 0 {
+		gardenLimits.Disk = garden.DiskLimits{ByteHard: *cl.Disk, Scope: garden.DiskLimitScopeExclusive}
+	}
+	if cl.Pids != nil && *cl.Pids > 0 {
+		gardenLimits.Pid = garden.PidLimits{Max: *cl.Pids}
+	}
 	return gardenLimits
 }
 
@@ -113,5 +131,9 @@ func (spec WorkerSpec) Description() string {
 		attrs = append(attrs, fmt.Sprintf("tag '%s'", tag))
 	}
 
+	for _, capability := range spec.Capabilities {
+		attrs = append(attrs, fmt.Sprintf("capability '%s'", capability))
+	}
+
 	return strings.Join(attrs, ", ")
 }
@@ -8,6 +8,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	bclient "github.com/concourse/baggageclaim/client"
 	"github.com/concourse/concourse/atc/db/lock"
+	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/worker/transport"
 	"github.com/concourse/retryhttp"
 	"github.com/cppforlife/go-semi-semantic/version"
@@ -28,6 +29,8 @@ type dbWorkerProvider struct {
 	dbWorkerFactory                   db.WorkerFactory
 	workerVersion                     version.Version
 	baggageclaimResponseHeaderTimeout time.Duration
+	quarantiner                       Quarantiner
+	registry                          *metric.Registry
 }
 
 func NewDBWorkerProvider(
@@ -43,6 +46,8 @@ func NewDBWorkerProvider(
 	workerFactory db.WorkerFactory,
 	workerVersion version.Version,
 	baggageclaimResponseHeaderTimeout time.Duration,
+	quarantiner Quarantiner,
+	registry *metric.Registry,
 ) WorkerProvider {
 	return &dbWorkerProvider{
 		lockFactory:                       lockFactory,
@@ -57,6 +62,8 @@ func NewDBWorkerProvider(
 		dbWorkerFactory:                   workerFactory,
 		workerVersion:                     workerVersion,
 		baggageclaimResponseHeaderTimeout: baggageclaimResponseHeaderTimeout,
+		quarantiner:                       quarantiner,
+		registry:                          registry,
 	}
 }
 
@@ -195,6 +202,7 @@ func (provider *dbWorkerProvider) NewGardenWorker(logger lager.Logger, tikTok cl
 		provider.dbVolumeRepository,
 		provider.dbWorkerBaseResourceTypeFactory,
 		provider.dbWorkerTaskCacheFactory,
+		provider.quarantiner,
 	)
 
 	containerProvider := NewContainerProvider(
@@ -205,6 +213,8 @@ func (provider *dbWorkerProvider) NewGardenWorker(logger lager.Logger, tikTok cl
 		provider.dbVolumeRepository,
 		provider.dbTeamFactory,
 		provider.lockFactory,
+		provider.quarantiner,
+		provider.registry,
 	)
 
 	return NewGardenWorker(
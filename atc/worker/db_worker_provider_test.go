@@ -173,6 +173,8 @@ var _ = Describe("DBProvider", func() {
 			fakeDBWorkerFactory,
 			wantWorkerVersion,
 			baggageclaimResponseHeaderTimeout,
+			new(workerfakes.FakeQuarantiner),
+			nil,
 		)
 		baggageclaimURL = baggageclaimServer.URL()
 	})
@@ -218,7 +220,7 @@ var _ = Describe("DBProvider", func() {
 				Expect([]int{workers[0].BuildContainers(), workers[1].BuildContainers()}).To(ConsistOf(57, 68))
 			})
 
-			Context("when some of the workers returned are stalled or landing", func() {
+			Context("when some of the workers returned are stalled, landing or quarantined", func() {
 				BeforeEach(func() {
 					landingWorker := new(dbfakes.FakeWorker)
 					landingWorker.NameReturns("landing-worker")
@@ -238,11 +240,21 @@ var _ = Describe("DBProvider", func() {
 					stalledWorker.ResourceTypesReturns([]atc.WorkerResourceType{
 						{Type: "some-resource-b", Image: "some-image-b"}})
 
+					quarantinedWorker := new(dbfakes.FakeWorker)
+					quarantinedWorker.NameReturns("quarantined-worker")
+					quarantinedWorker.GardenAddrReturns(&gardenAddr)
+					quarantinedWorker.BaggageclaimURLReturns(&baggageclaimURL)
+					quarantinedWorker.StateReturns(db.WorkerStateQuarantined)
+					quarantinedWorker.ActiveContainersReturns(3)
+					quarantinedWorker.ResourceTypesReturns([]atc.WorkerResourceType{
+						{Type: "some-resource-b", Image: "some-image-b"}})
+
 					fakeDBWorkerFactory.WorkersReturns(
 						[]db.Worker{
 							fakeWorker1,
 							stalledWorker,
 							landingWorker,
+							quarantinedWorker,
 						}, nil)
 				})
 
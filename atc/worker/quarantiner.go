@@ -0,0 +1,138 @@
+package worker
+
+import (
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/metric"
+)
+
+//go:generate counterfeiter . Quarantiner
+
+// Quarantiner tracks container and volume creation outcomes per worker and
+// takes workers whose failure rate crosses the configured threshold out of
+// placement.
+type Quarantiner interface {
+	RecordSuccess(lager.Logger, db.Worker)
+	RecordFailure(lager.Logger, db.Worker)
+}
+
+type QuarantineConfig struct {
+	// Minimum number of failures within the window before a worker is
+	// considered for quarantine. Zero disables quarantining.
+	FailureThreshold int
+
+	// Ratio of failures to attempts within the window above which a worker
+	// is quarantined.
+	FailureRate float64
+
+	Window   time.Duration
+	Duration time.Duration
+}
+
+type quarantiner struct {
+	clock    clock.Clock
+	config   QuarantineConfig
+	registry *metric.Registry
+
+	attemptsLock sync.Mutex
+	attempts     map[string][]attempt
+}
+
+type attempt struct {
+	time   time.Time
+	failed bool
+}
+
+func NewQuarantiner(clock clock.Clock, config QuarantineConfig, registry *metric.Registry) Quarantiner {
+	return &quarantiner{
+		clock:    clock,
+		config:   config,
+		registry: registry,
+		attempts: map[string][]attempt{},
+	}
+}
+
+func (q *quarantiner) RecordSuccess(logger lager.Logger, dbWorker db.Worker) {
+	if q.config.FailureThreshold == 0 {
+		return
+	}
+
+	q.attemptsLock.Lock()
+	defer q.attemptsLock.Unlock()
+
+	q.record(dbWorker.Name(), false)
+}
+
+func (q *quarantiner) RecordFailure(logger lager.Logger, dbWorker db.Worker) {
+	if q.config.FailureThreshold == 0 {
+		return
+	}
+
+	q.attemptsLock.Lock()
+	defer q.attemptsLock.Unlock()
+
+	attempts := q.record(dbWorker.Name(), true)
+
+	failures := 0
+	for _, a := range attempts {
+		if a.failed {
+			failures++
+		}
+	}
+
+	if failures < q.config.FailureThreshold {
+		return
+	}
+
+	if float64(failures)/float64(len(attempts)) < q.config.FailureRate {
+		return
+	}
+
+	logger = logger.Session("quarantine", lager.Data{
+		"worker":   dbWorker.Name(),
+		"failures": failures,
+		"attempts": len(attempts),
+	})
+
+	quarantined, err := dbWorker.Quarantine(q.config.Duration)
+	if err != nil {
+		logger.Error("failed-to-quarantine-worker", err)
+		return
+	}
+
+	delete(q.attempts, dbWorker.Name())
+
+	if !quarantined {
+		logger.Debug("worker-not-running")
+		return
+	}
+
+	logger.Info("quarantined-worker", lager.Data{"duration": q.config.Duration.String()})
+
+	metric.WorkerQuarantined{
+		WorkerName: dbWorker.Name(),
+		Failures:   failures,
+		Attempts:   len(attempts),
+	}.Emit(logger, q.registry)
+}
+
+func (q *quarantiner) record(workerName string, failed bool) []attempt {
+	now := q.clock.Now()
+
+	recent := []attempt{}
+	for _, a := range q.attempts[workerName] {
+		if now.Sub(a.time) < q.config.Window {
+			recent = append(recent, a)
+		}
+	}
+
+	recent = append(recent, attempt{time: now, failed: failed})
+
+	q.attempts[workerName] = recent
+
+	return recent
+}
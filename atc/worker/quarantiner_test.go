@@ -0,0 +1,170 @@
+package worker_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/concourse/concourse/atc/worker"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Quarantiner", func() {
+	var (
+		logger      *lagertest.TestLogger
+		fakeClock   *fakeclock.FakeClock
+		fakeWorker  *dbfakes.FakeWorker
+		config      QuarantineConfig
+		quarantiner Quarantiner
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+
+		fakeWorker = new(dbfakes.FakeWorker)
+		fakeWorker.NameReturns("some-worker")
+		fakeWorker.QuarantineReturns(true, nil)
+
+		config = QuarantineConfig{
+			FailureThreshold: 3,
+			FailureRate:      0.5,
+			Window:           10 * time.Minute,
+			Duration:         30 * time.Minute,
+		}
+	})
+
+	JustBeforeEach(func() {
+		quarantiner = NewQuarantiner(fakeClock, config, nil)
+	})
+
+	Context("when failures reach the threshold and rate", func() {
+		JustBeforeEach(func() {
+			quarantiner.RecordSuccess(logger, fakeWorker)
+			quarantiner.RecordFailure(logger, fakeWorker)
+			quarantiner.RecordFailure(logger, fakeWorker)
+			quarantiner.RecordFailure(logger, fakeWorker)
+		})
+
+		It("quarantines the worker for the configured duration", func() {
+			Expect(fakeWorker.QuarantineCallCount()).To(Equal(1))
+			Expect(fakeWorker.QuarantineArgsForCall(0)).To(Equal(30 * time.Minute))
+		})
+
+		It("logs the quarantine", func() {
+			Expect(logger.LogMessages()).To(ContainElement("test.quarantine.quarantined-worker"))
+		})
+
+		It("starts counting afresh", func() {
+			quarantiner.RecordFailure(logger, fakeWorker)
+			Expect(fakeWorker.QuarantineCallCount()).To(Equal(1))
+		})
+
+		Context("when the worker is no longer running", func() {
+			BeforeEach(func() {
+				fakeWorker.QuarantineReturns(false, nil)
+			})
+
+			It("does not report a quarantine", func() {
+				Expect(logger.LogMessages()).ToNot(ContainElement("test.quarantine.quarantined-worker"))
+			})
+
+			It("starts counting afresh", func() {
+				quarantiner.RecordFailure(logger, fakeWorker)
+				Expect(fakeWorker.QuarantineCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when quarantining the worker fails", func() {
+			BeforeEach(func() {
+				fakeWorker.QuarantineReturns(false, errors.New("nope"))
+			})
+
+			It("tries again on the next failure", func() {
+				quarantiner.RecordFailure(logger, fakeWorker)
+				Expect(fakeWorker.QuarantineCallCount()).To(Equal(2))
+			})
+		})
+	})
+
+	Context("when failures are below the threshold", func() {
+		JustBeforeEach(func() {
+			quarantiner.RecordFailure(logger, fakeWorker)
+			quarantiner.RecordFailure(logger, fakeWorker)
+		})
+
+		It("does not quarantine the worker", func() {
+			Expect(fakeWorker.QuarantineCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when failures are below the failure rate", func() {
+		JustBeforeEach(func() {
+			for i := 0; i < 4; i++ {
+				quarantiner.RecordSuccess(logger, fakeWorker)
+			}
+
+			quarantiner.RecordFailure(logger, fakeWorker)
+			quarantiner.RecordFailure(logger, fakeWorker)
+			quarantiner.RecordFailure(logger, fakeWorker)
+		})
+
+		It("does not quarantine the worker", func() {
+			Expect(fakeWorker.QuarantineCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when earlier failures fall outside the window", func() {
+		JustBeforeEach(func() {
+			quarantiner.RecordFailure(logger, fakeWorker)
+			quarantiner.RecordFailure(logger, fakeWorker)
+
+			fakeClock.Increment(11 * time.Minute)
+
+			quarantiner.RecordFailure(logger, fakeWorker)
+		})
+
+		It("does not quarantine the worker", func() {
+			Expect(fakeWorker.QuarantineCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when failures are spread across workers", func() {
+		var otherWorker *dbfakes.FakeWorker
+
+		JustBeforeEach(func() {
+			otherWorker = new(dbfakes.FakeWorker)
+			otherWorker.NameReturns("other-worker")
+
+			quarantiner.RecordFailure(logger, fakeWorker)
+			quarantiner.RecordFailure(logger, otherWorker)
+			quarantiner.RecordFailure(logger, fakeWorker)
+			quarantiner.RecordFailure(logger, otherWorker)
+		})
+
+		It("tracks each worker separately", func() {
+			Expect(fakeWorker.QuarantineCallCount()).To(BeZero())
+			Expect(otherWorker.QuarantineCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the failure threshold is zero", func() {
+		BeforeEach(func() {
+			config.FailureThreshold = 0
+		})
+
+		JustBeforeEach(func() {
+			for i := 0; i < 10; i++ {
+				quarantiner.RecordFailure(logger, fakeWorker)
+			}
+		})
+
+		It("never quarantines the worker", func() {
+			Expect(fakeWorker.QuarantineCallCount()).To(BeZero())
+		})
+	})
+})
@@ -27,6 +27,7 @@ type Volume interface {
 	InitializeResourceCache(db.UsedResourceCache) error
 	InitializeTaskCache(lager.Logger, int, string, string, bool) error
 	InitializeArtifact(name string, buildID int) (db.WorkerArtifact, error)
+	InitializeRetainedArtifact(name string, buildID int) (db.WorkerArtifact, error)
 
 	CreateChildForContainer(db.CreatingContainer, string) (db.CreatingVolume, error)
 
@@ -117,6 +118,10 @@ func (v *volume) InitializeArtifact(name string, buildID int) (db.WorkerArtifact
 	return v.dbVolume.InitializeArtifact(name, buildID)
 }
 
+func (v *volume) InitializeRetainedArtifact(name string, buildID int) (db.WorkerArtifact, error) {
+	return v.dbVolume.InitializeRetainedArtifact(name, buildID)
+}
+
 func (v *volume) InitializeTaskCache(
 	logger lager.Logger,
 	jobID int,
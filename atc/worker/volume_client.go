@@ -108,6 +108,7 @@ type volumeClient struct {
 	dbWorkerTaskCacheFactory        db.WorkerTaskCacheFactory
 	clock                           clock.Clock
 	dbWorker                        db.Worker
+	quarantiner                     Quarantiner
 }
 
 func NewVolumeClient(
@@ -119,6 +120,7 @@ func NewVolumeClient(
 	dbVolumeRepository db.VolumeRepository,
 	dbWorkerBaseResourceTypeFactory db.WorkerBaseResourceTypeFactory,
 	dbWorkerTaskCacheFactory db.WorkerTaskCacheFactory,
+	quarantiner Quarantiner,
 ) VolumeClient {
 	return &volumeClient{
 		baggageclaimClient:              baggageclaimClient,
@@ -128,6 +130,7 @@ func NewVolumeClient(
 		dbWorkerTaskCacheFactory:        dbWorkerTaskCacheFactory,
 		clock:                           clock,
 		dbWorker:                        dbWorker,
+		quarantiner:                     quarantiner,
 	}
 }
 
@@ -463,11 +466,13 @@ func (c *volumeClient) findOrCreateVolume(
 			}
 
 			metric.FailedVolumes.Inc()
+			c.quarantiner.RecordFailure(logger, c.dbWorker)
 
 			return nil, err
 		}
 
 		metric.VolumesCreated.Inc()
+		c.quarantiner.RecordSuccess(logger, c.dbWorker)
 	}
 
 	createdVolume, err = creatingVolume.Created()
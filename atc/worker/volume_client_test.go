@@ -31,6 +31,7 @@ var _ = Describe("VolumeClient", func() {
 		fakeWorkerTaskCacheFactory        *dbfakes.FakeWorkerTaskCacheFactory
 		fakeClock                         *fakeclock.FakeClock
 		dbWorker                          *dbfakes.FakeWorker
+		fakeQuarantiner                   *workerfakes.FakeQuarantiner
 
 		volumeClient worker.VolumeClient
 	)
@@ -41,6 +42,7 @@ var _ = Describe("VolumeClient", func() {
 		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
 		dbWorker = new(dbfakes.FakeWorker)
 		dbWorker.NameReturns("some-worker")
+		fakeQuarantiner = new(workerfakes.FakeQuarantiner)
 
 		testLogger = lagertest.NewTestLogger("test")
 
@@ -58,6 +60,7 @@ var _ = Describe("VolumeClient", func() {
 			fakeDBVolumeRepository,
 			fakeWorkerBaseResourceTypeFactory,
 			fakeWorkerTaskCacheFactory,
+			fakeQuarantiner,
 		)
 	})
 
@@ -250,6 +253,12 @@ var _ = Describe("VolumeClient", func() {
 				Expect(fakeBaggageclaimClient.CreateVolumeCallCount()).To(Equal(1))
 			})
 
+			It("records the successful creation against the worker", func() {
+				Expect(fakeQuarantiner.RecordSuccessCallCount()).To(Equal(1))
+				_, recordedWorker := fakeQuarantiner.RecordSuccessArgsForCall(0)
+				Expect(recordedWorker).To(Equal(dbWorker))
+			})
+
 			Context("when creating the volume in baggageclaim fails", func() {
 				BeforeEach(func() {
 					fakeBaggageclaimClient.CreateVolumeReturns(nil, errors.New("failed to create volume, oh no"))
@@ -258,6 +267,12 @@ var _ = Describe("VolumeClient", func() {
 				It("marks the creating volume for removal", func() {
 					Expect(fakeCreatingVolume.FailedCallCount()).To(Equal(1))
 				})
+
+				It("records the failure against the worker", func() {
+					Expect(fakeQuarantiner.RecordFailureCallCount()).To(Equal(1))
+					_, recordedWorker := fakeQuarantiner.RecordFailureArgsForCall(0)
+					Expect(recordedWorker).To(Equal(dbWorker))
+				})
 			})
 		})
 	})
@@ -765,6 +780,7 @@ var _ = Describe("VolumeClient", func() {
 				fakeDBVolumeRepository,
 				fakeWorkerBaseResourceTypeFactory,
 				fakeWorkerTaskCacheFactory,
+				fakeQuarantiner,
 			).LookupVolume(testLogger, handle)
 		})
 
@@ -15,6 +15,7 @@ import (
 )
 
 const userPropertyName = "user"
+const networkPropertyName = "network"
 
 //go:generate counterfeiter . Worker
 
@@ -254,6 +255,10 @@ func (worker *gardenWorker) Satisfies(logger lager.Logger, spec WorkerSpec) bool
 		return false
 	}
 
+	if !worker.hasCapabilities(spec.Capabilities) {
+		return false
+	}
+
 	return true
 }
 
@@ -311,3 +316,18 @@ insert_coin:
 
 	return true
 }
+
+func (worker *gardenWorker) hasCapabilities(capabilities []string) bool {
+	workerCapabilities := map[string]bool{}
+	for _, capability := range worker.dbWorker.Capabilities() {
+		workerCapabilities[capability] = true
+	}
+
+	for _, capability := range capabilities {
+		if !workerCapabilities[capability] {
+			return false
+		}
+	}
+
+	return true
+}
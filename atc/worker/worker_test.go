@@ -26,6 +26,7 @@ var _ = Describe("Worker", func() {
 		resourceTypes         []atc.WorkerResourceType
 		platform              string
 		tags                  atc.Tags
+		capabilities          []string
 		teamID                int
 		ephemeral             bool
 		workerName            string
@@ -50,6 +51,7 @@ var _ = Describe("Worker", func() {
 		}
 		platform = "some-platform"
 		tags = atc.Tags{"some", "tags"}
+		capabilities = []string{atc.WorkerCapabilityPidsLimit}
 		teamID = 17
 		ephemeral = true
 		workerName = "some-worker"
@@ -69,6 +71,7 @@ var _ = Describe("Worker", func() {
 		dbWorker.ResourceTypesReturns(resourceTypes)
 		dbWorker.PlatformReturns(platform)
 		dbWorker.TagsReturns(tags)
+		dbWorker.CapabilitiesReturns(capabilities)
 		dbWorker.EphemeralReturns(ephemeral)
 		dbWorker.TeamIDReturns(teamID)
 		dbWorker.NameReturns(workerName)
@@ -329,6 +332,26 @@ var _ = Describe("Worker", func() {
 					Expect(satisfies).To(BeFalse())
 				})
 			})
+
+			Context("when the worker has the requested capabilities", func() {
+				BeforeEach(func() {
+					spec.Capabilities = []string{atc.WorkerCapabilityPidsLimit}
+				})
+
+				It("returns true", func() {
+					Expect(satisfies).To(BeTrue())
+				})
+			})
+
+			Context("when the worker lacks any of the requested capabilities", func() {
+				BeforeEach(func() {
+					spec.Capabilities = []string{atc.WorkerCapabilityPidsLimit, atc.WorkerCapabilityNetworkNone}
+				})
+
+				It("returns false", func() {
+					Expect(satisfies).To(BeFalse())
+				})
+			})
 		})
 
 		Context("when the platform is incompatible", func() {
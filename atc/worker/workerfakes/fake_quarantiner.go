@@ -0,0 +1,119 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package workerfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+type FakeQuarantiner struct {
+	RecordFailureStub        func(lager.Logger, db.Worker)
+	recordFailureMutex       sync.RWMutex
+	recordFailureArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 db.Worker
+	}
+	RecordSuccessStub        func(lager.Logger, db.Worker)
+	recordSuccessMutex       sync.RWMutex
+	recordSuccessArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 db.Worker
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeQuarantiner) RecordFailure(arg1 lager.Logger, arg2 db.Worker) {
+	fake.recordFailureMutex.Lock()
+	fake.recordFailureArgsForCall = append(fake.recordFailureArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 db.Worker
+	}{arg1, arg2})
+	fake.recordInvocation("RecordFailure", []interface{}{arg1, arg2})
+	fake.recordFailureMutex.Unlock()
+	if fake.RecordFailureStub != nil {
+		fake.RecordFailureStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeQuarantiner) RecordFailureCallCount() int {
+	fake.recordFailureMutex.RLock()
+	defer fake.recordFailureMutex.RUnlock()
+	return len(fake.recordFailureArgsForCall)
+}
+
+func (fake *FakeQuarantiner) RecordFailureCalls(stub func(lager.Logger, db.Worker)) {
+	fake.recordFailureMutex.Lock()
+	defer fake.recordFailureMutex.Unlock()
+	fake.RecordFailureStub = stub
+}
+
+func (fake *FakeQuarantiner) RecordFailureArgsForCall(i int) (lager.Logger, db.Worker) {
+	fake.recordFailureMutex.RLock()
+	defer fake.recordFailureMutex.RUnlock()
+	argsForCall := fake.recordFailureArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuarantiner) RecordSuccess(arg1 lager.Logger, arg2 db.Worker) {
+	fake.recordSuccessMutex.Lock()
+	fake.recordSuccessArgsForCall = append(fake.recordSuccessArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 db.Worker
+	}{arg1, arg2})
+	fake.recordInvocation("RecordSuccess", []interface{}{arg1, arg2})
+	fake.recordSuccessMutex.Unlock()
+	if fake.RecordSuccessStub != nil {
+		fake.RecordSuccessStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeQuarantiner) RecordSuccessCallCount() int {
+	fake.recordSuccessMutex.RLock()
+	defer fake.recordSuccessMutex.RUnlock()
+	return len(fake.recordSuccessArgsForCall)
+}
+
+func (fake *FakeQuarantiner) RecordSuccessCalls(stub func(lager.Logger, db.Worker)) {
+	fake.recordSuccessMutex.Lock()
+	defer fake.recordSuccessMutex.Unlock()
+	fake.RecordSuccessStub = stub
+}
+
+func (fake *FakeQuarantiner) RecordSuccessArgsForCall(i int) (lager.Logger, db.Worker) {
+	fake.recordSuccessMutex.RLock()
+	defer fake.recordSuccessMutex.RUnlock()
+	argsForCall := fake.recordSuccessArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeQuarantiner) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.recordFailureMutex.RLock()
+	defer fake.recordFailureMutex.RUnlock()
+	fake.recordSuccessMutex.RLock()
+	defer fake.recordSuccessMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeQuarantiner) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ worker.Quarantiner = new(FakeQuarantiner)
@@ -81,6 +81,20 @@ type FakeVolume struct {
 	initializeResourceCacheReturnsOnCall map[int]struct {
 		result1 error
 	}
+	InitializeRetainedArtifactStub        func(string, int) (db.WorkerArtifact, error)
+	initializeRetainedArtifactMutex       sync.RWMutex
+	initializeRetainedArtifactArgsForCall []struct {
+		arg1 string
+		arg2 int
+	}
+	initializeRetainedArtifactReturns struct {
+		result1 db.WorkerArtifact
+		result2 error
+	}
+	initializeRetainedArtifactReturnsOnCall map[int]struct {
+		result1 db.WorkerArtifact
+		result2 error
+	}
 	InitializeTaskCacheStub        func(lager.Logger, int, string, string, bool) error
 	initializeTaskCacheMutex       sync.RWMutex
 	initializeTaskCacheArgsForCall []struct {
@@ -524,6 +538,70 @@ func (fake *FakeVolume) InitializeResourceCacheReturnsOnCall(i int, result1 erro
 	}{result1}
 }
 
+func (fake *FakeVolume) InitializeRetainedArtifact(arg1 string, arg2 int) (db.WorkerArtifact, error) {
+	fake.initializeRetainedArtifactMutex.Lock()
+	ret, specificReturn := fake.initializeRetainedArtifactReturnsOnCall[len(fake.initializeRetainedArtifactArgsForCall)]
+	fake.initializeRetainedArtifactArgsForCall = append(fake.initializeRetainedArtifactArgsForCall, struct {
+		arg1 string
+		arg2 int
+	}{arg1, arg2})
+	fake.recordInvocation("InitializeRetainedArtifact", []interface{}{arg1, arg2})
+	fake.initializeRetainedArtifactMutex.Unlock()
+	if fake.InitializeRetainedArtifactStub != nil {
+		return fake.InitializeRetainedArtifactStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.initializeRetainedArtifactReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeVolume) InitializeRetainedArtifactCallCount() int {
+	fake.initializeRetainedArtifactMutex.RLock()
+	defer fake.initializeRetainedArtifactMutex.RUnlock()
+	return len(fake.initializeRetainedArtifactArgsForCall)
+}
+
+func (fake *FakeVolume) InitializeRetainedArtifactCalls(stub func(string, int) (db.WorkerArtifact, error)) {
+	fake.initializeRetainedArtifactMutex.Lock()
+	defer fake.initializeRetainedArtifactMutex.Unlock()
+	fake.InitializeRetainedArtifactStub = stub
+}
+
+func (fake *FakeVolume) InitializeRetainedArtifactArgsForCall(i int) (string, int) {
+	fake.initializeRetainedArtifactMutex.RLock()
+	defer fake.initializeRetainedArtifactMutex.RUnlock()
+	argsForCall := fake.initializeRetainedArtifactArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeVolume) InitializeRetainedArtifactReturns(result1 db.WorkerArtifact, result2 error) {
+	fake.initializeRetainedArtifactMutex.Lock()
+	defer fake.initializeRetainedArtifactMutex.Unlock()
+	fake.InitializeRetainedArtifactStub = nil
+	fake.initializeRetainedArtifactReturns = struct {
+		result1 db.WorkerArtifact
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolume) InitializeRetainedArtifactReturnsOnCall(i int, result1 db.WorkerArtifact, result2 error) {
+	fake.initializeRetainedArtifactMutex.Lock()
+	defer fake.initializeRetainedArtifactMutex.Unlock()
+	fake.InitializeRetainedArtifactStub = nil
+	if fake.initializeRetainedArtifactReturnsOnCall == nil {
+		fake.initializeRetainedArtifactReturnsOnCall = make(map[int]struct {
+			result1 db.WorkerArtifact
+			result2 error
+		})
+	}
+	fake.initializeRetainedArtifactReturnsOnCall[i] = struct {
+		result1 db.WorkerArtifact
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeVolume) InitializeTaskCache(arg1 lager.Logger, arg2 int, arg3 string, arg4 string, arg5 bool) error {
 	fake.initializeTaskCacheMutex.Lock()
 	ret, specificReturn := fake.initializeTaskCacheReturnsOnCall[len(fake.initializeTaskCacheArgsForCall)]
@@ -1007,6 +1085,8 @@ func (fake *FakeVolume) Invocations() map[string][][]interface{} {
 	defer fake.initializeArtifactMutex.RUnlock()
 	fake.initializeResourceCacheMutex.RLock()
 	defer fake.initializeResourceCacheMutex.RUnlock()
+	fake.initializeRetainedArtifactMutex.RLock()
+	defer fake.initializeRetainedArtifactMutex.RUnlock()
 	fake.initializeTaskCacheMutex.RLock()
 	defer fake.initializeTaskCacheMutex.RUnlock()
 	fake.pathMutex.RLock()
@@ -5,4 +5,8 @@ type WorkerArtifact struct {
 	Name      string `json:"name"`
 	BuildID   int    `json:"build_id"`
 	CreatedAt int64  `json:"created_at"`
+
+	// Retained is set for task outputs kept around after their build so that
+	// they can be downloaded later.
+	Retained bool `json:"retained,omitempty"`
 }
@@ -0,0 +1,16 @@
+package atc
+
+type WorkerKey struct {
+	Name        string `json:"name"`
+	TeamName    string `json:"team_name,omitempty"`
+	PublicKey   string `json:"public_key"`
+	Fingerprint string `json:"fingerprint"`
+	CreatedAt   int64  `json:"created_at"`
+}
+
+type WorkerKeyRequest struct {
+	Name string `json:"name"`
+
+	// PublicKey is a single key in SSH authorized_keys format.
+	PublicKey string `json:"public_key"`
+}
@@ -45,6 +45,7 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		case atc.GetBuildPreparation,
 			atc.BuildEvents,
 			atc.GetBuildPlan,
+			atc.GetBuildTimeline,
 			atc.ListBuildArtifacts:
 			newHandler = wrappa.checkBuildReadAccessHandlerFactory.CheckIfPrivateJobHandler(handler, rejector)
 
@@ -56,6 +57,7 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		case atc.PruneWorker,
 			atc.LandWorker,
 			atc.RetireWorker,
+			atc.UnquarantineWorker,
 			atc.ListDestroyingVolumes,
 			atc.ListDestroyingContainers,
 			atc.ReportWorkerContainers,
@@ -90,6 +92,7 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.RegisterWorker,
 			atc.HeartbeatWorker,
 			atc.DeleteWorker,
+			atc.ListWorkerKeys,
 			atc.GetTeam,
 			atc.SetTeam,
 			atc.ListTeamBuilds,
@@ -108,12 +111,21 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListAllJobs,
 			atc.ListAllResources,
 			atc.ListBuilds,
+			atc.StatusEvents,
 			atc.MainJobBadge:
 			newHandler = auth.CheckAuthenticationIfProvidedHandler(handler, rejector)
 
 		case atc.GetLogLevel,
 			atc.SetLogLevel,
-			atc.GetInfoCreds:
+			atc.GetInfoCreds,
+			atc.ListLocalUsers,
+			atc.CreateLocalUser,
+			atc.SetLocalUserPassword,
+			atc.DisableLocalUser,
+			atc.EnableLocalUser,
+			atc.ListAuditEvents,
+			atc.CreateWorkerKey,
+			atc.DeleteWorkerKey:
 			newHandler = auth.CheckAdminHandler(handler, rejector)
 
 		// authorized (requested team matches resource team)
@@ -128,6 +140,9 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.UnpinResource,
 			atc.SetPinCommentOnResource,
 			atc.GetConfig,
+			atc.ListConfigVersions,
+			atc.GetConfigVersion,
+			atc.DiffConfigVersions,
 			atc.GetCC,
 			atc.GetVersionsDB,
 			atc.ListJobInputs,
@@ -142,7 +157,18 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.SaveConfig,
 			atc.ClearTaskCache,
 			atc.CreateArtifact,
-			atc.GetArtifact:
+			atc.GetArtifact,
+			atc.CreateAPIToken,
+			atc.ListAPITokens,
+			atc.RevokeAPIToken,
+			atc.ListTeamWorkerKeys,
+			atc.CreateTeamWorkerKey,
+			atc.DeleteTeamWorkerKey,
+			atc.ListTeamAuditEvents,
+			atc.ListNamedLocks,
+			atc.ReleaseNamedLock,
+			atc.ListHijackSessions,
+			atc.GetHijackSessionRecording:
 			newHandler = auth.CheckAuthorizationHandler(handler, rejector)
 
 		// think about it!
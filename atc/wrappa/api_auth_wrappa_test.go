@@ -152,6 +152,7 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.ListBuildArtifacts:  checksIfPrivateJob(inputHandlers[atc.ListBuildArtifacts]),
 				atc.GetBuildPreparation: checksIfPrivateJob(inputHandlers[atc.GetBuildPreparation]),
 				atc.GetBuildPlan:        checksIfPrivateJob(inputHandlers[atc.GetBuildPlan]),
+				atc.GetBuildTimeline:    checksIfPrivateJob(inputHandlers[atc.GetBuildTimeline]),
 
 				// resource belongs to authorized team
 				atc.AbortBuild: checkWritePermissionForBuild(inputHandlers[atc.AbortBuild]),
@@ -162,6 +163,7 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.ReportWorkerContainers:   checkTeamAccessForWorker(inputHandlers[atc.ReportWorkerContainers]),
 				atc.ReportWorkerVolumes:      checkTeamAccessForWorker(inputHandlers[atc.ReportWorkerVolumes]),
 				atc.RetireWorker:             checkTeamAccessForWorker(inputHandlers[atc.RetireWorker]),
+				atc.UnquarantineWorker:       checkTeamAccessForWorker(inputHandlers[atc.UnquarantineWorker]),
 				atc.ListDestroyingContainers: checkTeamAccessForWorker(inputHandlers[atc.ListDestroyingContainers]),
 				atc.ListDestroyingVolumes:    checkTeamAccessForWorker(inputHandlers[atc.ListDestroyingVolumes]),
 
@@ -194,6 +196,7 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.RegisterWorker:  authenticated(inputHandlers[atc.RegisterWorker]),
 				atc.HeartbeatWorker: authenticated(inputHandlers[atc.HeartbeatWorker]),
 				atc.DeleteWorker:    authenticated(inputHandlers[atc.DeleteWorker]),
+				atc.ListWorkerKeys:  authenticated(inputHandlers[atc.ListWorkerKeys]),
 				atc.GetTeam:         authenticated(inputHandlers[atc.GetTeam]),
 				atc.SetTeam:         authenticated(inputHandlers[atc.SetTeam]),
 				atc.RenameTeam:      authenticated(inputHandlers[atc.RenameTeam]),
@@ -207,14 +210,23 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.ListBuilds:           authenticateIfTokenProvided(inputHandlers[atc.ListBuilds]),
 				atc.ListPipelines:        authenticateIfTokenProvided(inputHandlers[atc.ListPipelines]),
 				atc.ListAllJobs:          authenticateIfTokenProvided(inputHandlers[atc.ListAllJobs]),
+				atc.StatusEvents:         authenticateIfTokenProvided(inputHandlers[atc.StatusEvents]),
 				atc.ListAllResources:     authenticateIfTokenProvided(inputHandlers[atc.ListAllResources]),
 				atc.ListTeams:            authenticateIfTokenProvided(inputHandlers[atc.ListTeams]),
 				atc.MainJobBadge:         authenticateIfTokenProvided(inputHandlers[atc.MainJobBadge]),
 
 				// authenticated and is admin
-				atc.GetLogLevel:  authenticatedAndAdmin(inputHandlers[atc.GetLogLevel]),
-				atc.SetLogLevel:  authenticatedAndAdmin(inputHandlers[atc.SetLogLevel]),
-				atc.GetInfoCreds: authenticatedAndAdmin(inputHandlers[atc.GetInfoCreds]),
+				atc.GetLogLevel:          authenticatedAndAdmin(inputHandlers[atc.GetLogLevel]),
+				atc.SetLogLevel:          authenticatedAndAdmin(inputHandlers[atc.SetLogLevel]),
+				atc.GetInfoCreds:         authenticatedAndAdmin(inputHandlers[atc.GetInfoCreds]),
+				atc.ListLocalUsers:       authenticatedAndAdmin(inputHandlers[atc.ListLocalUsers]),
+				atc.CreateLocalUser:      authenticatedAndAdmin(inputHandlers[atc.CreateLocalUser]),
+				atc.SetLocalUserPassword: authenticatedAndAdmin(inputHandlers[atc.SetLocalUserPassword]),
+				atc.DisableLocalUser:     authenticatedAndAdmin(inputHandlers[atc.DisableLocalUser]),
+				atc.EnableLocalUser:      authenticatedAndAdmin(inputHandlers[atc.EnableLocalUser]),
+				atc.ListAuditEvents:      authenticatedAndAdmin(inputHandlers[atc.ListAuditEvents]),
+				atc.CreateWorkerKey:      authenticatedAndAdmin(inputHandlers[atc.CreateWorkerKey]),
+				atc.DeleteWorkerKey:      authenticatedAndAdmin(inputHandlers[atc.DeleteWorkerKey]),
 
 				// authorized (requested team matches resource team)
 				atc.CheckResource:           authorized(inputHandlers[atc.CheckResource]),
@@ -227,6 +239,9 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.UnpinResource:           authorized(inputHandlers[atc.UnpinResource]),
 				atc.SetPinCommentOnResource: authorized(inputHandlers[atc.SetPinCommentOnResource]),
 				atc.GetConfig:               authorized(inputHandlers[atc.GetConfig]),
+				atc.ListConfigVersions:      authorized(inputHandlers[atc.ListConfigVersions]),
+				atc.GetConfigVersion:        authorized(inputHandlers[atc.GetConfigVersion]),
+				atc.DiffConfigVersions:      authorized(inputHandlers[atc.DiffConfigVersions]),
 				atc.GetCC:                   authorized(inputHandlers[atc.GetCC]),
 				atc.GetVersionsDB:           authorized(inputHandlers[atc.GetVersionsDB]),
 				atc.ListJobInputs:           authorized(inputHandlers[atc.ListJobInputs]),
@@ -243,6 +258,18 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.ClearTaskCache:          authorized(inputHandlers[atc.ClearTaskCache]),
 				atc.CreateArtifact:          authorized(inputHandlers[atc.CreateArtifact]),
 				atc.GetArtifact:             authorized(inputHandlers[atc.GetArtifact]),
+				atc.CreateAPIToken:          authorized(inputHandlers[atc.CreateAPIToken]),
+				atc.ListAPITokens:           authorized(inputHandlers[atc.ListAPITokens]),
+				atc.RevokeAPIToken:          authorized(inputHandlers[atc.RevokeAPIToken]),
+				atc.ListTeamWorkerKeys:      authorized(inputHandlers[atc.ListTeamWorkerKeys]),
+				atc.CreateTeamWorkerKey:     authorized(inputHandlers[atc.CreateTeamWorkerKey]),
+				atc.DeleteTeamWorkerKey:     authorized(inputHandlers[atc.DeleteTeamWorkerKey]),
+				atc.ListTeamAuditEvents:     authorized(inputHandlers[atc.ListTeamAuditEvents]),
+				atc.ListNamedLocks:          authorized(inputHandlers[atc.ListNamedLocks]),
+				atc.ReleaseNamedLock:        authorized(inputHandlers[atc.ReleaseNamedLock]),
+
+				atc.ListHijackSessions:        authorized(inputHandlers[atc.ListHijackSessions]),
+				atc.GetHijackSessionRecording: authorized(inputHandlers[atc.GetHijackSessionRecording]),
 			}
 		})
 
@@ -8,12 +8,14 @@ import (
 )
 
 type APIMetricsWrappa struct {
-	logger lager.Logger
+	logger   lager.Logger
+	registry *metric.Registry
 }
 
-func NewAPIMetricsWrappa(logger lager.Logger) Wrappa {
+func NewAPIMetricsWrappa(logger lager.Logger, registry *metric.Registry) Wrappa {
 	return APIMetricsWrappa{
-		logger: logger,
+		logger:   logger,
+		registry: registry,
 	}
 }
 
@@ -25,7 +27,7 @@ func (wrappa APIMetricsWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		case atc.BuildEvents, atc.DownloadCLI, atc.HijackContainer:
 			wrapped[name] = handler
 		default:
-			wrapped[name] = metric.WrapHandler(wrappa.logger, name, handler)
+			wrapped[name] = metric.WrapHandler(wrappa.logger, wrappa.registry, name, handler)
 		}
 	}
 
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+
+	"code.cloudfoundry.org/garden/server"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/baggageclaim/uidgid"
+	"github.com/concourse/concourse/worker/runtime"
+	"github.com/tedsuo/ifrit"
+)
+
+func (cmd *WorkerCommand) containerdRunner(logger lager.Logger) (ifrit.Runner, error) {
+	initBin := cmd.Containerd.InitBin.Path()
+
+	if initBin == "" {
+		if binDir := discoverAsset("bin"); binDir != "" {
+			initBin = filepath.Join(binDir, "init")
+		}
+	}
+
+	if initBin == "" {
+		return nil, errors.New("--containerd-init-bin must be specified when not using a packaged worker")
+	}
+
+	client, err := runtime.NewContainerdClient(
+		cmd.Containerd.Address,
+		cmd.Containerd.Namespace,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	network := runtime.NetworkCNI
+	if cmd.Containerd.InsecureHostNetwork {
+		logger.Info("sharing-host-network-with-containers")
+		network = runtime.NetworkHost
+	}
+
+	maxID := uidgid.MustGetMaxValidUID()
+	if maxGID := uidgid.MustGetMaxValidGID(); maxGID < maxID {
+		maxID = maxGID
+	}
+
+	backend := runtime.NewBackend(
+		logger.Session("containerd"),
+		client,
+		runtime.BackendConfig{
+			DepotDir:      filepath.Join(cmd.WorkDir.Path(), "containerd"),
+			InitPath:      initBin,
+			Network:       network,
+			MaxID:         uint32(maxID),
+			MaxContainers: cmd.Containerd.MaxContainers,
+			DiskQuota:     cmd.diskQuota(),
+		},
+	)
+
+	server := server.New(
+		"tcp",
+		cmd.bindAddr(),
+		0,
+		backend,
+		logger,
+	)
+
+	return gardenServerRunner{logger, server}, nil
+}
+
+// diskQuota limits volumes through btrfs qgroups, which is only possible when
+// baggageclaim creates each volume as a btrfs subvolume.
+func (cmd *WorkerCommand) diskQuota() runtime.DiskQuota {
+	if cmd.Baggageclaim.Driver != "btrfs" {
+		return nil
+	}
+
+	return runtime.NewBtrfsQuota(cmd.Baggageclaim.BtrfsBin)
+}
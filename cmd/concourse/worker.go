@@ -48,7 +48,10 @@ type WorkerCommand struct {
 
 	ConnectionDrainTimeout time.Duration `long:"connection-drain-timeout" default:"1h" description:"Duration after which a worker should give up draining forwarded connections on shutdown."`
 
-	Garden GardenBackend `group:"Garden Configuration" namespace:"garden"`
+	Runtime string `long:"runtime" default:"guardian" choice:"guardian" choice:"containerd" choice:"houdini" description:"Runtime to use for containers. Houdini is insecure and does not isolate containers."`
+
+	Garden     GardenBackend     `group:"Garden Configuration" namespace:"garden"`
+	Containerd ContainerdRuntime `group:"Containerd Configuration" namespace:"containerd"`
 
 	ExternalGardenURL flag.URL `long:"external-garden-url" description:"API endpoint of an externally managed Garden server to use instead of running the embedded Garden server."`
 
@@ -94,7 +97,10 @@ func (cmd *WorkerCommand) Runner(args []string) (ifrit.Runner, error) {
 		cmd.HealthCheckTimeout,
 	)
 
-	tsaClient := cmd.TSA.Client(atcWorker)
+	tsaClient, err := cmd.TSA.Client(atcWorker)
+	if err != nil {
+		return nil, err
+	}
 
 	beaconRunner := worker.NewBeaconRunner(
 		logger.Session("beacon-runner"),
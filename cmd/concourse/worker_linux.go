@@ -34,6 +34,16 @@ type GardenBackend struct {
 	DNS DNSConfig `group:"DNS Proxy Configuration" namespace:"dns-proxy"`
 }
 
+type ContainerdRuntime struct {
+	Address   string    `long:"address"   default:"/run/containerd/containerd.sock" description:"Path to the containerd socket."`
+	Namespace string    `long:"namespace" default:"concourse"                       description:"Containerd namespace in which to create containers."`
+	InitBin   flag.File `long:"init-bin"                                            description:"Path to the executable run as the first process of every container. Defaults to the packaged 'init'."`
+
+	MaxContainers int `long:"max-containers" default:"250" description:"Maximum number of containers. Zero means no limit."`
+
+	InsecureHostNetwork bool `long:"insecure-host-network" description:"Share the worker's network with containers instead of giving each its own network namespace through CNI. Containers can then reach anything listening on the worker, including Garden and Baggageclaim."`
+}
+
 func (cmd WorkerCommand) lessenRequirements(prefix string, command *flags.Command) {
 	// configured as work-dir/volumes
 	command.FindOptionByLongName(prefix + "baggageclaim-volumes").Required = false
@@ -63,18 +73,50 @@ func (cmd *WorkerCommand) gardenRunner(logger lager.Logger) (atc.Worker, ifrit.R
 	}
 
 	var runner ifrit.Runner
-	if cmd.Garden.UseHoudini {
+	switch {
+	case cmd.Garden.UseHoudini || cmd.Runtime == "houdini":
 		runner, err = cmd.houdiniRunner(logger)
-	} else {
+	case cmd.Runtime == "containerd":
+		runner, err = cmd.containerdRunner(logger)
+	default:
 		runner, err = cmd.gdnRunner(logger)
 	}
 	if err != nil {
 		return atc.Worker{}, nil, err
 	}
 
+	worker.Capabilities = cmd.capabilities()
+
 	return worker, runner, nil
 }
 
+// capabilities lists the container limits that the worker's runtime enforces,
+// so that tasks relying on them are only placed on workers that can.
+func (cmd *WorkerCommand) capabilities() []string {
+	switch {
+	case cmd.gardenIsExternal():
+		// nothing is known about what an external Garden enforces
+		return nil
+	case cmd.Garden.UseHoudini || cmd.Runtime == "houdini":
+		return nil
+	case cmd.Runtime == "containerd":
+		capabilities := []string{
+			atc.WorkerCapabilityPidsLimit,
+			atc.WorkerCapabilityNetworkNone,
+		}
+
+		if cmd.diskQuota() != nil {
+			capabilities = append(capabilities, atc.WorkerCapabilityDiskLimit)
+		}
+
+		return capabilities
+	default:
+		// Guardian limits pids, but ignores disk limits for raw:// rootfses
+		// and has no way of running containers without a network
+		return []string{atc.WorkerCapabilityPidsLimit}
+	}
+}
+
 func (cmd *WorkerCommand) gdnRunner(logger lager.Logger) (ifrit.Runner, error) {
 	if binDir := discoverAsset("bin"); binDir != "" {
 		// ensure packaged 'gdn' executable is available in $PATH
@@ -13,6 +13,8 @@ import (
 
 type GardenBackend struct{}
 
+type ContainerdRuntime struct{}
+
 type Certs struct{}
 
 func (cmd WorkerCommand) lessenRequirements(prefix string, command *flags.Command) {
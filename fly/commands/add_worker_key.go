@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type AddWorkerKeyCommand struct {
+	Name   string       `short:"n" long:"name" required:"true" description:"Name of the key"`
+	Key    atc.PathFlag `short:"k" long:"key" required:"true" description:"Path to the worker's public key, in authorized_keys format"`
+	Global bool         `long:"global" description:"Allow the key to register workers for any team (requires admin)"`
+	Json   bool         `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *AddWorkerKeyCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	publicKey, err := ioutil.ReadFile(string(command.Key))
+	if err != nil {
+		return err
+	}
+
+	request := atc.WorkerKeyRequest{
+		Name:      command.Name,
+		PublicKey: string(publicKey),
+	}
+
+	var key atc.WorkerKey
+	if command.Global {
+		key, err = target.Client().CreateWorkerKey(request)
+	} else {
+		key, err = target.Team().CreateWorkerKey(request)
+	}
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(key)
+	}
+
+	fmt.Printf("added '%s' (%s)\n", key.Name, key.Fingerprint)
+
+	return nil
+}
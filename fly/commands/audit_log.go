@@ -0,0 +1,118 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+type AuditLogCommand struct {
+	AllTeams bool   `short:"a" long:"all-teams" description:"Show events for all teams (requires admin)"`
+	Team     string `short:"n" long:"team" description:"Show events for this team only (requires admin)"`
+	User     string `short:"u" long:"user" description:"Show events caused by this user"`
+	Action   string `long:"action" description:"Show events for this API action, e.g. DeletePipeline"`
+	Since    string `long:"since" description:"Start of the range to filter events"`
+	Until    string `long:"until" description:"End of the range to filter events"`
+	Count    int    `short:"c" long:"count" default:"50" description:"Number of events you want to limit the return to"`
+	Json     bool   `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *AuditLogCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	if command.AllTeams && command.Team != "" {
+		return errors.New("Cannot specify both --all-teams and --team")
+	}
+
+	filter := concourse.AuditEventFilter{
+		Team:   command.Team,
+		User:   command.User,
+		Action: command.Action,
+	}
+
+	if command.Since != "" {
+		filter.From, err = time.ParseInLocation(inputTimeLayout, command.Since, time.Now().Location())
+		if err != nil {
+			return errors.New("Since time should be in the format: " + inputTimeLayout)
+		}
+	}
+
+	if command.Until != "" {
+		filter.To, err = time.ParseInLocation(inputTimeLayout, command.Until, time.Now().Location())
+		if err != nil {
+			return errors.New("Until time should be in the format: " + inputTimeLayout)
+		}
+	}
+
+	if filter.From.After(filter.To) && command.Since != "" && command.Until != "" {
+		return errors.New("Cannot have --since after --until")
+	}
+
+	page := concourse.Page{Limit: command.Count}
+
+	var events []atc.AuditEvent
+	if command.AllTeams || command.Team != "" {
+		events, _, err = target.Client().AuditEvents(filter, page)
+	} else {
+		events, _, err = target.Team().AuditEvents(filter, page)
+	}
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(events)
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "time", Color: color.New(color.Bold)},
+			{Contents: "user", Color: color.New(color.Bold)},
+			{Contents: "team", Color: color.New(color.Bold)},
+			{Contents: "action", Color: color.New(color.Bold)},
+			{Contents: "target", Color: color.New(color.Bold)},
+			{Contents: "status", Color: color.New(color.Bold)},
+			{Contents: "source", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, e := range events {
+		team := ui.TableCell{Contents: e.TeamName}
+		if e.TeamName == "" {
+			team = ui.TableCell{Contents: "none", Color: ui.OffColor}
+		}
+
+		status := ui.TableCell{Contents: strconv.Itoa(e.Status)}
+		if e.Status >= 400 {
+			status.Color = color.New(color.FgRed)
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: time.Unix(e.CreatedAt, 0).Format(timeDateLayout)},
+			{Contents: e.Actor},
+			team,
+			{Contents: e.Action},
+			{Contents: e.Target},
+			status,
+			{Contents: e.SourceIP},
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
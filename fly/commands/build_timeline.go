@@ -0,0 +1,209 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+const timelineChartWidth = 50
+
+type BuildTimelineCommand struct {
+	Job   flaghelpers.JobFlag `short:"j" long:"job"   value-name:"PIPELINE/JOB"  description:"Show the timeline of the latest build of the given job"`
+	Build string              `short:"b" long:"build"                            description:"Show the timeline of a specific build"`
+	Json  bool                `long:"json"                                       description:"Print command result as JSON"`
+}
+
+func (command *BuildTimelineCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var buildID int
+	client := target.Client()
+	if command.Job.JobName != "" || command.Build == "" {
+		build, err := GetBuild(client, target.Team(), command.Job.JobName, command.Build, command.Job.PipelineName)
+		if err != nil {
+			return err
+		}
+		buildID = build.ID
+	} else {
+		buildID, err = strconv.Atoi(command.Build)
+		if err != nil {
+			return err
+		}
+	}
+
+	timeline, found, err := client.BuildTimeline(buildID)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return errors.New("build not found")
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(timeline)
+	}
+
+	start, end := timelineBounds(timeline)
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "step", Color: color.New(color.Bold)},
+			{Contents: "worker", Color: color.New(color.Bold)},
+			{Contents: "cache", Color: color.New(color.Bold)},
+			{Contents: "duration", Color: color.New(color.Bold)},
+			{Contents: "timeline", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, node := range timeline.Nodes {
+		name := node.Type
+		if node.Name != "" {
+			name += " " + node.Name
+		}
+
+		step := ui.TableCell{Contents: strings.Repeat("  ", node.Depth) + name}
+		if node.Critical {
+			step.Color = color.New(color.FgRed)
+		}
+
+		worker := ui.TableCell{Contents: node.Worker}
+		if node.Worker == "" {
+			worker = ui.TableCell{Contents: "n/a", Color: color.New(color.Faint)}
+		}
+
+		cache := ui.TableCell{Contents: string(node.Cache)}
+		if node.Cache == "" {
+			cache = ui.TableCell{Contents: "n/a", Color: color.New(color.Faint)}
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			step,
+			worker,
+			cache,
+			timelineDuration(node),
+			{Contents: timelineBar(node, start, end)},
+		})
+	}
+
+	err = table.Render(os.Stdout, Fly.PrintTableHeaders)
+	if err != nil {
+		return err
+	}
+
+	_, isTTY := ui.ForTTY(os.Stdout)
+	if !isTTY && !Fly.PrintTableHeaders {
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Println("legend: '.' queued, '-' initializing, '=' running; the critical path is shown in " + color.New(color.FgRed).Sprint("red"))
+
+	return nil
+}
+
+// timelineBounds returns the range of time the chart should span. Builds which
+// are still running span up until now.
+func timelineBounds(timeline atc.BuildTimeline) (int64, int64) {
+	start, end := timeline.StartTime, timeline.EndTime
+
+	for _, node := range timeline.Nodes {
+		for _, t := range []int64{node.QueuedTime, node.InitializeTime, node.StartTime} {
+			if t != 0 && (start == 0 || t < start) {
+				start = t
+			}
+		}
+
+		if node.FinishTime > end {
+			end = node.FinishTime
+		}
+	}
+
+	if timeline.EndTime == 0 {
+		end = time.Now().Unix()
+	}
+
+	return start, end
+}
+
+func timelineDuration(node atc.TimelineNode) ui.TableCell {
+	begin := node.InitializeTime
+	if begin == 0 {
+		begin = node.StartTime
+	}
+
+	if begin == 0 {
+		return ui.TableCell{Contents: "n/a", Color: color.New(color.Faint)}
+	}
+
+	if node.FinishTime == 0 {
+		return ui.TableCell{Contents: "running", Color: color.New(color.FgYellow)}
+	}
+
+	return ui.TableCell{Contents: (time.Duration(node.FinishTime-begin) * time.Second).String()}
+}
+
+func timelineBar(node atc.TimelineNode, start, end int64) string {
+	if end <= start {
+		return ""
+	}
+
+	column := func(t int64) int {
+		return int(float64(t-start) / float64(end-start) * timelineChartWidth)
+	}
+
+	bar := []byte(strings.Repeat(" ", timelineChartWidth))
+
+	fill := func(from, to int64, char byte) {
+		if from == 0 {
+			return
+		}
+
+		if to == 0 {
+			to = end
+		}
+
+		for i := column(from); i <= column(to) && i < timelineChartWidth; i++ {
+			bar[i] = char
+		}
+	}
+
+	initialize := node.InitializeTime
+	if initialize == 0 {
+		initialize = node.StartTime
+	}
+
+	if initialize == 0 {
+		return strings.TrimRight(string(bar), " ")
+	}
+
+	initialized := node.StartTime
+	if initialized == 0 {
+		initialized = node.FinishTime
+	}
+
+	fill(node.QueuedTime, initialize, '.')
+	fill(node.InitializeTime, initialized, '-')
+	fill(node.StartTime, node.FinishTime, '=')
+
+	return strings.TrimRight(string(bar), " ")
+}
@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type CreateLocalUserCommand struct {
+	Username string `short:"u" long:"username" required:"true" description:"Username of the local user"`
+	Password string `short:"p" long:"password" description:"Password of the local user (prompted for if not given)"`
+}
+
+func (command *CreateLocalUserCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	password, err := localUserPassword(command.Password)
+	if err != nil {
+		return err
+	}
+
+	user, err := target.Client().CreateLocalUser(command.Username, password)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("created local user '%s'\n", user.Username)
+
+	return nil
+}
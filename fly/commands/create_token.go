@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+)
+
+type CreateTokenCommand struct {
+	Name           string        `short:"n" long:"name" required:"true" description:"Name of the token"`
+	Roles          []string      `short:"r" long:"role" default:"viewer" description:"Team role granted to the token (can be specified multiple times)"`
+	ServiceAccount string        `long:"service-account" description:"Create the token for this team service account instead of yourself"`
+	ExpiresIn      time.Duration `long:"expires-in" default:"2160h" description:"How long the token remains valid"`
+	Json           bool          `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *CreateTokenCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	token, err := target.Team().CreateAPIToken(atc.APITokenRequest{
+		Name:           command.Name,
+		ServiceAccount: command.ServiceAccount,
+		Roles:          command.Roles,
+		ExpiresAt:      time.Now().Add(command.ExpiresIn).Unix(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(token)
+	}
+
+	dst, _ := ui.ForTTY(os.Stdout)
+
+	fmt.Fprintf(dst, "created token '%s' expiring %s\n\n", token.Name, time.Unix(token.ExpiresAt, 0).Format(time.RFC1123))
+	fmt.Fprintln(dst, token.Token)
+	fmt.Fprintln(dst, "")
+	fmt.Fprintln(dst, "store it somewhere safe; it will not be shown again. log in with it by running:")
+	fmt.Fprintln(dst, "")
+	fmt.Fprintln(dst, "    "+ui.Embolden("fly -t %s login --api-token (token)", Fly.Target))
+	fmt.Fprintln(dst, "")
+
+	return nil
+}
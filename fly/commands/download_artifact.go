@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/mattn/go-isatty"
+)
+
+type DownloadArtifactCommand struct {
+	Job      flaghelpers.JobFlag `short:"j" long:"job"      value-name:"PIPELINE/JOB"  description:"Download from the latest build of the given job"`
+	Build    string              `short:"b" long:"build"                               description:"Download from a specific build of the job, or a specific build ID when no job is given"`
+	Artifact string              `short:"a" long:"artifact" required:"true"            description:"Name of the retained output to download"`
+	Output   string              `short:"o" long:"output"   value-name:"PATH"          description:"Write the tarball to the given path instead of stdout"`
+}
+
+func (command *DownloadArtifactCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var buildID int
+	client := target.Client()
+	if command.Job.JobName != "" || command.Build == "" {
+		build, err := GetBuild(client, target.Team(), command.Job.JobName, command.Build, command.Job.PipelineName)
+		if err != nil {
+			return err
+		}
+		buildID = build.ID
+	} else {
+		buildID, err = strconv.Atoi(command.Build)
+		if err != nil {
+			return err
+		}
+	}
+
+	artifacts, err := client.ListBuildArtifacts(strconv.Itoa(buildID))
+	if err != nil {
+		return err
+	}
+
+	// a retried task may have retained the same output more than once; the
+	// latest attempt is the most interesting one
+	var found *atc.WorkerArtifact
+	for i, artifact := range artifacts {
+		if artifact.Name != command.Artifact {
+			continue
+		}
+
+		if found == nil || artifact.ID > found.ID {
+			found = &artifacts[i]
+		}
+	}
+
+	if found == nil {
+		return fmt.Errorf("build %d has no artifact named '%s'", buildID, command.Artifact)
+	}
+
+	var dst io.Writer = os.Stdout
+	if command.Output != "" {
+		file, err := os.Create(command.Output)
+		if err != nil {
+			return err
+		}
+
+		defer file.Close()
+
+		dst = file
+	} else if isatty.IsTerminal(os.Stdout.Fd()) {
+		return errors.New("refusing to write a tarball to a terminal; redirect stdout or use --output")
+	}
+
+	tarball, err := target.Team().GetArtifact(found.ID)
+	if err != nil {
+		return err
+	}
+
+	defer tarball.Close()
+
+	_, err = io.Copy(dst, tarball)
+	return err
+}
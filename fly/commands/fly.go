@@ -53,6 +53,8 @@ type FlyCommand struct {
 	ValidatePipeline ValidatePipelineCommand `command:"validate-pipeline"   alias:"vp"   description:"Validate a pipeline config"`
 	FormatPipeline   FormatPipelineCommand   `command:"format-pipeline"     alias:"fp"   description:"Format a pipeline config"`
 	OrderPipelines   OrderPipelinesCommand   `command:"order-pipelines"     alias:"op"   description:"Orders pipelines"`
+	PipelineHistory  PipelineHistoryCommand  `command:"pipeline-history"    alias:"ph"   description:"List the saved versions of a pipeline's configuration"`
+	RollbackPipeline RollbackPipelineCommand `command:"rollback-pipeline"   alias:"rbp"  description:"Restore an earlier version of a pipeline's configuration"`
 
 	Resources        ResourcesCommand        `command:"resources"           alias:"rs"   description:"List the resources in the pipeline"`
 	ResourceVersions ResourceVersionsCommand `command:"resource-versions"   alias:"rvs"  description:"List the versions of a resource"`
@@ -62,16 +64,40 @@ type FlyCommand struct {
 
 	ClearTaskCache ClearTaskCacheCommand `command:"clear-task-cache" alias:"ctc" description:"Clears cache from a task container"`
 
-	Builds     BuildsCommand     `command:"builds"      alias:"bs" description:"List builds data"`
-	AbortBuild AbortBuildCommand `command:"abort-build" alias:"ab" description:"Abort a build"`
+	Builds           BuildsCommand           `command:"builds"      alias:"bs" description:"List builds data"`
+	AbortBuild       AbortBuildCommand       `command:"abort-build" alias:"ab" description:"Abort a build"`
+	BuildTimeline    BuildTimelineCommand    `command:"build-timeline" alias:"btl" description:"Chart when each step of a build ran and its critical path"`
+	DownloadArtifact DownloadArtifactCommand `command:"download-artifact" alias:"da" description:"Download an output retained by a build's task"`
 
 	TriggerJob TriggerJobCommand `command:"trigger-job" alias:"tj" description:"Start a job in a pipeline"`
 
 	Volumes VolumesCommand `command:"volumes" alias:"vs" description:"List the active volumes"`
 
-	Workers     WorkersCommand     `command:"workers" alias:"ws" description:"List the registered workers"`
-	LandWorker  LandWorkerCommand  `command:"land-worker" alias:"lw" description:"Land a worker"`
-	PruneWorker PruneWorkerCommand `command:"prune-worker" alias:"pw" description:"Prune a stalled, landing, landed, or retiring worker"`
+	Workers            WorkersCommand            `command:"workers" alias:"ws" description:"List the registered workers"`
+	LandWorker         LandWorkerCommand         `command:"land-worker" alias:"lw" description:"Land a worker"`
+	PruneWorker        PruneWorkerCommand        `command:"prune-worker" alias:"pw" description:"Prune a stalled, landing, landed, or retiring worker"`
+	UnquarantineWorker UnquarantineWorkerCommand `command:"unquarantine-worker" alias:"uqw" description:"Release a quarantined worker back into placement"`
+
+	AddWorkerKey    AddWorkerKeyCommand    `command:"add-worker-key" alias:"awk" description:"Allow workers to register with a public key"`
+	ListWorkerKeys  ListWorkerKeysCommand  `command:"list-worker-keys" alias:"lwk" description:"List the public keys workers may register with"`
+	RemoveWorkerKey RemoveWorkerKeyCommand `command:"remove-worker-key" alias:"rwk" description:"Stop workers from registering with a public key"`
+
+	CreateToken CreateTokenCommand `command:"create-token" alias:"ct" description:"Create a long-lived API token"`
+	ListTokens  ListTokensCommand  `command:"list-tokens" alias:"lt" description:"List API tokens in the team"`
+	RevokeToken RevokeTokenCommand `command:"revoke-token" alias:"rvt" description:"Revoke an API token"`
+
+	LocalUsers           LocalUsersCommand           `command:"local-users" alias:"lus" description:"List the local users"`
+	CreateLocalUser      CreateLocalUserCommand      `command:"create-local-user" alias:"clu" description:"Create a local user"`
+	SetLocalUserPassword SetLocalUserPasswordCommand `command:"set-local-user-password" alias:"slup" description:"Change a local user's password"`
+	DisableLocalUser     DisableLocalUserCommand     `command:"disable-local-user" alias:"dlu" description:"Disable a local user"`
+	EnableLocalUser      EnableLocalUserCommand      `command:"enable-local-user" alias:"elu" description:"Re-enable a disabled local user"`
+
+	AuditLog AuditLogCommand `command:"audit-log" alias:"al" description:"List audit events"`
+
+	HijackSessions HijackSessionsCommand `command:"hijack-sessions" alias:"hs" description:"List or replay the recorded intercepted sessions of the team"`
+
+	Locks       LocksCommand       `command:"locks" alias:"lk" description:"List the named locks of the team with their holders and waiters"`
+	ReleaseLock ReleaseLockCommand `command:"release-lock" alias:"rl" description:"Force-release a named lock from the builds holding it"`
 
 	Curl CurlCommand `command:"curl" alias:"c" description:"curl the api"`
 }
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
 	"github.com/concourse/concourse/fly/rc"
 	"github.com/concourse/concourse/fly/ui"
@@ -80,5 +82,52 @@ func (command *GetTeamCommand) Execute(args []string) error {
 		table.Data = append(table.Data, row)
 	}
 	sort.Sort(table.Data)
-	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+
+	err = table.Render(os.Stdout, Fly.PrintTableHeaders)
+	if err != nil {
+		return err
+	}
+
+	if team.Usage == nil {
+		return nil
+	}
+
+	quota := atc.TeamQuota{}
+	if team.Quota != nil {
+		quota = *team.Quota
+	}
+
+	usageTable := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "quota", Color: color.New(color.Bold)},
+			{Contents: "in use", Color: color.New(color.Bold)},
+			{Contents: "limit", Color: color.New(color.Bold)},
+		},
+		Data: []ui.TableRow{
+			quotaRow("running builds", team.Usage.RunningBuilds, quota.MaxRunningBuilds),
+			quotaRow("containers", team.Usage.Containers, quota.MaxContainers),
+		},
+	}
+
+	fmt.Println()
+
+	return usageTable.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+func quotaRow(name string, used int, limit int) ui.TableRow {
+	usedCell := ui.TableCell{Contents: strconv.Itoa(used)}
+	if limit != 0 && used >= limit {
+		usedCell.Color = color.New(color.FgRed)
+	}
+
+	limitCell := ui.TableCell{Contents: strconv.Itoa(limit)}
+	if limit == 0 {
+		limitCell = ui.TableCell{Contents: "unlimited", Color: color.New(color.Faint)}
+	}
+
+	return ui.TableRow{
+		{Contents: name},
+		usedCell,
+		limitCell,
+	}
 }
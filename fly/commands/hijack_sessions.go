@@ -0,0 +1,164 @@
+package commands
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+// replays skip over pauses longer than this, e.g. while the user was away
+const maxReplayIdle = 2 * time.Second
+
+type HijackSessionsCommand struct {
+	Session int     `short:"s" long:"session" description:"Replay the output of the session with the given ID"`
+	Speed   float64 `long:"speed" default:"1" description:"Playback speed of the replay"`
+	Raw     bool    `long:"raw" description:"Print the session as an asciicast recording instead of replaying it"`
+
+	Json bool `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *HijackSessionsCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	if command.Session != 0 {
+		return command.replay(target)
+	}
+
+	sessions, err := target.Team().HijackSessions()
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(sessions)
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "id", Color: color.New(color.Bold)},
+			{Contents: "user", Color: color.New(color.Bold)},
+			{Contents: "container", Color: color.New(color.Bold)},
+			{Contents: "worker", Color: color.New(color.Bold)},
+			{Contents: "command", Color: color.New(color.Bold)},
+			{Contents: "started", Color: color.New(color.Bold)},
+			{Contents: "duration", Color: color.New(color.Bold)},
+			{Contents: "exit status", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, session := range sessions {
+		duration := ui.TableCell{Contents: "running", Color: ui.StartedColor}
+		if session.EndedAt != 0 {
+			duration = ui.TableCell{Contents: (time.Duration(session.EndedAt-session.StartedAt) * time.Second).String()}
+		}
+
+		exitStatus := ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+		if session.ExitStatus != nil {
+			exitStatus = ui.TableCell{Contents: strconv.Itoa(*session.ExitStatus)}
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: strconv.Itoa(session.ID)},
+			{Contents: session.Username},
+			{Contents: session.ContainerHandle},
+			{Contents: session.WorkerName},
+			{Contents: strings.Join(append([]string{session.Path}, session.Args...), " ")},
+			{Contents: time.Unix(session.StartedAt, 0).Format(timeDateLayout)},
+			duration,
+			exitStatus,
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+func (command *HijackSessionsCommand) replay(target rc.Target) error {
+	recording, found, err := target.Team().HijackSessionRecording(command.Session)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("hijack session %d not found", command.Session)
+	}
+
+	defer recording.Close()
+
+	if command.Raw {
+		_, err = io.Copy(os.Stdout, recording)
+		return err
+	}
+
+	if command.Speed <= 0 {
+		return fmt.Errorf("invalid speed: %v", command.Speed)
+	}
+
+	return replayAsciicast(recording, os.Stdout, command.Speed)
+}
+
+// replayAsciicast writes the output events of an asciicast v2 recording to
+// the writer with their original timing.
+func replayAsciicast(recording io.Reader, out io.Writer, speed float64) error {
+	scanner := bufio.NewScanner(recording)
+	scanner.Buffer(nil, 1024*1024)
+
+	// the first line is the header
+	if !scanner.Scan() {
+		return scanner.Err()
+	}
+
+	var last float64
+	for scanner.Scan() {
+		var event []interface{}
+		err := json.Unmarshal(scanner.Bytes(), &event)
+		if err != nil {
+			return fmt.Errorf("malformed recording: %s", err)
+		}
+
+		if len(event) != 3 {
+			return fmt.Errorf("malformed recording: unexpected event %s", scanner.Text())
+		}
+
+		at, _ := event[0].(float64)
+		eventType, _ := event[1].(string)
+		data, _ := event[2].(string)
+
+		// only output is replayed; input is echoed back by the terminal
+		if eventType != "o" {
+			continue
+		}
+
+		pause := time.Duration((at - last) / speed * float64(time.Second))
+		if pause > maxReplayIdle {
+			pause = maxReplayIdle
+		}
+
+		time.Sleep(pause)
+		last = at
+
+		_, err = io.WriteString(out, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
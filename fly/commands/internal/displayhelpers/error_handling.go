@@ -31,14 +31,38 @@ func ShowErrors(errorHeader string, errorMessages []string) {
 }
 
 func ShowWarnings(warnings []concourse.ConfigWarning) {
-	fmt.Fprintln(ui.Stderr, "")
-	PrintDeprecationWarningHeader()
-
+	deprecations := []string{}
+	policyWarnings := []string{}
 	for _, warning := range warnings {
-		fmt.Fprintf(ui.Stderr, "  - %s\n", warning.Message)
+		if warning.Type == "policy" {
+			policyWarnings = append(policyWarnings, warning.Message)
+		} else {
+			deprecations = append(deprecations, warning.Message)
+		}
 	}
 
-	fmt.Fprintln(ui.Stderr, "")
+	if len(deprecations) > 0 {
+		fmt.Fprintln(ui.Stderr, "")
+		PrintDeprecationWarningHeader()
+
+		for _, message := range deprecations {
+			fmt.Fprintf(ui.Stderr, "  - %s\n", message)
+		}
+
+		fmt.Fprintln(ui.Stderr, "")
+	}
+
+	if len(policyWarnings) > 0 {
+		fmt.Fprintln(ui.Stderr, "")
+		PrintWarningHeader()
+
+		fmt.Fprintln(ui.Stderr, "policy check:")
+		for _, message := range policyWarnings {
+			fmt.Fprintf(ui.Stderr, "  - %s\n", message)
+		}
+
+		fmt.Fprintln(ui.Stderr, "")
+	}
 }
 
 func Failf(message string, args ...interface{}) {
@@ -46,6 +46,12 @@ func (atcConfig ATCConfig) Set(yamlTemplateWithParams templatehelpers.YamlTempla
 		return err
 	}
 
+	return atcConfig.Apply(evaluatedTemplate)
+}
+
+// Apply shows the diff between the pipeline's current config and the given
+// one and, once confirmed, saves it.
+func (atcConfig ATCConfig) Apply(evaluatedTemplate []byte) error {
 	existingConfig, existingConfigVersion, _, err := atcConfig.Team.PipelineConfig(atcConfig.PipelineName)
 	if err != nil {
 		return err
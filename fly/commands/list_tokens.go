@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type ListTokensCommand struct {
+	Json bool `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *ListTokensCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	tokens, err := target.Team().ListAPITokens()
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(tokens)
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "name", Color: color.New(color.Bold)},
+			{Contents: "owner", Color: color.New(color.Bold)},
+			{Contents: "roles", Color: color.New(color.Bold)},
+			{Contents: "created", Color: color.New(color.Bold)},
+			{Contents: "expires", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, t := range tokens {
+		owner := ui.TableCell{Contents: t.Owner}
+		if t.ServiceAccount {
+			owner.Contents += " (service account)"
+		}
+
+		expires := ui.TableCell{Contents: time.Unix(t.ExpiresAt, 0).Format(time.RFC1123)}
+		if time.Unix(t.ExpiresAt, 0).Before(time.Now()) {
+			expires.Color = color.New(color.FgRed)
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: t.Name},
+			owner,
+			{Contents: strings.Join(t.Roles, ", ")},
+			{Contents: time.Unix(t.CreatedAt, 0).Format(time.RFC1123)},
+			expires,
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"os"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type ListWorkerKeysCommand struct {
+	All  bool `short:"a" long:"all" description:"List the keys of every team along with the global keys (requires admin)"`
+	Json bool `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *ListWorkerKeysCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var keys []atc.WorkerKey
+	if command.All {
+		keys, err = target.Client().ListWorkerKeys()
+	} else {
+		keys, err = target.Team().ListWorkerKeys()
+	}
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(keys)
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "name", Color: color.New(color.Bold)},
+			{Contents: "team", Color: color.New(color.Bold)},
+			{Contents: "fingerprint", Color: color.New(color.Bold)},
+			{Contents: "created", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, k := range keys {
+		team := ui.TableCell{Contents: k.TeamName}
+		if k.TeamName == "" {
+			team = ui.TableCell{Contents: "global", Color: color.New(color.Faint)}
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: k.Name},
+			team,
+			{Contents: k.Fingerprint},
+			{Contents: time.Unix(k.CreatedAt, 0).Format(time.RFC1123)},
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
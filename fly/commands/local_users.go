@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"os"
+	"time"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type LocalUsersCommand struct {
+	Json bool `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *LocalUsersCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	users, err := target.Client().ListLocalUsers()
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(users)
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "username", Color: color.New(color.Bold)},
+			{Contents: "status", Color: color.New(color.Bold)},
+			{Contents: "updated", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, u := range users {
+		status := ui.TableCell{Contents: "enabled"}
+		if u.Disabled {
+			status = ui.TableCell{Contents: "disabled", Color: color.New(color.FgRed)}
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: u.Username},
+			status,
+			{Contents: time.Unix(u.UpdatedAt, 0).Format(time.RFC1123)},
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
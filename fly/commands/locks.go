@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type LocksCommand struct {
+	Json bool `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *LocksCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	locks, err := target.Team().NamedLocks()
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(locks)
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "name", Color: color.New(color.Bold)},
+			{Contents: "capacity", Color: color.New(color.Bold)},
+			{Contents: "held by", Color: color.New(color.Bold)},
+			{Contents: "waiting", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, lock := range locks {
+		holders := ui.TableCell{Contents: lockClaimBuilds(lock.Holders)}
+		if len(lock.Holders) == 0 {
+			holders.Contents = "none"
+			holders.Color = ui.OffColor
+		} else {
+			holders.Color = ui.StartedColor
+		}
+
+		waiters := ui.TableCell{Contents: lockClaimBuilds(lock.Waiters)}
+		if len(lock.Waiters) == 0 {
+			waiters.Contents = "none"
+			waiters.Color = ui.OffColor
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: lock.Name},
+			{Contents: strconv.Itoa(lock.Capacity)},
+			holders,
+			waiters,
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+func lockClaimBuilds(claims []atc.NamedLockClaim) string {
+	var builds []string
+	for _, claim := range claims {
+		if claim.JobName == "" {
+			builds = append(builds, fmt.Sprintf("build #%d", claim.BuildID))
+			continue
+		}
+
+		builds = append(builds, fmt.Sprintf("%s/%s #%s", claim.PipelineName, claim.JobName, claim.BuildName))
+	}
+
+	return strings.Join(builds, ", ")
+}
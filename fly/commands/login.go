@@ -29,6 +29,7 @@ type LoginCommand struct {
 	TeamName    string       `short:"n" long:"team-name" description:"Team to authenticate with"`
 	CACert      atc.PathFlag `long:"ca-cert" description:"Path to Concourse PEM-encoded CA certificate file."`
 	OpenBrowser bool         `short:"b" long:"open-browser" description:"Open browser to the auth endpoint"`
+	APIToken    string       `long:"api-token" description:"Long-lived API token created with create-token"`
 
 	BrowserOnly bool
 }
@@ -117,7 +118,9 @@ func (command *LoginCommand) Execute(args []string) error {
 		// Legacy Auth Support
 		tokenType, tokenValue, err = command.legacyAuth(target, command.BrowserOnly)
 	} else {
-		if command.Username != "" && command.Password != "" {
+		if command.APIToken != "" {
+			tokenType, tokenValue = "Bearer", command.APIToken
+		} else if command.Username != "" && command.Password != "" {
 			tokenType, tokenValue, err = command.passwordGrant(client, command.Username, command.Password)
 		} else {
 			tokenType, tokenValue, err = command.authCodeGrant(client.URL(), command.BrowserOnly)
@@ -0,0 +1,141 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	"github.com/mgutz/ansi"
+)
+
+type PipelineHistoryCommand struct {
+	Pipeline flaghelpers.PipelineFlag `short:"p" long:"pipeline" required:"true" description:"Pipeline whose config history to show"`
+
+	Version int `long:"version" description:"Print the config saved at this version"`
+
+	DiffFrom int `long:"diff-from" description:"Show the changes made to the config since this version"`
+	DiffTo   int `long:"diff-to"   description:"Version to diff against, instead of the current config (requires --diff-from)"`
+
+	Json bool `short:"j" long:"json" description:"Print command result as JSON"`
+}
+
+func (command *PipelineHistoryCommand) Validate() error {
+	if command.DiffTo != 0 && command.DiffFrom == 0 {
+		return errors.New("--diff-to requires --diff-from")
+	}
+
+	return command.Pipeline.Validate()
+}
+
+func (command *PipelineHistoryCommand) Execute([]string) error {
+	err := command.Validate()
+	if err != nil {
+		return err
+	}
+
+	pipelineName := string(command.Pipeline)
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	if command.Version != 0 {
+		version, found, err := target.Team().PipelineConfigVersion(pipelineName, command.Version)
+		if err != nil {
+			return err
+		}
+
+		if !found || version.Config == nil {
+			return errors.New("pipeline or config version not found")
+		}
+
+		if command.Json {
+			return displayhelpers.JsonPrint(version)
+		}
+
+		return dump(*version.Config, false)
+	}
+
+	if command.DiffFrom != 0 {
+		diff, found, err := target.Team().DiffPipelineConfigVersions(pipelineName, command.DiffFrom, command.DiffTo)
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			return errors.New("pipeline or config version not found")
+		}
+
+		if command.Json {
+			return displayhelpers.JsonPrint(diff)
+		}
+
+		for _, line := range diff.Lines {
+			switch line.Delta {
+			case atc.ConfigDiffAdded:
+				fmt.Printf("%s %s\n", ansi.Color("+", "green"), ansi.Color(line.Text, "green"))
+			case atc.ConfigDiffRemoved:
+				fmt.Printf("%s %s\n", ansi.Color("-", "red"), ansi.Color(line.Text, "red"))
+			default:
+				fmt.Printf("  %s\n", line.Text)
+			}
+		}
+
+		return nil
+	}
+
+	versions, found, err := target.Team().PipelineConfigVersions(pipelineName)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return errors.New("pipeline not found")
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(versions)
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "version", Color: color.New(color.Bold)},
+			{Contents: "saved by", Color: color.New(color.Bold)},
+			{Contents: "saved at", Color: color.New(color.Bold)},
+		},
+	}
+
+	for i, v := range versions {
+		version := ui.TableCell{Contents: strconv.Itoa(v.Version)}
+		if i == 0 {
+			version.Contents += " (current)"
+		}
+
+		savedBy := ui.TableCell{Contents: v.SavedBy}
+		if v.SavedBy == "" {
+			savedBy = ui.TableCell{Contents: "n/a", Color: color.New(color.Faint)}
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			version,
+			savedBy,
+			{Contents: time.Unix(v.SavedAt, 0).Format(time.RFC1123)},
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type ReleaseLockCommand struct {
+	Name string `short:"n" long:"name" required:"true" description:"Name of the lock to release"`
+}
+
+func (command *ReleaseLockCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	found, err := target.Team().ReleaseNamedLock(command.Name)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("lock '%s' not found", command.Name)
+	}
+
+	fmt.Printf("released '%s'\n", command.Name)
+
+	return nil
+}
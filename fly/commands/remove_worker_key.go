@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type RemoveWorkerKeyCommand struct {
+	Name   string `short:"n" long:"name" required:"true" description:"Name of the key to remove"`
+	Global bool   `long:"global" description:"Remove a global key rather than one of the team's keys (requires admin)"`
+}
+
+func (command *RemoveWorkerKeyCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var found bool
+	if command.Global {
+		found, err = target.Client().DeleteWorkerKey(command.Name)
+	} else {
+		found, err = target.Team().DeleteWorkerKey(command.Name)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("worker key '%s' not found", command.Name)
+	}
+
+	fmt.Printf("removed '%s'\n", command.Name)
+
+	return nil
+}
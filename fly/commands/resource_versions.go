@@ -17,6 +17,7 @@ import (
 type ResourceVersionsCommand struct {
 	Count    int                      `short:"c" long:"count" default:"50" description:"Number of builds you want to limit the return to"`
 	Resource flaghelpers.ResourceFlag `short:"r" long:"resource" required:"true" value-name:"PIPELINE/RESOURCE" description:"Name of a resource to get versions for"`
+	Filter   string                   `long:"filter" value-name:"EXPRESSION" description:"Only list versions matching a version filter, e.g. 'semver(tag) >=1.4 <2'"`
 	Json     bool                     `long:"json" description:"Print command result as JSON"`
 }
 
@@ -31,7 +32,7 @@ func (command *ResourceVersionsCommand) Execute([]string) error {
 		return err
 	}
 
-	page := concourse.Page{Limit: command.Count}
+	page := concourse.Page{Limit: command.Count, Filter: command.Filter}
 
 	team := target.Team()
 
@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/commands/internal/setpipelinehelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/mgutz/ansi"
+	"gopkg.in/yaml.v2"
+)
+
+type RollbackPipelineCommand struct {
+	SkipInteractive  bool `short:"n" long:"non-interactive" description:"Skips interactions, uses default values"`
+	DisableAnsiColor bool `long:"no-color"                  description:"Disable color output"`
+
+	CheckCredentials bool `long:"check-creds" description:"Validate credential variables against credential manager"`
+
+	Pipeline  flaghelpers.PipelineFlag `short:"p" long:"pipeline"   required:"true" description:"Pipeline to roll back"`
+	ToVersion int                      `long:"to-version"           required:"true" description:"Config version to restore, as listed by pipeline-history"`
+}
+
+func (command *RollbackPipelineCommand) Validate() error {
+	return command.Pipeline.Validate()
+}
+
+func (command *RollbackPipelineCommand) Execute([]string) error {
+	err := command.Validate()
+	if err != nil {
+		return err
+	}
+
+	pipelineName := string(command.Pipeline)
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	ansi.DisableColors(command.DisableAnsiColor)
+
+	version, found, err := target.Team().PipelineConfigVersion(pipelineName, command.ToVersion)
+	if err != nil {
+		return err
+	}
+
+	if !found || version.Config == nil {
+		return errors.New("pipeline or config version not found")
+	}
+
+	payload, err := yaml.Marshal(version.Config)
+	if err != nil {
+		return err
+	}
+
+	atcConfig := setpipelinehelpers.ATCConfig{
+		Team:             target.Team(),
+		PipelineName:     pipelineName,
+		TargetName:       Fly.Target,
+		Target:           target.Client().URL(),
+		SkipInteraction:  command.SkipInteractive,
+		CheckCredentials: command.CheckCredentials,
+	}
+
+	// the old config is saved again as a new version, so it goes through the
+	// same validation and policy checks as set-pipeline
+	return atcConfig.Apply(payload)
+}
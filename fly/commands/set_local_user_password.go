@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/vito/go-interact/interact"
+)
+
+type SetLocalUserPasswordCommand struct {
+	Username string `short:"u" long:"username" required:"true" description:"Username of the local user"`
+	Password string `short:"p" long:"password" description:"New password (prompted for if not given)"`
+}
+
+func (command *SetLocalUserPasswordCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	password, err := localUserPassword(command.Password)
+	if err != nil {
+		return err
+	}
+
+	found, err := target.Client().SetLocalUserPassword(command.Username, password)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("local user '%s' not found", command.Username)
+	}
+
+	fmt.Printf("updated password for '%s'\n", command.Username)
+
+	return nil
+}
+
+func localUserPassword(password string) (string, error) {
+	if password != "" {
+		return password, nil
+	}
+
+	var interactivePassword interact.Password
+	err := interact.NewInteraction("password").Resolve(interact.Required(&interactivePassword))
+	if err != nil {
+		return "", err
+	}
+
+	return string(interactivePassword), nil
+}
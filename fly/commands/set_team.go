@@ -1,9 +1,11 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"sort"
+	"strconv"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
@@ -27,6 +29,9 @@ type SetTeamCommand struct {
 	TeamName        string               `short:"n" long:"team-name" required:"true" description:"The team to create or modify"`
 	SkipInteractive bool                 `long:"non-interactive" description:"Force apply configuration"`
 	AuthFlags       skycmd.AuthTeamFlags `group:"Authentication"`
+
+	MaxRunningBuilds *int `long:"max-running-builds" description:"Maximum number of builds the team may run at once, 0 for unlimited (admins only)"`
+	MaxContainers    *int `long:"max-containers" description:"Maximum number of containers the team may have at once, 0 for unlimited (admins only)"`
 }
 
 func (command *SetTeamCommand) Execute([]string) error {
@@ -80,6 +85,18 @@ func (command *SetTeamCommand) Execute([]string) error {
 		}
 	}
 
+	quota, err := command.quota(target)
+	if err != nil {
+		return err
+	}
+
+	if quota != nil {
+		fmt.Println()
+		fmt.Printf("quota:\n")
+		fmt.Printf("  max running builds: %s\n", quotaLimit(quota.MaxRunningBuilds))
+		fmt.Printf("  max containers: %s\n", quotaLimit(quota.MaxContainers))
+	}
+
 	confirm := true
 	if !command.SkipInteractive {
 		confirm = false
@@ -93,13 +110,17 @@ func (command *SetTeamCommand) Execute([]string) error {
 		displayhelpers.Failf("bailing out")
 	}
 
-	team := atc.Team{Auth: atc.TeamAuth(authRoles)}
+	team := atc.Team{Auth: atc.TeamAuth(authRoles), Quota: quota}
 
-	_, created, updated, err := target.Client().Team(command.TeamName).CreateOrUpdate(team)
+	_, created, updated, warnings, err := target.Client().Team(command.TeamName).CreateOrUpdateWithWarnings(team)
 	if err != nil {
 		return err
 	}
 
+	if len(warnings) > 0 {
+		displayhelpers.ShowWarnings(warnings)
+	}
+
 	if created {
 		fmt.Println("team created")
 	} else if updated {
@@ -109,6 +130,51 @@ func (command *SetTeamCommand) Execute([]string) error {
 	return nil
 }
 
+// quota is the quota to set if any of the quota flags were given, keeping the
+// limits that were not given as they are.
+func (command *SetTeamCommand) quota(target rc.Target) (*atc.TeamQuota, error) {
+	if command.MaxRunningBuilds == nil && command.MaxContainers == nil {
+		return nil, nil
+	}
+
+	quota := atc.TeamQuota{}
+
+	team, found, err := target.Team().Team(command.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if found && team.Quota != nil {
+		quota = *team.Quota
+	}
+
+	if command.MaxRunningBuilds != nil {
+		if *command.MaxRunningBuilds < 0 {
+			return nil, errors.New("max running builds must not be negative")
+		}
+
+		quota.MaxRunningBuilds = *command.MaxRunningBuilds
+	}
+
+	if command.MaxContainers != nil {
+		if *command.MaxContainers < 0 {
+			return nil, errors.New("max containers must not be negative")
+		}
+
+		quota.MaxContainers = *command.MaxContainers
+	}
+
+	return &quota, nil
+}
+
+func quotaLimit(limit int) string {
+	if limit == 0 {
+		return ui.OffColor.Sprint("unlimited")
+	}
+
+	return strconv.Itoa(limit)
+}
+
 func (command *SetTeamCommand) ErrorAuthNotConfigured(err error) {
 	switch err {
 	case skycmd.ErrAuthNotConfiguredFromFile:
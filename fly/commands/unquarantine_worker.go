@@ -0,0 +1,34 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type UnquarantineWorkerCommand struct {
+	Worker string `short:"w"  long:"worker" required:"true" description:"Worker to release from quarantine"`
+}
+
+func (command *UnquarantineWorkerCommand) Execute(args []string) error {
+	workerName := command.Worker
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = target.Client().UnquarantineWorker(workerName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("unquarantined '%s'\n", workerName)
+
+	return nil
+}
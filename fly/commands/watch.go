@@ -2,18 +2,26 @@ package commands
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"strconv"
+	"time"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
 	"github.com/concourse/concourse/fly/eventstream"
 	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
 )
 
 type WatchCommand struct {
 	Job       flaghelpers.JobFlag `short:"j" long:"job"         value-name:"PIPELINE/JOB"  description:"Watches builds of the given job"`
 	Build     string              `short:"b" long:"build"                                  description:"Watches a specific build"`
 	Timestamp bool                `short:"t" long:"timestamps"                             description:"Print with local timestamp"`
+	All       bool                `short:"a" long:"all"                                    description:"Watches build and job status changes of all visible pipelines, or only of the given --job or --pipeline"`
+	Pipeline  string              `short:"p" long:"pipeline"                               description:"Watches status changes of the given pipeline (requires --all)"`
 }
 
 func (command *WatchCommand) Execute(args []string) error {
@@ -27,6 +35,14 @@ func (command *WatchCommand) Execute(args []string) error {
 		return err
 	}
 
+	if command.All {
+		return command.watchStatus(target)
+	}
+
+	if command.Pipeline != "" {
+		return fmt.Errorf("--pipeline can only be used together with --all")
+	}
+
 	var buildId int
 	client := target.Client()
 	if command.Job.JobName != "" || command.Build == "" {
@@ -58,3 +74,81 @@ func (command *WatchCommand) Execute(args []string) error {
 
 	return nil
 }
+
+func (command *WatchCommand) watchStatus(target rc.Target) error {
+	if command.Build != "" {
+		return fmt.Errorf("--build cannot be used together with --all")
+	}
+
+	var filter concourse.StatusEventFilter
+	if command.Job.JobName != "" {
+		filter = concourse.StatusEventFilter{
+			Team:     target.Team().Name(),
+			Pipeline: command.Job.PipelineName,
+			Job:      command.Job.JobName,
+		}
+	} else if command.Pipeline != "" {
+		filter = concourse.StatusEventFilter{
+			Team:     target.Team().Name(),
+			Pipeline: command.Pipeline,
+		}
+	}
+
+	stream, err := target.Client().StatusEvents(filter)
+	if err != nil {
+		return err
+	}
+
+	defer stream.Close()
+
+	dst, _ := ui.ForTTY(os.Stdout)
+
+	for {
+		ev, err := stream.NextStatusEvent()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+
+			return err
+		}
+
+		fmt.Fprintln(dst, formatStatusEvent(ev))
+	}
+}
+
+func formatStatusEvent(ev atc.StatusEvent) string {
+	subject := ev.TeamName
+	if ev.PipelineName != "" {
+		subject += "/" + ev.PipelineName
+	}
+
+	if ev.JobName != "" {
+		subject += "/" + ev.JobName
+	}
+
+	line := fmt.Sprintf("%s  %-14s %s", time.Unix(ev.Time, 0).Format(timeDateLayout), ev.Type, subject)
+
+	if ev.Build != nil {
+		line += fmt.Sprintf(" #%s %s", ev.Build.Name, statusColor(ev.Build.Status).Sprint(ev.Build.Status))
+	}
+
+	return line
+}
+
+func statusColor(status string) *color.Color {
+	switch status {
+	case "started":
+		return ui.StartedColor
+	case "succeeded":
+		return ui.SucceededColor
+	case "failed":
+		return ui.FailedColor
+	case "errored":
+		return ui.ErroredColor
+	case "aborted":
+		return ui.AbortedColor
+	default:
+		return ui.PendingColor
+	}
+}
@@ -48,9 +48,12 @@ func (command *WorkersCommand) Execute([]string) error {
 	var runningWorkers []worker
 	var stalledWorkers []worker
 	var outdatedWorkers []worker
+	var quarantinedWorkers []worker
 	for _, w := range workers {
 		if w.State == "stalled" {
 			stalledWorkers = append(stalledWorkers, worker{w, false})
+		} else if w.State == "quarantined" {
+			quarantinedWorkers = append(quarantinedWorkers, worker{w, false})
 		} else {
 			workerVersionCompatible, err := target.IsWorkerVersionCompatible(w.Version)
 			if err != nil {
@@ -67,7 +70,7 @@ func (command *WorkersCommand) Execute([]string) error {
 
 	dst, isTTY := ui.ForTTY(os.Stdout)
 	if !isTTY {
-		return command.tableFor(append(append(runningWorkers, outdatedWorkers...), stalledWorkers...)).Render(os.Stdout, Fly.PrintTableHeaders)
+		return command.tableFor(append(append(append(runningWorkers, outdatedWorkers...), quarantinedWorkers...), stalledWorkers...)).Render(os.Stdout, Fly.PrintTableHeaders)
 	}
 
 	err = command.tableFor(runningWorkers).Render(os.Stdout, Fly.PrintTableHeaders)
@@ -92,6 +95,24 @@ func (command *WorkersCommand) Execute([]string) error {
 		}
 	}
 
+	if len(quarantinedWorkers) > 0 {
+		fmt.Fprintln(dst, "")
+		fmt.Fprintln(dst, "")
+		fmt.Fprintln(dst, "the following workers have been quarantined after repeated failures:")
+		fmt.Fprintln(dst, "")
+
+		err = command.tableFor(quarantinedWorkers).Render(os.Stdout, Fly.PrintTableHeaders)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(dst, "")
+		fmt.Fprintln(dst, "these workers will be released automatically, or immediately by running:")
+		fmt.Fprintln(dst, "")
+		fmt.Fprintln(dst, "    "+ui.Embolden("fly -t %s unquarantine-worker -w (name)", Fly.Target))
+		fmt.Fprintln(dst, "")
+	}
+
 	if len(stalledWorkers) > 0 {
 		fmt.Fprintln(dst, "")
 		fmt.Fprintln(dst, "")
@@ -3,6 +3,7 @@ package integration_test
 import (
 	"fmt"
 	"os/exec"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/ui"
@@ -55,6 +56,9 @@ var _ = Describe("Fly CLI", func() {
 				}
 			}
 			BeforeEach(func() {
+				scheduledJob := createJob(3, false, "", "")
+				scheduledJob.NextScheduledBuild = 1557493200
+
 				pipelineName := "pipeline"
 				flyCmd = exec.Command(flyPath, "-t", targetName, "jobs", "--pipeline", pipelineName)
 				atcServer.AppendHandlers(
@@ -63,7 +67,7 @@ var _ = Describe("Fly CLI", func() {
 						ghttp.RespondWithJSONEncoded(200, []atc.Job{
 							createJob(1, false, "succeeded", "started"),
 							createJob(2, true, "failed", ""),
-							createJob(3, false, "", ""),
+							scheduledJob,
 						}),
 					),
 				)
@@ -126,6 +130,7 @@ var _ = Describe("Fly CLI", func() {
                 "name": "job-3",
                 "pipeline_name": "",
                 "team_name": "",
+                "next_scheduled_build": 1557493200,
                 "next_build": null,
                 "finished_build": null,
                 "inputs": null,
@@ -143,9 +148,9 @@ var _ = Describe("Fly CLI", func() {
 
 				Expect(sess.Out).To(PrintTable(ui.Table{
 					Data: []ui.TableRow{
-						{{Contents: "job-1"}, {Contents: "no"}, {Contents: "succeeded"}, {Contents: "started"}},
-						{{Contents: "job-2"}, {Contents: "yes", Color: color.New(color.FgCyan)}, {Contents: "failed"}, {Contents: "n/a"}},
-						{{Contents: "job-3"}, {Contents: "no"}, {Contents: "n/a"}, {Contents: "n/a"}},
+						{{Contents: "job-1"}, {Contents: "no"}, {Contents: "succeeded"}, {Contents: "started"}, {Contents: "n/a"}},
+						{{Contents: "job-2"}, {Contents: "yes", Color: color.New(color.FgCyan)}, {Contents: "failed"}, {Contents: "n/a"}, {Contents: "n/a"}},
+						{{Contents: "job-3"}, {Contents: "no"}, {Contents: "n/a"}, {Contents: "n/a"}, {Contents: time.Unix(1557493200, 0).Local().Format(timeDateLayout)}},
 					},
 				}))
 			})
@@ -379,6 +379,39 @@ var _ = Describe("Fly CLI", func() {
 				cmdParams = []string{"-c", "fixtures/team_config_mixed.yml"}
 			})
 
+			Context("when the server warns about the team config", func() {
+				BeforeEach(func() {
+					atcServer.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("PUT", "/api/v1/teams/venture"),
+							ghttp.RespondWithJSONEncoded(http.StatusOK, atc.SetTeamResponse{
+								Team: atc.Team{Name: "venture", ID: 8},
+								Warnings: []atc.ConfigWarning{
+									{Type: "policy", Message: "teams should have an owner group"},
+								},
+							}),
+						),
+					)
+				})
+
+				It("prints the warnings", func() {
+					stdin, err := flyCmd.StdinPipe()
+					Expect(err).NotTo(HaveOccurred())
+
+					sess, err := gexec.Start(flyCmd, nil, nil)
+					Expect(err).ToNot(HaveOccurred())
+
+					Eventually(sess).Should(gbytes.Say(`apply team configuration\? \[yN\]: `))
+					yes(stdin)
+
+					Eventually(sess.Err).Should(gbytes.Say("policy check:"))
+					Eventually(sess.Err).Should(gbytes.Say("teams should have an owner group"))
+					Eventually(sess.Out).Should(gbytes.Say("team updated"))
+
+					Eventually(sess).Should(gexec.Exit(0))
+				})
+			})
+
 			Context("when the server returns 500", func() {
 				BeforeEach(func() {
 					atcServer.AppendHandlers(
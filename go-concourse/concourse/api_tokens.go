@@ -0,0 +1,70 @@
+package concourse
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) CreateAPIToken(request atc.APITokenRequest) (atc.APIToken, error) {
+	params := rata.Params{"team_name": team.name}
+
+	buffer := &bytes.Buffer{}
+	err := json.NewEncoder(buffer).Encode(request)
+	if err != nil {
+		return atc.APIToken{}, err
+	}
+
+	var token atc.APIToken
+	err = team.connection.Send(internal.Request{
+		RequestName: atc.CreateAPIToken,
+		Params:      params,
+		Body:        buffer,
+		Header: http.Header{
+			"Content-Type": {"application/json"},
+		},
+	}, &internal.Response{
+		Result: &token,
+	})
+
+	return token, err
+}
+
+func (team *team) ListAPITokens() ([]atc.APIToken, error) {
+	params := rata.Params{"team_name": team.name}
+
+	var tokens []atc.APIToken
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListAPITokens,
+		Params:      params,
+	}, &internal.Response{
+		Result: &tokens,
+	})
+
+	return tokens, err
+}
+
+func (team *team) RevokeAPIToken(tokenName string) (bool, error) {
+	params := rata.Params{
+		"team_name":  team.name,
+		"token_name": tokenName,
+	}
+
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.RevokeAPIToken,
+		Params:      params,
+	}, nil)
+
+	switch err.(type) {
+	case nil:
+		return true, nil
+	case internal.ResourceNotFoundError:
+		return false, nil
+	default:
+		return false, err
+	}
+}
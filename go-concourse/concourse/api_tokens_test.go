@@ -0,0 +1,125 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("API Tokens", func() {
+	Describe("CreateAPIToken", func() {
+		var request atc.APITokenRequest
+
+		BeforeEach(func() {
+			request = atc.APITokenRequest{
+				Name:      "some-token",
+				Roles:     []string{"member"},
+				ExpiresAt: 200,
+			}
+		})
+
+		Context("when creating the token succeeds", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/api/v1/teams/some-team/tokens"),
+						ghttp.VerifyJSONRepresenting(request),
+						ghttp.RespondWithJSONEncoded(http.StatusCreated, atc.APIToken{
+							Name:      "some-token",
+							TeamName:  "some-team",
+							Roles:     []string{"member"},
+							ExpiresAt: 200,
+							Token:     "concourse_some-secret",
+						}),
+					),
+				)
+			})
+
+			It("returns the created token", func() {
+				token, err := team.CreateAPIToken(request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(token.Name).To(Equal("some-token"))
+				Expect(token.Token).To(Equal("concourse_some-secret"))
+			})
+		})
+
+		Context("when creating the token fails", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/api/v1/teams/some-team/tokens"),
+						ghttp.RespondWith(http.StatusForbidden, nil),
+					),
+				)
+			})
+
+			It("errors", func() {
+				_, err := team.CreateAPIToken(request)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("ListAPITokens", func() {
+		var expectedTokens []atc.APIToken
+
+		BeforeEach(func() {
+			expectedTokens = []atc.APIToken{
+				{Name: "some-token", TeamName: "some-team", Owner: "some-user", Roles: []string{"viewer"}},
+				{Name: "other-token", TeamName: "some-team", Owner: "ci", ServiceAccount: true, Roles: []string{"member"}},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/tokens"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedTokens),
+				),
+			)
+		})
+
+		It("returns the team's tokens", func() {
+			tokens, err := team.ListAPITokens()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokens).To(Equal(expectedTokens))
+		})
+	})
+
+	Describe("RevokeAPIToken", func() {
+		Context("when the token exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/api/v1/teams/some-team/tokens/some-token"),
+						ghttp.RespondWith(http.StatusNoContent, ""),
+					),
+				)
+			})
+
+			It("revokes the token", func() {
+				found, err := team.RevokeAPIToken("some-token")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when the token does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/api/v1/teams/some-team/tokens/some-token"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false and no error", func() {
+				found, err := team.RevokeAPIToken("some-token")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
@@ -0,0 +1,84 @@
+package concourse
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+type AuditEventFilter struct {
+	Team   string
+	User   string
+	Action string
+	From   time.Time
+	To     time.Time
+}
+
+func (f AuditEventFilter) QueryParams() url.Values {
+	queryParams := url.Values{}
+	if f.Team != "" {
+		queryParams.Add("team", f.Team)
+	}
+
+	if f.User != "" {
+		queryParams.Add("user", f.User)
+	}
+
+	if f.Action != "" {
+		queryParams.Add("action", f.Action)
+	}
+
+	if !f.From.IsZero() {
+		queryParams.Add("from", strconv.FormatInt(f.From.Unix(), 10))
+	}
+
+	if !f.To.IsZero() {
+		queryParams.Add("to", strconv.FormatInt(f.To.Unix(), 10))
+	}
+
+	return queryParams
+}
+
+func (client *client) AuditEvents(filter AuditEventFilter, page Page) ([]atc.AuditEvent, Pagination, error) {
+	return listAuditEvents(client.connection, internal.Request{
+		RequestName: atc.ListAuditEvents,
+	}, filter, page)
+}
+
+func (team *team) AuditEvents(filter AuditEventFilter, page Page) ([]atc.AuditEvent, Pagination, error) {
+	return listAuditEvents(team.connection, internal.Request{
+		RequestName: atc.ListTeamAuditEvents,
+		Params:      rata.Params{"team_name": team.name},
+	}, filter, page)
+}
+
+func listAuditEvents(connection internal.Connection, request internal.Request, filter AuditEventFilter, page Page) ([]atc.AuditEvent, Pagination, error) {
+	query := filter.QueryParams()
+	for key, values := range page.QueryParams() {
+		query[key] = values
+	}
+
+	request.Query = query
+
+	var events []atc.AuditEvent
+	headers := http.Header{}
+	err := connection.Send(request, &internal.Response{
+		Result:  &events,
+		Headers: &headers,
+	})
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	pagination, err := paginationFromHeaders(headers)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	return events, pagination, nil
+}
@@ -0,0 +1,85 @@
+package concourse_test
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Audit Events", func() {
+	var expectedEvents []atc.AuditEvent
+
+	BeforeEach(func() {
+		expectedEvents = []atc.AuditEvent{
+			{ID: 2, Actor: "some-user", TeamName: "some-team", Action: "DeletePipeline", Status: 204},
+			{ID: 1, Actor: "other-user", Action: "SetLogLevel", Status: 200},
+		}
+	})
+
+	Describe("AuditEvents", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/audit-events", "action=DeletePipeline&from=100&limit=2&team=some-team&user=some-user"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedEvents, http.Header{
+						"Link": []string{fmt.Sprintf(`<%s/api/v1/audit-events?since=1&limit=2>; rel="next"`, atcServer.URL())},
+					}),
+				),
+			)
+		})
+
+		It("returns the events and pagination", func() {
+			events, pagination, err := client.AuditEvents(concourse.AuditEventFilter{
+				Team:   "some-team",
+				User:   "some-user",
+				Action: "DeletePipeline",
+				From:   time.Unix(100, 0),
+			}, concourse.Page{Limit: 2})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(Equal(expectedEvents))
+			Expect(pagination.Next).To(Equal(&concourse.Page{Since: 1, Limit: 2}))
+			Expect(pagination.Previous).To(BeNil())
+		})
+	})
+
+	Describe("team AuditEvents", func() {
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/audit-events", "user=some-user"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedEvents),
+					),
+				)
+			})
+
+			It("returns the team's events", func() {
+				events, _, err := team.AuditEvents(concourse.AuditEventFilter{User: "some-user"}, concourse.Page{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(events).To(Equal(expectedEvents))
+			})
+		})
+
+		Context("when the requester is not a team owner", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/audit-events"),
+						ghttp.RespondWith(http.StatusForbidden, nil),
+					),
+				)
+			})
+
+			It("errors", func() {
+				_, _, err := team.AuditEvents(concourse.AuditEventFilter{}, concourse.Page{})
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
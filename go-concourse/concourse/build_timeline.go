@@ -0,0 +1,32 @@
+package concourse
+
+import (
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (client *client) BuildTimeline(buildID int) (atc.BuildTimeline, bool, error) {
+	params := rata.Params{
+		"build_id": strconv.Itoa(buildID),
+	}
+
+	var timeline atc.BuildTimeline
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.GetBuildTimeline,
+		Params:      params,
+	}, &internal.Response{
+		Result: &timeline,
+	})
+
+	switch err.(type) {
+	case nil:
+		return timeline, true, nil
+	case internal.ResourceNotFoundError:
+		return timeline, false, nil
+	default:
+		return timeline, false, err
+	}
+}
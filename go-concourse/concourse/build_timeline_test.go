@@ -0,0 +1,72 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Build Timelines", func() {
+	Describe("BuildTimeline", func() {
+		expectedURL := "/api/v1/builds/1234/timeline"
+
+		Context("when the build exists", func() {
+			exitStatus := 0
+			expectedTimeline := atc.BuildTimeline{
+				BuildID:   1234,
+				StartTime: 100,
+				EndTime:   200,
+				Nodes: []atc.TimelineNode{
+					{
+						ID:         "some-id",
+						Type:       "get",
+						Name:       "some-resource",
+						QueuedTime: 100,
+						StartTime:  110,
+						FinishTime: 200,
+						Worker:     "some-worker",
+						Cache:      atc.CacheMiss,
+						ExitStatus: &exitStatus,
+						Critical:   true,
+					},
+				},
+			}
+
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedTimeline),
+					),
+				)
+			})
+
+			It("returns the build's timeline", func() {
+				timeline, found, err := client.BuildTimeline(1234)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(timeline).To(Equal(expectedTimeline))
+			})
+		})
+
+		Context("when the build does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+					),
+				)
+			})
+
+			It("returns false and no error", func() {
+				_, found, err := client.BuildTimeline(1234)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
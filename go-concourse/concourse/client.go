@@ -17,20 +17,32 @@ type Client interface {
 	Builds(Page) ([]atc.Build, Pagination, error)
 	Build(buildID string) (atc.Build, bool, error)
 	BuildEvents(buildID string) (Events, error)
+	StatusEvents(filter StatusEventFilter) (StatusEventStream, error)
 	BuildResources(buildID int) (atc.BuildInputsOutputs, bool, error)
 	ListBuildArtifacts(buildID string) ([]atc.WorkerArtifact, error)
 	AbortBuild(buildID string) error
 	BuildPlan(buildID int) (atc.PublicBuildPlan, bool, error)
+	BuildTimeline(buildID int) (atc.BuildTimeline, bool, error)
 	SaveWorker(atc.Worker, *time.Duration) (*atc.Worker, error)
 	ListWorkers() ([]atc.Worker, error)
 	PruneWorker(workerName string) error
 	LandWorker(workerName string) error
+	UnquarantineWorker(workerName string) error
 	GetInfo() (atc.Info, error)
 	GetCLIReader(arch, platform string) (io.ReadCloser, http.Header, error)
 	ListPipelines() ([]atc.Pipeline, error)
 	ListTeams() ([]atc.Team, error)
 	Team(teamName string) Team
 	UserInfo() (map[string]interface{}, error)
+	ListLocalUsers() ([]atc.LocalUser, error)
+	CreateLocalUser(username string, password string) (atc.LocalUser, error)
+	SetLocalUserPassword(username string, password string) (bool, error)
+	DisableLocalUser(username string) (bool, error)
+	EnableLocalUser(username string) (bool, error)
+	ListWorkerKeys() ([]atc.WorkerKey, error)
+	CreateWorkerKey(atc.WorkerKeyRequest) (atc.WorkerKey, error)
+	DeleteWorkerKey(keyName string) (bool, error)
+	AuditEvents(filter AuditEventFilter, page Page) ([]atc.AuditEvent, Pagination, error)
 }
 
 type client struct {
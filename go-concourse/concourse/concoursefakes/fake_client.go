@@ -23,6 +23,22 @@ type FakeClient struct {
 	abortBuildReturnsOnCall map[int]struct {
 		result1 error
 	}
+	AuditEventsStub        func(concourse.AuditEventFilter, concourse.Page) ([]atc.AuditEvent, concourse.Pagination, error)
+	auditEventsMutex       sync.RWMutex
+	auditEventsArgsForCall []struct {
+		arg1 concourse.AuditEventFilter
+		arg2 concourse.Page
+	}
+	auditEventsReturns struct {
+		result1 []atc.AuditEvent
+		result2 concourse.Pagination
+		result3 error
+	}
+	auditEventsReturnsOnCall map[int]struct {
+		result1 []atc.AuditEvent
+		result2 concourse.Pagination
+		result3 error
+	}
 	BuildStub        func(string) (atc.Build, bool, error)
 	buildMutex       sync.RWMutex
 	buildArgsForCall []struct {
@@ -81,6 +97,21 @@ type FakeClient struct {
 		result2 bool
 		result3 error
 	}
+	BuildTimelineStub        func(int) (atc.BuildTimeline, bool, error)
+	buildTimelineMutex       sync.RWMutex
+	buildTimelineArgsForCall []struct {
+		arg1 int
+	}
+	buildTimelineReturns struct {
+		result1 atc.BuildTimeline
+		result2 bool
+		result3 error
+	}
+	buildTimelineReturnsOnCall map[int]struct {
+		result1 atc.BuildTimeline
+		result2 bool
+		result3 error
+	}
 	BuildsStub        func(concourse.Page) ([]atc.Build, concourse.Pagination, error)
 	buildsMutex       sync.RWMutex
 	buildsArgsForCall []struct {
@@ -96,6 +127,72 @@ type FakeClient struct {
 		result2 concourse.Pagination
 		result3 error
 	}
+	CreateLocalUserStub        func(string, string) (atc.LocalUser, error)
+	createLocalUserMutex       sync.RWMutex
+	createLocalUserArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	createLocalUserReturns struct {
+		result1 atc.LocalUser
+		result2 error
+	}
+	createLocalUserReturnsOnCall map[int]struct {
+		result1 atc.LocalUser
+		result2 error
+	}
+	CreateWorkerKeyStub        func(atc.WorkerKeyRequest) (atc.WorkerKey, error)
+	createWorkerKeyMutex       sync.RWMutex
+	createWorkerKeyArgsForCall []struct {
+		arg1 atc.WorkerKeyRequest
+	}
+	createWorkerKeyReturns struct {
+		result1 atc.WorkerKey
+		result2 error
+	}
+	createWorkerKeyReturnsOnCall map[int]struct {
+		result1 atc.WorkerKey
+		result2 error
+	}
+	DeleteWorkerKeyStub        func(string) (bool, error)
+	deleteWorkerKeyMutex       sync.RWMutex
+	deleteWorkerKeyArgsForCall []struct {
+		arg1 string
+	}
+	deleteWorkerKeyReturns struct {
+		result1 bool
+		result2 error
+	}
+	deleteWorkerKeyReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	DisableLocalUserStub        func(string) (bool, error)
+	disableLocalUserMutex       sync.RWMutex
+	disableLocalUserArgsForCall []struct {
+		arg1 string
+	}
+	disableLocalUserReturns struct {
+		result1 bool
+		result2 error
+	}
+	disableLocalUserReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	EnableLocalUserStub        func(string) (bool, error)
+	enableLocalUserMutex       sync.RWMutex
+	enableLocalUserArgsForCall []struct {
+		arg1 string
+	}
+	enableLocalUserReturns struct {
+		result1 bool
+		result2 error
+	}
+	enableLocalUserReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	GetCLIReaderStub        func(string, string) (io.ReadCloser, http.Header, error)
 	getCLIReaderMutex       sync.RWMutex
 	getCLIReaderArgsForCall []struct {
@@ -158,6 +255,18 @@ type FakeClient struct {
 		result1 []atc.WorkerArtifact
 		result2 error
 	}
+	ListLocalUsersStub        func() ([]atc.LocalUser, error)
+	listLocalUsersMutex       sync.RWMutex
+	listLocalUsersArgsForCall []struct {
+	}
+	listLocalUsersReturns struct {
+		result1 []atc.LocalUser
+		result2 error
+	}
+	listLocalUsersReturnsOnCall map[int]struct {
+		result1 []atc.LocalUser
+		result2 error
+	}
 	ListPipelinesStub        func() ([]atc.Pipeline, error)
 	listPipelinesMutex       sync.RWMutex
 	listPipelinesArgsForCall []struct {
@@ -182,6 +291,18 @@ type FakeClient struct {
 		result1 []atc.Team
 		result2 error
 	}
+	ListWorkerKeysStub        func() ([]atc.WorkerKey, error)
+	listWorkerKeysMutex       sync.RWMutex
+	listWorkerKeysArgsForCall []struct {
+	}
+	listWorkerKeysReturns struct {
+		result1 []atc.WorkerKey
+		result2 error
+	}
+	listWorkerKeysReturnsOnCall map[int]struct {
+		result1 []atc.WorkerKey
+		result2 error
+	}
 	ListWorkersStub        func() ([]atc.Worker, error)
 	listWorkersMutex       sync.RWMutex
 	listWorkersArgsForCall []struct {
@@ -219,6 +340,33 @@ type FakeClient struct {
 		result1 *atc.Worker
 		result2 error
 	}
+	SetLocalUserPasswordStub        func(string, string) (bool, error)
+	setLocalUserPasswordMutex       sync.RWMutex
+	setLocalUserPasswordArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	setLocalUserPasswordReturns struct {
+		result1 bool
+		result2 error
+	}
+	setLocalUserPasswordReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	StatusEventsStub        func(concourse.StatusEventFilter) (concourse.StatusEventStream, error)
+	statusEventsMutex       sync.RWMutex
+	statusEventsArgsForCall []struct {
+		arg1 concourse.StatusEventFilter
+	}
+	statusEventsReturns struct {
+		result1 concourse.StatusEventStream
+		result2 error
+	}
+	statusEventsReturnsOnCall map[int]struct {
+		result1 concourse.StatusEventStream
+		result2 error
+	}
 	TeamStub        func(string) concourse.Team
 	teamMutex       sync.RWMutex
 	teamArgsForCall []struct {
@@ -240,6 +388,17 @@ type FakeClient struct {
 	uRLReturnsOnCall map[int]struct {
 		result1 string
 	}
+	UnquarantineWorkerStub        func(string) error
+	unquarantineWorkerMutex       sync.RWMutex
+	unquarantineWorkerArgsForCall []struct {
+		arg1 string
+	}
+	unquarantineWorkerReturns struct {
+		result1 error
+	}
+	unquarantineWorkerReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UserInfoStub        func() (map[string]interface{}, error)
 	userInfoMutex       sync.RWMutex
 	userInfoArgsForCall []struct {
@@ -316,6 +475,73 @@ func (fake *FakeClient) AbortBuildReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeClient) AuditEvents(arg1 concourse.AuditEventFilter, arg2 concourse.Page) ([]atc.AuditEvent, concourse.Pagination, error) {
+	fake.auditEventsMutex.Lock()
+	ret, specificReturn := fake.auditEventsReturnsOnCall[len(fake.auditEventsArgsForCall)]
+	fake.auditEventsArgsForCall = append(fake.auditEventsArgsForCall, struct {
+		arg1 concourse.AuditEventFilter
+		arg2 concourse.Page
+	}{arg1, arg2})
+	fake.recordInvocation("AuditEvents", []interface{}{arg1, arg2})
+	fake.auditEventsMutex.Unlock()
+	if fake.AuditEventsStub != nil {
+		return fake.AuditEventsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.auditEventsReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) AuditEventsCallCount() int {
+	fake.auditEventsMutex.RLock()
+	defer fake.auditEventsMutex.RUnlock()
+	return len(fake.auditEventsArgsForCall)
+}
+
+func (fake *FakeClient) AuditEventsCalls(stub func(concourse.AuditEventFilter, concourse.Page) ([]atc.AuditEvent, concourse.Pagination, error)) {
+	fake.auditEventsMutex.Lock()
+	defer fake.auditEventsMutex.Unlock()
+	fake.AuditEventsStub = stub
+}
+
+func (fake *FakeClient) AuditEventsArgsForCall(i int) (concourse.AuditEventFilter, concourse.Page) {
+	fake.auditEventsMutex.RLock()
+	defer fake.auditEventsMutex.RUnlock()
+	argsForCall := fake.auditEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) AuditEventsReturns(result1 []atc.AuditEvent, result2 concourse.Pagination, result3 error) {
+	fake.auditEventsMutex.Lock()
+	defer fake.auditEventsMutex.Unlock()
+	fake.AuditEventsStub = nil
+	fake.auditEventsReturns = struct {
+		result1 []atc.AuditEvent
+		result2 concourse.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) AuditEventsReturnsOnCall(i int, result1 []atc.AuditEvent, result2 concourse.Pagination, result3 error) {
+	fake.auditEventsMutex.Lock()
+	defer fake.auditEventsMutex.Unlock()
+	fake.AuditEventsStub = nil
+	if fake.auditEventsReturnsOnCall == nil {
+		fake.auditEventsReturnsOnCall = make(map[int]struct {
+			result1 []atc.AuditEvent
+			result2 concourse.Pagination
+			result3 error
+		})
+	}
+	fake.auditEventsReturnsOnCall[i] = struct {
+		result1 []atc.AuditEvent
+		result2 concourse.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeClient) Build(arg1 string) (atc.Build, bool, error) {
 	fake.buildMutex.Lock()
 	ret, specificReturn := fake.buildReturnsOnCall[len(fake.buildArgsForCall)]
@@ -577,6 +803,72 @@ func (fake *FakeClient) BuildResourcesReturnsOnCall(i int, result1 atc.BuildInpu
 	}{result1, result2, result3}
 }
 
+func (fake *FakeClient) BuildTimeline(arg1 int) (atc.BuildTimeline, bool, error) {
+	fake.buildTimelineMutex.Lock()
+	ret, specificReturn := fake.buildTimelineReturnsOnCall[len(fake.buildTimelineArgsForCall)]
+	fake.buildTimelineArgsForCall = append(fake.buildTimelineArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("BuildTimeline", []interface{}{arg1})
+	fake.buildTimelineMutex.Unlock()
+	if fake.BuildTimelineStub != nil {
+		return fake.BuildTimelineStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.buildTimelineReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) BuildTimelineCallCount() int {
+	fake.buildTimelineMutex.RLock()
+	defer fake.buildTimelineMutex.RUnlock()
+	return len(fake.buildTimelineArgsForCall)
+}
+
+func (fake *FakeClient) BuildTimelineCalls(stub func(int) (atc.BuildTimeline, bool, error)) {
+	fake.buildTimelineMutex.Lock()
+	defer fake.buildTimelineMutex.Unlock()
+	fake.BuildTimelineStub = stub
+}
+
+func (fake *FakeClient) BuildTimelineArgsForCall(i int) int {
+	fake.buildTimelineMutex.RLock()
+	defer fake.buildTimelineMutex.RUnlock()
+	argsForCall := fake.buildTimelineArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) BuildTimelineReturns(result1 atc.BuildTimeline, result2 bool, result3 error) {
+	fake.buildTimelineMutex.Lock()
+	defer fake.buildTimelineMutex.Unlock()
+	fake.BuildTimelineStub = nil
+	fake.buildTimelineReturns = struct {
+		result1 atc.BuildTimeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) BuildTimelineReturnsOnCall(i int, result1 atc.BuildTimeline, result2 bool, result3 error) {
+	fake.buildTimelineMutex.Lock()
+	defer fake.buildTimelineMutex.Unlock()
+	fake.BuildTimelineStub = nil
+	if fake.buildTimelineReturnsOnCall == nil {
+		fake.buildTimelineReturnsOnCall = make(map[int]struct {
+			result1 atc.BuildTimeline
+			result2 bool
+			result3 error
+		})
+	}
+	fake.buildTimelineReturnsOnCall[i] = struct {
+		result1 atc.BuildTimeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeClient) Builds(arg1 concourse.Page) ([]atc.Build, concourse.Pagination, error) {
 	fake.buildsMutex.Lock()
 	ret, specificReturn := fake.buildsReturnsOnCall[len(fake.buildsArgsForCall)]
@@ -643,6 +935,322 @@ func (fake *FakeClient) BuildsReturnsOnCall(i int, result1 []atc.Build, result2
 	}{result1, result2, result3}
 }
 
+func (fake *FakeClient) CreateLocalUser(arg1 string, arg2 string) (atc.LocalUser, error) {
+	fake.createLocalUserMutex.Lock()
+	ret, specificReturn := fake.createLocalUserReturnsOnCall[len(fake.createLocalUserArgsForCall)]
+	fake.createLocalUserArgsForCall = append(fake.createLocalUserArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("CreateLocalUser", []interface{}{arg1, arg2})
+	fake.createLocalUserMutex.Unlock()
+	if fake.CreateLocalUserStub != nil {
+		return fake.CreateLocalUserStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createLocalUserReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) CreateLocalUserCallCount() int {
+	fake.createLocalUserMutex.RLock()
+	defer fake.createLocalUserMutex.RUnlock()
+	return len(fake.createLocalUserArgsForCall)
+}
+
+func (fake *FakeClient) CreateLocalUserCalls(stub func(string, string) (atc.LocalUser, error)) {
+	fake.createLocalUserMutex.Lock()
+	defer fake.createLocalUserMutex.Unlock()
+	fake.CreateLocalUserStub = stub
+}
+
+func (fake *FakeClient) CreateLocalUserArgsForCall(i int) (string, string) {
+	fake.createLocalUserMutex.RLock()
+	defer fake.createLocalUserMutex.RUnlock()
+	argsForCall := fake.createLocalUserArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) CreateLocalUserReturns(result1 atc.LocalUser, result2 error) {
+	fake.createLocalUserMutex.Lock()
+	defer fake.createLocalUserMutex.Unlock()
+	fake.CreateLocalUserStub = nil
+	fake.createLocalUserReturns = struct {
+		result1 atc.LocalUser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) CreateLocalUserReturnsOnCall(i int, result1 atc.LocalUser, result2 error) {
+	fake.createLocalUserMutex.Lock()
+	defer fake.createLocalUserMutex.Unlock()
+	fake.CreateLocalUserStub = nil
+	if fake.createLocalUserReturnsOnCall == nil {
+		fake.createLocalUserReturnsOnCall = make(map[int]struct {
+			result1 atc.LocalUser
+			result2 error
+		})
+	}
+	fake.createLocalUserReturnsOnCall[i] = struct {
+		result1 atc.LocalUser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) CreateWorkerKey(arg1 atc.WorkerKeyRequest) (atc.WorkerKey, error) {
+	fake.createWorkerKeyMutex.Lock()
+	ret, specificReturn := fake.createWorkerKeyReturnsOnCall[len(fake.createWorkerKeyArgsForCall)]
+	fake.createWorkerKeyArgsForCall = append(fake.createWorkerKeyArgsForCall, struct {
+		arg1 atc.WorkerKeyRequest
+	}{arg1})
+	fake.recordInvocation("CreateWorkerKey", []interface{}{arg1})
+	fake.createWorkerKeyMutex.Unlock()
+	if fake.CreateWorkerKeyStub != nil {
+		return fake.CreateWorkerKeyStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createWorkerKeyReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) CreateWorkerKeyCallCount() int {
+	fake.createWorkerKeyMutex.RLock()
+	defer fake.createWorkerKeyMutex.RUnlock()
+	return len(fake.createWorkerKeyArgsForCall)
+}
+
+func (fake *FakeClient) CreateWorkerKeyCalls(stub func(atc.WorkerKeyRequest) (atc.WorkerKey, error)) {
+	fake.createWorkerKeyMutex.Lock()
+	defer fake.createWorkerKeyMutex.Unlock()
+	fake.CreateWorkerKeyStub = stub
+}
+
+func (fake *FakeClient) CreateWorkerKeyArgsForCall(i int) atc.WorkerKeyRequest {
+	fake.createWorkerKeyMutex.RLock()
+	defer fake.createWorkerKeyMutex.RUnlock()
+	argsForCall := fake.createWorkerKeyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) CreateWorkerKeyReturns(result1 atc.WorkerKey, result2 error) {
+	fake.createWorkerKeyMutex.Lock()
+	defer fake.createWorkerKeyMutex.Unlock()
+	fake.CreateWorkerKeyStub = nil
+	fake.createWorkerKeyReturns = struct {
+		result1 atc.WorkerKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) CreateWorkerKeyReturnsOnCall(i int, result1 atc.WorkerKey, result2 error) {
+	fake.createWorkerKeyMutex.Lock()
+	defer fake.createWorkerKeyMutex.Unlock()
+	fake.CreateWorkerKeyStub = nil
+	if fake.createWorkerKeyReturnsOnCall == nil {
+		fake.createWorkerKeyReturnsOnCall = make(map[int]struct {
+			result1 atc.WorkerKey
+			result2 error
+		})
+	}
+	fake.createWorkerKeyReturnsOnCall[i] = struct {
+		result1 atc.WorkerKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) DeleteWorkerKey(arg1 string) (bool, error) {
+	fake.deleteWorkerKeyMutex.Lock()
+	ret, specificReturn := fake.deleteWorkerKeyReturnsOnCall[len(fake.deleteWorkerKeyArgsForCall)]
+	fake.deleteWorkerKeyArgsForCall = append(fake.deleteWorkerKeyArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("DeleteWorkerKey", []interface{}{arg1})
+	fake.deleteWorkerKeyMutex.Unlock()
+	if fake.DeleteWorkerKeyStub != nil {
+		return fake.DeleteWorkerKeyStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.deleteWorkerKeyReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) DeleteWorkerKeyCallCount() int {
+	fake.deleteWorkerKeyMutex.RLock()
+	defer fake.deleteWorkerKeyMutex.RUnlock()
+	return len(fake.deleteWorkerKeyArgsForCall)
+}
+
+func (fake *FakeClient) DeleteWorkerKeyCalls(stub func(string) (bool, error)) {
+	fake.deleteWorkerKeyMutex.Lock()
+	defer fake.deleteWorkerKeyMutex.Unlock()
+	fake.DeleteWorkerKeyStub = stub
+}
+
+func (fake *FakeClient) DeleteWorkerKeyArgsForCall(i int) string {
+	fake.deleteWorkerKeyMutex.RLock()
+	defer fake.deleteWorkerKeyMutex.RUnlock()
+	argsForCall := fake.deleteWorkerKeyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) DeleteWorkerKeyReturns(result1 bool, result2 error) {
+	fake.deleteWorkerKeyMutex.Lock()
+	defer fake.deleteWorkerKeyMutex.Unlock()
+	fake.DeleteWorkerKeyStub = nil
+	fake.deleteWorkerKeyReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) DeleteWorkerKeyReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.deleteWorkerKeyMutex.Lock()
+	defer fake.deleteWorkerKeyMutex.Unlock()
+	fake.DeleteWorkerKeyStub = nil
+	if fake.deleteWorkerKeyReturnsOnCall == nil {
+		fake.deleteWorkerKeyReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.deleteWorkerKeyReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) DisableLocalUser(arg1 string) (bool, error) {
+	fake.disableLocalUserMutex.Lock()
+	ret, specificReturn := fake.disableLocalUserReturnsOnCall[len(fake.disableLocalUserArgsForCall)]
+	fake.disableLocalUserArgsForCall = append(fake.disableLocalUserArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("DisableLocalUser", []interface{}{arg1})
+	fake.disableLocalUserMutex.Unlock()
+	if fake.DisableLocalUserStub != nil {
+		return fake.DisableLocalUserStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.disableLocalUserReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) DisableLocalUserCallCount() int {
+	fake.disableLocalUserMutex.RLock()
+	defer fake.disableLocalUserMutex.RUnlock()
+	return len(fake.disableLocalUserArgsForCall)
+}
+
+func (fake *FakeClient) DisableLocalUserCalls(stub func(string) (bool, error)) {
+	fake.disableLocalUserMutex.Lock()
+	defer fake.disableLocalUserMutex.Unlock()
+	fake.DisableLocalUserStub = stub
+}
+
+func (fake *FakeClient) DisableLocalUserArgsForCall(i int) string {
+	fake.disableLocalUserMutex.RLock()
+	defer fake.disableLocalUserMutex.RUnlock()
+	argsForCall := fake.disableLocalUserArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) DisableLocalUserReturns(result1 bool, result2 error) {
+	fake.disableLocalUserMutex.Lock()
+	defer fake.disableLocalUserMutex.Unlock()
+	fake.DisableLocalUserStub = nil
+	fake.disableLocalUserReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) DisableLocalUserReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.disableLocalUserMutex.Lock()
+	defer fake.disableLocalUserMutex.Unlock()
+	fake.DisableLocalUserStub = nil
+	if fake.disableLocalUserReturnsOnCall == nil {
+		fake.disableLocalUserReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.disableLocalUserReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) EnableLocalUser(arg1 string) (bool, error) {
+	fake.enableLocalUserMutex.Lock()
+	ret, specificReturn := fake.enableLocalUserReturnsOnCall[len(fake.enableLocalUserArgsForCall)]
+	fake.enableLocalUserArgsForCall = append(fake.enableLocalUserArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("EnableLocalUser", []interface{}{arg1})
+	fake.enableLocalUserMutex.Unlock()
+	if fake.EnableLocalUserStub != nil {
+		return fake.EnableLocalUserStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.enableLocalUserReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) EnableLocalUserCallCount() int {
+	fake.enableLocalUserMutex.RLock()
+	defer fake.enableLocalUserMutex.RUnlock()
+	return len(fake.enableLocalUserArgsForCall)
+}
+
+func (fake *FakeClient) EnableLocalUserCalls(stub func(string) (bool, error)) {
+	fake.enableLocalUserMutex.Lock()
+	defer fake.enableLocalUserMutex.Unlock()
+	fake.EnableLocalUserStub = stub
+}
+
+func (fake *FakeClient) EnableLocalUserArgsForCall(i int) string {
+	fake.enableLocalUserMutex.RLock()
+	defer fake.enableLocalUserMutex.RUnlock()
+	argsForCall := fake.enableLocalUserArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) EnableLocalUserReturns(result1 bool, result2 error) {
+	fake.enableLocalUserMutex.Lock()
+	defer fake.enableLocalUserMutex.Unlock()
+	fake.EnableLocalUserStub = nil
+	fake.enableLocalUserReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) EnableLocalUserReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.enableLocalUserMutex.Lock()
+	defer fake.enableLocalUserMutex.Unlock()
+	fake.EnableLocalUserStub = nil
+	if fake.enableLocalUserReturnsOnCall == nil {
+		fake.enableLocalUserReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.enableLocalUserReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) GetCLIReader(arg1 string, arg2 string) (io.ReadCloser, http.Header, error) {
 	fake.getCLIReaderMutex.Lock()
 	ret, specificReturn := fake.getCLIReaderReturnsOnCall[len(fake.getCLIReaderArgsForCall)]
@@ -940,6 +1548,61 @@ func (fake *FakeClient) ListBuildArtifactsReturnsOnCall(i int, result1 []atc.Wor
 	}{result1, result2}
 }
 
+func (fake *FakeClient) ListLocalUsers() ([]atc.LocalUser, error) {
+	fake.listLocalUsersMutex.Lock()
+	ret, specificReturn := fake.listLocalUsersReturnsOnCall[len(fake.listLocalUsersArgsForCall)]
+	fake.listLocalUsersArgsForCall = append(fake.listLocalUsersArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ListLocalUsers", []interface{}{})
+	fake.listLocalUsersMutex.Unlock()
+	if fake.ListLocalUsersStub != nil {
+		return fake.ListLocalUsersStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listLocalUsersReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ListLocalUsersCallCount() int {
+	fake.listLocalUsersMutex.RLock()
+	defer fake.listLocalUsersMutex.RUnlock()
+	return len(fake.listLocalUsersArgsForCall)
+}
+
+func (fake *FakeClient) ListLocalUsersCalls(stub func() ([]atc.LocalUser, error)) {
+	fake.listLocalUsersMutex.Lock()
+	defer fake.listLocalUsersMutex.Unlock()
+	fake.ListLocalUsersStub = stub
+}
+
+func (fake *FakeClient) ListLocalUsersReturns(result1 []atc.LocalUser, result2 error) {
+	fake.listLocalUsersMutex.Lock()
+	defer fake.listLocalUsersMutex.Unlock()
+	fake.ListLocalUsersStub = nil
+	fake.listLocalUsersReturns = struct {
+		result1 []atc.LocalUser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ListLocalUsersReturnsOnCall(i int, result1 []atc.LocalUser, result2 error) {
+	fake.listLocalUsersMutex.Lock()
+	defer fake.listLocalUsersMutex.Unlock()
+	fake.ListLocalUsersStub = nil
+	if fake.listLocalUsersReturnsOnCall == nil {
+		fake.listLocalUsersReturnsOnCall = make(map[int]struct {
+			result1 []atc.LocalUser
+			result2 error
+		})
+	}
+	fake.listLocalUsersReturnsOnCall[i] = struct {
+		result1 []atc.LocalUser
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) ListPipelines() ([]atc.Pipeline, error) {
 	fake.listPipelinesMutex.Lock()
 	ret, specificReturn := fake.listPipelinesReturnsOnCall[len(fake.listPipelinesArgsForCall)]
@@ -1050,6 +1713,61 @@ func (fake *FakeClient) ListTeamsReturnsOnCall(i int, result1 []atc.Team, result
 	}{result1, result2}
 }
 
+func (fake *FakeClient) ListWorkerKeys() ([]atc.WorkerKey, error) {
+	fake.listWorkerKeysMutex.Lock()
+	ret, specificReturn := fake.listWorkerKeysReturnsOnCall[len(fake.listWorkerKeysArgsForCall)]
+	fake.listWorkerKeysArgsForCall = append(fake.listWorkerKeysArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ListWorkerKeys", []interface{}{})
+	fake.listWorkerKeysMutex.Unlock()
+	if fake.ListWorkerKeysStub != nil {
+		return fake.ListWorkerKeysStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listWorkerKeysReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ListWorkerKeysCallCount() int {
+	fake.listWorkerKeysMutex.RLock()
+	defer fake.listWorkerKeysMutex.RUnlock()
+	return len(fake.listWorkerKeysArgsForCall)
+}
+
+func (fake *FakeClient) ListWorkerKeysCalls(stub func() ([]atc.WorkerKey, error)) {
+	fake.listWorkerKeysMutex.Lock()
+	defer fake.listWorkerKeysMutex.Unlock()
+	fake.ListWorkerKeysStub = stub
+}
+
+func (fake *FakeClient) ListWorkerKeysReturns(result1 []atc.WorkerKey, result2 error) {
+	fake.listWorkerKeysMutex.Lock()
+	defer fake.listWorkerKeysMutex.Unlock()
+	fake.ListWorkerKeysStub = nil
+	fake.listWorkerKeysReturns = struct {
+		result1 []atc.WorkerKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ListWorkerKeysReturnsOnCall(i int, result1 []atc.WorkerKey, result2 error) {
+	fake.listWorkerKeysMutex.Lock()
+	defer fake.listWorkerKeysMutex.Unlock()
+	fake.ListWorkerKeysStub = nil
+	if fake.listWorkerKeysReturnsOnCall == nil {
+		fake.listWorkerKeysReturnsOnCall = make(map[int]struct {
+			result1 []atc.WorkerKey
+			result2 error
+		})
+	}
+	fake.listWorkerKeysReturnsOnCall[i] = struct {
+		result1 []atc.WorkerKey
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) ListWorkers() ([]atc.Worker, error) {
 	fake.listWorkersMutex.Lock()
 	ret, specificReturn := fake.listWorkersReturnsOnCall[len(fake.listWorkersArgsForCall)]
@@ -1229,6 +1947,133 @@ func (fake *FakeClient) SaveWorkerReturnsOnCall(i int, result1 *atc.Worker, resu
 	}{result1, result2}
 }
 
+func (fake *FakeClient) SetLocalUserPassword(arg1 string, arg2 string) (bool, error) {
+	fake.setLocalUserPasswordMutex.Lock()
+	ret, specificReturn := fake.setLocalUserPasswordReturnsOnCall[len(fake.setLocalUserPasswordArgsForCall)]
+	fake.setLocalUserPasswordArgsForCall = append(fake.setLocalUserPasswordArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("SetLocalUserPassword", []interface{}{arg1, arg2})
+	fake.setLocalUserPasswordMutex.Unlock()
+	if fake.SetLocalUserPasswordStub != nil {
+		return fake.SetLocalUserPasswordStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.setLocalUserPasswordReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) SetLocalUserPasswordCallCount() int {
+	fake.setLocalUserPasswordMutex.RLock()
+	defer fake.setLocalUserPasswordMutex.RUnlock()
+	return len(fake.setLocalUserPasswordArgsForCall)
+}
+
+func (fake *FakeClient) SetLocalUserPasswordCalls(stub func(string, string) (bool, error)) {
+	fake.setLocalUserPasswordMutex.Lock()
+	defer fake.setLocalUserPasswordMutex.Unlock()
+	fake.SetLocalUserPasswordStub = stub
+}
+
+func (fake *FakeClient) SetLocalUserPasswordArgsForCall(i int) (string, string) {
+	fake.setLocalUserPasswordMutex.RLock()
+	defer fake.setLocalUserPasswordMutex.RUnlock()
+	argsForCall := fake.setLocalUserPasswordArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) SetLocalUserPasswordReturns(result1 bool, result2 error) {
+	fake.setLocalUserPasswordMutex.Lock()
+	defer fake.setLocalUserPasswordMutex.Unlock()
+	fake.SetLocalUserPasswordStub = nil
+	fake.setLocalUserPasswordReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) SetLocalUserPasswordReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.setLocalUserPasswordMutex.Lock()
+	defer fake.setLocalUserPasswordMutex.Unlock()
+	fake.SetLocalUserPasswordStub = nil
+	if fake.setLocalUserPasswordReturnsOnCall == nil {
+		fake.setLocalUserPasswordReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.setLocalUserPasswordReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) StatusEvents(arg1 concourse.StatusEventFilter) (concourse.StatusEventStream, error) {
+	fake.statusEventsMutex.Lock()
+	ret, specificReturn := fake.statusEventsReturnsOnCall[len(fake.statusEventsArgsForCall)]
+	fake.statusEventsArgsForCall = append(fake.statusEventsArgsForCall, struct {
+		arg1 concourse.StatusEventFilter
+	}{arg1})
+	fake.recordInvocation("StatusEvents", []interface{}{arg1})
+	fake.statusEventsMutex.Unlock()
+	if fake.StatusEventsStub != nil {
+		return fake.StatusEventsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.statusEventsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) StatusEventsCallCount() int {
+	fake.statusEventsMutex.RLock()
+	defer fake.statusEventsMutex.RUnlock()
+	return len(fake.statusEventsArgsForCall)
+}
+
+func (fake *FakeClient) StatusEventsCalls(stub func(concourse.StatusEventFilter) (concourse.StatusEventStream, error)) {
+	fake.statusEventsMutex.Lock()
+	defer fake.statusEventsMutex.Unlock()
+	fake.StatusEventsStub = stub
+}
+
+func (fake *FakeClient) StatusEventsArgsForCall(i int) concourse.StatusEventFilter {
+	fake.statusEventsMutex.RLock()
+	defer fake.statusEventsMutex.RUnlock()
+	argsForCall := fake.statusEventsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) StatusEventsReturns(result1 concourse.StatusEventStream, result2 error) {
+	fake.statusEventsMutex.Lock()
+	defer fake.statusEventsMutex.Unlock()
+	fake.StatusEventsStub = nil
+	fake.statusEventsReturns = struct {
+		result1 concourse.StatusEventStream
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) StatusEventsReturnsOnCall(i int, result1 concourse.StatusEventStream, result2 error) {
+	fake.statusEventsMutex.Lock()
+	defer fake.statusEventsMutex.Unlock()
+	fake.StatusEventsStub = nil
+	if fake.statusEventsReturnsOnCall == nil {
+		fake.statusEventsReturnsOnCall = make(map[int]struct {
+			result1 concourse.StatusEventStream
+			result2 error
+		})
+	}
+	fake.statusEventsReturnsOnCall[i] = struct {
+		result1 concourse.StatusEventStream
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) Team(arg1 string) concourse.Team {
 	fake.teamMutex.Lock()
 	ret, specificReturn := fake.teamReturnsOnCall[len(fake.teamArgsForCall)]
@@ -1341,6 +2186,66 @@ func (fake *FakeClient) URLReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeClient) UnquarantineWorker(arg1 string) error {
+	fake.unquarantineWorkerMutex.Lock()
+	ret, specificReturn := fake.unquarantineWorkerReturnsOnCall[len(fake.unquarantineWorkerArgsForCall)]
+	fake.unquarantineWorkerArgsForCall = append(fake.unquarantineWorkerArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("UnquarantineWorker", []interface{}{arg1})
+	fake.unquarantineWorkerMutex.Unlock()
+	if fake.UnquarantineWorkerStub != nil {
+		return fake.UnquarantineWorkerStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.unquarantineWorkerReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) UnquarantineWorkerCallCount() int {
+	fake.unquarantineWorkerMutex.RLock()
+	defer fake.unquarantineWorkerMutex.RUnlock()
+	return len(fake.unquarantineWorkerArgsForCall)
+}
+
+func (fake *FakeClient) UnquarantineWorkerCalls(stub func(string) error) {
+	fake.unquarantineWorkerMutex.Lock()
+	defer fake.unquarantineWorkerMutex.Unlock()
+	fake.UnquarantineWorkerStub = stub
+}
+
+func (fake *FakeClient) UnquarantineWorkerArgsForCall(i int) string {
+	fake.unquarantineWorkerMutex.RLock()
+	defer fake.unquarantineWorkerMutex.RUnlock()
+	argsForCall := fake.unquarantineWorkerArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) UnquarantineWorkerReturns(result1 error) {
+	fake.unquarantineWorkerMutex.Lock()
+	defer fake.unquarantineWorkerMutex.Unlock()
+	fake.UnquarantineWorkerStub = nil
+	fake.unquarantineWorkerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) UnquarantineWorkerReturnsOnCall(i int, result1 error) {
+	fake.unquarantineWorkerMutex.Lock()
+	defer fake.unquarantineWorkerMutex.Unlock()
+	fake.UnquarantineWorkerStub = nil
+	if fake.unquarantineWorkerReturnsOnCall == nil {
+		fake.unquarantineWorkerReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unquarantineWorkerReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeClient) UserInfo() (map[string]interface{}, error) {
 	fake.userInfoMutex.Lock()
 	ret, specificReturn := fake.userInfoReturnsOnCall[len(fake.userInfoArgsForCall)]
@@ -1401,6 +2306,8 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.abortBuildMutex.RLock()
 	defer fake.abortBuildMutex.RUnlock()
+	fake.auditEventsMutex.RLock()
+	defer fake.auditEventsMutex.RUnlock()
 	fake.buildMutex.RLock()
 	defer fake.buildMutex.RUnlock()
 	fake.buildEventsMutex.RLock()
@@ -1409,8 +2316,20 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.buildPlanMutex.RUnlock()
 	fake.buildResourcesMutex.RLock()
 	defer fake.buildResourcesMutex.RUnlock()
+	fake.buildTimelineMutex.RLock()
+	defer fake.buildTimelineMutex.RUnlock()
 	fake.buildsMutex.RLock()
 	defer fake.buildsMutex.RUnlock()
+	fake.createLocalUserMutex.RLock()
+	defer fake.createLocalUserMutex.RUnlock()
+	fake.createWorkerKeyMutex.RLock()
+	defer fake.createWorkerKeyMutex.RUnlock()
+	fake.deleteWorkerKeyMutex.RLock()
+	defer fake.deleteWorkerKeyMutex.RUnlock()
+	fake.disableLocalUserMutex.RLock()
+	defer fake.disableLocalUserMutex.RUnlock()
+	fake.enableLocalUserMutex.RLock()
+	defer fake.enableLocalUserMutex.RUnlock()
 	fake.getCLIReaderMutex.RLock()
 	defer fake.getCLIReaderMutex.RUnlock()
 	fake.getInfoMutex.RLock()
@@ -1421,20 +2340,30 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.landWorkerMutex.RUnlock()
 	fake.listBuildArtifactsMutex.RLock()
 	defer fake.listBuildArtifactsMutex.RUnlock()
+	fake.listLocalUsersMutex.RLock()
+	defer fake.listLocalUsersMutex.RUnlock()
 	fake.listPipelinesMutex.RLock()
 	defer fake.listPipelinesMutex.RUnlock()
 	fake.listTeamsMutex.RLock()
 	defer fake.listTeamsMutex.RUnlock()
+	fake.listWorkerKeysMutex.RLock()
+	defer fake.listWorkerKeysMutex.RUnlock()
 	fake.listWorkersMutex.RLock()
 	defer fake.listWorkersMutex.RUnlock()
 	fake.pruneWorkerMutex.RLock()
 	defer fake.pruneWorkerMutex.RUnlock()
 	fake.saveWorkerMutex.RLock()
 	defer fake.saveWorkerMutex.RUnlock()
+	fake.setLocalUserPasswordMutex.RLock()
+	defer fake.setLocalUserPasswordMutex.RUnlock()
+	fake.statusEventsMutex.RLock()
+	defer fake.statusEventsMutex.RUnlock()
 	fake.teamMutex.RLock()
 	defer fake.teamMutex.RUnlock()
 	fake.uRLMutex.RLock()
 	defer fake.uRLMutex.RUnlock()
+	fake.unquarantineWorkerMutex.RLock()
+	defer fake.unquarantineWorkerMutex.RUnlock()
 	fake.userInfoMutex.RLock()
 	defer fake.userInfoMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
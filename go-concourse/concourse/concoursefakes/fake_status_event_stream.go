@@ -0,0 +1,171 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package concoursefakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+)
+
+type FakeStatusEventStream struct {
+	CloseStub        func() error
+	closeMutex       sync.RWMutex
+	closeArgsForCall []struct {
+	}
+	closeReturns struct {
+		result1 error
+	}
+	closeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	NextStatusEventStub        func() (atc.StatusEvent, error)
+	nextStatusEventMutex       sync.RWMutex
+	nextStatusEventArgsForCall []struct {
+	}
+	nextStatusEventReturns struct {
+		result1 atc.StatusEvent
+		result2 error
+	}
+	nextStatusEventReturnsOnCall map[int]struct {
+		result1 atc.StatusEvent
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeStatusEventStream) Close() error {
+	fake.closeMutex.Lock()
+	ret, specificReturn := fake.closeReturnsOnCall[len(fake.closeArgsForCall)]
+	fake.closeArgsForCall = append(fake.closeArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Close", []interface{}{})
+	fake.closeMutex.Unlock()
+	if fake.CloseStub != nil {
+		return fake.CloseStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.closeReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeStatusEventStream) CloseCallCount() int {
+	fake.closeMutex.RLock()
+	defer fake.closeMutex.RUnlock()
+	return len(fake.closeArgsForCall)
+}
+
+func (fake *FakeStatusEventStream) CloseCalls(stub func() error) {
+	fake.closeMutex.Lock()
+	defer fake.closeMutex.Unlock()
+	fake.CloseStub = stub
+}
+
+func (fake *FakeStatusEventStream) CloseReturns(result1 error) {
+	fake.closeMutex.Lock()
+	defer fake.closeMutex.Unlock()
+	fake.CloseStub = nil
+	fake.closeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStatusEventStream) CloseReturnsOnCall(i int, result1 error) {
+	fake.closeMutex.Lock()
+	defer fake.closeMutex.Unlock()
+	fake.CloseStub = nil
+	if fake.closeReturnsOnCall == nil {
+		fake.closeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.closeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStatusEventStream) NextStatusEvent() (atc.StatusEvent, error) {
+	fake.nextStatusEventMutex.Lock()
+	ret, specificReturn := fake.nextStatusEventReturnsOnCall[len(fake.nextStatusEventArgsForCall)]
+	fake.nextStatusEventArgsForCall = append(fake.nextStatusEventArgsForCall, struct {
+	}{})
+	fake.recordInvocation("NextStatusEvent", []interface{}{})
+	fake.nextStatusEventMutex.Unlock()
+	if fake.NextStatusEventStub != nil {
+		return fake.NextStatusEventStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.nextStatusEventReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStatusEventStream) NextStatusEventCallCount() int {
+	fake.nextStatusEventMutex.RLock()
+	defer fake.nextStatusEventMutex.RUnlock()
+	return len(fake.nextStatusEventArgsForCall)
+}
+
+func (fake *FakeStatusEventStream) NextStatusEventCalls(stub func() (atc.StatusEvent, error)) {
+	fake.nextStatusEventMutex.Lock()
+	defer fake.nextStatusEventMutex.Unlock()
+	fake.NextStatusEventStub = stub
+}
+
+func (fake *FakeStatusEventStream) NextStatusEventReturns(result1 atc.StatusEvent, result2 error) {
+	fake.nextStatusEventMutex.Lock()
+	defer fake.nextStatusEventMutex.Unlock()
+	fake.NextStatusEventStub = nil
+	fake.nextStatusEventReturns = struct {
+		result1 atc.StatusEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStatusEventStream) NextStatusEventReturnsOnCall(i int, result1 atc.StatusEvent, result2 error) {
+	fake.nextStatusEventMutex.Lock()
+	defer fake.nextStatusEventMutex.Unlock()
+	fake.NextStatusEventStub = nil
+	if fake.nextStatusEventReturnsOnCall == nil {
+		fake.nextStatusEventReturnsOnCall = make(map[int]struct {
+			result1 atc.StatusEvent
+			result2 error
+		})
+	}
+	fake.nextStatusEventReturnsOnCall[i] = struct {
+		result1 atc.StatusEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStatusEventStream) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.closeMutex.RLock()
+	defer fake.closeMutex.RUnlock()
+	fake.nextStatusEventMutex.RLock()
+	defer fake.nextStatusEventMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeStatusEventStream) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ concourse.StatusEventStream = new(FakeStatusEventStream)
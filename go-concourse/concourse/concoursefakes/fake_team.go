@@ -10,6 +10,22 @@ import (
 )
 
 type FakeTeam struct {
+	AuditEventsStub        func(concourse.AuditEventFilter, concourse.Page) ([]atc.AuditEvent, concourse.Pagination, error)
+	auditEventsMutex       sync.RWMutex
+	auditEventsArgsForCall []struct {
+		arg1 concourse.AuditEventFilter
+		arg2 concourse.Page
+	}
+	auditEventsReturns struct {
+		result1 []atc.AuditEvent
+		result2 concourse.Pagination
+		result3 error
+	}
+	auditEventsReturnsOnCall map[int]struct {
+		result1 []atc.AuditEvent
+		result2 concourse.Pagination
+		result3 error
+	}
 	BuildInputsForJobStub        func(string, string) ([]atc.BuildInput, bool, error)
 	buildInputsForJobMutex       sync.RWMutex
 	buildInputsForJobArgsForCall []struct {
@@ -121,6 +137,19 @@ type FakeTeam struct {
 		result1 int64
 		result2 error
 	}
+	CreateAPITokenStub        func(atc.APITokenRequest) (atc.APIToken, error)
+	createAPITokenMutex       sync.RWMutex
+	createAPITokenArgsForCall []struct {
+		arg1 atc.APITokenRequest
+	}
+	createAPITokenReturns struct {
+		result1 atc.APIToken
+		result2 error
+	}
+	createAPITokenReturnsOnCall map[int]struct {
+		result1 atc.APIToken
+		result2 error
+	}
 	CreateArtifactStub        func(io.Reader) (atc.WorkerArtifact, error)
 	createArtifactMutex       sync.RWMutex
 	createArtifactArgsForCall []struct {
@@ -198,6 +227,25 @@ type FakeTeam struct {
 		result3 []concourse.ConfigWarning
 		result4 error
 	}
+	CreateOrUpdateWithWarningsStub        func(atc.Team) (atc.Team, bool, bool, []concourse.ConfigWarning, error)
+	createOrUpdateWithWarningsMutex       sync.RWMutex
+	createOrUpdateWithWarningsArgsForCall []struct {
+		arg1 atc.Team
+	}
+	createOrUpdateWithWarningsReturns struct {
+		result1 atc.Team
+		result2 bool
+		result3 bool
+		result4 []concourse.ConfigWarning
+		result5 error
+	}
+	createOrUpdateWithWarningsReturnsOnCall map[int]struct {
+		result1 atc.Team
+		result2 bool
+		result3 bool
+		result4 []concourse.ConfigWarning
+		result5 error
+	}
 	CreatePipelineBuildStub        func(string, atc.Plan) (atc.Build, error)
 	createPipelineBuildMutex       sync.RWMutex
 	createPipelineBuildArgsForCall []struct {
@@ -212,6 +260,19 @@ type FakeTeam struct {
 		result1 atc.Build
 		result2 error
 	}
+	CreateWorkerKeyStub        func(atc.WorkerKeyRequest) (atc.WorkerKey, error)
+	createWorkerKeyMutex       sync.RWMutex
+	createWorkerKeyArgsForCall []struct {
+		arg1 atc.WorkerKeyRequest
+	}
+	createWorkerKeyReturns struct {
+		result1 atc.WorkerKey
+		result2 error
+	}
+	createWorkerKeyReturnsOnCall map[int]struct {
+		result1 atc.WorkerKey
+		result2 error
+	}
 	DeletePipelineStub        func(string) (bool, error)
 	deletePipelineMutex       sync.RWMutex
 	deletePipelineArgsForCall []struct {
@@ -225,6 +286,19 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	DeleteWorkerKeyStub        func(string) (bool, error)
+	deleteWorkerKeyMutex       sync.RWMutex
+	deleteWorkerKeyArgsForCall []struct {
+		arg1 string
+	}
+	deleteWorkerKeyReturns struct {
+		result1 bool
+		result2 error
+	}
+	deleteWorkerKeyReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	DestroyTeamStub        func(string) error
 	destroyTeamMutex       sync.RWMutex
 	destroyTeamArgsForCall []struct {
@@ -236,6 +310,23 @@ type FakeTeam struct {
 	destroyTeamReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DiffPipelineConfigVersionsStub        func(string, int, int) (atc.ConfigDiff, bool, error)
+	diffPipelineConfigVersionsMutex       sync.RWMutex
+	diffPipelineConfigVersionsArgsForCall []struct {
+		arg1 string
+		arg2 int
+		arg3 int
+	}
+	diffPipelineConfigVersionsReturns struct {
+		result1 atc.ConfigDiff
+		result2 bool
+		result3 error
+	}
+	diffPipelineConfigVersionsReturnsOnCall map[int]struct {
+		result1 atc.ConfigDiff
+		result2 bool
+		result3 error
+	}
 	DisableResourceVersionStub        func(string, string, int) (bool, error)
 	disableResourceVersionMutex       sync.RWMutex
 	disableResourceVersionArgsForCall []struct {
@@ -318,6 +409,33 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	HijackSessionRecordingStub        func(int) (io.ReadCloser, bool, error)
+	hijackSessionRecordingMutex       sync.RWMutex
+	hijackSessionRecordingArgsForCall []struct {
+		arg1 int
+	}
+	hijackSessionRecordingReturns struct {
+		result1 io.ReadCloser
+		result2 bool
+		result3 error
+	}
+	hijackSessionRecordingReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 bool
+		result3 error
+	}
+	HijackSessionsStub        func() ([]atc.HijackSession, error)
+	hijackSessionsMutex       sync.RWMutex
+	hijackSessionsArgsForCall []struct {
+	}
+	hijackSessionsReturns struct {
+		result1 []atc.HijackSession
+		result2 error
+	}
+	hijackSessionsReturnsOnCall map[int]struct {
+		result1 []atc.HijackSession
+		result2 error
+	}
 	JobStub        func(string, string) (atc.Job, bool, error)
 	jobMutex       sync.RWMutex
 	jobArgsForCall []struct {
@@ -370,6 +488,18 @@ type FakeTeam struct {
 		result3 bool
 		result4 error
 	}
+	ListAPITokensStub        func() ([]atc.APIToken, error)
+	listAPITokensMutex       sync.RWMutex
+	listAPITokensArgsForCall []struct {
+	}
+	listAPITokensReturns struct {
+		result1 []atc.APIToken
+		result2 error
+	}
+	listAPITokensReturnsOnCall map[int]struct {
+		result1 []atc.APIToken
+		result2 error
+	}
 	ListContainersStub        func(map[string]string) ([]atc.Container, error)
 	listContainersMutex       sync.RWMutex
 	listContainersArgsForCall []struct {
@@ -433,6 +563,18 @@ type FakeTeam struct {
 		result1 []atc.Volume
 		result2 error
 	}
+	ListWorkerKeysStub        func() ([]atc.WorkerKey, error)
+	listWorkerKeysMutex       sync.RWMutex
+	listWorkerKeysArgsForCall []struct {
+	}
+	listWorkerKeysReturns struct {
+		result1 []atc.WorkerKey
+		result2 error
+	}
+	listWorkerKeysReturnsOnCall map[int]struct {
+		result1 []atc.WorkerKey
+		result2 error
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -443,6 +585,18 @@ type FakeTeam struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	NamedLocksStub        func() ([]atc.NamedLock, error)
+	namedLocksMutex       sync.RWMutex
+	namedLocksArgsForCall []struct {
+	}
+	namedLocksReturns struct {
+		result1 []atc.NamedLock
+		result2 error
+	}
+	namedLocksReturnsOnCall map[int]struct {
+		result1 []atc.NamedLock
+		result2 error
+	}
 	OrderingPipelinesStub        func([]string) error
 	orderingPipelinesMutex       sync.RWMutex
 	orderingPipelinesArgsForCall []struct {
@@ -531,6 +685,50 @@ type FakeTeam struct {
 		result3 bool
 		result4 error
 	}
+	PipelineConfigVersionStub        func(string, int) (atc.ConfigVersion, bool, error)
+	pipelineConfigVersionMutex       sync.RWMutex
+	pipelineConfigVersionArgsForCall []struct {
+		arg1 string
+		arg2 int
+	}
+	pipelineConfigVersionReturns struct {
+		result1 atc.ConfigVersion
+		result2 bool
+		result3 error
+	}
+	pipelineConfigVersionReturnsOnCall map[int]struct {
+		result1 atc.ConfigVersion
+		result2 bool
+		result3 error
+	}
+	PipelineConfigVersionsStub        func(string) ([]atc.ConfigVersion, bool, error)
+	pipelineConfigVersionsMutex       sync.RWMutex
+	pipelineConfigVersionsArgsForCall []struct {
+		arg1 string
+	}
+	pipelineConfigVersionsReturns struct {
+		result1 []atc.ConfigVersion
+		result2 bool
+		result3 error
+	}
+	pipelineConfigVersionsReturnsOnCall map[int]struct {
+		result1 []atc.ConfigVersion
+		result2 bool
+		result3 error
+	}
+	ReleaseNamedLockStub        func(string) (bool, error)
+	releaseNamedLockMutex       sync.RWMutex
+	releaseNamedLockArgsForCall []struct {
+		arg1 string
+	}
+	releaseNamedLockReturns struct {
+		result1 bool
+		result2 error
+	}
+	releaseNamedLockReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	RenamePipelineStub        func(string, string) (bool, error)
 	renamePipelineMutex       sync.RWMutex
 	renamePipelineArgsForCall []struct {
@@ -594,6 +792,19 @@ type FakeTeam struct {
 		result3 bool
 		result4 error
 	}
+	RevokeAPITokenStub        func(string) (bool, error)
+	revokeAPITokenMutex       sync.RWMutex
+	revokeAPITokenArgsForCall []struct {
+		arg1 string
+	}
+	revokeAPITokenReturns struct {
+		result1 bool
+		result2 error
+	}
+	revokeAPITokenReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	TeamStub        func(string) (atc.Team, bool, error)
 	teamMutex       sync.RWMutex
 	teamArgsForCall []struct {
@@ -655,6 +866,73 @@ type FakeTeam struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeTeam) AuditEvents(arg1 concourse.AuditEventFilter, arg2 concourse.Page) ([]atc.AuditEvent, concourse.Pagination, error) {
+	fake.auditEventsMutex.Lock()
+	ret, specificReturn := fake.auditEventsReturnsOnCall[len(fake.auditEventsArgsForCall)]
+	fake.auditEventsArgsForCall = append(fake.auditEventsArgsForCall, struct {
+		arg1 concourse.AuditEventFilter
+		arg2 concourse.Page
+	}{arg1, arg2})
+	fake.recordInvocation("AuditEvents", []interface{}{arg1, arg2})
+	fake.auditEventsMutex.Unlock()
+	if fake.AuditEventsStub != nil {
+		return fake.AuditEventsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.auditEventsReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) AuditEventsCallCount() int {
+	fake.auditEventsMutex.RLock()
+	defer fake.auditEventsMutex.RUnlock()
+	return len(fake.auditEventsArgsForCall)
+}
+
+func (fake *FakeTeam) AuditEventsCalls(stub func(concourse.AuditEventFilter, concourse.Page) ([]atc.AuditEvent, concourse.Pagination, error)) {
+	fake.auditEventsMutex.Lock()
+	defer fake.auditEventsMutex.Unlock()
+	fake.AuditEventsStub = stub
+}
+
+func (fake *FakeTeam) AuditEventsArgsForCall(i int) (concourse.AuditEventFilter, concourse.Page) {
+	fake.auditEventsMutex.RLock()
+	defer fake.auditEventsMutex.RUnlock()
+	argsForCall := fake.auditEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) AuditEventsReturns(result1 []atc.AuditEvent, result2 concourse.Pagination, result3 error) {
+	fake.auditEventsMutex.Lock()
+	defer fake.auditEventsMutex.Unlock()
+	fake.AuditEventsStub = nil
+	fake.auditEventsReturns = struct {
+		result1 []atc.AuditEvent
+		result2 concourse.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) AuditEventsReturnsOnCall(i int, result1 []atc.AuditEvent, result2 concourse.Pagination, result3 error) {
+	fake.auditEventsMutex.Lock()
+	defer fake.auditEventsMutex.Unlock()
+	fake.AuditEventsStub = nil
+	if fake.auditEventsReturnsOnCall == nil {
+		fake.auditEventsReturnsOnCall = make(map[int]struct {
+			result1 []atc.AuditEvent
+			result2 concourse.Pagination
+			result3 error
+		})
+	}
+	fake.auditEventsReturnsOnCall[i] = struct {
+		result1 []atc.AuditEvent
+		result2 concourse.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) BuildInputsForJob(arg1 string, arg2 string) ([]atc.BuildInput, bool, error) {
 	fake.buildInputsForJobMutex.Lock()
 	ret, specificReturn := fake.buildInputsForJobReturnsOnCall[len(fake.buildInputsForJobArgsForCall)]
@@ -1120,6 +1398,69 @@ func (fake *FakeTeam) ClearTaskCacheReturnsOnCall(i int, result1 int64, result2
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) CreateAPIToken(arg1 atc.APITokenRequest) (atc.APIToken, error) {
+	fake.createAPITokenMutex.Lock()
+	ret, specificReturn := fake.createAPITokenReturnsOnCall[len(fake.createAPITokenArgsForCall)]
+	fake.createAPITokenArgsForCall = append(fake.createAPITokenArgsForCall, struct {
+		arg1 atc.APITokenRequest
+	}{arg1})
+	fake.recordInvocation("CreateAPIToken", []interface{}{arg1})
+	fake.createAPITokenMutex.Unlock()
+	if fake.CreateAPITokenStub != nil {
+		return fake.CreateAPITokenStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createAPITokenReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) CreateAPITokenCallCount() int {
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
+	return len(fake.createAPITokenArgsForCall)
+}
+
+func (fake *FakeTeam) CreateAPITokenCalls(stub func(atc.APITokenRequest) (atc.APIToken, error)) {
+	fake.createAPITokenMutex.Lock()
+	defer fake.createAPITokenMutex.Unlock()
+	fake.CreateAPITokenStub = stub
+}
+
+func (fake *FakeTeam) CreateAPITokenArgsForCall(i int) atc.APITokenRequest {
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
+	argsForCall := fake.createAPITokenArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) CreateAPITokenReturns(result1 atc.APIToken, result2 error) {
+	fake.createAPITokenMutex.Lock()
+	defer fake.createAPITokenMutex.Unlock()
+	fake.CreateAPITokenStub = nil
+	fake.createAPITokenReturns = struct {
+		result1 atc.APIToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) CreateAPITokenReturnsOnCall(i int, result1 atc.APIToken, result2 error) {
+	fake.createAPITokenMutex.Lock()
+	defer fake.createAPITokenMutex.Unlock()
+	fake.CreateAPITokenStub = nil
+	if fake.createAPITokenReturnsOnCall == nil {
+		fake.createAPITokenReturnsOnCall = make(map[int]struct {
+			result1 atc.APIToken
+			result2 error
+		})
+	}
+	fake.createAPITokenReturnsOnCall[i] = struct {
+		result1 atc.APIToken
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) CreateArtifact(arg1 io.Reader) (atc.WorkerArtifact, error) {
 	fake.createArtifactMutex.Lock()
 	ret, specificReturn := fake.createArtifactReturnsOnCall[len(fake.createArtifactArgsForCall)]
@@ -1456,6 +1797,78 @@ func (fake *FakeTeam) CreateOrUpdatePipelineConfigReturnsOnCall(i int, result1 b
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeTeam) CreateOrUpdateWithWarnings(arg1 atc.Team) (atc.Team, bool, bool, []concourse.ConfigWarning, error) {
+	fake.createOrUpdateWithWarningsMutex.Lock()
+	ret, specificReturn := fake.createOrUpdateWithWarningsReturnsOnCall[len(fake.createOrUpdateWithWarningsArgsForCall)]
+	fake.createOrUpdateWithWarningsArgsForCall = append(fake.createOrUpdateWithWarningsArgsForCall, struct {
+		arg1 atc.Team
+	}{arg1})
+	fake.recordInvocation("CreateOrUpdateWithWarnings", []interface{}{arg1})
+	fake.createOrUpdateWithWarningsMutex.Unlock()
+	if fake.CreateOrUpdateWithWarningsStub != nil {
+		return fake.CreateOrUpdateWithWarningsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3, ret.result4, ret.result5
+	}
+	fakeReturns := fake.createOrUpdateWithWarningsReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3, fakeReturns.result4, fakeReturns.result5
+}
+
+func (fake *FakeTeam) CreateOrUpdateWithWarningsCallCount() int {
+	fake.createOrUpdateWithWarningsMutex.RLock()
+	defer fake.createOrUpdateWithWarningsMutex.RUnlock()
+	return len(fake.createOrUpdateWithWarningsArgsForCall)
+}
+
+func (fake *FakeTeam) CreateOrUpdateWithWarningsCalls(stub func(atc.Team) (atc.Team, bool, bool, []concourse.ConfigWarning, error)) {
+	fake.createOrUpdateWithWarningsMutex.Lock()
+	defer fake.createOrUpdateWithWarningsMutex.Unlock()
+	fake.CreateOrUpdateWithWarningsStub = stub
+}
+
+func (fake *FakeTeam) CreateOrUpdateWithWarningsArgsForCall(i int) atc.Team {
+	fake.createOrUpdateWithWarningsMutex.RLock()
+	defer fake.createOrUpdateWithWarningsMutex.RUnlock()
+	argsForCall := fake.createOrUpdateWithWarningsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) CreateOrUpdateWithWarningsReturns(result1 atc.Team, result2 bool, result3 bool, result4 []concourse.ConfigWarning, result5 error) {
+	fake.createOrUpdateWithWarningsMutex.Lock()
+	defer fake.createOrUpdateWithWarningsMutex.Unlock()
+	fake.CreateOrUpdateWithWarningsStub = nil
+	fake.createOrUpdateWithWarningsReturns = struct {
+		result1 atc.Team
+		result2 bool
+		result3 bool
+		result4 []concourse.ConfigWarning
+		result5 error
+	}{result1, result2, result3, result4, result5}
+}
+
+func (fake *FakeTeam) CreateOrUpdateWithWarningsReturnsOnCall(i int, result1 atc.Team, result2 bool, result3 bool, result4 []concourse.ConfigWarning, result5 error) {
+	fake.createOrUpdateWithWarningsMutex.Lock()
+	defer fake.createOrUpdateWithWarningsMutex.Unlock()
+	fake.CreateOrUpdateWithWarningsStub = nil
+	if fake.createOrUpdateWithWarningsReturnsOnCall == nil {
+		fake.createOrUpdateWithWarningsReturnsOnCall = make(map[int]struct {
+			result1 atc.Team
+			result2 bool
+			result3 bool
+			result4 []concourse.ConfigWarning
+			result5 error
+		})
+	}
+	fake.createOrUpdateWithWarningsReturnsOnCall[i] = struct {
+		result1 atc.Team
+		result2 bool
+		result3 bool
+		result4 []concourse.ConfigWarning
+		result5 error
+	}{result1, result2, result3, result4, result5}
+}
+
 func (fake *FakeTeam) CreatePipelineBuild(arg1 string, arg2 atc.Plan) (atc.Build, error) {
 	fake.createPipelineBuildMutex.Lock()
 	ret, specificReturn := fake.createPipelineBuildReturnsOnCall[len(fake.createPipelineBuildArgsForCall)]
@@ -1520,6 +1933,69 @@ func (fake *FakeTeam) CreatePipelineBuildReturnsOnCall(i int, result1 atc.Build,
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) CreateWorkerKey(arg1 atc.WorkerKeyRequest) (atc.WorkerKey, error) {
+	fake.createWorkerKeyMutex.Lock()
+	ret, specificReturn := fake.createWorkerKeyReturnsOnCall[len(fake.createWorkerKeyArgsForCall)]
+	fake.createWorkerKeyArgsForCall = append(fake.createWorkerKeyArgsForCall, struct {
+		arg1 atc.WorkerKeyRequest
+	}{arg1})
+	fake.recordInvocation("CreateWorkerKey", []interface{}{arg1})
+	fake.createWorkerKeyMutex.Unlock()
+	if fake.CreateWorkerKeyStub != nil {
+		return fake.CreateWorkerKeyStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createWorkerKeyReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) CreateWorkerKeyCallCount() int {
+	fake.createWorkerKeyMutex.RLock()
+	defer fake.createWorkerKeyMutex.RUnlock()
+	return len(fake.createWorkerKeyArgsForCall)
+}
+
+func (fake *FakeTeam) CreateWorkerKeyCalls(stub func(atc.WorkerKeyRequest) (atc.WorkerKey, error)) {
+	fake.createWorkerKeyMutex.Lock()
+	defer fake.createWorkerKeyMutex.Unlock()
+	fake.CreateWorkerKeyStub = stub
+}
+
+func (fake *FakeTeam) CreateWorkerKeyArgsForCall(i int) atc.WorkerKeyRequest {
+	fake.createWorkerKeyMutex.RLock()
+	defer fake.createWorkerKeyMutex.RUnlock()
+	argsForCall := fake.createWorkerKeyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) CreateWorkerKeyReturns(result1 atc.WorkerKey, result2 error) {
+	fake.createWorkerKeyMutex.Lock()
+	defer fake.createWorkerKeyMutex.Unlock()
+	fake.CreateWorkerKeyStub = nil
+	fake.createWorkerKeyReturns = struct {
+		result1 atc.WorkerKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) CreateWorkerKeyReturnsOnCall(i int, result1 atc.WorkerKey, result2 error) {
+	fake.createWorkerKeyMutex.Lock()
+	defer fake.createWorkerKeyMutex.Unlock()
+	fake.CreateWorkerKeyStub = nil
+	if fake.createWorkerKeyReturnsOnCall == nil {
+		fake.createWorkerKeyReturnsOnCall = make(map[int]struct {
+			result1 atc.WorkerKey
+			result2 error
+		})
+	}
+	fake.createWorkerKeyReturnsOnCall[i] = struct {
+		result1 atc.WorkerKey
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) DeletePipeline(arg1 string) (bool, error) {
 	fake.deletePipelineMutex.Lock()
 	ret, specificReturn := fake.deletePipelineReturnsOnCall[len(fake.deletePipelineArgsForCall)]
@@ -1583,6 +2059,69 @@ func (fake *FakeTeam) DeletePipelineReturnsOnCall(i int, result1 bool, result2 e
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) DeleteWorkerKey(arg1 string) (bool, error) {
+	fake.deleteWorkerKeyMutex.Lock()
+	ret, specificReturn := fake.deleteWorkerKeyReturnsOnCall[len(fake.deleteWorkerKeyArgsForCall)]
+	fake.deleteWorkerKeyArgsForCall = append(fake.deleteWorkerKeyArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("DeleteWorkerKey", []interface{}{arg1})
+	fake.deleteWorkerKeyMutex.Unlock()
+	if fake.DeleteWorkerKeyStub != nil {
+		return fake.DeleteWorkerKeyStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.deleteWorkerKeyReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) DeleteWorkerKeyCallCount() int {
+	fake.deleteWorkerKeyMutex.RLock()
+	defer fake.deleteWorkerKeyMutex.RUnlock()
+	return len(fake.deleteWorkerKeyArgsForCall)
+}
+
+func (fake *FakeTeam) DeleteWorkerKeyCalls(stub func(string) (bool, error)) {
+	fake.deleteWorkerKeyMutex.Lock()
+	defer fake.deleteWorkerKeyMutex.Unlock()
+	fake.DeleteWorkerKeyStub = stub
+}
+
+func (fake *FakeTeam) DeleteWorkerKeyArgsForCall(i int) string {
+	fake.deleteWorkerKeyMutex.RLock()
+	defer fake.deleteWorkerKeyMutex.RUnlock()
+	argsForCall := fake.deleteWorkerKeyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) DeleteWorkerKeyReturns(result1 bool, result2 error) {
+	fake.deleteWorkerKeyMutex.Lock()
+	defer fake.deleteWorkerKeyMutex.Unlock()
+	fake.DeleteWorkerKeyStub = nil
+	fake.deleteWorkerKeyReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) DeleteWorkerKeyReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.deleteWorkerKeyMutex.Lock()
+	defer fake.deleteWorkerKeyMutex.Unlock()
+	fake.DeleteWorkerKeyStub = nil
+	if fake.deleteWorkerKeyReturnsOnCall == nil {
+		fake.deleteWorkerKeyReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.deleteWorkerKeyReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) DestroyTeam(arg1 string) error {
 	fake.destroyTeamMutex.Lock()
 	ret, specificReturn := fake.destroyTeamReturnsOnCall[len(fake.destroyTeamArgsForCall)]
@@ -1643,17 +2182,85 @@ func (fake *FakeTeam) DestroyTeamReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeTeam) DisableResourceVersion(arg1 string, arg2 string, arg3 int) (bool, error) {
-	fake.disableResourceVersionMutex.Lock()
-	ret, specificReturn := fake.disableResourceVersionReturnsOnCall[len(fake.disableResourceVersionArgsForCall)]
-	fake.disableResourceVersionArgsForCall = append(fake.disableResourceVersionArgsForCall, struct {
+func (fake *FakeTeam) DiffPipelineConfigVersions(arg1 string, arg2 int, arg3 int) (atc.ConfigDiff, bool, error) {
+	fake.diffPipelineConfigVersionsMutex.Lock()
+	ret, specificReturn := fake.diffPipelineConfigVersionsReturnsOnCall[len(fake.diffPipelineConfigVersionsArgsForCall)]
+	fake.diffPipelineConfigVersionsArgsForCall = append(fake.diffPipelineConfigVersionsArgsForCall, struct {
 		arg1 string
-		arg2 string
+		arg2 int
 		arg3 int
 	}{arg1, arg2, arg3})
-	fake.recordInvocation("DisableResourceVersion", []interface{}{arg1, arg2, arg3})
-	fake.disableResourceVersionMutex.Unlock()
-	if fake.DisableResourceVersionStub != nil {
+	fake.recordInvocation("DiffPipelineConfigVersions", []interface{}{arg1, arg2, arg3})
+	fake.diffPipelineConfigVersionsMutex.Unlock()
+	if fake.DiffPipelineConfigVersionsStub != nil {
+		return fake.DiffPipelineConfigVersionsStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.diffPipelineConfigVersionsReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) DiffPipelineConfigVersionsCallCount() int {
+	fake.diffPipelineConfigVersionsMutex.RLock()
+	defer fake.diffPipelineConfigVersionsMutex.RUnlock()
+	return len(fake.diffPipelineConfigVersionsArgsForCall)
+}
+
+func (fake *FakeTeam) DiffPipelineConfigVersionsCalls(stub func(string, int, int) (atc.ConfigDiff, bool, error)) {
+	fake.diffPipelineConfigVersionsMutex.Lock()
+	defer fake.diffPipelineConfigVersionsMutex.Unlock()
+	fake.DiffPipelineConfigVersionsStub = stub
+}
+
+func (fake *FakeTeam) DiffPipelineConfigVersionsArgsForCall(i int) (string, int, int) {
+	fake.diffPipelineConfigVersionsMutex.RLock()
+	defer fake.diffPipelineConfigVersionsMutex.RUnlock()
+	argsForCall := fake.diffPipelineConfigVersionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) DiffPipelineConfigVersionsReturns(result1 atc.ConfigDiff, result2 bool, result3 error) {
+	fake.diffPipelineConfigVersionsMutex.Lock()
+	defer fake.diffPipelineConfigVersionsMutex.Unlock()
+	fake.DiffPipelineConfigVersionsStub = nil
+	fake.diffPipelineConfigVersionsReturns = struct {
+		result1 atc.ConfigDiff
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) DiffPipelineConfigVersionsReturnsOnCall(i int, result1 atc.ConfigDiff, result2 bool, result3 error) {
+	fake.diffPipelineConfigVersionsMutex.Lock()
+	defer fake.diffPipelineConfigVersionsMutex.Unlock()
+	fake.DiffPipelineConfigVersionsStub = nil
+	if fake.diffPipelineConfigVersionsReturnsOnCall == nil {
+		fake.diffPipelineConfigVersionsReturnsOnCall = make(map[int]struct {
+			result1 atc.ConfigDiff
+			result2 bool
+			result3 error
+		})
+	}
+	fake.diffPipelineConfigVersionsReturnsOnCall[i] = struct {
+		result1 atc.ConfigDiff
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) DisableResourceVersion(arg1 string, arg2 string, arg3 int) (bool, error) {
+	fake.disableResourceVersionMutex.Lock()
+	ret, specificReturn := fake.disableResourceVersionReturnsOnCall[len(fake.disableResourceVersionArgsForCall)]
+	fake.disableResourceVersionArgsForCall = append(fake.disableResourceVersionArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("DisableResourceVersion", []interface{}{arg1, arg2, arg3})
+	fake.disableResourceVersionMutex.Unlock()
+	if fake.DisableResourceVersionStub != nil {
 		return fake.DisableResourceVersionStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
@@ -2025,6 +2632,127 @@ func (fake *FakeTeam) HidePipelineReturnsOnCall(i int, result1 bool, result2 err
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) HijackSessionRecording(arg1 int) (io.ReadCloser, bool, error) {
+	fake.hijackSessionRecordingMutex.Lock()
+	ret, specificReturn := fake.hijackSessionRecordingReturnsOnCall[len(fake.hijackSessionRecordingArgsForCall)]
+	fake.hijackSessionRecordingArgsForCall = append(fake.hijackSessionRecordingArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("HijackSessionRecording", []interface{}{arg1})
+	fake.hijackSessionRecordingMutex.Unlock()
+	if fake.HijackSessionRecordingStub != nil {
+		return fake.HijackSessionRecordingStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.hijackSessionRecordingReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) HijackSessionRecordingCallCount() int {
+	fake.hijackSessionRecordingMutex.RLock()
+	defer fake.hijackSessionRecordingMutex.RUnlock()
+	return len(fake.hijackSessionRecordingArgsForCall)
+}
+
+func (fake *FakeTeam) HijackSessionRecordingCalls(stub func(int) (io.ReadCloser, bool, error)) {
+	fake.hijackSessionRecordingMutex.Lock()
+	defer fake.hijackSessionRecordingMutex.Unlock()
+	fake.HijackSessionRecordingStub = stub
+}
+
+func (fake *FakeTeam) HijackSessionRecordingArgsForCall(i int) int {
+	fake.hijackSessionRecordingMutex.RLock()
+	defer fake.hijackSessionRecordingMutex.RUnlock()
+	argsForCall := fake.hijackSessionRecordingArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) HijackSessionRecordingReturns(result1 io.ReadCloser, result2 bool, result3 error) {
+	fake.hijackSessionRecordingMutex.Lock()
+	defer fake.hijackSessionRecordingMutex.Unlock()
+	fake.HijackSessionRecordingStub = nil
+	fake.hijackSessionRecordingReturns = struct {
+		result1 io.ReadCloser
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) HijackSessionRecordingReturnsOnCall(i int, result1 io.ReadCloser, result2 bool, result3 error) {
+	fake.hijackSessionRecordingMutex.Lock()
+	defer fake.hijackSessionRecordingMutex.Unlock()
+	fake.HijackSessionRecordingStub = nil
+	if fake.hijackSessionRecordingReturnsOnCall == nil {
+		fake.hijackSessionRecordingReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 bool
+			result3 error
+		})
+	}
+	fake.hijackSessionRecordingReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) HijackSessions() ([]atc.HijackSession, error) {
+	fake.hijackSessionsMutex.Lock()
+	ret, specificReturn := fake.hijackSessionsReturnsOnCall[len(fake.hijackSessionsArgsForCall)]
+	fake.hijackSessionsArgsForCall = append(fake.hijackSessionsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("HijackSessions", []interface{}{})
+	fake.hijackSessionsMutex.Unlock()
+	if fake.HijackSessionsStub != nil {
+		return fake.HijackSessionsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.hijackSessionsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) HijackSessionsCallCount() int {
+	fake.hijackSessionsMutex.RLock()
+	defer fake.hijackSessionsMutex.RUnlock()
+	return len(fake.hijackSessionsArgsForCall)
+}
+
+func (fake *FakeTeam) HijackSessionsCalls(stub func() ([]atc.HijackSession, error)) {
+	fake.hijackSessionsMutex.Lock()
+	defer fake.hijackSessionsMutex.Unlock()
+	fake.HijackSessionsStub = stub
+}
+
+func (fake *FakeTeam) HijackSessionsReturns(result1 []atc.HijackSession, result2 error) {
+	fake.hijackSessionsMutex.Lock()
+	defer fake.hijackSessionsMutex.Unlock()
+	fake.HijackSessionsStub = nil
+	fake.hijackSessionsReturns = struct {
+		result1 []atc.HijackSession
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) HijackSessionsReturnsOnCall(i int, result1 []atc.HijackSession, result2 error) {
+	fake.hijackSessionsMutex.Lock()
+	defer fake.hijackSessionsMutex.Unlock()
+	fake.HijackSessionsStub = nil
+	if fake.hijackSessionsReturnsOnCall == nil {
+		fake.hijackSessionsReturnsOnCall = make(map[int]struct {
+			result1 []atc.HijackSession
+			result2 error
+		})
+	}
+	fake.hijackSessionsReturnsOnCall[i] = struct {
+		result1 []atc.HijackSession
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) Job(arg1 string, arg2 string) (atc.Job, bool, error) {
 	fake.jobMutex.Lock()
 	ret, specificReturn := fake.jobReturnsOnCall[len(fake.jobArgsForCall)]
@@ -2231,6 +2959,61 @@ func (fake *FakeTeam) JobBuildsReturnsOnCall(i int, result1 []atc.Build, result2
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeTeam) ListAPITokens() ([]atc.APIToken, error) {
+	fake.listAPITokensMutex.Lock()
+	ret, specificReturn := fake.listAPITokensReturnsOnCall[len(fake.listAPITokensArgsForCall)]
+	fake.listAPITokensArgsForCall = append(fake.listAPITokensArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ListAPITokens", []interface{}{})
+	fake.listAPITokensMutex.Unlock()
+	if fake.ListAPITokensStub != nil {
+		return fake.ListAPITokensStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listAPITokensReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) ListAPITokensCallCount() int {
+	fake.listAPITokensMutex.RLock()
+	defer fake.listAPITokensMutex.RUnlock()
+	return len(fake.listAPITokensArgsForCall)
+}
+
+func (fake *FakeTeam) ListAPITokensCalls(stub func() ([]atc.APIToken, error)) {
+	fake.listAPITokensMutex.Lock()
+	defer fake.listAPITokensMutex.Unlock()
+	fake.ListAPITokensStub = stub
+}
+
+func (fake *FakeTeam) ListAPITokensReturns(result1 []atc.APIToken, result2 error) {
+	fake.listAPITokensMutex.Lock()
+	defer fake.listAPITokensMutex.Unlock()
+	fake.ListAPITokensStub = nil
+	fake.listAPITokensReturns = struct {
+		result1 []atc.APIToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ListAPITokensReturnsOnCall(i int, result1 []atc.APIToken, result2 error) {
+	fake.listAPITokensMutex.Lock()
+	defer fake.listAPITokensMutex.Unlock()
+	fake.ListAPITokensStub = nil
+	if fake.listAPITokensReturnsOnCall == nil {
+		fake.listAPITokensReturnsOnCall = make(map[int]struct {
+			result1 []atc.APIToken
+			result2 error
+		})
+	}
+	fake.listAPITokensReturnsOnCall[i] = struct {
+		result1 []atc.APIToken
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) ListContainers(arg1 map[string]string) ([]atc.Container, error) {
 	fake.listContainersMutex.Lock()
 	ret, specificReturn := fake.listContainersReturnsOnCall[len(fake.listContainersArgsForCall)]
@@ -2530,6 +3313,61 @@ func (fake *FakeTeam) ListVolumesReturnsOnCall(i int, result1 []atc.Volume, resu
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) ListWorkerKeys() ([]atc.WorkerKey, error) {
+	fake.listWorkerKeysMutex.Lock()
+	ret, specificReturn := fake.listWorkerKeysReturnsOnCall[len(fake.listWorkerKeysArgsForCall)]
+	fake.listWorkerKeysArgsForCall = append(fake.listWorkerKeysArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ListWorkerKeys", []interface{}{})
+	fake.listWorkerKeysMutex.Unlock()
+	if fake.ListWorkerKeysStub != nil {
+		return fake.ListWorkerKeysStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listWorkerKeysReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) ListWorkerKeysCallCount() int {
+	fake.listWorkerKeysMutex.RLock()
+	defer fake.listWorkerKeysMutex.RUnlock()
+	return len(fake.listWorkerKeysArgsForCall)
+}
+
+func (fake *FakeTeam) ListWorkerKeysCalls(stub func() ([]atc.WorkerKey, error)) {
+	fake.listWorkerKeysMutex.Lock()
+	defer fake.listWorkerKeysMutex.Unlock()
+	fake.ListWorkerKeysStub = stub
+}
+
+func (fake *FakeTeam) ListWorkerKeysReturns(result1 []atc.WorkerKey, result2 error) {
+	fake.listWorkerKeysMutex.Lock()
+	defer fake.listWorkerKeysMutex.Unlock()
+	fake.ListWorkerKeysStub = nil
+	fake.listWorkerKeysReturns = struct {
+		result1 []atc.WorkerKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ListWorkerKeysReturnsOnCall(i int, result1 []atc.WorkerKey, result2 error) {
+	fake.listWorkerKeysMutex.Lock()
+	defer fake.listWorkerKeysMutex.Unlock()
+	fake.ListWorkerKeysStub = nil
+	if fake.listWorkerKeysReturnsOnCall == nil {
+		fake.listWorkerKeysReturnsOnCall = make(map[int]struct {
+			result1 []atc.WorkerKey
+			result2 error
+		})
+	}
+	fake.listWorkerKeysReturnsOnCall[i] = struct {
+		result1 []atc.WorkerKey
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) Name() string {
 	fake.nameMutex.Lock()
 	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
@@ -2582,6 +3420,61 @@ func (fake *FakeTeam) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeTeam) NamedLocks() ([]atc.NamedLock, error) {
+	fake.namedLocksMutex.Lock()
+	ret, specificReturn := fake.namedLocksReturnsOnCall[len(fake.namedLocksArgsForCall)]
+	fake.namedLocksArgsForCall = append(fake.namedLocksArgsForCall, struct {
+	}{})
+	fake.recordInvocation("NamedLocks", []interface{}{})
+	fake.namedLocksMutex.Unlock()
+	if fake.NamedLocksStub != nil {
+		return fake.NamedLocksStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.namedLocksReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) NamedLocksCallCount() int {
+	fake.namedLocksMutex.RLock()
+	defer fake.namedLocksMutex.RUnlock()
+	return len(fake.namedLocksArgsForCall)
+}
+
+func (fake *FakeTeam) NamedLocksCalls(stub func() ([]atc.NamedLock, error)) {
+	fake.namedLocksMutex.Lock()
+	defer fake.namedLocksMutex.Unlock()
+	fake.NamedLocksStub = stub
+}
+
+func (fake *FakeTeam) NamedLocksReturns(result1 []atc.NamedLock, result2 error) {
+	fake.namedLocksMutex.Lock()
+	defer fake.namedLocksMutex.Unlock()
+	fake.NamedLocksStub = nil
+	fake.namedLocksReturns = struct {
+		result1 []atc.NamedLock
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) NamedLocksReturnsOnCall(i int, result1 []atc.NamedLock, result2 error) {
+	fake.namedLocksMutex.Lock()
+	defer fake.namedLocksMutex.Unlock()
+	fake.NamedLocksStub = nil
+	if fake.namedLocksReturnsOnCall == nil {
+		fake.namedLocksReturnsOnCall = make(map[int]struct {
+			result1 []atc.NamedLock
+			result2 error
+		})
+	}
+	fake.namedLocksReturnsOnCall[i] = struct {
+		result1 []atc.NamedLock
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) OrderingPipelines(arg1 []string) error {
 	var arg1Copy []string
 	if arg1 != nil {
@@ -2979,6 +3872,202 @@ func (fake *FakeTeam) PipelineConfigReturnsOnCall(i int, result1 atc.Config, res
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeTeam) PipelineConfigVersion(arg1 string, arg2 int) (atc.ConfigVersion, bool, error) {
+	fake.pipelineConfigVersionMutex.Lock()
+	ret, specificReturn := fake.pipelineConfigVersionReturnsOnCall[len(fake.pipelineConfigVersionArgsForCall)]
+	fake.pipelineConfigVersionArgsForCall = append(fake.pipelineConfigVersionArgsForCall, struct {
+		arg1 string
+		arg2 int
+	}{arg1, arg2})
+	fake.recordInvocation("PipelineConfigVersion", []interface{}{arg1, arg2})
+	fake.pipelineConfigVersionMutex.Unlock()
+	if fake.PipelineConfigVersionStub != nil {
+		return fake.PipelineConfigVersionStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.pipelineConfigVersionReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) PipelineConfigVersionCallCount() int {
+	fake.pipelineConfigVersionMutex.RLock()
+	defer fake.pipelineConfigVersionMutex.RUnlock()
+	return len(fake.pipelineConfigVersionArgsForCall)
+}
+
+func (fake *FakeTeam) PipelineConfigVersionCalls(stub func(string, int) (atc.ConfigVersion, bool, error)) {
+	fake.pipelineConfigVersionMutex.Lock()
+	defer fake.pipelineConfigVersionMutex.Unlock()
+	fake.PipelineConfigVersionStub = stub
+}
+
+func (fake *FakeTeam) PipelineConfigVersionArgsForCall(i int) (string, int) {
+	fake.pipelineConfigVersionMutex.RLock()
+	defer fake.pipelineConfigVersionMutex.RUnlock()
+	argsForCall := fake.pipelineConfigVersionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) PipelineConfigVersionReturns(result1 atc.ConfigVersion, result2 bool, result3 error) {
+	fake.pipelineConfigVersionMutex.Lock()
+	defer fake.pipelineConfigVersionMutex.Unlock()
+	fake.PipelineConfigVersionStub = nil
+	fake.pipelineConfigVersionReturns = struct {
+		result1 atc.ConfigVersion
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) PipelineConfigVersionReturnsOnCall(i int, result1 atc.ConfigVersion, result2 bool, result3 error) {
+	fake.pipelineConfigVersionMutex.Lock()
+	defer fake.pipelineConfigVersionMutex.Unlock()
+	fake.PipelineConfigVersionStub = nil
+	if fake.pipelineConfigVersionReturnsOnCall == nil {
+		fake.pipelineConfigVersionReturnsOnCall = make(map[int]struct {
+			result1 atc.ConfigVersion
+			result2 bool
+			result3 error
+		})
+	}
+	fake.pipelineConfigVersionReturnsOnCall[i] = struct {
+		result1 atc.ConfigVersion
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) PipelineConfigVersions(arg1 string) ([]atc.ConfigVersion, bool, error) {
+	fake.pipelineConfigVersionsMutex.Lock()
+	ret, specificReturn := fake.pipelineConfigVersionsReturnsOnCall[len(fake.pipelineConfigVersionsArgsForCall)]
+	fake.pipelineConfigVersionsArgsForCall = append(fake.pipelineConfigVersionsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("PipelineConfigVersions", []interface{}{arg1})
+	fake.pipelineConfigVersionsMutex.Unlock()
+	if fake.PipelineConfigVersionsStub != nil {
+		return fake.PipelineConfigVersionsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.pipelineConfigVersionsReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) PipelineConfigVersionsCallCount() int {
+	fake.pipelineConfigVersionsMutex.RLock()
+	defer fake.pipelineConfigVersionsMutex.RUnlock()
+	return len(fake.pipelineConfigVersionsArgsForCall)
+}
+
+func (fake *FakeTeam) PipelineConfigVersionsCalls(stub func(string) ([]atc.ConfigVersion, bool, error)) {
+	fake.pipelineConfigVersionsMutex.Lock()
+	defer fake.pipelineConfigVersionsMutex.Unlock()
+	fake.PipelineConfigVersionsStub = stub
+}
+
+func (fake *FakeTeam) PipelineConfigVersionsArgsForCall(i int) string {
+	fake.pipelineConfigVersionsMutex.RLock()
+	defer fake.pipelineConfigVersionsMutex.RUnlock()
+	argsForCall := fake.pipelineConfigVersionsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) PipelineConfigVersionsReturns(result1 []atc.ConfigVersion, result2 bool, result3 error) {
+	fake.pipelineConfigVersionsMutex.Lock()
+	defer fake.pipelineConfigVersionsMutex.Unlock()
+	fake.PipelineConfigVersionsStub = nil
+	fake.pipelineConfigVersionsReturns = struct {
+		result1 []atc.ConfigVersion
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) PipelineConfigVersionsReturnsOnCall(i int, result1 []atc.ConfigVersion, result2 bool, result3 error) {
+	fake.pipelineConfigVersionsMutex.Lock()
+	defer fake.pipelineConfigVersionsMutex.Unlock()
+	fake.PipelineConfigVersionsStub = nil
+	if fake.pipelineConfigVersionsReturnsOnCall == nil {
+		fake.pipelineConfigVersionsReturnsOnCall = make(map[int]struct {
+			result1 []atc.ConfigVersion
+			result2 bool
+			result3 error
+		})
+	}
+	fake.pipelineConfigVersionsReturnsOnCall[i] = struct {
+		result1 []atc.ConfigVersion
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) ReleaseNamedLock(arg1 string) (bool, error) {
+	fake.releaseNamedLockMutex.Lock()
+	ret, specificReturn := fake.releaseNamedLockReturnsOnCall[len(fake.releaseNamedLockArgsForCall)]
+	fake.releaseNamedLockArgsForCall = append(fake.releaseNamedLockArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("ReleaseNamedLock", []interface{}{arg1})
+	fake.releaseNamedLockMutex.Unlock()
+	if fake.ReleaseNamedLockStub != nil {
+		return fake.ReleaseNamedLockStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.releaseNamedLockReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) ReleaseNamedLockCallCount() int {
+	fake.releaseNamedLockMutex.RLock()
+	defer fake.releaseNamedLockMutex.RUnlock()
+	return len(fake.releaseNamedLockArgsForCall)
+}
+
+func (fake *FakeTeam) ReleaseNamedLockCalls(stub func(string) (bool, error)) {
+	fake.releaseNamedLockMutex.Lock()
+	defer fake.releaseNamedLockMutex.Unlock()
+	fake.ReleaseNamedLockStub = stub
+}
+
+func (fake *FakeTeam) ReleaseNamedLockArgsForCall(i int) string {
+	fake.releaseNamedLockMutex.RLock()
+	defer fake.releaseNamedLockMutex.RUnlock()
+	argsForCall := fake.releaseNamedLockArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) ReleaseNamedLockReturns(result1 bool, result2 error) {
+	fake.releaseNamedLockMutex.Lock()
+	defer fake.releaseNamedLockMutex.Unlock()
+	fake.ReleaseNamedLockStub = nil
+	fake.releaseNamedLockReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ReleaseNamedLockReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.releaseNamedLockMutex.Lock()
+	defer fake.releaseNamedLockMutex.Unlock()
+	fake.ReleaseNamedLockStub = nil
+	if fake.releaseNamedLockReturnsOnCall == nil {
+		fake.releaseNamedLockReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.releaseNamedLockReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) RenamePipeline(arg1 string, arg2 string) (bool, error) {
 	fake.renamePipelineMutex.Lock()
 	ret, specificReturn := fake.renamePipelineReturnsOnCall[len(fake.renamePipelineArgsForCall)]
@@ -3245,6 +4334,69 @@ func (fake *FakeTeam) ResourceVersionsReturnsOnCall(i int, result1 []atc.Resourc
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeTeam) RevokeAPIToken(arg1 string) (bool, error) {
+	fake.revokeAPITokenMutex.Lock()
+	ret, specificReturn := fake.revokeAPITokenReturnsOnCall[len(fake.revokeAPITokenArgsForCall)]
+	fake.revokeAPITokenArgsForCall = append(fake.revokeAPITokenArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("RevokeAPIToken", []interface{}{arg1})
+	fake.revokeAPITokenMutex.Unlock()
+	if fake.RevokeAPITokenStub != nil {
+		return fake.RevokeAPITokenStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.revokeAPITokenReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) RevokeAPITokenCallCount() int {
+	fake.revokeAPITokenMutex.RLock()
+	defer fake.revokeAPITokenMutex.RUnlock()
+	return len(fake.revokeAPITokenArgsForCall)
+}
+
+func (fake *FakeTeam) RevokeAPITokenCalls(stub func(string) (bool, error)) {
+	fake.revokeAPITokenMutex.Lock()
+	defer fake.revokeAPITokenMutex.Unlock()
+	fake.RevokeAPITokenStub = stub
+}
+
+func (fake *FakeTeam) RevokeAPITokenArgsForCall(i int) string {
+	fake.revokeAPITokenMutex.RLock()
+	defer fake.revokeAPITokenMutex.RUnlock()
+	argsForCall := fake.revokeAPITokenArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) RevokeAPITokenReturns(result1 bool, result2 error) {
+	fake.revokeAPITokenMutex.Lock()
+	defer fake.revokeAPITokenMutex.Unlock()
+	fake.RevokeAPITokenStub = nil
+	fake.revokeAPITokenReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) RevokeAPITokenReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.revokeAPITokenMutex.Lock()
+	defer fake.revokeAPITokenMutex.Unlock()
+	fake.RevokeAPITokenStub = nil
+	if fake.revokeAPITokenReturnsOnCall == nil {
+		fake.revokeAPITokenReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.revokeAPITokenReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) Team(arg1 string) (atc.Team, bool, error) {
 	fake.teamMutex.Lock()
 	ret, specificReturn := fake.teamReturnsOnCall[len(fake.teamArgsForCall)]
@@ -3507,6 +4659,8 @@ func (fake *FakeTeam) VersionedResourceTypesReturnsOnCall(i int, result1 atc.Ver
 func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.auditEventsMutex.RLock()
+	defer fake.auditEventsMutex.RUnlock()
 	fake.buildInputsForJobMutex.RLock()
 	defer fake.buildInputsForJobMutex.RUnlock()
 	fake.buildsMutex.RLock()
@@ -3521,6 +4675,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.checkResourceTypeMutex.RUnlock()
 	fake.clearTaskCacheMutex.RLock()
 	defer fake.clearTaskCacheMutex.RUnlock()
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
 	fake.createArtifactMutex.RLock()
 	defer fake.createArtifactMutex.RUnlock()
 	fake.createBuildMutex.RLock()
@@ -3531,12 +4687,20 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.createOrUpdateMutex.RUnlock()
 	fake.createOrUpdatePipelineConfigMutex.RLock()
 	defer fake.createOrUpdatePipelineConfigMutex.RUnlock()
+	fake.createOrUpdateWithWarningsMutex.RLock()
+	defer fake.createOrUpdateWithWarningsMutex.RUnlock()
 	fake.createPipelineBuildMutex.RLock()
 	defer fake.createPipelineBuildMutex.RUnlock()
+	fake.createWorkerKeyMutex.RLock()
+	defer fake.createWorkerKeyMutex.RUnlock()
 	fake.deletePipelineMutex.RLock()
 	defer fake.deletePipelineMutex.RUnlock()
+	fake.deleteWorkerKeyMutex.RLock()
+	defer fake.deleteWorkerKeyMutex.RUnlock()
 	fake.destroyTeamMutex.RLock()
 	defer fake.destroyTeamMutex.RUnlock()
+	fake.diffPipelineConfigVersionsMutex.RLock()
+	defer fake.diffPipelineConfigVersionsMutex.RUnlock()
 	fake.disableResourceVersionMutex.RLock()
 	defer fake.disableResourceVersionMutex.RUnlock()
 	fake.enableResourceVersionMutex.RLock()
@@ -3549,12 +4713,18 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.getContainerMutex.RUnlock()
 	fake.hidePipelineMutex.RLock()
 	defer fake.hidePipelineMutex.RUnlock()
+	fake.hijackSessionRecordingMutex.RLock()
+	defer fake.hijackSessionRecordingMutex.RUnlock()
+	fake.hijackSessionsMutex.RLock()
+	defer fake.hijackSessionsMutex.RUnlock()
 	fake.jobMutex.RLock()
 	defer fake.jobMutex.RUnlock()
 	fake.jobBuildMutex.RLock()
 	defer fake.jobBuildMutex.RUnlock()
 	fake.jobBuildsMutex.RLock()
 	defer fake.jobBuildsMutex.RUnlock()
+	fake.listAPITokensMutex.RLock()
+	defer fake.listAPITokensMutex.RUnlock()
 	fake.listContainersMutex.RLock()
 	defer fake.listContainersMutex.RUnlock()
 	fake.listJobsMutex.RLock()
@@ -3565,8 +4735,12 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.listResourcesMutex.RUnlock()
 	fake.listVolumesMutex.RLock()
 	defer fake.listVolumesMutex.RUnlock()
+	fake.listWorkerKeysMutex.RLock()
+	defer fake.listWorkerKeysMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.namedLocksMutex.RLock()
+	defer fake.namedLocksMutex.RUnlock()
 	fake.orderingPipelinesMutex.RLock()
 	defer fake.orderingPipelinesMutex.RUnlock()
 	fake.pauseJobMutex.RLock()
@@ -3579,6 +4753,12 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.pipelineBuildsMutex.RUnlock()
 	fake.pipelineConfigMutex.RLock()
 	defer fake.pipelineConfigMutex.RUnlock()
+	fake.pipelineConfigVersionMutex.RLock()
+	defer fake.pipelineConfigVersionMutex.RUnlock()
+	fake.pipelineConfigVersionsMutex.RLock()
+	defer fake.pipelineConfigVersionsMutex.RUnlock()
+	fake.releaseNamedLockMutex.RLock()
+	defer fake.releaseNamedLockMutex.RUnlock()
 	fake.renamePipelineMutex.RLock()
 	defer fake.renamePipelineMutex.RUnlock()
 	fake.renameTeamMutex.RLock()
@@ -3587,6 +4767,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.resourceMutex.RUnlock()
 	fake.resourceVersionsMutex.RLock()
 	defer fake.resourceVersionsMutex.RUnlock()
+	fake.revokeAPITokenMutex.RLock()
+	defer fake.revokeAPITokenMutex.RUnlock()
 	fake.teamMutex.RLock()
 	defer fake.teamMutex.RUnlock()
 	fake.unpauseJobMutex.RLock()
@@ -0,0 +1,92 @@
+package concourse
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) PipelineConfigVersions(pipelineName string) ([]atc.ConfigVersion, bool, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineName,
+		"team_name":     team.name,
+	}
+
+	var versions []atc.ConfigVersion
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListConfigVersions,
+		Params:      params,
+	}, &internal.Response{
+		Result: &versions,
+	})
+
+	switch err.(type) {
+	case nil:
+		return versions, true, nil
+	case internal.ResourceNotFoundError:
+		return nil, false, nil
+	default:
+		return nil, false, err
+	}
+}
+
+func (team *team) PipelineConfigVersion(pipelineName string, version int) (atc.ConfigVersion, bool, error) {
+	params := rata.Params{
+		"pipeline_name":  pipelineName,
+		"team_name":      team.name,
+		"config_version": strconv.Itoa(version),
+	}
+
+	var configVersion atc.ConfigVersion
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.GetConfigVersion,
+		Params:      params,
+	}, &internal.Response{
+		Result: &configVersion,
+	})
+
+	switch err.(type) {
+	case nil:
+		return configVersion, true, nil
+	case internal.ResourceNotFoundError:
+		return atc.ConfigVersion{}, false, nil
+	default:
+		return atc.ConfigVersion{}, false, err
+	}
+}
+
+// DiffPipelineConfigVersions diffs two versions of a pipeline's config. If to
+// is 0 the diff is against the current config.
+func (team *team) DiffPipelineConfigVersions(pipelineName string, from int, to int) (atc.ConfigDiff, bool, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineName,
+		"team_name":     team.name,
+	}
+
+	queryParams := url.Values{}
+	queryParams.Add(atc.DiffConfigFrom, strconv.Itoa(from))
+	if to != 0 {
+		queryParams.Add(atc.DiffConfigTo, strconv.Itoa(to))
+	}
+
+	var diff atc.ConfigDiff
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.DiffConfigVersions,
+		Params:      params,
+		Query:       queryParams,
+	}, &internal.Response{
+		Result: &diff,
+	})
+
+	switch err.(type) {
+	case nil:
+		return diff, true, nil
+	case internal.ResourceNotFoundError:
+		return atc.ConfigDiff{}, false, nil
+	default:
+		return atc.ConfigDiff{}, false, err
+	}
+}
@@ -0,0 +1,154 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Config Versions", func() {
+	Describe("PipelineConfigVersions", func() {
+		expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/config/versions"
+
+		Context("when the pipeline exists", func() {
+			expectedVersions := []atc.ConfigVersion{
+				{Version: 2, SavedBy: "some-user", SavedAt: 200},
+				{Version: 1, SavedAt: 100},
+			}
+
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedVersions),
+					),
+				)
+			})
+
+			It("returns the history", func() {
+				versions, found, err := team.PipelineConfigVersions("mypipeline")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(versions).To(Equal(expectedVersions))
+			})
+		})
+
+		Context("when the pipeline does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false", func() {
+				_, found, err := team.PipelineConfigVersions("mypipeline")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("PipelineConfigVersion", func() {
+		expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/config/versions/7"
+
+		Context("when the version exists", func() {
+			expectedVersion := atc.ConfigVersion{
+				Version: 7,
+				SavedBy: "some-user",
+				SavedAt: 100,
+				Config: &atc.Config{
+					Jobs: atc.JobConfigs{{Name: "some-job"}},
+				},
+			}
+
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedVersion),
+					),
+				)
+			})
+
+			It("returns the version", func() {
+				version, found, err := team.PipelineConfigVersion("mypipeline", 7)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(version).To(Equal(expectedVersion))
+			})
+		})
+
+		Context("when the version does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false", func() {
+				_, found, err := team.PipelineConfigVersion("mypipeline", 7)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("DiffPipelineConfigVersions", func() {
+		expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/config/diff"
+
+		expectedDiff := atc.ConfigDiff{
+			From: 1,
+			To:   2,
+			Lines: []atc.ConfigDiffLine{
+				{Delta: atc.ConfigDiffCommon, Text: "jobs:"},
+				{Delta: atc.ConfigDiffRemoved, Text: "- name: job-one"},
+				{Delta: atc.ConfigDiffAdded, Text: "- name: job-two"},
+			},
+		}
+
+		Context("when both versions are given", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, "from=1&to=2"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedDiff),
+					),
+				)
+			})
+
+			It("returns the diff", func() {
+				diff, found, err := team.DiffPipelineConfigVersions("mypipeline", 1, 2)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(diff).To(Equal(expectedDiff))
+			})
+		})
+
+		Context("when the target version is 0", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, "from=1"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedDiff),
+					),
+				)
+			})
+
+			It("diffs against the current config", func() {
+				_, found, err := team.DiffPipelineConfigVersions("mypipeline", 1, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+	})
+})
@@ -0,0 +1,48 @@
+package concourse
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) HijackSessions() ([]atc.HijackSession, error) {
+	params := rata.Params{"team_name": team.name}
+
+	var sessions []atc.HijackSession
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListHijackSessions,
+		Params:      params,
+	}, &internal.Response{
+		Result: &sessions,
+	})
+
+	return sessions, err
+}
+
+// HijackSessionRecording returns the session as an asciicast v2 recording.
+func (team *team) HijackSessionRecording(sessionID int) (io.ReadCloser, bool, error) {
+	params := rata.Params{
+		"team_name":  team.name,
+		"session_id": strconv.Itoa(sessionID),
+	}
+
+	response := internal.Response{}
+	err := team.connection.Send(internal.Request{
+		RequestName:        atc.GetHijackSessionRecording,
+		Params:             params,
+		ReturnResponseBody: true,
+	}, &response)
+
+	switch err.(type) {
+	case nil:
+		return response.Result.(io.ReadCloser), true, nil
+	case internal.ResourceNotFoundError:
+		return nil, false, nil
+	default:
+		return nil, false, err
+	}
+}
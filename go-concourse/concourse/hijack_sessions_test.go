@@ -0,0 +1,88 @@
+package concourse_test
+
+import (
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Hijack Sessions", func() {
+	Describe("HijackSessions", func() {
+		var expectedSessions []atc.HijackSession
+
+		BeforeEach(func() {
+			exitStatus := 0
+
+			expectedSessions = []atc.HijackSession{
+				{
+					ID:              1,
+					TeamName:        "some-team",
+					ContainerHandle: "some-handle",
+					WorkerName:      "some-worker",
+					Username:        "some-user",
+					Path:            "bash",
+					StartedAt:       100,
+					EndedAt:         110,
+					ExitStatus:      &exitStatus,
+				},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/hijack-sessions"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedSessions),
+				),
+			)
+		})
+
+		It("returns the team's sessions", func() {
+			sessions, err := team.HijackSessions()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sessions).To(Equal(expectedSessions))
+		})
+	})
+
+	Describe("HijackSessionRecording", func() {
+		Context("when the session exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/hijack-sessions/42/recording"),
+						ghttp.RespondWith(http.StatusOK, "some-recording"),
+					),
+				)
+			})
+
+			It("returns the recording", func() {
+				recording, found, err := team.HijackSessionRecording(42)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				defer recording.Close()
+
+				Expect(ioutil.ReadAll(recording)).To(Equal([]byte("some-recording")))
+			})
+		})
+
+		Context("when the session does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/hijack-sessions/42/recording"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false and no error", func() {
+				_, found, err := team.HijackSessionRecording(42)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
@@ -0,0 +1,96 @@
+package concourse
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (client *client) ListLocalUsers() ([]atc.LocalUser, error) {
+	var users []atc.LocalUser
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.ListLocalUsers,
+	}, &internal.Response{
+		Result: &users,
+	})
+
+	return users, err
+}
+
+func (client *client) CreateLocalUser(username string, password string) (atc.LocalUser, error) {
+	buffer := &bytes.Buffer{}
+	err := json.NewEncoder(buffer).Encode(atc.LocalUserRequest{
+		Username: username,
+		Password: password,
+	})
+	if err != nil {
+		return atc.LocalUser{}, err
+	}
+
+	var user atc.LocalUser
+	err = client.connection.Send(internal.Request{
+		RequestName: atc.CreateLocalUser,
+		Body:        buffer,
+		Header: http.Header{
+			"Content-Type": {"application/json"},
+		},
+	}, &internal.Response{
+		Result: &user,
+	})
+
+	return user, err
+}
+
+func (client *client) SetLocalUserPassword(username string, password string) (bool, error) {
+	buffer := &bytes.Buffer{}
+	err := json.NewEncoder(buffer).Encode(atc.LocalUserRequest{
+		Password: password,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	err = client.connection.Send(internal.Request{
+		RequestName: atc.SetLocalUserPassword,
+		Params:      rata.Params{"username": username},
+		Body:        buffer,
+		Header: http.Header{
+			"Content-Type": {"application/json"},
+		},
+	}, nil)
+
+	return localUserFound(err)
+}
+
+func (client *client) DisableLocalUser(username string) (bool, error) {
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.DisableLocalUser,
+		Params:      rata.Params{"username": username},
+	}, nil)
+
+	return localUserFound(err)
+}
+
+func (client *client) EnableLocalUser(username string) (bool, error) {
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.EnableLocalUser,
+		Params:      rata.Params{"username": username},
+	}, nil)
+
+	return localUserFound(err)
+}
+
+func localUserFound(err error) (bool, error) {
+	switch err.(type) {
+	case nil:
+		return true, nil
+	case internal.ResourceNotFoundError:
+		return false, nil
+	default:
+		return false, err
+	}
+}
@@ -0,0 +1,150 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Local Users", func() {
+	Describe("ListLocalUsers", func() {
+		var expectedUsers []atc.LocalUser
+
+		BeforeEach(func() {
+			expectedUsers = []atc.LocalUser{
+				{Username: "some-user", CreatedAt: 100, UpdatedAt: 200},
+				{Username: "other-user", Disabled: true},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/users/local"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedUsers),
+				),
+			)
+		})
+
+		It("returns the users", func() {
+			users, err := client.ListLocalUsers()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(users).To(Equal(expectedUsers))
+		})
+	})
+
+	Describe("CreateLocalUser", func() {
+		Context("when creating the user succeeds", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/api/v1/users/local"),
+						ghttp.VerifyJSONRepresenting(atc.LocalUserRequest{
+							Username: "some-user",
+							Password: "some-password",
+						}),
+						ghttp.RespondWithJSONEncoded(http.StatusCreated, atc.LocalUser{
+							Username: "some-user",
+						}),
+					),
+				)
+			})
+
+			It("returns the created user", func() {
+				user, err := client.CreateLocalUser("some-user", "some-password")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(user.Username).To(Equal("some-user"))
+			})
+		})
+
+		Context("when the user already exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/api/v1/users/local"),
+						ghttp.RespondWith(http.StatusConflict, nil),
+					),
+				)
+			})
+
+			It("errors", func() {
+				_, err := client.CreateLocalUser("some-user", "some-password")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("SetLocalUserPassword", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/users/local/some-user/password"),
+					ghttp.VerifyJSONRepresenting(atc.LocalUserRequest{
+						Password: "new-password",
+					}),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			)
+		})
+
+		It("sets the password", func() {
+			found, err := client.SetLocalUserPassword("some-user", "new-password")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+	})
+
+	Describe("DisableLocalUser", func() {
+		Context("when the user exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/users/local/some-user/disable"),
+						ghttp.RespondWith(http.StatusOK, ""),
+					),
+				)
+			})
+
+			It("disables the user", func() {
+				found, err := client.DisableLocalUser("some-user")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when the user does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/users/local/some-user/disable"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false and no error", func() {
+				found, err := client.DisableLocalUser("some-user")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("EnableLocalUser", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/users/local/some-user/enable"),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			)
+		})
+
+		It("enables the user", func() {
+			found, err := client.EnableLocalUser("some-user")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+	})
+})
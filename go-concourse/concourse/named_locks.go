@@ -0,0 +1,42 @@
+package concourse
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) NamedLocks() ([]atc.NamedLock, error) {
+	params := rata.Params{"team_name": team.name}
+
+	var locks []atc.NamedLock
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListNamedLocks,
+		Params:      params,
+	}, &internal.Response{
+		Result: &locks,
+	})
+
+	return locks, err
+}
+
+func (team *team) ReleaseNamedLock(lockName string) (bool, error) {
+	params := rata.Params{
+		"team_name": team.name,
+		"lock_name": lockName,
+	}
+
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ReleaseNamedLock,
+		Params:      params,
+	}, nil)
+
+	switch err.(type) {
+	case nil:
+		return true, nil
+	case internal.ResourceNotFoundError:
+		return false, nil
+	default:
+		return false, err
+	}
+}
@@ -0,0 +1,78 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Named Locks", func() {
+	Describe("NamedLocks", func() {
+		var expectedLocks []atc.NamedLock
+
+		BeforeEach(func() {
+			expectedLocks = []atc.NamedLock{
+				{
+					Name:     "some-lock",
+					Capacity: 1,
+					Holders: []atc.NamedLockClaim{
+						{BuildID: 42, BuildName: "7", JobName: "some-job", PipelineName: "some-pipeline", ClaimedAt: 100, AcquiredAt: 110},
+					},
+					Waiters: []atc.NamedLockClaim{},
+				},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/locks"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedLocks),
+				),
+			)
+		})
+
+		It("returns the team's locks", func() {
+			locks, err := team.NamedLocks()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(locks).To(Equal(expectedLocks))
+		})
+	})
+
+	Describe("ReleaseNamedLock", func() {
+		Context("when the lock exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/some-team/locks/some-lock/release"),
+						ghttp.RespondWith(http.StatusOK, ""),
+					),
+				)
+			})
+
+			It("releases the lock", func() {
+				found, err := team.ReleaseNamedLock("some-lock")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when the lock does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/some-team/locks/some-lock/release"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false and no error", func() {
+				found, err := team.ReleaseNamedLock("some-lock")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
@@ -47,6 +47,7 @@ type Page struct {
 	Until      int
 	Limit      int
 	Timestamps bool
+	Filter     string
 }
 
 func pageFromURI(uri string) (Page, error) {
@@ -60,6 +61,7 @@ func pageFromURI(uri string) (Page, error) {
 	page.Since, _ = strconv.Atoi(params.Get("since"))
 	page.Until, _ = strconv.Atoi(params.Get("until"))
 	page.Limit, _ = strconv.Atoi(params.Get("limit"))
+	page.Filter = params.Get("filter")
 
 	return page, nil
 }
@@ -82,5 +84,9 @@ func (p Page) QueryParams() url.Values {
 		queryParams.Add("timestamps", "true")
 	}
 
+	if p.Filter != "" {
+		queryParams.Add("filter", p.Filter)
+	}
+
 	return queryParams
 }
@@ -155,6 +155,25 @@ var _ = Describe("ATC Handler Resource Versions", func() {
 			})
 		})
 
+		Context("when a filter is specified", func() {
+			BeforeEach(func() {
+				page = concourse.Page{Limit: 15, Filter: "ref =~ ^release/"}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, "limit=15&filter=ref+%3D~+%5Erelease%2F"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedVersions),
+					),
+				)
+			})
+
+			It("sends the filter", func() {
+				Expect(clientErr).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(versions).To(Equal(expectedVersions))
+			})
+		})
+
 		Context("when the server returns an error", func() {
 			BeforeEach(func() {
 				atcServer.AppendHandlers(
@@ -0,0 +1,82 @@
+package concourse
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/vito/go-sse/sse"
+)
+
+type StatusEventFilter struct {
+	Team     string
+	Pipeline string
+	Job      string
+}
+
+func (f StatusEventFilter) QueryParams() url.Values {
+	queryParams := url.Values{}
+	if f.Team != "" {
+		queryParams.Add(atc.StatusEventsTeam, f.Team)
+	}
+
+	if f.Pipeline != "" {
+		queryParams.Add(atc.StatusEventsPipeline, f.Pipeline)
+	}
+
+	if f.Job != "" {
+		queryParams.Add(atc.StatusEventsJob, f.Job)
+	}
+
+	return queryParams
+}
+
+//go:generate counterfeiter . StatusEventStream
+
+// StatusEventStream is a live stream of build and job status changes. It
+// reconnects and resumes where it left off if the connection drops.
+type StatusEventStream interface {
+	NextStatusEvent() (atc.StatusEvent, error)
+	Close() error
+}
+
+func (client *client) StatusEvents(filter StatusEventFilter) (StatusEventStream, error) {
+	sseEvents, err := client.connection.ConnectToEventStream(internal.Request{
+		RequestName: atc.StatusEvents,
+		Query:       filter.QueryParams(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &statusEventStream{sseReader: sseEvents}, nil
+}
+
+type statusEventStream struct {
+	sseReader *sse.EventSource
+}
+
+func (s *statusEventStream) NextStatusEvent() (atc.StatusEvent, error) {
+	se, err := s.sseReader.Next()
+	if err != nil {
+		return atc.StatusEvent{}, err
+	}
+
+	if se.Name != "event" {
+		return atc.StatusEvent{}, fmt.Errorf("unknown event name: %s", se.Name)
+	}
+
+	var statusEvent atc.StatusEvent
+	err = json.Unmarshal(se.Data, &statusEvent)
+	if err != nil {
+		return atc.StatusEvent{}, err
+	}
+
+	return statusEvent, nil
+}
+
+func (s *statusEventStream) Close() error {
+	return s.sseReader.Close()
+}
@@ -0,0 +1,101 @@
+package concourse_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/vito/go-sse/sse"
+)
+
+var _ = Describe("ATC Handler Status Events", func() {
+	Describe("StatusEvents", func() {
+		var statusEvents []atc.StatusEvent
+
+		BeforeEach(func() {
+			statusEvents = []atc.StatusEvent{
+				{
+					ID:           1,
+					Type:         atc.StatusEventBuildStarted,
+					TeamName:     "some-team",
+					PipelineName: "some-pipeline",
+					JobName:      "some-job",
+					Build: &atc.Build{
+						ID:     42,
+						Name:   "7",
+						Status: "started",
+					},
+				},
+				{
+					ID:           2,
+					Type:         atc.StatusEventJobPaused,
+					TeamName:     "some-team",
+					PipelineName: "some-pipeline",
+					JobName:      "some-job",
+				},
+			}
+		})
+
+		Context("when the server streams events", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/events", "team=some-team&pipeline=some-pipeline"),
+						func(w http.ResponseWriter, r *http.Request) {
+							w.Header().Add("Content-Type", "text/event-stream; charset=utf-8")
+							w.WriteHeader(http.StatusOK)
+
+							for _, ev := range statusEvents {
+								payload, err := json.Marshal(ev)
+								Expect(err).NotTo(HaveOccurred())
+
+								err = sse.Event{
+									ID:   strconv.FormatInt(ev.ID, 10),
+									Name: "event",
+									Data: payload,
+								}.Write(w)
+								Expect(err).NotTo(HaveOccurred())
+							}
+
+							w.(http.Flusher).Flush()
+						},
+					),
+				)
+			})
+
+			It("returns the events", func() {
+				stream, err := client.StatusEvents(concourse.StatusEventFilter{
+					Team:     "some-team",
+					Pipeline: "some-pipeline",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				next, err := stream.NextStatusEvent()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(next).To(Equal(statusEvents[0]))
+
+				next, err = stream.NextStatusEvent()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(next).To(Equal(statusEvents[1]))
+
+				Expect(stream.Close()).To(Succeed())
+			})
+		})
+
+		Context("when the server returns 401", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(ghttp.RespondWith(http.StatusUnauthorized, ""))
+			})
+
+			It("returns ErrUnauthorized", func() {
+				_, err := client.StatusEvents(concourse.StatusEventFilter{})
+				Expect(err).To(Equal(concourse.ErrUnauthorized))
+			})
+		})
+	})
+})
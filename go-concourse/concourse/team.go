@@ -14,6 +14,7 @@ type Team interface {
 
 	Team(teamName string) (atc.Team, bool, error)
 	CreateOrUpdate(team atc.Team) (atc.Team, bool, bool, error)
+	CreateOrUpdateWithWarnings(team atc.Team) (atc.Team, bool, bool, []ConfigWarning, error)
 	RenameTeam(teamName, name string) (bool, error)
 	DestroyTeam(teamName string) error
 
@@ -28,6 +29,9 @@ type Team interface {
 	ListPipelines() ([]atc.Pipeline, error)
 	PipelineConfig(pipelineName string) (atc.Config, string, bool, error)
 	CreateOrUpdatePipelineConfig(pipelineName string, configVersion string, passedConfig []byte, checkCredentials bool) (bool, bool, []ConfigWarning, error)
+	PipelineConfigVersions(pipelineName string) ([]atc.ConfigVersion, bool, error)
+	PipelineConfigVersion(pipelineName string, version int) (atc.ConfigVersion, bool, error)
+	DiffPipelineConfigVersions(pipelineName string, from int, to int) (atc.ConfigDiff, bool, error)
 
 	CreatePipelineBuild(pipelineName string, plan atc.Plan) (atc.Build, error)
 
@@ -65,6 +69,22 @@ type Team interface {
 
 	CreateArtifact(io.Reader) (atc.WorkerArtifact, error)
 	GetArtifact(int) (io.ReadCloser, error)
+
+	CreateAPIToken(atc.APITokenRequest) (atc.APIToken, error)
+	ListAPITokens() ([]atc.APIToken, error)
+	RevokeAPIToken(tokenName string) (bool, error)
+
+	ListWorkerKeys() ([]atc.WorkerKey, error)
+	CreateWorkerKey(atc.WorkerKeyRequest) (atc.WorkerKey, error)
+	DeleteWorkerKey(keyName string) (bool, error)
+
+	AuditEvents(filter AuditEventFilter, page Page) ([]atc.AuditEvent, Pagination, error)
+
+	NamedLocks() ([]atc.NamedLock, error)
+	ReleaseNamedLock(lockName string) (bool, error)
+
+	HijackSessions() ([]atc.HijackSession, error)
+	HijackSessionRecording(sessionID int) (io.ReadCloser, bool, error)
 }
 
 type team struct {
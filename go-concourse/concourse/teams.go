@@ -35,18 +35,31 @@ func (team *team) Team(teamName string) (atc.Team, bool, error) {
 	}
 }
 
+type setTeamResponse struct {
+	atc.Team
+
+	Warnings []ConfigWarning `json:"warnings"`
+}
+
 // CreateOrUpdate creates or updates team teamName with the settings provided in passedTeam.
 // passedTeam should reflect the desired state of team's configuration.
 func (team *team) CreateOrUpdate(passedTeam atc.Team) (atc.Team, bool, bool, error) {
+	savedTeam, created, updated, _, err := team.CreateOrUpdateWithWarnings(passedTeam)
+	return savedTeam, created, updated, err
+}
+
+// CreateOrUpdateWithWarnings is CreateOrUpdate, but also returns the warnings
+// raised while checking the team's configuration.
+func (team *team) CreateOrUpdateWithWarnings(passedTeam atc.Team) (atc.Team, bool, bool, []ConfigWarning, error) {
 	params := rata.Params{"team_name": team.name}
 
 	buffer := &bytes.Buffer{}
 	err := json.NewEncoder(buffer).Encode(passedTeam)
 	if err != nil {
-		return atc.Team{}, false, false, fmt.Errorf("Unable to marshal plan: %s", err)
+		return atc.Team{}, false, false, []ConfigWarning{}, fmt.Errorf("Unable to marshal plan: %s", err)
 	}
 
-	var savedTeam atc.Team
+	var savedTeam setTeamResponse
 	response := internal.Response{
 		Result: &savedTeam,
 	}
@@ -60,7 +73,7 @@ func (team *team) CreateOrUpdate(passedTeam atc.Team) (atc.Team, bool, bool, err
 	}, &response)
 
 	if err != nil {
-		return savedTeam, false, false, err
+		return savedTeam.Team, false, false, []ConfigWarning{}, err
 	}
 
 	var created, updated bool
@@ -70,7 +83,7 @@ func (team *team) CreateOrUpdate(passedTeam atc.Team) (atc.Team, bool, bool, err
 		updated = true
 	}
 
-	return savedTeam, created, updated, nil
+	return savedTeam.Team, created, updated, savedTeam.Warnings, nil
 }
 
 // DestroyTeam destroys the team with the name given as argument.
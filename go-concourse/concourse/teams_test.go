@@ -152,6 +152,31 @@ var _ = Describe("ATC Handler Teams", func() {
 				Expect(updated).To(BeTrue())
 			})
 		})
+
+		Context("when the server returns warnings", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.SetTeamResponse{
+							Team: expectedTeam,
+							Warnings: []atc.ConfigWarning{
+								{Type: "policy", Message: "some-warning"},
+							},
+						}),
+					),
+				)
+			})
+
+			It("returns the team and the warnings", func() {
+				team, _, _, warnings, err := team.CreateOrUpdateWithWarnings(desiredTeam)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(team).To(Equal(expectedTeam))
+				Expect(warnings).To(Equal([]concourse.ConfigWarning{
+					{Type: "policy", Message: "some-warning"},
+				}))
+			})
+		})
 	})
 
 	Describe("Destroy", func() {
@@ -0,0 +1,95 @@
+package concourse
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (client *client) ListWorkerKeys() ([]atc.WorkerKey, error) {
+	var keys []atc.WorkerKey
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.ListWorkerKeys,
+	}, &internal.Response{
+		Result: &keys,
+	})
+
+	return keys, err
+}
+
+func (client *client) CreateWorkerKey(request atc.WorkerKeyRequest) (atc.WorkerKey, error) {
+	return createWorkerKey(client.connection, atc.CreateWorkerKey, nil, request)
+}
+
+func (client *client) DeleteWorkerKey(keyName string) (bool, error) {
+	return deleteWorkerKey(client.connection, atc.DeleteWorkerKey, rata.Params{
+		"key_name": keyName,
+	})
+}
+
+func (team *team) ListWorkerKeys() ([]atc.WorkerKey, error) {
+	var keys []atc.WorkerKey
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListTeamWorkerKeys,
+		Params:      rata.Params{"team_name": team.name},
+	}, &internal.Response{
+		Result: &keys,
+	})
+
+	return keys, err
+}
+
+func (team *team) CreateWorkerKey(request atc.WorkerKeyRequest) (atc.WorkerKey, error) {
+	return createWorkerKey(team.connection, atc.CreateTeamWorkerKey, rata.Params{
+		"team_name": team.name,
+	}, request)
+}
+
+func (team *team) DeleteWorkerKey(keyName string) (bool, error) {
+	return deleteWorkerKey(team.connection, atc.DeleteTeamWorkerKey, rata.Params{
+		"team_name": team.name,
+		"key_name":  keyName,
+	})
+}
+
+func createWorkerKey(connection internal.Connection, requestName string, params rata.Params, request atc.WorkerKeyRequest) (atc.WorkerKey, error) {
+	buffer := &bytes.Buffer{}
+	err := json.NewEncoder(buffer).Encode(request)
+	if err != nil {
+		return atc.WorkerKey{}, err
+	}
+
+	var key atc.WorkerKey
+	err = connection.Send(internal.Request{
+		RequestName: requestName,
+		Params:      params,
+		Body:        buffer,
+		Header: http.Header{
+			"Content-Type": {"application/json"},
+		},
+	}, &internal.Response{
+		Result: &key,
+	})
+
+	return key, err
+}
+
+func deleteWorkerKey(connection internal.Connection, requestName string, params rata.Params) (bool, error) {
+	err := connection.Send(internal.Request{
+		RequestName: requestName,
+		Params:      params,
+	}, nil)
+
+	switch err.(type) {
+	case nil:
+		return true, nil
+	case internal.ResourceNotFoundError:
+		return false, nil
+	default:
+		return false, err
+	}
+}
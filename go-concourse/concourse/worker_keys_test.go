@@ -0,0 +1,148 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Worker Keys", func() {
+	var request atc.WorkerKeyRequest
+
+	BeforeEach(func() {
+		request = atc.WorkerKeyRequest{
+			Name:      "some-key",
+			PublicKey: "ssh-ed25519 AAAA",
+		}
+	})
+
+	Describe("ListWorkerKeys", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/worker_keys"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.WorkerKey{
+						{Name: "global-key"},
+						{Name: "team-key", TeamName: "some-team"},
+					}),
+				),
+			)
+		})
+
+		It("returns every key", func() {
+			keys, err := client.ListWorkerKeys()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(Equal([]atc.WorkerKey{
+				{Name: "global-key"},
+				{Name: "team-key", TeamName: "some-team"},
+			}))
+		})
+	})
+
+	Describe("CreateWorkerKey", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v1/worker_keys"),
+					ghttp.VerifyJSONRepresenting(request),
+					ghttp.RespondWithJSONEncoded(http.StatusCreated, atc.WorkerKey{
+						Name:        "some-key",
+						Fingerprint: "SHA256:some-fingerprint",
+					}),
+				),
+			)
+		})
+
+		It("returns the created key", func() {
+			key, err := client.CreateWorkerKey(request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key.Fingerprint).To(Equal("SHA256:some-fingerprint"))
+		})
+	})
+
+	Describe("DeleteWorkerKey", func() {
+		Context("when the key exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/api/v1/worker_keys/some-key"),
+						ghttp.RespondWith(http.StatusNoContent, nil),
+					),
+				)
+			})
+
+			It("returns true", func() {
+				found, err := client.DeleteWorkerKey("some-key")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when the key does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/api/v1/worker_keys/some-key"),
+						ghttp.RespondWith(http.StatusNotFound, nil),
+					),
+				)
+			})
+
+			It("returns false", func() {
+				found, err := client.DeleteWorkerKey("some-key")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("team worker keys", func() {
+		It("lists the team's keys", func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/worker_keys"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.WorkerKey{
+						{Name: "team-key", TeamName: "some-team"},
+					}),
+				),
+			)
+
+			keys, err := team.ListWorkerKeys()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(HaveLen(1))
+		})
+
+		It("creates a key for the team", func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v1/teams/some-team/worker_keys"),
+					ghttp.VerifyJSONRepresenting(request),
+					ghttp.RespondWithJSONEncoded(http.StatusCreated, atc.WorkerKey{
+						Name:     "some-key",
+						TeamName: "some-team",
+					}),
+				),
+			)
+
+			key, err := team.CreateWorkerKey(request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key.TeamName).To(Equal("some-team"))
+		})
+
+		It("deletes the team's key", func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/api/v1/teams/some-team/worker_keys/some-key"),
+					ghttp.RespondWith(http.StatusNoContent, nil),
+				),
+			)
+
+			found, err := team.DeleteWorkerKey("some-key")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+	})
+})
@@ -92,3 +92,16 @@ func (client *client) LandWorker(workerName string) error {
 
 	return err
 }
+
+func (client *client) UnquarantineWorker(workerName string) error {
+	params := rata.Params{"worker_name": workerName}
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.UnquarantineWorker,
+		Params:      params,
+		Header: http.Header{
+			"Content-Type": {"application/json"},
+		},
+	}, nil)
+
+	return err
+}
@@ -160,4 +160,38 @@ var _ = Describe("ATC Handler Workers", func() {
 			})
 		})
 	})
+
+	Describe("UnquarantineWorker", func() {
+		Context("when succeeds", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/workers/some-worker/unquarantine"),
+						ghttp.RespondWith(http.StatusOK, nil),
+					),
+				)
+			})
+
+			It("releases the worker from quarantine", func() {
+				err := client.UnquarantineWorker("some-worker")
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("failing to unquarantine worker", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/workers/some-worker/unquarantine"),
+						ghttp.RespondWith(http.StatusConflict, nil),
+					),
+				)
+			})
+
+			It("returns the error", func() {
+				err := client.UnquarantineWorker("some-worker")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
 })
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/skymarshal/logger"
 	"github.com/concourse/concourse/skymarshal/skycmd"
 	s "github.com/concourse/concourse/skymarshal/storage"
@@ -14,15 +15,18 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+const localConnectorID = "local"
+
 type DexConfig struct {
-	Logger       lager.Logger
-	IssuerURL    string
-	WebHostURL   string
-	ClientID     string
-	ClientSecret string
-	RedirectURL  string
-	Flags        skycmd.AuthFlags
-	Storage      s.Storage
+	Logger           lager.Logger
+	IssuerURL        string
+	WebHostURL       string
+	ClientID         string
+	ClientSecret     string
+	RedirectURL      string
+	Flags            skycmd.AuthFlags
+	Storage          s.Storage
+	LocalUserFactory db.LocalUserFactory
 }
 
 func NewDexServer(config *DexConfig) (*server.Server, error) {
@@ -39,24 +43,23 @@ func NewDexServerConfig(config *DexConfig) (server.Config, error) {
 
 	var clients []storage.Client
 	var connectors []storage.Connector
-	var passwords []storage.Password
 
+	// users given on the command line only seed the table; users that
+	// already exist keep whatever password was set through the API
 	for username, password := range newLocalUsers(config) {
-		passwords = append(passwords, storage.Password{
-			UserID:   username,
-			Username: username,
-			Email:    username,
-			Hash:     password,
-		})
+		err := config.LocalUserFactory.SeedLocalUser(username, password)
+		if err != nil {
+			return server.Config{}, err
+		}
 	}
 
-	if len(passwords) > 0 {
-		connectors = append(connectors, storage.Connector{
-			ID:   "local",
-			Type: "local",
-			Name: "Username/Password",
-		})
-	}
+	// always registered; localUserStorage hides it while there are no
+	// enabled local users
+	connectors = append(connectors, storage.Connector{
+		ID:   localConnectorID,
+		Type: localConnectorID,
+		Name: "Username/Password",
+	})
 
 	redirectURI := strings.TrimRight(config.IssuerURL, "/") + "/callback"
 
@@ -77,7 +80,9 @@ func NewDexServerConfig(config *DexConfig) (server.Config, error) {
 		RedirectURIs: []string{config.RedirectURL},
 	})
 
-	if err := replacePasswords(config.Storage, passwords); err != nil {
+	// local users are served from the local_users table now, so clear out
+	// anything left in dex's own password storage
+	if err := replacePasswords(config.Storage, nil); err != nil {
 		return server.Config{}, err
 	}
 
@@ -98,11 +103,11 @@ func NewDexServerConfig(config *DexConfig) (server.Config, error) {
 	}
 
 	return server.Config{
-		PasswordConnector:      "local",
+		PasswordConnector:      localConnectorID,
 		SupportedResponseTypes: []string{"code", "token", "id_token"},
 		SkipApprovalScreen:     true,
 		Issuer:                 config.IssuerURL,
-		Storage:                config.Storage,
+		Storage:                localUserStorage{config.Storage, config.LocalUserFactory},
 		Web:                    webConfig,
 		Logger:                 logger.New(config.Logger),
 	}, nil
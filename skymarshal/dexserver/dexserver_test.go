@@ -1,7 +1,7 @@
 package dexserver_test
 
 import (
-	"sort"
+	"errors"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -9,9 +9,11 @@ import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/concourse/atc/atccmd"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/skymarshal/dexserver"
 	"github.com/concourse/concourse/skymarshal/skycmd"
-	store "github.com/concourse/concourse/skymarshal/storage"
+	dexstore "github.com/concourse/concourse/skymarshal/storage"
 	"github.com/concourse/dex/server"
 	"github.com/concourse/dex/storage"
 	"github.com/concourse/flag"
@@ -22,14 +24,15 @@ import (
 var _ = Describe("Dex Server", func() {
 	var config *dexserver.DexConfig
 	var serverConfig server.Config
-	var storage storage.Storage
+	var store storage.Storage
+	var fakeLocalUserFactory *dbfakes.FakeLocalUserFactory
 	var logger lager.Logger
 	var err error
 
 	BeforeEach(func() {
 		logger = lagertest.NewTestLogger("dex")
 
-		storage, err = store.NewPostgresStorage(logger, flag.PostgresConfig{
+		store, err = dexstore.NewPostgresStorage(logger, flag.PostgresConfig{
 			Host:     "127.0.0.1",
 			Port:     uint16(5433 + GinkgoParallelNode()),
 			User:     "postgres",
@@ -38,14 +41,17 @@ var _ = Describe("Dex Server", func() {
 		})
 		Expect(err).ToNot(HaveOccurred())
 
+		fakeLocalUserFactory = new(dbfakes.FakeLocalUserFactory)
+
 		config = &dexserver.DexConfig{
-			Logger:  logger,
-			Storage: storage,
+			Logger:           logger,
+			Storage:          store,
+			LocalUserFactory: fakeLocalUserFactory,
 		}
 	})
 
 	AfterEach(func() {
-		storage.Close()
+		store.Close()
 	})
 
 	JustBeforeEach(func() {
@@ -71,34 +77,18 @@ var _ = Describe("Dex Server", func() {
 
 		Context("when local users are configured", func() {
 
-			ConfiguresUsersCorrectly := func() {
-				It("should configure local connector", func() {
-					connectors, err := storage.ListConnectors()
-					Expect(err).NotTo(HaveOccurred())
-
-					Expect(connectors[0].ID).To(Equal("local"))
-					Expect(connectors[0].Type).To(Equal("local"))
-					Expect(connectors[0].Name).To(Equal("Username/Password"))
-				})
-
-				It("should configure local users", func() {
-					passwords, err := storage.ListPasswords()
-					Expect(err).NotTo(HaveOccurred())
-
-					// we're adding users from a map, which is unordered
-					sort.Slice(passwords, func(i, j int) bool {
-						return passwords[i].Username < passwords[j].Username
-					})
+			SeedsUsersCorrectly := func() {
+				It("should seed the local users", func() {
+					Expect(fakeLocalUserFactory.SeedLocalUserCallCount()).To(Equal(2))
 
-					Expect(passwords[0].UserID).To(Equal("some-user-0"))
-					Expect(passwords[0].Username).To(Equal("some-user-0"))
-					Expect(passwords[0].Email).To(Equal("some-user-0"))
-					Expect(bcrypt.CompareHashAndPassword(passwords[0].Hash, []byte("some-password-0"))).NotTo(HaveOccurred())
+					seeded := map[string][]byte{}
+					for i := 0; i < fakeLocalUserFactory.SeedLocalUserCallCount(); i++ {
+						username, hash := fakeLocalUserFactory.SeedLocalUserArgsForCall(i)
+						seeded[username] = hash
+					}
 
-					Expect(passwords[1].UserID).To(Equal("some-user-1"))
-					Expect(passwords[1].Username).To(Equal("some-user-1"))
-					Expect(passwords[1].Email).To(Equal("some-user-1"))
-					Expect(bcrypt.CompareHashAndPassword(passwords[1].Hash, []byte("some-password-1"))).NotTo(HaveOccurred())
+					Expect(bcrypt.CompareHashAndPassword(seeded["some-user-0"], []byte("some-password-0"))).NotTo(HaveOccurred())
+					Expect(bcrypt.CompareHashAndPassword(seeded["some-user-1"], []byte("some-password-1"))).NotTo(HaveOccurred())
 				})
 			}
 
@@ -112,7 +102,7 @@ var _ = Describe("Dex Server", func() {
 					}
 				})
 
-				ConfiguresUsersCorrectly()
+				SeedsUsersCorrectly()
 			})
 
 			Context("when the user's password is provided in plaintext", func() {
@@ -125,73 +115,90 @@ var _ = Describe("Dex Server", func() {
 					}
 				})
 
-				ConfiguresUsersCorrectly()
-
-				Context("when a user's password is changed", func() {
-					BeforeEach(func() {
-						// First create the first config based on the parent Context
-						serverConfig, err = dexserver.NewDexServerConfig(config)
-						Expect(err).ToNot(HaveOccurred())
-
-						// The final config will be created in the JustBeforeEach block
-						config.Flags = skycmd.AuthFlags{
-							LocalUsers: map[string]string{
-								"some-user-0": "some-password-0",
-								"some-user-1": "some-password-1-changed",
-							},
-						}
-					})
-
-					It("should update the user's password", func() {
-						passwords, err := storage.ListPasswords()
-						Expect(err).NotTo(HaveOccurred())
-
-						// we're adding users from a map, which is unordered
-						sort.Slice(passwords, func(i, j int) bool {
-							return passwords[i].Username < passwords[j].Username
-						})
-
-						Expect(passwords[0].UserID).To(Equal("some-user-0"))
-						Expect(passwords[0].Username).To(Equal("some-user-0"))
-						Expect(passwords[0].Email).To(Equal("some-user-0"))
-						Expect(bcrypt.CompareHashAndPassword(passwords[0].Hash, []byte("some-password-0"))).NotTo(HaveOccurred())
-
-						Expect(passwords[1].UserID).To(Equal("some-user-1"))
-						Expect(passwords[1].Username).To(Equal("some-user-1"))
-						Expect(passwords[1].Email).To(Equal("some-user-1"))
-						Expect(bcrypt.CompareHashAndPassword(passwords[1].Hash, []byte("some-password-1-changed"))).NotTo(HaveOccurred())
-					})
+				SeedsUsersCorrectly()
+			})
+
+			Context("when seeding a user fails", func() {
+				BeforeEach(func() {
+					config.Flags = skycmd.AuthFlags{
+						LocalUsers: map[string]string{
+							"some-user-0": "some-password-0",
+						},
+					}
+
+					fakeLocalUserFactory.SeedLocalUserReturns(errors.New("nope"))
 				})
 
-				Context("when a user is then removed", func() {
-					BeforeEach(func() {
-						// First create the first config based on the parent Context
-						serverConfig, err = dexserver.NewDexServerConfig(config)
-						Expect(err).ToNot(HaveOccurred())
-
-						// The final config will be created in the JustBeforeEach block
-						config.Flags = skycmd.AuthFlags{
-							LocalUsers: map[string]string{
-								"some-user-0": "some-password-0",
-							},
-						}
-					})
-
-					It("should remove the user's password", func() {
-						passwords, err := storage.ListPasswords()
-						Expect(err).NotTo(HaveOccurred())
-
-						Expect(len(passwords)).To(Equal(1))
-
-						Expect(passwords[0].UserID).To(Equal("some-user-0"))
-						Expect(passwords[0].Username).To(Equal("some-user-0"))
-						Expect(passwords[0].Email).To(Equal("some-user-0"))
-						Expect(bcrypt.CompareHashAndPassword(passwords[0].Hash, []byte("some-password-0"))).NotTo(HaveOccurred())
-					})
+				It("returns the error", func() {
+					_, err := dexserver.NewDexServerConfig(config)
+					Expect(err).To(HaveOccurred())
 				})
 			})
 		})
 
+		Context("local users", func() {
+			var hash []byte
+
+			BeforeEach(func() {
+				hash, err = bcrypt.GenerateFromPassword([]byte("some-password"), 10)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			Context("when there are enabled local users", func() {
+				BeforeEach(func() {
+					user := db.LocalUser{Username: "some-user", PasswordHash: hash}
+					fakeLocalUserFactory.LocalUsersReturns([]db.LocalUser{user}, nil)
+					fakeLocalUserFactory.FindLocalUserReturns(user, true, nil)
+				})
+
+				It("should configure local connector", func() {
+					connectors, err := serverConfig.Storage.ListConnectors()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(connectors).To(HaveLen(1))
+					Expect(connectors[0].ID).To(Equal("local"))
+					Expect(connectors[0].Type).To(Equal("local"))
+					Expect(connectors[0].Name).To(Equal("Username/Password"))
+				})
+
+				It("serves passwords from the local users table", func() {
+					password, err := serverConfig.Storage.GetPassword("Some-User")
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeLocalUserFactory.FindLocalUserArgsForCall(0)).To(Equal("Some-User"))
+					Expect(password.UserID).To(Equal("some-user"))
+					Expect(password.Username).To(Equal("some-user"))
+					Expect(password.Email).To(Equal("some-user"))
+					Expect(bcrypt.CompareHashAndPassword(password.Hash, []byte("some-password"))).NotTo(HaveOccurred())
+				})
+			})
+
+			Context("when the local user is disabled", func() {
+				BeforeEach(func() {
+					user := db.LocalUser{Username: "some-user", PasswordHash: hash, Disabled: true}
+					fakeLocalUserFactory.LocalUsersReturns([]db.LocalUser{user}, nil)
+					fakeLocalUserFactory.FindLocalUserReturns(user, true, nil)
+				})
+
+				It("does not serve the password", func() {
+					_, err := serverConfig.Storage.GetPassword("some-user")
+					Expect(err).To(Equal(storage.ErrNotFound))
+				})
+
+				It("hides the local connector", func() {
+					connectors, err := serverConfig.Storage.ListConnectors()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(connectors).To(BeEmpty())
+				})
+			})
+
+			It("clears passwords left in dex's storage", func() {
+				passwords, err := store.ListPasswords()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(passwords).To(BeEmpty())
+			})
+		})
+
 		Context("when clientId and clientSecret are configured", func() {
 			BeforeEach(func() {
 				config.ClientID = "some-client-id"
@@ -200,7 +207,7 @@ var _ = Describe("Dex Server", func() {
 			})
 
 			It("should contain the configured clients", func() {
-				clients, err := storage.ListClients()
+				clients, err := store.ListClients()
 				Expect(err).NotTo(HaveOccurred())
 				Expect(clients).To(HaveLen(1))
 				Expect(clients[0].ID).To(Equal("some-client-id"))
@@ -244,7 +251,7 @@ var _ = Describe("Dex Server", func() {
 			})
 
 			It("sets up an oauth connector", func() {
-				connectors, err := storage.ListConnectors()
+				connectors, err := serverConfig.Storage.ListConnectors()
 				Expect(err).NotTo(HaveOccurred())
 				Expect(len(connectors)).To(Equal(1))
 
@@ -272,7 +279,7 @@ var _ = Describe("Dex Server", func() {
 				})
 
 				It("should update the oauth connector", func() {
-					connectors, err := storage.ListConnectors()
+					connectors, err := serverConfig.Storage.ListConnectors()
 					Expect(err).NotTo(HaveOccurred())
 					Expect(len(connectors)).To(Equal(1))
 
@@ -302,7 +309,7 @@ var _ = Describe("Dex Server", func() {
 				})
 
 				It("should remove the oauth connector", func() {
-					connectors, err := storage.ListConnectors()
+					connectors, err := serverConfig.Storage.ListConnectors()
 					Expect(err).NotTo(HaveOccurred())
 					Expect(len(connectors)).To(BeZero())
 				})
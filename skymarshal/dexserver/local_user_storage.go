@@ -0,0 +1,81 @@
+package dexserver
+
+import (
+	"github.com/concourse/concourse/atc/db"
+	s "github.com/concourse/concourse/skymarshal/storage"
+	"github.com/concourse/dex/storage"
+)
+
+// localUserStorage serves dex's password connector out of the local_users
+// table so that users created, reset, or disabled through the API take
+// effect without restarting the web nodes.
+type localUserStorage struct {
+	s.Storage
+
+	users db.LocalUserFactory
+}
+
+func (store localUserStorage) GetPassword(email string) (storage.Password, error) {
+	user, found, err := store.users.FindLocalUser(email)
+	if err != nil {
+		return storage.Password{}, err
+	}
+
+	if !found || user.Disabled {
+		return storage.Password{}, storage.ErrNotFound
+	}
+
+	return localUserPassword(user), nil
+}
+
+func (store localUserStorage) ListPasswords() ([]storage.Password, error) {
+	users, err := store.users.LocalUsers()
+	if err != nil {
+		return nil, err
+	}
+
+	passwords := []storage.Password{}
+	for _, user := range users {
+		if !user.Disabled {
+			passwords = append(passwords, localUserPassword(user))
+		}
+	}
+
+	return passwords, nil
+}
+
+// ListConnectors hides the local connector while no local user can log in,
+// so the login page doesn't offer a form nobody can use.
+func (store localUserStorage) ListConnectors() ([]storage.Connector, error) {
+	connectors, err := store.Storage.ListConnectors()
+	if err != nil {
+		return nil, err
+	}
+
+	passwords, err := store.ListPasswords()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(passwords) > 0 {
+		return connectors, nil
+	}
+
+	visible := []storage.Connector{}
+	for _, connector := range connectors {
+		if connector.ID != localConnectorID {
+			visible = append(visible, connector)
+		}
+	}
+
+	return visible, nil
+}
+
+func localUserPassword(user db.LocalUser) storage.Password {
+	return storage.Password{
+		UserID:   user.Username,
+		Username: user.Username,
+		Email:    user.Username,
+		Hash:     user.PasswordHash,
+	}
+}
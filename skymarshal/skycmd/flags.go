@@ -47,11 +47,11 @@ type AuthFlags struct {
 	SecureCookies bool              `long:"cookie-secure" description:"Force sending secure flag on http cookies"`
 	Expiration    time.Duration     `long:"auth-duration" default:"24h" description:"Length of time for which tokens are valid. Afterwards, users will have to log back in."`
 	SigningKey    *flag.PrivateKey  `long:"session-signing-key" description:"File containing an RSA private key, used to sign auth tokens."`
-	LocalUsers    map[string]string `long:"add-local-user" description:"List of username:password combinations for all your local users. The password can be bcrypted - if so, it must have a minimum cost of 10." value-name:"USERNAME:PASSWORD"`
+	LocalUsers    map[string]string `long:"add-local-user" description:"List of username:password combinations used to seed local users on startup. Users that already exist keep their current password. The password can be bcrypted - if so, it must have a minimum cost of 10." value-name:"USERNAME:PASSWORD"`
 }
 
 type AuthTeamFlags struct {
-	LocalUsers []string  `long:"local-user" description:"List of whitelisted local concourse users. These are the users you've added at web startup with the --add-local-user flag." value-name:"USERNAME"`
+	LocalUsers []string  `long:"local-user" description:"List of whitelisted local concourse users. These are the users seeded with the --add-local-user flag or created with fly create-local-user." value-name:"USERNAME"`
 	Config     flag.File `short:"c" long:"config" description:"Configuration file for specifying team params"`
 }
 
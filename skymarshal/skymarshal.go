@@ -20,12 +20,13 @@ import (
 )
 
 type Config struct {
-	Logger      lager.Logger
-	TeamFactory db.TeamFactory
-	Flags       skycmd.AuthFlags
-	ExternalURL string
-	HTTPClient  *http.Client
-	Storage     storage.Storage
+	Logger           lager.Logger
+	TeamFactory      db.TeamFactory
+	LocalUserFactory db.LocalUserFactory
+	Flags            skycmd.AuthFlags
+	ExternalURL      string
+	HTTPClient       *http.Client
+	Storage          storage.Storage
 }
 
 type Server struct {
@@ -76,14 +77,15 @@ func NewServer(config *Config) (*Server, error) {
 	}
 
 	dexServer, err := dexserver.NewDexServer(&dexserver.DexConfig{
-		Logger:       config.Logger.Session("dex"),
-		Flags:        config.Flags,
-		IssuerURL:    issuerURL,
-		WebHostURL:   issuerPath,
-		ClientID:     clientID,
-		ClientSecret: clientSecret,
-		RedirectURL:  redirectURL,
-		Storage:      config.Storage,
+		Logger:           config.Logger.Session("dex"),
+		Flags:            config.Flags,
+		IssuerURL:        issuerURL,
+		WebHostURL:       issuerPath,
+		ClientID:         clientID,
+		ClientSecret:     clientSecret,
+		RedirectURL:      redirectURL,
+		Storage:          config.Storage,
+		LocalUserFactory: config.LocalUserFactory,
 	})
 	if err != nil {
 		return nil, err
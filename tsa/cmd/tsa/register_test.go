@@ -2,6 +2,7 @@ package main_test
 
 import (
 	"context"
+	"crypto/rsa"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -21,6 +22,7 @@ import (
 	"github.com/concourse/concourse/tsa"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/ssh"
 )
 
 type registration struct {
@@ -254,6 +256,7 @@ var _ = Describe("Register", func() {
 			expectedWorkerPayload.BaggageclaimURL = registration.worker.BaggageclaimURL
 			expectedWorkerPayload.ActiveContainers = 3
 			expectedWorkerPayload.ActiveVolumes = 2
+			expectedWorkerPayload.KeyFingerprint = keyFingerprint(tsaClient.PrivateKey)
 
 			By("registering a forwarded garden address")
 			host, port, err := net.SplitHostPort(registration.worker.GardenAddr)
@@ -278,6 +281,7 @@ var _ = Describe("Register", func() {
 			expectedWorkerPayload.BaggageclaimURL = registration.worker.BaggageclaimURL
 			expectedWorkerPayload.ActiveContainers = 2
 			expectedWorkerPayload.ActiveVolumes = 1
+			expectedWorkerPayload.KeyFingerprint = keyFingerprint(tsaClient.PrivateKey)
 			Expect(registration.worker).To(Equal(expectedWorkerPayload))
 
 			By("heartbeating a forwarded garden address")
@@ -309,6 +313,7 @@ var _ = Describe("Register", func() {
 			expectedWorkerPayload.BaggageclaimURL = registration.worker.BaggageclaimURL
 			expectedWorkerPayload.ActiveContainers = 1
 			expectedWorkerPayload.ActiveVolumes = 0
+			expectedWorkerPayload.KeyFingerprint = keyFingerprint(tsaClient.PrivateKey)
 			Expect(registration.worker).To(Equal(expectedWorkerPayload))
 
 			By("having heartbeated after another interval passed")
@@ -526,6 +531,24 @@ var _ = Describe("Register", func() {
 			itSuccessfullyRegistersAndHeartbeats()
 		})
 
+		Context("when the key was added globally through the API", func() {
+			BeforeEach(func() {
+				tsaClient.PrivateKey = apiKey("", "some-api-key")
+			})
+
+			itSuccessfullyRegistersAndHeartbeats()
+		})
+
+		Context("when the key was added for a team through the API", func() {
+			BeforeEach(func() {
+				tsaClient.PrivateKey = apiKey("some-team", "some-api-key")
+			})
+
+			It("returns an error", func() {
+				Expect(<-registerErr).To(HaveOccurred())
+			})
+		})
+
 		Context("when the key is not authorized", func() {
 			BeforeEach(func() {
 				_, _, badKey, _ := generateSSHKeypair()
@@ -569,6 +592,36 @@ var _ = Describe("Register", func() {
 			})
 		})
 
+		Context("when the key was added for the same team through the API", func() {
+			BeforeEach(func() {
+				tsaClient.PrivateKey = apiKey("some-team", "some-api-key")
+			})
+
+			itSuccessfullyRegistersAndHeartbeats()
+
+			Context("when the key is removed while the worker is registered", func() {
+				It("ends the worker's connection", func() {
+					Eventually(registerDone).Should(BeClosed())
+
+					workerKeysLock.Lock()
+					workerKeys = []atc.WorkerKey{}
+					workerKeysLock.Unlock()
+
+					Eventually(registerErr, 5*time.Second).Should(Receive(HaveOccurred()))
+				})
+			})
+		})
+
+		Context("when the key was added for some other team through the API", func() {
+			BeforeEach(func() {
+				tsaClient.PrivateKey = apiKey("some-other-team", "some-api-key")
+			})
+
+			It("returns an error", func() {
+				Expect(<-registerErr).To(HaveOccurred())
+			})
+		})
+
 		Context("when the key is not authorized", func() {
 			BeforeEach(func() {
 				_, _, badKey, _ := generateSSHKeypair()
@@ -581,3 +634,28 @@ var _ = Describe("Register", func() {
 		})
 	})
 })
+
+func keyFingerprint(privateKey *rsa.PrivateKey) string {
+	publicKey, err := ssh.NewPublicKey(&privateKey.PublicKey)
+	Expect(err).NotTo(HaveOccurred())
+
+	return ssh.FingerprintSHA256(publicKey)
+}
+
+// apiKey generates a key and serves it to the TSA as though it were added
+// through the API.
+func apiKey(teamName string, name string) *rsa.PrivateKey {
+	_, _, privateKey, publicKey := generateSSHKeypair()
+
+	workerKeysLock.Lock()
+	defer workerKeysLock.Unlock()
+
+	workerKeys = append(workerKeys, atc.WorkerKey{
+		Name:        name,
+		TeamName:    teamName,
+		PublicKey:   string(ssh.MarshalAuthorizedKey(publicKey)),
+		Fingerprint: ssh.FingerprintSHA256(publicKey),
+	})
+
+	return privateKey
+}
@@ -4,12 +4,15 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
+	"encoding/json"
 	"encoding/pem"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"os/exec"
 	"path/filepath"
 	"strconv"
+	"sync"
 	"testing"
 	"time"
 
@@ -21,6 +24,7 @@ import (
 	"code.cloudfoundry.org/localip"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/tsa"
 	jwt "github.com/dgrijalva/jwt-go"
 	. "github.com/onsi/ginkgo"
@@ -80,6 +84,10 @@ var (
 	otherTeamKeyFile    string
 	otherTeamPubKeyFile string
 
+	// workerKeys are served to the TSA as the keys managed through the API
+	workerKeys     []atc.WorkerKey
+	workerKeysLock sync.Mutex
+
 	tsaRunner *ginkgomon.Runner
 	tsaClient *tsa.Client
 )
@@ -110,6 +118,18 @@ var _ = BeforeEach(func() {
 
 	atcServer = ghttp.NewServer()
 
+	workerKeysLock.Lock()
+	workerKeys = []atc.WorkerKey{}
+	workerKeysLock.Unlock()
+
+	atcServer.RouteToHandler("GET", "/api/v1/worker_keys", func(w http.ResponseWriter, r *http.Request) {
+		Expect(accessFactory.Create(r, "some-action").IsSystem()).To(BeTrue())
+		workerKeysLock.Lock()
+		defer workerKeysLock.Unlock()
+
+		json.NewEncoder(w).Encode(workerKeys)
+	})
+
 	hostKeyFile, hostPubKeyFile, _, hostPubKey = generateSSHKeypair()
 
 	globalKeyFile, _, globalKey, _ = generateSSHKeypair()
@@ -144,7 +164,7 @@ var _ = BeforeEach(func() {
 	signingKey, err := jwt.ParseRSAPrivateKeyFromPEM(rsaKeyBlob)
 	Expect(err).NotTo(HaveOccurred())
 
-	accessFactory = accessor.NewAccessFactory(&signingKey.PublicKey)
+	accessFactory = accessor.NewAccessFactory(&signingKey.PublicKey, new(dbfakes.FakeAPITokenFactory))
 
 	tsaCommand := exec.Command(
 		tsaPath,
@@ -158,6 +178,7 @@ var _ = BeforeEach(func() {
 		"--session-signing-key", sessionSigningPrivateKeyFile,
 		"--atc-url", atcServer.URL(),
 		"--heartbeat-interval", heartbeatInterval.String(),
+		"--worker-keys-refresh-interval", "1s",
 	)
 
 	tsaRunner = ginkgomon.New(ginkgomon.Config{
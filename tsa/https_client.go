@@ -0,0 +1,381 @@
+package tsa
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/hashicorp/yamux"
+)
+
+// ErrAllTLSGatewaysUnreachable is returned when all hosts reject the
+// connection.
+var ErrAllTLSGatewaysUnreachable = errors.New("all worker TLS gateways unreachable")
+
+// ErrTunnelClosed is returned when the tunnel underlying a registration goes
+// away without the gateway ending the registration.
+var ErrTunnelClosed = errors.New("tunnel to gateway closed unexpectedly")
+
+// HTTPSClient is used to communicate with a pool of gateways over HTTPS,
+// authenticating with a client certificate. It is an alternative to the SSH
+// based Client; Garden and Baggageclaim traffic is carried over a yamux
+// session on the upgraded registration connection.
+type HTTPSClient struct {
+	Hosts []string
+
+	// TLSConfig must contain the worker's client certificate and the CA used
+	// to verify the gateway.
+	TLSConfig *tls.Config
+
+	Worker atc.Worker
+}
+
+// Register calls the forward-worker endpoint, upgrading the connection to a
+// tunnel over which the gateway opens a stream for each connection to Garden
+// or Baggageclaim, along with a stream of registration events. The gateway
+// will continuously heartbeat the worker.
+//
+// If the context is canceled, the gateway is told to stop heartbeating and
+// will close the tunnel once forwarded connections have drained. If a
+// ConnectionDrainTimeout is configured, the connection will be terminated
+// after no data has gone to/from the gateway for the configured duration.
+func (client *HTTPSClient) Register(ctx context.Context, opts RegisterOptions) error {
+	logger := lagerctx.FromContext(ctx)
+
+	conn, err := client.dialTunnel(ctx, opts.ConnectionDrainTimeout)
+	if err != nil {
+		logger.Error("failed-to-dial", err)
+		return err
+	}
+
+	defer conn.Close()
+
+	session, err := yamux.Server(conn, TunnelConfig())
+	if err != nil {
+		logger.Error("failed-to-start-session", err)
+		return err
+	}
+
+	defer session.Close()
+
+	go client.keepAlive(ctx, session)
+
+	eventStreams := make(chan net.Conn, 1)
+	go client.acceptStreams(ctx, session, opts, eventStreams)
+
+	var events net.Conn
+	select {
+	case events = <-eventStreams:
+	case <-session.CloseChan():
+		return ErrTunnelClosed
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			logger.Info("draining")
+
+			err := WriteTunnelMessage(events, TunnelDrain)
+			if err != nil {
+				logger.Error("failed-to-send-drain", err)
+			}
+
+		case <-session.CloseChan():
+		}
+	}()
+
+	reader := NewEventReader(events)
+	for {
+		ev, err := reader.Next()
+		if err != nil {
+			break
+		}
+
+		switch ev.Type {
+		case EventTypeRegistered:
+			if opts.RegisteredFunc != nil {
+				opts.RegisteredFunc()
+			}
+
+		case EventTypeHeartbeated:
+			if opts.HeartbeatedFunc != nil {
+				opts.HeartbeatedFunc()
+			}
+		}
+	}
+
+	// the gateway closes the event stream and then waits for the worker to
+	// close the session, so if the session is already gone the tunnel broke
+	if !session.IsClosed() {
+		logger.Debug("registration-ended")
+		return nil
+	}
+
+	if ctx.Err() != nil && opts.ConnectionDrainTimeout != 0 {
+		return ErrConnectionDrainTimeout
+	}
+
+	return ErrTunnelClosed
+}
+
+// Land calls the land-worker endpoint, which will initiate the landing
+// process for the worker.
+func (client *HTTPSClient) Land(ctx context.Context) error {
+	return client.do(ctx, HTTPSLandWorkerPath, nil, nil)
+}
+
+// Retire calls the retire-worker endpoint, which will initiate the retiring
+// process for the worker.
+func (client *HTTPSClient) Retire(ctx context.Context) error {
+	return client.do(ctx, HTTPSRetireWorkerPath, nil, nil)
+}
+
+// Delete calls the delete-worker endpoint, which will immediately unregister
+// the worker without draining.
+func (client *HTTPSClient) Delete(ctx context.Context) error {
+	return client.do(ctx, HTTPSDeleteWorkerPath, nil, nil)
+}
+
+// ContainersToDestroy calls the sweep-containers endpoint, returning a list of
+// handles to be destroyed.
+func (client *HTTPSClient) ContainersToDestroy(ctx context.Context) ([]string, error) {
+	var handles []string
+	err := client.do(ctx, HTTPSSweepContainersPath, nil, &handles)
+	if err != nil {
+		return nil, err
+	}
+
+	return handles, nil
+}
+
+// ReportContainers calls the report-containers endpoint, sending a list of
+// the worker's container handles to Concourse.
+func (client *HTTPSClient) ReportContainers(ctx context.Context, handles []string) error {
+	return client.do(ctx, HTTPSReportContainersPath, handles, nil)
+}
+
+// VolumesToDestroy calls the sweep-volumes endpoint, returning a list of
+// handles to be destroyed.
+func (client *HTTPSClient) VolumesToDestroy(ctx context.Context) ([]string, error) {
+	var handles []string
+	err := client.do(ctx, HTTPSSweepVolumesPath, nil, &handles)
+	if err != nil {
+		return nil, err
+	}
+
+	return handles, nil
+}
+
+// ReportVolumes calls the report-volumes endpoint, sending a list of the
+// worker's volume handles to Concourse.
+func (client *HTTPSClient) ReportVolumes(ctx context.Context, handles []string) error {
+	return client.do(ctx, HTTPSReportVolumesPath, handles, nil)
+}
+
+func (client *HTTPSClient) do(ctx context.Context, path string, handles []string, response interface{}) error {
+	logger := lagerctx.WithSession(ctx, "request", lager.Data{
+		"path": path,
+	})
+
+	payload, err := json.Marshal(WorkerRequest{
+		Worker:  client.Worker,
+		Handles: handles,
+	})
+	if err != nil {
+		return err
+	}
+
+	httpClient := &http.Client{
+		Timeout: 1 * time.Minute,
+		Transport: &http.Transport{
+			TLSClientConfig: client.TLSConfig,
+		},
+	}
+
+	for _, host := range client.shuffledHosts() {
+		req, err := http.NewRequest("POST", "https://"+host+path, bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := httpClient.Do(req.WithContext(ctx))
+		if err != nil {
+			logger.Error("failed-to-connect-to-gateway", err)
+			continue
+		}
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			body, _ := ioutil.ReadAll(resp.Body)
+			return fmt.Errorf("bad response (%d): %s", resp.StatusCode, bytes.TrimSpace(body))
+		}
+
+		if response == nil {
+			return nil
+		}
+
+		err = json.NewDecoder(resp.Body).Decode(response)
+		if err != nil {
+			logger.Error("failed-to-decode-response", err)
+			return err
+		}
+
+		return nil
+	}
+
+	return ErrAllTLSGatewaysUnreachable
+}
+
+func (client *HTTPSClient) dialTunnel(ctx context.Context, idleTimeout time.Duration) (net.Conn, error) {
+	logger := lagerctx.WithSession(ctx, "dial")
+
+	payload, err := json.Marshal(WorkerRequest{Worker: client.Worker})
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 15 * time.Second,
+	}
+
+	tlsConfig := client.TLSConfig.Clone()
+	tlsConfig.NextProtos = []string{"http/1.1"}
+
+	for _, host := range client.shuffledHosts() {
+		tlsConn, err := tls.DialWithDialer(dialer, "tcp", host, tlsConfig)
+		if err != nil {
+			logger.Error("failed-to-connect-to-gateway", err)
+			continue
+		}
+
+		var conn net.Conn = tlsConn
+		if idleTimeout != 0 {
+			conn = &timeoutConn{
+				Conn:        tlsConn,
+				IdleTimeout: idleTimeout,
+			}
+		}
+
+		req, err := http.NewRequest("POST", "https://"+host+HTTPSForwardWorkerPath, bytes.NewReader(payload))
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Connection", "Upgrade")
+		req.Header.Set("Upgrade", TunnelProtocol)
+
+		err = req.Write(conn)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+
+		reader := bufio.NewReader(conn)
+
+		resp, err := http.ReadResponse(reader, req)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusSwitchingProtocols {
+			body, _ := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			conn.Close()
+			return nil, fmt.Errorf("bad response (%d): %s", resp.StatusCode, bytes.TrimSpace(body))
+		}
+
+		return &BufferedConn{Conn: conn, Reader: reader}, nil
+	}
+
+	return nil, ErrAllTLSGatewaysUnreachable
+}
+
+func (client *HTTPSClient) acceptStreams(ctx context.Context, session *yamux.Session, opts RegisterOptions, events chan<- net.Conn) {
+	logger := lagerctx.WithSession(ctx, "accept-streams")
+
+	for {
+		stream, err := session.Accept()
+		if err != nil {
+			return
+		}
+
+		kind, err := ReadTunnelMessage(stream)
+		if err != nil {
+			logger.Error("failed-to-read-stream-kind", err)
+			stream.Close()
+			continue
+		}
+
+		switch kind {
+		case TunnelStreamGarden:
+			go handleForwardedConn(ctx, stream, opts.LocalGardenNetwork, opts.LocalGardenAddr)
+
+		case TunnelStreamBaggageclaim:
+			go handleForwardedConn(ctx, stream, opts.LocalBaggageclaimNetwork, opts.LocalBaggageclaimAddr)
+
+		case TunnelStreamEvents:
+			select {
+			case events <- stream:
+			default:
+				logger.Info("ignoring-extra-event-stream")
+				stream.Close()
+			}
+
+		default:
+			logger.Info("ignoring-unknown-stream", lager.Data{"kind": kind})
+			stream.Close()
+		}
+	}
+}
+
+func (client *HTTPSClient) keepAlive(ctx context.Context, session *yamux.Session) {
+	logger := lagerctx.WithSession(ctx, "keepalive")
+
+	kas := time.NewTicker(5 * time.Second)
+	defer kas.Stop()
+
+	for {
+		_, err := session.Ping()
+		if err != nil {
+			logger.Error("failed", err)
+			return
+		}
+
+		select {
+		case <-kas.C:
+			logger.Debug("tick")
+
+		case <-ctx.Done():
+			// stop sending keepalives so that the connection can go idle once
+			// forwarded connections have drained
+			logger.Debug("stopping")
+			return
+		}
+	}
+}
+
+func (client *HTTPSClient) shuffledHosts() []string {
+	shuffled := make([]string, len(client.Hosts))
+	copy(shuffled, client.Hosts)
+	shuffle(sort.StringSlice(shuffled))
+	return shuffled
+}
@@ -0,0 +1,268 @@
+package tsa_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/tsa"
+	"github.com/hashicorp/yamux"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("HTTPSClient", func() {
+	var (
+		ctx    context.Context
+		worker atc.Worker
+
+		client *tsa.HTTPSClient
+	)
+
+	tlsConfigFor := func(server *httptest.Server) *tls.Config {
+		pool := x509.NewCertPool()
+		pool.AddCert(server.Certificate())
+		return &tls.Config{RootCAs: pool}
+	}
+
+	hostOf := func(server *httptest.Server) string {
+		serverURL, err := url.Parse(server.URL)
+		Expect(err).ToNot(HaveOccurred())
+		return serverURL.Host
+	}
+
+	BeforeEach(func() {
+		ctx = lagerctx.NewContext(context.Background(), lagertest.NewTestLogger("test"))
+		worker = atc.Worker{Name: "some-worker", Team: "some-team"}
+	})
+
+	Describe("API calls", func() {
+		var gateway *ghttp.Server
+
+		BeforeEach(func() {
+			gateway = ghttp.NewTLSServer()
+
+			client = &tsa.HTTPSClient{
+				Hosts:     []string{hostOf(gateway.HTTPTestServer)},
+				TLSConfig: tlsConfigFor(gateway.HTTPTestServer),
+				Worker:    worker,
+			}
+		})
+
+		AfterEach(func() {
+			gateway.Close()
+		})
+
+		It("lands the worker", func() {
+			gateway.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", tsa.HTTPSLandWorkerPath),
+				ghttp.VerifyJSONRepresenting(tsa.WorkerRequest{Worker: worker}),
+				ghttp.RespondWith(http.StatusOK, nil),
+			))
+
+			Expect(client.Land(ctx)).To(Succeed())
+			Expect(gateway.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("reports the worker's volumes", func() {
+			gateway.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", tsa.HTTPSReportVolumesPath),
+				ghttp.VerifyJSONRepresenting(tsa.WorkerRequest{
+					Worker:  worker,
+					Handles: []string{"some-handle", "other-handle"},
+				}),
+				ghttp.RespondWith(http.StatusOK, nil),
+			))
+
+			Expect(client.ReportVolumes(ctx, []string{"some-handle", "other-handle"})).To(Succeed())
+		})
+
+		It("returns the containers to destroy", func() {
+			gateway.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", tsa.HTTPSSweepContainersPath),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, []string{"some-handle"}),
+			))
+
+			Expect(client.ContainersToDestroy(ctx)).To(Equal([]string{"some-handle"}))
+		})
+
+		It("returns an error when the gateway fails", func() {
+			gateway.AppendHandlers(ghttp.RespondWith(http.StatusForbidden, "nope"))
+
+			err := client.Retire(ctx)
+			Expect(err).To(MatchError("bad response (403): nope"))
+		})
+
+		Context("when no gateway can be reached", func() {
+			BeforeEach(func() {
+				client.Hosts = []string{"127.0.0.1:1"}
+			})
+
+			It("returns ErrAllTLSGatewaysUnreachable", func() {
+				Expect(client.Delete(ctx)).To(Equal(tsa.ErrAllTLSGatewaysUnreachable))
+			})
+		})
+	})
+
+	Describe("Register", func() {
+		var (
+			gateway  *httptest.Server
+			sessions chan *yamux.Session
+			requests chan tsa.WorkerRequest
+
+			gardenListener net.Listener
+		)
+
+		BeforeEach(func() {
+			sessions = make(chan *yamux.Session, 1)
+			requests = make(chan tsa.WorkerRequest, 1)
+
+			gateway = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+
+				Expect(r.URL.Path).To(Equal(tsa.HTTPSForwardWorkerPath))
+				Expect(r.Header.Get("Upgrade")).To(Equal(tsa.TunnelProtocol))
+
+				var req tsa.WorkerRequest
+				Expect(json.NewDecoder(r.Body).Decode(&req)).To(Succeed())
+				requests <- req
+
+				conn, buf, err := w.(http.Hijacker).Hijack()
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = buf.WriteString("HTTP/1.1 101 Switching Protocols\r\n\r\n")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(buf.Flush()).To(Succeed())
+
+				session, err := yamux.Client(&tsa.BufferedConn{Conn: conn, Reader: buf.Reader}, tsa.TunnelConfig())
+				Expect(err).ToNot(HaveOccurred())
+
+				sessions <- session
+			}))
+
+			var err error
+			gardenListener, err = net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).ToNot(HaveOccurred())
+
+			client = &tsa.HTTPSClient{
+				Hosts:     []string{hostOf(gateway)},
+				TLSConfig: tlsConfigFor(gateway),
+				Worker:    worker,
+			}
+		})
+
+		AfterEach(func() {
+			gardenListener.Close()
+			gateway.Close()
+		})
+
+		openStream := func(session *yamux.Session, kind string) net.Conn {
+			stream, err := session.Open()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tsa.WriteTunnelMessage(stream, kind)).To(Succeed())
+			return stream
+		}
+
+		It("forwards streams to the local servers until the gateway ends the registration", func() {
+			registered := make(chan struct{})
+			errs := make(chan error, 1)
+
+			go func() {
+				errs <- client.Register(ctx, tsa.RegisterOptions{
+					LocalGardenNetwork: "tcp",
+					LocalGardenAddr:    gardenListener.Addr().String(),
+					RegisteredFunc: func() {
+						close(registered)
+					},
+				})
+			}()
+
+			Eventually(requests).Should(Receive(Equal(tsa.WorkerRequest{Worker: worker})))
+
+			var session *yamux.Session
+			Eventually(sessions).Should(Receive(&session))
+
+			events := openStream(session, tsa.TunnelStreamEvents)
+			Expect(tsa.NewEventWriter(events).Registered()).To(Succeed())
+			Eventually(registered).Should(BeClosed())
+
+			garden := openStream(session, tsa.TunnelStreamGarden)
+			_, err := garden.Write([]byte("hello"))
+			Expect(err).ToNot(HaveOccurred())
+
+			localConn, err := gardenListener.Accept()
+			Expect(err).ToNot(HaveOccurred())
+
+			buf := make([]byte, 5)
+			_, err = localConn.Read(buf)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(buf)).To(Equal("hello"))
+
+			Expect(events.Close()).To(Succeed())
+
+			Eventually(errs).Should(Receive(BeNil()))
+			Eventually(session.CloseChan()).Should(BeClosed())
+		})
+
+		It("asks the gateway to drain when the context is canceled", func() {
+			ctx, cancel := context.WithCancel(ctx)
+
+			errs := make(chan error, 1)
+			go func() {
+				errs <- client.Register(ctx, tsa.RegisterOptions{})
+			}()
+
+			var session *yamux.Session
+			Eventually(sessions).Should(Receive(&session))
+
+			events := openStream(session, tsa.TunnelStreamEvents)
+
+			cancel()
+
+			Expect(tsa.ReadTunnelMessage(events)).To(Equal(tsa.TunnelDrain))
+			Consistently(errs).ShouldNot(Receive())
+
+			Expect(events.Close()).To(Succeed())
+			Eventually(errs).Should(Receive(BeNil()))
+		})
+
+		It("returns ErrTunnelClosed when the tunnel breaks", func() {
+			errs := make(chan error, 1)
+			go func() {
+				errs <- client.Register(ctx, tsa.RegisterOptions{})
+			}()
+
+			var session *yamux.Session
+			Eventually(sessions).Should(Receive(&session))
+
+			openStream(session, tsa.TunnelStreamEvents)
+			Expect(session.Close()).To(Succeed())
+
+			Eventually(errs).Should(Receive(Equal(tsa.ErrTunnelClosed)))
+		})
+
+		Context("when the gateway refuses the registration", func() {
+			BeforeEach(func() {
+				gateway.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					ioutil.ReadAll(r.Body)
+					http.Error(w, "not authorized", http.StatusForbidden)
+				})
+			})
+
+			It("returns the error", func() {
+				err := client.Register(ctx, tsa.RegisterOptions{})
+				Expect(err).To(MatchError("bad response (403): not authorized"))
+			})
+		})
+	})
+})
@@ -0,0 +1,68 @@
+package tsa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/tedsuo/rata"
+)
+
+// KeyFetcher lists the worker keys managed through the ATC's API, so that
+// workers can be authorized without every key being configured up front.
+type KeyFetcher struct {
+	ATCEndpoint    *rata.RequestGenerator
+	TokenGenerator TokenGenerator
+}
+
+func (f *KeyFetcher) FetchKeys(ctx context.Context) ([]atc.WorkerKey, error) {
+	logger := lagerctx.FromContext(ctx)
+
+	logger.Debug("start")
+	defer logger.Debug("end")
+
+	request, err := f.ATCEndpoint.CreateRequest(atc.ListWorkerKeys, nil, nil)
+	if err != nil {
+		logger.Error("failed-to-construct-request", err)
+		return nil, err
+	}
+
+	jwtToken, err := f.TokenGenerator.GenerateSystemToken()
+	if err != nil {
+		logger.Error("failed-to-generate-token", err)
+		return nil, err
+	}
+
+	request.Header.Add("Authorization", "Bearer "+jwtToken)
+
+	response, err := http.DefaultClient.Do(request.WithContext(ctx))
+	if err != nil {
+		logger.Error("failed-to-fetch-keys", err)
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		logger.Error("bad-response", nil, lager.Data{
+			"status-code": response.StatusCode,
+		})
+
+		b, _ := httputil.DumpResponse(response, true)
+		return nil, fmt.Errorf("bad-response (%d): %s", response.StatusCode, string(b))
+	}
+
+	var keys []atc.WorkerKey
+	err = json.NewDecoder(response.Body).Decode(&keys)
+	if err != nil {
+		logger.Error("failed-to-decode-keys", err)
+		return nil, err
+	}
+
+	return keys, nil
+}
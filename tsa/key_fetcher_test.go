@@ -0,0 +1,70 @@
+package tsa_test
+
+import (
+	"context"
+	"net/http"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/tsa"
+	"github.com/concourse/concourse/tsa/tsafakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/tedsuo/rata"
+)
+
+var _ = Describe("KeyFetcher", func() {
+	var (
+		fetcher *tsa.KeyFetcher
+
+		ctx                context.Context
+		fakeTokenGenerator *tsafakes.FakeTokenGenerator
+		fakeATC            *ghttp.Server
+	)
+
+	BeforeEach(func() {
+		ctx = lagerctx.NewContext(context.Background(), lagertest.NewTestLogger("test"))
+
+		fakeTokenGenerator = new(tsafakes.FakeTokenGenerator)
+		fakeTokenGenerator.GenerateSystemTokenReturns("yo", nil)
+
+		fakeATC = ghttp.NewServer()
+
+		fetcher = &tsa.KeyFetcher{
+			ATCEndpoint:    rata.NewRequestGenerator(fakeATC.URL(), atc.Routes),
+			TokenGenerator: fakeTokenGenerator,
+		}
+	})
+
+	AfterEach(func() {
+		fakeATC.Close()
+	})
+
+	It("lists the worker keys with a system token", func() {
+		keys := []atc.WorkerKey{
+			{Name: "global-key", PublicKey: "ssh-ed25519 AAAA", Fingerprint: "SHA256:global"},
+			{Name: "team-key", TeamName: "some-team", PublicKey: "ssh-ed25519 BBBB", Fingerprint: "SHA256:team"},
+		}
+
+		fakeATC.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", "/api/v1/worker_keys"),
+			ghttp.VerifyHeaderKV("Authorization", "Bearer yo"),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, keys),
+		))
+
+		Expect(fetcher.FetchKeys(ctx)).To(Equal(keys))
+	})
+
+	Context("when the ATC responds with an error", func() {
+		BeforeEach(func() {
+			fakeATC.AppendHandlers(ghttp.RespondWith(http.StatusForbidden, nil))
+		})
+
+		It("returns an error", func() {
+			_, err := fetcher.FetchKeys(ctx)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
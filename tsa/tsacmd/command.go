@@ -2,7 +2,11 @@ package tsacmd
 
 import (
 	"bytes"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"sync"
@@ -38,6 +42,15 @@ type TSACommand struct {
 	SessionSigningKey *flag.PrivateKey `long:"session-signing-key" required:"true" description:"Path to private key to use when signing tokens in reqests to the ATC during registration."`
 
 	HeartbeatInterval time.Duration `long:"heartbeat-interval" default:"30s" description:"interval on which to heartbeat workers to the ATC"`
+
+	WorkerKeysRefreshInterval time.Duration `long:"worker-keys-refresh-interval" default:"10s" description:"Interval on which to fetch the worker keys managed through the ATC's API."`
+	WorkerKeysMaxStaleness    time.Duration `long:"worker-keys-max-staleness"    default:"5m"  description:"How long the worker keys managed through the ATC's API remain valid when they cannot be fetched again."`
+
+	TLSBindPort       uint16               `long:"tls-bind-port" description:"Port on which to listen for workers registering over HTTPS with a client certificate. Disabled unless specified."`
+	TLSCert           flag.File            `long:"tls-cert" description:"File containing the certificate to present to workers registering over HTTPS."`
+	TLSKey            flag.File            `long:"tls-key" description:"File containing the private key for the certificate presented to workers registering over HTTPS."`
+	ClientCACert      flag.File            `long:"client-ca-cert" description:"File containing CA certificates which issue client certificates to workers registering over HTTPS."`
+	TeamClientCACerts map[string]flag.File `long:"team-client-ca-cert" value-name:"NAME:PATH" description:"File containing CA certificates which issue client certificates to workers of the team registering over HTTPS."`
 }
 
 type TeamAuthKeys struct {
@@ -88,22 +101,35 @@ func (cmd *TSACommand) Runner(args []string) (ifrit.Runner, error) {
 
 	sessionAuthTeam := &sessionTeam{
 		sessionTeams: make(map[string]string),
+		sessionKeys:  make(map[string]string),
+		sessionConns: make(map[string]io.Closer),
 		lock:         &sync.RWMutex{},
 	}
 
-	config, err := cmd.configureSSHServer(sessionAuthTeam, cmd.AuthorizedKeys.Keys, teamAuthorizedKeys)
-	if err != nil {
-		return nil, fmt.Errorf("failed to configure SSH server: %s", err)
-	}
-
-	listenAddr := fmt.Sprintf("%s:%d", cmd.BindIP, cmd.BindPort)
-
 	if cmd.SessionSigningKey == nil {
 		return nil, fmt.Errorf("missing session signing key")
 	}
 
 	tokenGenerator := tsa.NewTokenGenerator(cmd.SessionSigningKey.PrivateKey)
 
+	keyStore := &workerKeyStore{
+		logger:            logger,
+		atcEndpointPicker: atcEndpointPicker,
+		tokenGenerator:    tokenGenerator,
+		refreshInterval:   cmd.WorkerKeysRefreshInterval,
+		maxStaleness:      cmd.WorkerKeysMaxStaleness,
+		revoke: func(fingerprints []string) {
+			sessionAuthTeam.RevokeKeys(revocableKeys(fingerprints, cmd.AuthorizedKeys.Keys, teamAuthorizedKeys))
+		},
+	}
+
+	config, err := cmd.configureSSHServer(sessionAuthTeam, cmd.AuthorizedKeys.Keys, teamAuthorizedKeys, keyStore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure SSH server: %s", err)
+	}
+
+	listenAddr := fmt.Sprintf("%s:%d", cmd.BindIP, cmd.BindPort)
+
 	server := &server{
 		logger:            logger,
 		heartbeatInterval: cmd.HeartbeatInterval,
@@ -116,7 +142,114 @@ func (cmd *TSACommand) Runner(args []string) (ifrit.Runner, error) {
 		sessionTeam:       sessionAuthTeam,
 	}
 
-	return serverRunner{logger, server, listenAddr}, nil
+	members := grouper.Members{
+		{Name: "ssh", Runner: serverRunner{logger, server, listenAddr}},
+		{Name: "worker-keys", Runner: keyStore},
+	}
+
+	if cmd.TLSBindPort != 0 {
+		httpsRunner, err := cmd.httpsRunner(server)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure HTTPS server: %s", err)
+		}
+
+		members = append(members, grouper.Member{Name: "https", Runner: httpsRunner})
+	}
+
+	return grouper.NewParallel(os.Interrupt, members), nil
+}
+
+// revocableKeys filters out the keys which are also authorized through
+// flags, as sessions authenticated with them remain valid.
+func revocableKeys(fingerprints []string, authorizedKeys []ssh.PublicKey, teamAuthorizedKeys []TeamAuthKeys) []string {
+	static := map[string]bool{}
+	for _, key := range authorizedKeys {
+		static[ssh.FingerprintSHA256(key)] = true
+	}
+
+	for _, teamKeys := range teamAuthorizedKeys {
+		for _, key := range teamKeys.AuthKeys {
+			static[ssh.FingerprintSHA256(key)] = true
+		}
+	}
+
+	revocable := []string{}
+	for _, fingerprint := range fingerprints {
+		if !static[fingerprint] {
+			revocable = append(revocable, fingerprint)
+		}
+	}
+
+	return revocable
+}
+
+func (cmd *TSACommand) httpsRunner(server *server) (ifrit.Runner, error) {
+	if cmd.TLSCert == "" || cmd.TLSKey == "" {
+		return nil, fmt.Errorf("tls-cert and tls-key must be specified along with tls-bind-port")
+	}
+
+	if cmd.ClientCACert == "" && len(cmd.TeamClientCACerts) == 0 {
+		return nil, fmt.Errorf("client-ca-cert or team-client-ca-cert must be specified along with tls-bind-port")
+	}
+
+	cert, err := tls.LoadX509KeyPair(string(cmd.TLSCert), string(cmd.TLSKey))
+	if err != nil {
+		return nil, err
+	}
+
+	// the TLS handshake accepts certificates issued by any of the CAs; the
+	// handlers then determine which team, if any, the certificate is for
+	allCAs := x509.NewCertPool()
+
+	var clientCAs *x509.CertPool
+	if cmd.ClientCACert != "" {
+		clientCAs, err = loadCertPool(allCAs, cmd.ClientCACert)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	teamClientCAs := map[string]*x509.CertPool{}
+	for team, caCert := range cmd.TeamClientCACerts {
+		teamClientCAs[team], err = loadCertPool(allCAs, caCert)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    allCAs,
+		MinVersion:   tls.VersionTLS12,
+		// the forward-worker request is upgraded to a tunnel, which HTTP/2
+		// does not support
+		NextProtos: []string{"http/1.1"},
+	}
+
+	handler := (&httpsServer{
+		server:        server,
+		clientCAs:     clientCAs,
+		teamClientCAs: teamClientCAs,
+	}).Handler()
+
+	return http_server.NewTLSServer(cmd.tlsBindAddr(), handler, tlsConfig), nil
+}
+
+func loadCertPool(allCAs *x509.CertPool, path flag.File) (*x509.CertPool, error) {
+	pem, err := ioutil.ReadFile(string(path))
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %s", path)
+	}
+
+	allCAs.AppendCertsFromPEM(pem)
+
+	return pool, nil
 }
 
 func (cmd *TSACommand) constructLogger() (lager.Logger, *lager.ReconfigurableSink) {
@@ -138,7 +271,7 @@ func (cmd *TSACommand) loadTeamAuthorizedKeys() ([]TeamAuthKeys, error) {
 	return teamKeys, nil
 }
 
-func (cmd *TSACommand) configureSSHServer(sessionAuthTeam *sessionTeam, authorizedKeys []ssh.PublicKey, teamAuthorizedKeys []TeamAuthKeys) (*ssh.ServerConfig, error) {
+func (cmd *TSACommand) configureSSHServer(sessionAuthTeam *sessionTeam, authorizedKeys []ssh.PublicKey, teamAuthorizedKeys []TeamAuthKeys, keyStore *workerKeyStore) (*ssh.ServerConfig, error) {
 	certChecker := &ssh.CertChecker{
 		IsUserAuthority: func(key ssh.PublicKey) bool {
 			return false
@@ -149,8 +282,11 @@ func (cmd *TSACommand) configureSSHServer(sessionAuthTeam *sessionTeam, authoriz
 		},
 
 		UserKeyFallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			sessionID := string(conn.SessionID())
+
 			for _, k := range authorizedKeys {
 				if bytes.Equal(k.Marshal(), key.Marshal()) {
+					sessionAuthTeam.RecordKey(sessionID, key)
 					return nil, nil
 				}
 			}
@@ -158,12 +294,24 @@ func (cmd *TSACommand) configureSSHServer(sessionAuthTeam *sessionTeam, authoriz
 			for _, teamKeys := range teamAuthorizedKeys {
 				for _, k := range teamKeys.AuthKeys {
 					if bytes.Equal(k.Marshal(), key.Marshal()) {
-						sessionAuthTeam.AuthorizeTeam(string(conn.SessionID()), teamKeys.Team)
+						sessionAuthTeam.AuthorizeTeam(sessionID, teamKeys.Team)
+						sessionAuthTeam.RecordKey(sessionID, key)
 						return nil, nil
 					}
 				}
 			}
 
+			// keys added through the API are checked last, as they may need to
+			// be fetched from the ATC
+			if team, found := keyStore.Lookup(key); found {
+				if team != "" {
+					sessionAuthTeam.AuthorizeTeam(sessionID, team)
+				}
+
+				sessionAuthTeam.RecordKey(sessionID, key)
+				return nil, nil
+			}
+
 			return nil, fmt.Errorf("unknown public key")
 		},
 	}
@@ -185,6 +333,10 @@ func (cmd *TSACommand) configureSSHServer(sessionAuthTeam *sessionTeam, authoriz
 	return config, nil
 }
 
+func (cmd *TSACommand) tlsBindAddr() string {
+	return fmt.Sprintf("%s:%d", cmd.BindIP, cmd.TLSBindPort)
+}
+
 func (cmd *TSACommand) debugBindAddr() string {
 	return fmt.Sprintf("%s:%d", cmd.DebugBindIP, cmd.DebugBindPort)
 }
@@ -0,0 +1,505 @@
+package tsacmd
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	gclient "code.cloudfoundry.org/garden/client"
+	gconn "code.cloudfoundry.org/garden/client/connection"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	bclient "github.com/concourse/baggageclaim/client"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/tsa"
+	"github.com/hashicorp/yamux"
+)
+
+// how long to wait for the worker to close the tunnel once the registration
+// has ended, before closing it from this end
+const tunnelCloseTimeout = 10 * time.Second
+
+// httpsServer serves the same commands as the SSH server to workers which
+// authenticate with a client certificate.
+type httpsServer struct {
+	*server
+
+	clientCAs     *x509.CertPool
+	teamClientCAs map[string]*x509.CertPool
+}
+
+func (server *httpsServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(tsa.HTTPSForwardWorkerPath, server.forwardWorker)
+	mux.HandleFunc(tsa.HTTPSLandWorkerPath, server.landWorker)
+	mux.HandleFunc(tsa.HTTPSRetireWorkerPath, server.retireWorker)
+	mux.HandleFunc(tsa.HTTPSDeleteWorkerPath, server.deleteWorker)
+	mux.HandleFunc(tsa.HTTPSSweepContainersPath, server.sweepContainers)
+	mux.HandleFunc(tsa.HTTPSReportContainersPath, server.reportContainers)
+	mux.HandleFunc(tsa.HTTPSSweepVolumesPath, server.sweepVolumes)
+	mux.HandleFunc(tsa.HTTPSReportVolumesPath, server.reportVolumes)
+	return mux
+}
+
+// authorizedTeam determines the team the client certificate is authorized
+// for, by finding which of the configured CAs issued it. Certificates issued
+// by the global CA can be used for all teams.
+func (server *httpsServer) authorizedTeam(r *http.Request) (string, error) {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return "", errors.New("no client certificate provided")
+	}
+
+	cert := r.TLS.PeerCertificates[0]
+
+	intermediates := x509.NewCertPool()
+	for _, c := range r.TLS.PeerCertificates[1:] {
+		intermediates.AddCert(c)
+	}
+
+	verifies := func(roots *x509.CertPool) bool {
+		_, err := cert.Verify(x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: intermediates,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		})
+		return err == nil
+	}
+
+	if server.clientCAs != nil && verifies(server.clientCAs) {
+		return "", nil
+	}
+
+	teams := []string{}
+	for team := range server.teamClientCAs {
+		teams = append(teams, team)
+	}
+
+	sort.Strings(teams)
+
+	for _, team := range teams {
+		if verifies(server.teamClientCAs[team]) {
+			return team, nil
+		}
+	}
+
+	return "", errors.New("client certificate is not authorized")
+}
+
+// readRequest decodes the worker request and checks that the client is
+// authorized for the worker's team, responding with an error if not.
+func (server *httpsServer) readRequest(w http.ResponseWriter, r *http.Request) (context.Context, tsa.WorkerRequest, bool) {
+	logger := server.logger.Session("request", lager.Data{
+		"path":   r.URL.Path,
+		"remote": r.RemoteAddr,
+	})
+
+	var req tsa.WorkerRequest
+
+	if r.Method != "POST" {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return nil, req, false
+	}
+
+	team, err := server.authorizedTeam(r)
+	if err != nil {
+		logger.Info("unauthorized", lager.Data{"error": err.Error()})
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return nil, req, false
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Error("malformed-request", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, req, false
+	}
+
+	err = checkTeam(ConnState{Team: team}, req.Worker)
+	if err != nil {
+		logger.Info("unauthorized", lager.Data{"error": err.Error()})
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return nil, req, false
+	}
+
+	return lagerctx.NewContext(r.Context(), logger), req, true
+}
+
+func (server *httpsServer) respond(ctx context.Context, w http.ResponseWriter, err error) {
+	if err != nil {
+		lagerctx.FromContext(ctx).Error("failed", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (server *httpsServer) landWorker(w http.ResponseWriter, r *http.Request) {
+	ctx, req, ok := server.readRequest(w, r)
+	if !ok {
+		return
+	}
+
+	server.respond(ctx, w, (&tsa.Lander{
+		ATCEndpoint:    server.atcEndpointPicker.Pick(),
+		TokenGenerator: server.tokenGenerator,
+	}).Land(ctx, req.Worker))
+}
+
+func (server *httpsServer) retireWorker(w http.ResponseWriter, r *http.Request) {
+	ctx, req, ok := server.readRequest(w, r)
+	if !ok {
+		return
+	}
+
+	server.respond(ctx, w, (&tsa.Retirer{
+		ATCEndpoint:    server.atcEndpointPicker.Pick(),
+		TokenGenerator: server.tokenGenerator,
+	}).Retire(ctx, req.Worker))
+}
+
+func (server *httpsServer) deleteWorker(w http.ResponseWriter, r *http.Request) {
+	ctx, req, ok := server.readRequest(w, r)
+	if !ok {
+		return
+	}
+
+	server.respond(ctx, w, (&tsa.Deleter{
+		ATCEndpoint:    server.atcEndpointPicker.Pick(),
+		TokenGenerator: server.tokenGenerator,
+	}).Delete(ctx, req.Worker))
+}
+
+func (server *httpsServer) sweepContainers(w http.ResponseWriter, r *http.Request) {
+	server.sweep(w, r, tsa.SweepContainers)
+}
+
+func (server *httpsServer) sweepVolumes(w http.ResponseWriter, r *http.Request) {
+	server.sweep(w, r, tsa.SweepVolumes)
+}
+
+func (server *httpsServer) sweep(w http.ResponseWriter, r *http.Request, resourceAction string) {
+	ctx, req, ok := server.readRequest(w, r)
+	if !ok {
+		return
+	}
+
+	handles, err := (&tsa.Sweeper{
+		ATCEndpoint:    server.atcEndpointPicker.Pick(),
+		TokenGenerator: server.tokenGenerator,
+	}).Sweep(ctx, req.Worker, resourceAction)
+	if err != nil {
+		server.respond(ctx, w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(handles)
+}
+
+func (server *httpsServer) reportContainers(w http.ResponseWriter, r *http.Request) {
+	ctx, req, ok := server.readRequest(w, r)
+	if !ok {
+		return
+	}
+
+	server.respond(ctx, w, (&tsa.WorkerStatus{
+		ATCEndpoint:      server.atcEndpointPicker.Pick(),
+		TokenGenerator:   server.tokenGenerator,
+		ContainerHandles: req.Handles,
+	}).WorkerStatus(ctx, req.Worker, tsa.ReportContainers))
+}
+
+func (server *httpsServer) reportVolumes(w http.ResponseWriter, r *http.Request) {
+	ctx, req, ok := server.readRequest(w, r)
+	if !ok {
+		return
+	}
+
+	server.respond(ctx, w, (&tsa.WorkerStatus{
+		ATCEndpoint:    server.atcEndpointPicker.Pick(),
+		TokenGenerator: server.tokenGenerator,
+		VolumeHandles:  req.Handles,
+	}).WorkerStatus(ctx, req.Worker, tsa.ReportVolumes))
+}
+
+// forwardWorker upgrades the connection to a yamux session and forwards
+// connections to Garden and Baggageclaim over it while heartbeating the
+// worker, much like the SSH server's forward-worker command.
+func (server *httpsServer) forwardWorker(w http.ResponseWriter, r *http.Request) {
+	reqCtx, req, ok := server.readRequest(w, r)
+	if !ok {
+		return
+	}
+
+	logger := lagerctx.FromContext(reqCtx)
+
+	if r.Header.Get("Upgrade") != tsa.TunnelProtocol {
+		http.Error(w, "expected upgrade to "+tsa.TunnelProtocol, http.StatusBadRequest)
+		return
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "connection cannot be upgraded", http.StatusInternalServerError)
+		return
+	}
+
+	conn, buf, err := hijacker.Hijack()
+	if err != nil {
+		logger.Error("failed-to-hijack", err)
+		return
+	}
+
+	defer conn.Close()
+
+	_, err = buf.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: " + tsa.TunnelProtocol + "\r\n\r\n")
+	if err == nil {
+		err = buf.Flush()
+	}
+
+	if err != nil {
+		logger.Error("failed-to-upgrade", err)
+		return
+	}
+
+	session, err := yamux.Client(&tsa.BufferedConn{Conn: conn, Reader: buf.Reader}, tsa.TunnelConfig())
+	if err != nil {
+		logger.Error("failed-to-start-session", err)
+		return
+	}
+
+	defer session.Close()
+
+	// the request's context ends when the handler returns, and it is not
+	// canceled on hijacked connections anyway
+	ctx, cancel := context.WithCancel(lagerctx.NewContext(context.Background(), logger))
+	defer cancel()
+
+	err = server.forwardOverTunnel(ctx, cancel, session, req.Worker)
+	if err != nil {
+		logger.Error("failed-to-forward-worker", err)
+	}
+}
+
+func (server *httpsServer) forwardOverTunnel(ctx context.Context, cancel context.CancelFunc, session *yamux.Session, worker atc.Worker) error {
+	logger := lagerctx.FromContext(ctx)
+
+	events, err := server.openStream(session, tsa.TunnelStreamEvents)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		// the worker sends a message to drain; either way, stop heartbeating
+		// if the stream or the session goes away
+		msg, err := tsa.ReadTunnelMessage(events)
+		if err == nil {
+			logger.Info("received-message", lager.Data{"message": msg})
+		}
+
+		cancel()
+	}()
+
+	go func() {
+		select {
+		case <-session.CloseChan():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	gardenForward, err := server.forwardStreams(ctx, session, tsa.TunnelStreamGarden)
+	if err != nil {
+		return err
+	}
+
+	defer gardenForward.Close()
+
+	baggageclaimForward, err := server.forwardStreams(ctx, session, tsa.TunnelStreamBaggageclaim)
+	if err != nil {
+		return err
+	}
+
+	defer baggageclaimForward.Close()
+
+	worker.GardenAddr = fmt.Sprintf("%s:%d", server.forwardHost, gardenForward.port)
+	worker.BaggageclaimURL = fmt.Sprintf("http://%s:%d", server.forwardHost, baggageclaimForward.port)
+
+	heartbeater := tsa.NewHeartbeater(
+		clock.NewClock(),
+		server.heartbeatInterval,
+		server.cprInterval,
+		gclient.New(
+			gconn.NewWithDialerAndLogger(
+				keepaliveDialerFactory("tcp", worker.GardenAddr),
+				lagerctx.WithSession(ctx, "garden-connection"),
+			),
+		),
+		bclient.NewWithHTTPClient(worker.BaggageclaimURL, &http.Client{
+			Transport: &http.Transport{
+				DisableKeepAlives:     true,
+				ResponseHeaderTimeout: 1 * time.Minute,
+			},
+		}),
+		server.atcEndpointPicker,
+		server.tokenGenerator,
+		worker,
+		tsa.NewEventWriter(events),
+	)
+
+	err = heartbeater.Heartbeat(ctx)
+	if err != nil {
+		return err
+	}
+
+	// prevent new connections from being accepted
+	gardenForward.Close()
+	baggageclaimForward.Close()
+
+	// only drain if heartbeating was interrupted; otherwise the worker landed or
+	// retired, so it's time to go away
+	if ctx.Err() != nil {
+		logger.Info("draining-forwarded-connections")
+
+		gardenForward.Wait()
+		baggageclaimForward.Wait()
+	}
+
+	// closing the event stream tells the worker the registration has ended;
+	// wait for it to close the session so that it can tell this apart from the
+	// tunnel breaking
+	events.Close()
+
+	select {
+	case <-session.CloseChan():
+	case <-time.After(tunnelCloseTimeout):
+	}
+
+	return nil
+}
+
+func (server *httpsServer) openStream(session *yamux.Session, kind string) (net.Conn, error) {
+	stream, err := session.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tsa.WriteTunnelMessage(stream, kind)
+	if err != nil {
+		stream.Close()
+		return nil, err
+	}
+
+	return stream, nil
+}
+
+type tunnelForward struct {
+	listener net.Listener
+	port     int
+
+	closeOnce *sync.Once
+	wg        *sync.WaitGroup
+}
+
+func (forward tunnelForward) Close() {
+	forward.closeOnce.Do(func() {
+		forward.listener.Close()
+	})
+}
+
+func (forward tunnelForward) Wait() {
+	forward.wg.Wait()
+}
+
+// forwardStreams listens on a random port, forwarding each connection to the
+// worker over a new stream of the given kind.
+func (server *httpsServer) forwardStreams(ctx context.Context, session *yamux.Session, kind string) (tunnelForward, error) {
+	logger := lagerctx.WithSession(ctx, "forward", lager.Data{
+		"kind": kind,
+	})
+
+	listener, err := net.Listen("tcp", "0.0.0.0:0")
+	if err != nil {
+		return tunnelForward{}, err
+	}
+
+	forward := tunnelForward{
+		listener: listener,
+		port:     listener.Addr().(*net.TCPAddr).Port,
+
+		closeOnce: new(sync.Once),
+		wg:        new(sync.WaitGroup),
+	}
+
+	logger.Debug("listening", lager.Data{"addr": listener.Addr().String()})
+
+	forward.wg.Add(1)
+	go func() {
+		defer forward.wg.Done()
+
+		for {
+			localConn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			forward.wg.Add(1)
+			go func() {
+				defer forward.wg.Done()
+				server.forwardTunnelConn(lagerctx.NewContext(ctx, logger.Session("forward-conn")), localConn, session, kind)
+			}()
+		}
+	}()
+
+	return forward, nil
+}
+
+func (server *httpsServer) forwardTunnelConn(ctx context.Context, localConn net.Conn, session *yamux.Session, kind string) {
+	logger := lagerctx.FromContext(ctx)
+
+	defer localConn.Close()
+
+	stream, err := server.openStream(session, kind)
+	if err != nil {
+		logger.Error("failed-to-open-stream", err)
+		return
+	}
+
+	defer stream.Close()
+
+	numPipes := 2
+	wait := make(chan struct{}, numPipes)
+
+	pipe := func(to io.WriteCloser, from io.ReadCloser) {
+		// if either end breaks, close both ends to ensure they're both unblocked,
+		// otherwise io.Copy can block forever if e.g. reading after write end has
+		// gone away
+		defer to.Close()
+		defer from.Close()
+		defer func() {
+			wait <- struct{}{}
+		}()
+
+		io.Copy(to, from)
+	}
+
+	go pipe(localConn, stream)
+	go pipe(stream, localConn)
+
+	for i := 0; i < numPipes; i++ {
+		<-wait
+	}
+
+	logger.Debug("tcpip-io-complete")
+}
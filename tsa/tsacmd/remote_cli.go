@@ -43,6 +43,8 @@ func (req forwardWorkerRequest) Handle(ctx context.Context, state ConnState, cha
 		return err
 	}
 
+	worker.KeyFingerprint = state.KeyFingerprint
+
 	forwards := map[string]ForwardedTCPIP{}
 	for i := 0; i < 2; i++ {
 		select {
@@ -153,6 +155,8 @@ func (req registerWorkerRequest) Handle(ctx context.Context, state ConnState, ch
 		return err
 	}
 
+	worker.KeyFingerprint = state.KeyFingerprint
+
 	heartbeater := tsa.NewHeartbeater(
 		clock.NewClock(),
 		req.server.heartbeatInterval,
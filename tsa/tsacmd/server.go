@@ -33,6 +33,8 @@ type server struct {
 
 type sessionTeam struct {
 	sessionTeams map[string]string
+	sessionKeys  map[string]string
+	sessionConns map[string]io.Closer
 	lock         *sync.RWMutex
 }
 
@@ -59,9 +61,67 @@ func (s *sessionTeam) AuthorizedTeamFor(sessionID string) string {
 	return s.sessionTeams[sessionID]
 }
 
+// RecordKey remembers the key a session authenticated with, so that workers
+// registered through it can be traced back to the key.
+func (s *sessionTeam) RecordKey(sessionID string, key ssh.PublicKey) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.sessionKeys[sessionID] = ssh.FingerprintSHA256(key)
+}
+
+func (s *sessionTeam) KeyFingerprintFor(sessionID string) string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.sessionKeys[sessionID]
+}
+
+// Track remembers the connection of an authenticated session, so that it can
+// be closed if its key is revoked.
+func (s *sessionTeam) Track(sessionID string, conn io.Closer) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.sessionConns[sessionID] = conn
+}
+
+// Forget removes everything remembered about a session once its connection
+// has ended.
+func (s *sessionTeam) Forget(sessionID string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.sessionTeams, sessionID)
+	delete(s.sessionKeys, sessionID)
+	delete(s.sessionConns, sessionID)
+}
+
+// RevokeKeys closes the connections of sessions authenticated with any of
+// the given keys.
+func (s *sessionTeam) RevokeKeys(fingerprints []string) {
+	revoked := map[string]bool{}
+	for _, fingerprint := range fingerprints {
+		revoked[fingerprint] = true
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	for sessionID, conn := range s.sessionConns {
+		if revoked[s.sessionKeys[sessionID]] {
+			_ = conn.Close()
+		}
+	}
+}
+
 type ConnState struct {
 	Team string
 
+	// KeyFingerprint is the fingerprint of the key the connection
+	// authenticated with.
+	KeyFingerprint string
+
 	ForwardedTCPIPs <-chan ForwardedTCPIP
 }
 
@@ -115,11 +175,15 @@ func (server *server) handshake(logger lager.Logger, netConn net.Conn) {
 
 	sessionID := string(conn.SessionID())
 
+	server.sessionTeam.Track(sessionID, conn)
+	defer server.sessionTeam.Forget(sessionID)
+
 	forwardedTCPIPs := make(chan ForwardedTCPIP, maxForwards)
 	go server.handleForwardRequests(ctx, conn, reqs, forwardedTCPIPs)
 
 	state := ConnState{
-		Team: server.sessionTeam.AuthorizedTeamFor(sessionID),
+		Team:           server.sessionTeam.AuthorizedTeamFor(sessionID),
+		KeyFingerprint: server.sessionTeam.KeyFingerprintFor(sessionID),
 
 		ForwardedTCPIPs: forwardedTCPIPs,
 	}
@@ -0,0 +1,208 @@
+package tsacmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/tsa"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/sync/singleflight"
+)
+
+// missRefreshInterval limits how often an unknown key causes the keys to be
+// fetched early, so that a newly added key works right away without every
+// rejected connection hitting the ATC.
+const missRefreshInterval = time.Second
+
+const fetchKeysTimeout = 10 * time.Second
+
+// workerKeyStore caches the worker keys managed through the ATC's API,
+// fetching them again once they are older than the refresh interval.
+//
+// Keys are only trusted for maxStaleness after they were last fetched, so
+// that a key deleted while the ATC can't be reached stops working
+// eventually. Sessions authenticated with keys that are no longer valid are
+// ended through revoke.
+type workerKeyStore struct {
+	logger            lager.Logger
+	atcEndpointPicker tsa.EndpointPicker
+	tokenGenerator    tsa.TokenGenerator
+	refreshInterval   time.Duration
+	maxStaleness      time.Duration
+
+	// revoke is called with the fingerprints of keys that are no longer
+	// valid.
+	revoke func(fingerprints []string)
+
+	fetches singleflight.Group
+
+	lock        sync.Mutex
+	keys        []TeamAuthKeys
+	refreshedAt time.Time
+	attemptedAt time.Time
+}
+
+// Run refreshes the keys on the refresh interval, so that deleted keys are
+// revoked even when no worker connects.
+func (store *workerKeyStore) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	close(ready)
+
+	ticker := time.NewTicker(store.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			store.refresh()
+		case <-signals:
+			return nil
+		}
+	}
+}
+
+// Lookup returns the team the key is authorized for, which is empty for
+// global keys.
+func (store *workerKeyStore) Lookup(key ssh.PublicKey) (string, bool) {
+	store.lock.Lock()
+	stale := time.Since(store.refreshedAt) >= store.refreshInterval
+	team, found := store.find(key)
+	retry := !found && time.Since(store.attemptedAt) >= missRefreshInterval
+	store.lock.Unlock()
+
+	if found && !stale {
+		return team, true
+	}
+
+	if stale || retry {
+		// fetched without holding the lock so that a slow ATC doesn't block
+		// keys that are already known; concurrent lookups share one fetch
+		store.refresh()
+	}
+
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	return store.find(key)
+}
+
+func (store *workerKeyStore) find(key ssh.PublicKey) (string, bool) {
+	if time.Since(store.refreshedAt) >= store.maxStaleness {
+		return "", false
+	}
+
+	for _, teamKeys := range store.keys {
+		for _, k := range teamKeys.AuthKeys {
+			if bytes.Equal(k.Marshal(), key.Marshal()) {
+				return teamKeys.Team, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// refresh replaces the cached keys, revoking the ones that are gone. If they
+// cannot be fetched the previous keys are kept until they are older than
+// maxStaleness, so that the ATC being briefly unavailable doesn't lock
+// workers out.
+func (store *workerKeyStore) refresh() {
+	_, _, _ = store.fetches.Do("refresh", func() (interface{}, error) {
+		logger := store.logger.Session("refresh-worker-keys")
+
+		attemptedAt := time.Now()
+
+		keys, err := store.fetch(logger)
+
+		store.lock.Lock()
+
+		store.attemptedAt = attemptedAt
+
+		previous := store.keys
+		if err == nil {
+			store.keys = keys
+			store.refreshedAt = attemptedAt
+		} else if time.Since(store.refreshedAt) >= store.maxStaleness {
+			store.keys = nil
+		}
+
+		revoked := removedKeys(previous, store.keys)
+
+		store.lock.Unlock()
+
+		if len(revoked) > 0 {
+			logger.Info("revoking-keys", lager.Data{"fingerprints": revoked})
+			store.revoke(revoked)
+		}
+
+		return nil, nil
+	})
+}
+
+func (store *workerKeyStore) fetch(logger lager.Logger) ([]TeamAuthKeys, error) {
+	ctx, cancel := context.WithTimeout(lagerctx.NewContext(context.Background(), logger), fetchKeysTimeout)
+	defer cancel()
+
+	workerKeys, err := (&tsa.KeyFetcher{
+		ATCEndpoint:    store.atcEndpointPicker.Pick(),
+		TokenGenerator: store.tokenGenerator,
+	}).FetchKeys(ctx)
+	if err != nil {
+		logger.Error("failed-to-fetch-keys", err)
+		return nil, err
+	}
+
+	teamKeys := map[string][]ssh.PublicKey{}
+	for _, workerKey := range workerKeys {
+		key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(workerKey.PublicKey))
+		if err != nil {
+			logger.Error("failed-to-parse-key", err, lager.Data{
+				"name": workerKey.Name,
+				"team": workerKey.TeamName,
+			})
+
+			continue
+		}
+
+		teamKeys[workerKey.TeamName] = append(teamKeys[workerKey.TeamName], key)
+	}
+
+	keys := []TeamAuthKeys{}
+	for team, authKeys := range teamKeys {
+		keys = append(keys, TeamAuthKeys{
+			Team:     team,
+			AuthKeys: authKeys,
+		})
+	}
+
+	return keys, nil
+}
+
+// removedKeys returns the fingerprints of the keys which are no longer
+// authorized for the same team.
+func removedKeys(previous []TeamAuthKeys, current []TeamAuthKeys) []string {
+	currentTeams := map[string]string{}
+	for _, teamKeys := range current {
+		for _, key := range teamKeys.AuthKeys {
+			currentTeams[ssh.FingerprintSHA256(key)] = teamKeys.Team
+		}
+	}
+
+	removed := []string{}
+	for _, teamKeys := range previous {
+		for _, key := range teamKeys.AuthKeys {
+			fingerprint := ssh.FingerprintSHA256(key)
+
+			team, found := currentTeams[fingerprint]
+			if !found || team != teamKeys.Team {
+				removed = append(removed, fingerprint)
+			}
+		}
+	}
+
+	return removed
+}
@@ -0,0 +1,103 @@
+package tsa
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/hashicorp/yamux"
+)
+
+// These paths are served by the gateway's mutual-TLS listener, with each one
+// corresponding to a command of the SSH gateway.
+const (
+	HTTPSForwardWorkerPath = "/workers/forward"
+
+	HTTPSLandWorkerPath   = "/workers/land"
+	HTTPSRetireWorkerPath = "/workers/retire"
+	HTTPSDeleteWorkerPath = "/workers/delete"
+
+	HTTPSSweepContainersPath  = "/workers/containers/sweep"
+	HTTPSReportContainersPath = "/workers/containers/report"
+	HTTPSSweepVolumesPath     = "/workers/volumes/sweep"
+	HTTPSReportVolumesPath    = "/workers/volumes/report"
+)
+
+// TunnelProtocol is the protocol named in the Upgrade header when the
+// forward-worker request switches the connection over to a yamux session.
+const TunnelProtocol = "yamux"
+
+// Streams opened by the gateway over the tunnel start with a message naming
+// where the worker should forward them.
+const (
+	TunnelStreamGarden       = "garden"
+	TunnelStreamBaggageclaim = "baggageclaim"
+	TunnelStreamEvents       = "events"
+)
+
+// TunnelDrain is sent by the worker on the events stream to stop
+// heartbeating and drain forwarded connections, like signalling the SSH
+// gateway's forward-worker command.
+const TunnelDrain = "drain"
+
+const maxTunnelMessageLength = 64
+
+// WorkerRequest is the body of each request made to the mutual-TLS gateway.
+type WorkerRequest struct {
+	Worker  atc.Worker `json:"worker"`
+	Handles []string   `json:"handles,omitempty"`
+}
+
+// TunnelConfig configures yamux for either end of the tunnel. Keepalives are
+// sent by the worker for as long as it is registered, so that the connection
+// can go idle once it starts draining.
+func TunnelConfig() *yamux.Config {
+	config := yamux.DefaultConfig()
+	config.EnableKeepAlive = false
+	config.LogOutput = ioutil.Discard
+	return config
+}
+
+// WriteTunnelMessage writes a newline-terminated message to a stream.
+func WriteTunnelMessage(w io.Writer, msg string) error {
+	_, err := io.WriteString(w, msg+"\n")
+	return err
+}
+
+// ReadTunnelMessage reads a newline-terminated message from a stream. It
+// reads a byte at a time so that none of the traffic that follows is
+// consumed.
+func ReadTunnelMessage(r io.Reader) (string, error) {
+	var msg []byte
+
+	buf := make([]byte, 1)
+	for len(msg) < maxTunnelMessageLength {
+		_, err := io.ReadFull(r, buf)
+		if err != nil {
+			return "", err
+		}
+
+		if buf[0] == '\n' {
+			return strings.TrimSpace(string(msg)), nil
+		}
+
+		msg = append(msg, buf[0])
+	}
+
+	return "", errors.New("tunnel message too long")
+}
+
+// BufferedConn is a connection whose initial reads are served from the
+// buffer used to read the HTTP upgrade exchange.
+type BufferedConn struct {
+	net.Conn
+	Reader *bufio.Reader
+}
+
+func (c *BufferedConn) Read(b []byte) (int, error) {
+	return c.Reader.Read(b)
+}
@@ -210,6 +210,9 @@ handleEvent event ( model, effects, outmsg ) =
             , outmsg
             )
 
+        SelectedWorker _ _ ->
+            ( model, effects, outmsg )
+
         BuildStatus status date ->
             let
                 newSt =
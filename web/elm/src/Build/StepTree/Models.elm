@@ -55,6 +55,7 @@ type StepTree
     | Try StepTree
     | Retry StepID Int TabFocus (Array StepTree)
     | Timeout StepTree
+    | Lock StepTree
 
 
 type alias StepFocus =
@@ -130,6 +131,7 @@ type BuildEvent
     | InitializePut Origin Time.Posix
     | StartPut Origin Time.Posix
     | FinishPut Origin Int Concourse.Version Concourse.Metadata (Maybe Time.Posix)
+    | SelectedWorker Origin String
     | Log Origin String (Maybe Time.Posix)
     | Error Origin String Time.Posix
     | End
@@ -224,6 +226,9 @@ updateStep update tree =
         Timeout step ->
             Timeout (update step)
 
+        Lock step ->
+            Lock (update step)
+
         _ ->
             --impossible
             tree
@@ -362,6 +367,9 @@ finishTree root =
         Timeout tree ->
             Timeout (finishTree tree)
 
+        Lock tree ->
+            Lock (finishTree tree)
+
 
 finishStep : Step -> Step
 finishStep step =
@@ -113,6 +113,9 @@ init hl resources buildPlan =
         Concourse.BuildStepTimeout plan ->
             initWrappedStep hl resources Timeout plan
 
+        Concourse.BuildStepLock plan ->
+            initWrappedStep hl resources Lock plan
+
 
 initMultiStep :
     Highlight
@@ -264,6 +267,9 @@ treeIsActive stepTree =
         Timeout tree ->
             treeIsActive tree
 
+        Lock tree ->
+            treeIsActive tree
+
         Retry _ _ _ trees ->
             List.any treeIsActive (Array.toList trees)
 
@@ -448,6 +454,9 @@ viewTree timeZone model tree =
         Timeout step ->
             viewTree timeZone model step
 
+        Lock step ->
+            viewTree timeZone model step
+
         Aggregate steps ->
             Html.div [ class "aggregate" ]
                 (Array.toList <| Array.map (viewSeq timeZone model) steps)
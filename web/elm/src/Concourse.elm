@@ -348,6 +348,7 @@ type BuildStep
     | BuildStepTry BuildPlan
     | BuildStepRetry (Array BuildPlan)
     | BuildStepTimeout BuildPlan
+    | BuildStepLock BuildPlan
 
 
 type alias HookedPlan =
@@ -403,6 +404,8 @@ decodeBuildPlan_ =
                     lazy (\_ -> decodeBuildStepRetry)
                 , Json.Decode.field "timeout" <|
                     lazy (\_ -> decodeBuildStepTimeout)
+                , Json.Decode.field "lock" <|
+                    lazy (\_ -> decodeBuildStepLock)
                 ]
             )
 
@@ -519,6 +522,12 @@ decodeBuildStepTimeout =
         |> andMap (Json.Decode.field "step" <| lazy (\_ -> decodeBuildPlan_))
 
 
+decodeBuildStepLock : Json.Decode.Decoder BuildStep
+decodeBuildStepLock =
+    Json.Decode.succeed BuildStepLock
+        |> andMap (Json.Decode.field "step" <| lazy (\_ -> decodeBuildPlan_))
+
+
 
 -- Info
 
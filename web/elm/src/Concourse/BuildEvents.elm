@@ -151,6 +151,14 @@ decodeBuildEvent =
                     "finish-put" ->
                         Json.Decode.field "data" (decodeFinishResource FinishPut)
 
+                    "selected-worker" ->
+                        Json.Decode.field
+                            "data"
+                            (Json.Decode.map2 SelectedWorker
+                                (Json.Decode.field "origin" decodeOrigin)
+                                (Json.Decode.field "selected_worker" Json.Decode.string)
+                            )
+
                     unknown ->
                         Json.Decode.fail ("unknown event type: " ++ unknown)
             )
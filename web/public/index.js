@@ -411,6 +411,37 @@ function createGraph(svg, jobs, resources) {
     }
   }
 
+  // populate jobs from other pipelines referenced by passed constraints
+  for (var i in jobs) {
+    var job = jobs[i];
+
+    for (var j in job.inputs) {
+      var input = job.inputs[j];
+
+      for (var p in input.passed) {
+        var passed = input.passed[p];
+        var separator = passed.indexOf("/");
+        if (separator == -1) {
+          continue;
+        }
+
+        var externalId = jobNode(passed);
+        if (graph.node(externalId)) {
+          continue;
+        }
+
+        graph.setNode(externalId, new GraphNode({
+          id: externalId,
+          name: passed,
+          class: "job external",
+          status: "external",
+          url: "/teams/"+job.team_name+"/pipelines/"+encodeURIComponent(passed.substring(0, separator))+"/jobs/"+encodeURIComponent(passed.substring(separator+1)),
+          svg: svg,
+        }));
+      }
+    }
+  }
+
   // populate dependant job input edges
   //
   // do this first as this is what primarily determines node ranks
@@ -14,7 +14,7 @@ import (
 
 func NewBeaconRunner(
 	logger lager.Logger,
-	tsaClient *tsa.Client,
+	tsaClient TSAClient,
 	rebalanceInterval time.Duration,
 	connectionDrainTimeout time.Duration,
 	gardenAddr string,
@@ -47,12 +47,12 @@ func NewBeaconRunner(
 				return nil
 			}
 
-			if prevErr == tsa.ErrAllGatewaysUnreachable && beacon.Drained() {
+			if (prevErr == tsa.ErrAllGatewaysUnreachable || prevErr == tsa.ErrAllTLSGatewaysUnreachable) && beacon.Drained() {
 				// this could happen if the whole deployment is being deleted. in this
 				// case, we should just exit and stop retrying, because draining can't
 				// complete anyway.
 				logger.Info("exiting", lager.Data{
-					"reason": "all SSH gateways disappeared while draining",
+					"reason": "all gateways disappeared while draining",
 				})
 				return nil
 			}
@@ -0,0 +1,396 @@
+package runtime
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
+	uuid "github.com/nu7hatch/gouuid"
+)
+
+// Backend implements Garden on top of containerd, so that the worker can
+// serve the same API to the ATC without running a separate Garden server.
+type Backend struct {
+	logger lager.Logger
+
+	client Client
+	config BackendConfig
+
+	containers  map[string]*container
+	creating    map[string]bool
+	containersL sync.RWMutex
+}
+
+type BackendConfig struct {
+	DepotDir string
+	InitPath string
+
+	// Network is given to every container that isn't created without a
+	// network.
+	Network Network
+
+	// MaxID is the highest user and group ID on the host. Root in
+	// unprivileged containers is mapped to it.
+	MaxID uint32
+
+	// MaxContainers limits the number of containers; zero means no limit.
+	MaxContainers int
+
+	// DiskQuota enforces disk limits. Containers with a disk limit can't be
+	// created without it.
+	DiskQuota DiskQuota
+}
+
+func NewBackend(logger lager.Logger, client Client, config BackendConfig) *Backend {
+	return &Backend{
+		logger: logger,
+
+		client: client,
+		config: config,
+
+		containers: map[string]*container{},
+		creating:   map[string]bool{},
+	}
+}
+
+// Start restores the containers left running by a previous worker process,
+// destroying any that containerd or the depot no longer know about.
+func (backend *Backend) Start() error {
+	logger := backend.logger.Session("start")
+
+	err := os.MkdirAll(backend.config.DepotDir, 0755)
+	if err != nil {
+		return err
+	}
+
+	handles, err := backend.client.Containers()
+	if err != nil {
+		return err
+	}
+
+	running := map[string]bool{}
+	for _, handle := range handles {
+		running[handle] = true
+	}
+
+	entries, err := ioutil.ReadDir(backend.config.DepotDir)
+	if err != nil {
+		return err
+	}
+
+	restored := map[string]*container{}
+	for _, entry := range entries {
+		handle := entry.Name()
+		depotDir := filepath.Join(backend.config.DepotDir, handle)
+
+		if running[handle] {
+			container, err := loadContainer(backend.client, depotDir)
+			if err == nil {
+				restored[handle] = container
+				continue
+			}
+
+			logger.Error("failed-to-restore-container", err, lager.Data{"handle": handle})
+		}
+
+		err := os.RemoveAll(depotDir)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, handle := range handles {
+		if restored[handle] != nil {
+			continue
+		}
+
+		err := backend.client.Destroy(handle)
+		if err != nil {
+			return err
+		}
+	}
+
+	backend.containersL.Lock()
+	backend.containers = restored
+	backend.containersL.Unlock()
+
+	logger.Info("restored", lager.Data{"containers": len(restored)})
+
+	return nil
+}
+
+// Stop leaves containers and their processes running so that they can be
+// restored by Start, but stops streaming the output of processes.
+func (backend *Backend) Stop() {
+	backend.containersL.RLock()
+	defer backend.containersL.RUnlock()
+
+	for _, container := range backend.containers {
+		container.detach()
+	}
+}
+
+func (backend *Backend) GraceTime(c garden.Container) time.Duration {
+	return c.(*container).currentGraceTime()
+}
+
+func (backend *Backend) Ping() error {
+	return backend.client.Ping()
+}
+
+func (backend *Backend) Capacity() (garden.Capacity, error) {
+	memory, disk, err := hostCapacity(backend.config.DepotDir)
+	if err != nil {
+		return garden.Capacity{}, err
+	}
+
+	return garden.Capacity{
+		MemoryInBytes: memory,
+		DiskInBytes:   disk,
+		MaxContainers: uint64(backend.config.MaxContainers),
+	}, nil
+}
+
+func (backend *Backend) Create(spec garden.ContainerSpec) (garden.Container, error) {
+	if spec.Handle == "" {
+		id, err := uuid.NewV4()
+		if err != nil {
+			return nil, err
+		}
+
+		spec.Handle = id.String()
+	}
+
+	err := backend.reserve(spec.Handle)
+	if err != nil {
+		return nil, err
+	}
+
+	defer backend.release(spec.Handle)
+
+	depotDir := filepath.Join(backend.config.DepotDir, spec.Handle)
+
+	container, err := newContainer(backend.client, depotDir, containerState{
+		Spec:       spec,
+		Properties: spec.Properties,
+		GraceTime:  spec.GraceTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.MkdirAll(depotDir, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	err = container.save()
+	if err != nil {
+		_ = os.RemoveAll(depotDir)
+		return nil, err
+	}
+
+	err = backend.limitDisk(spec)
+	if err != nil {
+		_ = os.RemoveAll(depotDir)
+		return nil, err
+	}
+
+	err = backend.client.Create(spec.Handle, container.containerdSpec(
+		backend.config.InitPath,
+		backend.config.Network,
+		backend.config.MaxID,
+	))
+	if err != nil {
+		_ = os.RemoveAll(depotDir)
+		return nil, err
+	}
+
+	backend.containersL.Lock()
+	backend.containers[spec.Handle] = container
+	backend.containersL.Unlock()
+
+	return container, nil
+}
+
+// limitDisk applies the container's disk limit to its rootfs and each of its
+// writable bind mounts.
+func (backend *Backend) limitDisk(spec garden.ContainerSpec) error {
+	limit := spec.Limits.Disk.ByteHard
+	if limit == 0 {
+		return nil
+	}
+
+	if backend.config.DiskQuota == nil {
+		return garden.NewError("disk limits are not supported by this worker")
+	}
+
+	rootfs, err := rootfsPath(spec.RootFSPath)
+	if err != nil {
+		return err
+	}
+
+	paths := []string{rootfs}
+	for _, bm := range spec.BindMounts {
+		if bm.Mode == garden.BindMountModeRW {
+			paths = append(paths, bm.SrcPath)
+		}
+	}
+
+	for _, path := range paths {
+		err := backend.config.DiskQuota.Limit(path, limit)
+		if err != nil {
+			return fmt.Errorf("limit disk of %s: %s", path, err)
+		}
+	}
+
+	return nil
+}
+
+// reserve claims a handle for a container being created, so that concurrent
+// creates can't use the same handle or exceed the container limit.
+func (backend *Backend) reserve(handle string) error {
+	backend.containersL.Lock()
+	defer backend.containersL.Unlock()
+
+	if backend.containers[handle] != nil || backend.creating[handle] {
+		return garden.NewError("handle already exists: " + handle)
+	}
+
+	if backend.config.MaxContainers > 0 && len(backend.containers)+len(backend.creating) >= backend.config.MaxContainers {
+		return garden.NewError(fmt.Sprintf("max containers reached: %d", backend.config.MaxContainers))
+	}
+
+	backend.creating[handle] = true
+
+	return nil
+}
+
+func (backend *Backend) release(handle string) {
+	backend.containersL.Lock()
+	delete(backend.creating, handle)
+	backend.containersL.Unlock()
+}
+
+func (backend *Backend) Destroy(handle string) error {
+	backend.containersL.RLock()
+	container, found := backend.containers[handle]
+	backend.containersL.RUnlock()
+
+	if !found {
+		return garden.ContainerNotFoundError{Handle: handle}
+	}
+
+	// destroying the container kills its processes
+	err := backend.client.Destroy(handle)
+	if err != nil {
+		return err
+	}
+
+	container.detach()
+
+	err = os.RemoveAll(container.depotDir)
+	if err != nil {
+		return err
+	}
+
+	backend.containersL.Lock()
+	delete(backend.containers, handle)
+	backend.containersL.Unlock()
+
+	return nil
+}
+
+func (backend *Backend) Containers(filter garden.Properties) ([]garden.Container, error) {
+	matchingContainers := []garden.Container{}
+
+	backend.containersL.RLock()
+
+	for _, container := range backend.containers {
+		if containerHasProperties(container, filter) {
+			matchingContainers = append(matchingContainers, container)
+		}
+	}
+
+	backend.containersL.RUnlock()
+
+	return matchingContainers, nil
+}
+
+func (backend *Backend) BulkInfo(handles []string) (map[string]garden.ContainerInfoEntry, error) {
+	infos := map[string]garden.ContainerInfoEntry{}
+
+	for _, handle := range handles {
+		container, err := backend.Lookup(handle)
+		if err != nil {
+			infos[handle] = garden.ContainerInfoEntry{Err: garden.NewError(err.Error())}
+			continue
+		}
+
+		info, err := container.Info()
+		if err != nil {
+			infos[handle] = garden.ContainerInfoEntry{Err: garden.NewError(err.Error())}
+			continue
+		}
+
+		infos[handle] = garden.ContainerInfoEntry{Info: info}
+	}
+
+	return infos, nil
+}
+
+func (backend *Backend) BulkMetrics(handles []string) (map[string]garden.ContainerMetricsEntry, error) {
+	metrics := map[string]garden.ContainerMetricsEntry{}
+
+	for _, handle := range handles {
+		container, err := backend.Lookup(handle)
+		if err != nil {
+			metrics[handle] = garden.ContainerMetricsEntry{Err: garden.NewError(err.Error())}
+			continue
+		}
+
+		containerMetrics, err := container.Metrics()
+		if err != nil {
+			metrics[handle] = garden.ContainerMetricsEntry{Err: garden.NewError(err.Error())}
+			continue
+		}
+
+		metrics[handle] = garden.ContainerMetricsEntry{Metrics: containerMetrics}
+	}
+
+	return metrics, nil
+}
+
+func (backend *Backend) Lookup(handle string) (garden.Container, error) {
+	backend.containersL.RLock()
+	container, found := backend.containers[handle]
+	backend.containersL.RUnlock()
+
+	if !found {
+		return nil, garden.ContainerNotFoundError{Handle: handle}
+	}
+
+	return container, nil
+}
+
+func containerHasProperties(container *container, properties garden.Properties) bool {
+	containerProps := container.currentProperties()
+
+	for key, val := range properties {
+		cval, ok := containerProps[key]
+		if !ok {
+			return false
+		}
+
+		if cval != val {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,602 @@
+package runtime_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/worker/runtime"
+	"github.com/concourse/concourse/worker/runtime/runtimefakes"
+	"github.com/concourse/go-archive/tarfs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Backend", func() {
+	var (
+		fakeClient *runtimefakes.FakeClient
+		tmpDir     string
+		depotDir   string
+		rootfsDir  string
+		volumeDir  string
+
+		config  runtime.BackendConfig
+		backend *runtime.Backend
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "runtime")
+		Expect(err).ToNot(HaveOccurred())
+
+		depotDir = filepath.Join(tmpDir, "depot")
+		rootfsDir = filepath.Join(tmpDir, "rootfs")
+		volumeDir = filepath.Join(tmpDir, "volume")
+
+		Expect(os.MkdirAll(rootfsDir, 0755)).To(Succeed())
+		Expect(os.MkdirAll(volumeDir, 0755)).To(Succeed())
+
+		fakeClient = new(runtimefakes.FakeClient)
+		config = runtime.BackendConfig{
+			DepotDir: depotDir,
+			InitPath: "/path/to/init",
+			Network:  runtime.NetworkCNI,
+			MaxID:    4294967294,
+		}
+	})
+
+	JustBeforeEach(func() {
+		backend = runtime.NewBackend(lagertest.NewTestLogger("test"), fakeClient, config)
+
+		Expect(backend.Start()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(tmpDir)).To(Succeed())
+	})
+
+	containerSpec := func() garden.ContainerSpec {
+		return garden.ContainerSpec{
+			Handle:     "some-handle",
+			RootFSPath: "raw://" + rootfsDir,
+			Env:        []string{"FOO=bar"},
+			Privileged: true,
+			Properties: garden.Properties{"some": "property"},
+			BindMounts: []garden.BindMount{
+				{SrcPath: volumeDir, DstPath: "/tmp/build/some-volume", Mode: garden.BindMountModeRW},
+			},
+			Limits: garden.Limits{
+				Memory: garden.MemoryLimits{LimitInBytes: 1024},
+				CPU:    garden.CPULimits{LimitInShares: 512},
+			},
+		}
+	}
+
+	Describe("Create", func() {
+		It("creates the container through containerd", func() {
+			container, err := backend.Create(containerSpec())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(container.Handle()).To(Equal("some-handle"))
+
+			Expect(fakeClient.CreateCallCount()).To(Equal(1))
+			handle, spec := fakeClient.CreateArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+			Expect(spec).To(Equal(runtime.Spec{
+				RootFS:      rootfsDir,
+				Init:        "/path/to/init",
+				Env:         []string{"FOO=bar"},
+				Privileged:  true,
+				Network:     runtime.NetworkCNI,
+				MemoryLimit: 1024,
+				CPUShares:   512,
+				Mounts: []runtime.Mount{
+					{Source: volumeDir, Destination: "/tmp/build/some-volume"},
+				},
+			}))
+		})
+
+		Context("when the container is unprivileged", func() {
+			It("maps root in the container to the highest ID on the host", func() {
+				spec := containerSpec()
+				spec.Privileged = false
+
+				_, err := backend.Create(spec)
+				Expect(err).ToNot(HaveOccurred())
+
+				mappings := []runtime.IDMapping{
+					{ContainerID: 0, HostID: 4294967294, Size: 1},
+					{ContainerID: 1, HostID: 1, Size: 4294967293},
+				}
+
+				_, ctrSpec := fakeClient.CreateArgsForCall(0)
+				Expect(ctrSpec.UIDMappings).To(Equal(mappings))
+				Expect(ctrSpec.GIDMappings).To(Equal(mappings))
+			})
+		})
+
+		Context("when the container must have no network", func() {
+			It("creates it with a network of its own with only loopback", func() {
+				spec := containerSpec()
+				spec.Properties["network"] = "none"
+
+				_, err := backend.Create(spec)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, ctrSpec := fakeClient.CreateArgsForCall(0)
+				Expect(ctrSpec.Network).To(Equal(runtime.NetworkNone))
+			})
+		})
+
+		Context("when the container limits its pids", func() {
+			It("creates it with the pids limit", func() {
+				spec := containerSpec()
+				spec.Limits.Pid = garden.PidLimits{Max: 100}
+
+				_, err := backend.Create(spec)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, ctrSpec := fakeClient.CreateArgsForCall(0)
+				Expect(ctrSpec.PidsLimit).To(Equal(uint64(100)))
+			})
+		})
+
+		Context("when the container limits its disk", func() {
+			var spec garden.ContainerSpec
+
+			BeforeEach(func() {
+				spec = containerSpec()
+				spec.BindMounts = append(spec.BindMounts, garden.BindMount{
+					SrcPath: "/some/input",
+					DstPath: "/tmp/build/some-input",
+					Mode:    garden.BindMountModeRO,
+				})
+				spec.Limits.Disk = garden.DiskLimits{ByteHard: 2048}
+			})
+
+			Context("when the worker has a disk quota", func() {
+				var fakeDiskQuota *runtimefakes.FakeDiskQuota
+
+				BeforeEach(func() {
+					fakeDiskQuota = new(runtimefakes.FakeDiskQuota)
+					config.DiskQuota = fakeDiskQuota
+				})
+
+				It("limits the rootfs and each writable volume", func() {
+					_, err := backend.Create(spec)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(fakeDiskQuota.LimitCallCount()).To(Equal(2))
+
+					path, limit := fakeDiskQuota.LimitArgsForCall(0)
+					Expect(path).To(Equal(rootfsDir))
+					Expect(limit).To(Equal(uint64(2048)))
+
+					path, limit = fakeDiskQuota.LimitArgsForCall(1)
+					Expect(path).To(Equal(volumeDir))
+					Expect(limit).To(Equal(uint64(2048)))
+
+					Expect(fakeClient.CreateCallCount()).To(Equal(1))
+				})
+
+				Context("when the limit can't be applied", func() {
+					BeforeEach(func() {
+						fakeDiskQuota.LimitReturns(errors.New("nope"))
+					})
+
+					It("fails without creating the container", func() {
+						_, err := backend.Create(spec)
+						Expect(err).To(HaveOccurred())
+						Expect(fakeClient.CreateCallCount()).To(BeZero())
+						Expect(filepath.Join(depotDir, "some-handle")).ToNot(BeADirectory())
+					})
+				})
+			})
+
+			Context("when the worker has no disk quota", func() {
+				It("fails without creating the container", func() {
+					_, err := backend.Create(spec)
+					Expect(err).To(MatchError("disk limits are not supported by this worker"))
+					Expect(fakeClient.CreateCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when configured to share the host's network", func() {
+			BeforeEach(func() {
+				config.Network = runtime.NetworkHost
+			})
+
+			It("creates containers with the host's network", func() {
+				_, err := backend.Create(containerSpec())
+				Expect(err).ToNot(HaveOccurred())
+
+				_, ctrSpec := fakeClient.CreateArgsForCall(0)
+				Expect(ctrSpec.Network).To(Equal(runtime.NetworkHost))
+			})
+		})
+
+		Context("when a container with the handle is being created", func() {
+			var created chan struct{}
+
+			BeforeEach(func() {
+				created = make(chan struct{})
+				fakeClient.CreateStub = func(string, runtime.Spec) error {
+					<-created
+					return nil
+				}
+			})
+
+			It("fails to create another", func() {
+				errs := make(chan error, 1)
+				go func() {
+					_, err := backend.Create(containerSpec())
+					errs <- err
+				}()
+
+				Eventually(fakeClient.CreateCallCount).Should(Equal(1))
+
+				_, err := backend.Create(containerSpec())
+				Expect(err).To(MatchError("handle already exists: some-handle"))
+
+				close(created)
+				Expect(<-errs).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("when the maximum number of containers is reached", func() {
+			BeforeEach(func() {
+				config.MaxContainers = 1
+			})
+
+			It("fails to create more", func() {
+				_, err := backend.Create(containerSpec())
+				Expect(err).ToNot(HaveOccurred())
+
+				spec := containerSpec()
+				spec.Handle = "other-handle"
+
+				_, err = backend.Create(spec)
+				Expect(err).To(MatchError("max containers reached: 1"))
+			})
+		})
+
+		It("can be looked up by handle and properties", func() {
+			_, err := backend.Create(containerSpec())
+			Expect(err).ToNot(HaveOccurred())
+
+			container, err := backend.Lookup("some-handle")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(container.Handle()).To(Equal("some-handle"))
+
+			containers, err := backend.Containers(garden.Properties{"some": "property"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(containers).To(HaveLen(1))
+
+			containers, err = backend.Containers(garden.Properties{"some": "other-property"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(containers).To(BeEmpty())
+		})
+
+		It("reports the configured limits", func() {
+			container, err := backend.Create(containerSpec())
+			Expect(err).ToNot(HaveOccurred())
+
+			memory, err := container.CurrentMemoryLimits()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(memory.LimitInBytes).To(Equal(uint64(1024)))
+
+			cpu, err := container.CurrentCPULimits()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cpu.LimitInShares).To(Equal(uint64(512)))
+		})
+
+		Context("when the rootfs is not a raw path", func() {
+			It("returns an error without creating the container", func() {
+				spec := containerSpec()
+				spec.RootFSPath = "docker:///busybox"
+
+				_, err := backend.Create(spec)
+				Expect(err).To(HaveOccurred())
+				Expect(fakeClient.CreateCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when containerd fails to create the container", func() {
+			BeforeEach(func() {
+				fakeClient.CreateReturns(errors.New("nope"))
+			})
+
+			It("returns the error and leaves nothing behind", func() {
+				_, err := backend.Create(containerSpec())
+				Expect(err).To(MatchError("nope"))
+
+				_, err = backend.Lookup("some-handle")
+				Expect(err).To(Equal(garden.ContainerNotFoundError{Handle: "some-handle"}))
+
+				Expect(filepath.Join(depotDir, "some-handle")).ToNot(BeADirectory())
+			})
+		})
+	})
+
+	Describe("Capacity", func() {
+		BeforeEach(func() {
+			config.MaxContainers = 42
+		})
+
+		It("reports the host's memory and disk and the container limit", func() {
+			capacity, err := backend.Capacity()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(capacity.MemoryInBytes).ToNot(BeZero())
+			Expect(capacity.DiskInBytes).ToNot(BeZero())
+			Expect(capacity.MaxContainers).To(Equal(uint64(42)))
+		})
+	})
+
+	Describe("BulkMetrics", func() {
+		JustBeforeEach(func() {
+			_, err := backend.Create(containerSpec())
+			Expect(err).ToNot(HaveOccurred())
+
+			fakeClient.MetricsReturns(garden.Metrics{
+				MemoryStat: garden.ContainerMemoryStat{TotalUsageTowardLimit: 123},
+			}, nil)
+		})
+
+		It("reports the metrics of each container from containerd", func() {
+			metrics, err := backend.BulkMetrics([]string{"some-handle", "bogus"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(metrics["some-handle"].Metrics.MemoryStat.TotalUsageTowardLimit).To(Equal(uint64(123)))
+			Expect(metrics["some-handle"].Err).To(BeNil())
+			Expect(metrics["bogus"].Err).ToNot(BeNil())
+
+			Expect(fakeClient.MetricsArgsForCall(0)).To(Equal("some-handle"))
+		})
+	})
+
+	Describe("Destroy", func() {
+		JustBeforeEach(func() {
+			_, err := backend.Create(containerSpec())
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("destroys the container in containerd and forgets it", func() {
+			Expect(backend.Destroy("some-handle")).To(Succeed())
+
+			Expect(fakeClient.DestroyCallCount()).To(Equal(1))
+			Expect(fakeClient.DestroyArgsForCall(0)).To(Equal("some-handle"))
+
+			_, err := backend.Lookup("some-handle")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("returns ContainerNotFoundError for unknown handles", func() {
+			Expect(backend.Destroy("bogus")).To(Equal(garden.ContainerNotFoundError{Handle: "bogus"}))
+		})
+	})
+
+	Describe("Start", func() {
+		JustBeforeEach(func() {
+			container, err := backend.Create(containerSpec())
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(container.SetProperty("other", "property")).To(Succeed())
+
+			backend = runtime.NewBackend(lagertest.NewTestLogger("test"), fakeClient, config)
+		})
+
+		Context("when the container is still running", func() {
+			JustBeforeEach(func() {
+				fakeClient.ContainersReturns([]string{"some-handle", "orphaned-handle"}, nil)
+			})
+
+			It("restores it along with its properties", func() {
+				Expect(backend.Start()).To(Succeed())
+
+				container, err := backend.Lookup("some-handle")
+				Expect(err).ToNot(HaveOccurred())
+
+				properties, err := container.Properties()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(properties).To(Equal(garden.Properties{
+					"some":  "property",
+					"other": "property",
+				}))
+			})
+
+			It("destroys containers it does not know about", func() {
+				Expect(backend.Start()).To(Succeed())
+
+				Expect(fakeClient.DestroyCallCount()).To(Equal(1))
+				Expect(fakeClient.DestroyArgsForCall(0)).To(Equal("orphaned-handle"))
+			})
+		})
+
+		Context("when the container is gone", func() {
+			It("cleans up its state", func() {
+				Expect(backend.Start()).To(Succeed())
+
+				_, err := backend.Lookup("some-handle")
+				Expect(err).To(HaveOccurred())
+
+				Expect(filepath.Join(depotDir, "some-handle")).ToNot(BeADirectory())
+			})
+		})
+	})
+
+	Describe("streaming", func() {
+		var container garden.Container
+
+		JustBeforeEach(func() {
+			var err error
+			container, err = backend.Create(containerSpec())
+			Expect(err).ToNot(HaveOccurred())
+
+			srcDir := filepath.Join(tmpDir, "src")
+			Expect(os.MkdirAll(srcDir, 0755)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(srcDir, "some-file"), []byte("some-content"), 0644)).To(Succeed())
+
+			buf := new(bytes.Buffer)
+			Expect(tarfs.Compress(buf, srcDir, ".")).To(Succeed())
+
+			err = container.StreamIn(garden.StreamInSpec{
+				Path:      "/tmp/build/some-volume/inputs",
+				TarStream: buf,
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("streams into the bind mount backing the path", func() {
+			Expect(ioutil.ReadFile(filepath.Join(volumeDir, "inputs", "some-file"))).To(Equal([]byte("some-content")))
+		})
+
+		It("streams back out of the bind mount", func() {
+			out, err := container.StreamOut(garden.StreamOutSpec{Path: "/tmp/build/some-volume/inputs/"})
+			Expect(err).ToNot(HaveOccurred())
+
+			dstDir := filepath.Join(tmpDir, "dst")
+			Expect(tarfs.Extract(out, dstDir)).To(Succeed())
+			Expect(out.Close()).To(Succeed())
+
+			Expect(ioutil.ReadFile(filepath.Join(dstDir, "some-file"))).To(Equal([]byte("some-content")))
+		})
+
+		It("streams paths outside of mounts into the rootfs", func() {
+			buf := new(bytes.Buffer)
+			Expect(tarfs.Compress(buf, volumeDir, "inputs")).To(Succeed())
+
+			err := container.StreamIn(garden.StreamInSpec{Path: "/opt", TarStream: buf})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(filepath.Join(rootfsDir, "opt", "inputs", "some-file")).To(BeARegularFile())
+		})
+	})
+
+	Describe("running processes", func() {
+		var (
+			container   garden.Container
+			fakeProcess *runtimefakes.FakeProcess
+		)
+
+		BeforeEach(func() {
+			fakeProcess = new(runtimefakes.FakeProcess)
+			fakeClient.ExecReturns(fakeProcess, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			container, err = backend.Create(containerSpec())
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("executes the process in the container through containerd", func() {
+			stdout := new(bytes.Buffer)
+
+			process, err := container.Run(garden.ProcessSpec{
+				ID:   "some-process",
+				Path: "some-path",
+			}, garden.ProcessIO{Stdout: stdout})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(process).To(Equal(fakeProcess))
+
+			handle, spec, processIO := fakeClient.ExecArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+			Expect(spec.ID).To(Equal("some-process"))
+			Expect(spec.Path).To(Equal("some-path"))
+			Expect(processIO.Stdout).To(Equal(stdout))
+		})
+
+		It("generates an ID when none is given", func() {
+			_, err := container.Run(garden.ProcessSpec{Path: "some-path"}, garden.ProcessIO{})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, spec, _ := fakeClient.ExecArgsForCall(0)
+			Expect(spec.ID).ToNot(BeEmpty())
+		})
+
+		It("reports the running processes from containerd", func() {
+			fakeClient.ProcessesReturns([]string{"some-process"}, nil)
+
+			info, err := container.Info()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.ProcessIDs).To(ConsistOf("some-process"))
+
+			Expect(fakeClient.ProcessesArgsForCall(0)).To(Equal("some-handle"))
+		})
+
+		It("returns ProcessNotFoundError when attaching to an unknown process", func() {
+			fakeClient.AttachReturns(nil, garden.ProcessNotFoundError{ProcessID: "bogus"})
+
+			_, err := container.Attach("bogus", garden.ProcessIO{})
+			Expect(err).To(Equal(garden.ProcessNotFoundError{ProcessID: "bogus"}))
+		})
+
+		It("stops streaming to the previous attachment when attached to again", func() {
+			_, err := container.Run(garden.ProcessSpec{ID: "some-process", Path: "some-path"}, garden.ProcessIO{})
+			Expect(err).ToNot(HaveOccurred())
+
+			reattached := new(runtimefakes.FakeProcess)
+			fakeClient.AttachReturns(reattached, nil)
+
+			stdout := new(bytes.Buffer)
+
+			process, err := container.Attach("some-process", garden.ProcessIO{Stdout: stdout})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(process).To(Equal(reattached))
+
+			Expect(fakeProcess.DetachCallCount()).To(Equal(1))
+
+			handle, processID, processIO := fakeClient.AttachArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+			Expect(processID).To(Equal("some-process"))
+			Expect(processIO.Stdout).To(Equal(stdout))
+		})
+
+		Context("when the worker stops", func() {
+			It("stops streaming the output of processes, leaving them running", func() {
+				_, err := container.Run(garden.ProcessSpec{ID: "some-process", Path: "some-path"}, garden.ProcessIO{})
+				Expect(err).ToNot(HaveOccurred())
+
+				backend.Stop()
+
+				Expect(fakeProcess.DetachCallCount()).To(Equal(1))
+				Expect(fakeClient.SignalCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the container is stopped", func() {
+			BeforeEach(func() {
+				fakeClient.ProcessesReturns([]string{"some-process", "exited-process"}, nil)
+				fakeClient.SignalStub = func(handle string, processID string, signal garden.Signal) error {
+					if processID == "exited-process" {
+						return garden.ProcessNotFoundError{ProcessID: processID}
+					}
+
+					return nil
+				}
+			})
+
+			It("signals each of its running processes", func() {
+				Expect(container.Stop(false)).To(Succeed())
+
+				Expect(fakeClient.SignalCallCount()).To(Equal(2))
+
+				handle, processID, signal := fakeClient.SignalArgsForCall(0)
+				Expect(handle).To(Equal("some-handle"))
+				Expect(processID).To(Equal("some-process"))
+				Expect(signal).To(Equal(garden.SignalTerminate))
+			})
+
+			It("kills them when told to", func() {
+				Expect(container.Stop(true)).To(Succeed())
+
+				_, _, signal := fakeClient.SignalArgsForCall(0)
+				Expect(signal).To(Equal(garden.SignalKill))
+			})
+		})
+	})
+})
@@ -0,0 +1,89 @@
+package runtime
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+const (
+	btrfsFSType = 0x9123683e
+
+	// btrfsSubvolumeInode is the inode of the root of every btrfs subvolume.
+	btrfsSubvolumeInode = 256
+)
+
+type btrfsQuota struct {
+	bin string
+}
+
+// NewBtrfsQuota limits volumes through btrfs qgroups, which requires them to
+// be btrfs subvolumes as created by baggageclaim's btrfs driver.
+func NewBtrfsQuota(bin string) DiskQuota {
+	return &btrfsQuota{bin: bin}
+}
+
+func (quota *btrfsQuota) Limit(path string, limit uint64) error {
+	subvolume, err := btrfsSubvolume(path)
+	if err != nil {
+		return err
+	}
+
+	err = quota.run("quota", "enable", subvolume)
+	if err != nil {
+		return err
+	}
+
+	return quota.run("qgroup", "limit", "-e", strconv.FormatUint(limit, 10), subvolume)
+}
+
+func (quota *btrfsQuota) run(args ...string) error {
+	stderr := new(bytes.Buffer)
+
+	cmd := exec.Command(quota.bin, args...)
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return fmt.Errorf("btrfs %s: %s: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+// btrfsSubvolume finds the subvolume holding the given path.
+func btrfsSubvolume(path string) (string, error) {
+	var fsStat syscall.Statfs_t
+	err := syscall.Statfs(path, &fsStat)
+	if err != nil {
+		return "", err
+	}
+
+	if uint32(fsStat.Type) != btrfsFSType {
+		return "", fmt.Errorf("not on a btrfs filesystem: %s", path)
+	}
+
+	dir := filepath.Clean(path)
+	for {
+		info, err := os.Stat(dir)
+		if err != nil {
+			return "", err
+		}
+
+		if stat, ok := info.Sys().(*syscall.Stat_t); ok && stat.Ino == btrfsSubvolumeInode {
+			return dir, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("not in a btrfs subvolume: %s", path)
+		}
+
+		dir = parent
+	}
+}
@@ -0,0 +1,107 @@
+package runtime
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/garden"
+)
+
+var errDetached = errors.New("process was detached")
+
+// Mount bind-mounts a path on the host into a container.
+type Mount struct {
+	Source      string
+	Destination string
+	ReadOnly    bool
+}
+
+// Network is the network a container is given.
+type Network string
+
+const (
+	// NetworkNone gives the container its own network namespace with only
+	// loopback.
+	NetworkNone Network = "none"
+
+	// NetworkCNI gives the container its own network namespace, connected
+	// through the host's CNI configuration.
+	NetworkCNI Network = "cni"
+
+	// NetworkHost shares the host's network with the container, which lets
+	// it reach anything listening on the worker's loopback.
+	NetworkHost Network = "host"
+)
+
+// IDMapping maps a range of user or group IDs in a container's user
+// namespace to IDs on the host.
+type IDMapping struct {
+	ContainerID uint32
+	HostID      uint32
+	Size        uint32
+}
+
+// Spec describes a container to be created by containerd.
+type Spec struct {
+	RootFS string
+	Init   string
+
+	Env        []string
+	Mounts     []Mount
+	Privileged bool
+
+	Network Network
+
+	// UIDMappings and GIDMappings run the container in its own user
+	// namespace. They are empty for privileged containers.
+	UIDMappings []IDMapping
+	GIDMappings []IDMapping
+
+	MemoryLimit uint64
+	CPUShares   uint64
+
+	// PidsLimit is the most processes the container may run; zero means no
+	// limit.
+	PidsLimit uint64
+}
+
+//go:generate counterfeiter . Client
+
+// Client is the subset of containerd used by the backend. Each container
+// runs an init process that keeps it alive; processes requested through
+// Garden are executed alongside it.
+type Client interface {
+	Ping() error
+
+	Create(handle string, spec Spec) error
+	Destroy(handle string) error
+	Containers() ([]string, error)
+	Metrics(handle string) (garden.Metrics, error)
+
+	// Exec runs the process in the container, streaming its stdio. The
+	// process is run by containerd rather than the worker, so it keeps
+	// running while the worker restarts.
+	Exec(handle string, spec garden.ProcessSpec, processIO garden.ProcessIO) (Process, error)
+
+	// Attach streams the output of a process started through Exec, even one
+	// started before the worker restarted. Stdin can't be resumed; a process
+	// that was given stdin sees it end.
+	Attach(handle string, processID string, processIO garden.ProcessIO) (Process, error)
+
+	// Processes returns the IDs of the running processes started through
+	// Exec.
+	Processes(handle string) ([]string, error)
+
+	// Signal sends a signal to a process started through Exec.
+	Signal(handle string, processID string, signal garden.Signal) error
+}
+
+//go:generate counterfeiter . Process
+
+// Process is a process started through Exec.
+type Process interface {
+	garden.Process
+
+	// Detach stops streaming the process's stdio, leaving it running so that
+	// it can be attached to again.
+	Detach()
+}
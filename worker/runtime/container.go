@@ -0,0 +1,455 @@
+package runtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"github.com/concourse/go-archive/tarfs"
+	uuid "github.com/nu7hatch/gouuid"
+)
+
+const stateFile = "container.json"
+
+// networkPropertyName is set by the ATC to networkNone for containers that
+// must have no network.
+const (
+	networkPropertyName = "network"
+	networkNone         = "none"
+)
+
+type UndefinedPropertyError struct {
+	Key string
+}
+
+func (err UndefinedPropertyError) Error() string {
+	return fmt.Sprintf("property does not exist: %s", err.Key)
+}
+
+// containerState is persisted in the container's depot directory so that
+// containers survive restarts of the worker.
+type containerState struct {
+	Spec       garden.ContainerSpec `json:"spec"`
+	Properties garden.Properties    `json:"properties"`
+	GraceTime  time.Duration        `json:"grace_time"`
+}
+
+type container struct {
+	handle string
+	spec   garden.ContainerSpec
+	rootfs string
+
+	depotDir string
+	client   Client
+
+	properties  garden.Properties
+	propertiesL sync.RWMutex
+
+	graceTime  time.Duration
+	graceTimeL sync.RWMutex
+
+	// the processes being streamed, so that streaming can be stopped when
+	// the worker stops or the process is attached to again
+	processes  map[string]Process
+	processesL sync.Mutex
+}
+
+func newContainer(client Client, depotDir string, state containerState) (*container, error) {
+	rootfs, err := rootfsPath(state.Spec.RootFSPath)
+	if err != nil {
+		return nil, err
+	}
+
+	properties := state.Properties
+	if properties == nil {
+		properties = garden.Properties{}
+	}
+
+	return &container{
+		handle: state.Spec.Handle,
+		spec:   state.Spec,
+		rootfs: rootfs,
+
+		depotDir: depotDir,
+		client:   client,
+
+		properties: properties,
+		graceTime:  state.GraceTime,
+
+		processes: map[string]Process{},
+	}, nil
+}
+
+func loadContainer(client Client, depotDir string) (*container, error) {
+	payload, err := ioutil.ReadFile(filepath.Join(depotDir, stateFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var state containerState
+	err = json.Unmarshal(payload, &state)
+	if err != nil {
+		return nil, err
+	}
+
+	return newContainer(client, depotDir, state)
+}
+
+func rootfsPath(rootfsURI string) (string, error) {
+	if !strings.HasPrefix(rootfsURI, "raw://") {
+		return "", fmt.Errorf("unsupported rootfs uri (must be raw://): %s", rootfsURI)
+	}
+
+	return strings.TrimPrefix(rootfsURI, "raw://"), nil
+}
+
+func (container *container) containerdSpec(initPath string, network Network, maxID uint32) Spec {
+	spec := Spec{
+		RootFS: container.rootfs,
+		Init:   initPath,
+
+		Env:        container.spec.Env,
+		Privileged: container.spec.Privileged,
+
+		Network: network,
+
+		MemoryLimit: container.spec.Limits.Memory.LimitInBytes,
+		CPUShares:   container.spec.Limits.CPU.Weight,
+		PidsLimit:   container.spec.Limits.Pid.Max,
+	}
+
+	if container.spec.Properties[networkPropertyName] == networkNone {
+		spec.Network = NetworkNone
+	}
+
+	if !container.spec.Privileged {
+		// root is mapped to the host's highest ID and every other ID to
+		// itself, matching the ownership baggageclaim gives unprivileged
+		// volumes
+		mappings := []IDMapping{
+			{ContainerID: 0, HostID: maxID, Size: 1},
+			{ContainerID: 1, HostID: 1, Size: maxID - 1},
+		}
+
+		spec.UIDMappings = mappings
+		spec.GIDMappings = mappings
+	}
+
+	if spec.CPUShares == 0 {
+		spec.CPUShares = container.spec.Limits.CPU.LimitInShares
+	}
+
+	for _, bm := range container.spec.BindMounts {
+		spec.Mounts = append(spec.Mounts, Mount{
+			Source:      bm.SrcPath,
+			Destination: bm.DstPath,
+			ReadOnly:    bm.Mode == garden.BindMountModeRO,
+		})
+	}
+
+	return spec
+}
+
+func (container *container) save() error {
+	container.propertiesL.RLock()
+	container.graceTimeL.RLock()
+
+	payload, err := json.Marshal(containerState{
+		Spec:       container.spec,
+		Properties: container.properties,
+		GraceTime:  container.graceTime,
+	})
+
+	container.graceTimeL.RUnlock()
+	container.propertiesL.RUnlock()
+
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(container.depotDir, stateFile), payload, 0644)
+}
+
+// hostPath resolves a path in the container to the host, following bind
+// mounts so that streaming into a volume writes to the volume itself.
+func (container *container) hostPath(path string) string {
+	path = filepath.Clean("/" + filepath.FromSlash(path))
+
+	var (
+		source      string
+		destination string
+	)
+
+	for _, bm := range container.spec.BindMounts {
+		dst := filepath.Clean(bm.DstPath)
+		if path != dst && !strings.HasPrefix(path, dst+string(os.PathSeparator)) {
+			continue
+		}
+
+		if len(dst) > len(destination) {
+			source = bm.SrcPath
+			destination = dst
+		}
+	}
+
+	if destination == "" {
+		return filepath.Join(container.rootfs, path)
+	}
+
+	return filepath.Join(source, strings.TrimPrefix(path, destination))
+}
+
+func (container *container) Handle() string {
+	return container.handle
+}
+
+func (container *container) Stop(kill bool) error {
+	signal := garden.SignalTerminate
+	if kill {
+		signal = garden.SignalKill
+	}
+
+	processIDs, err := container.client.Processes(container.handle)
+	if err != nil {
+		return err
+	}
+
+	for _, processID := range processIDs {
+		err := container.client.Signal(container.handle, processID, signal)
+		if err != nil {
+			// the process exited in the meantime
+			if _, ok := err.(garden.ProcessNotFoundError); ok {
+				continue
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// detach stops streaming the output of the container's processes, leaving
+// them running so that they can be attached to once the worker restarts.
+func (container *container) detach() {
+	container.processesL.Lock()
+	defer container.processesL.Unlock()
+
+	for _, process := range container.processes {
+		process.Detach()
+	}
+}
+
+func (container *container) Info() (garden.ContainerInfo, error) {
+	processIDs, err := container.client.Processes(container.handle)
+	if err != nil {
+		return garden.ContainerInfo{}, err
+	}
+
+	return garden.ContainerInfo{
+		State:         "active",
+		ContainerPath: container.depotDir,
+		ProcessIDs:    processIDs,
+		Properties:    container.currentProperties(),
+	}, nil
+}
+
+func (container *container) StreamIn(spec garden.StreamInSpec) error {
+	destination := container.hostPath(spec.Path)
+
+	err := os.MkdirAll(destination, 0755)
+	if err != nil {
+		return err
+	}
+
+	return tarfs.Extract(spec.TarStream, destination)
+}
+
+func (container *container) StreamOut(spec garden.StreamOutSpec) (io.ReadCloser, error) {
+	source := container.hostPath(spec.Path)
+	if strings.HasSuffix(spec.Path, "/") {
+		source += string(os.PathSeparator) + "."
+	}
+
+	_, err := os.Lstat(source)
+	if err != nil {
+		return nil, err
+	}
+
+	r, w := io.Pipe()
+
+	errs := make(chan error, 1)
+	go func() {
+		err := tarfs.Compress(w, filepath.Dir(source), filepath.Base(source))
+		_ = w.CloseWithError(err)
+		errs <- err
+	}()
+
+	return waitCloser{
+		ReadCloser: r,
+		wait:       errs,
+	}, nil
+}
+
+type waitCloser struct {
+	io.ReadCloser
+	wait <-chan error
+}
+
+func (c waitCloser) Close() error {
+	err := c.ReadCloser.Close()
+	if err != nil {
+		return err
+	}
+
+	return <-c.wait
+}
+
+func (container *container) CurrentBandwidthLimits() (garden.BandwidthLimits, error) {
+	return container.spec.Limits.Bandwidth, nil
+}
+
+func (container *container) CurrentCPULimits() (garden.CPULimits, error) {
+	return container.spec.Limits.CPU, nil
+}
+
+func (container *container) CurrentDiskLimits() (garden.DiskLimits, error) {
+	return container.spec.Limits.Disk, nil
+}
+
+func (container *container) CurrentMemoryLimits() (garden.MemoryLimits, error) {
+	return container.spec.Limits.Memory, nil
+}
+
+// Ports can't be mapped into containers and there are no rules to apply;
+// containers either share the host's network or are connected through CNI.
+func (container *container) NetIn(hostPort, containerPort uint32) (uint32, uint32, error) {
+	return 0, 0, nil
+}
+
+func (container *container) NetOut(garden.NetOutRule) error { return nil }
+
+func (container *container) BulkNetOut([]garden.NetOutRule) error { return nil }
+
+func (container *container) Run(spec garden.ProcessSpec, processIO garden.ProcessIO) (garden.Process, error) {
+	if spec.ID == "" {
+		id, err := uuid.NewV4()
+		if err != nil {
+			return nil, err
+		}
+
+		spec.ID = id.String()
+	}
+
+	container.processesL.Lock()
+	defer container.processesL.Unlock()
+
+	process, err := container.client.Exec(container.handle, spec, processIO)
+	if err != nil {
+		return nil, err
+	}
+
+	container.processes[spec.ID] = process
+
+	return process, nil
+}
+
+// Attach finds processes even if they were started before the worker last
+// restarted, streaming their output to the given writers in place of the
+// previous attachment.
+func (container *container) Attach(processID string, processIO garden.ProcessIO) (garden.Process, error) {
+	container.processesL.Lock()
+	defer container.processesL.Unlock()
+
+	previous, found := container.processes[processID]
+	if found {
+		previous.Detach()
+		delete(container.processes, processID)
+	}
+
+	process, err := container.client.Attach(container.handle, processID, processIO)
+	if err != nil {
+		return nil, err
+	}
+
+	container.processes[processID] = process
+
+	return process, nil
+}
+
+func (container *container) Metrics() (garden.Metrics, error) {
+	return container.client.Metrics(container.handle)
+}
+
+func (container *container) SetGraceTime(t time.Duration) error {
+	container.graceTimeL.Lock()
+	container.graceTime = t
+	container.graceTimeL.Unlock()
+
+	return container.save()
+}
+
+func (container *container) Properties() (garden.Properties, error) {
+	return container.currentProperties(), nil
+}
+
+func (container *container) Property(name string) (string, error) {
+	container.propertiesL.RLock()
+	property, found := container.properties[name]
+	container.propertiesL.RUnlock()
+
+	if !found {
+		return "", UndefinedPropertyError{name}
+	}
+
+	return property, nil
+}
+
+func (container *container) SetProperty(name string, value string) error {
+	container.propertiesL.Lock()
+	container.properties[name] = value
+	container.propertiesL.Unlock()
+
+	return container.save()
+}
+
+func (container *container) RemoveProperty(name string) error {
+	container.propertiesL.Lock()
+	_, found := container.properties[name]
+	delete(container.properties, name)
+	container.propertiesL.Unlock()
+
+	if !found {
+		return UndefinedPropertyError{name}
+	}
+
+	return container.save()
+}
+
+func (container *container) currentProperties() garden.Properties {
+	properties := garden.Properties{}
+
+	container.propertiesL.RLock()
+
+	for k, v := range container.properties {
+		properties[k] = v
+	}
+
+	container.propertiesL.RUnlock()
+
+	return properties
+}
+
+func (container *container) currentGraceTime() time.Duration {
+	container.graceTimeL.RLock()
+	defer container.graceTimeL.RUnlock()
+
+	return container.graceTime
+}
@@ -0,0 +1,707 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+
+	"code.cloudfoundry.org/garden"
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/cio"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/defaults"
+	"github.com/containerd/containerd/errdefs"
+	v1 "github.com/containerd/containerd/metrics/types/v1"
+	v2 "github.com/containerd/containerd/metrics/types/v2"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/oci"
+	"github.com/containerd/containerd/runtime/v2/runc/options"
+	gocni "github.com/containerd/go-cni"
+	"github.com/containerd/typeurl"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// InitPath is where the init binary is mounted in every container.
+const InitPath = "/tmp/garden-init"
+
+// networkLabel records the network a container was given, so that its CNI
+// network can be torn down when it is destroyed.
+const networkLabel = "concourse.network"
+
+type containerdClient struct {
+	client    *containerd.Client
+	namespace string
+}
+
+// NewContainerdClient connects to containerd through its socket at the given
+// address, creating containers in the given namespace.
+func NewContainerdClient(address string, namespace string) (Client, error) {
+	client, err := containerd.New(address, containerd.WithDefaultNamespace(namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	return &containerdClient{
+		client:    client,
+		namespace: namespace,
+	}, nil
+}
+
+func (client *containerdClient) Ping() error {
+	_, err := client.client.Version(client.context())
+	return err
+}
+
+func (client *containerdClient) Create(handle string, spec Spec) error {
+	ctx := client.context()
+
+	mounts := append([]Mount{
+		{Source: spec.Init, Destination: InitPath, ReadOnly: true},
+		{Source: "/etc/resolv.conf", Destination: "/etc/resolv.conf", ReadOnly: true},
+		{Source: "/etc/hosts", Destination: "/etc/hosts", ReadOnly: true},
+	}, spec.Mounts...)
+
+	ociMounts := []specs.Mount{}
+	for _, mount := range mounts {
+		mode := "rw"
+		if mount.ReadOnly {
+			mode = "ro"
+		}
+
+		ociMounts = append(ociMounts, specs.Mount{
+			Type:        "bind",
+			Source:      mount.Source,
+			Destination: mount.Destination,
+			Options:     []string{"rbind", mode},
+		})
+	}
+
+	opts := []oci.SpecOpts{
+		oci.WithRootFSPath(spec.RootFS),
+		oci.WithProcessArgs(InitPath),
+		oci.WithEnv(spec.Env),
+		oci.WithMounts(ociMounts),
+	}
+
+	if spec.Network == NetworkHost {
+		opts = append(opts, oci.WithHostNamespace(specs.NetworkNamespace))
+	}
+
+	if len(spec.UIDMappings) > 0 || len(spec.GIDMappings) > 0 {
+		opts = append(opts, oci.WithUserNamespace(
+			ociIDMappings(spec.UIDMappings),
+			ociIDMappings(spec.GIDMappings),
+		))
+	}
+
+	if spec.Privileged {
+		opts = append(opts, oci.WithPrivileged, oci.WithAllDevicesAllowed, oci.WithHostDevices)
+	}
+
+	if spec.MemoryLimit > 0 {
+		opts = append(opts, oci.WithMemoryLimit(spec.MemoryLimit))
+	}
+
+	if spec.CPUShares > 0 {
+		opts = append(opts, oci.WithCPUShares(spec.CPUShares))
+	}
+
+	if spec.PidsLimit > 0 {
+		opts = append(opts, oci.WithPidsLimit(int64(spec.PidsLimit)))
+	}
+
+	container, err := client.client.NewContainer(
+		ctx,
+		handle,
+		containerd.WithContainerLabels(map[string]string{networkLabel: string(spec.Network)}),
+		containerd.WithNewSpec(opts...),
+	)
+	if err != nil {
+		return err
+	}
+
+	task, err := container.NewTask(ctx, cio.NullIO)
+	if err != nil {
+		_ = client.Destroy(handle)
+		return err
+	}
+
+	// the network namespace exists once the task is created, and is
+	// connected before anything runs in it
+	if spec.Network == NetworkCNI {
+		network, err := gocni.New(gocni.WithDefaultConf)
+		if err == nil {
+			_, err = network.Setup(ctx, client.networkID(handle), netnsPath(task.Pid()))
+		}
+
+		if err != nil {
+			_ = client.Destroy(handle)
+			return fmt.Errorf("set up network: %s", err)
+		}
+	}
+
+	err = task.Start(ctx)
+	if err != nil {
+		_ = client.Destroy(handle)
+		return err
+	}
+
+	return nil
+}
+
+func (client *containerdClient) Destroy(handle string) error {
+	ctx := client.context()
+
+	container, err := client.client.LoadContainer(ctx, handle)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return os.RemoveAll(client.fifoDir(handle))
+		}
+
+		return err
+	}
+
+	task, err := container.Task(ctx, nil)
+	if err == nil {
+		labels, err := container.Labels(ctx)
+		if err != nil {
+			return err
+		}
+
+		// torn down while the task's network namespace still exists
+		if labels[networkLabel] == string(NetworkCNI) {
+			network, err := gocni.New(gocni.WithDefaultConf)
+			if err == nil {
+				err = network.Remove(ctx, client.networkID(handle), netnsPath(task.Pid()))
+			}
+
+			if err != nil {
+				return fmt.Errorf("remove network: %s", err)
+			}
+		}
+
+		_, err = task.Delete(ctx, containerd.WithProcessKill)
+		if err != nil && !errdefs.IsNotFound(err) {
+			return err
+		}
+	} else if !errdefs.IsNotFound(err) {
+		return err
+	}
+
+	err = container.Delete(ctx)
+	if err != nil && !errdefs.IsNotFound(err) {
+		return err
+	}
+
+	return os.RemoveAll(client.fifoDir(handle))
+}
+
+func (client *containerdClient) Containers() ([]string, error) {
+	containers, err := client.client.Containers(client.context())
+	if err != nil {
+		return nil, err
+	}
+
+	handles := []string{}
+	for _, container := range containers {
+		handles = append(handles, container.ID())
+	}
+
+	return handles, nil
+}
+
+func (client *containerdClient) Metrics(handle string) (garden.Metrics, error) {
+	ctx := client.context()
+
+	task, err := client.task(ctx, handle)
+	if err != nil {
+		return garden.Metrics{}, err
+	}
+
+	metric, err := task.Metrics(ctx)
+	if err != nil {
+		return garden.Metrics{}, err
+	}
+
+	data, err := typeurl.UnmarshalAny(metric.Data)
+	if err != nil {
+		return garden.Metrics{}, err
+	}
+
+	return gardenMetrics(data)
+}
+
+func (client *containerdClient) Exec(handle string, spec garden.ProcessSpec, processIO garden.ProcessIO) (Process, error) {
+	ctx := client.context()
+
+	container, err := client.client.LoadContainer(ctx, handle)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil, garden.ContainerNotFoundError{Handle: handle}
+		}
+
+		return nil, err
+	}
+
+	task, err := container.Task(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := container.Info(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	containerSpec, err := container.Spec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	processSpec, err := execSpec(ctx, client.client, &info, containerSpec, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(client.fifoDir(handle), spec.ID)
+
+	err = os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, err
+	}
+
+	config := cio.Config{
+		Terminal: processSpec.Terminal,
+		Stdout:   filepath.Join(dir, "stdout"),
+		Stderr:   filepath.Join(dir, "stderr"),
+	}
+
+	var stdin *stdinCloser
+	if processIO.Stdin != nil {
+		config.Stdin = filepath.Join(dir, "stdin")
+		stdin = &stdinCloser{
+			stdin:   processIO.Stdin,
+			started: make(chan struct{}),
+		}
+
+		defer close(stdin.started)
+	}
+
+	// the FIFOs are left in place when the worker stops streaming, so that
+	// the process can be attached to again; they're removed along with the
+	// container
+	process, err := task.Exec(ctx, spec.ID, processSpec, func(string) (cio.IO, error) {
+		streams := []cio.Opt{cio.WithStreams(stdinReader(stdin), writerOrDiscard(processIO.Stdout), writerOrDiscard(processIO.Stderr))}
+		if config.Terminal {
+			streams = append(streams, cio.WithTerminal)
+		}
+
+		return cio.NewAttach(streams...)(cio.NewFIFOSet(config, nil))
+	})
+	if err != nil {
+		if errdefs.IsAlreadyExists(err) {
+			return nil, garden.NewError("process already exists: " + spec.ID)
+		}
+
+		return nil, err
+	}
+
+	if stdin != nil {
+		stdin.process = process
+	}
+
+	attached, err := attachProcess(process)
+	if err != nil {
+		return nil, err
+	}
+
+	err = process.Start(ctx)
+	if err != nil {
+		attached.Detach()
+		_, _ = process.Delete(ctx)
+		return nil, err
+	}
+
+	if spec.TTY != nil {
+		err := attached.SetTTY(*spec.TTY)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return attached, nil
+}
+
+func (client *containerdClient) Attach(handle string, processID string, processIO garden.ProcessIO) (Process, error) {
+	ctx := client.context()
+
+	task, err := client.task(ctx, handle)
+	if err != nil {
+		return nil, err
+	}
+
+	// stdin can't be resumed, so a process that was given stdin sees it end
+	process, err := task.LoadProcess(ctx, processID, cio.NewAttach(cio.WithStreams(
+		strings.NewReader(""),
+		writerOrDiscard(processIO.Stdout),
+		writerOrDiscard(processIO.Stderr),
+	)))
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil, garden.ProcessNotFoundError{ProcessID: processID}
+		}
+
+		return nil, err
+	}
+
+	if process.IO().Config().Stdin != "" {
+		err := process.CloseIO(ctx, containerd.WithStdinCloser)
+		if err != nil && !errdefs.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
+	return attachProcess(process)
+}
+
+func (client *containerdClient) Processes(handle string) ([]string, error) {
+	ctx := client.context()
+
+	task, err := client.task(ctx, handle)
+	if err != nil {
+		return nil, err
+	}
+
+	infos, err := task.Pids(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string{}
+	for _, info := range infos {
+		if info.Info == nil {
+			continue
+		}
+
+		details, err := typeurl.UnmarshalAny(info.Info)
+		if err != nil {
+			return nil, err
+		}
+
+		// the init process has no exec ID
+		if details, ok := details.(*options.ProcessDetails); ok && details.ExecID != "" {
+			ids = append(ids, details.ExecID)
+		}
+	}
+
+	return ids, nil
+}
+
+func (client *containerdClient) Signal(handle string, processID string, signal garden.Signal) error {
+	ctx := client.context()
+
+	task, err := client.task(ctx, handle)
+	if err != nil {
+		return err
+	}
+
+	process, err := task.LoadProcess(ctx, processID, nil)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return garden.ProcessNotFoundError{ProcessID: processID}
+		}
+
+		return err
+	}
+
+	return process.Kill(ctx, syscallSignal(signal))
+}
+
+func (client *containerdClient) task(ctx context.Context, handle string) (containerd.Task, error) {
+	container, err := client.client.LoadContainer(ctx, handle)
+	if err == nil {
+		var task containerd.Task
+		task, err = container.Task(ctx, nil)
+		if err == nil {
+			return task, nil
+		}
+	}
+
+	if errdefs.IsNotFound(err) {
+		return nil, garden.ContainerNotFoundError{Handle: handle}
+	}
+
+	return nil, err
+}
+
+// context carries the namespace, which generating a container's spec
+// requires even though the client has a default for its requests.
+func (client *containerdClient) context() context.Context {
+	return namespaces.WithNamespace(context.Background(), client.namespace)
+}
+
+func (client *containerdClient) fifoDir(handle string) string {
+	return filepath.Join(defaults.DefaultFIFODir, client.namespace, handle)
+}
+
+func (client *containerdClient) networkID(handle string) string {
+	return client.namespace + "-" + handle
+}
+
+// execSpec derives a process's spec from its container's, so that it runs
+// with the container's environment and capabilities. The process's own
+// environment is merged into the container's.
+func execSpec(ctx context.Context, client oci.Client, info *containers.Container, containerSpec *oci.Spec, spec garden.ProcessSpec) (*specs.Process, error) {
+	opts := []oci.SpecOpts{
+		oci.WithProcessArgs(append([]string{spec.Path}, spec.Args...)...),
+		oci.WithEnv(spec.Env),
+	}
+
+	if spec.Dir != "" {
+		opts = append(opts, oci.WithProcessCwd(spec.Dir))
+	}
+
+	if spec.User != "" {
+		opts = append(opts, oci.WithUser(spec.User))
+	}
+
+	err := oci.ApplyOpts(ctx, client, info, containerSpec, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	process := containerSpec.Process
+	process.Terminal = spec.TTY != nil
+
+	return process, nil
+}
+
+func ociIDMappings(mappings []IDMapping) []specs.LinuxIDMapping {
+	ociMappings := []specs.LinuxIDMapping{}
+	for _, mapping := range mappings {
+		ociMappings = append(ociMappings, specs.LinuxIDMapping{
+			ContainerID: mapping.ContainerID,
+			HostID:      mapping.HostID,
+			Size:        mapping.Size,
+		})
+	}
+
+	return ociMappings
+}
+
+func netnsPath(pid uint32) string {
+	return fmt.Sprintf("/proc/%d/ns/net", pid)
+}
+
+func syscallSignal(signal garden.Signal) syscall.Signal {
+	if signal == garden.SignalKill {
+		return syscall.SIGKILL
+	}
+
+	return syscall.SIGTERM
+}
+
+func writerOrDiscard(w io.Writer) io.Writer {
+	if w == nil {
+		return ioutil.Discard
+	}
+
+	return w
+}
+
+func stdinReader(stdin *stdinCloser) io.Reader {
+	if stdin == nil {
+		return nil
+	}
+
+	return stdin
+}
+
+// gardenMetrics reports the cgroup v1 or v2 statistics of a task through
+// Garden.
+func gardenMetrics(data interface{}) (garden.Metrics, error) {
+	var gardenMetrics garden.Metrics
+
+	switch metrics := data.(type) {
+	case *v1.Metrics:
+		if metrics.Memory != nil {
+			gardenMetrics.MemoryStat.TotalCache = metrics.Memory.TotalCache
+
+			if metrics.Memory.Usage != nil && metrics.Memory.Usage.Usage > metrics.Memory.TotalCache {
+				gardenMetrics.MemoryStat.TotalUsageTowardLimit = metrics.Memory.Usage.Usage - metrics.Memory.TotalCache
+			}
+		}
+
+		if metrics.CPU != nil && metrics.CPU.Usage != nil {
+			gardenMetrics.CPUStat = garden.ContainerCPUStat{
+				Usage:  metrics.CPU.Usage.Total,
+				User:   metrics.CPU.Usage.User,
+				System: metrics.CPU.Usage.Kernel,
+			}
+		}
+
+		if metrics.Pids != nil {
+			gardenMetrics.PidStat = garden.ContainerPidStat{
+				Current: metrics.Pids.Current,
+				Max:     metrics.Pids.Limit,
+			}
+		}
+
+	case *v2.Metrics:
+		if metrics.Memory != nil {
+			gardenMetrics.MemoryStat.TotalCache = metrics.Memory.File
+
+			if metrics.Memory.Usage > metrics.Memory.File {
+				gardenMetrics.MemoryStat.TotalUsageTowardLimit = metrics.Memory.Usage - metrics.Memory.File
+			}
+		}
+
+		// reported in microseconds rather than nanoseconds
+		if metrics.CPU != nil {
+			gardenMetrics.CPUStat = garden.ContainerCPUStat{
+				Usage:  metrics.CPU.UsageUsec * 1000,
+				User:   metrics.CPU.UserUsec * 1000,
+				System: metrics.CPU.SystemUsec * 1000,
+			}
+		}
+
+		if metrics.Pids != nil {
+			gardenMetrics.PidStat = garden.ContainerPidStat{
+				Current: metrics.Pids.Current,
+				Max:     metrics.Pids.Limit,
+			}
+		}
+
+	default:
+		return garden.Metrics{}, fmt.Errorf("unsupported metrics type: %T", data)
+	}
+
+	return gardenMetrics, nil
+}
+
+// stdinCloser ends the process's stdin once the stdin given to it has been
+// read to its end. containerd keeps the process's stdin open until told to
+// close it.
+type stdinCloser struct {
+	stdin io.Reader
+
+	// closed once the process has been created, or failed to be
+	started chan struct{}
+	process containerd.Process
+}
+
+func (stdin *stdinCloser) Read(p []byte) (int, error) {
+	n, err := stdin.stdin.Read(p)
+	if err == io.EOF {
+		<-stdin.started
+
+		if stdin.process != nil {
+			_ = stdin.process.CloseIO(context.Background(), containerd.WithStdinCloser)
+		}
+	}
+
+	return n, err
+}
+
+// containerdProcess streams the stdio of a process run by containerd. The
+// process keeps running when streaming stops, and can be attached to again.
+type containerdProcess struct {
+	process containerd.Process
+
+	cancel   context.CancelFunc
+	detached chan struct{}
+	detachL  sync.Once
+
+	exited chan struct{}
+	status containerd.ExitStatus
+}
+
+func attachProcess(process containerd.Process) (*containerdProcess, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	statusC, err := process.Wait(ctx)
+	if err != nil {
+		cancel()
+		_ = process.IO().Close()
+		return nil, err
+	}
+
+	attached := &containerdProcess{
+		process: process,
+
+		cancel:   cancel,
+		detached: make(chan struct{}),
+
+		exited: make(chan struct{}),
+	}
+
+	go func() {
+		attached.status = <-statusC
+		close(attached.exited)
+	}()
+
+	return attached, nil
+}
+
+func (process *containerdProcess) ID() string {
+	return process.process.ID()
+}
+
+// Wait returns the process's exit status once it has exited and all of its
+// output has been streamed.
+func (process *containerdProcess) Wait() (int, error) {
+	select {
+	case <-process.exited:
+	case <-process.detached:
+		return 0, errDetached
+	}
+
+	select {
+	case <-process.detached:
+		return 0, errDetached
+	default:
+	}
+
+	process.process.IO().Wait()
+
+	code, _, err := process.status.Result()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(code), nil
+}
+
+func (process *containerdProcess) SetTTY(spec garden.TTYSpec) error {
+	if !process.process.IO().Config().Terminal {
+		return garden.NewError("process was not started with a tty: " + process.ID())
+	}
+
+	if spec.WindowSize == nil {
+		return nil
+	}
+
+	return process.process.Resize(
+		context.Background(),
+		uint32(spec.WindowSize.Columns),
+		uint32(spec.WindowSize.Rows),
+	)
+}
+
+func (process *containerdProcess) Signal(signal garden.Signal) error {
+	return process.process.Kill(context.Background(), syscallSignal(signal))
+}
+
+func (process *containerdProcess) Detach() {
+	process.detachL.Do(func() {
+		close(process.detached)
+		process.cancel()
+		_ = process.process.IO().Close()
+	})
+}
@@ -0,0 +1,102 @@
+package runtime
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/garden"
+	cgroupsv1 "github.com/containerd/cgroups/stats/v1"
+	"github.com/containerd/containerd/containers"
+	v1 "github.com/containerd/containerd/metrics/types/v1"
+	v2 "github.com/containerd/containerd/metrics/types/v2"
+	"github.com/containerd/containerd/oci"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+var _ = Describe("containerd client", func() {
+	Describe("execSpec", func() {
+		var containerSpec *oci.Spec
+
+		BeforeEach(func() {
+			containerSpec = &oci.Spec{
+				Root: &specs.Root{Path: "/some/rootfs"},
+				Process: &specs.Process{
+					Args: []string{InitPath},
+					Env:  []string{"PATH=/usr/bin", "FOO=container"},
+					Cwd:  "/",
+				},
+			}
+		})
+
+		It("runs the process directly, without wrapping it in 'env'", func() {
+			process, err := execSpec(context.Background(), nil, &containers.Container{}, containerSpec, garden.ProcessSpec{
+				Path: "some-path",
+				Args: []string{"some", "args"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(process.Args).To(Equal([]string{"some-path", "some", "args"}))
+		})
+
+		It("merges the process env over the container's", func() {
+			process, err := execSpec(context.Background(), nil, &containers.Container{}, containerSpec, garden.ProcessSpec{
+				Path: "some-path",
+				Env:  []string{"FOO=process", "BAR=baz"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(process.Env).To(Equal([]string{"PATH=/usr/bin", "FOO=process", "BAR=baz"}))
+		})
+
+		It("sets the working directory, user, and terminal", func() {
+			process, err := execSpec(context.Background(), nil, &containers.Container{}, containerSpec, garden.ProcessSpec{
+				Path: "some-path",
+				Dir:  "/some/dir",
+				User: "1000:1001",
+				TTY:  &garden.TTYSpec{},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(process.Cwd).To(Equal("/some/dir"))
+			Expect(process.User.UID).To(Equal(uint32(1000)))
+			Expect(process.User.GID).To(Equal(uint32(1001)))
+			Expect(process.Terminal).To(BeTrue())
+		})
+	})
+
+	Describe("gardenMetrics", func() {
+		It("converts cgroup v1 metrics", func() {
+			metrics, err := gardenMetrics(&v1.Metrics{
+				Memory: &v1.MemoryStat{
+					TotalCache: 10,
+					Usage:      &cgroupsv1.MemoryEntry{Usage: 30},
+				},
+				CPU: &v1.CPUStat{
+					Usage: &v1.CPUUsage{Total: 100, User: 60, Kernel: 40},
+				},
+				Pids: &v1.PidsStat{Current: 2, Limit: 10},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(metrics.MemoryStat.TotalCache).To(Equal(uint64(10)))
+			Expect(metrics.MemoryStat.TotalUsageTowardLimit).To(Equal(uint64(20)))
+			Expect(metrics.CPUStat).To(Equal(garden.ContainerCPUStat{Usage: 100, User: 60, System: 40}))
+			Expect(metrics.PidStat).To(Equal(garden.ContainerPidStat{Current: 2, Max: 10}))
+		})
+
+		It("converts cgroup v2 metrics, reporting CPU time in nanoseconds", func() {
+			metrics, err := gardenMetrics(&v2.Metrics{
+				Memory: &v2.MemoryStat{File: 10, Usage: 30},
+				CPU:    &v2.CPUStat{UsageUsec: 100, UserUsec: 60, SystemUsec: 40},
+				Pids:   &v2.PidsStat{Current: 2, Limit: 10},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(metrics.MemoryStat.TotalCache).To(Equal(uint64(10)))
+			Expect(metrics.MemoryStat.TotalUsageTowardLimit).To(Equal(uint64(20)))
+			Expect(metrics.CPUStat).To(Equal(garden.ContainerCPUStat{Usage: 100000, User: 60000, System: 40000}))
+			Expect(metrics.PidStat).To(Equal(garden.ContainerPidStat{Current: 2, Max: 10}))
+		})
+
+		It("errors on unknown metrics", func() {
+			_, err := gardenMetrics("bogus")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
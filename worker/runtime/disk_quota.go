@@ -0,0 +1,8 @@
+package runtime
+
+//go:generate counterfeiter . DiskQuota
+
+// DiskQuota limits the bytes that can be written to a volume on the host.
+type DiskQuota interface {
+	Limit(path string, bytes uint64) error
+}
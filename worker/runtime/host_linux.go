@@ -0,0 +1,23 @@
+package runtime
+
+import (
+	"syscall"
+)
+
+// hostCapacity reports the host's memory and the size of the filesystem
+// holding the given directory.
+func hostCapacity(dir string) (uint64, uint64, error) {
+	var info syscall.Sysinfo_t
+	err := syscall.Sysinfo(&info)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var stat syscall.Statfs_t
+	err = syscall.Statfs(dir, &stat)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return uint64(info.Totalram) * uint64(info.Unit), stat.Blocks * uint64(stat.Bsize), nil
+}
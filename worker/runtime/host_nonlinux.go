@@ -0,0 +1,7 @@
+// +build !linux
+
+package runtime
+
+func hostCapacity(dir string) (uint64, uint64, error) {
+	return 0, 0, nil
+}
@@ -0,0 +1,716 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package runtimefakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/garden"
+	"github.com/concourse/concourse/worker/runtime"
+)
+
+type FakeClient struct {
+	AttachStub        func(string, string, garden.ProcessIO) (runtime.Process, error)
+	attachMutex       sync.RWMutex
+	attachArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 garden.ProcessIO
+	}
+	attachReturns struct {
+		result1 runtime.Process
+		result2 error
+	}
+	attachReturnsOnCall map[int]struct {
+		result1 runtime.Process
+		result2 error
+	}
+	ContainersStub        func() ([]string, error)
+	containersMutex       sync.RWMutex
+	containersArgsForCall []struct {
+	}
+	containersReturns struct {
+		result1 []string
+		result2 error
+	}
+	containersReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	CreateStub        func(string, runtime.Spec) error
+	createMutex       sync.RWMutex
+	createArgsForCall []struct {
+		arg1 string
+		arg2 runtime.Spec
+	}
+	createReturns struct {
+		result1 error
+	}
+	createReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DestroyStub        func(string) error
+	destroyMutex       sync.RWMutex
+	destroyArgsForCall []struct {
+		arg1 string
+	}
+	destroyReturns struct {
+		result1 error
+	}
+	destroyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ExecStub        func(string, garden.ProcessSpec, garden.ProcessIO) (runtime.Process, error)
+	execMutex       sync.RWMutex
+	execArgsForCall []struct {
+		arg1 string
+		arg2 garden.ProcessSpec
+		arg3 garden.ProcessIO
+	}
+	execReturns struct {
+		result1 runtime.Process
+		result2 error
+	}
+	execReturnsOnCall map[int]struct {
+		result1 runtime.Process
+		result2 error
+	}
+	MetricsStub        func(string) (garden.Metrics, error)
+	metricsMutex       sync.RWMutex
+	metricsArgsForCall []struct {
+		arg1 string
+	}
+	metricsReturns struct {
+		result1 garden.Metrics
+		result2 error
+	}
+	metricsReturnsOnCall map[int]struct {
+		result1 garden.Metrics
+		result2 error
+	}
+	PingStub        func() error
+	pingMutex       sync.RWMutex
+	pingArgsForCall []struct {
+	}
+	pingReturns struct {
+		result1 error
+	}
+	pingReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ProcessesStub        func(string) ([]string, error)
+	processesMutex       sync.RWMutex
+	processesArgsForCall []struct {
+		arg1 string
+	}
+	processesReturns struct {
+		result1 []string
+		result2 error
+	}
+	processesReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	SignalStub        func(string, string, garden.Signal) error
+	signalMutex       sync.RWMutex
+	signalArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 garden.Signal
+	}
+	signalReturns struct {
+		result1 error
+	}
+	signalReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeClient) Attach(arg1 string, arg2 string, arg3 garden.ProcessIO) (runtime.Process, error) {
+	fake.attachMutex.Lock()
+	ret, specificReturn := fake.attachReturnsOnCall[len(fake.attachArgsForCall)]
+	fake.attachArgsForCall = append(fake.attachArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 garden.ProcessIO
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Attach", []interface{}{arg1, arg2, arg3})
+	fake.attachMutex.Unlock()
+	if fake.AttachStub != nil {
+		return fake.AttachStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.attachReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) AttachCallCount() int {
+	fake.attachMutex.RLock()
+	defer fake.attachMutex.RUnlock()
+	return len(fake.attachArgsForCall)
+}
+
+func (fake *FakeClient) AttachCalls(stub func(string, string, garden.ProcessIO) (runtime.Process, error)) {
+	fake.attachMutex.Lock()
+	defer fake.attachMutex.Unlock()
+	fake.AttachStub = stub
+}
+
+func (fake *FakeClient) AttachArgsForCall(i int) (string, string, garden.ProcessIO) {
+	fake.attachMutex.RLock()
+	defer fake.attachMutex.RUnlock()
+	argsForCall := fake.attachArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClient) AttachReturns(result1 runtime.Process, result2 error) {
+	fake.attachMutex.Lock()
+	defer fake.attachMutex.Unlock()
+	fake.AttachStub = nil
+	fake.attachReturns = struct {
+		result1 runtime.Process
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) AttachReturnsOnCall(i int, result1 runtime.Process, result2 error) {
+	fake.attachMutex.Lock()
+	defer fake.attachMutex.Unlock()
+	fake.AttachStub = nil
+	if fake.attachReturnsOnCall == nil {
+		fake.attachReturnsOnCall = make(map[int]struct {
+			result1 runtime.Process
+			result2 error
+		})
+	}
+	fake.attachReturnsOnCall[i] = struct {
+		result1 runtime.Process
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) Containers() ([]string, error) {
+	fake.containersMutex.Lock()
+	ret, specificReturn := fake.containersReturnsOnCall[len(fake.containersArgsForCall)]
+	fake.containersArgsForCall = append(fake.containersArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Containers", []interface{}{})
+	fake.containersMutex.Unlock()
+	if fake.ContainersStub != nil {
+		return fake.ContainersStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.containersReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ContainersCallCount() int {
+	fake.containersMutex.RLock()
+	defer fake.containersMutex.RUnlock()
+	return len(fake.containersArgsForCall)
+}
+
+func (fake *FakeClient) ContainersCalls(stub func() ([]string, error)) {
+	fake.containersMutex.Lock()
+	defer fake.containersMutex.Unlock()
+	fake.ContainersStub = stub
+}
+
+func (fake *FakeClient) ContainersReturns(result1 []string, result2 error) {
+	fake.containersMutex.Lock()
+	defer fake.containersMutex.Unlock()
+	fake.ContainersStub = nil
+	fake.containersReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ContainersReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.containersMutex.Lock()
+	defer fake.containersMutex.Unlock()
+	fake.ContainersStub = nil
+	if fake.containersReturnsOnCall == nil {
+		fake.containersReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.containersReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) Create(arg1 string, arg2 runtime.Spec) error {
+	fake.createMutex.Lock()
+	ret, specificReturn := fake.createReturnsOnCall[len(fake.createArgsForCall)]
+	fake.createArgsForCall = append(fake.createArgsForCall, struct {
+		arg1 string
+		arg2 runtime.Spec
+	}{arg1, arg2})
+	fake.recordInvocation("Create", []interface{}{arg1, arg2})
+	fake.createMutex.Unlock()
+	if fake.CreateStub != nil {
+		return fake.CreateStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.createReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) CreateCallCount() int {
+	fake.createMutex.RLock()
+	defer fake.createMutex.RUnlock()
+	return len(fake.createArgsForCall)
+}
+
+func (fake *FakeClient) CreateCalls(stub func(string, runtime.Spec) error) {
+	fake.createMutex.Lock()
+	defer fake.createMutex.Unlock()
+	fake.CreateStub = stub
+}
+
+func (fake *FakeClient) CreateArgsForCall(i int) (string, runtime.Spec) {
+	fake.createMutex.RLock()
+	defer fake.createMutex.RUnlock()
+	argsForCall := fake.createArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) CreateReturns(result1 error) {
+	fake.createMutex.Lock()
+	defer fake.createMutex.Unlock()
+	fake.CreateStub = nil
+	fake.createReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) CreateReturnsOnCall(i int, result1 error) {
+	fake.createMutex.Lock()
+	defer fake.createMutex.Unlock()
+	fake.CreateStub = nil
+	if fake.createReturnsOnCall == nil {
+		fake.createReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) Destroy(arg1 string) error {
+	fake.destroyMutex.Lock()
+	ret, specificReturn := fake.destroyReturnsOnCall[len(fake.destroyArgsForCall)]
+	fake.destroyArgsForCall = append(fake.destroyArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("Destroy", []interface{}{arg1})
+	fake.destroyMutex.Unlock()
+	if fake.DestroyStub != nil {
+		return fake.DestroyStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.destroyReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) DestroyCallCount() int {
+	fake.destroyMutex.RLock()
+	defer fake.destroyMutex.RUnlock()
+	return len(fake.destroyArgsForCall)
+}
+
+func (fake *FakeClient) DestroyCalls(stub func(string) error) {
+	fake.destroyMutex.Lock()
+	defer fake.destroyMutex.Unlock()
+	fake.DestroyStub = stub
+}
+
+func (fake *FakeClient) DestroyArgsForCall(i int) string {
+	fake.destroyMutex.RLock()
+	defer fake.destroyMutex.RUnlock()
+	argsForCall := fake.destroyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) DestroyReturns(result1 error) {
+	fake.destroyMutex.Lock()
+	defer fake.destroyMutex.Unlock()
+	fake.DestroyStub = nil
+	fake.destroyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) DestroyReturnsOnCall(i int, result1 error) {
+	fake.destroyMutex.Lock()
+	defer fake.destroyMutex.Unlock()
+	fake.DestroyStub = nil
+	if fake.destroyReturnsOnCall == nil {
+		fake.destroyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.destroyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) Exec(arg1 string, arg2 garden.ProcessSpec, arg3 garden.ProcessIO) (runtime.Process, error) {
+	fake.execMutex.Lock()
+	ret, specificReturn := fake.execReturnsOnCall[len(fake.execArgsForCall)]
+	fake.execArgsForCall = append(fake.execArgsForCall, struct {
+		arg1 string
+		arg2 garden.ProcessSpec
+		arg3 garden.ProcessIO
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Exec", []interface{}{arg1, arg2, arg3})
+	fake.execMutex.Unlock()
+	if fake.ExecStub != nil {
+		return fake.ExecStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.execReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ExecCallCount() int {
+	fake.execMutex.RLock()
+	defer fake.execMutex.RUnlock()
+	return len(fake.execArgsForCall)
+}
+
+func (fake *FakeClient) ExecCalls(stub func(string, garden.ProcessSpec, garden.ProcessIO) (runtime.Process, error)) {
+	fake.execMutex.Lock()
+	defer fake.execMutex.Unlock()
+	fake.ExecStub = stub
+}
+
+func (fake *FakeClient) ExecArgsForCall(i int) (string, garden.ProcessSpec, garden.ProcessIO) {
+	fake.execMutex.RLock()
+	defer fake.execMutex.RUnlock()
+	argsForCall := fake.execArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClient) ExecReturns(result1 runtime.Process, result2 error) {
+	fake.execMutex.Lock()
+	defer fake.execMutex.Unlock()
+	fake.ExecStub = nil
+	fake.execReturns = struct {
+		result1 runtime.Process
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ExecReturnsOnCall(i int, result1 runtime.Process, result2 error) {
+	fake.execMutex.Lock()
+	defer fake.execMutex.Unlock()
+	fake.ExecStub = nil
+	if fake.execReturnsOnCall == nil {
+		fake.execReturnsOnCall = make(map[int]struct {
+			result1 runtime.Process
+			result2 error
+		})
+	}
+	fake.execReturnsOnCall[i] = struct {
+		result1 runtime.Process
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) Metrics(arg1 string) (garden.Metrics, error) {
+	fake.metricsMutex.Lock()
+	ret, specificReturn := fake.metricsReturnsOnCall[len(fake.metricsArgsForCall)]
+	fake.metricsArgsForCall = append(fake.metricsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("Metrics", []interface{}{arg1})
+	fake.metricsMutex.Unlock()
+	if fake.MetricsStub != nil {
+		return fake.MetricsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.metricsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) MetricsCallCount() int {
+	fake.metricsMutex.RLock()
+	defer fake.metricsMutex.RUnlock()
+	return len(fake.metricsArgsForCall)
+}
+
+func (fake *FakeClient) MetricsCalls(stub func(string) (garden.Metrics, error)) {
+	fake.metricsMutex.Lock()
+	defer fake.metricsMutex.Unlock()
+	fake.MetricsStub = stub
+}
+
+func (fake *FakeClient) MetricsArgsForCall(i int) string {
+	fake.metricsMutex.RLock()
+	defer fake.metricsMutex.RUnlock()
+	argsForCall := fake.metricsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) MetricsReturns(result1 garden.Metrics, result2 error) {
+	fake.metricsMutex.Lock()
+	defer fake.metricsMutex.Unlock()
+	fake.MetricsStub = nil
+	fake.metricsReturns = struct {
+		result1 garden.Metrics
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) MetricsReturnsOnCall(i int, result1 garden.Metrics, result2 error) {
+	fake.metricsMutex.Lock()
+	defer fake.metricsMutex.Unlock()
+	fake.MetricsStub = nil
+	if fake.metricsReturnsOnCall == nil {
+		fake.metricsReturnsOnCall = make(map[int]struct {
+			result1 garden.Metrics
+			result2 error
+		})
+	}
+	fake.metricsReturnsOnCall[i] = struct {
+		result1 garden.Metrics
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) Ping() error {
+	fake.pingMutex.Lock()
+	ret, specificReturn := fake.pingReturnsOnCall[len(fake.pingArgsForCall)]
+	fake.pingArgsForCall = append(fake.pingArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Ping", []interface{}{})
+	fake.pingMutex.Unlock()
+	if fake.PingStub != nil {
+		return fake.PingStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.pingReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) PingCallCount() int {
+	fake.pingMutex.RLock()
+	defer fake.pingMutex.RUnlock()
+	return len(fake.pingArgsForCall)
+}
+
+func (fake *FakeClient) PingCalls(stub func() error) {
+	fake.pingMutex.Lock()
+	defer fake.pingMutex.Unlock()
+	fake.PingStub = stub
+}
+
+func (fake *FakeClient) PingReturns(result1 error) {
+	fake.pingMutex.Lock()
+	defer fake.pingMutex.Unlock()
+	fake.PingStub = nil
+	fake.pingReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) PingReturnsOnCall(i int, result1 error) {
+	fake.pingMutex.Lock()
+	defer fake.pingMutex.Unlock()
+	fake.PingStub = nil
+	if fake.pingReturnsOnCall == nil {
+		fake.pingReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.pingReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) Processes(arg1 string) ([]string, error) {
+	fake.processesMutex.Lock()
+	ret, specificReturn := fake.processesReturnsOnCall[len(fake.processesArgsForCall)]
+	fake.processesArgsForCall = append(fake.processesArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("Processes", []interface{}{arg1})
+	fake.processesMutex.Unlock()
+	if fake.ProcessesStub != nil {
+		return fake.ProcessesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.processesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ProcessesCallCount() int {
+	fake.processesMutex.RLock()
+	defer fake.processesMutex.RUnlock()
+	return len(fake.processesArgsForCall)
+}
+
+func (fake *FakeClient) ProcessesCalls(stub func(string) ([]string, error)) {
+	fake.processesMutex.Lock()
+	defer fake.processesMutex.Unlock()
+	fake.ProcessesStub = stub
+}
+
+func (fake *FakeClient) ProcessesArgsForCall(i int) string {
+	fake.processesMutex.RLock()
+	defer fake.processesMutex.RUnlock()
+	argsForCall := fake.processesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) ProcessesReturns(result1 []string, result2 error) {
+	fake.processesMutex.Lock()
+	defer fake.processesMutex.Unlock()
+	fake.ProcessesStub = nil
+	fake.processesReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ProcessesReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.processesMutex.Lock()
+	defer fake.processesMutex.Unlock()
+	fake.ProcessesStub = nil
+	if fake.processesReturnsOnCall == nil {
+		fake.processesReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.processesReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) Signal(arg1 string, arg2 string, arg3 garden.Signal) error {
+	fake.signalMutex.Lock()
+	ret, specificReturn := fake.signalReturnsOnCall[len(fake.signalArgsForCall)]
+	fake.signalArgsForCall = append(fake.signalArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 garden.Signal
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Signal", []interface{}{arg1, arg2, arg3})
+	fake.signalMutex.Unlock()
+	if fake.SignalStub != nil {
+		return fake.SignalStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.signalReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) SignalCallCount() int {
+	fake.signalMutex.RLock()
+	defer fake.signalMutex.RUnlock()
+	return len(fake.signalArgsForCall)
+}
+
+func (fake *FakeClient) SignalCalls(stub func(string, string, garden.Signal) error) {
+	fake.signalMutex.Lock()
+	defer fake.signalMutex.Unlock()
+	fake.SignalStub = stub
+}
+
+func (fake *FakeClient) SignalArgsForCall(i int) (string, string, garden.Signal) {
+	fake.signalMutex.RLock()
+	defer fake.signalMutex.RUnlock()
+	argsForCall := fake.signalArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClient) SignalReturns(result1 error) {
+	fake.signalMutex.Lock()
+	defer fake.signalMutex.Unlock()
+	fake.SignalStub = nil
+	fake.signalReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) SignalReturnsOnCall(i int, result1 error) {
+	fake.signalMutex.Lock()
+	defer fake.signalMutex.Unlock()
+	fake.SignalStub = nil
+	if fake.signalReturnsOnCall == nil {
+		fake.signalReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.signalReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.attachMutex.RLock()
+	defer fake.attachMutex.RUnlock()
+	fake.containersMutex.RLock()
+	defer fake.containersMutex.RUnlock()
+	fake.createMutex.RLock()
+	defer fake.createMutex.RUnlock()
+	fake.destroyMutex.RLock()
+	defer fake.destroyMutex.RUnlock()
+	fake.execMutex.RLock()
+	defer fake.execMutex.RUnlock()
+	fake.metricsMutex.RLock()
+	defer fake.metricsMutex.RUnlock()
+	fake.pingMutex.RLock()
+	defer fake.pingMutex.RUnlock()
+	fake.processesMutex.RLock()
+	defer fake.processesMutex.RUnlock()
+	fake.signalMutex.RLock()
+	defer fake.signalMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ runtime.Client = new(FakeClient)
@@ -0,0 +1,112 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package runtimefakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/worker/runtime"
+)
+
+type FakeDiskQuota struct {
+	LimitStub        func(string, uint64) error
+	limitMutex       sync.RWMutex
+	limitArgsForCall []struct {
+		arg1 string
+		arg2 uint64
+	}
+	limitReturns struct {
+		result1 error
+	}
+	limitReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeDiskQuota) Limit(arg1 string, arg2 uint64) error {
+	fake.limitMutex.Lock()
+	ret, specificReturn := fake.limitReturnsOnCall[len(fake.limitArgsForCall)]
+	fake.limitArgsForCall = append(fake.limitArgsForCall, struct {
+		arg1 string
+		arg2 uint64
+	}{arg1, arg2})
+	fake.recordInvocation("Limit", []interface{}{arg1, arg2})
+	fake.limitMutex.Unlock()
+	if fake.LimitStub != nil {
+		return fake.LimitStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.limitReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeDiskQuota) LimitCallCount() int {
+	fake.limitMutex.RLock()
+	defer fake.limitMutex.RUnlock()
+	return len(fake.limitArgsForCall)
+}
+
+func (fake *FakeDiskQuota) LimitCalls(stub func(string, uint64) error) {
+	fake.limitMutex.Lock()
+	defer fake.limitMutex.Unlock()
+	fake.LimitStub = stub
+}
+
+func (fake *FakeDiskQuota) LimitArgsForCall(i int) (string, uint64) {
+	fake.limitMutex.RLock()
+	defer fake.limitMutex.RUnlock()
+	argsForCall := fake.limitArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDiskQuota) LimitReturns(result1 error) {
+	fake.limitMutex.Lock()
+	defer fake.limitMutex.Unlock()
+	fake.LimitStub = nil
+	fake.limitReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDiskQuota) LimitReturnsOnCall(i int, result1 error) {
+	fake.limitMutex.Lock()
+	defer fake.limitMutex.Unlock()
+	fake.LimitStub = nil
+	if fake.limitReturnsOnCall == nil {
+		fake.limitReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.limitReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDiskQuota) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.limitMutex.RLock()
+	defer fake.limitMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeDiskQuota) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ runtime.DiskQuota = new(FakeDiskQuota)
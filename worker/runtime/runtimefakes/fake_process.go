@@ -0,0 +1,346 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package runtimefakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/garden"
+	"github.com/concourse/concourse/worker/runtime"
+)
+
+type FakeProcess struct {
+	DetachStub        func()
+	detachMutex       sync.RWMutex
+	detachArgsForCall []struct {
+	}
+	IDStub        func() string
+	iDMutex       sync.RWMutex
+	iDArgsForCall []struct {
+	}
+	iDReturns struct {
+		result1 string
+	}
+	iDReturnsOnCall map[int]struct {
+		result1 string
+	}
+	SetTTYStub        func(garden.TTYSpec) error
+	setTTYMutex       sync.RWMutex
+	setTTYArgsForCall []struct {
+		arg1 garden.TTYSpec
+	}
+	setTTYReturns struct {
+		result1 error
+	}
+	setTTYReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SignalStub        func(garden.Signal) error
+	signalMutex       sync.RWMutex
+	signalArgsForCall []struct {
+		arg1 garden.Signal
+	}
+	signalReturns struct {
+		result1 error
+	}
+	signalReturnsOnCall map[int]struct {
+		result1 error
+	}
+	WaitStub        func() (int, error)
+	waitMutex       sync.RWMutex
+	waitArgsForCall []struct {
+	}
+	waitReturns struct {
+		result1 int
+		result2 error
+	}
+	waitReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeProcess) Detach() {
+	fake.detachMutex.Lock()
+	fake.detachArgsForCall = append(fake.detachArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Detach", []interface{}{})
+	fake.detachMutex.Unlock()
+	if fake.DetachStub != nil {
+		fake.DetachStub()
+	}
+}
+
+func (fake *FakeProcess) DetachCallCount() int {
+	fake.detachMutex.RLock()
+	defer fake.detachMutex.RUnlock()
+	return len(fake.detachArgsForCall)
+}
+
+func (fake *FakeProcess) DetachCalls(stub func()) {
+	fake.detachMutex.Lock()
+	defer fake.detachMutex.Unlock()
+	fake.DetachStub = stub
+}
+
+func (fake *FakeProcess) ID() string {
+	fake.iDMutex.Lock()
+	ret, specificReturn := fake.iDReturnsOnCall[len(fake.iDArgsForCall)]
+	fake.iDArgsForCall = append(fake.iDArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ID", []interface{}{})
+	fake.iDMutex.Unlock()
+	if fake.IDStub != nil {
+		return fake.IDStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.iDReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeProcess) IDCallCount() int {
+	fake.iDMutex.RLock()
+	defer fake.iDMutex.RUnlock()
+	return len(fake.iDArgsForCall)
+}
+
+func (fake *FakeProcess) IDCalls(stub func() string) {
+	fake.iDMutex.Lock()
+	defer fake.iDMutex.Unlock()
+	fake.IDStub = stub
+}
+
+func (fake *FakeProcess) IDReturns(result1 string) {
+	fake.iDMutex.Lock()
+	defer fake.iDMutex.Unlock()
+	fake.IDStub = nil
+	fake.iDReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeProcess) IDReturnsOnCall(i int, result1 string) {
+	fake.iDMutex.Lock()
+	defer fake.iDMutex.Unlock()
+	fake.IDStub = nil
+	if fake.iDReturnsOnCall == nil {
+		fake.iDReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.iDReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeProcess) SetTTY(arg1 garden.TTYSpec) error {
+	fake.setTTYMutex.Lock()
+	ret, specificReturn := fake.setTTYReturnsOnCall[len(fake.setTTYArgsForCall)]
+	fake.setTTYArgsForCall = append(fake.setTTYArgsForCall, struct {
+		arg1 garden.TTYSpec
+	}{arg1})
+	fake.recordInvocation("SetTTY", []interface{}{arg1})
+	fake.setTTYMutex.Unlock()
+	if fake.SetTTYStub != nil {
+		return fake.SetTTYStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.setTTYReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeProcess) SetTTYCallCount() int {
+	fake.setTTYMutex.RLock()
+	defer fake.setTTYMutex.RUnlock()
+	return len(fake.setTTYArgsForCall)
+}
+
+func (fake *FakeProcess) SetTTYCalls(stub func(garden.TTYSpec) error) {
+	fake.setTTYMutex.Lock()
+	defer fake.setTTYMutex.Unlock()
+	fake.SetTTYStub = stub
+}
+
+func (fake *FakeProcess) SetTTYArgsForCall(i int) garden.TTYSpec {
+	fake.setTTYMutex.RLock()
+	defer fake.setTTYMutex.RUnlock()
+	argsForCall := fake.setTTYArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeProcess) SetTTYReturns(result1 error) {
+	fake.setTTYMutex.Lock()
+	defer fake.setTTYMutex.Unlock()
+	fake.SetTTYStub = nil
+	fake.setTTYReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeProcess) SetTTYReturnsOnCall(i int, result1 error) {
+	fake.setTTYMutex.Lock()
+	defer fake.setTTYMutex.Unlock()
+	fake.SetTTYStub = nil
+	if fake.setTTYReturnsOnCall == nil {
+		fake.setTTYReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setTTYReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeProcess) Signal(arg1 garden.Signal) error {
+	fake.signalMutex.Lock()
+	ret, specificReturn := fake.signalReturnsOnCall[len(fake.signalArgsForCall)]
+	fake.signalArgsForCall = append(fake.signalArgsForCall, struct {
+		arg1 garden.Signal
+	}{arg1})
+	fake.recordInvocation("Signal", []interface{}{arg1})
+	fake.signalMutex.Unlock()
+	if fake.SignalStub != nil {
+		return fake.SignalStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.signalReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeProcess) SignalCallCount() int {
+	fake.signalMutex.RLock()
+	defer fake.signalMutex.RUnlock()
+	return len(fake.signalArgsForCall)
+}
+
+func (fake *FakeProcess) SignalCalls(stub func(garden.Signal) error) {
+	fake.signalMutex.Lock()
+	defer fake.signalMutex.Unlock()
+	fake.SignalStub = stub
+}
+
+func (fake *FakeProcess) SignalArgsForCall(i int) garden.Signal {
+	fake.signalMutex.RLock()
+	defer fake.signalMutex.RUnlock()
+	argsForCall := fake.signalArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeProcess) SignalReturns(result1 error) {
+	fake.signalMutex.Lock()
+	defer fake.signalMutex.Unlock()
+	fake.SignalStub = nil
+	fake.signalReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeProcess) SignalReturnsOnCall(i int, result1 error) {
+	fake.signalMutex.Lock()
+	defer fake.signalMutex.Unlock()
+	fake.SignalStub = nil
+	if fake.signalReturnsOnCall == nil {
+		fake.signalReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.signalReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeProcess) Wait() (int, error) {
+	fake.waitMutex.Lock()
+	ret, specificReturn := fake.waitReturnsOnCall[len(fake.waitArgsForCall)]
+	fake.waitArgsForCall = append(fake.waitArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Wait", []interface{}{})
+	fake.waitMutex.Unlock()
+	if fake.WaitStub != nil {
+		return fake.WaitStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.waitReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeProcess) WaitCallCount() int {
+	fake.waitMutex.RLock()
+	defer fake.waitMutex.RUnlock()
+	return len(fake.waitArgsForCall)
+}
+
+func (fake *FakeProcess) WaitCalls(stub func() (int, error)) {
+	fake.waitMutex.Lock()
+	defer fake.waitMutex.Unlock()
+	fake.WaitStub = stub
+}
+
+func (fake *FakeProcess) WaitReturns(result1 int, result2 error) {
+	fake.waitMutex.Lock()
+	defer fake.waitMutex.Unlock()
+	fake.WaitStub = nil
+	fake.waitReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeProcess) WaitReturnsOnCall(i int, result1 int, result2 error) {
+	fake.waitMutex.Lock()
+	defer fake.waitMutex.Unlock()
+	fake.WaitStub = nil
+	if fake.waitReturnsOnCall == nil {
+		fake.waitReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.waitReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeProcess) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.detachMutex.RLock()
+	defer fake.detachMutex.RUnlock()
+	fake.iDMutex.RLock()
+	defer fake.iDMutex.RUnlock()
+	fake.setTTYMutex.RLock()
+	defer fake.setTTYMutex.RUnlock()
+	fake.signalMutex.RLock()
+	defer fake.signalMutex.RUnlock()
+	fake.waitMutex.RLock()
+	defer fake.waitMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeProcess) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ runtime.Process = new(FakeProcess)
@@ -1,6 +1,12 @@
 package worker
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/tsa"
 	"github.com/concourse/flag"
@@ -9,14 +15,68 @@ import (
 type TSAConfig struct {
 	Hosts            []string            `long:"host" default:"127.0.0.1:2222" description:"TSA host to forward the worker through. Can be specified multiple times."`
 	PublicKey        flag.AuthorizedKeys `long:"public-key" description:"File containing a public key to expect from the TSA."`
-	WorkerPrivateKey *flag.PrivateKey    `long:"worker-private-key" required:"true" description:"File containing the private key to use when authenticating to the TSA."`
+	WorkerPrivateKey *flag.PrivateKey    `long:"worker-private-key" description:"File containing the private key to use when authenticating to the TSA."`
+
+	TLSHosts  []string  `long:"tls-host" description:"TSA host to register the worker with over HTTPS instead of SSH, authenticating with a client certificate. Can be specified multiple times."`
+	TLSCert   flag.File `long:"tls-cert" description:"File containing the client certificate to use when registering over HTTPS."`
+	TLSKey    flag.File `long:"tls-key" description:"File containing the private key for the client certificate."`
+	TLSCACert flag.File `long:"tls-ca-cert" description:"File containing CA certificates to verify the TSA with when registering over HTTPS. Defaults to the system's CAs."`
 }
 
-func (config TSAConfig) Client(worker atc.Worker) *tsa.Client {
+// Client returns a client for registering over HTTPS if any TLS hosts are
+// configured, falling back to the SSH gateway otherwise.
+func (config TSAConfig) Client(worker atc.Worker) (TSAClient, error) {
+	if len(config.TLSHosts) != 0 {
+		tlsConfig, err := config.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+
+		return &tsa.HTTPSClient{
+			Hosts:     config.TLSHosts,
+			TLSConfig: tlsConfig,
+			Worker:    worker,
+		}, nil
+	}
+
+	if config.WorkerPrivateKey == nil {
+		return nil, errors.New("either a worker private key or a TLS host must be configured")
+	}
+
 	return &tsa.Client{
 		Hosts:      config.Hosts,
 		HostKeys:   config.PublicKey.Keys,
 		PrivateKey: config.WorkerPrivateKey.PrivateKey,
 		Worker:     worker,
+	}, nil
+}
+
+func (config TSAConfig) tlsConfig() (*tls.Config, error) {
+	if config.TLSCert == "" || config.TLSKey == "" {
+		return nil, errors.New("a client certificate and key must be configured to register over HTTPS")
 	}
+
+	cert, err := tls.LoadX509KeyPair(string(config.TLSCert), string(config.TLSKey))
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if config.TLSCACert != "" {
+		pem, err := ioutil.ReadFile(string(config.TLSCACert))
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", config.TLSCACert)
+		}
+	}
+
+	return tlsConfig, nil
 }